@@ -6,27 +6,74 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/extreme-business/lingo/apps/account/auth/authentication"
 	"github.com/extreme-business/lingo/apps/account/auth/registration"
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
 	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrPermissionDenied is returned when the caller is not allowed to perform the operation.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrInvalidRegistrationToken is returned when a registration token is invalid or was already used.
+	ErrInvalidRegistrationToken = errors.New("invalid registration token")
+)
+
 // App is the application service for the account domain.
 type App struct {
-	logger              *slog.Logger
-	userReader          *user.Reader
-	authenticator       *authentication.Authenticator
-	registrationManager *registration.Manager
+	logger                *slog.Logger
+	userReader            *user.Reader
+	userUpdater           *user.Updater
+	sessionReader         *session.Reader
+	sessionWriter         *session.Writer
+	auditReader           *audit.Reader
+	auditRecorder         *audit.Recorder
+	webhookReader         *webhook.Reader
+	webhookWriter         *webhook.Writer
+	webhookDeliveryWriter *webhook.DeliveryWriter
+	conversationReader    *conversation.Reader
+	conversationWriter    *conversation.Writer
+	chatHub               *conversation.Hub
+	chatHeartbeat         time.Duration
+	translations          *translation.Service
+	glossaryReader        *glossary.Reader
+	glossaryWriter        *glossary.Writer
+	eventFeed             *outbox.Feed
+	authenticator         *authentication.Authenticator
+	registrationManager   *registration.Manager
 }
 
 type Config struct {
-	Logger              *slog.Logger
-	UserReader          *user.Reader
-	Authenticator       *authentication.Authenticator
-	RegistrationManager *registration.Manager
+	Logger                *slog.Logger
+	UserReader            *user.Reader
+	UserUpdater           *user.Updater
+	SessionReader         *session.Reader
+	SessionWriter         *session.Writer
+	AuditReader           *audit.Reader
+	AuditRecorder         *audit.Recorder
+	WebhookReader         *webhook.Reader
+	WebhookWriter         *webhook.Writer
+	WebhookDeliveryWriter *webhook.DeliveryWriter
+	ConversationReader    *conversation.Reader
+	ConversationWriter    *conversation.Writer
+	ChatHub               *conversation.Hub
+	ChatHeartbeat         time.Duration // ChatHeartbeat is the heartbeat interval of chat streams, defaults to 30 seconds.
+	Translations          *translation.Service
+	GlossaryReader        *glossary.Reader
+	GlossaryWriter        *glossary.Writer
+	EventFeed             *outbox.Feed
+	Authenticator         *authentication.Authenticator
+	RegistrationManager   *registration.Manager
 }
 
 // Validate validates the configuration.
@@ -37,6 +84,54 @@ func (c Config) Validate() error {
 	if c.UserReader == nil {
 		return errors.New("user reader is nil")
 	}
+	if c.UserUpdater == nil {
+		return errors.New("user updater is nil")
+	}
+	if c.SessionReader == nil {
+		return errors.New("session reader is nil")
+	}
+	if c.SessionWriter == nil {
+		return errors.New("session writer is nil")
+	}
+	if c.AuditReader == nil {
+		return errors.New("audit reader is nil")
+	}
+	if c.AuditRecorder == nil {
+		return errors.New("audit recorder is nil")
+	}
+	if c.WebhookReader == nil {
+		return errors.New("webhook reader is nil")
+	}
+	if c.WebhookWriter == nil {
+		return errors.New("webhook writer is nil")
+	}
+	if c.WebhookDeliveryWriter == nil {
+		return errors.New("webhook delivery writer is nil")
+	}
+	if c.ConversationReader == nil {
+		return errors.New("conversation reader is nil")
+	}
+	if c.ConversationWriter == nil {
+		return errors.New("conversation writer is nil")
+	}
+	if c.ChatHub == nil {
+		return errors.New("chat hub is nil")
+	}
+	if c.ChatHeartbeat < 0 {
+		return errors.New("chat heartbeat is negative")
+	}
+	if c.Translations == nil {
+		return errors.New("translations is nil")
+	}
+	if c.GlossaryReader == nil {
+		return errors.New("glossary reader is nil")
+	}
+	if c.GlossaryWriter == nil {
+		return errors.New("glossary writer is nil")
+	}
+	if c.EventFeed == nil {
+		return errors.New("event feed is nil")
+	}
 	if c.Authenticator == nil {
 		return errors.New("authenticator is nil")
 	}
@@ -50,31 +145,76 @@ func New(c Config) (*App, error) {
 	if err := c.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	if c.ChatHeartbeat == 0 {
+		c.ChatHeartbeat = defaultChatHeartbeat
+	}
 	return &App{
-		logger:              c.Logger,
-		userReader:          c.UserReader,
-		authenticator:       c.Authenticator,
-		registrationManager: c.RegistrationManager,
+		logger:                c.Logger,
+		userReader:            c.UserReader,
+		userUpdater:           c.UserUpdater,
+		sessionReader:         c.SessionReader,
+		sessionWriter:         c.SessionWriter,
+		auditReader:           c.AuditReader,
+		auditRecorder:         c.AuditRecorder,
+		webhookReader:         c.WebhookReader,
+		webhookWriter:         c.WebhookWriter,
+		webhookDeliveryWriter: c.WebhookDeliveryWriter,
+		conversationReader:    c.ConversationReader,
+		conversationWriter:    c.ConversationWriter,
+		chatHub:               c.ChatHub,
+		chatHeartbeat:         c.ChatHeartbeat,
+		translations:          c.Translations,
+		glossaryReader:        c.GlossaryReader,
+		glossaryWriter:        c.GlossaryWriter,
+		eventFeed:             c.EventFeed,
+		authenticator:         c.Authenticator,
+		registrationManager:   c.RegistrationManager,
 	}, nil
 }
 
 type RegisterUser struct {
-	OrganizationID uuid.UUID
-	DisplayName    string
-	Email          string
-	Password       string
+	OrganizationID    uuid.UUID
+	DisplayName       string
+	Email             string
+	Password          string
+	PreferredLanguage string
+	// RegistrationToken is the registration token of the relay for the email address. Without it only
+	// an admin of the organization can register the user.
+	RegistrationToken string
 }
 
+// RegisterUser registers a user with a registration token, or on behalf of a signed in admin.
 func (r *App) RegisterUser(ctx context.Context, i RegisterUser) (*domain.User, error) {
+	p, _ := authentication.FromContext(ctx)
+	if i.RegistrationToken == "" {
+		if err := authorizeOrganizationAdmin(p, i.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
 	user, err := r.registrationManager.Register(ctx, registration.Registration{
-		OrganizationID: i.OrganizationID,
-		DisplayName:    i.DisplayName,
-		Email:          i.Email,
-		Password:       i.Password,
+		OrganizationID:    i.OrganizationID,
+		DisplayName:       i.DisplayName,
+		Email:             i.Email,
+		Password:          i.Password,
+		PreferredLanguage: i.PreferredLanguage,
+		Token:             i.RegistrationToken,
 	})
 	if err != nil {
+		if errors.Is(err, registration.ErrInvalidToken) || errors.Is(err, registration.ErrTokenUsed) {
+			return nil, ErrInvalidRegistrationToken
+		}
 		return nil, fmt.Errorf("failed to register user: %w", err)
 	}
+
+	// there only is an actor when a signed in admin registers someone else.
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: user.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionUserRegistered,
+		Resource:       domain.UserName(user.OrganizationID, user.ID),
+	})
+
 	return user, nil
 }
 
@@ -10,16 +10,39 @@ import (
 	"github.com/extreme-business/lingo/apps/account/auth/authentication"
 	"github.com/extreme-business/lingo/apps/account/auth/registration"
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
 	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+	"github.com/google/uuid"
 )
 
 func TestNew(t *testing.T) {
 	t.Run("New", func(t *testing.T) {
 		c := Config{
-			Logger:              slog.Default(),
-			UserReader:          user.NewReader(nil),
-			Authenticator:       authentication.New(authentication.Config{}),
-			RegistrationManager: registration.NewManager(registration.Config{}),
+			Logger:                slog.Default(),
+			UserReader:            user.NewReader(nil),
+			SessionReader:         session.NewReader(nil, nil),
+			SessionWriter:         session.NewWriter(nil, nil),
+			AuditReader:           audit.NewReader(nil),
+			AuditRecorder:         audit.NewRecorder(nil, nil),
+			WebhookReader:         webhook.NewReader(nil, nil),
+			WebhookWriter:         webhook.NewWriter(nil, nil, nil),
+			WebhookDeliveryWriter: webhook.NewDeliveryWriter(nil, nil, nil),
+			ConversationReader:    conversation.NewReader(nil, nil, nil),
+			ConversationWriter:    conversation.NewWriter(nil, nil, nil),
+			ChatHub:               conversation.NewHub(),
+			EventFeed:             &outbox.Feed{},
+			UserUpdater:           user.NewUpdater(nil, nil),
+			Translations:          &translation.Service{},
+			GlossaryReader:        glossary.NewReader(nil, nil, nil),
+			GlossaryWriter:        glossary.NewWriter(nil, nil, nil),
+			Authenticator:         authentication.New(authentication.Config{}),
+			RegistrationManager:   registration.NewManager(registration.Config{}),
 		}
 
 		got, err := New(c)
@@ -53,7 +76,19 @@ func TestApp_RegisterUser(t *testing.T) {
 		want    *domain.User
 		wantErr bool
 	}{
-		// TODO: Add test cases.
+		{
+			name: "should not register a user without a registration token or an admin",
+			args: args{
+				ctx: context.Background(),
+				i: RegisterUser{
+					OrganizationID: uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
+					DisplayName:    "username",
+					Email:          "jane@example.com",
+					Password:       "password!1",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+)
+
+// ListAuditEvents lists the audit events of an organization, newest first.
+// Only admins of the organization and the system user may read the audit log.
+func (r *App) ListAuditEvents(ctx context.Context, p *authentication.Principal, q audit.ListQuery) ([]*domain.AuditEvent, int64, error) {
+	if p == nil {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	switch {
+	case p.Role == domain.UserRoleSystem,
+		p.Role == domain.UserRoleAdmin && p.OrganizationID == q.OrganizationID:
+	default:
+		return nil, 0, ErrPermissionDenied
+	}
+
+	events, next, err := r.auditReader.List(ctx, q)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, next, nil
+}
+
+// record appends an event to the audit log.
+// A failure to record is logged but does not fail the operation that caused the event.
+func (r *App) record(ctx context.Context, e *domain.AuditEvent) {
+	if err := r.auditRecorder.Record(ctx, e); err != nil {
+		r.logger.Error("failed to record audit event",
+			slog.String("action", e.Action.String()),
+			slog.String("resource", e.Resource),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// actorName returns the resource name of who makes the change.
+// For an impersonated principal that is the impersonator, not the impersonated user.
+func actorName(p *authentication.Principal) string {
+	if p == nil {
+		return ""
+	}
+	if p.Impersonated() {
+		return p.Actor
+	}
+	return domain.UserName(p.OrganizationID, p.UserID)
+}
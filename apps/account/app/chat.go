@@ -0,0 +1,289 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrChatLagged is returned when a chat stream does not keep up with the events of its conversations.
+	// It can reconnect and resume after the last message it received.
+	ErrChatLagged = errors.New("chat stream fell behind")
+	// ErrChatStopped is returned when the server stops. The stream can reconnect to another server.
+	ErrChatStopped = errors.New("chat stopped")
+	// ErrChatIdle is returned when the client sent nothing for two heartbeat intervals.
+	ErrChatIdle = errors.New("chat stream is idle")
+)
+
+const (
+	defaultChatHeartbeat = 30 * time.Second
+	// chatBufferSize is the number of events a chat stream can fall behind before it is stopped.
+	chatBufferSize = 256
+	// chatReplayLimit is the maximum number of message changes replayed per conversation when a stream resumes,
+	// older messages can be listed instead.
+	chatReplayLimit = 500
+	// chatIdleHeartbeats is the number of heartbeats without a request after which a stream is considered dead.
+	chatIdleHeartbeats = 2
+)
+
+// ChatRequestType is the type of a ChatRequest.
+type ChatRequestType string
+
+const (
+	ChatSubscribe   ChatRequestType = "subscribe"
+	ChatUnsubscribe ChatRequestType = "unsubscribe"
+	ChatTyping      ChatRequestType = "typing"
+	ChatMarkRead    ChatRequestType = "mark_read"
+	ChatPing        ChatRequestType = "ping"
+)
+
+// ChatRequest is a request of a chat client.
+type ChatRequest struct {
+	Type            ChatRequestType
+	OrganizationID  uuid.UUID
+	ConversationIDs []uuid.UUID // ConversationIDs are the conversations to subscribe to or unsubscribe from.
+	ConversationID  uuid.UUID   // ConversationID is the conversation the user types in or has read.
+	// MessageID is the message that was read. For a subscription it is the last message seen
+	// before reconnecting, the messages created, edited or deleted after it are replayed.
+	MessageID uuid.UUID
+}
+
+// ChatEventType is the type of a ChatEvent.
+type ChatEventType string
+
+const (
+	ChatEventSubscribed   ChatEventType = "subscribed"
+	ChatEventConversation ChatEventType = "conversation"
+	ChatEventHeartbeat    ChatEventType = "heartbeat"
+)
+
+// ChatEvent is sent to a chat client.
+type ChatEvent struct {
+	Type            ChatEventType
+	ConversationIDs []uuid.UUID        // ConversationIDs are the conversations that were subscribed to.
+	Event           conversation.Event // Event is the event of a conversation.
+	Time            time.Time          // Time is the time of a heartbeat.
+}
+
+// chat is the state of a single chat stream.
+type chat struct {
+	app           *App
+	p             *authentication.Principal
+	subscription  *conversation.Subscription
+	send          func(ChatEvent) error
+	authorized    map[uuid.UUID]struct{} // conversations the user participates in.
+	subscriptions map[uuid.UUID]struct{}
+	replayed      map[uuid.UUID]map[uuid.UUID]time.Time // the update time of the replayed messages per conversation.
+}
+
+// Chat handles the requests of a chat client and sends it the events of the conversations it subscribes to,
+// until the requests are closed or the context is canceled. A heartbeat is sent at a fixed interval,
+// a client that sends nothing for two intervals is disconnected.
+func (r *App) Chat(ctx context.Context, p *authentication.Principal, requests <-chan ChatRequest, send func(ChatEvent) error) error {
+	if p == nil {
+		return ErrPermissionDenied
+	}
+
+	// subscribe before any replay, so no event is missed between replaying and following the hub.
+	subscription := r.chatHub.Subscribe(chatBufferSize)
+	defer subscription.Close()
+
+	c := &chat{
+		app:           r,
+		p:             p,
+		subscription:  subscription,
+		send:          send,
+		authorized:    make(map[uuid.UUID]struct{}),
+		subscriptions: make(map[uuid.UUID]struct{}),
+		replayed:      make(map[uuid.UUID]map[uuid.UUID]time.Time),
+	}
+
+	heartbeat := time.NewTicker(r.chatHeartbeat)
+	defer heartbeat.Stop()
+
+	var idle int // heartbeats since the last request.
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.chatHub.Done():
+			return ErrChatStopped
+		case <-subscription.Lagged():
+			return ErrChatLagged
+		case req, ok := <-requests:
+			if !ok {
+				return nil
+			}
+
+			idle = 0
+			if err := c.handle(ctx, req); err != nil {
+				return err
+			}
+		case e := <-subscription.Events():
+			if err := c.deliver(e); err != nil {
+				return err
+			}
+		case t := <-heartbeat.C:
+			if idle++; idle > chatIdleHeartbeats {
+				return ErrChatIdle
+			}
+
+			if err := send(ChatEvent{Type: ChatEventHeartbeat, Time: t}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle handles a request of the client.
+func (c *chat) handle(ctx context.Context, req ChatRequest) error {
+	switch req.Type {
+	case ChatSubscribe:
+		return c.subscribe(ctx, req)
+	case ChatUnsubscribe:
+		c.subscription.Remove(req.ConversationIDs...)
+		for _, id := range req.ConversationIDs {
+			delete(c.subscriptions, id)
+			delete(c.replayed, id)
+		}
+		return nil
+	case ChatTyping:
+		if err := c.authorize(ctx, req.OrganizationID, req.ConversationID); err != nil {
+			return err
+		}
+
+		c.app.chatHub.Publish(conversation.Event{
+			Type:           conversation.EventTyping,
+			ConversationID: req.ConversationID,
+			UserID:         c.p.UserID,
+		})
+		return nil
+	case ChatMarkRead:
+		if err := c.authorize(ctx, req.OrganizationID, req.ConversationID); err != nil {
+			return err
+		}
+
+		if _, err := c.app.getMessage(ctx, req.ConversationID, req.MessageID); err != nil {
+			return err
+		}
+
+		c.app.chatHub.Publish(conversation.Event{
+			Type:           conversation.EventRead,
+			ConversationID: req.ConversationID,
+			UserID:         c.p.UserID,
+			MessageID:      req.MessageID,
+		})
+		return nil
+	case ChatPing:
+		return nil
+	default:
+		return fmt.Errorf("unknown chat request type: %q", req.Type)
+	}
+}
+
+// subscribe follows the conversations and replays the changes to messages after the last seen message.
+// The changes are replayed in commit order from the commit that created the last seen message, a message
+// that was created later is replayed as created, an older one as updated or deleted.
+func (c *chat) subscribe(ctx context.Context, req ChatRequest) error {
+	for _, id := range req.ConversationIDs {
+		if err := c.authorize(ctx, req.OrganizationID, id); err != nil {
+			return err
+		}
+	}
+
+	c.subscription.Add(req.ConversationIDs...)
+	for _, id := range req.ConversationIDs {
+		c.subscriptions[id] = struct{}{}
+	}
+
+	if err := c.send(ChatEvent{Type: ChatEventSubscribed, ConversationIDs: req.ConversationIDs}); err != nil {
+		return err
+	}
+
+	if req.MessageID == uuid.Nil {
+		return nil
+	}
+
+	last, err := c.app.conversationReader.GetMessage(ctx, req.MessageID)
+	if err != nil {
+		if errors.Is(err, conversation.ErrMessageNotFound) {
+			return ErrMessageNotFound
+		}
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if _, ok := c.authorized[last.ConversationID]; !ok {
+		return ErrMessageNotFound
+	}
+
+	for _, id := range req.ConversationIDs {
+		messages, err := c.app.conversationReader.ListMessageChanges(ctx, id, last.CreatePosition, chatReplayLimit)
+		if err != nil {
+			return fmt.Errorf("failed to replay messages: %w", err)
+		}
+
+		replayed := make(map[uuid.UUID]time.Time, len(messages))
+		for _, m := range messages {
+			t := conversation.EventMessageUpdated
+			switch {
+			case m.CreatePosition > last.CreatePosition:
+				t = conversation.EventMessageCreated
+			case m.Deleted():
+				t = conversation.EventMessageDeleted
+			}
+
+			if err = c.send(ChatEvent{Type: ChatEventConversation, Event: conversation.Event{
+				Type:           t,
+				ConversationID: id,
+				UserID:         m.SenderID,
+				Message:        m,
+			}}); err != nil {
+				return err
+			}
+			replayed[m.ID] = m.UpdateTime
+		}
+		c.replayed[id] = replayed
+	}
+
+	return nil
+}
+
+// authorize checks once per stream whether the user participates in the conversation.
+func (c *chat) authorize(ctx context.Context, organizationID, conversationID uuid.UUID) error {
+	if _, ok := c.authorized[conversationID]; ok && organizationID == c.p.OrganizationID {
+		return nil
+	}
+
+	if _, err := c.app.getConversation(ctx, c.p, organizationID, conversationID); err != nil {
+		return err
+	}
+
+	c.authorized[conversationID] = struct{}{}
+	return nil
+}
+
+// deliver sends an event of the hub to the client, unless it is the typing indicator of the user itself
+// or a change to a message that was already replayed.
+func (c *chat) deliver(e conversation.Event) error {
+	if _, ok := c.subscriptions[e.ConversationID]; !ok {
+		return nil // the event was published before the conversation was unsubscribed.
+	}
+
+	if e.Type == conversation.EventTyping && e.UserID == c.p.UserID {
+		return nil
+	}
+
+	if e.Message != nil {
+		if t, ok := c.replayed[e.ConversationID][e.Message.ID]; ok && !e.Message.UpdateTime.After(t) {
+			return nil
+		}
+	}
+
+	return c.send(ChatEvent{Type: ChatEventConversation, Event: e})
+}
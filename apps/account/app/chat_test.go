@@ -0,0 +1,200 @@
+package app_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+// chatClient runs a chat stream for a user and collects the events it receives.
+type chatClient struct {
+	requests chan app.ChatRequest
+	events   chan app.ChatEvent
+	done     chan error
+}
+
+func connect(ctx context.Context, a *app.App, userID uuid.UUID) *chatClient {
+	c := &chatClient{
+		requests: make(chan app.ChatRequest),
+		events:   make(chan app.ChatEvent, 16),
+		done:     make(chan error, 1),
+	}
+
+	go func() {
+		c.done <- a.Chat(ctx, chatPrincipal(userID, domain.UserRoleUser), c.requests, func(e app.ChatEvent) error {
+			c.events <- e
+			return nil
+		})
+	}()
+
+	return c
+}
+
+// next returns the next event that is not a heartbeat.
+func (c *chatClient) next(t *testing.T) app.ChatEvent {
+	t.Helper()
+	for {
+		select {
+		case e := <-c.events:
+			if e.Type != app.ChatEventHeartbeat {
+				return e
+			}
+			c.requests <- app.ChatRequest{Type: app.ChatPing}
+		case err := <-c.done:
+			t.Fatalf("chat stopped: %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+		}
+	}
+}
+
+func (c *chatClient) subscribe(t *testing.T, resumeAfter uuid.UUID) {
+	t.Helper()
+	c.requests <- app.ChatRequest{
+		Type:            app.ChatSubscribe,
+		OrganizationID:  chatOrg,
+		ConversationIDs: []uuid.UUID{chatConvID},
+		MessageID:       resumeAfter,
+	}
+
+	if e := c.next(t); e.Type != app.ChatEventSubscribed {
+		t.Fatalf("expected %s, got %s", app.ChatEventSubscribed, e.Type)
+	}
+}
+
+func TestApp_Chat(t *testing.T) {
+	t.Run("should deliver new messages and typing indicators of others", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := newChatStore()
+		a := newChatApp(t, s)
+		bob := connect(ctx, a, chatBob)
+		bob.subscribe(t, uuid.Nil)
+
+		alice := connect(ctx, a, chatAlice)
+		alice.subscribe(t, uuid.Nil)
+		alice.requests <- app.ChatRequest{Type: app.ChatTyping, OrganizationID: chatOrg, ConversationID: chatConvID}
+
+		if e := bob.next(t); e.Event.Type != conversation.EventTyping || e.Event.UserID != chatAlice {
+			t.Errorf("expected alice to be typing, got %+v", e.Event)
+		}
+
+		if _, err := a.CreateMessage(ctx, chatPrincipal(chatAlice, domain.UserRoleUser), chatOrg, chatConvID, "hi bob"); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range []*chatClient{alice, bob} {
+			if e := c.next(t); e.Event.Type != conversation.EventMessageCreated || e.Event.Message.Body != "hi bob" {
+				t.Errorf("expected the new message, got %+v", e.Event)
+			}
+		}
+	})
+
+	t.Run("should replay the changes after the last seen message in commit order", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		s := newChatStore()
+		s.later = []storage.Message{
+			// sent before the last seen message and deleted after it.
+			{ID: uuid.New(), Sequence: 0, CreatePosition: 1, Position: 5, ConversationID: chatConvID, SenderID: chatBob, DeleteTime: sql.NullTime{Time: now, Valid: true}},
+			// took its sequence before the last seen message, but committed after it.
+			{ID: uuid.New(), Sequence: 0, CreatePosition: 3, Position: 3, ConversationID: chatConvID, SenderID: chatAlice, Body: "late"},
+		}
+		s.message.CreatePosition, s.message.Position = 2, 4 // the last seen message was edited.
+		s.message.EditTime = sql.NullTime{Time: now, Valid: true}
+		a := newChatApp(t, s)
+
+		bob := connect(ctx, a, chatBob)
+		bob.subscribe(t, chatMessageID)
+
+		want := []conversation.EventType{conversation.EventMessageCreated, conversation.EventMessageUpdated, conversation.EventMessageDeleted}
+		for i, w := range want {
+			if e := bob.next(t); e.Event.Type != w || e.Event.Message.Position != int64(i+3) {
+				t.Errorf("expected %s at position %d, got %s at %d", w, i+3, e.Event.Type, e.Event.Message.Position)
+			}
+		}
+	})
+
+	t.Run("should not deliver a replayed change again", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := newChatStore()
+		missed := storage.Message{ID: uuid.New(), Sequence: 2, CreatePosition: 2, Position: 2, ConversationID: chatConvID, SenderID: chatAlice, Body: "missed"}
+		s.later = []storage.Message{missed}
+		a := newChatApp(t, s)
+
+		bob := connect(ctx, a, chatBob)
+		bob.subscribe(t, chatMessageID)
+		if e := bob.next(t); e.Event.Type != conversation.EventMessageCreated || e.Event.Message.Body != "missed" {
+			t.Fatalf("expected the missed message, got %+v", e.Event)
+		}
+
+		// the event of the replayed message is published after the replay.
+		var m domain.Message
+		if err := m.FromStorage(&missed); err != nil {
+			t.Fatal(err)
+		}
+		s.hub.Publish(conversation.Event{Type: conversation.EventMessageCreated, ConversationID: chatConvID, Message: &m})
+
+		if _, err := a.UpdateMessage(ctx, chatPrincipal(chatAlice, domain.UserRoleUser), chatOrg, chatConvID, chatMessageID, "edited"); err != nil {
+			t.Fatal(err)
+		}
+
+		if e := bob.next(t); e.Event.Type != conversation.EventMessageUpdated || e.Event.Message.Body != "edited" {
+			t.Errorf("expected the edit, got %+v", e.Event)
+		}
+	})
+
+	t.Run("should not subscribe to conversations of others", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		carol := connect(ctx, newChatApp(t, newChatStore()), chatCarol)
+		carol.requests <- app.ChatRequest{Type: app.ChatSubscribe, OrganizationID: chatOrg, ConversationIDs: []uuid.UUID{chatConvID}}
+
+		if err := <-carol.done; !errors.Is(err, app.ErrPermissionDenied) {
+			t.Errorf("expected %v, got %v", app.ErrPermissionDenied, err)
+		}
+	})
+
+	t.Run("should disconnect idle clients", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bob := connect(ctx, newChatApp(t, newChatStore()), chatBob)
+
+		select {
+		case err := <-bob.done:
+			if !errors.Is(err, app.ErrChatIdle) {
+				t.Errorf("expected %v, got %v", app.ErrChatIdle, err)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected the idle client to be disconnected")
+		}
+	})
+
+	t.Run("should stop when the hub closes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := newChatStore()
+		bob := connect(ctx, newChatApp(t, s), chatBob)
+		s.hub.Close()
+
+		if err := <-bob.done; !errors.Is(err, app.ErrChatStopped) {
+			t.Errorf("expected %v, got %v", app.ErrChatStopped, err)
+		}
+	})
+}
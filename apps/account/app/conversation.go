@@ -0,0 +1,365 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/validate"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrConversationNotFound is returned when the conversation is not found.
+	ErrConversationNotFound = errors.New("conversation not found")
+	// ErrMessageNotFound is returned when the message is not found.
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrMessageDeleted is returned when a deleted message is edited.
+	ErrMessageDeleted = errors.New("message is deleted")
+	// ErrUnknownParticipant is returned when a participant is not a user of the organization.
+	ErrUnknownParticipant = errors.New("participant is not a user of the organization")
+)
+
+const (
+	defaultConversationPageSize = 50
+	maxConversationPageSize     = 500
+	defaultMessagePageSize      = 50
+	maxMessagePageSize          = 500
+)
+
+// CreateConversation starts a conversation in the organization of the caller. The caller is always a participant.
+func (r *App) CreateConversation(ctx context.Context, p *authentication.Principal, c *domain.Conversation) (*domain.Conversation, error) {
+	if err := authorizeOrganizationUser(p, c.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	c.CreatorID = p.UserID
+	if !slices.Contains(c.Participants, p.UserID) {
+		c.Participants = append([]uuid.UUID{p.UserID}, c.Participants...)
+	}
+
+	if err := conversation.Validate(c); err != nil {
+		return nil, err
+	}
+
+	for _, id := range c.Participants {
+		u, err := r.userReader.Get(ctx, id)
+		if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+			return nil, fmt.Errorf("failed to get participant: %w", err)
+		}
+
+		if err != nil || u.OrganizationID != c.OrganizationID {
+			return nil, validate.NewError("participants", fmt.Sprintf("user %s is not a user of the organization", id), ErrUnknownParticipant)
+		}
+	}
+
+	c, err := r.conversationWriter.Create(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	return c, nil
+}
+
+// ListConversations lists the conversations the caller participates in, most recently active first.
+// It returns the offset of the next page, 0 when there is none.
+func (r *App) ListConversations(ctx context.Context, p *authentication.Principal, organizationID uuid.UUID, pageSize, offset int) ([]*domain.Conversation, int, error) {
+	if err := authorizeOrganizationUser(p, organizationID); err != nil {
+		return nil, 0, err
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultConversationPageSize
+	}
+	pageSize = min(pageSize, maxConversationPageSize)
+
+	conversations, err := r.conversationReader.ListByParticipant(ctx, organizationID, p.UserID, storage.Pagination{Limit: pageSize, Offset: offset})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list conversations: %w", err)
+	}
+
+	var next int
+	if len(conversations) == pageSize {
+		next = offset + pageSize
+	}
+
+	return conversations, next, nil
+}
+
+// GetConversation gets a conversation the caller participates in.
+func (r *App) GetConversation(ctx context.Context, p *authentication.Principal, organizationID, conversationID uuid.UUID) (*domain.Conversation, error) {
+	return r.getConversation(ctx, p, organizationID, conversationID)
+}
+
+// CreateMessage sends a message to a conversation the caller participates in. The message is in the preferred
+// language of the caller, it is translated in the background into the preferred languages of the other participants.
+func (r *App) CreateMessage(ctx context.Context, p *authentication.Principal, organizationID, conversationID uuid.UUID, body string) (*domain.Message, error) {
+	c, err := r.getConversation(ctx, p, organizationID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conversation.ValidateBody(body); err != nil {
+		return nil, err
+	}
+
+	language, readers, err := r.participantLanguages(ctx, c, p.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.conversationWriter.CreateMessage(ctx, &domain.Message{
+		ConversationID: conversationID,
+		SenderID:       p.UserID,
+		Body:           body,
+		Language:       language,
+	})
+	if err != nil {
+		if errors.Is(err, conversation.ErrConversationNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	r.translations.Enqueue(organizationID, m, readers)
+	r.publishMessage(conversation.EventMessageCreated, m)
+	return m, nil
+}
+
+// ListMessages lists the messages of a conversation the caller participates in, newest first.
+// Only messages before the sequence are listed, unless it is 0.
+// Messages in another language than the requested language are translated, unless no language is requested.
+// It returns the sequence to continue from, 0 when there are no more messages.
+func (r *App) ListMessages(ctx context.Context, p *authentication.Principal, organizationID, conversationID uuid.UUID, pageSize int, before int64, language string) ([]*domain.Message, int64, error) {
+	if _, err := r.getConversation(ctx, p, organizationID, conversationID); err != nil {
+		return nil, 0, err
+	}
+
+	language, err := translation.ParseLanguage("language", language)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultMessagePageSize
+	}
+	pageSize = min(pageSize, maxMessagePageSize)
+
+	messages, err := r.conversationReader.ListMessages(ctx, conversationID, before, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	if err = r.translations.Translate(ctx, organizationID, messages, language); err != nil {
+		return nil, 0, fmt.Errorf("failed to translate messages: %w", err)
+	}
+
+	var next int64
+	if len(messages) == pageSize {
+		next = messages[len(messages)-1].Sequence
+	}
+
+	return messages, next, nil
+}
+
+// GetMessage gets a message of a conversation the caller participates in.
+// A message in another language than the requested language is translated, unless no language is requested.
+func (r *App) GetMessage(ctx context.Context, p *authentication.Principal, organizationID, conversationID, messageID uuid.UUID, language string) (*domain.Message, error) {
+	if _, err := r.getConversation(ctx, p, organizationID, conversationID); err != nil {
+		return nil, err
+	}
+
+	language, err := translation.ParseLanguage("language", language)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.getMessage(ctx, conversationID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.translations.Translate(ctx, organizationID, []*domain.Message{m}, language); err != nil {
+		return nil, fmt.Errorf("failed to translate message: %w", err)
+	}
+
+	return m, nil
+}
+
+// UpdateMessage changes the body of a message. Only the sender may edit a message.
+// The edit is translated again, earlier translations are no longer used.
+func (r *App) UpdateMessage(ctx context.Context, p *authentication.Principal, organizationID, conversationID, messageID uuid.UUID, body string) (*domain.Message, error) {
+	c, err := r.getConversation(ctx, p, organizationID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.getMessage(ctx, conversationID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.SenderID != p.UserID {
+		return nil, ErrPermissionDenied
+	}
+
+	if m.Deleted() {
+		return nil, ErrMessageDeleted
+	}
+
+	if err = conversation.ValidateBody(body); err != nil {
+		return nil, err
+	}
+
+	if m, err = r.conversationWriter.EditMessage(ctx, m, body); err != nil {
+		if errors.Is(err, conversation.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to update message: %w", err)
+	}
+
+	if _, readers, err := r.participantLanguages(ctx, c, p.UserID); err != nil {
+		r.logger.WarnContext(ctx, "failed to get the languages of the participants", slog.String("error", err.Error()))
+	} else {
+		r.translations.Enqueue(organizationID, m, readers)
+	}
+
+	r.publishMessage(conversation.EventMessageUpdated, m)
+	return m, nil
+}
+
+// DeleteMessage removes the body of a message. The sender and admins of the organization may delete a message,
+// admins do not have to participate in the conversation. Deleting a deleted message returns it as it is.
+func (r *App) DeleteMessage(ctx context.Context, p *authentication.Principal, organizationID, conversationID, messageID uuid.UUID) (*domain.Message, error) {
+	var m *domain.Message
+	var err error
+	if p != nil && p.Role == domain.UserRoleAdmin && p.OrganizationID == organizationID {
+		if _, err = r.getOrganizationConversation(ctx, organizationID, conversationID); err != nil {
+			return nil, err
+		}
+		m, err = r.getMessage(ctx, conversationID, messageID)
+	} else {
+		m, err = r.GetMessage(ctx, p, organizationID, conversationID, messageID, "")
+		if err == nil && m.SenderID != p.UserID {
+			err = ErrPermissionDenied
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Deleted() {
+		return m, nil
+	}
+
+	if m, err = r.conversationWriter.DeleteMessage(ctx, m); err != nil {
+		if errors.Is(err, conversation.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	r.publishMessage(conversation.EventMessageDeleted, m)
+	return m, nil
+}
+
+// publishMessage tells the chat streams of the conversation about a change to a message.
+func (r *App) publishMessage(t conversation.EventType, m *domain.Message) {
+	r.chatHub.Publish(conversation.Event{
+		Type:           t,
+		ConversationID: m.ConversationID,
+		UserID:         m.SenderID,
+		Message:        m,
+	})
+}
+
+// participantLanguages returns the preferred language of the sender and the preferred languages of the other
+// participants of a conversation. Participants without a preferred language are left out.
+func (r *App) participantLanguages(ctx context.Context, c *domain.Conversation, senderID uuid.UUID) (string, []string, error) {
+	users, err := r.userReader.ListByIDs(ctx, c.Participants)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	var sender string
+	var readers []string
+	for _, u := range users {
+		switch {
+		case u.ID == senderID:
+			sender = u.PreferredLanguage
+		case u.PreferredLanguage != "" && !slices.Contains(readers, u.PreferredLanguage):
+			readers = append(readers, u.PreferredLanguage)
+		}
+	}
+
+	return sender, readers, nil
+}
+
+// getConversation gets a conversation of the organization and checks that the caller participates in it.
+func (r *App) getConversation(ctx context.Context, p *authentication.Principal, organizationID, conversationID uuid.UUID) (*domain.Conversation, error) {
+	if err := authorizeOrganizationUser(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	c, err := r.getOrganizationConversation(ctx, organizationID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(c.Participants, p.UserID) {
+		return nil, ErrPermissionDenied
+	}
+
+	return c, nil
+}
+
+// getOrganizationConversation gets a conversation and checks that it belongs to the organization.
+func (r *App) getOrganizationConversation(ctx context.Context, organizationID, conversationID uuid.UUID) (*domain.Conversation, error) {
+	c, err := r.conversationReader.Get(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, conversation.ErrConversationNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	if c.OrganizationID != organizationID {
+		return nil, ErrConversationNotFound
+	}
+
+	return c, nil
+}
+
+// getMessage gets a message and checks that it belongs to the conversation.
+func (r *App) getMessage(ctx context.Context, conversationID, messageID uuid.UUID) (*domain.Message, error) {
+	m, err := r.conversationReader.GetMessage(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, conversation.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if m.ConversationID != conversationID {
+		return nil, ErrMessageNotFound
+	}
+
+	return m, nil
+}
+
+// authorizeOrganizationUser checks whether the principal is a user of the organization.
+// Unlike the other checks the system user is not allowed, conversations are private to their participants.
+func authorizeOrganizationUser(p *authentication.Principal, organizationID uuid.UUID) error {
+	if p == nil || p.OrganizationID != organizationID {
+		return ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+package app_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/auth/registration"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/validate"
+	"github.com/google/uuid"
+
+	conversationMock "github.com/extreme-business/lingo/apps/account/storage/mock/conversation"
+	managerMock "github.com/extreme-business/lingo/apps/account/storage/mock/manager"
+	messageMock "github.com/extreme-business/lingo/apps/account/storage/mock/message"
+	translationMock "github.com/extreme-business/lingo/apps/account/storage/mock/messagetranslation"
+	participantMock "github.com/extreme-business/lingo/apps/account/storage/mock/participant"
+	userMock "github.com/extreme-business/lingo/apps/account/storage/mock/user"
+)
+
+var (
+	chatOrg       = uuid.MustParse("3f1c0a5e-2c7b-4d7e-9a51-0b6f5a2e8c11")
+	chatAlice     = uuid.MustParse("8d0c6a4e-5b5f-4b8e-8f4a-2f0f3f9b1a01")
+	chatBob       = uuid.MustParse("8d0c6a4e-5b5f-4b8e-8f4a-2f0f3f9b1a02")
+	chatCarol     = uuid.MustParse("8d0c6a4e-5b5f-4b8e-8f4a-2f0f3f9b1a03")
+	chatOutsider  = uuid.MustParse("8d0c6a4e-5b5f-4b8e-8f4a-2f0f3f9b1a04")
+	chatConvID    = uuid.MustParse("c2a6f7de-0d5e-4a8e-b5f3-1a2b3c4d5e60")
+	chatMessageID = uuid.MustParse("c2a6f7de-0d5e-4a8e-b5f3-1a2b3c4d5e61")
+)
+
+// chatStore keeps a single direct conversation between alice and bob with one message of alice.
+type chatStore struct {
+	participants []uuid.UUID
+	message      storage.Message
+	later        []storage.Message    // later are other messages of the conversation, they can only be listed.
+	languages    map[uuid.UUID]string // languages are the preferred languages of the users.
+	hub          *conversation.Hub
+}
+
+func newChatStore() *chatStore {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &chatStore{
+		participants: []uuid.UUID{chatAlice, chatBob},
+		message: storage.Message{
+			ID:             chatMessageID,
+			Sequence:       1,
+			CreatePosition: 1,
+			Position:       1,
+			ConversationID: chatConvID,
+			SenderID:       chatAlice,
+			Body:           "hello",
+			CreateTime:     now,
+			UpdateTime:     now,
+		},
+		hub: conversation.NewHub(),
+	}
+}
+
+func (s *chatStore) repositories() storage.Repositories {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return storage.Repositories{
+		User: &userMock.Repository{
+			GetFunc: func(_ context.Context, id uuid.UUID) (*storage.User, error) {
+				orgID := chatOrg
+				if id == chatOutsider {
+					orgID = otherOrg
+				}
+				return &storage.User{ID: id, OrganizationID: orgID, PreferredLanguage: s.languages[id]}, nil
+			},
+			ListFunc: func(_ context.Context, _ storage.Pagination, _ storage.UserOrderBy, conditions ...storage.Condition) ([]*storage.User, error) {
+				var out []*storage.User
+				for _, id := range conditions[0].(storage.UserByIDsCondition).IDs {
+					out = append(out, &storage.User{ID: id, OrganizationID: chatOrg, PreferredLanguage: s.languages[id]})
+				}
+				return out, nil
+			},
+		},
+		MessageTranslation: &translationMock.Repository{
+			UpsertFunc: func(context.Context, *storage.MessageTranslation) error {
+				return nil
+			},
+			ListFunc: func(context.Context, storage.Pagination, storage.MessageTranslationOrderBy, ...storage.Condition) ([]*storage.MessageTranslation, error) {
+				return nil, nil
+			},
+		},
+		Conversation: &conversationMock.Repository{
+			CreateFunc: func(_ context.Context, c *storage.Conversation) (*storage.Conversation, error) {
+				return c, nil
+			},
+			UpdateFunc: func(_ context.Context, c *storage.Conversation, _ []storage.ConversationField) (*storage.Conversation, error) {
+				return c, nil
+			},
+			GetFunc: func(_ context.Context, id uuid.UUID) (*storage.Conversation, error) {
+				if id != chatConvID {
+					return nil, storage.ErrConversationNotFound
+				}
+				return &storage.Conversation{
+					ID:             chatConvID,
+					OrganizationID: chatOrg,
+					Type:           string(domain.ConversationTypeDirect),
+					CreatorID:      chatAlice,
+					CreateTime:     now,
+					UpdateTime:     now,
+				}, nil
+			},
+		},
+		Participant: &participantMock.Repository{
+			CreateFunc: func(_ context.Context, p *storage.Participant) (*storage.Participant, error) {
+				return p, nil
+			},
+			ListFunc: func(_ context.Context, _ storage.Pagination, _ storage.ParticipantOrderBy, _ ...storage.Condition) ([]*storage.Participant, error) {
+				out := make([]*storage.Participant, 0, len(s.participants))
+				for _, id := range s.participants {
+					out = append(out, &storage.Participant{ConversationID: chatConvID, UserID: id, JoinTime: now})
+				}
+				return out, nil
+			},
+		},
+		Message: &messageMock.Repository{
+			GetFunc: func(_ context.Context, id uuid.UUID) (*storage.Message, error) {
+				if id != chatMessageID {
+					return nil, storage.ErrMessageNotFound
+				}
+				m := s.message
+				return &m, nil
+			},
+			CreateFunc: func(_ context.Context, m *storage.Message) (*storage.Message, error) {
+				m.Sequence = s.message.Sequence + 1
+				return m, nil
+			},
+			UpdateFunc: func(_ context.Context, m *storage.Message, _ []storage.MessageField) (*storage.Message, error) {
+				s.message = *m
+				return m, nil
+			},
+			ListFunc: func(_ context.Context, _ storage.Pagination, _ storage.MessageOrderBy, conditions ...storage.Condition) ([]*storage.Message, error) {
+				var after int64
+				for _, c := range conditions {
+					if c, ok := c.(storage.MessageAfterPositionCondition); ok {
+						after = c.Position
+					}
+				}
+
+				var out []*storage.Message
+				for _, m := range append([]storage.Message{s.message}, s.later...) {
+					if m.Position > after {
+						out = append(out, &m)
+					}
+				}
+				slices.SortFunc(out, func(a, b *storage.Message) int { return cmp.Compare(a.Position, b.Position) })
+				return out, nil
+			},
+		},
+	}
+}
+
+func newChatApp(t *testing.T, s *chatStore) *app.App {
+	t.Helper()
+
+	repos := s.repositories()
+	now := func() time.Time { return time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC) }
+	a, err := app.New(app.Config{
+		Logger:                slog.Default(),
+		UserReader:            user.NewReader(repos.User),
+		SessionReader:         session.NewReader(nil, nil),
+		SessionWriter:         session.NewWriter(nil, nil),
+		AuditReader:           audit.NewReader(nil),
+		AuditRecorder:         audit.NewRecorder(nil, nil),
+		WebhookReader:         webhook.NewReader(nil, nil),
+		WebhookWriter:         webhook.NewWriter(nil, nil, nil),
+		WebhookDeliveryWriter: webhook.NewDeliveryWriter(nil, nil, nil),
+		ConversationReader:    conversation.NewReader(repos.Conversation, repos.Participant, repos.Message),
+		ConversationWriter:    conversation.NewWriter(now, uuid.New, managerMock.New(repos)),
+		ChatHub:               s.hub,
+		ChatHeartbeat:         20 * time.Millisecond,
+		EventFeed:             &outbox.Feed{},
+		UserUpdater:           user.NewUpdater(nil, nil),
+		Translations:          newTranslations(t, repos),
+		GlossaryReader:        glossary.NewReader(nil, nil, nil),
+		GlossaryWriter:        glossary.NewWriter(nil, nil, nil),
+		Authenticator:         authentication.New(authentication.Config{}),
+		RegistrationManager:   registration.NewManager(registration.Config{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return a
+}
+
+func newTranslations(t *testing.T, repos storage.Repositories) *translation.Service {
+	t.Helper()
+
+	s, err := translation.NewService(translation.Config{
+		Logger:     slog.Default(),
+		Clock:      time.Now,
+		Translator: translation.NewDictionary(translation.Entry{Source: "en", Target: "nl", Word: "hello", Translation: "hallo"}),
+		DBManager:  managerMock.New(repos),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func chatPrincipal(userID uuid.UUID, role domain.UserRole) *authentication.Principal {
+	return &authentication.Principal{UserID: userID, OrganizationID: chatOrg, Role: role}
+}
+
+func TestApp_CreateConversation(t *testing.T) {
+	t.Run("should add the creator as participant", func(t *testing.T) {
+		s := newChatStore()
+		a := newChatApp(t, s)
+
+		c, err := a.CreateConversation(context.Background(), chatPrincipal(chatAlice, domain.UserRoleUser), &domain.Conversation{
+			OrganizationID: chatOrg,
+			Type:           domain.ConversationTypeDirect,
+			Participants:   []uuid.UUID{chatBob},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(c.Participants) != 2 || c.Participants[0] != chatAlice || c.CreatorID != chatAlice {
+			t.Errorf("expected alice to be the creator and a participant, got %v", c.Participants)
+		}
+	})
+
+	t.Run("should reject users of other organizations", func(t *testing.T) {
+		a := newChatApp(t, newChatStore())
+
+		_, err := a.CreateConversation(context.Background(), chatPrincipal(chatAlice, domain.UserRoleUser), &domain.Conversation{
+			OrganizationID: chatOrg,
+			Type:           domain.ConversationTypeDirect,
+			Participants:   []uuid.UUID{chatOutsider},
+		})
+		if !errors.Is(err, app.ErrUnknownParticipant) {
+			t.Errorf("expected %v, got %v", app.ErrUnknownParticipant, err)
+		}
+	})
+
+	t.Run("should reject conversations in other organizations", func(t *testing.T) {
+		a := newChatApp(t, newChatStore())
+
+		_, err := a.CreateConversation(context.Background(), chatPrincipal(chatAlice, domain.UserRoleUser), &domain.Conversation{
+			OrganizationID: otherOrg,
+			Type:           domain.ConversationTypeDirect,
+			Participants:   []uuid.UUID{chatBob},
+		})
+		if !errors.Is(err, app.ErrPermissionDenied) {
+			t.Errorf("expected %v, got %v", app.ErrPermissionDenied, err)
+		}
+	})
+}
+
+func TestApp_GetConversation(t *testing.T) {
+	a := newChatApp(t, newChatStore())
+
+	if _, err := a.GetConversation(context.Background(), chatPrincipal(chatBob, domain.UserRoleUser), chatOrg, chatConvID); err != nil {
+		t.Errorf("expected a participant to get the conversation, got %v", err)
+	}
+
+	_, err := a.GetConversation(context.Background(), chatPrincipal(chatCarol, domain.UserRoleAdmin), chatOrg, chatConvID)
+	if !errors.Is(err, app.ErrPermissionDenied) {
+		t.Errorf("expected %v, got %v", app.ErrPermissionDenied, err)
+	}
+
+	_, err = a.GetConversation(context.Background(), chatPrincipal(chatBob, domain.UserRoleUser), chatOrg, uuid.New())
+	if !errors.Is(err, app.ErrConversationNotFound) {
+		t.Errorf("expected %v, got %v", app.ErrConversationNotFound, err)
+	}
+}
+
+func TestApp_CreateMessage(t *testing.T) {
+	t.Run("should send the message in the preferred language of the sender", func(t *testing.T) {
+		s := newChatStore()
+		s.languages = map[uuid.UUID]string{chatAlice: "en", chatBob: "nl"}
+		a := newChatApp(t, s)
+
+		m, err := a.CreateMessage(context.Background(), chatPrincipal(chatAlice, domain.UserRoleUser), chatOrg, chatConvID, "hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if m.Language != "en" {
+			t.Errorf("expected the message to be in english, got %q", m.Language)
+		}
+	})
+}
+
+func TestApp_ListMessages(t *testing.T) {
+	t.Run("should translate the messages into the requested language", func(t *testing.T) {
+		s := newChatStore()
+		s.message.Language = "en"
+		a := newChatApp(t, s)
+
+		messages, _, err := a.ListMessages(context.Background(), chatPrincipal(chatBob, domain.UserRoleUser), chatOrg, chatConvID, 10, 0, "nl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(messages) != 1 || messages[0].Translation == nil || messages[0].Translation.Body != "[nl] hallo" {
+			t.Errorf("expected the message to be translated, got %+v", messages)
+		}
+	})
+
+	t.Run("should reject an invalid language", func(t *testing.T) {
+		a := newChatApp(t, newChatStore())
+
+		_, _, err := a.ListMessages(context.Background(), chatPrincipal(chatBob, domain.UserRoleUser), chatOrg, chatConvID, 10, 0, "not a language")
+		var vErr *validate.Error
+		if !errors.As(err, &vErr) || vErr.Field() != "language" {
+			t.Errorf("expected a validation error of the language, got %v", err)
+		}
+	})
+}
+
+func TestApp_UpdateMessage(t *testing.T) {
+	t.Run("should edit the message of the sender", func(t *testing.T) {
+		a := newChatApp(t, newChatStore())
+
+		m, err := a.UpdateMessage(context.Background(), chatPrincipal(chatAlice, domain.UserRoleUser), chatOrg, chatConvID, chatMessageID, "hi")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if m.Body != "hi" || !m.Edited() {
+			t.Errorf("expected an edited message, got %+v", m)
+		}
+	})
+
+	t.Run("should not edit the message of someone else", func(t *testing.T) {
+		a := newChatApp(t, newChatStore())
+
+		_, err := a.UpdateMessage(context.Background(), chatPrincipal(chatBob, domain.UserRoleUser), chatOrg, chatConvID, chatMessageID, "hi")
+		if !errors.Is(err, app.ErrPermissionDenied) {
+			t.Errorf("expected %v, got %v", app.ErrPermissionDenied, err)
+		}
+	})
+
+	t.Run("should not edit a deleted message", func(t *testing.T) {
+		s := newChatStore()
+		s.message.Body = ""
+		s.message.DeleteTime.Time, s.message.DeleteTime.Valid = s.message.CreateTime, true
+		a := newChatApp(t, s)
+
+		_, err := a.UpdateMessage(context.Background(), chatPrincipal(chatAlice, domain.UserRoleUser), chatOrg, chatConvID, chatMessageID, "hi")
+		if !errors.Is(err, app.ErrMessageDeleted) {
+			t.Errorf("expected %v, got %v", app.ErrMessageDeleted, err)
+		}
+	})
+}
+
+func TestApp_DeleteMessage(t *testing.T) {
+	t.Run("should delete the message of the sender only once", func(t *testing.T) {
+		s := newChatStore()
+		a := newChatApp(t, s)
+		p := chatPrincipal(chatAlice, domain.UserRoleUser)
+
+		m, err := a.DeleteMessage(context.Background(), p, chatOrg, chatConvID, chatMessageID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if m.Body != "" || !m.Deleted() {
+			t.Errorf("expected a deleted message, got %+v", m)
+		}
+
+		deleteTime := s.message.DeleteTime
+		if _, err = a.DeleteMessage(context.Background(), p, chatOrg, chatConvID, chatMessageID); err != nil {
+			t.Fatal(err)
+		}
+
+		if s.message.DeleteTime != deleteTime {
+			t.Error("expected the second delete to keep the message as it is")
+		}
+	})
+
+	t.Run("should not delete the message of someone else", func(t *testing.T) {
+		a := newChatApp(t, newChatStore())
+
+		_, err := a.DeleteMessage(context.Background(), chatPrincipal(chatBob, domain.UserRoleUser), chatOrg, chatConvID, chatMessageID)
+		if !errors.Is(err, app.ErrPermissionDenied) {
+			t.Errorf("expected %v, got %v", app.ErrPermissionDenied, err)
+		}
+	})
+
+	t.Run("should let admins delete messages without participating", func(t *testing.T) {
+		a := newChatApp(t, newChatStore())
+
+		if _, err := a.DeleteMessage(context.Background(), chatPrincipal(chatCarol, domain.UserRoleAdmin), chatOrg, chatConvID, chatMessageID); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
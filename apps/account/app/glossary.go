@@ -0,0 +1,538 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrGlossaryNotFound is returned when the glossary is not found.
+	ErrGlossaryNotFound = errors.New("glossary not found")
+	// ErrGlossaryTermNotFound is returned when the glossary term is not found.
+	ErrGlossaryTermNotFound = errors.New("glossary term not found")
+	// ErrGlossarySegmentNotFound is returned when the glossary segment is not found.
+	ErrGlossarySegmentNotFound = errors.New("glossary segment not found")
+)
+
+const (
+	defaultGlossaryPageSize = 100
+	maxGlossaryPageSize     = 1000
+
+	defaultSegmentMatches = 10
+	maxSegmentMatches     = 100
+
+	// defaultSegmentMatchScore is the lowest similarity of a searched segment when none is given.
+	defaultSegmentMatchScore = 0.5
+)
+
+// GlossaryUpdate is a change to a glossary. Nil fields are left unchanged.
+type GlossaryUpdate struct {
+	OrganizationID uuid.UUID
+	GlossaryID     uuid.UUID
+	DisplayName    *string
+}
+
+// GlossaryTermUpdate is a change to a glossary term. Nil fields are left unchanged.
+type GlossaryTermUpdate struct {
+	OrganizationID uuid.UUID
+	GlossaryID     uuid.UUID
+	TermID         uuid.UUID
+	Term           *string
+	Language       *string
+	Translation    *string
+	DoNotTranslate *bool
+}
+
+// CreateGlossary creates a glossary for an organization.
+// Members of the organization may read its glossaries, only admins and the system user may change them.
+func (r *App) CreateGlossary(ctx context.Context, p *authentication.Principal, g *domain.Glossary) (*domain.Glossary, error) {
+	if err := authorizeOrganizationAdmin(p, g.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if err := glossary.Validate(g); err != nil {
+		return nil, err
+	}
+
+	g, err := r.glossaryWriter.Create(ctx, g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create glossary: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: g.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionGlossaryCreated,
+		Resource:       domain.GlossaryName(g.OrganizationID, g.ID),
+	})
+
+	return g, nil
+}
+
+// ListGlossaries lists the glossaries of an organization.
+func (r *App) ListGlossaries(ctx context.Context, p *authentication.Principal, organizationID uuid.UUID) ([]*domain.Glossary, error) {
+	if err := authorizeOrganizationMember(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	glossaries, err := r.glossaryReader.List(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossaries: %w", err)
+	}
+
+	return glossaries, nil
+}
+
+// GetGlossary gets a glossary of an organization.
+func (r *App) GetGlossary(ctx context.Context, p *authentication.Principal, organizationID, glossaryID uuid.UUID) (*domain.Glossary, error) {
+	if err := authorizeOrganizationMember(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	return r.getGlossary(ctx, organizationID, glossaryID)
+}
+
+// UpdateGlossary changes the display name of a glossary.
+func (r *App) UpdateGlossary(ctx context.Context, p *authentication.Principal, u GlossaryUpdate) (*domain.Glossary, error) {
+	if err := authorizeOrganizationAdmin(p, u.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	g, err := r.getGlossary(ctx, u.OrganizationID, u.GlossaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []storage.GlossaryField
+	if u.DisplayName != nil {
+		g.DisplayName = *u.DisplayName
+		fields = append(fields, storage.GlossaryDisplayName)
+	}
+
+	if err = glossary.Validate(g); err != nil {
+		return nil, err
+	}
+
+	if g, err = r.glossaryWriter.Update(ctx, g, fields); err != nil {
+		if errors.Is(err, glossary.ErrGlossaryNotFound) {
+			return nil, ErrGlossaryNotFound
+		}
+		return nil, fmt.Errorf("failed to update glossary: %w", err)
+	}
+
+	changed := make([]string, 0, len(fields))
+	for _, f := range fields {
+		changed = append(changed, string(f))
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: g.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionGlossaryUpdated,
+		Resource:       domain.GlossaryName(g.OrganizationID, g.ID),
+		Details:        map[string]string{"fields": strings.Join(changed, ",")},
+	})
+
+	return g, nil
+}
+
+// DeleteGlossary deletes a glossary together with its terms and segments.
+func (r *App) DeleteGlossary(ctx context.Context, p *authentication.Principal, organizationID, glossaryID uuid.UUID) error {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		return err
+	}
+
+	if err := r.glossaryWriter.Delete(ctx, glossaryID); err != nil {
+		if errors.Is(err, glossary.ErrGlossaryNotFound) {
+			return ErrGlossaryNotFound
+		}
+		return fmt.Errorf("failed to delete glossary: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: organizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionGlossaryDeleted,
+		Resource:       domain.GlossaryName(organizationID, glossaryID),
+	})
+
+	return nil
+}
+
+// CreateGlossaryTerm adds a term to a glossary of an organization.
+func (r *App) CreateGlossaryTerm(ctx context.Context, p *authentication.Principal, organizationID uuid.UUID, t *domain.GlossaryTerm) (*domain.GlossaryTerm, error) {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, t.GlossaryID); err != nil {
+		return nil, err
+	}
+
+	if err := glossary.ValidateTerm(t); err != nil {
+		return nil, err
+	}
+
+	t, err := r.glossaryWriter.CreateTerm(ctx, t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create glossary term: %w", err)
+	}
+
+	r.recordGlossaryChange(ctx, p, organizationID, domain.GlossaryTermName(organizationID, t.GlossaryID, t.ID), "created")
+
+	return t, nil
+}
+
+// ListGlossaryTerms lists the terms of a glossary in alphabetical order.
+// It returns the offset of the next page, 0 when there is none.
+func (r *App) ListGlossaryTerms(ctx context.Context, p *authentication.Principal, organizationID, glossaryID uuid.UUID, pageSize, offset int) ([]*domain.GlossaryTerm, int, error) {
+	if err := authorizeOrganizationMember(p, organizationID); err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		return nil, 0, err
+	}
+
+	pageSize = glossaryPageSize(pageSize)
+	terms, err := r.glossaryReader.ListTerms(ctx, glossaryID, storage.Pagination{Limit: pageSize, Offset: offset})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list glossary terms: %w", err)
+	}
+
+	var next int
+	if len(terms) == pageSize {
+		next = offset + pageSize
+	}
+
+	return terms, next, nil
+}
+
+// UpdateGlossaryTerm changes a term of a glossary.
+func (r *App) UpdateGlossaryTerm(ctx context.Context, p *authentication.Principal, u GlossaryTermUpdate) (*domain.GlossaryTerm, error) {
+	if err := authorizeOrganizationAdmin(p, u.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	t, err := r.getGlossaryTerm(ctx, u.OrganizationID, u.GlossaryID, u.TermID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []storage.GlossaryTermField
+	if u.Term != nil {
+		t.Term = *u.Term
+		fields = append(fields, storage.GlossaryTermTerm)
+	}
+	if u.Language != nil {
+		t.Language = *u.Language
+		fields = append(fields, storage.GlossaryTermLanguage)
+	}
+	if u.Translation != nil {
+		t.Translation = *u.Translation
+		fields = append(fields, storage.GlossaryTermTranslation)
+	}
+	if u.DoNotTranslate != nil {
+		t.DoNotTranslate = *u.DoNotTranslate
+		fields = append(fields, storage.GlossaryTermDoNotTranslate)
+	}
+
+	if err = glossary.ValidateTerm(t); err != nil {
+		return nil, err
+	}
+
+	if t, err = r.glossaryWriter.UpdateTerm(ctx, t, fields); err != nil {
+		if errors.Is(err, glossary.ErrTermNotFound) {
+			return nil, ErrGlossaryTermNotFound
+		}
+		return nil, fmt.Errorf("failed to update glossary term: %w", err)
+	}
+
+	r.recordGlossaryChange(ctx, p, u.OrganizationID, domain.GlossaryTermName(u.OrganizationID, t.GlossaryID, t.ID), "updated")
+
+	return t, nil
+}
+
+// DeleteGlossaryTerm deletes a term of a glossary.
+func (r *App) DeleteGlossaryTerm(ctx context.Context, p *authentication.Principal, organizationID, glossaryID, termID uuid.UUID) error {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return err
+	}
+
+	if _, err := r.getGlossaryTerm(ctx, organizationID, glossaryID, termID); err != nil {
+		return err
+	}
+
+	if err := r.glossaryWriter.DeleteTerm(ctx, termID); err != nil {
+		if errors.Is(err, glossary.ErrTermNotFound) {
+			return ErrGlossaryTermNotFound
+		}
+		return fmt.Errorf("failed to delete glossary term: %w", err)
+	}
+
+	r.recordGlossaryChange(ctx, p, organizationID, domain.GlossaryTermName(organizationID, glossaryID, termID), "deleted")
+
+	return nil
+}
+
+// CreateSegment adds an approved translation to the translation memory of a glossary.
+func (r *App) CreateSegment(ctx context.Context, p *authentication.Principal, organizationID uuid.UUID, s *domain.GlossarySegment) (*domain.GlossarySegment, error) {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, s.GlossaryID); err != nil {
+		return nil, err
+	}
+
+	if err := glossary.ValidateSegment(s); err != nil {
+		return nil, err
+	}
+
+	s, err := r.glossaryWriter.CreateSegment(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create glossary segment: %w", err)
+	}
+
+	r.recordGlossaryChange(ctx, p, organizationID, domain.GlossarySegmentName(organizationID, s.GlossaryID, s.ID), "created")
+
+	return s, nil
+}
+
+// ListSegments lists the segments of a glossary, oldest first.
+// It returns the offset of the next page, 0 when there is none.
+func (r *App) ListSegments(ctx context.Context, p *authentication.Principal, organizationID, glossaryID uuid.UUID, pageSize, offset int) ([]*domain.GlossarySegment, int, error) {
+	if err := authorizeOrganizationMember(p, organizationID); err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		return nil, 0, err
+	}
+
+	pageSize = glossaryPageSize(pageSize)
+	segments, err := r.glossaryReader.ListSegments(ctx, glossaryID, storage.Pagination{Limit: pageSize, Offset: offset})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list glossary segments: %w", err)
+	}
+
+	var next int
+	if len(segments) == pageSize {
+		next = offset + pageSize
+	}
+
+	return segments, next, nil
+}
+
+// DeleteSegment deletes a segment of a glossary.
+func (r *App) DeleteSegment(ctx context.Context, p *authentication.Principal, organizationID, glossaryID, segmentID uuid.UUID) error {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return err
+	}
+
+	if _, err := r.getSegment(ctx, organizationID, glossaryID, segmentID); err != nil {
+		return err
+	}
+
+	if err := r.glossaryWriter.DeleteSegment(ctx, segmentID); err != nil {
+		if errors.Is(err, glossary.ErrSegmentNotFound) {
+			return ErrGlossarySegmentNotFound
+		}
+		return fmt.Errorf("failed to delete glossary segment: %w", err)
+	}
+
+	r.recordGlossaryChange(ctx, p, organizationID, domain.GlossarySegmentName(organizationID, glossaryID, segmentID), "deleted")
+
+	return nil
+}
+
+// SearchSegments finds the segments of a glossary with a source text similar to a text, best match first.
+// The minimum score defaults to 0.5 and the limit to 10 matches.
+func (r *App) SearchSegments(ctx context.Context, p *authentication.Principal, organizationID, glossaryID uuid.UUID, q glossary.Query) ([]*domain.GlossarySegmentMatch, error) {
+	if err := authorizeOrganizationMember(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		return nil, err
+	}
+
+	if q.MinScore == 0 {
+		q.MinScore = defaultSegmentMatchScore
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultSegmentMatches
+	}
+	q.Limit = min(q.Limit, maxSegmentMatches)
+
+	if err := glossary.ValidateQuery(&q); err != nil {
+		return nil, err
+	}
+
+	matches, err := r.glossaryReader.Search(ctx, glossaryID, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search glossary segments: %w", err)
+	}
+
+	return matches, nil
+}
+
+// ImportGlossary adds the terms of a CSV file or the segments of a TMX file to a glossary.
+// The file is imported as a whole or not at all. It returns the number of imported terms and segments.
+func (r *App) ImportGlossary(ctx context.Context, p *authentication.Principal, organizationID, glossaryID uuid.UUID, f glossary.Format, content []byte) (int, int, error) {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return 0, 0, err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		return 0, 0, err
+	}
+
+	terms, segments, err := glossary.Decode(f, content)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err = r.glossaryWriter.Import(ctx, glossaryID, terms, segments); err != nil {
+		return 0, 0, fmt.Errorf("failed to import glossary: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: organizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionGlossaryImported,
+		Resource:       domain.GlossaryName(organizationID, glossaryID),
+		Details: map[string]string{
+			"terms":    strconv.Itoa(len(terms)),
+			"segments": strconv.Itoa(len(segments)),
+		},
+	})
+
+	return len(terms), len(segments), nil
+}
+
+// ExportGlossary writes the terms of a glossary as a CSV file or its segments as a TMX file.
+func (r *App) ExportGlossary(ctx context.Context, p *authentication.Principal, organizationID, glossaryID uuid.UUID, f glossary.Format) ([]byte, error) {
+	if err := authorizeOrganizationMember(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		return nil, err
+	}
+
+	var (
+		terms    []*domain.GlossaryTerm
+		segments []*domain.GlossarySegment
+		err      error
+	)
+	switch f {
+	case glossary.FormatCSV:
+		if terms, err = r.glossaryReader.ListTerms(ctx, glossaryID, storage.Pagination{}); err != nil {
+			return nil, fmt.Errorf("failed to list glossary terms: %w", err)
+		}
+	case glossary.FormatTMX:
+		if segments, err = r.glossaryReader.ListSegments(ctx, glossaryID, storage.Pagination{}); err != nil {
+			return nil, fmt.Errorf("failed to list glossary segments: %w", err)
+		}
+	}
+
+	return glossary.Encode(f, terms, segments)
+}
+
+// getGlossary gets a glossary and checks that it belongs to the organization.
+func (r *App) getGlossary(ctx context.Context, organizationID, glossaryID uuid.UUID) (*domain.Glossary, error) {
+	g, err := r.glossaryReader.Get(ctx, glossaryID)
+	if err != nil {
+		if errors.Is(err, glossary.ErrGlossaryNotFound) {
+			return nil, ErrGlossaryNotFound
+		}
+		return nil, fmt.Errorf("failed to get glossary: %w", err)
+	}
+
+	if g.OrganizationID != organizationID {
+		return nil, ErrGlossaryNotFound
+	}
+
+	return g, nil
+}
+
+// getGlossaryTerm gets a term and checks that it belongs to the glossary of the organization.
+func (r *App) getGlossaryTerm(ctx context.Context, organizationID, glossaryID, termID uuid.UUID) (*domain.GlossaryTerm, error) {
+	t, err := r.glossaryReader.GetTerm(ctx, termID)
+	if err != nil {
+		if errors.Is(err, glossary.ErrTermNotFound) {
+			return nil, ErrGlossaryTermNotFound
+		}
+		return nil, fmt.Errorf("failed to get glossary term: %w", err)
+	}
+
+	if t.GlossaryID != glossaryID {
+		return nil, ErrGlossaryTermNotFound
+	}
+
+	if _, err = r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		if errors.Is(err, ErrGlossaryNotFound) {
+			return nil, ErrGlossaryTermNotFound
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// getSegment gets a segment and checks that it belongs to the glossary of the organization.
+func (r *App) getSegment(ctx context.Context, organizationID, glossaryID, segmentID uuid.UUID) (*domain.GlossarySegment, error) {
+	s, err := r.glossaryReader.GetSegment(ctx, segmentID)
+	if err != nil {
+		if errors.Is(err, glossary.ErrSegmentNotFound) {
+			return nil, ErrGlossarySegmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get glossary segment: %w", err)
+	}
+
+	if s.GlossaryID != glossaryID {
+		return nil, ErrGlossarySegmentNotFound
+	}
+
+	if _, err = r.getGlossary(ctx, organizationID, glossaryID); err != nil {
+		if errors.Is(err, ErrGlossaryNotFound) {
+			return nil, ErrGlossarySegmentNotFound
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// recordGlossaryChange records a change to a term or segment as an update of its glossary.
+func (r *App) recordGlossaryChange(ctx context.Context, p *authentication.Principal, organizationID uuid.UUID, resource, change string) {
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: organizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionGlossaryUpdated,
+		Resource:       resource,
+		Details:        map[string]string{"change": change},
+	})
+}
+
+// glossaryPageSize returns the page size of a list of terms or segments.
+func glossaryPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return defaultGlossaryPageSize
+	}
+	return min(pageSize, maxGlossaryPageSize)
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/google/uuid"
+)
+
+// ErrReasonRequired is returned when an impersonation is requested without a reason.
+var ErrReasonRequired = errors.New("reason is required")
+
+// Impersonation is the request to act as another user.
+type Impersonation struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Reason         string        // Reason is written to the audit log, for example a support ticket.
+	TTL            time.Duration // TTL is how long the access token is valid, it is capped to authentication.ImpersonationDuration.
+	Client         Client
+}
+
+// ImpersonateUser starts a session in which the principal acts as another user.
+// The system user may impersonate anyone, admins only the regular users of their organization.
+// Nobody can impersonate the system user, themselves or someone else while impersonating.
+// The impersonation is only granted when it could be written to the audit log.
+func (r *App) ImpersonateUser(ctx context.Context, p *authentication.Principal, i Impersonation) (*LoginResult, error) {
+	if p == nil || p.Impersonated() || p.UserID == i.UserID {
+		return nil, ErrPermissionDenied
+	}
+
+	if strings.TrimSpace(i.Reason) == "" {
+		return nil, ErrReasonRequired
+	}
+
+	u, err := r.userReader.Get(ctx, i.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if u.OrganizationID != i.OrganizationID {
+		return nil, ErrUserNotFound
+	}
+
+	switch {
+	case u.Role == domain.UserRoleSystem:
+		return nil, ErrPermissionDenied
+	case p.Role == domain.UserRoleSystem,
+		p.Role == domain.UserRoleAdmin && p.OrganizationID == u.OrganizationID && u.Role == domain.UserRoleUser:
+	default:
+		return nil, ErrPermissionDenied
+	}
+
+	a, err := r.authenticator.Impersonate(ctx, p, u, i.TTL, authentication.Client{
+		UserAgent: i.Client.UserAgent,
+		IPAddress: i.Client.IPAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to impersonate user: %w", err)
+	}
+
+	if err = r.auditRecorder.Record(ctx, &domain.AuditEvent{
+		OrganizationID: u.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionUserImpersonated,
+		Resource:       domain.SessionName(u.OrganizationID, u.ID, a.Session.ID),
+		IPAddress:      i.Client.IPAddress,
+		Details: map[string]string{
+			"reason":      i.Reason,
+			"expire_time": a.Session.ExpireTime.UTC().Format(time.RFC3339),
+		},
+	}); err != nil {
+		// an impersonation that is not in the audit log must not be usable.
+		if _, rErr := r.sessionWriter.Revoke(ctx, a.Session); rErr != nil {
+			return nil, errors.Join(fmt.Errorf("failed to record impersonation: %w", err), rErr)
+		}
+		return nil, fmt.Errorf("failed to record impersonation: %w", err)
+	}
+
+	return &LoginResult{
+		User:        a.User,
+		Session:     a.Session,
+		AccessToken: a.AccessToken,
+	}, nil
+}
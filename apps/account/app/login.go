@@ -15,32 +15,71 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 )
 
+// Client describes the device a request originates from.
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
 // LoginResult is the result of a login operation.
 type LoginResult struct {
 	User         *domain.User
+	Session      *domain.Session
 	AccessToken  string
 	RefreshToken string
 }
 
-// LoginUser logs in a user with the given email and password.
-func (r *App) LoginUser(ctx context.Context, email, password string) (*LoginResult, error) {
+// LoginUser logs in a user with the given email and password and starts a session for the client.
+func (r *App) LoginUser(ctx context.Context, email, password string, client Client) (*LoginResult, error) {
 	a, err := r.authenticator.Authenticate(ctx, authentication.Credentials{
 		Email:    email,
 		Password: []byte(password),
+	}, authentication.Client{
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
 	})
 	if err != nil {
 		switch err {
 		case authentication.ErrInvalidCredentials:
+			r.recordLoginFailure(ctx, email, "invalid_credentials")
 			return nil, ErrInvalidCredentials
 		case authentication.ErrUserNotFound:
+			r.recordLoginFailure(ctx, email, "user_not_found")
 			return nil, ErrUserNotFound
 		default:
 			return nil, err
 		}
 	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: a.User.OrganizationID,
+		Actor:          domain.UserName(a.User.OrganizationID, a.User.ID),
+		Action:         domain.AuditActionLoginSucceeded,
+		Resource:       domain.SessionName(a.User.OrganizationID, a.User.ID, a.Session.ID),
+		IPAddress:      client.IPAddress,
+		Details:        map[string]string{"user_agent": client.UserAgent},
+	})
+
 	return &LoginResult{
 		User:         a.User,
+		Session:      a.Session,
 		AccessToken:  a.AccessToken,
 		RefreshToken: a.RefreshToken,
 	}, nil
 }
+
+// recordLoginFailure records a failed login. When the email belongs to a user,
+// the event is attached to the user so admins of the organization can see it.
+func (r *App) recordLoginFailure(ctx context.Context, email, reason string) {
+	e := &domain.AuditEvent{
+		Action:  domain.AuditActionLoginFailed,
+		Details: map[string]string{"email": email, "reason": reason},
+	}
+
+	if u, err := r.userReader.GetByEmail(ctx, email); err == nil {
+		e.OrganizationID = u.OrganizationID
+		e.Resource = domain.UserName(u.OrganizationID, u.ID)
+	}
+
+	r.record(ctx, e)
+}
@@ -11,11 +11,19 @@ import (
 	"github.com/extreme-business/lingo/apps/account/auth/authentication"
 	"github.com/extreme-business/lingo/apps/account/auth/registration"
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
 	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
 	"github.com/extreme-business/lingo/apps/account/storage"
 	"github.com/extreme-business/lingo/apps/account/storage/postgres"
 	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
 	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 )
@@ -30,6 +38,35 @@ func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.Postgre
 	return dbc
 }
 
+// newEventFeed returns an event feed that is not notified about new events.
+func newEventFeed(t *testing.T, repos storage.Repositories) *outbox.Feed {
+	t.Helper()
+	f, err := outbox.NewFeed(outbox.FeedConfig{
+		Logger: slog.Default(),
+		Reader: repos.OutboxEvent,
+		Listen: func(ctx context.Context, _ func(string)) error {
+			<-ctx.Done()
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func newAuthenticator(repos storage.Repositories, clock func() time.Time) *authentication.Authenticator {
+	return authentication.New(authentication.Config{
+		Clock:                  clock,
+		GenUUID:                uuidgen.Default(),
+		SigningKeyAccessToken:  []byte("access"),
+		SigningKeyRefreshToken: []byte("refresh"),
+		UserReader:             user.NewReader(repos.User),
+		SessionReader:          session.NewReader(clock, repos.Session),
+		SessionWriter:          session.NewWriter(clock, repos.Session),
+	})
+}
+
 func TestApp_LoginUser(t *testing.T) {
 	dbc := setupTestDB(context.Background(), t, t.Name())
 	db := dbtest.Connect(context.Background(), t, dbc.ConnectionString)
@@ -62,21 +99,31 @@ func TestApp_LoginUser(t *testing.T) {
 
 	t.Run("should return the user if the credentials are valid", func(t *testing.T) {
 		now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
-		app, err := app.New(app.Config{
-			Logger: slog.Default(),
-			Authenticator: authentication.New(authentication.Config{
-				Clock:                  func() time.Time { return now },
-				SigningKeyAccessToken:  []byte("access"),
-				SigningKeyRefreshToken: []byte("refresh"),
-				UserReader:             user.NewReader(dbManager.Op().User),
-			}),
-			UserReader:          user.NewReader(dbManager.Op().User),
-			RegistrationManager: registration.NewManager(registration.Config{}),
+		a, err := app.New(app.Config{
+			Logger:                slog.Default(),
+			Authenticator:         newAuthenticator(dbManager.Op(), func() time.Time { return now }),
+			UserReader:            user.NewReader(dbManager.Op().User),
+			SessionReader:         session.NewReader(func() time.Time { return now }, dbManager.Op().Session),
+			SessionWriter:         session.NewWriter(func() time.Time { return now }, dbManager.Op().Session),
+			AuditReader:           audit.NewReader(dbManager.Op().AuditEvent),
+			AuditRecorder:         audit.NewRecorder(func() time.Time { return now }, dbManager),
+			WebhookReader:         webhook.NewReader(dbManager.Op().Webhook, dbManager.Op().WebhookDelivery),
+			WebhookWriter:         webhook.NewWriter(func() time.Time { return now }, uuid.New, dbManager.Op().Webhook),
+			WebhookDeliveryWriter: webhook.NewDeliveryWriter(func() time.Time { return now }, uuid.New, dbManager.Op().WebhookDelivery),
+			ConversationReader:    conversation.NewReader(dbManager.Op().Conversation, dbManager.Op().Participant, dbManager.Op().Message),
+			ConversationWriter:    conversation.NewWriter(func() time.Time { return now }, uuid.New, dbManager),
+			ChatHub:               conversation.NewHub(),
+			EventFeed:             newEventFeed(t, dbManager.Op()),
+			UserUpdater:           user.NewUpdater(nil, nil),
+			Translations:          &translation.Service{},
+			GlossaryReader:        glossary.NewReader(nil, nil, nil),
+			GlossaryWriter:        glossary.NewWriter(nil, nil, nil),
+			RegistrationManager:   registration.NewManager(registration.Config{}),
 		})
 		if err != nil {
 			t.Errorf("Expected no error, but got %v", err)
 		}
-		result, err := app.LoginUser(context.Background(), "buguser@test.com", "thisismypassword")
+		result, err := a.LoginUser(context.Background(), "buguser@test.com", "thisismypassword", app.Client{})
 		if err != nil {
 			t.Errorf("Expected no error, but got %v", err)
 		}
@@ -91,6 +138,7 @@ func TestApp_LoginUser(t *testing.T) {
 			DisplayName:    "biguser",
 			Email:          "buguser@test.com",
 			Status:         "active",
+			Role:           "user",
 			CreateTime:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			UpdateTime:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 		}
@@ -111,20 +159,30 @@ func TestApp_LoginUser(t *testing.T) {
 	t.Run("should return an error if the credentials are invalid", func(t *testing.T) {
 		now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 		a, err := app.New(app.Config{
-			Logger: slog.Default(),
-			Authenticator: authentication.New(authentication.Config{
-				Clock:                  func() time.Time { return now },
-				SigningKeyAccessToken:  []byte("access"),
-				SigningKeyRefreshToken: []byte("refresh"),
-				UserReader:             user.NewReader(dbManager.Op().User),
-			}),
-			UserReader:          user.NewReader(dbManager.Op().User),
-			RegistrationManager: registration.NewManager(registration.Config{}),
+			Logger:                slog.Default(),
+			Authenticator:         newAuthenticator(dbManager.Op(), func() time.Time { return now }),
+			UserReader:            user.NewReader(dbManager.Op().User),
+			SessionReader:         session.NewReader(func() time.Time { return now }, dbManager.Op().Session),
+			SessionWriter:         session.NewWriter(func() time.Time { return now }, dbManager.Op().Session),
+			AuditReader:           audit.NewReader(dbManager.Op().AuditEvent),
+			AuditRecorder:         audit.NewRecorder(func() time.Time { return now }, dbManager),
+			WebhookReader:         webhook.NewReader(dbManager.Op().Webhook, dbManager.Op().WebhookDelivery),
+			WebhookWriter:         webhook.NewWriter(func() time.Time { return now }, uuid.New, dbManager.Op().Webhook),
+			WebhookDeliveryWriter: webhook.NewDeliveryWriter(func() time.Time { return now }, uuid.New, dbManager.Op().WebhookDelivery),
+			ConversationReader:    conversation.NewReader(dbManager.Op().Conversation, dbManager.Op().Participant, dbManager.Op().Message),
+			ConversationWriter:    conversation.NewWriter(func() time.Time { return now }, uuid.New, dbManager),
+			ChatHub:               conversation.NewHub(),
+			EventFeed:             newEventFeed(t, dbManager.Op()),
+			UserUpdater:           user.NewUpdater(nil, nil),
+			Translations:          &translation.Service{},
+			GlossaryReader:        glossary.NewReader(nil, nil, nil),
+			GlossaryWriter:        glossary.NewWriter(nil, nil, nil),
+			RegistrationManager:   registration.NewManager(registration.Config{}),
 		})
 		if err != nil {
 			t.Errorf("Expected no error, but got %v", err)
 		}
-		if _, err = a.LoginUser(context.Background(), "buguser@test.com", "invalid"); err == nil {
+		if _, err = a.LoginUser(context.Background(), "buguser@test.com", "invalid", app.Client{}); err == nil {
 			t.Error("Expected an error, but got nil")
 		} else if !errors.Is(err, app.ErrInvalidCredentials) {
 			t.Errorf("Expected an ErrInvalidCredentials error, but got %v", err)
@@ -135,21 +193,31 @@ func TestApp_LoginUser(t *testing.T) {
 		now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 
 		a, err := app.New(app.Config{
-			Logger: slog.Default(),
-			Authenticator: authentication.New(authentication.Config{
-				Clock:                  func() time.Time { return now },
-				SigningKeyAccessToken:  []byte("access"),
-				SigningKeyRefreshToken: []byte("refresh"),
-				UserReader:             user.NewReader(dbManager.Op().User),
-			}),
-			UserReader:          user.NewReader(dbManager.Op().User),
-			RegistrationManager: registration.NewManager(registration.Config{}),
+			Logger:                slog.Default(),
+			Authenticator:         newAuthenticator(dbManager.Op(), func() time.Time { return now }),
+			UserReader:            user.NewReader(dbManager.Op().User),
+			SessionReader:         session.NewReader(func() time.Time { return now }, dbManager.Op().Session),
+			SessionWriter:         session.NewWriter(func() time.Time { return now }, dbManager.Op().Session),
+			AuditReader:           audit.NewReader(dbManager.Op().AuditEvent),
+			AuditRecorder:         audit.NewRecorder(func() time.Time { return now }, dbManager),
+			WebhookReader:         webhook.NewReader(dbManager.Op().Webhook, dbManager.Op().WebhookDelivery),
+			WebhookWriter:         webhook.NewWriter(func() time.Time { return now }, uuid.New, dbManager.Op().Webhook),
+			WebhookDeliveryWriter: webhook.NewDeliveryWriter(func() time.Time { return now }, uuid.New, dbManager.Op().WebhookDelivery),
+			ConversationReader:    conversation.NewReader(dbManager.Op().Conversation, dbManager.Op().Participant, dbManager.Op().Message),
+			ConversationWriter:    conversation.NewWriter(func() time.Time { return now }, uuid.New, dbManager),
+			ChatHub:               conversation.NewHub(),
+			EventFeed:             newEventFeed(t, dbManager.Op()),
+			UserUpdater:           user.NewUpdater(nil, nil),
+			Translations:          &translation.Service{},
+			GlossaryReader:        glossary.NewReader(nil, nil, nil),
+			GlossaryWriter:        glossary.NewWriter(nil, nil, nil),
+			RegistrationManager:   registration.NewManager(registration.Config{}),
 		})
 		if err != nil {
 			t.Errorf("Expected no error, but got %v", err)
 		}
 
-		if _, err = a.LoginUser(context.Background(), "invalid", "invalid"); err == nil {
+		if _, err = a.LoginUser(context.Background(), "invalid", "invalid", app.Client{}); err == nil {
 			t.Error("Expected an error, but got nil")
 			return
 		} else if !errors.Is(err, app.ErrUserNotFound) {
@@ -157,3 +225,109 @@ func TestApp_LoginUser(t *testing.T) {
 		}
 	})
 }
+
+func TestApp_RefreshToken(t *testing.T) {
+	dbc := setupTestDB(context.Background(), t, t.Name())
+	db := dbtest.Connect(context.Background(), t, dbc.ConnectionString)
+	dbManager := postgres.NewManager(db)
+
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization(
+				"0463e149-e143-4033-b617-7867824deb0d",
+				"bigorg",
+				"bigorg",
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			),
+		},
+		Users: []*storage.User{
+			seed.NewUser(
+				"d58c4b17-9a1c-4853-9bbf-9467df86307e",
+				"0463e149-e143-4033-b617-7867824deb0d",
+				"biguser",
+				"active",
+				"buguser@test.com",
+				"$2a$12$8QwkQCXa5omOq4KgNC5Wquv8eGikumiWyUdM0SShfQ/oSt6On0AUu", // thisismypassword
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Time{},
+			),
+		},
+	})
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	a, err := app.New(app.Config{
+		Logger:                slog.Default(),
+		Authenticator:         newAuthenticator(dbManager.Op(), clock),
+		UserReader:            user.NewReader(dbManager.Op().User),
+		SessionReader:         session.NewReader(clock, dbManager.Op().Session),
+		SessionWriter:         session.NewWriter(clock, dbManager.Op().Session),
+		AuditReader:           audit.NewReader(dbManager.Op().AuditEvent),
+		AuditRecorder:         audit.NewRecorder(clock, dbManager),
+		WebhookReader:         webhook.NewReader(dbManager.Op().Webhook, dbManager.Op().WebhookDelivery),
+		WebhookWriter:         webhook.NewWriter(clock, uuid.New, dbManager.Op().Webhook),
+		WebhookDeliveryWriter: webhook.NewDeliveryWriter(clock, uuid.New, dbManager.Op().WebhookDelivery),
+		ConversationReader:    conversation.NewReader(dbManager.Op().Conversation, dbManager.Op().Participant, dbManager.Op().Message),
+		ConversationWriter:    conversation.NewWriter(clock, uuid.New, dbManager),
+		ChatHub:               conversation.NewHub(),
+		EventFeed:             newEventFeed(t, dbManager.Op()),
+		UserUpdater:           user.NewUpdater(nil, nil),
+		Translations:          &translation.Service{},
+		GlossaryReader:        glossary.NewReader(nil, nil, nil),
+		GlossaryWriter:        glossary.NewWriter(nil, nil, nil),
+		RegistrationManager:   registration.NewManager(registration.Config{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("should rotate the refresh token", func(t *testing.T) {
+		login, err := a.LoginUser(context.Background(), "buguser@test.com", "thisismypassword", app.Client{UserAgent: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refreshed, err := a.RefreshToken(context.Background(), login.RefreshToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if refreshed.Session.ID != login.Session.ID {
+			t.Errorf("Expected session %s, but got %s", login.Session.ID, refreshed.Session.ID)
+		}
+
+		if refreshed.RefreshToken == login.RefreshToken {
+			t.Error("Expected a new refresh token")
+		}
+
+		if _, err = a.Authenticate(context.Background(), refreshed.AccessToken); err != nil {
+			t.Errorf("Expected the new access token to be valid, but got %v", err)
+		}
+	})
+
+	t.Run("should revoke the session if a refresh token is reused", func(t *testing.T) {
+		login, err := a.LoginUser(context.Background(), "buguser@test.com", "thisismypassword", app.Client{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refreshed, err := a.RefreshToken(context.Background(), login.RefreshToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = a.RefreshToken(context.Background(), login.RefreshToken); !errors.Is(err, app.ErrInvalidToken) {
+			t.Errorf("Expected an ErrInvalidToken error, but got %v", err)
+		}
+
+		if _, err = a.RefreshToken(context.Background(), refreshed.RefreshToken); !errors.Is(err, app.ErrInvalidToken) {
+			t.Errorf("Expected the session to be revoked, but got %v", err)
+		}
+
+		if _, err = a.Authenticate(context.Background(), refreshed.AccessToken); !errors.Is(err, app.ErrInvalidToken) {
+			t.Errorf("Expected the access token to be rejected, but got %v", err)
+		}
+	})
+}
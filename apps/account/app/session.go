@@ -0,0 +1,198 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed, expired or belongs to a revoked session.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrSessionNotFound is returned when the session is not found.
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// Authenticate validates an access token and returns the principal it was issued to.
+func (r *App) Authenticate(ctx context.Context, accessToken string) (*authentication.Principal, error) {
+	p, err := r.authenticator.Validate(ctx, accessToken)
+	if err != nil {
+		if errors.Is(err, authentication.ErrInvalidToken) || errors.Is(err, authentication.ErrSessionRevoked) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	return p, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token.
+func (r *App) RefreshToken(ctx context.Context, refreshToken string) (*LoginResult, error) {
+	a, err := r.authenticator.Refresh(ctx, refreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, authentication.ErrRefreshTokenReused):
+			r.logger.Warn("refresh token reused, session revoked")
+			var reused *authentication.RefreshTokenReusedError
+			if errors.As(err, &reused) {
+				r.recordRefreshTokenReuse(ctx, reused.Session)
+			}
+			return nil, ErrInvalidToken
+		case errors.Is(err, authentication.ErrInvalidToken),
+			errors.Is(err, authentication.ErrSessionRevoked),
+			errors.Is(err, authentication.ErrUserNotFound):
+			return nil, ErrInvalidToken
+		default:
+			return nil, fmt.Errorf("failed to refresh token: %w", err)
+		}
+	}
+	return &LoginResult{
+		User:         a.User,
+		Session:      a.Session,
+		AccessToken:  a.AccessToken,
+		RefreshToken: a.RefreshToken,
+	}, nil
+}
+
+// LogoutUser revokes the session of the principal.
+func (r *App) LogoutUser(ctx context.Context, p *authentication.Principal) error {
+	s, err := r.sessionReader.Get(ctx, p.SessionID)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if _, err = r.sessionWriter.Revoke(ctx, s); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: p.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionSessionRevoked,
+		Resource:       domain.SessionName(p.OrganizationID, p.UserID, s.ID),
+		Details:        map[string]string{"reason": "logout"},
+	})
+
+	return nil
+}
+
+// ListSessions lists the active sessions of a user.
+func (r *App) ListSessions(ctx context.Context, p *authentication.Principal, organizationID, userID uuid.UUID) ([]*domain.Session, error) {
+	if _, err := r.authorizeUser(ctx, p, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := r.sessionReader.ListActive(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes a single session of a user.
+func (r *App) RevokeSession(ctx context.Context, p *authentication.Principal, organizationID, userID, sessionID uuid.UUID) error {
+	if _, err := r.authorizeUser(ctx, p, organizationID, userID); err != nil {
+		return err
+	}
+
+	s, err := r.sessionReader.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if s.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	if _, err = r.sessionWriter.Revoke(ctx, s); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: organizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionSessionRevoked,
+		Resource:       domain.SessionName(organizationID, userID, s.ID),
+	})
+
+	return nil
+}
+
+// RevokeAllSessions revokes all active sessions of a user and returns how many were revoked.
+func (r *App) RevokeAllSessions(ctx context.Context, p *authentication.Principal, organizationID, userID uuid.UUID) (int, error) {
+	if _, err := r.authorizeUser(ctx, p, organizationID, userID); err != nil {
+		return 0, err
+	}
+
+	n, err := r.sessionWriter.RevokeAll(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: organizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionSessionRevoked,
+		Resource:       domain.UserName(organizationID, userID),
+		Details:        map[string]string{"count": strconv.Itoa(n)},
+	})
+
+	return n, nil
+}
+
+// recordRefreshTokenReuse records that a session was revoked because one of its refresh tokens was reused.
+func (r *App) recordRefreshTokenReuse(ctx context.Context, s *domain.Session) {
+	e := &domain.AuditEvent{
+		Actor:  domain.SystemActor,
+		Action: domain.AuditActionRefreshTokenReused,
+	}
+
+	if u, err := r.userReader.Get(ctx, s.UserID); err == nil {
+		e.OrganizationID = u.OrganizationID
+		e.Resource = domain.SessionName(u.OrganizationID, u.ID, s.ID)
+	}
+
+	r.record(ctx, e)
+}
+
+// authorizeUser checks whether the principal may manage the user and returns the user.
+// Users may manage themselves, admins the users of their organization and the system user everyone.
+func (r *App) authorizeUser(ctx context.Context, p *authentication.Principal, organizationID, userID uuid.UUID) (*domain.User, error) {
+	if p == nil {
+		return nil, ErrPermissionDenied
+	}
+
+	u, err := r.userReader.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if u.OrganizationID != organizationID {
+		return nil, ErrUserNotFound
+	}
+
+	switch {
+	case p.UserID == u.ID,
+		p.Role == domain.UserRoleSystem,
+		p.Role == domain.UserRoleAdmin && p.OrganizationID == u.OrganizationID:
+		return u, nil
+	default:
+		return nil, ErrPermissionDenied
+	}
+}
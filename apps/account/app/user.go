@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+// UserUpdate is a change to the preferences of a user. Nil fields are left unchanged.
+type UserUpdate struct {
+	OrganizationID    uuid.UUID
+	UserID            uuid.UUID
+	PreferredLanguage *string // PreferredLanguage is a BCP 47 language tag, empty clears it.
+}
+
+// UpdateUser changes the preferences of a user. Users may update themselves, admins the users of their organization.
+func (r *App) UpdateUser(ctx context.Context, p *authentication.Principal, u UserUpdate) (*domain.User, error) {
+	in, err := r.authorizeUser(ctx, p, u.OrganizationID, u.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []storage.UserField
+	if u.PreferredLanguage != nil {
+		if in.PreferredLanguage, err = translation.ParseLanguage("preferred_language", *u.PreferredLanguage); err != nil {
+			return nil, err
+		}
+		fields = append(fields, storage.UserPreferredLanguage)
+	}
+
+	if len(fields) == 0 {
+		return in, nil
+	}
+
+	out, err := r.userUpdater.Update(ctx, in, fields)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	changed := make([]string, 0, len(fields))
+	for _, f := range fields {
+		changed = append(changed, string(f))
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: out.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionUserUpdated,
+		Resource:       domain.UserName(out.OrganizationID, out.ID),
+		Details:        map[string]string{"fields": strings.Join(changed, ",")},
+	})
+
+	return out, nil
+}
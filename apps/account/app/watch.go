@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrWatchLagged is returned when a watcher does not keep up with the changes.
+	// It can resume after the last change it received.
+	ErrWatchLagged = errors.New("watcher fell behind")
+	// ErrWatchStopped is returned when the server stops. The watcher can resume on another server.
+	ErrWatchStopped = errors.New("watch stopped")
+	// ErrResumeTokenExpired is returned when the changes after a resume token were purged.
+	// The watcher has to start over with a snapshot.
+	ErrResumeTokenExpired = errors.New("resume token expired")
+)
+
+// watchBufferSize is the number of changes a watcher can fall behind before it is stopped.
+const watchBufferSize = 256
+
+// UserChangeType is the type of a UserChange.
+type UserChangeType string
+
+const (
+	UserChangeSnapshot    UserChangeType = "snapshot"     // the user existed when the watch started.
+	UserChangeSnapshotEnd UserChangeType = "snapshot_end" // all users of the snapshot are sent, carries no user.
+	UserChangeCreated     UserChangeType = "created"
+	UserChangeUpdated     UserChangeType = "updated"
+	UserChangeDeleted     UserChangeType = "deleted" // the user is its last state.
+)
+
+// UserChange is a change to a user of a watched organization.
+// A watch can resume after any change with a resume token, the users of the snapshot have none.
+// The resume token is the position of the event of the change, see storage.OutboxEvent.
+type UserChange struct {
+	Type        UserChangeType
+	User        *domain.User
+	ResumeToken int64
+}
+
+// WatchUsers sends the users of an organization and then every change to them until the context
+// is canceled or send fails. Without a resume token the watch starts with a snapshot of the current
+// users, with a resume token it replays the changes after it instead. It fails with ErrResumeTokenExpired
+// when those changes were purged.
+func (r *App) WatchUsers(ctx context.Context, p *authentication.Principal, organizationID uuid.UUID, resumeToken int64, send func(UserChange) error) error {
+	if err := authorizeOrganizationMember(p, organizationID); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-r.eventFeed.Done():
+		return ErrWatchStopped
+	case <-r.eventFeed.Ready():
+	}
+
+	// subscribe before reading, so no change is missed between reading and following the feed.
+	changes := make(chan outbox.Message, watchBufferSize)
+	lagged := make(chan struct{})
+	var lagOnce sync.Once
+	unsubscribe := r.eventFeed.Subscribe(func(_ context.Context, m outbox.Message) error {
+		if m.AggregateType != domain.UserAggregate {
+			return nil
+		}
+
+		select {
+		case changes <- m:
+		default:
+			lagOnce.Do(func() { close(lagged) })
+		}
+		return nil
+	})
+	defer unsubscribe()
+
+	if resumeToken != 0 {
+		oldest, err := r.eventFeed.Oldest(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the oldest event: %w", err)
+		}
+
+		// the events after the oldest one are all kept, so only events up to it can be missing. A gap in
+		// the positions right before it makes the token look expired, the watcher then starts over.
+		if oldest > resumeToken+1 {
+			return ErrResumeTokenExpired
+		}
+	}
+
+	after := resumeToken
+	if after == 0 {
+		latest, err := r.eventFeed.Latest(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the latest event: %w", err)
+		}
+
+		if err = r.sendUserSnapshot(ctx, organizationID, send); err != nil {
+			return err
+		}
+
+		if err = send(UserChange{Type: UserChangeSnapshotEnd, ResumeToken: latest}); err != nil {
+			return err
+		}
+		after = latest
+	}
+
+	// the feed and the replay both deliver the changes in position order without gaps, so a change
+	// at or before the last one sent was already sent by the replay.
+	if err := r.eventFeed.Replay(ctx, after, domain.UserAggregate, func(m outbox.Message) error {
+		after = m.Position
+		return sendUserChange(organizationID, m, send)
+	}); err != nil {
+		return fmt.Errorf("failed to replay user changes: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.eventFeed.Done():
+			return ErrWatchStopped
+		case <-lagged:
+			return ErrWatchLagged
+		case m := <-changes:
+			if m.Position <= after {
+				continue
+			}
+			after = m.Position
+
+			if err := sendUserChange(organizationID, m, send); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendUserSnapshot sends the current users of the organization.
+func (r *App) sendUserSnapshot(ctx context.Context, organizationID uuid.UUID, send func(UserChange) error) error {
+	for page := uint(0); ; page++ {
+		users, err := r.userReader.ListByOrganization(ctx, organizationID, page)
+		if err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+
+		if len(users) == 0 {
+			return nil
+		}
+
+		for _, u := range users {
+			if err = send(UserChange{Type: UserChangeSnapshot, User: u}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendUserChange sends the change of a user event, if the user belongs to the organization.
+func sendUserChange(organizationID uuid.UUID, m outbox.Message, send func(UserChange) error) error {
+	e, err := m.Decode()
+	if err != nil {
+		return err
+	}
+
+	c := UserChange{ResumeToken: m.Position}
+	switch e := e.(type) {
+	case domain.UserCreated:
+		c.Type, c.User = UserChangeCreated, e.User.User()
+	case domain.UserUpdated:
+		c.Type, c.User = UserChangeUpdated, e.User.User()
+	case domain.UserDeleted:
+		c.Type, c.User = UserChangeDeleted, e.User.User()
+	default:
+		return nil
+	}
+
+	if c.User.OrganizationID != organizationID {
+		return nil
+	}
+
+	return send(c)
+}
+
+// authorizeOrganizationMember checks whether the principal belongs to the organization or is the system user.
+func authorizeOrganizationMember(p *authentication.Principal, organizationID uuid.UUID) error {
+	switch {
+	case p == nil:
+		return ErrPermissionDenied
+	case p.Role == domain.UserRoleSystem,
+		p.OrganizationID == organizationID:
+		return nil
+	default:
+		return ErrPermissionDenied
+	}
+}
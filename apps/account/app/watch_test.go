@@ -0,0 +1,352 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/auth/registration"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	jobMock "github.com/extreme-business/lingo/apps/account/storage/mock/job"
+	outboxMock "github.com/extreme-business/lingo/apps/account/storage/mock/outbox"
+	userMock "github.com/extreme-business/lingo/apps/account/storage/mock/user"
+)
+
+var (
+	watchedOrg = uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	otherOrg   = uuid.MustParse("b9a6a3b4-3c1d-4a50-8a4e-64b8e0d7e0a1")
+	alice      = domain.UserState{ID: uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770"), OrganizationID: watchedOrg, DisplayName: "alice"}
+	bob        = domain.UserState{ID: uuid.MustParse("a3c8e7a1-4b4e-4f4b-9c1f-6c9f3f1e2d7b"), OrganizationID: otherOrg, DisplayName: "bob"}
+)
+
+// eventStore keeps outbox events in memory and notifies the feed about new events.
+// Events are only visible once they are committed, which gives them their position.
+type eventStore struct {
+	mu            sync.Mutex
+	events        []*storage.OutboxEvent
+	position      int64
+	notifications chan string
+}
+
+func (s *eventStore) repository() *outboxMock.Repository {
+	return &outboxMock.Repository{
+		CreateFunc: func(_ context.Context, e *storage.OutboxEvent) (*storage.OutboxEvent, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			e.ID = int64(len(s.events) + 1)
+			s.events = append(s.events, e)
+			return e, nil
+		},
+		ListFunc: func(_ context.Context, p storage.Pagination, o storage.OutboxEventOrderBy, conditions ...storage.Condition) ([]*storage.OutboxEvent, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			var out []*storage.OutboxEvent
+			for _, e := range s.events {
+				match := e.Position > 0
+				for _, c := range conditions {
+					switch c := c.(type) {
+					case storage.OutboxEventAfterPositionCondition:
+						match = match && e.Position > c.Position
+					case storage.OutboxEventByAggregateTypeCondition:
+						match = match && e.AggregateType == c.AggregateType
+					}
+				}
+				if match {
+					out = append(out, e)
+				}
+			}
+
+			slices.SortFunc(out, func(a, b *storage.OutboxEvent) int { return int(a.Position - b.Position) })
+			if len(o) > 0 && o[0].Direction == storage.DESC {
+				slices.Reverse(out)
+			}
+
+			return out[:min(len(out), p.Limit)], nil
+		},
+	}
+}
+
+// begin stores an event without committing it and returns a function that commits it.
+func (s *eventStore) begin(t *testing.T, event domain.Event) (commit func()) {
+	t.Helper()
+	if err := outbox.NewWriter(time.Now, s.repository(), &jobMock.Enqueuer{
+		EnqueueFunc: func(context.Context, jobs.Args, ...jobs.EnqueueOption) (bool, error) { return true, nil },
+	}).Add(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	s.mu.Lock()
+	e := s.events[len(s.events)-1]
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		s.position++
+		e.Position = s.position
+		s.mu.Unlock()
+		s.notifications <- strconv.FormatInt(e.Position, 10)
+	}
+}
+
+// purge deletes the events up to the position, as storage.OutboxEventWriter.Purge does.
+func (s *eventStore) purge(position int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = slices.DeleteFunc(s.events, func(e *storage.OutboxEvent) bool { return e.Position <= position })
+}
+
+// add stores and commits the events.
+func (s *eventStore) add(t *testing.T, events ...domain.Event) {
+	t.Helper()
+	for _, e := range events {
+		s.begin(t, e)()
+	}
+}
+
+func newWatchApp(t *testing.T, ctx context.Context, store *eventStore) *app.App {
+	t.Helper()
+
+	feed, err := outbox.NewFeed(outbox.FeedConfig{
+		Logger: slog.Default(),
+		Reader: store.repository(),
+		Listen: func(ctx context.Context, notify func(string)) error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case n := <-store.notifications:
+					notify(n)
+				}
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = feed.Run(ctx) }()
+
+	users := &userMock.Repository{
+		ListFunc: func(_ context.Context, p storage.Pagination, _ storage.UserOrderBy, _ ...storage.Condition) ([]*storage.User, error) {
+			if p.Offset > 0 {
+				return nil, nil
+			}
+			return []*storage.User{{ID: alice.ID, OrganizationID: watchedOrg, DisplayName: alice.DisplayName}}, nil
+		},
+	}
+
+	a, err := app.New(app.Config{
+		Logger:                slog.Default(),
+		UserReader:            user.NewReader(users),
+		SessionReader:         session.NewReader(nil, nil),
+		SessionWriter:         session.NewWriter(nil, nil),
+		AuditReader:           audit.NewReader(nil),
+		AuditRecorder:         audit.NewRecorder(nil, nil),
+		WebhookReader:         webhook.NewReader(nil, nil),
+		WebhookWriter:         webhook.NewWriter(nil, nil, nil),
+		WebhookDeliveryWriter: webhook.NewDeliveryWriter(nil, nil, nil),
+		ConversationReader:    conversation.NewReader(nil, nil, nil),
+		ConversationWriter:    conversation.NewWriter(nil, nil, nil),
+		ChatHub:               conversation.NewHub(),
+		EventFeed:             feed,
+		UserUpdater:           user.NewUpdater(nil, nil),
+		Translations:          &translation.Service{},
+		GlossaryReader:        glossary.NewReader(nil, nil, nil),
+		GlossaryWriter:        glossary.NewWriter(nil, nil, nil),
+		Authenticator:         authentication.New(authentication.Config{}),
+		RegistrationManager:   registration.NewManager(registration.Config{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return a
+}
+
+// watch starts watching the users of the watched organization and returns the received changes.
+func watch(ctx context.Context, a *app.App, resumeToken int64) (<-chan app.UserChange, <-chan error) {
+	changes := make(chan app.UserChange, 16)
+	errs := make(chan error, 1)
+	p := &authentication.Principal{OrganizationID: watchedOrg, Role: domain.UserRoleUser}
+	go func() {
+		errs <- a.WatchUsers(ctx, p, watchedOrg, resumeToken, func(c app.UserChange) error {
+			changes <- c
+			return nil
+		})
+	}()
+	return changes, errs
+}
+
+// change is a UserChange that is easy to compare.
+type change struct {
+	Type        app.UserChangeType
+	DisplayName string
+	ResumeToken int64
+}
+
+func receive(t *testing.T, changes <-chan app.UserChange, n int) []change {
+	t.Helper()
+	var out []change
+	for range n {
+		select {
+		case c := <-changes:
+			var name string
+			if c.User != nil {
+				name = c.User.DisplayName
+			}
+			out = append(out, change{Type: c.Type, DisplayName: name, ResumeToken: c.ResumeToken})
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d changes, got %d", n, len(out))
+		}
+	}
+	return out
+}
+
+func TestApp_WatchUsers(t *testing.T) {
+	t.Run("should send a snapshot and then the changes of the organization", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		store := &eventStore{notifications: make(chan string, 16)}
+		store.add(t, domain.UserCreated{User: alice})
+		a := newWatchApp(t, ctx, store)
+
+		changes, errs := watch(ctx, a, 0)
+		got := receive(t, changes, 2)
+		store.add(t, domain.UserUpdated{User: bob}, domain.UserDeleted{User: alice})
+		got = append(got, receive(t, changes, 1)...)
+
+		want := []change{
+			{Type: app.UserChangeSnapshot, DisplayName: "alice"},
+			{Type: app.UserChangeSnapshotEnd, ResumeToken: 1},
+			{Type: app.UserChangeDeleted, DisplayName: "alice", ResumeToken: 3},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("WatchUsers() mismatch (-want +got):\n%s", diff)
+		}
+
+		cancel()
+		if err := <-errs; err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should resume after a token without a snapshot", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		store := &eventStore{notifications: make(chan string, 16)}
+		a := newWatchApp(t, ctx, store)
+		store.add(t, domain.UserCreated{User: alice}, domain.UserUpdated{User: alice}, domain.UserCreated{User: bob})
+
+		changes, _ := watch(ctx, a, 1)
+		store.add(t, domain.UserDeleted{User: alice})
+
+		want := []change{
+			{Type: app.UserChangeUpdated, DisplayName: "alice", ResumeToken: 2},
+			{Type: app.UserChangeDeleted, DisplayName: "alice", ResumeToken: 4},
+		}
+		if diff := cmp.Diff(want, receive(t, changes, 2)); diff != "" {
+			t.Errorf("WatchUsers() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should fail when the changes after the resume token were purged", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		store := &eventStore{notifications: make(chan string, 16)}
+		a := newWatchApp(t, ctx, store)
+		store.add(t, domain.UserCreated{User: alice}, domain.UserUpdated{User: alice}, domain.UserUpdated{User: alice})
+		store.purge(2)
+
+		_, errs := watch(ctx, a, 1)
+		if err := <-errs; !errors.Is(err, app.ErrResumeTokenExpired) {
+			t.Errorf("expected %v, got %v", app.ErrResumeTokenExpired, err)
+		}
+
+		changes, _ := watch(ctx, a, 2)
+		want := []change{{Type: app.UserChangeUpdated, DisplayName: "alice", ResumeToken: 3}}
+		if diff := cmp.Diff(want, receive(t, changes, 1)); diff != "" {
+			t.Errorf("WatchUsers() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should send a change that commits after a later change", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		store := &eventStore{notifications: make(chan string, 16)}
+		a := newWatchApp(t, ctx, store)
+
+		changes, _ := watch(ctx, a, 0)
+		receive(t, changes, 2) // the snapshot.
+
+		// the update takes the lower id, but commits last.
+		commit := store.begin(t, domain.UserUpdated{User: alice})
+		store.add(t, domain.UserCreated{User: domain.UserState{ID: uuid.New(), OrganizationID: watchedOrg, DisplayName: "carol"}})
+		commit()
+
+		want := []change{
+			{Type: app.UserChangeCreated, DisplayName: "carol", ResumeToken: 1},
+			{Type: app.UserChangeUpdated, DisplayName: "alice", ResumeToken: 2},
+		}
+		if diff := cmp.Diff(want, receive(t, changes, 2)); diff != "" {
+			t.Errorf("WatchUsers() mismatch (-want +got):\n%s", diff)
+		}
+
+		resumed, _ := watch(ctx, a, 1)
+		if diff := cmp.Diff(want[1:], receive(t, resumed, 1)); diff != "" {
+			t.Errorf("WatchUsers() after resume mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should stop when the feed stops", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		store := &eventStore{notifications: make(chan string, 16)}
+		a := newWatchApp(t, ctx, store)
+
+		_, errs := watch(context.Background(), a, 1)
+		cancel()
+
+		select {
+		case err := <-errs:
+			if !errors.Is(err, app.ErrWatchStopped) {
+				t.Errorf("expected %v, got %v", app.ErrWatchStopped, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the watch to stop")
+		}
+	})
+
+	t.Run("should not allow other organizations", func(t *testing.T) {
+		store := &eventStore{notifications: make(chan string, 16)}
+		a := newWatchApp(t, context.Background(), store)
+
+		p := &authentication.Principal{OrganizationID: otherOrg, Role: domain.UserRoleAdmin}
+		err := a.WatchUsers(context.Background(), p, watchedOrg, 0, func(app.UserChange) error { return nil })
+		if !errors.Is(err, app.ErrPermissionDenied) {
+			t.Errorf("expected %v, got %v", app.ErrPermissionDenied, err)
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrWebhookNotFound is returned when the webhook is not found.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when the webhook delivery is not found.
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrWebhookDeliveryPending is returned when an event is redelivered while it is still being delivered.
+	ErrWebhookDeliveryPending = errors.New("webhook delivery is already pending")
+)
+
+const (
+	defaultDeliveryPageSize = 50
+	maxDeliveryPageSize     = 500
+)
+
+// WebhookUpdate is a change to a webhook. Nil fields are left unchanged.
+type WebhookUpdate struct {
+	OrganizationID uuid.UUID
+	WebhookID      uuid.UUID
+	URL            *string
+	EventTypes     []domain.EventType
+	Disabled       *bool // Disabled stops or resumes deliveries, resuming also resets the failures.
+}
+
+// CreateWebhook subscribes a URL to events of an organization. The returned webhook contains the secret.
+// Only admins of the organization and the system user may manage webhooks.
+func (r *App) CreateWebhook(ctx context.Context, p *authentication.Principal, w *domain.Webhook) (*domain.Webhook, error) {
+	if err := authorizeOrganizationAdmin(p, w.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if err := webhook.Validate(w); err != nil {
+		return nil, err
+	}
+
+	w, err := r.webhookWriter.Create(ctx, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: w.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionWebhookCreated,
+		Resource:       domain.WebhookName(w.OrganizationID, w.ID),
+		Details:        map[string]string{"url": w.URL},
+	})
+
+	return w, nil
+}
+
+// ListWebhooks lists the webhooks of an organization.
+func (r *App) ListWebhooks(ctx context.Context, p *authentication.Principal, organizationID uuid.UUID) ([]*domain.Webhook, error) {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := r.webhookReader.List(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhook gets a webhook of an organization.
+func (r *App) GetWebhook(ctx context.Context, p *authentication.Principal, organizationID, webhookID uuid.UUID) (*domain.Webhook, error) {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	return r.getWebhook(ctx, organizationID, webhookID)
+}
+
+// UpdateWebhook changes the URL, event types or state of a webhook.
+func (r *App) UpdateWebhook(ctx context.Context, p *authentication.Principal, u WebhookUpdate) (*domain.Webhook, error) {
+	if err := authorizeOrganizationAdmin(p, u.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	w, err := r.getWebhook(ctx, u.OrganizationID, u.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []storage.WebhookField
+	if u.URL != nil {
+		w.URL = *u.URL
+		fields = append(fields, storage.WebhookURL)
+	}
+	if u.EventTypes != nil {
+		w.EventTypes = u.EventTypes
+		fields = append(fields, storage.WebhookEventTypes)
+	}
+	if u.Disabled != nil && *u.Disabled != w.Disabled() {
+		fields = append(fields, r.webhookWriter.SetDisabled(w, *u.Disabled)...)
+	}
+
+	if err = webhook.Validate(w); err != nil {
+		return nil, err
+	}
+
+	if w, err = r.webhookWriter.Update(ctx, w, fields); err != nil {
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	changed := make([]string, 0, len(fields))
+	for _, f := range fields {
+		changed = append(changed, string(f))
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: w.OrganizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionWebhookUpdated,
+		Resource:       domain.WebhookName(w.OrganizationID, w.ID),
+		Details:        map[string]string{"fields": strings.Join(changed, ",")},
+	})
+
+	return w, nil
+}
+
+// DeleteWebhook deletes a webhook together with its deliveries.
+func (r *App) DeleteWebhook(ctx context.Context, p *authentication.Principal, organizationID, webhookID uuid.UUID) error {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return err
+	}
+
+	if _, err := r.getWebhook(ctx, organizationID, webhookID); err != nil {
+		return err
+	}
+
+	if err := r.webhookWriter.Delete(ctx, webhookID); err != nil {
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			return ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	r.record(ctx, &domain.AuditEvent{
+		OrganizationID: organizationID,
+		Actor:          actorName(p),
+		Action:         domain.AuditActionWebhookDeleted,
+		Resource:       domain.WebhookName(organizationID, webhookID),
+	})
+
+	return nil
+}
+
+// ListWebhookDeliveries lists the delivery attempts of a webhook, newest first.
+// It returns the offset of the next page, 0 when there is none.
+func (r *App) ListWebhookDeliveries(ctx context.Context, p *authentication.Principal, organizationID, webhookID uuid.UUID, pageSize, offset int) ([]*domain.WebhookDelivery, int, error) {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := r.getWebhook(ctx, organizationID, webhookID); err != nil {
+		return nil, 0, err
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultDeliveryPageSize
+	}
+	pageSize = min(pageSize, maxDeliveryPageSize)
+
+	deliveries, err := r.webhookReader.ListDeliveries(ctx, webhookID, storage.Pagination{Limit: pageSize, Offset: offset})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	var next int
+	if len(deliveries) == pageSize {
+		next = offset + pageSize
+	}
+
+	return deliveries, next, nil
+}
+
+// RedeliverWebhookDelivery schedules the event of a delivery to be delivered again right away.
+// Redelivering to a disabled webhook fails the delivery until the webhook is enabled again.
+func (r *App) RedeliverWebhookDelivery(ctx context.Context, p *authentication.Principal, organizationID, webhookID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	if err := authorizeOrganizationAdmin(p, organizationID); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.getWebhook(ctx, organizationID, webhookID); err != nil {
+		return nil, err
+	}
+
+	d, err := r.webhookReader.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, webhook.ErrDeliveryNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if d.WebhookID != webhookID {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	d, err = r.webhookDeliveryWriter.Redeliver(ctx, d)
+	if err != nil {
+		if errors.Is(err, webhook.ErrDeliveryPending) {
+			return nil, ErrWebhookDeliveryPending
+		}
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+
+	return d, nil
+}
+
+// getWebhook gets a webhook and checks that it belongs to the organization.
+func (r *App) getWebhook(ctx context.Context, organizationID, webhookID uuid.UUID) (*domain.Webhook, error) {
+	w, err := r.webhookReader.Get(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	if w.OrganizationID != organizationID {
+		return nil, ErrWebhookNotFound
+	}
+
+	return w, nil
+}
+
+// authorizeOrganizationAdmin checks whether the principal is an admin of the organization or the system user.
+func authorizeOrganizationAdmin(p *authentication.Principal, organizationID uuid.UUID) error {
+	switch {
+	case p == nil:
+		return ErrPermissionDenied
+	case p.Role == domain.UserRoleSystem,
+		p.Role == domain.UserRoleAdmin && p.OrganizationID == organizationID:
+		return nil
+	default:
+		return ErrPermissionDenied
+	}
+}
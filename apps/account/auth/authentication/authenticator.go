@@ -7,14 +7,20 @@ import (
 	"time"
 
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
 	"github.com/extreme-business/lingo/apps/account/domain/user"
 	"github.com/extreme-business/lingo/apps/account/password"
+	"github.com/extreme-business/lingo/pkg/database"
 	"github.com/extreme-business/lingo/pkg/token"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+	"github.com/google/uuid"
 )
 
 const (
 	accountTokenDuration = 5 * time.Minute
 	refreshTokenDuration = 30 * time.Minute
+	// ImpersonationDuration is the longest an impersonation may last.
+	ImpersonationDuration = 15 * time.Minute
 )
 
 // Error type allows the linter to force the user to check all errors.
@@ -23,27 +29,56 @@ type Error error
 var (
 	ErrUserNotFound       Error = errors.New("user not found")
 	ErrInvalidCredentials Error = errors.New("invalid credentials")
+	ErrInvalidToken       Error = errors.New("invalid token")
+	ErrSessionRevoked     Error = errors.New("session is revoked or expired")
+	// ErrRefreshTokenReused is returned when a refresh token is used that was already exchanged.
+	// The session is revoked when this happens, since the token may have been stolen.
+	ErrRefreshTokenReused Error = errors.New("refresh token reused")
 )
 
+// RefreshTokenReusedError is returned together with ErrRefreshTokenReused and contains the revoked session.
+type RefreshTokenReusedError struct {
+	Session *domain.Session
+}
+
+func (e *RefreshTokenReusedError) Error() string {
+	return fmt.Sprintf("session %s: %s", e.Session.ID, ErrRefreshTokenReused)
+}
+
+func (e *RefreshTokenReusedError) Unwrap() error { return ErrRefreshTokenReused }
+
 // Authenticator is responsible for authenticating users.
 type Authenticator struct {
+	clock                func() time.Time
+	genUUID              uuidgen.Generator
 	credentialsValidator *credentialsValidator
 	userReader           *user.Reader
+	sessionReader        *session.Reader
+	sessionWriter        *session.Writer
 	AccessTokenManager   *token.Manager
 	RefreshTokenManager  *token.Manager
+	// impersonationTokenManager signs with the access token key, so its tokens are accepted as access tokens.
+	impersonationTokenManager *token.Manager
 }
 
 type Config struct {
 	Clock                  func() time.Time
+	GenUUID                uuidgen.Generator
 	SigningKeyAccessToken  []byte
 	SigningKeyRefreshToken []byte
 	UserReader             *user.Reader
+	SessionReader          *session.Reader
+	SessionWriter          *session.Writer
 }
 
 func New(c Config) *Authenticator {
 	return &Authenticator{
+		clock:                c.Clock,
+		genUUID:              c.GenUUID,
 		credentialsValidator: newCredentialsValidator(),
 		userReader:           c.UserReader,
+		sessionReader:        c.SessionReader,
+		sessionWriter:        c.SessionWriter,
 		AccessTokenManager: token.NewManager(
 			c.Clock,
 			c.SigningKeyAccessToken,
@@ -54,6 +89,11 @@ func New(c Config) *Authenticator {
 			c.SigningKeyRefreshToken,
 			refreshTokenDuration,
 		),
+		impersonationTokenManager: token.NewManager(
+			c.Clock,
+			c.SigningKeyAccessToken,
+			ImpersonationDuration,
+		),
 	}
 }
 
@@ -62,15 +102,22 @@ type Credentials struct {
 	Password []byte
 }
 
+// Client describes the device a user authenticates from.
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
 // Authentication is the process of verifying whether someone is who they claim to be when accessing a system.
 type Authentication struct {
 	User         *domain.User
+	Session      *domain.Session
 	AccessToken  string
 	RefreshToken string
 }
 
-// Authenticate a user with the given credentials.
-func (m *Authenticator) Authenticate(ctx context.Context, c Credentials) (*Authentication, error) {
+// Authenticate a user with the given credentials and start a new session for the client.
+func (m *Authenticator) Authenticate(ctx context.Context, c Credentials, client Client) (*Authentication, error) {
 	if err := m.credentialsValidator.Validate(c); err != nil {
 		return nil, fmt.Errorf("could not validate: %w", err)
 	}
@@ -90,21 +137,206 @@ func (m *Authenticator) Authenticate(ctx context.Context, c Credentials) (*Authe
 		return nil, fmt.Errorf("failed to check password: %w", err)
 	}
 
-	accountToken, err := m.AccessTokenManager.Create(u.ID.String())
+	id := m.genUUID()
+	s, err := m.sessionWriter.Create(ctx, &domain.Session{
+		ID:             id,
+		UserID:         u.ID,
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IPAddress,
+		RefreshFamily:  id,
+		RefreshTokenID: m.genUUID(),
+		ExpireTime:     m.clock().Add(refreshTokenDuration),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create account token: %w", err)
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	refreshToken, err := m.RefreshTokenManager.Create(u.ID.String())
+	u.HashedPassword = ""
+
+	return m.issue(u, s)
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token.
+// The exchanged refresh token can not be used again; if it is, the whole session is revoked.
+func (m *Authenticator) Refresh(ctx context.Context, refreshToken string) (*Authentication, error) {
+	claims, err := m.RefreshTokenManager.Validate(refreshToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+		return nil, ErrInvalidToken
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// a replica may not have seen the latest rotation or revocation of the session yet.
+	s, err := m.sessionReader.Get(database.NewPrimaryContext(ctx), sessionID)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return nil, ErrSessionRevoked
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	now := m.clock()
+	if !s.Active(now) {
+		return nil, ErrSessionRevoked
+	}
+
+	// impersonations can not be extended, no refresh token is issued for them.
+	if s.Impersonated() {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Sub != s.UserID.String() || claims.Family != s.RefreshFamily.String() {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.TokenID != s.RefreshTokenID.String() {
+		return nil, m.revokeReused(ctx, s)
+	}
+
+	u, err := m.userReader.Get(ctx, s.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	rotated := *s
+	rotated.RefreshTokenID = m.genUUID()
+	rotated.LastRefreshTime = now
+	rotated.ExpireTime = now.Add(refreshTokenDuration)
+	if s, err = m.sessionWriter.RotateRefreshToken(ctx, &rotated, s.RefreshTokenID); err != nil {
+		// another refresh with the same token rotated it first, so the token was used twice.
+		if errors.Is(err, session.ErrStaleRefreshToken) {
+			return nil, m.revokeReused(ctx, &rotated)
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
 	}
 
 	u.HashedPassword = ""
 
+	return m.issue(u, s)
+}
+
+// revokeReused revokes the session of a refresh token that was used more than once and returns the
+// RefreshTokenReusedError for it.
+func (m *Authenticator) revokeReused(ctx context.Context, s *domain.Session) error {
+	s, err := m.sessionWriter.Revoke(ctx, s)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return ErrSessionRevoked
+		}
+		return fmt.Errorf("failed to revoke session after refresh token reuse: %w", err)
+	}
+	return &RefreshTokenReusedError{Session: s}
+}
+
+// Validate validates an access token and returns the principal it was issued to.
+// Tokens of revoked sessions are rejected even when they have not expired yet.
+func (m *Authenticator) Validate(ctx context.Context, accessToken string) (*Principal, error) {
+	claims, err := m.AccessTokenManager.Validate(accessToken)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	p, err := principalFromClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// a replica may not have seen the revocation of the session yet.
+	s, err := m.sessionReader.Get(database.NewPrimaryContext(ctx), p.SessionID)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return nil, ErrSessionRevoked
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if !s.RevokeTime.IsZero() || s.UserID != p.UserID {
+		return nil, ErrSessionRevoked
+	}
+
+	if s.Impersonator != p.Actor {
+		return nil, ErrInvalidToken
+	}
+
+	return p, nil
+}
+
+// Impersonate starts a session in which the actor acts as the target user.
+// Only an access token is issued; it expires with the session after ttl and can not be refreshed.
+// A ttl of zero or above ImpersonationDuration is capped to ImpersonationDuration.
+func (m *Authenticator) Impersonate(ctx context.Context, actor *Principal, target *domain.User, ttl time.Duration, client Client) (*Authentication, error) {
+	if ttl <= 0 || ttl > ImpersonationDuration {
+		ttl = ImpersonationDuration
+	}
+
+	id := m.genUUID()
+	s, err := m.sessionWriter.Create(ctx, &domain.Session{
+		ID:             id,
+		UserID:         target.ID,
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IPAddress,
+		RefreshFamily:  id,
+		RefreshTokenID: m.genUUID(),
+		ExpireTime:     m.clock().Add(ttl),
+		Impersonator:   domain.UserName(actor.OrganizationID, actor.UserID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	accessToken, err := m.impersonationTokenManager.Create(
+		target.ID.String(),
+		token.WithSessionID(s.ID.String()),
+		token.WithOrganizationID(target.OrganizationID.String()),
+		token.WithRole(target.Role.String()),
+		token.WithActor(s.Impersonator),
+		token.WithExpirationTime(s.ExpireTime),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account token: %w", err)
+	}
+
+	target.HashedPassword = ""
+
+	return &Authentication{
+		User:        target,
+		Session:     s,
+		AccessToken: accessToken,
+	}, nil
+}
+
+// issue creates the access and refresh token for a session.
+func (m *Authenticator) issue(u *domain.User, s *domain.Session) (*Authentication, error) {
+	accessToken, err := m.AccessTokenManager.Create(
+		u.ID.String(),
+		token.WithSessionID(s.ID.String()),
+		token.WithOrganizationID(u.OrganizationID.String()),
+		token.WithRole(u.Role.String()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account token: %w", err)
+	}
+
+	refreshToken, err := m.RefreshTokenManager.Create(
+		u.ID.String(),
+		token.WithSessionID(s.ID.String()),
+		token.WithFamily(s.RefreshFamily.String()),
+		token.WithTokenID(s.RefreshTokenID.String()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
 	return &Authentication{
 		User:         u,
-		AccessToken:  accountToken,
+		Session:      s,
+		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
 }
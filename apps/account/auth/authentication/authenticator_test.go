@@ -0,0 +1,269 @@
+package authentication_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/password"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/token"
+	"github.com/google/uuid"
+
+	sessionMock "github.com/extreme-business/lingo/apps/account/storage/mock/session"
+	userMock "github.com/extreme-business/lingo/apps/account/storage/mock/user"
+)
+
+// newSessionRepo returns a mock session repository that keeps sessions in a map.
+func newSessionRepo() *sessionMock.Repository {
+	sessions := map[uuid.UUID]storage.Session{}
+	return &sessionMock.Repository{
+		CreateFunc: func(_ context.Context, s *storage.Session) (*storage.Session, error) {
+			sessions[s.ID] = *s
+			return s, nil
+		},
+		GetFunc: func(_ context.Context, id uuid.UUID) (*storage.Session, error) {
+			s, ok := sessions[id]
+			if !ok {
+				return nil, storage.ErrSessionNotFound
+			}
+			return &s, nil
+		},
+		UpdateFunc: func(_ context.Context, s *storage.Session, _ []storage.SessionField) (*storage.Session, error) {
+			sessions[s.ID] = *s
+			return s, nil
+		},
+		RotateRefreshTokenFunc: func(_ context.Context, s *storage.Session, previousTokenID uuid.UUID) (*storage.Session, error) {
+			stored, ok := sessions[s.ID]
+			if !ok || stored.RefreshTokenID != previousTokenID || stored.RevokeTime.Valid {
+				return nil, storage.ErrStaleSessionRefreshToken
+			}
+			sessions[s.ID] = *s
+			return s, nil
+		},
+	}
+}
+
+func newAuthenticator(t *testing.T) *authentication.Authenticator {
+	t.Helper()
+	return newAuthenticatorWithSessions(t, newSessionRepo())
+}
+
+func newAuthenticatorWithSessions(t *testing.T, sessionRepo *sessionMock.Repository) *authentication.Authenticator {
+	t.Helper()
+
+	hashed, err := password.Hash([]byte("password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := &storage.User{
+		ID:             uuid.MustParse("d58c4b17-9a1c-4853-9bbf-9467df86307e"),
+		OrganizationID: uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
+		Email:          "user@test.com",
+		HashedPassword: string(hashed),
+		Role:           "user",
+	}
+
+	userRepo := &userMock.Repository{
+		GetFunc:        func(context.Context, uuid.UUID) (*storage.User, error) { return u, nil },
+		GetByEmailFunc: func(context.Context, string) (*storage.User, error) { return u, nil },
+	}
+
+	clock := time.Now
+
+	return authentication.New(authentication.Config{
+		Clock:                  clock,
+		GenUUID:                uuid.New,
+		SigningKeyAccessToken:  []byte("access"),
+		SigningKeyRefreshToken: []byte("refresh"),
+		UserReader:             user.NewReader(userRepo),
+		SessionReader:          session.NewReader(clock, sessionRepo),
+		SessionWriter:          session.NewWriter(clock, sessionRepo),
+	})
+}
+
+func login(t *testing.T, a *authentication.Authenticator) *authentication.Authentication {
+	t.Helper()
+
+	l, err := a.Authenticate(context.Background(), authentication.Credentials{
+		Email:    "user@test.com",
+		Password: []byte("password"),
+	}, authentication.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return l
+}
+
+func TestAuthenticator_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should rotate the refresh token", func(t *testing.T) {
+		a := newAuthenticator(t)
+		l := login(t, a)
+
+		refreshed, err := a.Refresh(ctx, l.RefreshToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if refreshed.Session.RefreshTokenID == l.Session.RefreshTokenID {
+			t.Error("expected the refresh token id to be rotated")
+		}
+
+		p, err := a.Validate(ctx, refreshed.AccessToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p.SessionID != l.Session.ID {
+			t.Errorf("expected session %s, got %s", l.Session.ID, p.SessionID)
+		}
+	})
+
+	t.Run("should revoke the session when a refresh token is reused", func(t *testing.T) {
+		a := newAuthenticator(t)
+		l := login(t, a)
+
+		refreshed, err := a.Refresh(ctx, l.RefreshToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = a.Refresh(ctx, l.RefreshToken); !errors.Is(err, authentication.ErrRefreshTokenReused) {
+			t.Errorf("expected %v, got %v", authentication.ErrRefreshTokenReused, err)
+		}
+
+		if _, err = a.Refresh(ctx, refreshed.RefreshToken); !errors.Is(err, authentication.ErrSessionRevoked) {
+			t.Errorf("expected %v, got %v", authentication.ErrSessionRevoked, err)
+		}
+
+		if _, err = a.Validate(ctx, refreshed.AccessToken); !errors.Is(err, authentication.ErrSessionRevoked) {
+			t.Errorf("expected %v, got %v", authentication.ErrSessionRevoked, err)
+		}
+	})
+
+	t.Run("should revoke the session when another refresh with the same token rotates it first", func(t *testing.T) {
+		sessionRepo := newSessionRepo()
+		a := newAuthenticatorWithSessions(t, sessionRepo)
+		l := login(t, a)
+
+		// the other refresh runs after this one read the session, before it rotates the token.
+		var other *authentication.Authentication
+		get := sessionRepo.GetFunc
+		sessionRepo.GetFunc = func(ctx context.Context, id uuid.UUID) (*storage.Session, error) {
+			s, err := get(ctx, id)
+			sessionRepo.GetFunc = get
+
+			var rErr error
+			if other, rErr = a.Refresh(ctx, l.RefreshToken); rErr != nil {
+				t.Fatal(rErr)
+			}
+			return s, err
+		}
+
+		if _, err := a.Refresh(ctx, l.RefreshToken); !errors.Is(err, authentication.ErrRefreshTokenReused) {
+			t.Errorf("expected %v, got %v", authentication.ErrRefreshTokenReused, err)
+		}
+
+		if _, err := a.Validate(ctx, other.AccessToken); !errors.Is(err, authentication.ErrSessionRevoked) {
+			t.Errorf("expected %v, got %v", authentication.ErrSessionRevoked, err)
+		}
+	})
+
+	t.Run("should reject an access token as refresh token", func(t *testing.T) {
+		a := newAuthenticator(t)
+		l := login(t, a)
+
+		if _, err := a.Refresh(ctx, l.AccessToken); !errors.Is(err, authentication.ErrInvalidToken) {
+			t.Errorf("expected %v, got %v", authentication.ErrInvalidToken, err)
+		}
+	})
+}
+
+func TestAuthenticator_Impersonate(t *testing.T) {
+	ctx := context.Background()
+	actor := &authentication.Principal{
+		UserID:         uuid.MustParse("8a6c2f4b-7f0c-4a8e-9d2b-3c1e5f6a7b8c"),
+		OrganizationID: uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
+		SessionID:      uuid.MustParse("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+		Role:           domain.UserRoleAdmin,
+	}
+	target := func() *domain.User {
+		return &domain.User{
+			ID:             uuid.MustParse("d58c4b17-9a1c-4853-9bbf-9467df86307e"),
+			OrganizationID: uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
+			Role:           domain.UserRoleUser,
+		}
+	}
+
+	t.Run("should issue an access token that carries the actor", func(t *testing.T) {
+		a := newAuthenticator(t)
+
+		i, err := a.Impersonate(ctx, actor, target(), 0, authentication.Client{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i.RefreshToken != "" {
+			t.Error("expected no refresh token")
+		}
+
+		if want := domain.UserName(actor.OrganizationID, actor.UserID); i.Session.Impersonator != want {
+			t.Errorf("expected impersonator %s, got %s", want, i.Session.Impersonator)
+		}
+
+		p, err := a.Validate(ctx, i.AccessToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p.UserID != target().ID {
+			t.Errorf("expected user %s, got %s", target().ID, p.UserID)
+		}
+
+		if p.Actor != i.Session.Impersonator {
+			t.Errorf("expected actor %s, got %s", i.Session.Impersonator, p.Actor)
+		}
+	})
+
+	t.Run("should cap the lifetime of the impersonation", func(t *testing.T) {
+		a := newAuthenticator(t)
+
+		i, err := a.Impersonate(ctx, actor, target(), 24*time.Hour, authentication.Client{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if limit := time.Now().Add(authentication.ImpersonationDuration); i.Session.ExpireTime.After(limit) {
+			t.Errorf("expected the session to expire before %v, got %v", limit, i.Session.ExpireTime)
+		}
+	})
+
+	t.Run("should reject a forged actor", func(t *testing.T) {
+		a := newAuthenticator(t)
+		l := login(t, a)
+
+		forged, err := a.AccessTokenManager.Create(
+			l.User.ID.String(),
+			token.WithSessionID(l.Session.ID.String()),
+			token.WithOrganizationID(l.User.OrganizationID.String()),
+			token.WithRole(l.User.Role.String()),
+			token.WithActor("organizations/1/users/2"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = a.Validate(ctx, forged); !errors.Is(err, authentication.ErrInvalidToken) {
+			t.Errorf("expected %v, got %v", authentication.ErrInvalidToken, err)
+		}
+	})
+}
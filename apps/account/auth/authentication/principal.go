@@ -0,0 +1,62 @@
+package authentication
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/token"
+	"github.com/google/uuid"
+)
+
+// Principal is the authenticated user on whose behalf a request is made.
+type Principal struct {
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	SessionID      uuid.UUID
+	Role           domain.UserRole
+	Actor          string // Actor is the resource name of the user impersonating this principal, if any.
+}
+
+// Impersonated reports whether someone else acts as the principal.
+func (p *Principal) Impersonated() bool {
+	return p.Actor != ""
+}
+
+// principalFromClaims maps the claims of an access token to a principal.
+func principalFromClaims(c *token.Claims) (*Principal, error) {
+	userID, err := uuid.Parse(c.Sub)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	organizationID, err := uuid.Parse(c.OrganizationID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	sessionID, err := uuid.Parse(c.SessionID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &Principal{
+		UserID:         userID,
+		OrganizationID: organizationID,
+		SessionID:      sessionID,
+		Role:           domain.UserRole(c.Role),
+		Actor:          c.Actor,
+	}, nil
+}
+
+type principalKey struct{}
+
+// NewContext returns a new context that carries the principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
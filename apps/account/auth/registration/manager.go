@@ -4,43 +4,66 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
 	"github.com/extreme-business/lingo/apps/account/domain/user"
 	"github.com/extreme-business/lingo/apps/account/password"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/token"
 	"github.com/extreme-business/lingo/pkg/uuidgen"
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrInvalidToken is returned when a registration token is malformed, expired, not signed with the
+	// registration key, or issued for another email address or organization.
+	ErrInvalidToken = errors.New("invalid registration token")
+	// ErrTokenUsed is returned when a registration token was already used to create an account.
+	ErrTokenUsed = errors.New("registration token was already used")
+)
+
 // Manager is a manager for registration.
 type Manager struct {
+	clock                 func() time.Time
 	genUUID               uuidgen.Generator
-	userWriter            *user.Writer
+	dbManager             storage.DBManager
+	tokens                *token.Validator
 	registrationValidator *registrationValidator
 }
 
 // Config is the configuration for the manager.
 type Config struct {
-	GenUUID    uuidgen.Generator
-	UserWriter *user.Writer
+	Clock     func() time.Time
+	GenUUID   uuidgen.Generator
+	DBManager storage.DBManager
+	// Tokens validates the registration tokens of the relay. Without it registration tokens are rejected.
+	Tokens *token.Validator
 }
 
 // NewManager creates a new manager.
 func NewManager(c Config) *Manager {
 	return &Manager{
+		clock:                 c.Clock,
 		genUUID:               c.GenUUID,
-		userWriter:            c.UserWriter,
+		dbManager:             c.DBManager,
+		tokens:                c.Tokens,
 		registrationValidator: newRegistrationValidator(),
 	}
 }
 
 // configured checks if the manager is configured.
 func (m *Manager) configured() error {
+	if m.clock == nil {
+		return errors.New("clock is nil")
+	}
 	if m.genUUID == nil {
 		return errors.New("uuidgen is nil")
 	}
-	if m.userWriter == nil {
-		return errors.New("user repository is nil")
+	if m.dbManager == nil {
+		return errors.New("db manager is nil")
 	}
 	if m.registrationValidator == nil {
 		return errors.New("registration validator is nil")
@@ -54,6 +77,11 @@ type Registration struct {
 	DisplayName    string
 	Email          string
 	Password       string
+	// PreferredLanguage is a BCP 47 language tag, it is optional.
+	PreferredLanguage string
+	// Token is the registration token the relay issued for the email address, it is optional. A token
+	// can only be used once.
+	Token string
 }
 
 // CreateUser creates a new user.
@@ -64,21 +92,76 @@ func (m *Manager) Register(ctx context.Context, r Registration) (*domain.User, e
 	if err := m.registrationValidator.Validate(r); err != nil {
 		return nil, err
 	}
+	var used *storage.RegistrationToken
+	if r.Token != "" {
+		var err error
+		if used, err = m.validateToken(r); err != nil {
+			return nil, err
+		}
+	}
+	preferredLanguage, err := translation.ParseLanguage("preferred_language", r.PreferredLanguage)
+	if err != nil {
+		return nil, err
+	}
 	hashedPassword, err := password.Hash([]byte(r.Password))
 	if err != nil {
 		return nil, fmt.Errorf("could not hash password: %w", err)
 	}
 	u := &domain.User{
-		ID:             m.genUUID(),
-		Email:          r.Email,
-		DisplayName:    r.DisplayName,
-		OrganizationID: r.OrganizationID,
-		HashedPassword: string(hashedPassword),
+		ID:                m.genUUID(),
+		Email:             r.Email,
+		DisplayName:       r.DisplayName,
+		OrganizationID:    r.OrganizationID,
+		Role:              domain.UserRoleUser,
+		HashedPassword:    string(hashedPassword),
+		PreferredLanguage: preferredLanguage,
 	}
-	user, err := m.userWriter.Create(ctx, u)
-	if err != nil {
+	// create the user and its event in one transaction, so the event is only published for a stored user.
+	// The token is used in the same transaction, so it is used up only when the user is created.
+	var created *domain.User
+	if err = m.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		if used != nil {
+			if err = r.RegistrationToken.Use(ctx, used); err != nil {
+				if errors.Is(err, storage.ErrRegistrationTokenUsed) {
+					return ErrTokenUsed
+				}
+				return fmt.Errorf("failed to use registration token: %w", err)
+			}
+		}
+		w := user.NewWriter(m.clock, r.User, outbox.NewWriter(m.clock, r.OutboxEvent, r.Jobs))
+		created, err = w.Create(ctx, u)
+		return err
+	}); err != nil {
 		return nil, err
 	}
-	user.HashedPassword = "" // Do not return the password
-	return user, nil
+	created.HashedPassword = "" // Do not return the password
+	return created, nil
+}
+
+// validateToken validates the registration token of the registration and returns it as used.
+func (m *Manager) validateToken(r Registration) (*storage.RegistrationToken, error) {
+	if m.tokens == nil {
+		return nil, fmt.Errorf("%w: registration tokens are not accepted", ErrInvalidToken)
+	}
+
+	claims, err := m.tokens.Validate(r.Token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if claims.Sub != r.Email || claims.OrganizationID != r.OrganizationID.String() {
+		return nil, fmt.Errorf("%w: issued for another email address or organization", ErrInvalidToken)
+	}
+
+	id, err := uuid.Parse(claims.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: token id: %w", ErrInvalidToken, err)
+	}
+
+	return &storage.RegistrationToken{
+		ID:         id,
+		Email:      claims.Sub,
+		UseTime:    m.clock(),
+		ExpireTime: claims.ExpirationTime,
+	}, nil
 }
@@ -9,13 +9,18 @@ import (
 
 	"github.com/extreme-business/lingo/apps/account/auth/registration"
 	"github.com/extreme-business/lingo/apps/account/domain"
-	"github.com/extreme-business/lingo/apps/account/domain/user"
 	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+	"github.com/extreme-business/lingo/pkg/token"
 	"github.com/extreme-business/lingo/pkg/uuidgen"
 	"github.com/extreme-business/lingo/pkg/validate"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 
+	jobMock "github.com/extreme-business/lingo/apps/account/storage/mock/job"
+	managerMock "github.com/extreme-business/lingo/apps/account/storage/mock/manager"
+	outboxMock "github.com/extreme-business/lingo/apps/account/storage/mock/outbox"
+	registrationTokenMock "github.com/extreme-business/lingo/apps/account/storage/mock/registrationtoken"
 	userMock "github.com/extreme-business/lingo/apps/account/storage/mock/user"
 )
 
@@ -36,44 +41,62 @@ func TestManager_Register(t *testing.T) {
 		userRepo := userMock.Repository{
 			CreateFunc: func(_ context.Context, u *storage.User) (*storage.User, error) {
 				return &storage.User{
-					ID:             u.ID,
-					OrganizationID: u.OrganizationID,
-					DisplayName:    u.DisplayName,
-					Email:          u.Email,
-					HashedPassword: u.HashedPassword,
-					CreateTime:     u.CreateTime,
-					UpdateTime:     u.UpdateTime,
+					ID:                u.ID,
+					OrganizationID:    u.OrganizationID,
+					DisplayName:       u.DisplayName,
+					Email:             u.Email,
+					HashedPassword:    u.HashedPassword,
+					PreferredLanguage: u.PreferredLanguage,
+					CreateTime:        u.CreateTime,
+					UpdateTime:        u.UpdateTime,
 				}, nil
 			},
 		}
 
+		var events []*storage.OutboxEvent
+		outboxRepo := outboxMock.Repository{
+			CreateFunc: func(_ context.Context, e *storage.OutboxEvent) (*storage.OutboxEvent, error) {
+				events = append(events, e)
+				return e, nil
+			},
+		}
+
 		clock := func() time.Time { return now }
 
 		m := registration.NewManager(registration.Config{
-			UserWriter: user.NewWriter(clock, &userRepo),
+			Clock: clock,
+			DBManager: managerMock.New(storage.Repositories{
+				User:        &userRepo,
+				OutboxEvent: &outboxRepo,
+				Jobs: &jobMock.Enqueuer{
+					EnqueueFunc: func(context.Context, jobs.Args, ...jobs.EnqueueOption) (bool, error) { return true, nil },
+				},
+			}),
 			GenUUID: func() uuid.UUID {
 				return uuid.MustParse("c5172a66-3dbe-4415-bbf9-9921d9798698")
 			},
 		})
 
 		u, err := m.Register(context.TODO(), registration.Registration{
-			OrganizationID: uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
-			DisplayName:    "username",
-			Email:          "email",
-			Password:       "password!1",
+			OrganizationID:    uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
+			DisplayName:       "username",
+			Email:             "email",
+			Password:          "password!1",
+			PreferredLanguage: "EN-us",
 		})
 		if err != nil {
 			t.Fatalf("Register() = %v, want nil", err)
 		}
 
 		expected := &domain.User{
-			ID:             uuid.MustParse("c5172a66-3dbe-4415-bbf9-9921d9798698"),
-			OrganizationID: uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
-			DisplayName:    "username",
-			Email:          "email",
-			CreateTime:     now,
-			UpdateTime:     now,
-			Organization:   nil,
+			ID:                uuid.MustParse("c5172a66-3dbe-4415-bbf9-9921d9798698"),
+			OrganizationID:    uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
+			DisplayName:       "username",
+			Email:             "email",
+			PreferredLanguage: "en-US",
+			CreateTime:        now,
+			UpdateTime:        now,
+			Organization:      nil,
 		}
 
 		if u == nil {
@@ -83,6 +106,10 @@ func TestManager_Register(t *testing.T) {
 		if diff := cmp.Diff(expected, u); diff != "" {
 			t.Fatalf("Register() mismatch (-want +got):\n%s", diff)
 		}
+
+		if len(events) != 1 || events[0].Type != domain.EventUserCreated.String() {
+			t.Errorf("Register() events = %v, want one %s event", events, domain.EventUserCreated)
+		}
 	})
 
 	t.Run("should return an error if the user repo fails", func(t *testing.T) {
@@ -95,7 +122,8 @@ func TestManager_Register(t *testing.T) {
 		}
 
 		m := registration.NewManager(registration.Config{
-			UserWriter: user.NewWriter(func() time.Time { return now }, &userRepo),
+			Clock:     func() time.Time { return now },
+			DBManager: managerMock.New(storage.Repositories{User: &userRepo}),
 			GenUUID: func() uuid.UUID {
 				return uuid.MustParse("c5172a66-3dbe-4415-bbf9-9921d9798698")
 			},
@@ -118,6 +146,108 @@ func TestManager_Register(t *testing.T) {
 	})
 }
 
+func TestManager_Register_token(t *testing.T) {
+	now := time.Now().Truncate(time.Second) // the token is validated with the current time.
+	organizationID := uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d")
+	tokenID := uuid.MustParse("3f0d9a4e-2b1c-4e7d-9a8b-6c5d4e3f2a19")
+	tokens := token.NewManager(func() time.Time { return now }, []byte("secret"), 24*time.Hour)
+
+	newManager := func(t *testing.T, used map[uuid.UUID]*storage.RegistrationToken) *registration.Manager {
+		t.Helper()
+		return registration.NewManager(registration.Config{
+			Clock: func() time.Time { return now },
+			DBManager: managerMock.New(storage.Repositories{
+				User: &userMock.Repository{
+					CreateFunc: func(_ context.Context, u *storage.User) (*storage.User, error) { return u, nil },
+				},
+				OutboxEvent: &outboxMock.Repository{
+					CreateFunc: func(_ context.Context, e *storage.OutboxEvent) (*storage.OutboxEvent, error) { return e, nil },
+				},
+				Jobs: &jobMock.Enqueuer{
+					EnqueueFunc: func(context.Context, jobs.Args, ...jobs.EnqueueOption) (bool, error) { return true, nil },
+				},
+				RegistrationToken: &registrationTokenMock.Repository{
+					UseFunc: func(_ context.Context, t *storage.RegistrationToken) error {
+						if _, ok := used[t.ID]; ok {
+							return storage.ErrRegistrationTokenUsed
+						}
+						used[t.ID] = t
+						return nil
+					},
+				},
+			}),
+			GenUUID: uuidgen.Default(),
+			Tokens:  tokens.Validator,
+		})
+	}
+
+	registrationFor := func(t *testing.T, email string, opts ...token.ClaimOption) registration.Registration {
+		t.Helper()
+		tok, err := tokens.Create(email, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return registration.Registration{
+			OrganizationID: organizationID,
+			DisplayName:    "username",
+			Email:          "jane@example.com",
+			Password:       "password!1",
+			Token:          tok,
+		}
+	}
+
+	t.Run("should use a token only once", func(t *testing.T) {
+		used := map[uuid.UUID]*storage.RegistrationToken{}
+		m := newManager(t, used)
+		r := registrationFor(t, "jane@example.com", token.WithTokenID(tokenID.String()), token.WithOrganizationID(organizationID.String()))
+
+		if _, err := m.Register(context.Background(), r); err != nil {
+			t.Fatalf("Register() = %v, want nil", err)
+		}
+
+		want := &storage.RegistrationToken{
+			ID:         tokenID,
+			Email:      "jane@example.com",
+			UseTime:    now,
+			ExpireTime: now.Add(24 * time.Hour),
+		}
+		if diff := cmp.Diff(want, used[tokenID]); diff != "" {
+			t.Errorf("Use() mismatch (-want +got):\n%s", diff)
+		}
+
+		if _, err := m.Register(context.Background(), r); !errors.Is(err, registration.ErrTokenUsed) {
+			t.Errorf("Register() = %v, want %v", err, registration.ErrTokenUsed)
+		}
+	})
+
+	t.Run("should reject a token of another email address, organization or without an id", func(t *testing.T) {
+		m := newManager(t, map[uuid.UUID]*storage.RegistrationToken{})
+		for name, r := range map[string]registration.Registration{
+			"email":        registrationFor(t, "john@example.com", token.WithTokenID(tokenID.String()), token.WithOrganizationID(organizationID.String())),
+			"organization": registrationFor(t, "jane@example.com", token.WithTokenID(tokenID.String()), token.WithOrganizationID(uuid.NewString())),
+			"id":           registrationFor(t, "jane@example.com", token.WithOrganizationID(organizationID.String())),
+		} {
+			if _, err := m.Register(context.Background(), r); !errors.Is(err, registration.ErrInvalidToken) {
+				t.Errorf("%s: Register() = %v, want %v", name, err, registration.ErrInvalidToken)
+			}
+		}
+	})
+
+	t.Run("should reject tokens without a validator", func(t *testing.T) {
+		m := registration.NewManager(registration.Config{
+			Clock:     func() time.Time { return now },
+			DBManager: managerMock.New(storage.Repositories{}),
+			GenUUID:   uuidgen.Default(),
+		})
+
+		r := registrationFor(t, "jane@example.com", token.WithTokenID(tokenID.String()), token.WithOrganizationID(organizationID.String()))
+		if _, err := m.Register(context.Background(), r); !errors.Is(err, registration.ErrInvalidToken) {
+			t.Errorf("Register() = %v, want %v", err, registration.ErrInvalidToken)
+		}
+	})
+}
+
 func TestManager_Register_validations(t *testing.T) {
 	t.Run("should return an error if registration is invalid", func(t *testing.T) {
 		type fields struct {
@@ -138,8 +268,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "display name too short",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -158,8 +289,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "display name too long",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -178,8 +310,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "display name contains non allowed special char",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -198,8 +331,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "email too short",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -218,8 +352,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "email too long",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -238,8 +373,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "password too short",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -258,8 +394,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "password too long",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -278,8 +415,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "password does not contain special char",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -298,8 +436,9 @@ func TestManager_Register_validations(t *testing.T) {
 				name: "password does not contain digit",
 				fields: fields{
 					config: registration.Config{
-						GenUUID:    uuidgen.Default(),
-						UserWriter: user.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }, &userMock.Repository{}),
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
 					},
 				},
 				args: args{
@@ -314,6 +453,28 @@ func TestManager_Register_validations(t *testing.T) {
 				want:  nil,
 				want2: "password",
 			},
+			{
+				name: "preferred language is not a language tag",
+				fields: fields{
+					config: registration.Config{
+						Clock:     func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+						GenUUID:   uuidgen.Default(),
+						DBManager: managerMock.New(storage.Repositories{User: &userMock.Repository{}}),
+					},
+				},
+				args: args{
+					ctx: context.TODO(),
+					registration: registration.Registration{
+						DisplayName:       "username",
+						OrganizationID:    uuid.MustParse("0463e149-e143-4033-b617-7867824deb0d"),
+						Email:             "email@test.com",
+						Password:          "password!1",
+						PreferredLanguage: "english",
+					},
+				},
+				want:  nil,
+				want2: "preferred_language",
+			},
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
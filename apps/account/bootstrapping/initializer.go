@@ -3,17 +3,22 @@ package bootstrapping
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
 	"github.com/extreme-business/lingo/apps/account/domain/organization"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
 	"github.com/extreme-business/lingo/apps/account/domain/user"
 	"github.com/extreme-business/lingo/apps/account/password"
 	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
 	"github.com/extreme-business/lingo/pkg/validate"
 	"github.com/google/uuid"
 )
@@ -125,7 +130,8 @@ func New(config Config) (*Bootstrapper, error) {
 	}, config.Validate()
 }
 
-// Setup sets up the system user and organization.
+// Setup sets up the system user and organization. It checks and writes them in a serializable transaction,
+// so instances that start at the same time do not both create them, the later one is run again instead.
 func (s *Bootstrapper) Setup(ctx context.Context, systemUserConfig SystemUserConfig, systemOrganizationConfig SystemOrgConfig) error {
 	return s.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
 		if r.User == nil {
@@ -136,12 +142,42 @@ func (s *Bootstrapper) Setup(ctx context.Context, systemUserConfig SystemUserCon
 			return errors.New("organization repository is required")
 		}
 
+		if r.AuditEvent == nil {
+			return errors.New("audit event repository is required")
+		}
+
+		if r.OutboxEvent == nil {
+			return errors.New("outbox event repository is required")
+		}
+
 		return s.setup(ctx, &r, systemUserConfig, systemOrganizationConfig)
-	})
+	}, database.WithIsolation(sql.LevelSerializable))
+}
+
+// SetupOrganization sets up an organization besides the system organization, such as the organization
+// the relay registers accounts in. If the organization already exists, it will be updated if necessary.
+// Like Setup it runs in a serializable transaction.
+func (s *Bootstrapper) SetupOrganization(ctx context.Context, c SystemOrgConfig) error {
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid organization config: %w", err)
+	}
+
+	return s.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		_, err := s.setupOrganization(
+			ctx,
+			organization.NewReader(r.Organization),
+			organization.NewWriter(s.clock, r.Organization, outbox.NewWriter(s.clock, r.OutboxEvent, r.Jobs)),
+			audit.NewWriter(s.clock, r.AuditEvent),
+			c,
+			"organization "+c.Slug,
+		)
+		return err
+	}, database.WithIsolation(sql.LevelSerializable))
 }
 
-// setupOrganization sets up the system organization. If the organization already exists, it will be updated if necessary.
-func (s *Bootstrapper) setupOrganization(ctx context.Context, r *organization.Reader, w *organization.Writer, c SystemOrgConfig) (*domain.Organization, error) {
+// setupOrganization sets up an organization, kind names it in the logs and errors, such as "system organization".
+// If the organization already exists, it will be updated if necessary. The change is logged once it is committed.
+func (s *Bootstrapper) setupOrganization(ctx context.Context, r *organization.Reader, w *organization.Writer, a *audit.Writer, c SystemOrgConfig, kind string) (*domain.Organization, error) {
 	// check if the organization already exists
 	org, err := r.Get(ctx, c.ID)
 	if err == nil {
@@ -163,22 +199,33 @@ func (s *Bootstrapper) setupOrganization(ctx context.Context, r *organization.Re
 		}
 
 		if len(changes) > 0 {
-			s.logger.Info("system organization update triggered", slog.Any("changes", changes))
 			org.LegalName = c.LegalName
 			org.Slug = c.Slug
 
 			o, uErr := w.Update(ctx, org, changes)
 			if uErr != nil {
-				return nil, fmt.Errorf("failed to update system organization: %w", uErr)
+				return nil, fmt.Errorf("failed to update %s: %w", kind, uErr)
+			}
+
+			if _, uErr = a.Append(ctx, &domain.AuditEvent{
+				OrganizationID: o.ID,
+				Actor:          domain.SystemActor,
+				Action:         domain.AuditActionOrganizationUpdated,
+				Resource:       domain.OrganizationName(o.ID),
+				Details:        map[string]string{"fields": joinFields(changes)},
+			}); uErr != nil {
+				return nil, fmt.Errorf("failed to audit %s update: %w", kind, uErr)
 			}
 
+			database.AfterCommit(ctx, func(context.Context) {
+				s.logger.Info(kind+" updated", slog.Any("changes", changes))
+			})
 			return o, nil
 		}
 	}
 
 	// if the organization does not exist, create it
 	if errors.Is(err, organization.ErrOrganizationNotFound) {
-		s.logger.Info("system organization creation triggered")
 		now := s.clock()
 
 		o, cErr := w.Create(ctx, &domain.Organization{
@@ -190,9 +237,19 @@ func (s *Bootstrapper) setupOrganization(ctx context.Context, r *organization.Re
 		})
 
 		if cErr != nil {
-			return nil, fmt.Errorf("failed to create system organization: %w", cErr)
+			return nil, fmt.Errorf("failed to create %s: %w", kind, cErr)
 		}
 
+		if _, cErr = a.Append(ctx, &domain.AuditEvent{
+			OrganizationID: o.ID,
+			Actor:          domain.SystemActor,
+			Action:         domain.AuditActionOrganizationCreated,
+			Resource:       domain.OrganizationName(o.ID),
+		}); cErr != nil {
+			return nil, fmt.Errorf("failed to audit %s creation: %w", kind, cErr)
+		}
+
+		database.AfterCommit(ctx, func(context.Context) { s.logger.Info(kind + " created") })
 		return o, nil
 	}
 
@@ -200,7 +257,8 @@ func (s *Bootstrapper) setupOrganization(ctx context.Context, r *organization.Re
 }
 
 // setupUser sets up the system user. If the user already exists, it will be updated if necessary.
-func (s *Bootstrapper) setupUser(ctx context.Context, org *domain.Organization, r *user.Reader, w *user.Writer, c SystemUserConfig) (*domain.User, error) {
+// The change is logged once it is committed.
+func (s *Bootstrapper) setupUser(ctx context.Context, org *domain.Organization, r *user.Reader, w *user.Writer, a *audit.Writer, c SystemUserConfig) (*domain.User, error) {
 	currentPassword := []byte(c.Password)
 	hashedPassword, hErr := password.Hash(currentPassword)
 	if hErr != nil {
@@ -222,6 +280,7 @@ func (s *Bootstrapper) setupUser(ctx context.Context, org *domain.Organization,
 			{storage.UserOrganizationID, func(u *domain.User) bool { return u.OrganizationID != org.ID }},
 			{storage.UserDisplayName, func(u *domain.User) bool { return u.DisplayName != systemUserName }},
 			{storage.UserEmail, func(u *domain.User) bool { return u.Email != c.Email }},
+			{storage.UserRole, func(u *domain.User) bool { return u.Role != domain.UserRoleSystem }},
 			{storage.UserHashedPassword, func(u *domain.User) bool { return password.Check(currentPassword, []byte(u.HashedPassword)) != nil }},
 			{storage.UserUpdateTime, func(u *domain.User) bool { return false }},
 			{storage.UserCreateTime, func(u *domain.User) bool { return false }},
@@ -233,10 +292,10 @@ func (s *Bootstrapper) setupUser(ctx context.Context, org *domain.Organization,
 
 		if len(changes) > 0 {
 			slices.Sort(changes)
-			s.logger.Info("system user update triggered", slog.Any("changes", changes))
 			u.OrganizationID = org.ID
 			u.DisplayName = systemUserName
 			u.Email = c.Email
+			u.Role = domain.UserRoleSystem
 			u.HashedPassword = string(hashedPassword)
 
 			u, err = w.Update(ctx, u, changes)
@@ -244,13 +303,30 @@ func (s *Bootstrapper) setupUser(ctx context.Context, org *domain.Organization,
 				return nil, fmt.Errorf("failed to update system user: %w", err)
 			}
 
+			action := domain.AuditActionUserUpdated
+			if slices.Contains(changes, storage.UserRole) {
+				action = domain.AuditActionUserRoleChanged
+			}
+
+			if _, err = a.Append(ctx, &domain.AuditEvent{
+				OrganizationID: u.OrganizationID,
+				Actor:          domain.SystemActor,
+				Action:         action,
+				Resource:       domain.UserName(u.OrganizationID, u.ID),
+				Details:        map[string]string{"fields": joinFields(changes)},
+			}); err != nil {
+				return nil, fmt.Errorf("failed to audit system user update: %w", err)
+			}
+
+			database.AfterCommit(ctx, func(context.Context) {
+				s.logger.Info("system user updated", slog.Any("changes", changes))
+			})
 			return u, nil
 		}
 	}
 
 	// if the user does not exist, create it
 	if errors.Is(err, user.ErrUserNotFound) {
-		s.logger.Info("system user creation triggered")
 		now := s.clock()
 		u, err = w.Create(ctx, &domain.User{
 			ID:             c.ID,
@@ -258,6 +334,7 @@ func (s *Bootstrapper) setupUser(ctx context.Context, org *domain.Organization,
 			DisplayName:    systemUserName,
 			Email:          c.Email,
 			Status:         domain.UserStatusActive,
+			Role:           domain.UserRoleSystem,
 			HashedPassword: string(hashedPassword),
 			CreateTime:     now,
 			UpdateTime:     now,
@@ -268,6 +345,16 @@ func (s *Bootstrapper) setupUser(ctx context.Context, org *domain.Organization,
 			return nil, fmt.Errorf("failed to create system user: %w", err)
 		}
 
+		if _, err = a.Append(ctx, &domain.AuditEvent{
+			OrganizationID: u.OrganizationID,
+			Actor:          domain.SystemActor,
+			Action:         domain.AuditActionUserCreated,
+			Resource:       domain.UserName(u.OrganizationID, u.ID),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to audit system user creation: %w", err)
+		}
+
+		database.AfterCommit(ctx, func(context.Context) { s.logger.Info("system user created") })
 		return u, nil
 	}
 
@@ -287,12 +374,17 @@ func (s *Bootstrapper) setup(ctx context.Context, r *storage.Repositories, u Sys
 		return fmt.Errorf("invalid system organization config: %w", err)
 	}
 
+	a := audit.NewWriter(s.clock, r.AuditEvent)
+	events := outbox.NewWriter(s.clock, r.OutboxEvent, r.Jobs)
+
 	// Create the system organization and user.
 	org, err := s.setupOrganization(
 		ctx,
 		organization.NewReader(r.Organization),
-		organization.NewWriter(s.clock, r.Organization),
+		organization.NewWriter(s.clock, r.Organization, events),
+		a,
 		o,
+		"system organization",
 	)
 	if err != nil {
 		return err
@@ -302,7 +394,8 @@ func (s *Bootstrapper) setup(ctx context.Context, r *storage.Repositories, u Sys
 		ctx,
 		org,
 		user.NewReader(r.User),
-		user.NewWriter(s.clock, r.User),
+		user.NewWriter(s.clock, r.User, events),
+		a,
 		u,
 	); err != nil {
 		return err
@@ -310,3 +403,12 @@ func (s *Bootstrapper) setup(ctx context.Context, r *storage.Repositories, u Sys
 
 	return nil
 }
+
+// joinFields joins the changed fields for the details of an audit event.
+func joinFields[F ~string](fields []F) string {
+	s := make([]string, len(fields))
+	for i, f := range fields {
+		s[i] = string(f)
+	}
+	return strings.Join(s, ",")
+}
@@ -2,13 +2,16 @@ package bootstrapping_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"log/slog"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/extreme-business/lingo/apps/account/bootstrapping"
 	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/memory"
 	"github.com/extreme-business/lingo/apps/account/storage/postgres"
 	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
 	"github.com/extreme-business/lingo/pkg/database"
@@ -17,6 +20,7 @@ import (
 	"github.com/extreme-business/lingo/pkg/validate"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
@@ -42,6 +46,12 @@ func dbUserDiff(t *testing.T, r storage.UserRepository, u *storage.User) string
 	return cmp.Diff(u, user)
 }
 
+// systemUser sets the system role on a seeded user.
+func systemUser(u *storage.User) *storage.User {
+	u.Role = "system"
+	return u
+}
+
 func dbOrgDiff(t *testing.T, r storage.OrganizationRepository, o *storage.Organization) string {
 	t.Helper()
 	org, err := r.Get(context.Background(), o.ID)
@@ -164,7 +174,7 @@ func TestInitializer_Setup(t *testing.T) {
 			t.Errorf("Organization.Get() mismatch (-want +got):\n%s", diff)
 		}
 
-		if diff := dbUserDiff(t, repos.User, seed.NewUser(
+		if diff := dbUserDiff(t, repos.User, systemUser(seed.NewUser(
 			"44756c0a-28b7-40c7-a066-f8db23d7dbe3",
 			"c105ca54-68f0-4bc4-aca1-b54065b4e9b4",
 			"system",
@@ -174,7 +184,7 @@ func TestInitializer_Setup(t *testing.T) {
 			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			time.Time{},
-		)); diff != "" {
+		))); diff != "" {
 			t.Errorf("User.Get() mismatch (-want +got):\n%s", diff)
 		}
 	})
@@ -249,7 +259,7 @@ func TestInitializer_Setup(t *testing.T) {
 			t.Errorf("Organization.Get() mismatch (-want +got):\n%s", diff)
 		}
 
-		if diff := dbUserDiff(t, repos.User, seed.NewUser(
+		if diff := dbUserDiff(t, repos.User, systemUser(seed.NewUser(
 			"44756c0a-28b7-40c7-a066-f8db23d7dbe3",
 			"c105ca54-68f0-4bc4-aca1-b54065b4e9b4",
 			"system",
@@ -259,12 +269,157 @@ func TestInitializer_Setup(t *testing.T) {
 			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
 			time.Time{},
-		)); diff != "" {
+		))); diff != "" {
 			t.Errorf("User.Get() mismatch (-want +got):\n%s", diff)
 		}
 	})
 }
 
+func TestInitializer_SetupOrganization(t *testing.T) {
+	t.Run("SetupOrganization should create the organization and update it when the config changes", func(t *testing.T) {
+		ctx := context.Background()
+		dbManager := memory.NewManager()
+		initializer, err := bootstrapping.New(bootstrapping.Config{
+			Logger:    slog.Default(),
+			Clock:     func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) },
+			DBManager: dbManager,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := bootstrapping.SystemOrgConfig{
+			ID:        uuid.MustParse("5a1d2c3b-4e5f-4a6b-8c7d-9e0f1a2b3c4d"),
+			LegalName: "Lingo",
+			Slug:      "lingo",
+		}
+		if err = initializer.SetupOrganization(ctx, c); err != nil {
+			t.Fatalf("SetupOrganization() error = %v, want %v", err, nil)
+		}
+
+		c.LegalName = "Lingo B.V."
+		if err = initializer.SetupOrganization(ctx, c); err != nil {
+			t.Fatalf("SetupOrganization() error = %v, want %v", err, nil)
+		}
+
+		if diff := dbOrgDiff(t, dbManager.Op().Organization, seed.NewOrganization(
+			"5a1d2c3b-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+			"Lingo B.V.",
+			"lingo",
+			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		)); diff != "" {
+			t.Errorf("Organization.Get() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("SetupOrganization should validate the config", func(t *testing.T) {
+		initializer, err := bootstrapping.New(bootstrapping.Config{
+			Logger:    slog.Default(),
+			Clock:     time.Now,
+			DBManager: memory.NewManager(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vErr *validate.Error
+		if err = initializer.SetupOrganization(context.Background(), bootstrapping.SystemOrgConfig{}); !errors.As(err, &vErr) {
+			t.Errorf("SetupOrganization() error = %v, want a validation error", err)
+		}
+	})
+}
+
+func TestInitializer_Setup_faults(t *testing.T) {
+	t.Run("Setup should roll back when the system organization can not be created", func(t *testing.T) {
+		ctx := context.Background()
+		noRows := func(_ context.Context, _ string, _ ...interface{}) *database.Row {
+			return database.NewRow(&dbmock.RowHandler{ScanFunc: func(...interface{}) error { return sql.ErrNoRows }})
+		}
+		faults := dbtest.NewFaults(&dbmock.DBHandler{
+			BeginTxFunc: func(_ context.Context, _ *sql.TxOptions) (*database.Tx, error) {
+				return database.NewTxWithHandler(&dbmock.TxHandler{
+					DBHandler:    dbmock.DBHandler{QueryRowContextFunc: noRows},
+					CommitFunc:   func() error { return nil },
+					RollbackFunc: func() error { return nil },
+				}), nil
+			},
+		})
+		faults.On(`^INSERT INTO organizations`).Fail()
+
+		initializer, err := bootstrapping.New(bootstrapping.Config{
+			Logger:    slog.Default(),
+			Clock:     func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) },
+			DBManager: postgres.NewManagerWithWrapper(database.NewDBWithHandler(faults)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = initializer.Setup(ctx, bootstrapping.SystemUserConfig{
+			ID:       uuid.MustParse("44756c0a-28b7-40c7-a066-f8db23d7dbe3"),
+			Email:    "test@test.com",
+			Password: "password",
+		}, bootstrapping.SystemOrgConfig{
+			ID:        uuid.MustParse("c105ca54-68f0-4bc4-aca1-b54065b4e9b4"),
+			LegalName: "Test Organization",
+			Slug:      "test-organization",
+		})
+		if !errors.Is(err, dbtest.ErrInjected) {
+			t.Errorf("Setup() error = %v, want %v", err, dbtest.ErrInjected)
+		}
+
+		queries := faults.Queries()
+		if last := queries[len(queries)-1]; last != dbtest.Rollback {
+			t.Errorf("expected the transaction to roll back, got %v", queries)
+		}
+	})
+
+	t.Run("SetupOrganization should run again on a serialization failure", func(t *testing.T) {
+		ctx := context.Background()
+		var isolation []sql.IsolationLevel
+		noRows := func(_ context.Context, _ string, _ ...interface{}) *database.Row {
+			return database.NewRow(&dbmock.RowHandler{ScanFunc: func(...interface{}) error { return sql.ErrNoRows }})
+		}
+		faults := dbtest.NewFaults(&dbmock.DBHandler{
+			BeginTxFunc: func(_ context.Context, opts *sql.TxOptions) (*database.Tx, error) {
+				isolation = append(isolation, opts.Isolation)
+				return database.NewTxWithHandler(&dbmock.TxHandler{
+					DBHandler:    dbmock.DBHandler{QueryRowContextFunc: noRows},
+					CommitFunc:   func() error { return nil },
+					RollbackFunc: func() error { return nil },
+				}), nil
+			},
+		})
+		// another instance created the organization first, the second attempt fails for good.
+		faults.On(`^INSERT INTO organizations`).Nth(1).Return(&pq.Error{Code: "40001"})
+		faults.On(`^INSERT INTO organizations`).Nth(2).Fail()
+
+		initializer, err := bootstrapping.New(bootstrapping.Config{
+			Logger:    slog.Default(),
+			Clock:     func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) },
+			DBManager: postgres.NewManagerWithWrapper(database.NewDBWithHandler(faults)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = initializer.SetupOrganization(ctx, bootstrapping.SystemOrgConfig{
+			ID:        uuid.MustParse("c105ca54-68f0-4bc4-aca1-b54065b4e9b4"),
+			LegalName: "Test Organization",
+			Slug:      "test-organization",
+		})
+		if !errors.Is(err, dbtest.ErrInjected) {
+			t.Errorf("SetupOrganization() error = %v, want %v", err, dbtest.ErrInjected)
+		}
+
+		want := []sql.IsolationLevel{sql.LevelSerializable, sql.LevelSerializable}
+		if !slices.Equal(isolation, want) {
+			t.Errorf("expected two serializable transactions, got %v", isolation)
+		}
+	})
+}
+
 func TestSystemUserConfig_Validate(t *testing.T) {
 	type fields struct {
 		ID       uuid.UUID
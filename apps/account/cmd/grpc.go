@@ -2,14 +2,22 @@ package cmd
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/server"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/memory"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres"
 	"github.com/extreme-business/lingo/pkg/config"
-	"github.com/extreme-business/lingo/pkg/database/postgres"
+	dbpostgres "github.com/extreme-business/lingo/pkg/database/postgres"
 	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
@@ -17,6 +25,147 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
+)
+
+// backend is the storage of the account server, with the processes that depend on it.
+type backend struct {
+	dbManager    storage.DBManager
+	eventFeed    *outbox.Feed
+	translations *translation.Service
+	healthCheck  server.HealthCheck
+	workers      []func(context.Context) error // workers run next to the server until it stops.
+	close        func()
+}
+
+// setupPostgresBackend connects to the database and checks its schema, the migrations are applied
+// first with autoMigrate.
+func setupPostgresBackend(ctx context.Context, logger *slog.Logger, config *config.Config, autoMigrate bool) (*backend, error) {
+	dbURL, err := config.DatabaseURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database url: %w", err)
+	}
+
+	db, err := connectDatabase(ctx, config, dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup database: %w", err)
+	}
+
+	b, err := newPostgresBackend(ctx, logger, config, db, dbURL, autoMigrate)
+	if err != nil {
+		if cErr := db.Close(); cErr != nil {
+			logger.Error("Failed to close database", slog.String("error", cErr.Error()))
+		}
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func newPostgresBackend(ctx context.Context, logger *slog.Logger, config *config.Config, db *sql.DB, dbURL string, autoMigrate bool) (*backend, error) {
+	migrator, err := newMigrator(logger, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// refuse to start on a schema the build does not know, it would fail later with confusing SQL errors.
+	if err = checkSchema(ctx, logger, migrator, autoMigrate); err != nil {
+		return nil, err
+	}
+
+	dbWrapper, err := newDBWrapper(logger, config, db)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas, closeReplicas, err := setupReplicas(ctx, logger, config, dbWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup replicas: %w", err)
+	}
+
+	dbManager := postgres.NewManagerWithWrapper(dbWrapper)
+	if replicas != nil {
+		dbManager.SetReplicas(replicas)
+	}
+
+	if config.DatabaseInstrument() {
+		if err = dbManager.Instrument(nil); err != nil {
+			closeReplicas()
+			return nil, fmt.Errorf("failed to instrument database manager: %w", err)
+		}
+	}
+
+	eventFeed, err := setupEventFeed(logger, postgres.NewManager(db).Op().OutboxEvent, func(ctx context.Context, notify func(payload string)) error {
+		return dbpostgres.Listen(ctx, dbURL, outbox.NotifyChannel, notify)
+	})
+	if err != nil {
+		closeReplicas()
+		return nil, fmt.Errorf("failed to setup event feed: %w", err)
+	}
+
+	translations, err := setupTranslations(logger, postgres.NewManager(db))
+	if err != nil {
+		closeReplicas()
+		return nil, fmt.Errorf("failed to setup translations: %w", err)
+	}
+
+	_, deliverer, err := setupWebhooks(logger, db)
+	if err != nil {
+		closeReplicas()
+		return nil, fmt.Errorf("failed to setup webhooks: %w", err)
+	}
+
+	workers := []func(context.Context) error{eventFeed.Run, deliverer.Run, translations.Run}
+	if replicas != nil {
+		workers = append(workers, replicas.Run)
+	}
+
+	return &backend{
+		dbManager:    dbManager,
+		eventFeed:    eventFeed,
+		translations: translations,
+		healthCheck:  schemaHealthCheck(logger, migrator),
+		workers:      workers,
+		close: func() {
+			closeReplicas()
+			if err := db.Close(); err != nil {
+				logger.Error("Failed to close database", slog.String("error", err.Error()))
+			}
+		},
+	}, nil
+}
+
+// setupMemoryBackend keeps the data in memory, to run the server without a database. The features
+// the memory storage does not support fail with memory.ErrUnsupported, and the background jobs,
+// such as the outbox relay and the webhook deliveries, do not run.
+func setupMemoryBackend(logger *slog.Logger) (*backend, error) {
+	logger.Warn("Using the memory storage, the data is lost when the server stops",
+		slog.String("supported", "users, organizations, sessions, audit log, user changes"),
+	)
+
+	dbManager := memory.NewManager()
+	eventFeed, err := setupEventFeed(logger, dbManager.Op().OutboxEvent, dbManager.Listen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup event feed: %w", err)
+	}
+
+	translations, err := setupTranslations(logger, dbManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup translations: %w", err)
+	}
+
+	return &backend{
+		dbManager:    dbManager,
+		eventFeed:    eventFeed,
+		translations: translations,
+		healthCheck:  func(context.Context) error { return nil },
+		workers:      []func(context.Context) error{eventFeed.Run, translations.Run},
+		close:        func() {},
+	}, nil
+}
+
 // runAccount runs the account server.
 func runAccount(cmd *cobra.Command, _ []string) error {
 	logger := slog.Default()
@@ -34,37 +183,65 @@ func runAccount(cmd *cobra.Command, _ []string) error {
 
 	config := config.New()
 
-	dbURL, err := config.DatabaseURL()
+	flushTelemetry, err := setupTelemetry(ctx, logger, config, "account")
 	if err != nil {
-		return fmt.Errorf("failed to get database url: %w", err)
+		return err
 	}
+	defer flushTelemetry()
 
-	db, err := postgres.Connect(ctx, dbURL)
+	storageName, err := cmd.Flags().GetString("storage")
 	if err != nil {
-		return fmt.Errorf("failed to setup database: %w", err)
+		return err
 	}
-	defer func() {
-		if err = db.Close(); err != nil {
-			logger.Error("Failed to close database", slog.String("error", err.Error()))
+
+	var b *backend
+	switch storageName {
+	case storagePostgres:
+		autoMigrate, fErr := cmd.Flags().GetBool("migrate")
+		if fErr != nil {
+			return fErr
 		}
-	}()
 
-	account, err := setupAccount(ctx, logger, config, db)
+		b, err = setupPostgresBackend(ctx, logger, config, autoMigrate)
+	case storageMemory:
+		b, err = setupMemoryBackend(logger)
+	default:
+		return fmt.Errorf("unknown storage %q, use %q or %q", storageName, storagePostgres, storageMemory)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to setup relay app: %w", err)
+		return err
 	}
+	defer b.close()
 
-	accountServer := setupService(account)
-	grpcServer, err := setupServer(config, func(s grpc.ServiceRegistrar) {
+	chatHub := conversation.NewHub()
+	account, err := setupAccount(ctx, logger, config, b.dbManager, b.eventFeed, chatHub, b.translations)
+	if err != nil {
+		return fmt.Errorf("failed to setup account app: %w", err)
+	}
+
+	accountServer, err := setupService(config, account, b.healthCheck)
+	if err != nil {
+		return fmt.Errorf("failed to setup account service: %w", err)
+	}
+
+	registerServices := func(s grpc.ServiceRegistrar) {
 		protoaccount.RegisterAccountServiceServer(s, accountServer)
 		grpc_health_v1.RegisterHealthServer(s, accountServer)
-	})
+	}
+	// chat streams are ended first, so their clients reconnect to another server while this one drains.
+	grpcServer, err := setupServer(config, registerServices, chatHub.Close,
+		grpc.ChainUnaryInterceptor(accountServer.RequestInterceptor(), accountServer.AuthInterceptor()),
+		grpc.ChainStreamInterceptor(accountServer.RequestStreamInterceptor(), accountServer.AuthStreamInterceptor()),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to setup grpc server: %w", err)
 	}
 
 	g := new(errgroup.Group)
 	g.Go(func() error { return grpcServer.Serve(ctx) })
+	for _, run := range b.workers {
+		g.Go(func() error { return run(ctx) })
+	}
 
 	logger.Info("Waiting for servers to finish")
 
@@ -72,9 +249,13 @@ func runAccount(cmd *cobra.Command, _ []string) error {
 }
 
 func NewGrpcCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "account",
 		Short: "Start the account grpc service",
 		RunE:  runAccount,
 	}
+	cmd.Flags().Bool("migrate", false, "apply the pending migrations before starting")
+	cmd.Flags().String("storage", storagePostgres, "where to store the data: postgres, or memory to run without a database during development; "+
+		"the memory storage loses the data when the server stops and supports only users, organizations, sessions and the audit log")
+	return cmd
 }
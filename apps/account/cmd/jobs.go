@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/extreme-business/lingo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// runJobs runs the background jobs of the account app.
+func runJobs(cmd *cobra.Command, _ []string) error {
+	logger := slog.Default()
+	ctx, cancel := context.WithCancel(cmd.Context())
+
+	// Set up channel to receive signals
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		s := <-sigs
+		logger.Info("Signal received", slog.String("signal", s.String()))
+		cancel()
+	}()
+
+	config := config.New()
+
+	dbURL, err := config.DatabaseURL()
+	if err != nil {
+		return fmt.Errorf("failed to get database url: %w", err)
+	}
+
+	db, err := connectDatabase(ctx, config, dbURL)
+	if err != nil {
+		return fmt.Errorf("failed to setup database: %w", err)
+	}
+	defer func() {
+		if err = db.Close(); err != nil {
+			logger.Error("Failed to close database", slog.String("error", err.Error()))
+		}
+	}()
+
+	runner, err := setupJobs(logger, db)
+	if err != nil {
+		return fmt.Errorf("failed to setup jobs: %w", err)
+	}
+
+	logger.Info("Running jobs")
+
+	return runner.Run(ctx)
+}
+
+// runRedriveOutbox hands parked outbox events back to the relay.
+func runRedriveOutbox(cmd *cobra.Command, args []string) error {
+	logger := slog.Default()
+	ctx := cmd.Context()
+
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+
+	if all == (len(args) > 0) {
+		return errors.New("pass the ids of the events to redrive or --all")
+	}
+
+	ids := make([]int64, 0, len(args))
+	for _, a := range args {
+		id, err := strconv.ParseInt(a, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid event id %q: %w", a, err)
+		}
+		ids = append(ids, id)
+	}
+
+	config := config.New()
+
+	dbURL, err := config.DatabaseURL()
+	if err != nil {
+		return fmt.Errorf("failed to get database url: %w", err)
+	}
+
+	db, err := connectDatabase(ctx, config, dbURL)
+	if err != nil {
+		return fmt.Errorf("failed to setup database: %w", err)
+	}
+	defer func() {
+		if err = db.Close(); err != nil {
+			logger.Error("Failed to close database", slog.String("error", err.Error()))
+		}
+	}()
+
+	relay, err := setupOutboxRelay(logger, db)
+	if err != nil {
+		return fmt.Errorf("failed to setup outbox relay: %w", err)
+	}
+
+	n, err := relay.Redrive(ctx, ids...)
+	if err != nil {
+		return fmt.Errorf("failed to redrive outbox events: %w", err)
+	}
+
+	logger.Info("Handed outbox events back to the relay", slog.Int64("count", n))
+
+	return nil
+}
+
+func NewJobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Run the background jobs of the account service",
+		RunE:  runJobs,
+	}
+
+	redrive := &cobra.Command{
+		Use:   "redrive-outbox [id...]",
+		Short: "Hand outbox events the relay gave up on back to it",
+		RunE:  runRedriveOutbox,
+	}
+	redrive.Flags().Bool("all", false, "redrive all parked events")
+	cmd.AddCommand(redrive)
+
+	return cmd
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"text/tabwriter"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/migrations"
+	"github.com/extreme-business/lingo/apps/account/server"
+	"github.com/extreme-business/lingo/pkg/config"
+	"github.com/extreme-business/lingo/pkg/database/migrate"
+	"github.com/spf13/cobra"
+)
+
+// newMigrator returns a migrator of the account migrations.
+func newMigrator(logger *slog.Logger, db *sql.DB) (*migrate.Migrator, error) {
+	m, err := migrate.New(migrate.Config{
+		Logger: logger,
+		Clock:  time.Now,
+		DB:     db,
+		FS:     migrations.FS,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup migrator: %w", err)
+	}
+
+	return m, nil
+}
+
+// checkSchema fails when the database schema does not match the migrations of the build. The
+// pending migrations are applied first when autoMigrate is set.
+func checkSchema(ctx context.Context, logger *slog.Logger, m *migrate.Migrator, autoMigrate bool) error {
+	if autoMigrate {
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to migrate: %w", err)
+		}
+		logger.Info("Migrated database", slog.Int("applied", len(applied)))
+	}
+
+	report, err := m.Check(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check the database schema: %w", err)
+	}
+
+	if err = report.Err(); err != nil {
+		return fmt.Errorf("%w, run `lingo migrate account up` or start with --migrate", err)
+	}
+
+	return nil
+}
+
+// schemaHealthCheck reports the server as not serving while the database schema does not match the migrations of the build.
+func schemaHealthCheck(logger *slog.Logger, m *migrate.Migrator) server.HealthCheck {
+	return func(ctx context.Context) error {
+		report, err := m.Check(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err = report.Err(); err != nil {
+			logger.WarnContext(ctx, "Database schema mismatch", slog.String("report", report.String()))
+			return err
+		}
+
+		return nil
+	}
+}
+
+// withMigrator connects to the account database and calls f with a migrator of the account migrations.
+func withMigrator(ctx context.Context, f func(m *migrate.Migrator) error) error {
+	logger := slog.Default()
+	config := config.New()
+
+	dbURL, err := config.DatabaseURL()
+	if err != nil {
+		return fmt.Errorf("failed to get database url: %w", err)
+	}
+
+	db, err := connectDatabase(ctx, config, dbURL)
+	if err != nil {
+		return fmt.Errorf("failed to setup database: %w", err)
+	}
+	defer func(db *sql.DB) {
+		if err = db.Close(); err != nil {
+			logger.Error("Failed to close database", slog.String("error", err.Error()))
+		}
+	}(db)
+
+	m, err := newMigrator(logger, db)
+	if err != nil {
+		return err
+	}
+
+	return f(m)
+}
+
+func NewMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "account",
+		Short: "Migrate the account database",
+		Long: `Migrate the account database with the migrations that are built into lingo.
+
+Migrations only go forward, a change is undone with a new migration.`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(cmd.Context(), func(m *migrate.Migrator) error {
+				applied, err := m.Up(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf("applied %d migrations\n", len(applied))
+				return nil
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "List the migrations and whether they are applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(cmd.Context(), func(m *migrate.Migrator) error {
+				statuses, err := m.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "VERSION\tDESCRIPTION\tSTATUS\tAPPLIED")
+				for _, s := range statuses {
+					status, applied := "pending", ""
+					if s.Applied {
+						status, applied = "applied", s.ApplyTime.Format(time.RFC3339)
+					}
+					if s.Baseline {
+						status = "baseline"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Version, s.Description, status, applied)
+				}
+				return w.Flush()
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "baseline <version>",
+		Short: "Mark the migrations up to a version as applied without running them",
+		Long: `Mark the migrations up to and including a version as applied without running them.
+
+Use it once on a database that was migrated with the atlas CLI before, with the version of the
+last migration atlas applied.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd.Context(), func(m *migrate.Migrator) error {
+				marked, err := m.Baseline(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf("marked %d migrations as applied\n", len(marked))
+				return nil
+			})
+		},
+	})
+
+	return cmd
+}
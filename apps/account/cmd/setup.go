@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -13,14 +14,28 @@ import (
 	"github.com/extreme-business/lingo/apps/account/auth/registration"
 	"github.com/extreme-business/lingo/apps/account/bootstrapping"
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/domain/maintenance"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/session"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
 	"github.com/extreme-business/lingo/apps/account/domain/user"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+	"github.com/extreme-business/lingo/apps/account/gateway"
 	"github.com/extreme-business/lingo/apps/account/server"
+	"github.com/extreme-business/lingo/apps/account/storage"
 	"github.com/extreme-business/lingo/apps/account/storage/postgres"
 	"github.com/extreme-business/lingo/pkg/config"
+	"github.com/extreme-business/lingo/pkg/database"
+	dbpostgres "github.com/extreme-business/lingo/pkg/database/postgres"
 	"github.com/extreme-business/lingo/pkg/grpcserver"
 	"github.com/extreme-business/lingo/pkg/httpmiddleware"
 	"github.com/extreme-business/lingo/pkg/httpserver"
+	"github.com/extreme-business/lingo/pkg/jobs"
 	"github.com/extreme-business/lingo/pkg/resource"
+	"github.com/extreme-business/lingo/pkg/telemetry"
 	"github.com/extreme-business/lingo/pkg/token"
 	"github.com/extreme-business/lingo/pkg/uuidgen"
 	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
@@ -37,6 +52,9 @@ const (
 	writeTimeout    = 10 * time.Second
 	idleTimeout     = 15 * time.Second
 	shutdownTimeout = 5 * time.Second
+	drainTimeout    = 10 * time.Second // drainTimeout is how long open grpc calls may take to finish when the server stops.
+	// telemetryShutdownTimeout is how long the last traces and metrics may take to export when the server stops.
+	telemetryShutdownTimeout = 5 * time.Second
 )
 
 // getSystemUserConfig gets the system user configuration from the config.
@@ -91,12 +109,42 @@ func getSystemOrgConfig(c *config.Config) (bootstrapping.SystemOrgConfig, error)
 	return soc, nil
 }
 
+// getRegisterOrgConfig gets the configuration of the organization the relay registers accounts in.
+// It reports false when no such organization is configured.
+func getRegisterOrgConfig(c *config.Config) (bootstrapping.SystemOrgConfig, bool, error) {
+	roc := bootstrapping.SystemOrgConfig{}
+	id, err := c.RegisterOrganizationID()
+	if err != nil {
+		return roc, false, nil
+	}
+
+	roc.ID, err = uuid.Parse(id)
+	if err != nil {
+		return roc, false, fmt.Errorf("failed to parse register org id: %w", err)
+	}
+
+	roc.LegalName, err = c.RegisterOrganizationLegalName()
+	if err != nil {
+		return roc, false, err
+	}
+
+	roc.Slug, err = c.RegisterOrganizationSlug()
+	if err != nil {
+		return roc, false, err
+	}
+
+	return roc, true, nil
+}
+
 // setupAccount sets up the account application.
 func setupAccount(
 	ctx context.Context,
 	logger *slog.Logger,
 	config *config.Config,
-	db *sql.DB,
+	dbManager storage.DBManager,
+	eventFeed *outbox.Feed,
+	chatHub *conversation.Hub,
+	translations *translation.Service,
 ) (*app.App, error) {
 	signingKeyAccessToken, err := config.SigningKeyAccessToken()
 	if err != nil {
@@ -110,7 +158,6 @@ func setupAccount(
 
 	clock := time.Now
 	uuidgen := uuidgen.Default()
-	dbManager := postgres.NewManager(db)
 	repos := dbManager.Op()
 
 	suc, err := getSystemUserConfig(config)
@@ -136,21 +183,58 @@ func setupAccount(
 		return nil, fmt.Errorf("failed to setup system user and organization: %w", err)
 	}
 
+	roc, ok, err := getRegisterOrgConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		// accounts of the relay would otherwise join the system user in its organization.
+		if roc.ID == soc.ID {
+			return nil, errors.New("the register organization can not be the system organization")
+		}
+
+		if err = i.SetupOrganization(ctx, roc); err != nil {
+			return nil, fmt.Errorf("failed to setup register organization: %w", err)
+		}
+	}
+
 	userReader := user.NewReader(repos.User)
-	userWriter := user.NewWriter(clock, repos.User)
+	sessionReader := session.NewReader(clock, repos.Session)
+	sessionWriter := session.NewWriter(clock, repos.Session)
 
 	app, err := app.New(app.Config{
-		Logger:     logger,
-		UserReader: userReader,
+		Logger:                logger,
+		UserReader:            userReader,
+		SessionReader:         sessionReader,
+		SessionWriter:         sessionWriter,
+		AuditReader:           audit.NewReader(repos.AuditEvent),
+		AuditRecorder:         audit.NewRecorder(clock, dbManager),
+		WebhookReader:         webhook.NewReader(repos.Webhook, repos.WebhookDelivery),
+		WebhookWriter:         webhook.NewWriter(clock, uuidgen, repos.Webhook),
+		WebhookDeliveryWriter: webhook.NewDeliveryWriter(clock, uuidgen, repos.WebhookDelivery),
+		ConversationReader:    conversation.NewReader(repos.Conversation, repos.Participant, repos.Message),
+		ConversationWriter:    conversation.NewWriter(clock, uuidgen, dbManager),
+		ChatHub:               chatHub,
+		EventFeed:             eventFeed,
+		UserUpdater:           user.NewUpdater(clock, dbManager),
+		Translations:          translations,
+		GlossaryReader:        glossary.NewReader(repos.Glossary, repos.GlossaryTerm, repos.GlossarySegment),
+		GlossaryWriter:        glossary.NewWriter(clock, uuidgen, dbManager),
 		Authenticator: authentication.New(authentication.Config{
 			Clock:                  clock,
+			GenUUID:                uuidgen,
 			SigningKeyAccessToken:  []byte(signingKeyAccessToken),
 			SigningKeyRefreshToken: []byte(signingKeyRefreshToken),
 			UserReader:             userReader,
+			SessionReader:          sessionReader,
+			SessionWriter:          sessionWriter,
 		}),
 		RegistrationManager: registration.NewManager(registration.Config{
-			GenUUID:    uuidgen,
-			UserWriter: userWriter,
+			Clock:     clock,
+			GenUUID:   uuidgen,
+			DBManager: dbManager,
+			Tokens:    registrationTokens(logger, config),
 		}),
 	})
 	if err != nil {
@@ -160,15 +244,245 @@ func setupAccount(
 	return app, nil
 }
 
+// registrationTokens returns the validator of the registration tokens of the relay. Without a signing key
+// registration tokens are rejected, so only admins can create users.
+func registrationTokens(logger *slog.Logger, config *config.Config) *token.Validator {
+	key, err := config.SigningKeyRegisterToken()
+	if err != nil {
+		logger.Info("No signing key for registration tokens, only admins can create users")
+		return nil
+	}
+
+	return token.NewValidator([]byte(key))
+}
+
+// defaultApplicationName is the application_name of the connections when the config does not set one.
+const defaultApplicationName = "lingo-account"
+
+// connectDatabase connects to the database with the driver and pool settings of the config.
+func connectDatabase(ctx context.Context, config *config.Config, dataSourceName string) (*sql.DB, error) {
+	name := config.DatabaseApplicationName()
+	if name == "" {
+		name = defaultApplicationName
+	}
+
+	return dbpostgres.Open(ctx, dataSourceName, dbpostgres.Config{
+		Driver:                 config.DatabaseDriver(),
+		ApplicationName:        name,
+		MaxOpenConns:           config.DatabaseMaxOpenConns(),
+		MaxIdleConns:           config.DatabaseMaxIdleConns(),
+		ConnMaxLifetime:        config.DatabaseConnMaxLifetime(),
+		ConnMaxIdleTime:        config.DatabaseConnMaxIdleTime(),
+		StatementCache:         config.DatabaseStatementCache(),
+		StatementCacheCapacity: config.DatabaseStatementCacheCapacity(),
+	})
+}
+
+// setupTelemetry exports the traces and metrics of the instrumentation when a collector is configured.
+// The returned function flushes them.
+func setupTelemetry(ctx context.Context, logger *slog.Logger, config *config.Config, serviceName string) (func(), error) {
+	endpoint := config.TelemetryEndpoint()
+	if endpoint == "" {
+		if config.DatabaseInstrument() {
+			logger.Warn("Database instrumentation is enabled without a telemetry endpoint, only slow queries are logged")
+		}
+		return func() {}, nil
+	}
+
+	shutdown, err := telemetry.Setup(ctx, telemetry.Config{
+		ServiceName: serviceName,
+		Endpoint:    endpoint,
+		Insecure:    config.TelemetryInsecure(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup telemetry: %w", err)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), telemetryShutdownTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logger.Error("Failed to flush telemetry", slog.String("error", err.Error()))
+		}
+	}, nil
+}
+
+// newDBWrapper wraps the database, instrumenting its queries when the config enables it.
+func newDBWrapper(logger *slog.Logger, config *config.Config, db *sql.DB) (*database.DBWrapper, error) {
+	if !config.DatabaseInstrument() {
+		return database.NewDBWrapper(db), nil
+	}
+
+	i, err := database.NewInstrumented(database.InstrumentedConfig{
+		Logger:        logger,
+		Handler:       database.NewSQLDBWrapper(db),
+		SlowThreshold: config.DatabaseSlowQueryThreshold(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to instrument database: %w", err)
+	}
+
+	return database.NewDBWithHandler(i), nil
+}
+
+// setupReplicas connects to the read replicas of the database. It returns nil without replicas.
+// The primary is the wrapper of the manager, so its queries are instrumented once.
+// The returned function closes the connections.
+func setupReplicas(ctx context.Context, logger *slog.Logger, config *config.Config, primary *database.DBWrapper) (*database.Replicas, func(), error) {
+	urls := config.DatabaseReplicaURLs()
+	if len(urls) == 0 {
+		return nil, func() {}, nil
+	}
+
+	var conns []*sql.DB
+	closeAll := func() {
+		for _, c := range conns {
+			if err := c.Close(); err != nil {
+				logger.Error("Failed to close replica", slog.String("error", err.Error()))
+			}
+		}
+	}
+
+	wrappers := make([]*database.DBWrapper, 0, len(urls))
+	for _, url := range urls {
+		c, err := connectDatabase(ctx, config, url)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to connect to replica: %w", err)
+		}
+		conns = append(conns, c)
+
+		w, err := newDBWrapper(logger, config, c)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		wrappers = append(wrappers, w)
+	}
+
+	replicas, err := database.NewReplicas(database.ReplicasConfig{
+		Logger:   logger,
+		Primary:  primary,
+		Replicas: wrappers,
+	})
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+
+	return replicas, closeAll, nil
+}
+
+// setupOutboxRelay sets up the relay that publishes the domain events of the account app.
+func setupOutboxRelay(logger *slog.Logger, db *sql.DB, sinks ...outbox.Sink) (*outbox.Relay, error) {
+	return outbox.NewRelay(outbox.Config{
+		Logger:    logger,
+		Clock:     time.Now,
+		DBManager: postgres.NewManager(db),
+		Sinks:     append([]outbox.Sink{outbox.LogSink{Logger: logger}}, sinks...),
+	})
+}
+
+// setupJobs sets up the runner of the background jobs: the maintenance jobs and the outbox relay,
+// which publishes the domain events to the log and the webhooks.
+func setupJobs(logger *slog.Logger, db *sql.DB) (*jobs.Runner, error) {
+	dispatcher, _, err := setupWebhooks(logger, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup webhooks: %w", err)
+	}
+
+	relay, err := setupOutboxRelay(logger, db, dispatcher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup outbox relay: %w", err)
+	}
+
+	m, err := maintenance.New(maintenance.Config{
+		Logger:    logger,
+		Clock:     time.Now,
+		DBManager: postgres.NewManager(db),
+		Publisher: relay,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	registry := jobs.NewRegistry()
+	if err = m.Register(registry); err != nil {
+		return nil, err
+	}
+
+	return jobs.NewRunner(jobs.Config{
+		Logger:    logger,
+		Clock:     time.Now,
+		DB:        database.NewDBWrapper(db),
+		Registry:  registry,
+		Schedules: m.Schedules(),
+	})
+}
+
+// setupTranslations sets up the service that translates the messages of conversations.
+// The glossaries of the organizations are consulted before the translator.
+func setupTranslations(logger *slog.Logger, dbManager storage.DBManager) (*translation.Service, error) {
+	repos := dbManager.Op()
+	return translation.NewService(translation.Config{
+		Logger:     logger,
+		Clock:      time.Now,
+		Translator: translation.NewDictionary(),
+		Memory:     glossary.NewMemory(glossary.NewReader(repos.Glossary, repos.GlossaryTerm, repos.GlossarySegment), glossary.DefaultMatchScore),
+		DBManager:  dbManager,
+	})
+}
+
+// setupEventFeed sets up the feed that follows the domain events of all account processes.
+func setupEventFeed(logger *slog.Logger, reader storage.OutboxEventReader, listen outbox.ListenFunc) (*outbox.Feed, error) {
+	return outbox.NewFeed(outbox.FeedConfig{
+		Logger: logger,
+		Reader: reader,
+		Listen: listen,
+	})
+}
+
+// setupWebhooks sets up the dispatcher that schedules webhook deliveries for domain events
+// and the deliverer that sends them.
+func setupWebhooks(logger *slog.Logger, db *sql.DB) (*webhook.Dispatcher, *webhook.Deliverer, error) {
+	dbManager := postgres.NewManager(db)
+	dispatcher := webhook.NewDispatcher(time.Now, uuidgen.Default(), dbManager)
+	deliverer, err := webhook.NewDeliverer(webhook.DelivererConfig{
+		Logger:    logger,
+		Clock:     time.Now,
+		GenUUID:   uuidgen.Default(),
+		DBManager: dbManager,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dispatcher, deliverer, nil
+}
+
 // setupRelayGrpcServer sets up a gRPC server for the relay service.
-func setupService(account *app.App) *server.Server {
+func setupService(config *config.Config, account *app.App, healthCheck server.HealthCheck) (*server.Server, error) {
+	trustedProxies, err := server.ParseTrustedProxies(config.TrustedProxies())
+	if err != nil {
+		return nil, err
+	}
+
 	resourceParser := resource.NewParser()
 	resourceParser.RegisterChild(domain.OrganizationCollection, domain.UserCollection)
-	return server.New(account, resourceParser)
+	resourceParser.RegisterChild(domain.UserCollection, domain.SessionCollection)
+	resourceParser.RegisterChild(domain.OrganizationCollection, domain.AuditEventCollection)
+	resourceParser.RegisterChild(domain.OrganizationCollection, domain.WebhookCollection)
+	resourceParser.RegisterChild(domain.WebhookCollection, domain.WebhookDeliveryCollection)
+	resourceParser.RegisterChild(domain.OrganizationCollection, domain.ConversationCollection)
+	resourceParser.RegisterChild(domain.ConversationCollection, domain.MessageCollection)
+	resourceParser.RegisterChild(domain.OrganizationCollection, domain.GlossaryCollection)
+	resourceParser.RegisterChild(domain.GlossaryCollection, domain.GlossaryTermCollection)
+	resourceParser.RegisterChild(domain.GlossaryCollection, domain.GlossarySegmentCollection)
+	return server.New(account, resourceParser, healthCheck, trustedProxies), nil
 }
 
 // setupGrpcServer sets up a gRPC server for the relay service.
-func setupServer(config *config.Config, serviceRegistrar func(grpc.ServiceRegistrar)) (*grpcserver.Server, error) {
+func setupServer(config *config.Config, serviceRegistrar func(grpc.ServiceRegistrar), onDrain func(), options ...grpc.ServerOption) (*grpcserver.Server, error) {
 	grpcPort, err := config.GRPCPort()
 	if err != nil {
 		return nil, err
@@ -191,54 +505,78 @@ func setupServer(config *config.Config, serviceRegistrar func(grpc.ServiceRegist
 
 	return grpcserver.New(
 		grpcserver.WithReflection(),
-		grpcserver.WithGrpcServer(grpc.NewServer(grpc.Creds(creds))),
+		grpcserver.WithGrpcServer(grpc.NewServer(append([]grpc.ServerOption{grpc.Creds(creds)}, options...)...)),
 		grpcserver.WithAddress(fmt.Sprintf(":%d", grpcPort)),
 		grpcserver.WithServiceRegistrar(serviceRegistrar),
+		grpcserver.WithDrainHook(onDrain),
+		grpcserver.WithDrainTimeout(drainTimeout),
 	), nil
 }
 
 // https://github.com/youngderekm/grpc-cookies-example/blob/master/cmd/gateway/gateway.go
 func gatewayMetadataAnnotator(_ context.Context, r *http.Request) metadata.MD {
-	// read token from cookie
-	cookie, err := r.Cookie("token")
+	md := metadata.MD{}
+
+	// read tokens from cookies
+	for _, name := range []string{"token", "refresh_token"} {
+		if cookie, err := r.Cookie(name); err == nil {
+			md.Set(name, cookie.Value)
+		}
+	}
+
+	return md
+}
+
+// setTokenCookies stores the access and refresh token in cookies.
+func setTokenCookies(r http.ResponseWriter, accessToken, refreshToken string) error {
+	tokenExp, err := token.ExpirationTime(accessToken)
 	if err != nil {
-		return nil
+		return err
+	}
+
+	http.SetCookie(r, &http.Cookie{
+		Name:     "token",
+		Value:    accessToken,
+		SameSite: http.SameSiteStrictMode,
+		Expires:  tokenExp,
+	})
+
+	refreshTokenExp, err := token.ExpirationTime(refreshToken)
+	if err != nil {
+		return err
 	}
 
-	return metadata.Pairs("token", cookie.Value)
+	http.SetCookie(r, &http.Cookie{
+		Name:     "refresh_token",
+		Value:    refreshToken,
+		Expires:  refreshTokenExp,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/v1/refresh",
+	})
+
+	return nil
 }
 
 func gatewayResponseModifier(_ context.Context, r http.ResponseWriter, m proto.Message) error {
-	// check if login response
-	if msg, ok := m.(*protoaccount.LoginUserResponse); ok {
-		tokenExp, err := token.ExpirationTime(msg.GetAccessToken())
-		if err != nil {
+	switch msg := m.(type) {
+	case *protoaccount.LoginUserResponse:
+		if err := setTokenCookies(r, msg.GetAccessToken(), msg.GetRefreshToken()); err != nil {
 			return err
 		}
 
-		http.SetCookie(r, &http.Cookie{
-			Name:     "token",
-			Value:    msg.GetAccessToken(),
-			SameSite: http.SameSiteStrictMode,
-			Expires:  tokenExp,
-		})
-
-		refreshTokenExp, err := token.ExpirationTime(msg.GetRefreshToken())
-		if err != nil {
+		msg.AccessToken = ""
+		msg.RefreshToken = ""
+	case *protoaccount.RefreshTokenResponse:
+		if err := setTokenCookies(r, msg.GetAccessToken(), msg.GetRefreshToken()); err != nil {
 			return err
 		}
 
-		http.SetCookie(r, &http.Cookie{
-			Name:     "refresh_token",
-			Value:    msg.GetRefreshToken(),
-			Expires:  refreshTokenExp,
-			HttpOnly: true,
-			SameSite: http.SameSiteStrictMode,
-			Path:     "/v1/refresh",
-		})
-
 		msg.AccessToken = ""
 		msg.RefreshToken = ""
+	case *protoaccount.LogoutUserResponse:
+		http.SetCookie(r, &http.Cookie{Name: "token", MaxAge: -1})
+		http.SetCookie(r, &http.Cookie{Name: "refresh_token", MaxAge: -1, Path: "/v1/refresh"})
 	}
 
 	return nil
@@ -278,9 +616,28 @@ func setupHTTPServer(ctx context.Context, config *config.Config) (*httpserver.Se
 		return nil, fmt.Errorf("failed to register gateway: %w", err)
 	}
 
+	// the streaming RPCs are served to browsers over WebSocket, next to the JSON gateway.
+	conn, err := grpc.NewClient(accountURL, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account client: %w", err)
+	}
+
+	bridge, err := gateway.New(gateway.Config{
+		Logger:         slog.Default(),
+		Client:         protoaccount.NewAccountServiceClient(conn),
+		AllowedOrigins: config.WebSocketAllowedOrigins(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create websocket bridge: %w", err)
+	}
+
+	handler := http.NewServeMux()
+	handler.Handle("/v1/ws/", bridge)
+	handler.Handle("/", mux)
+
 	return httpserver.New(
 		httpserver.WithAddr(fmt.Sprintf(":%d", port)),
-		httpserver.WithHandler(mux),
+		httpserver.WithHandler(handler),
 		httpserver.WithTimeouts(httpserver.Timeouts{
 			ReadTimeout:     readTimeout,
 			WriteTimeout:    writeTimeout,
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditEventCollection is the name of the audit event collection.
+const AuditEventCollection = "auditEvents"
+
+// AuditAction is the kind of security relevant change an audit event records.
+type AuditAction string
+
+func (a AuditAction) String() string { return string(a) }
+
+const (
+	AuditActionLoginSucceeded      AuditAction = "user.login_succeeded"
+	AuditActionLoginFailed         AuditAction = "user.login_failed"
+	AuditActionUserRegistered      AuditAction = "user.registered"
+	AuditActionUserCreated         AuditAction = "user.created"
+	AuditActionUserUpdated         AuditAction = "user.updated"
+	AuditActionUserRoleChanged     AuditAction = "user.role_changed"
+	AuditActionUserDeleted         AuditAction = "user.deleted"
+	AuditActionUserImpersonated    AuditAction = "user.impersonated"
+	AuditActionOrganizationCreated AuditAction = "organization.created"
+	AuditActionOrganizationUpdated AuditAction = "organization.updated"
+	AuditActionSessionRevoked      AuditAction = "session.revoked"
+	AuditActionRefreshTokenReused  AuditAction = "session.refresh_token_reused"
+	AuditActionWebhookCreated      AuditAction = "webhook.created"
+	AuditActionWebhookUpdated      AuditAction = "webhook.updated"
+	AuditActionWebhookDeleted      AuditAction = "webhook.deleted"
+	AuditActionGlossaryCreated     AuditAction = "glossary.created"
+	AuditActionGlossaryUpdated     AuditAction = "glossary.updated"
+	AuditActionGlossaryDeleted     AuditAction = "glossary.deleted"
+	AuditActionGlossaryImported    AuditAction = "glossary.imported"
+)
+
+// SystemActor is the actor of changes made by the system itself, such as bootstrapping.
+const SystemActor = "system"
+
+// AuditEvent is a security relevant change. Events are chained: each event
+// contains the hash of the event before it, so removing or altering an event breaks the chain.
+type AuditEvent struct {
+	ID             int64
+	OrganizationID uuid.UUID // OrganizationID is the organization the event belongs to, if any.
+	Actor          string    // Actor is the resource name of who made the change.
+	Action         AuditAction
+	Resource       string // Resource is the resource name of what was changed.
+	IPAddress      string
+	RequestID      string
+	Details        map[string]string
+	CreateTime     time.Time
+	PreviousHash   string
+	Hash           string
+}
+
+func (e *AuditEvent) ToProto(in *protoaccount.AuditEvent) error {
+	in.Name = fmt.Sprintf("%s/%s/%d", OrganizationName(e.OrganizationID), AuditEventCollection, e.ID)
+	in.Actor = e.Actor
+	in.Action = e.Action.String()
+	in.Resource = e.Resource
+	in.IpAddress = e.IPAddress
+	in.RequestId = e.RequestID
+	in.Details = e.Details
+	in.CreateTime = timestamppb.New(e.CreateTime)
+	in.PreviousHash = e.PreviousHash
+	in.Hash = e.Hash
+	return nil
+}
+
+// ToStorage maps an AuditEvent to a storage.AuditEvent.
+func (e *AuditEvent) ToStorage(out *storage.AuditEvent) error {
+	for _, field := range storage.AuditEventFields() {
+		switch field {
+		case storage.AuditEventID:
+			out.ID = e.ID
+		case storage.AuditEventOrganizationID:
+			out.OrganizationID = uuid.NullUUID{UUID: e.OrganizationID, Valid: e.OrganizationID != uuid.Nil}
+		case storage.AuditEventActor:
+			out.Actor = e.Actor
+		case storage.AuditEventAction:
+			out.Action = e.Action.String()
+		case storage.AuditEventResource:
+			out.Resource = e.Resource
+		case storage.AuditEventIPAddress:
+			out.IPAddress = e.IPAddress
+		case storage.AuditEventRequestID:
+			out.RequestID = e.RequestID
+		case storage.AuditEventDetails:
+			out.Details = e.Details
+		case storage.AuditEventCreateTime:
+			out.CreateTime = e.CreateTime
+		case storage.AuditEventPreviousHash:
+			out.PreviousHash = e.PreviousHash
+		case storage.AuditEventHash:
+			out.Hash = e.Hash
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.AuditEvent to an AuditEvent.
+func (e *AuditEvent) FromStorage(in *storage.AuditEvent) error {
+	for _, field := range storage.AuditEventFields() {
+		switch field {
+		case storage.AuditEventID:
+			e.ID = in.ID
+		case storage.AuditEventOrganizationID:
+			e.OrganizationID = in.OrganizationID.UUID
+		case storage.AuditEventActor:
+			e.Actor = in.Actor
+		case storage.AuditEventAction:
+			e.Action = AuditAction(in.Action)
+		case storage.AuditEventResource:
+			e.Resource = in.Resource
+		case storage.AuditEventIPAddress:
+			e.IPAddress = in.IPAddress
+		case storage.AuditEventRequestID:
+			e.RequestID = in.RequestID
+		case storage.AuditEventDetails:
+			e.Details = in.Details
+		case storage.AuditEventCreateTime:
+			e.CreateTime = in.CreateTime
+		case storage.AuditEventPreviousHash:
+			e.PreviousHash = in.PreviousHash
+		case storage.AuditEventHash:
+			e.Hash = in.Hash
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
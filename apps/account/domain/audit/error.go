@@ -0,0 +1,4 @@
+package audit
+
+// Error defines the audit domain errors.
+type Error error
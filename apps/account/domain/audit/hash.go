@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+)
+
+// GenesisHash is the previous hash of the first event in the log.
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+var (
+	// ErrChainBroken is returned when the audit log has been tampered with.
+	ErrChainBroken Error = errors.New("audit chain is broken")
+)
+
+// separator separates the fields of an event in the hashed payload.
+const separator = "\x1f"
+
+// Hash computes the hash of an event. It covers every field of the event and the previous hash.
+func Hash(e *domain.AuditEvent) string {
+	keys := make([]string, 0, len(e.Details))
+	for k := range e.Details {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	details := make([]string, 0, len(keys))
+	for _, k := range keys {
+		details = append(details, strconv.Quote(k)+"="+strconv.Quote(e.Details[k]))
+	}
+
+	payload := strings.Join([]string{
+		strconv.FormatInt(e.ID, 10),
+		e.OrganizationID.String(),
+		e.Actor,
+		e.Action.String(),
+		e.Resource,
+		e.IPAddress,
+		e.RequestID,
+		strings.Join(details, ","),
+		e.CreateTime.UTC().Format(time.RFC3339Nano),
+		e.PreviousHash,
+	}, separator)
+
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks that events, ordered by ascending id, form an unbroken chain
+// starting after previous. Pass nil as previous when events starts at the beginning of the log.
+func Verify(previous *domain.AuditEvent, events []*domain.AuditEvent) error {
+	prevID, prevHash := int64(0), GenesisHash
+	if previous != nil {
+		prevID, prevHash = previous.ID, previous.Hash
+	}
+
+	for _, e := range events {
+		if e.ID != prevID+1 {
+			return fmt.Errorf("event %d follows event %d: %w", e.ID, prevID, ErrChainBroken)
+		}
+
+		if e.PreviousHash != prevHash {
+			return fmt.Errorf("event %d does not link to event %d: %w", e.ID, prevID, ErrChainBroken)
+		}
+
+		if Hash(e) != e.Hash {
+			return fmt.Errorf("event %d has been altered: %w", e.ID, ErrChainBroken)
+		}
+
+		prevID, prevHash = e.ID, e.Hash
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+
+	auditMock "github.com/extreme-business/lingo/apps/account/storage/mock/audit"
+)
+
+// newLog returns a mock repository that keeps the audit log in a slice.
+func newLog() *auditMock.Repository {
+	var events []*storage.AuditEvent
+	return &auditMock.Repository{
+		LockFunc: func(context.Context) error { return nil },
+		LastFunc: func(context.Context) (*storage.AuditEvent, error) {
+			if len(events) == 0 {
+				return nil, storage.ErrAuditEventNotFound
+			}
+			return events[len(events)-1], nil
+		},
+		CreateFunc: func(_ context.Context, e *storage.AuditEvent) (*storage.AuditEvent, error) {
+			events = append(events, e)
+			return e, nil
+		},
+	}
+}
+
+func appendEvents(t *testing.T, n int) []*domain.AuditEvent {
+	t.Helper()
+
+	repo := newLog()
+	w := audit.NewWriter(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 1, time.UTC) }, repo)
+	ctx := audit.NewContext(context.Background(), audit.Source{RequestID: "request", IPAddress: "127.0.0.1"})
+
+	var out []*domain.AuditEvent
+	for i := 0; i < n; i++ {
+		e, err := w.Append(ctx, &domain.AuditEvent{
+			OrganizationID: uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264"),
+			Actor:          domain.SystemActor,
+			Action:         domain.AuditActionUserUpdated,
+			Details:        map[string]string{"fields": "email"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, e)
+	}
+
+	return out
+}
+
+func TestWriter_Append(t *testing.T) {
+	t.Run("should chain events", func(t *testing.T) {
+		events := appendEvents(t, 3)
+
+		if events[0].PreviousHash != audit.GenesisHash {
+			t.Errorf("expected the first event to link to the genesis hash, got %q", events[0].PreviousHash)
+		}
+
+		for i, e := range events {
+			if e.ID != int64(i+1) {
+				t.Errorf("expected id %d, got %d", i+1, e.ID)
+			}
+
+			if i > 0 && e.PreviousHash != events[i-1].Hash {
+				t.Errorf("event %d does not link to the previous event", e.ID)
+			}
+		}
+	})
+
+	t.Run("should take the request source from the context", func(t *testing.T) {
+		e := appendEvents(t, 1)[0]
+		if e.RequestID != "request" || e.IPAddress != "127.0.0.1" {
+			t.Errorf("expected the source of the context, got %q %q", e.RequestID, e.IPAddress)
+		}
+
+		if e.CreateTime.Nanosecond() != 0 {
+			t.Errorf("expected the create time to be truncated to microseconds, got %v", e.CreateTime)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("should accept an unbroken chain", func(t *testing.T) {
+		if err := audit.Verify(nil, appendEvents(t, 3)); err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+	})
+
+	t.Run("should detect an altered event", func(t *testing.T) {
+		events := appendEvents(t, 3)
+		events[1].Actor = "someone"
+		if err := audit.Verify(nil, events); !errors.Is(err, audit.ErrChainBroken) {
+			t.Errorf("Verify() error = %v, want %v", err, audit.ErrChainBroken)
+		}
+	})
+
+	t.Run("should detect a removed event", func(t *testing.T) {
+		events := appendEvents(t, 3)
+		if err := audit.Verify(nil, []*domain.AuditEvent{events[0], events[2]}); !errors.Is(err, audit.ErrChainBroken) {
+			t.Errorf("Verify() error = %v, want %v", err, audit.ErrChainBroken)
+		}
+	})
+
+	t.Run("should continue after a previous event", func(t *testing.T) {
+		events := appendEvents(t, 3)
+		if err := audit.Verify(events[0], events[1:]); err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+type Reader struct {
+	reader storage.AuditEventReader
+}
+
+func NewReader(storage storage.AuditEventReader) *Reader {
+	return &Reader{reader: storage}
+}
+
+// ListQuery filters the audit events of an organization.
+type ListQuery struct {
+	OrganizationID uuid.UUID
+	Start          time.Time // Start is inclusive, a zero value leaves the range open.
+	End            time.Time // End is exclusive, a zero value leaves the range open.
+	PageSize       int
+	Before         int64 // Before only lists events older than this id, 0 starts at the newest event.
+}
+
+// List lists audit events, newest first.
+// It returns the id to pass as Before to get the next page, or 0 if there are no more events.
+func (r *Reader) List(ctx context.Context, q ListQuery) ([]*domain.AuditEvent, int64, Error) {
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	conditions := []storage.Condition{
+		storage.AuditEventByOrganizationIDCondition{OrganizationID: q.OrganizationID},
+		storage.AuditEventCreateTimeCondition{Start: q.Start, End: q.End},
+	}
+	if q.Before > 0 {
+		conditions = append(conditions, storage.AuditEventIDBeforeCondition{ID: q.Before})
+	}
+
+	// fetch one more event than requested to know if there is a next page.
+	events, err := r.reader.List(ctx, storage.Pagination{Limit: pageSize + 1}, storage.AuditEventOrderBy{
+		{Field: storage.AuditEventID, Direction: storage.DESC},
+	}, conditions...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var next int64
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		next = events[pageSize-1].ID
+	}
+
+	out := make([]*domain.AuditEvent, 0, len(events))
+	for _, event := range events {
+		var e domain.AuditEvent
+		if err = e.FromStorage(event); err != nil {
+			return nil, 0, err
+		}
+		out = append(out, &e)
+	}
+
+	return out, next, nil
+}
+
+// Verify walks the whole audit log and checks that the hash chain is unbroken.
+// It returns the number of verified events.
+func (r *Reader) Verify(ctx context.Context) (int64, Error) {
+	var previous *domain.AuditEvent
+	var n int64
+	for {
+		events, err := r.reader.List(ctx, storage.Pagination{Limit: maxPageSize}, storage.AuditEventOrderBy{
+			{Field: storage.AuditEventID, Direction: storage.ASC},
+		}, storage.AuditEventIDAfterCondition{ID: n})
+		if err != nil {
+			return n, fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		if len(events) == 0 {
+			return n, nil
+		}
+
+		page := make([]*domain.AuditEvent, 0, len(events))
+		for _, event := range events {
+			var e domain.AuditEvent
+			if err = e.FromStorage(event); err != nil {
+				return n, err
+			}
+			page = append(page, &e)
+		}
+
+		if err = Verify(previous, page); err != nil {
+			return n, err
+		}
+
+		previous = page[len(page)-1]
+		n = previous.ID
+	}
+}
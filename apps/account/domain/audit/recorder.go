@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+// Recorder records events in their own transaction.
+type Recorder struct {
+	c         func() time.Time // c is the clock function.
+	dbManager storage.DBManager
+}
+
+func NewRecorder(c func() time.Time, dbManager storage.DBManager) *Recorder {
+	return &Recorder{
+		c:         c,
+		dbManager: dbManager,
+	}
+}
+
+// Record appends the event to the audit log.
+func (r *Recorder) Record(ctx context.Context, e *domain.AuditEvent) Error {
+	return r.dbManager.BeginOp(ctx, func(ctx context.Context, repos storage.Repositories) error {
+		_, err := NewWriter(r.c, repos.AuditEvent).Append(ctx, e)
+		return err
+	})
+}
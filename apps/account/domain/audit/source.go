@@ -0,0 +1,22 @@
+package audit
+
+import "context"
+
+// Source describes the request that caused an event.
+type Source struct {
+	RequestID string
+	IPAddress string
+}
+
+type sourceKey struct{}
+
+// NewContext returns a new context that carries the source of the request.
+func NewContext(ctx context.Context, s Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, s)
+}
+
+// SourceFromContext returns the source stored in ctx, if any.
+func SourceFromContext(ctx context.Context) (Source, bool) {
+	s, ok := ctx.Value(sourceKey{}).(Source)
+	return s, ok
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+// Writer appends events to the audit log.
+// Append must run inside a transaction so the lock is held until the event is committed.
+type Writer struct {
+	c  func() time.Time // c is the clock function.
+	rw storage.AuditEventRepository
+}
+
+func NewWriter(c func() time.Time, rw storage.AuditEventRepository) *Writer {
+	return &Writer{
+		c:  c,
+		rw: rw,
+	}
+}
+
+// Append links the event to the last event in the log and stores it.
+// The request source is taken from the context when the event does not set it.
+func (w *Writer) Append(ctx context.Context, e *domain.AuditEvent) (*domain.AuditEvent, Error) {
+	if err := w.rw.Lock(ctx); err != nil {
+		return nil, err
+	}
+
+	e.ID = 1
+	e.PreviousHash = GenesisHash
+	last, err := w.rw.Last(ctx)
+	switch {
+	case err == nil:
+		e.ID = last.ID + 1
+		e.PreviousHash = last.Hash
+	case !errors.Is(err, storage.ErrAuditEventNotFound):
+		return nil, fmt.Errorf("failed to get last audit event: %w", err)
+	}
+
+	if s, ok := SourceFromContext(ctx); ok {
+		if e.RequestID == "" {
+			e.RequestID = s.RequestID
+		}
+		if e.IPAddress == "" {
+			e.IPAddress = s.IPAddress
+		}
+	}
+
+	// the database stores microseconds, truncate so the hash can be recomputed from stored events.
+	e.CreateTime = w.c().UTC().Truncate(time.Microsecond)
+	e.Hash = Hash(e)
+
+	in := &storage.AuditEvent{}
+	if err = e.ToStorage(in); err != nil {
+		return nil, err
+	}
+
+	in, err = w.rw.Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.AuditEvent{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
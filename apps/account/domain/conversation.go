@@ -0,0 +1,303 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConversationCollection is the name of the conversation collection.
+const ConversationCollection = "conversations"
+
+// MessageCollection is the name of the message collection.
+const MessageCollection = "messages"
+
+// ConversationType tells a direct conversation between two users from a group conversation.
+type ConversationType string
+
+func (t ConversationType) String() string { return string(t) }
+
+const (
+	ConversationTypeDirect ConversationType = "direct"
+	ConversationTypeGroup  ConversationType = "group"
+)
+
+// Conversation is a chat between users of an organization.
+type Conversation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Type           ConversationType
+	Title          string
+	CreatorID      uuid.UUID
+	Participants   []uuid.UUID // Participants are the ids of the users in the conversation.
+	CreateTime     time.Time
+	UpdateTime     time.Time // UpdateTime is the time of the last message.
+}
+
+// ConversationName returns the resource name of a conversation.
+func ConversationName(organizationID, conversationID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", OrganizationName(organizationID), ConversationCollection, conversationID)
+}
+
+// ToProto maps the conversation to its proto representation.
+func (c *Conversation) ToProto(in *protoaccount.Conversation) error {
+	in.Name = ConversationName(c.OrganizationID, c.ID)
+	in.Type = c.Type.String()
+	in.Title = c.Title
+	in.Participants = make([]string, 0, len(c.Participants))
+	for _, id := range c.Participants {
+		in.Participants = append(in.Participants, UserName(c.OrganizationID, id))
+	}
+	in.Creator = UserName(c.OrganizationID, c.CreatorID)
+	in.CreateTime = timestamppb.New(c.CreateTime)
+	in.UpdateTime = timestamppb.New(c.UpdateTime)
+	return nil
+}
+
+// ToStorage maps a Conversation to a storage.Conversation. The participants are stored separately.
+func (c *Conversation) ToStorage(out *storage.Conversation) error {
+	for _, field := range storage.ConversationFields() {
+		switch field {
+		case storage.ConversationID:
+			out.ID = c.ID
+		case storage.ConversationOrganizationID:
+			out.OrganizationID = c.OrganizationID
+		case storage.ConversationType:
+			out.Type = c.Type.String()
+		case storage.ConversationTitle:
+			out.Title = c.Title
+		case storage.ConversationCreatorID:
+			out.CreatorID = c.CreatorID
+		case storage.ConversationCreateTime:
+			out.CreateTime = c.CreateTime
+		case storage.ConversationUpdateTime:
+			out.UpdateTime = c.UpdateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.Conversation to a Conversation. The participants are left as they are.
+func (c *Conversation) FromStorage(in *storage.Conversation) error {
+	for _, field := range storage.ConversationFields() {
+		switch field {
+		case storage.ConversationID:
+			c.ID = in.ID
+		case storage.ConversationOrganizationID:
+			c.OrganizationID = in.OrganizationID
+		case storage.ConversationType:
+			c.Type = ConversationType(in.Type)
+		case storage.ConversationTitle:
+			c.Title = in.Title
+		case storage.ConversationCreatorID:
+			c.CreatorID = in.CreatorID
+		case storage.ConversationCreateTime:
+			c.CreateTime = in.CreateTime
+		case storage.ConversationUpdateTime:
+			c.UpdateTime = in.UpdateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// Message is a message sent in a conversation.
+type Message struct {
+	ID             uuid.UUID
+	Sequence       int64 // Sequence orders the messages of all conversations.
+	ConversationID uuid.UUID
+	SenderID       uuid.UUID
+	Body           string
+	Language       string // Language is the language the body is written in, a BCP 47 tag. Empty when unknown.
+	CreateTime     time.Time
+	UpdateTime     time.Time
+	EditTime       time.Time
+	DeleteTime     time.Time
+	// CreatePosition and Position order the creation and the last change of the messages by commit.
+	CreatePosition int64
+	Position       int64
+	// Translation is the body in the language the message was requested in, if it differs from Language.
+	Translation *MessageTranslation
+}
+
+// MessageName returns the resource name of a message.
+func MessageName(organizationID, conversationID, messageID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", ConversationName(organizationID, conversationID), MessageCollection, messageID)
+}
+
+// Edited reports whether the body was changed after the message was sent.
+func (m *Message) Edited() bool {
+	return !m.EditTime.IsZero()
+}
+
+// Deleted reports whether the message was deleted.
+func (m *Message) Deleted() bool {
+	return !m.DeleteTime.IsZero()
+}
+
+// ToProto maps the message to its proto representation.
+// The organization is needed to build the resource names.
+func (m *Message) ToProto(organizationID uuid.UUID, in *protoaccount.Message) error {
+	in.Name = MessageName(organizationID, m.ConversationID, m.ID)
+	in.Sender = UserName(organizationID, m.SenderID)
+	in.Body = m.Body
+	in.Language = m.Language
+	if m.Translation != nil {
+		in.Translation = &protoaccount.MessageTranslation{
+			Language: m.Translation.Language,
+			Body:     m.Translation.Body,
+		}
+	}
+	in.Edited = m.Edited()
+	in.Deleted = m.Deleted()
+	in.CreateTime = timestamppb.New(m.CreateTime)
+	in.UpdateTime = timestamppb.New(m.UpdateTime)
+	if m.Edited() {
+		in.EditTime = timestamppb.New(m.EditTime)
+	}
+	if m.Deleted() {
+		in.DeleteTime = timestamppb.New(m.DeleteTime)
+	}
+	return nil
+}
+
+// ToStorage maps a Message to a storage.Message.
+func (m *Message) ToStorage(out *storage.Message) error {
+	for _, field := range storage.MessageFields() {
+		switch field {
+		case storage.MessageID:
+			out.ID = m.ID
+		case storage.MessageSequence:
+			out.Sequence = m.Sequence
+		case storage.MessageConversationID:
+			out.ConversationID = m.ConversationID
+		case storage.MessageSenderID:
+			out.SenderID = m.SenderID
+		case storage.MessageBody:
+			out.Body = m.Body
+		case storage.MessageLanguage:
+			out.Language = m.Language
+		case storage.MessageCreateTime:
+			out.CreateTime = m.CreateTime
+		case storage.MessageUpdateTime:
+			out.UpdateTime = m.UpdateTime
+		case storage.MessageEditTime:
+			out.EditTime.Time = m.EditTime
+			out.EditTime.Valid = !m.EditTime.IsZero()
+		case storage.MessageDeleteTime:
+			out.DeleteTime.Time = m.DeleteTime
+			out.DeleteTime.Valid = !m.DeleteTime.IsZero()
+		case storage.MessageCreatePosition:
+			out.CreatePosition = m.CreatePosition
+		case storage.MessagePosition:
+			out.Position = m.Position
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.Message to a Message.
+func (m *Message) FromStorage(in *storage.Message) error {
+	for _, field := range storage.MessageFields() {
+		switch field {
+		case storage.MessageID:
+			m.ID = in.ID
+		case storage.MessageSequence:
+			m.Sequence = in.Sequence
+		case storage.MessageConversationID:
+			m.ConversationID = in.ConversationID
+		case storage.MessageSenderID:
+			m.SenderID = in.SenderID
+		case storage.MessageBody:
+			m.Body = in.Body
+		case storage.MessageLanguage:
+			m.Language = in.Language
+		case storage.MessageCreateTime:
+			m.CreateTime = in.CreateTime
+		case storage.MessageUpdateTime:
+			m.UpdateTime = in.UpdateTime
+		case storage.MessageEditTime:
+			m.EditTime = in.EditTime.Time
+		case storage.MessageDeleteTime:
+			m.DeleteTime = in.DeleteTime.Time
+		case storage.MessageCreatePosition:
+			m.CreatePosition = in.CreatePosition
+		case storage.MessagePosition:
+			m.Position = in.Position
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// MessageTranslation is the body of a message translated into another language.
+type MessageTranslation struct {
+	MessageID         uuid.UUID
+	Language          string
+	Body              string
+	MessageUpdateTime time.Time // MessageUpdateTime is the update time of the message that was translated.
+	CreateTime        time.Time
+}
+
+// Current reports whether the translation was made from the current version of the message.
+func (t *MessageTranslation) Current(m *Message) bool {
+	return t.MessageID == m.ID && t.MessageUpdateTime.Equal(m.UpdateTime)
+}
+
+// ToStorage maps a MessageTranslation to a storage.MessageTranslation.
+func (t *MessageTranslation) ToStorage(out *storage.MessageTranslation) error {
+	for _, field := range storage.MessageTranslationFields() {
+		switch field {
+		case storage.MessageTranslationMessageID:
+			out.MessageID = t.MessageID
+		case storage.MessageTranslationLanguage:
+			out.Language = t.Language
+		case storage.MessageTranslationBody:
+			out.Body = t.Body
+		case storage.MessageTranslationMessageUpdateTime:
+			out.MessageUpdateTime = t.MessageUpdateTime
+		case storage.MessageTranslationCreateTime:
+			out.CreateTime = t.CreateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.MessageTranslation to a MessageTranslation.
+func (t *MessageTranslation) FromStorage(in *storage.MessageTranslation) error {
+	for _, field := range storage.MessageTranslationFields() {
+		switch field {
+		case storage.MessageTranslationMessageID:
+			t.MessageID = in.MessageID
+		case storage.MessageTranslationLanguage:
+			t.Language = in.Language
+		case storage.MessageTranslationBody:
+			t.Body = in.Body
+		case storage.MessageTranslationMessageUpdateTime:
+			t.MessageUpdateTime = in.MessageUpdateTime
+		case storage.MessageTranslationCreateTime:
+			t.CreateTime = in.CreateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,4 @@
+package conversation
+
+// Error defines the conversation domain errors.
+type Error error
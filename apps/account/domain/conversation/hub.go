@@ -0,0 +1,154 @@
+package conversation
+
+import (
+	"sync"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/google/uuid"
+)
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	EventMessageCreated EventType = "message_created"
+	EventMessageUpdated EventType = "message_updated"
+	EventMessageDeleted EventType = "message_deleted"
+	EventTyping         EventType = "typing"
+	EventRead           EventType = "read"
+)
+
+// Event is something that happened in a conversation.
+type Event struct {
+	Type           EventType
+	ConversationID uuid.UUID
+	UserID         uuid.UUID       // UserID is the sender of a message, or the user that is typing or has read.
+	Message        *domain.Message // Message is set for the message events.
+	MessageID      uuid.UUID       // MessageID is the message that was read.
+}
+
+// Ephemeral reports whether the event may be dropped for a subscriber that falls behind.
+// Losing a typing indicator is harmless, losing a message is not.
+func (e Event) Ephemeral() bool {
+	return e.Type == EventTyping
+}
+
+// Hub fans out the events of a conversation to the subscriptions that follow it.
+// The hub is in-process, so it only reaches the subscriptions of the same server.
+type Hub struct {
+	mu            sync.RWMutex
+	conversations map[uuid.UUID]map[*Subscription]struct{}
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		conversations: make(map[uuid.UUID]map[*Subscription]struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Publish sends the event to the subscriptions of its conversation without blocking.
+// A subscription that has no room for an event that is not ephemeral is marked as lagged.
+func (h *Hub) Publish(e Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for s := range h.conversations[e.ConversationID] {
+		select {
+		case s.events <- e:
+		default:
+			if !e.Ephemeral() {
+				s.lagOnce.Do(func() { close(s.lagged) })
+			}
+		}
+	}
+}
+
+// Subscribe returns a subscription that buffers up to bufferSize events.
+// It follows no conversation until they are added.
+func (h *Hub) Subscribe(bufferSize int) *Subscription {
+	return &Subscription{
+		hub:           h,
+		events:        make(chan Event, bufferSize),
+		lagged:        make(chan struct{}),
+		conversations: make(map[uuid.UUID]struct{}),
+	}
+}
+
+// Close tells the subscriptions the hub stops, so they can reconnect to another server.
+func (h *Hub) Close() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
+
+// Done is closed when the hub is closed.
+func (h *Hub) Done() <-chan struct{} {
+	return h.done
+}
+
+// Subscription receives the events of the conversations it follows.
+type Subscription struct {
+	hub           *Hub
+	events        chan Event
+	lagged        chan struct{}
+	lagOnce       sync.Once
+	conversations map[uuid.UUID]struct{} // guarded by the mutex of the hub.
+}
+
+// Events returns the events of the followed conversations.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Lagged is closed when an event was dropped because the subscription fell behind.
+func (s *Subscription) Lagged() <-chan struct{} {
+	return s.lagged
+}
+
+// Add follows the conversations.
+func (s *Subscription) Add(conversationIDs ...uuid.UUID) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	for _, id := range conversationIDs {
+		subscriptions, ok := s.hub.conversations[id]
+		if !ok {
+			subscriptions = make(map[*Subscription]struct{})
+			s.hub.conversations[id] = subscriptions
+		}
+		subscriptions[s] = struct{}{}
+		s.conversations[id] = struct{}{}
+	}
+}
+
+// Remove stops following the conversations.
+func (s *Subscription) Remove(conversationIDs ...uuid.UUID) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	for _, id := range conversationIDs {
+		s.remove(id)
+	}
+}
+
+// Close stops following all conversations.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	for id := range s.conversations {
+		s.remove(id)
+	}
+}
+
+// remove stops following a conversation, the caller holds the lock of the hub.
+func (s *Subscription) remove(conversationID uuid.UUID) {
+	delete(s.conversations, conversationID)
+	if subscriptions, ok := s.hub.conversations[conversationID]; ok {
+		delete(subscriptions, s)
+		if len(subscriptions) == 0 {
+			delete(s.hub.conversations, conversationID)
+		}
+	}
+}
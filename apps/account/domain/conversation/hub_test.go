@@ -0,0 +1,101 @@
+package conversation_test
+
+import (
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/google/uuid"
+)
+
+func TestHub_Publish(t *testing.T) {
+	t.Run("should fan out events to the subscriptions of the conversation", func(t *testing.T) {
+		h := conversation.NewHub()
+		conversationID, otherID := uuid.New(), uuid.New()
+
+		a := h.Subscribe(1)
+		a.Add(conversationID)
+		b := h.Subscribe(1)
+		b.Add(otherID)
+
+		h.Publish(conversation.Event{Type: conversation.EventTyping, ConversationID: conversationID})
+
+		select {
+		case e := <-a.Events():
+			if e.ConversationID != conversationID {
+				t.Errorf("expected an event of %s, got %s", conversationID, e.ConversationID)
+			}
+		default:
+			t.Error("expected an event")
+		}
+
+		select {
+		case e := <-b.Events():
+			t.Errorf("expected no event, got %v", e)
+		default:
+		}
+	})
+
+	t.Run("should not send events after a subscription is removed", func(t *testing.T) {
+		h := conversation.NewHub()
+		conversationID := uuid.New()
+
+		s := h.Subscribe(1)
+		s.Add(conversationID)
+		s.Remove(conversationID)
+
+		h.Publish(conversation.Event{Type: conversation.EventMessageCreated, ConversationID: conversationID})
+
+		select {
+		case e := <-s.Events():
+			t.Errorf("expected no event, got %v", e)
+		default:
+		}
+	})
+
+	t.Run("should drop ephemeral events for a full subscription", func(t *testing.T) {
+		h := conversation.NewHub()
+		conversationID := uuid.New()
+
+		s := h.Subscribe(1)
+		s.Add(conversationID)
+
+		h.Publish(conversation.Event{Type: conversation.EventTyping, ConversationID: conversationID})
+		h.Publish(conversation.Event{Type: conversation.EventTyping, ConversationID: conversationID})
+
+		select {
+		case <-s.Lagged():
+			t.Error("expected the subscription not to lag")
+		default:
+		}
+	})
+
+	t.Run("should mark a full subscription as lagged", func(t *testing.T) {
+		h := conversation.NewHub()
+		conversationID := uuid.New()
+
+		s := h.Subscribe(1)
+		s.Add(conversationID)
+
+		h.Publish(conversation.Event{Type: conversation.EventMessageCreated, ConversationID: conversationID})
+		h.Publish(conversation.Event{Type: conversation.EventMessageCreated, ConversationID: conversationID})
+		h.Publish(conversation.Event{Type: conversation.EventMessageCreated, ConversationID: conversationID})
+
+		select {
+		case <-s.Lagged():
+		default:
+			t.Error("expected the subscription to lag")
+		}
+	})
+}
+
+func TestHub_Close(t *testing.T) {
+	h := conversation.NewHub()
+	h.Close()
+	h.Close()
+
+	select {
+	case <-h.Done():
+	default:
+		t.Error("expected the hub to be done")
+	}
+}
@@ -0,0 +1,176 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrConversationNotFound Error = errors.New("conversation not found")
+	ErrMessageNotFound      Error = errors.New("message not found")
+)
+
+type Reader struct {
+	conversations storage.ConversationReader
+	participants  storage.ParticipantReader
+	messages      storage.MessageReader
+}
+
+func NewReader(conversations storage.ConversationReader, participants storage.ParticipantReader, messages storage.MessageReader) *Reader {
+	return &Reader{
+		conversations: conversations,
+		participants:  participants,
+		messages:      messages,
+	}
+}
+
+// Get gets a conversation with its participants.
+func (r *Reader) Get(ctx context.Context, id uuid.UUID) (*domain.Conversation, Error) {
+	in, err := r.conversations.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrConversationNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, err
+	}
+
+	var c = new(domain.Conversation)
+	if err = c.FromStorage(in); err != nil {
+		return nil, err
+	}
+
+	if c.Participants, err = r.listParticipants(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ListByParticipant lists the conversations of an organization a user participates in, most recently active first.
+func (r *Reader) ListByParticipant(ctx context.Context, organizationID, userID uuid.UUID, p storage.Pagination) ([]*domain.Conversation, Error) {
+	conversations, err := r.conversations.List(ctx, p, storage.ConversationOrderBy{
+		{Field: storage.ConversationUpdateTime, Direction: storage.DESC},
+		{Field: storage.ConversationID, Direction: storage.DESC},
+	},
+		storage.ConversationByOrganizationIDCondition{OrganizationID: organizationID},
+		storage.ConversationByParticipantCondition{UserID: userID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Conversation, 0, len(conversations))
+	for _, in := range conversations {
+		var c domain.Conversation
+		if err = c.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		if c.Participants, err = r.listParticipants(ctx, c.ID); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &c)
+	}
+
+	return out, nil
+}
+
+// IsParticipant reports whether the user participates in the conversation.
+func (r *Reader) IsParticipant(ctx context.Context, conversationID, userID uuid.UUID) (bool, Error) {
+	if _, err := r.participants.Get(ctx, conversationID, userID); err != nil {
+		if errors.Is(err, storage.ErrParticipantNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// listParticipants lists the ids of the participants of a conversation, in the order they joined.
+func (r *Reader) listParticipants(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error) {
+	participants, err := r.participants.List(ctx, storage.Pagination{}, storage.ParticipantOrderBy{
+		{Field: storage.ParticipantJoinTime, Direction: storage.ASC},
+		{Field: storage.ParticipantUserID, Direction: storage.ASC},
+	}, storage.ParticipantByConversationIDCondition{ConversationID: conversationID})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(participants))
+	for _, p := range participants {
+		ids = append(ids, p.UserID)
+	}
+
+	return ids, nil
+}
+
+func (r *Reader) GetMessage(ctx context.Context, id uuid.UUID) (*domain.Message, Error) {
+	in, err := r.messages.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	var m = new(domain.Message)
+	return m, m.FromStorage(in)
+}
+
+// ListMessages lists the messages of a conversation, newest first.
+// Only messages with a sequence lower than before are listed, unless before is 0.
+func (r *Reader) ListMessages(ctx context.Context, conversationID uuid.UUID, before int64, limit int) ([]*domain.Message, Error) {
+	conditions := []storage.Condition{storage.MessageByConversationIDCondition{ConversationID: conversationID}}
+	if before > 0 {
+		conditions = append(conditions, storage.MessageBeforeSequenceCondition{Sequence: before})
+	}
+
+	messages, err := r.messages.List(ctx, storage.Pagination{Limit: limit}, storage.MessageOrderBy{
+		{Field: storage.MessageSequence, Direction: storage.DESC},
+	}, conditions...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Message, 0, len(messages))
+	for _, in := range messages {
+		var m domain.Message
+		if err = m.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &m)
+	}
+
+	return out, nil
+}
+
+// ListMessageChanges lists the messages of a conversation that were created, edited or deleted after
+// the position, in commit order. It is used to replay the changes a client missed while it was disconnected.
+func (r *Reader) ListMessageChanges(ctx context.Context, conversationID uuid.UUID, after int64, limit int) ([]*domain.Message, Error) {
+	messages, err := r.messages.List(ctx, storage.Pagination{Limit: limit}, storage.MessageOrderBy{
+		{Field: storage.MessagePosition, Direction: storage.ASC},
+	},
+		storage.MessageByConversationIDCondition{ConversationID: conversationID},
+		storage.MessageAfterPositionCondition{Position: after},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Message, 0, len(messages))
+	for _, in := range messages {
+		var m domain.Message
+		if err = m.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &m)
+	}
+
+	return out, nil
+}
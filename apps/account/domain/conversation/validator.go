@@ -0,0 +1,73 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/validate"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxTitleLength is the maximum length of a conversation title.
+	maxTitleLength = 255
+	// maxParticipants is the maximum number of participants in a conversation.
+	maxParticipants = 256
+	// maxBodyLength is the maximum length of a message body.
+	maxBodyLength = 4096
+)
+
+var (
+	ErrUnknownType          Error = errors.New("unknown conversation type")
+	ErrInvalidParticipants  Error = errors.New("invalid participants")
+	ErrDirectTitle          Error = errors.New("direct conversations have no title")
+	ErrDuplicateParticipant Error = errors.New("duplicate participant")
+)
+
+var bodyValidator = validate.StringValidator{
+	validate.StringNotEmpty("body"),
+	validate.StringUtf8("body"),
+	validate.StringMaxLength("body", maxBodyLength),
+}
+
+// Validate checks the type, title and participants of a conversation.
+// A direct conversation is between exactly two users, a group conversation has at least one participant.
+func Validate(c *domain.Conversation) error {
+	switch c.Type {
+	case domain.ConversationTypeDirect:
+		if len(c.Participants) != 2 {
+			return validate.NewError("participants", "a direct conversation has exactly two participants", ErrInvalidParticipants)
+		}
+		if c.Title != "" {
+			return validate.NewError("title", "a direct conversation has no title", ErrDirectTitle)
+		}
+	case domain.ConversationTypeGroup:
+		if len(c.Participants) == 0 || len(c.Participants) > maxParticipants {
+			return validate.NewError("participants", fmt.Sprintf("a group conversation has 1 to %d participants", maxParticipants), ErrInvalidParticipants)
+		}
+		if err := validate.StringMaxLength("title", maxTitleLength)(c.Title); err != nil {
+			return err
+		}
+	default:
+		return validate.NewError("type", fmt.Sprintf("unknown conversation type %q", c.Type), ErrUnknownType)
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(c.Participants))
+	for _, id := range c.Participants {
+		if _, ok := seen[id]; ok {
+			return validate.NewError("participants", fmt.Sprintf("participant %s is listed twice", id), ErrDuplicateParticipant)
+		}
+		seen[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// ValidateBody checks the body of a message.
+func ValidateBody(body string) error {
+	if err := bodyValidator.Validate(body); err != nil {
+		return err
+	}
+	return nil
+}
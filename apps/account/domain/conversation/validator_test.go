@@ -0,0 +1,86 @@
+package conversation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/pkg/validate"
+	"github.com/google/uuid"
+)
+
+func TestValidate(t *testing.T) {
+	jane, john := uuid.New(), uuid.New()
+	tests := []struct {
+		name         string
+		conversation *domain.Conversation
+		err          error
+	}{
+		{
+			name:         "direct",
+			conversation: &domain.Conversation{Type: domain.ConversationTypeDirect, Participants: []uuid.UUID{jane, john}},
+		},
+		{
+			name:         "group",
+			conversation: &domain.Conversation{Type: domain.ConversationTypeGroup, Title: "general", Participants: []uuid.UUID{jane}},
+		},
+		{
+			name:         "unknown type",
+			conversation: &domain.Conversation{Type: "channel", Participants: []uuid.UUID{jane}},
+			err:          conversation.ErrUnknownType,
+		},
+		{
+			name:         "direct with one participant",
+			conversation: &domain.Conversation{Type: domain.ConversationTypeDirect, Participants: []uuid.UUID{jane}},
+			err:          conversation.ErrInvalidParticipants,
+		},
+		{
+			name:         "direct with a title",
+			conversation: &domain.Conversation{Type: domain.ConversationTypeDirect, Title: "hi", Participants: []uuid.UUID{jane, john}},
+			err:          conversation.ErrDirectTitle,
+		},
+		{
+			name:         "direct with oneself",
+			conversation: &domain.Conversation{Type: domain.ConversationTypeDirect, Participants: []uuid.UUID{jane, jane}},
+			err:          conversation.ErrDuplicateParticipant,
+		},
+		{
+			name:         "group without participants",
+			conversation: &domain.Conversation{Type: domain.ConversationTypeGroup},
+			err:          conversation.ErrInvalidParticipants,
+		},
+		{
+			name:         "group with a long title",
+			conversation: &domain.Conversation{Type: domain.ConversationTypeGroup, Title: strings.Repeat("a", 256), Participants: []uuid.UUID{jane}},
+			err:          validate.ErrStringMaxLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := conversation.Validate(tt.conversation); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+	}{
+		{name: "valid", body: "hello"},
+		{name: "empty", body: "", err: validate.ErrEmptyString},
+		{name: "too long", body: strings.Repeat("a", 4097), err: validate.ErrStringMaxLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := conversation.ValidateBody(tt.body); !errors.Is(err, tt.err) {
+				t.Errorf("ValidateBody() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+)
+
+// Writer writes conversations and messages. Changes that touch more than one table run in their own transaction.
+type Writer struct {
+	c         func() time.Time // c is the clock function.
+	genUUID   uuidgen.Generator
+	dbManager storage.DBManager
+}
+
+func NewWriter(c func() time.Time, genUUID uuidgen.Generator, dbManager storage.DBManager) *Writer {
+	return &Writer{
+		c:         c,
+		genUUID:   genUUID,
+		dbManager: dbManager,
+	}
+}
+
+// Create creates a conversation with a new id together with its participants.
+func (w *Writer) Create(ctx context.Context, c *domain.Conversation) (*domain.Conversation, Error) {
+	c.ID = w.genUUID()
+	c.CreateTime = w.c()
+	c.UpdateTime = c.CreateTime
+
+	var in = new(storage.Conversation)
+	if err := c.ToStorage(in); err != nil {
+		return nil, err
+	}
+
+	result := &domain.Conversation{}
+	if err := w.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		created, err := r.Conversation.Create(ctx, in)
+		if err != nil {
+			return err
+		}
+
+		if err = result.FromStorage(created); err != nil {
+			return err
+		}
+
+		for _, userID := range c.Participants {
+			if _, err = r.Participant.Create(ctx, &storage.Participant{
+				ConversationID: created.ID,
+				UserID:         userID,
+				JoinTime:       created.CreateTime,
+			}); err != nil {
+				return err
+			}
+
+			result.Participants = append(result.Participants, userID)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateMessage adds a message with a new id to its conversation, and moves the update time
+// of the conversation to the time of the message.
+func (w *Writer) CreateMessage(ctx context.Context, m *domain.Message) (*domain.Message, Error) {
+	m.ID = w.genUUID()
+	m.CreateTime = w.c()
+	m.UpdateTime = m.CreateTime
+	m.EditTime = time.Time{}
+	m.DeleteTime = time.Time{}
+
+	var in = new(storage.Message)
+	if err := m.ToStorage(in); err != nil {
+		return nil, err
+	}
+
+	result := &domain.Message{}
+	if err := w.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		created, err := r.Message.Create(ctx, in)
+		if err != nil {
+			return err
+		}
+
+		if _, err = r.Conversation.Update(ctx, &storage.Conversation{
+			ID:         created.ConversationID,
+			UpdateTime: created.CreateTime,
+		}, []storage.ConversationField{storage.ConversationUpdateTime}); err != nil {
+			if errors.Is(err, storage.ErrConversationNotFound) {
+				return ErrConversationNotFound
+			}
+			return err
+		}
+
+		return result.FromStorage(created)
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// EditMessage replaces the body of a message and marks it as edited.
+func (w *Writer) EditMessage(ctx context.Context, m *domain.Message, body string) (*domain.Message, Error) {
+	m.Body = body
+	m.EditTime = w.c()
+	return w.updateMessage(ctx, m, []storage.MessageField{storage.MessageBody, storage.MessageEditTime})
+}
+
+// DeleteMessage removes the body of a message and marks it as deleted.
+// The message itself is kept, so the conversation shows where it was.
+func (w *Writer) DeleteMessage(ctx context.Context, m *domain.Message) (*domain.Message, Error) {
+	m.Body = ""
+	m.DeleteTime = w.c()
+	return w.updateMessage(ctx, m, []storage.MessageField{storage.MessageBody, storage.MessageDeleteTime})
+}
+
+// updateMessage updates the fields of a message and sets its update time.
+func (w *Writer) updateMessage(ctx context.Context, m *domain.Message, fields []storage.MessageField) (*domain.Message, Error) {
+	m.UpdateTime = w.c()
+	var err error
+	in := &storage.Message{}
+	if err = m.ToStorage(in); err != nil {
+		return nil, err
+	}
+
+	in, err = w.dbManager.Op().Message.Update(ctx, in, append(fields, storage.MessageUpdateTime))
+	if err != nil {
+		if errors.Is(err, storage.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+
+	result := &domain.Message{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
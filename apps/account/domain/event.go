@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Aggregate types of the account domain.
+const (
+	UserAggregate         = "user"
+	OrganizationAggregate = "organization"
+)
+
+// EventType names a kind of domain event. It is stable and used by consumers to decode the payload.
+type EventType string
+
+func (t EventType) String() string { return string(t) }
+
+const (
+	EventUserCreated         EventType = "user.created"
+	EventUserUpdated         EventType = "user.updated"
+	EventUserDeleted         EventType = "user.deleted"
+	EventOrganizationCreated EventType = "organization.created"
+	EventOrganizationUpdated EventType = "organization.updated"
+	EventOrganizationDeleted EventType = "organization.deleted"
+)
+
+// EventTypes returns all event types.
+func EventTypes() []EventType {
+	return []EventType{
+		EventUserCreated,
+		EventUserUpdated,
+		EventUserDeleted,
+		EventOrganizationCreated,
+		EventOrganizationUpdated,
+		EventOrganizationDeleted,
+	}
+}
+
+// Event is a change to an aggregate that other services may want to know about.
+// Events are JSON encoded, so their fields are part of the public contract.
+type Event interface {
+	AggregateType() string
+	AggregateID() uuid.UUID
+	EventType() EventType
+}
+
+// UserState is a user as carried by events. It never contains the password.
+type UserState struct {
+	ID                uuid.UUID `json:"id"`
+	OrganizationID    uuid.UUID `json:"organization_id"`
+	DisplayName       string    `json:"display_name"`
+	Email             string    `json:"email"`
+	Status            string    `json:"status"`
+	Role              string    `json:"role"`
+	PreferredLanguage string    `json:"preferred_language,omitempty"`
+	CreateTime        time.Time `json:"create_time"`
+	UpdateTime        time.Time `json:"update_time"`
+}
+
+// NewUserState returns the state of u for an event.
+func NewUserState(u *User) UserState {
+	return UserState{
+		ID:                u.ID,
+		OrganizationID:    u.OrganizationID,
+		DisplayName:       u.DisplayName,
+		Email:             u.Email,
+		Status:            u.Status.String(),
+		Role:              u.Role.String(),
+		PreferredLanguage: u.PreferredLanguage,
+		CreateTime:        u.CreateTime,
+		UpdateTime:        u.UpdateTime,
+	}
+}
+
+// User returns the user described by the state.
+func (s UserState) User() *User {
+	return &User{
+		ID:                s.ID,
+		OrganizationID:    s.OrganizationID,
+		DisplayName:       s.DisplayName,
+		Email:             s.Email,
+		Status:            UserStatus(s.Status),
+		Role:              UserRole(s.Role),
+		PreferredLanguage: s.PreferredLanguage,
+		CreateTime:        s.CreateTime,
+		UpdateTime:        s.UpdateTime,
+	}
+}
+
+// UserCreated is published when a user is created.
+type UserCreated struct {
+	User UserState `json:"user"`
+}
+
+func (e UserCreated) AggregateType() string  { return UserAggregate }
+func (e UserCreated) AggregateID() uuid.UUID { return e.User.ID }
+func (e UserCreated) EventType() EventType   { return EventUserCreated }
+
+// UserUpdated is published when a user is updated. Fields lists the changed fields.
+type UserUpdated struct {
+	User   UserState `json:"user"`
+	Fields []string  `json:"fields"`
+}
+
+func (e UserUpdated) AggregateType() string  { return UserAggregate }
+func (e UserUpdated) AggregateID() uuid.UUID { return e.User.ID }
+func (e UserUpdated) EventType() EventType   { return EventUserUpdated }
+
+// UserDeleted is published when a user is deleted. User is the last state of the user.
+type UserDeleted struct {
+	User UserState `json:"user"`
+}
+
+func (e UserDeleted) AggregateType() string  { return UserAggregate }
+func (e UserDeleted) AggregateID() uuid.UUID { return e.User.ID }
+func (e UserDeleted) EventType() EventType   { return EventUserDeleted }
+
+// OrganizationState is an organization as carried by events.
+type OrganizationState struct {
+	ID         uuid.UUID `json:"id"`
+	LegalName  string    `json:"legal_name"`
+	Slug       string    `json:"slug"`
+	CreateTime time.Time `json:"create_time"`
+	UpdateTime time.Time `json:"update_time"`
+}
+
+// NewOrganizationState returns the state of o for an event.
+func NewOrganizationState(o *Organization) OrganizationState {
+	return OrganizationState{
+		ID:         o.ID,
+		LegalName:  o.LegalName,
+		Slug:       o.Slug,
+		CreateTime: o.CreateTime,
+		UpdateTime: o.UpdateTime,
+	}
+}
+
+// OrganizationCreated is published when an organization is created.
+type OrganizationCreated struct {
+	Organization OrganizationState `json:"organization"`
+}
+
+func (e OrganizationCreated) AggregateType() string  { return OrganizationAggregate }
+func (e OrganizationCreated) AggregateID() uuid.UUID { return e.Organization.ID }
+func (e OrganizationCreated) EventType() EventType   { return EventOrganizationCreated }
+
+// OrganizationUpdated is published when an organization is updated. Fields lists the changed fields.
+type OrganizationUpdated struct {
+	Organization OrganizationState `json:"organization"`
+	Fields       []string          `json:"fields"`
+}
+
+func (e OrganizationUpdated) AggregateType() string  { return OrganizationAggregate }
+func (e OrganizationUpdated) AggregateID() uuid.UUID { return e.Organization.ID }
+func (e OrganizationUpdated) EventType() EventType   { return EventOrganizationUpdated }
+
+// OrganizationDeleted is published when an organization is deleted.
+type OrganizationDeleted struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+func (e OrganizationDeleted) AggregateType() string  { return OrganizationAggregate }
+func (e OrganizationDeleted) AggregateID() uuid.UUID { return e.OrganizationID }
+func (e OrganizationDeleted) EventType() EventType   { return EventOrganizationDeleted }
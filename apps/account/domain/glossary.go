@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GlossaryCollection is the name of the glossary collection.
+const GlossaryCollection = "glossaries"
+
+// GlossaryTermCollection is the name of the glossary term collection.
+const GlossaryTermCollection = "terms"
+
+// GlossarySegmentCollection is the name of the glossary segment collection.
+const GlossarySegmentCollection = "segments"
+
+// Glossary keeps the vocabulary of an organization and the translations it approved before.
+type Glossary struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	DisplayName    string
+	CreateTime     time.Time
+	UpdateTime     time.Time
+}
+
+// GlossaryName returns the resource name of a glossary.
+func GlossaryName(organizationID, glossaryID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", OrganizationName(organizationID), GlossaryCollection, glossaryID)
+}
+
+// ToProto maps the glossary to its proto representation.
+func (g *Glossary) ToProto(in *protoaccount.Glossary) error {
+	in.Name = GlossaryName(g.OrganizationID, g.ID)
+	in.DisplayName = g.DisplayName
+	in.CreateTime = timestamppb.New(g.CreateTime)
+	in.UpdateTime = timestamppb.New(g.UpdateTime)
+	return nil
+}
+
+// ToStorage maps a Glossary to a storage.Glossary.
+func (g *Glossary) ToStorage(out *storage.Glossary) error {
+	for _, field := range storage.GlossaryFields() {
+		switch field {
+		case storage.GlossaryID:
+			out.ID = g.ID
+		case storage.GlossaryOrganizationID:
+			out.OrganizationID = g.OrganizationID
+		case storage.GlossaryDisplayName:
+			out.DisplayName = g.DisplayName
+		case storage.GlossaryCreateTime:
+			out.CreateTime = g.CreateTime
+		case storage.GlossaryUpdateTime:
+			out.UpdateTime = g.UpdateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.Glossary to a Glossary.
+func (g *Glossary) FromStorage(in *storage.Glossary) error {
+	for _, field := range storage.GlossaryFields() {
+		switch field {
+		case storage.GlossaryID:
+			g.ID = in.ID
+		case storage.GlossaryOrganizationID:
+			g.OrganizationID = in.OrganizationID
+		case storage.GlossaryDisplayName:
+			g.DisplayName = in.DisplayName
+		case storage.GlossaryCreateTime:
+			g.CreateTime = in.CreateTime
+		case storage.GlossaryUpdateTime:
+			g.UpdateTime = in.UpdateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// GlossaryTerm is the approved translation of a word or phrase into a language,
+// or a word or phrase that is not translated at all.
+type GlossaryTerm struct {
+	ID             uuid.UUID
+	GlossaryID     uuid.UUID
+	Term           string
+	Language       string // Language is the language of the translation, empty when the term is not translated.
+	Translation    string
+	DoNotTranslate bool
+	CreateTime     time.Time
+	UpdateTime     time.Time
+}
+
+// GlossaryTermName returns the resource name of a glossary term.
+func GlossaryTermName(organizationID, glossaryID, termID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", GlossaryName(organizationID, glossaryID), GlossaryTermCollection, termID)
+}
+
+// ToProto maps the term to its proto representation.
+// The organization is needed to build the resource name.
+func (t *GlossaryTerm) ToProto(organizationID uuid.UUID, in *protoaccount.GlossaryTerm) error {
+	in.Name = GlossaryTermName(organizationID, t.GlossaryID, t.ID)
+	in.Term = t.Term
+	in.Language = t.Language
+	in.Translation = t.Translation
+	in.DoNotTranslate = t.DoNotTranslate
+	in.CreateTime = timestamppb.New(t.CreateTime)
+	in.UpdateTime = timestamppb.New(t.UpdateTime)
+	return nil
+}
+
+// ToStorage maps a GlossaryTerm to a storage.GlossaryTerm.
+func (t *GlossaryTerm) ToStorage(out *storage.GlossaryTerm) error {
+	for _, field := range storage.GlossaryTermFields() {
+		switch field {
+		case storage.GlossaryTermID:
+			out.ID = t.ID
+		case storage.GlossaryTermGlossaryID:
+			out.GlossaryID = t.GlossaryID
+		case storage.GlossaryTermTerm:
+			out.Term = t.Term
+		case storage.GlossaryTermLanguage:
+			out.Language = t.Language
+		case storage.GlossaryTermTranslation:
+			out.Translation = t.Translation
+		case storage.GlossaryTermDoNotTranslate:
+			out.DoNotTranslate = t.DoNotTranslate
+		case storage.GlossaryTermCreateTime:
+			out.CreateTime = t.CreateTime
+		case storage.GlossaryTermUpdateTime:
+			out.UpdateTime = t.UpdateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.GlossaryTerm to a GlossaryTerm.
+func (t *GlossaryTerm) FromStorage(in *storage.GlossaryTerm) error {
+	for _, field := range storage.GlossaryTermFields() {
+		switch field {
+		case storage.GlossaryTermID:
+			t.ID = in.ID
+		case storage.GlossaryTermGlossaryID:
+			t.GlossaryID = in.GlossaryID
+		case storage.GlossaryTermTerm:
+			t.Term = in.Term
+		case storage.GlossaryTermLanguage:
+			t.Language = in.Language
+		case storage.GlossaryTermTranslation:
+			t.Translation = in.Translation
+		case storage.GlossaryTermDoNotTranslate:
+			t.DoNotTranslate = in.DoNotTranslate
+		case storage.GlossaryTermCreateTime:
+			t.CreateTime = in.CreateTime
+		case storage.GlossaryTermUpdateTime:
+			t.UpdateTime = in.UpdateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// GlossarySegment is a text and its approved translation, an entry of the translation memory of a glossary.
+type GlossarySegment struct {
+	ID             uuid.UUID
+	GlossaryID     uuid.UUID
+	SourceLanguage string
+	SourceText     string
+	TargetLanguage string
+	TargetText     string
+	CreateTime     time.Time
+}
+
+// GlossarySegmentName returns the resource name of a glossary segment.
+func GlossarySegmentName(organizationID, glossaryID, segmentID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", GlossaryName(organizationID, glossaryID), GlossarySegmentCollection, segmentID)
+}
+
+// ToProto maps the segment to its proto representation.
+// The organization is needed to build the resource name.
+func (s *GlossarySegment) ToProto(organizationID uuid.UUID, in *protoaccount.Segment) error {
+	in.Name = GlossarySegmentName(organizationID, s.GlossaryID, s.ID)
+	in.SourceLanguage = s.SourceLanguage
+	in.SourceText = s.SourceText
+	in.TargetLanguage = s.TargetLanguage
+	in.TargetText = s.TargetText
+	in.CreateTime = timestamppb.New(s.CreateTime)
+	return nil
+}
+
+// ToStorage maps a GlossarySegment to a storage.GlossarySegment.
+func (s *GlossarySegment) ToStorage(out *storage.GlossarySegment) error {
+	for _, field := range storage.GlossarySegmentFields() {
+		switch field {
+		case storage.GlossarySegmentID:
+			out.ID = s.ID
+		case storage.GlossarySegmentGlossaryID:
+			out.GlossaryID = s.GlossaryID
+		case storage.GlossarySegmentSourceLanguage:
+			out.SourceLanguage = s.SourceLanguage
+		case storage.GlossarySegmentSourceText:
+			out.SourceText = s.SourceText
+		case storage.GlossarySegmentTargetLanguage:
+			out.TargetLanguage = s.TargetLanguage
+		case storage.GlossarySegmentTargetText:
+			out.TargetText = s.TargetText
+		case storage.GlossarySegmentCreateTime:
+			out.CreateTime = s.CreateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.GlossarySegment to a GlossarySegment.
+func (s *GlossarySegment) FromStorage(in *storage.GlossarySegment) error {
+	for _, field := range storage.GlossarySegmentFields() {
+		switch field {
+		case storage.GlossarySegmentID:
+			s.ID = in.ID
+		case storage.GlossarySegmentGlossaryID:
+			s.GlossaryID = in.GlossaryID
+		case storage.GlossarySegmentSourceLanguage:
+			s.SourceLanguage = in.SourceLanguage
+		case storage.GlossarySegmentSourceText:
+			s.SourceText = in.SourceText
+		case storage.GlossarySegmentTargetLanguage:
+			s.TargetLanguage = in.TargetLanguage
+		case storage.GlossarySegmentTargetText:
+			s.TargetText = in.TargetText
+		case storage.GlossarySegmentCreateTime:
+			s.CreateTime = in.CreateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// GlossarySegmentMatch is a segment with a source text similar to a searched text.
+type GlossarySegmentMatch struct {
+	Segment *GlossarySegment
+	Score   float64 // Score is the similarity of the source text and the searched text, from 0 to 1.
+}
@@ -0,0 +1,90 @@
+package glossary
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/validate"
+)
+
+// csvHeader is the header row of a glossary CSV file.
+var csvHeader = []string{"term", "language", "translation", "do_not_translate"}
+
+var ErrInvalidCSV Error = errors.New("invalid glossary csv")
+
+// ReadCSV reads the terms of a CSV file with the columns term, language, translation and do_not_translate.
+// The first row is the header. Every term is validated, one invalid row fails the whole file.
+func ReadCSV(r io.Reader) ([]*domain.GlossaryTerm, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, invalidCSVErr(1, err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+	if !slices.Equal(header, csvHeader) {
+		return nil, validate.NewError("content", fmt.Sprintf("the header should be %q", strings.Join(csvHeader, ",")), ErrInvalidCSV)
+	}
+
+	var terms []*domain.GlossaryTerm
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			return nil, invalidCSVErr(line, err)
+		}
+
+		t := &domain.GlossaryTerm{
+			Term:        strings.TrimSpace(record[0]),
+			Language:    strings.TrimSpace(record[1]),
+			Translation: strings.TrimSpace(record[2]),
+		}
+		if s := strings.TrimSpace(record[3]); s != "" {
+			if t.DoNotTranslate, err = strconv.ParseBool(s); err != nil {
+				return nil, invalidCSVErr(line, fmt.Errorf("do_not_translate should be true or false, got %q", s))
+			}
+		}
+
+		if err = ValidateTerm(t); err != nil {
+			return nil, invalidCSVErr(line, err)
+		}
+
+		terms = append(terms, t)
+	}
+
+	return terms, nil
+}
+
+// WriteCSV writes the terms as a CSV file that ReadCSV reads.
+func WriteCSV(w io.Writer, terms []*domain.GlossaryTerm) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, t := range terms {
+		if err := cw.Write([]string{t.Term, t.Language, t.Translation, strconv.FormatBool(t.DoNotTranslate)}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// invalidCSVErr returns a validation error of the content for a line of a CSV file.
+func invalidCSVErr(line int, err error) error {
+	return validate.NewError("content", fmt.Sprintf("line %d: %s", line, err), ErrInvalidCSV)
+}
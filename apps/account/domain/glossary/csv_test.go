@@ -0,0 +1,72 @@
+package glossary_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadCSV(t *testing.T) {
+	t.Run("should read the terms", func(t *testing.T) {
+		got, err := glossary.ReadCSV(strings.NewReader("Term,Language,Translation,Do_Not_Translate\n" +
+			"workspace,nl,werkruimte,\n" +
+			"Lingo,,,true\n" +
+			"\"sign in\",de,anmelden,false\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*domain.GlossaryTerm{
+			{Term: "workspace", Language: "nl", Translation: "werkruimte"},
+			{Term: "Lingo", DoNotTranslate: true},
+			{Term: "sign in", Language: "de", Translation: "anmelden"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ReadCSV() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "empty file", content: ""},
+		{name: "unknown header", content: "source,target\nworkspace,werkruimte\n"},
+		{name: "missing column", content: "term,language,translation,do_not_translate\nworkspace,nl,werkruimte\n"},
+		{name: "invalid flag", content: "term,language,translation,do_not_translate\nLingo,,,maybe\n"},
+		{name: "invalid term", content: "term,language,translation,do_not_translate\nworkspace,nl,,\n"},
+	}
+	for _, tt := range tests {
+		t.Run("should fail on "+tt.name, func(t *testing.T) {
+			if _, err := glossary.ReadCSV(strings.NewReader(tt.content)); !errors.Is(err, glossary.ErrInvalidCSV) {
+				t.Errorf("ReadCSV() error = %v, want %v", err, glossary.ErrInvalidCSV)
+			}
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	terms := []*domain.GlossaryTerm{
+		{Term: "Lingo", DoNotTranslate: true},
+		{Term: "workspace, shared", Language: "nl", Translation: "gedeelde werkruimte"},
+	}
+
+	var buf bytes.Buffer
+	if err := glossary.WriteCSV(&buf, terms); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := glossary.ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(terms, got); diff != "" {
+		t.Errorf("WriteCSV() did not round trip (-want +got):\n%s", diff)
+	}
+}
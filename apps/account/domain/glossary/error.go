@@ -0,0 +1,4 @@
+package glossary
+
+// Error defines the glossary domain errors.
+type Error error
@@ -0,0 +1,63 @@
+package glossary
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/validate"
+)
+
+// MaxImportSize is the maximum size of an imported file in bytes.
+const MaxImportSize = 10 << 20
+
+// Format is the file format of an imported or exported glossary.
+type Format int
+
+const (
+	// FormatCSV holds the terms of a glossary.
+	FormatCSV Format = iota + 1
+	// FormatTMX holds the segments of a glossary.
+	FormatTMX
+)
+
+var (
+	ErrUnknownFormat  Error = errors.New("unknown glossary format")
+	ErrImportTooLarge Error = errors.New("glossary import is too large")
+)
+
+// Decode reads the terms of a CSV file or the segments of a TMX file.
+func Decode(f Format, content []byte) ([]*domain.GlossaryTerm, []*domain.GlossarySegment, error) {
+	if len(content) > MaxImportSize {
+		return nil, nil, validate.NewError("content", "content is larger than 10 MiB", ErrImportTooLarge)
+	}
+
+	switch f {
+	case FormatCSV:
+		terms, err := ReadCSV(bytes.NewReader(content))
+		return terms, nil, err
+	case FormatTMX:
+		segments, err := ReadTMX(bytes.NewReader(content))
+		return nil, segments, err
+	default:
+		return nil, nil, validate.NewError("format", "format should be CSV or TMX", ErrUnknownFormat)
+	}
+}
+
+// Encode writes the terms as a CSV file or the segments as a TMX file.
+func Encode(f Format, terms []*domain.GlossaryTerm, segments []*domain.GlossarySegment) ([]byte, error) {
+	var buf bytes.Buffer
+	switch f {
+	case FormatCSV:
+		if err := WriteCSV(&buf, terms); err != nil {
+			return nil, err
+		}
+	case FormatTMX:
+		if err := WriteTMX(&buf, segments); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, validate.NewError("format", "format should be CSV or TMX", ErrUnknownFormat)
+	}
+	return buf.Bytes(), nil
+}
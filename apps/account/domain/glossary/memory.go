@@ -0,0 +1,72 @@
+package glossary
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/google/uuid"
+)
+
+// DefaultMatchScore is the lowest similarity of a segment that Memory uses instead of translating a text:
+// only a source text that is the same as the text, ignoring case and punctuation.
+const DefaultMatchScore = 1
+
+// Memory is the translation memory of the glossaries of an organization, for the translation pipeline.
+type Memory struct {
+	reader   *Reader
+	minScore float64
+}
+
+var _ translation.Memory = (*Memory)(nil)
+
+// NewMemory returns a Memory that uses a segment when its similarity to a text is at least minScore.
+// A minScore of 0 or less uses DefaultMatchScore.
+func NewMemory(reader *Reader, minScore float64) *Memory {
+	if minScore <= 0 {
+		minScore = DefaultMatchScore
+	}
+
+	return &Memory{
+		reader:   reader,
+		minScore: minScore,
+	}
+}
+
+// Match returns the translation of the best matching segment in the glossaries of the organization.
+func (m *Memory) Match(ctx context.Context, organizationID uuid.UUID, text, source, target string) (string, bool, error) {
+	matches, err := m.reader.SearchOrganization(ctx, organizationID, Query{
+		Text:           text,
+		SourceLanguage: source,
+		TargetLanguage: target,
+		MinScore:       m.minScore,
+		Limit:          1,
+	})
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(matches) == 0 {
+		return "", false, nil
+	}
+
+	return matches[0].Segment.TargetText, true, nil
+}
+
+// Terms returns the terms in the glossaries of the organization that apply to translations into the target language.
+func (m *Memory) Terms(ctx context.Context, organizationID uuid.UUID, target string) ([]translation.Term, error) {
+	terms, err := m.reader.ListTermsForLanguage(ctx, organizationID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]translation.Term, 0, len(terms))
+	for _, t := range terms {
+		out = append(out, translation.Term{
+			Term:           t.Term,
+			Translation:    t.Translation,
+			DoNotTranslate: t.DoNotTranslate,
+		})
+	}
+
+	return out, nil
+}
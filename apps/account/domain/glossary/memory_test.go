@@ -0,0 +1,98 @@
+package glossary_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	segmentMock "github.com/extreme-business/lingo/apps/account/storage/mock/glossarysegment"
+	termMock "github.com/extreme-business/lingo/apps/account/storage/mock/glossaryterm"
+)
+
+var organizationID = uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+
+func TestMemory_Match(t *testing.T) {
+	ctx := context.Background()
+
+	var search storage.GlossarySegmentSearch
+	var conditions []storage.Condition
+	segments := &segmentMock.Repository{
+		SearchFunc: func(_ context.Context, q storage.GlossarySegmentSearch, c ...storage.Condition) ([]*storage.GlossarySegmentMatch, error) {
+			search, conditions = q, c
+			if q.Text != "good morning" {
+				return nil, nil
+			}
+			return []*storage.GlossarySegmentMatch{{
+				Segment: &storage.GlossarySegment{SourceLanguage: "en", SourceText: "Good morning", TargetLanguage: "nl", TargetText: "Goedemorgen"},
+				Score:   1,
+			}}, nil
+		},
+	}
+	m := glossary.NewMemory(glossary.NewReader(nil, nil, segments), 0)
+
+	t.Run("should return the translation of the best match", func(t *testing.T) {
+		got, ok, err := m.Match(ctx, organizationID, "good morning", "en-GB", "nl-BE")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ok || got != "Goedemorgen" {
+			t.Errorf("expected the approved translation, got %q", got)
+		}
+
+		want := storage.GlossarySegmentSearch{Text: "good morning", SourceLanguage: "en", TargetLanguage: "nl", MinScore: glossary.DefaultMatchScore, Limit: 1}
+		if diff := cmp.Diff(want, search); diff != "" {
+			t.Errorf("Search() mismatch (-want +got):\n%s", diff)
+		}
+
+		if diff := cmp.Diff([]storage.Condition{storage.GlossarySegmentByOrganizationIDCondition{OrganizationID: organizationID}}, conditions); diff != "" {
+			t.Errorf("conditions mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should report no match", func(t *testing.T) {
+		if _, ok, err := m.Match(ctx, organizationID, "good night", "en", "nl"); err != nil || ok {
+			t.Errorf("expected no match, got %v, %v", ok, err)
+		}
+	})
+}
+
+func TestMemory_Terms(t *testing.T) {
+	var conditions []storage.Condition
+	terms := &termMock.Repository{
+		ListFunc: func(_ context.Context, _ storage.Pagination, _ storage.GlossaryTermOrderBy, c ...storage.Condition) ([]*storage.GlossaryTerm, error) {
+			conditions = c
+			return []*storage.GlossaryTerm{
+				{Term: "Lingo", DoNotTranslate: true},
+				{Term: "workspace", Language: "nl", Translation: "werkruimte"},
+			}, nil
+		},
+	}
+	m := glossary.NewMemory(glossary.NewReader(nil, terms, nil), 0)
+
+	got, err := m.Terms(context.Background(), organizationID, "nl-BE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []translation.Term{
+		{Term: "Lingo", DoNotTranslate: true},
+		{Term: "workspace", Translation: "werkruimte"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Terms() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantConditions := []storage.Condition{
+		storage.GlossaryTermByOrganizationIDCondition{OrganizationID: organizationID},
+		storage.GlossaryTermForLanguageCondition{Language: "nl"},
+	}
+	if diff := cmp.Diff(wantConditions, conditions); diff != "" {
+		t.Errorf("conditions mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,194 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGlossaryNotFound Error = errors.New("glossary not found")
+	ErrTermNotFound     Error = errors.New("glossary term not found")
+	ErrSegmentNotFound  Error = errors.New("glossary segment not found")
+)
+
+// Query searches the translation memory for segments with a source text similar to a text.
+// Segments match in variants of the languages as well, "pt-BR" matches segments in "pt" and "pt-PT".
+type Query struct {
+	Text           string
+	SourceLanguage string
+	TargetLanguage string
+	MinScore       float64 // MinScore is the lowest similarity of a match, from 0 to 1.
+	Limit          int
+}
+
+type Reader struct {
+	glossaries storage.GlossaryReader
+	terms      storage.GlossaryTermReader
+	segments   storage.GlossarySegmentReader
+}
+
+func NewReader(glossaries storage.GlossaryReader, terms storage.GlossaryTermReader, segments storage.GlossarySegmentReader) *Reader {
+	return &Reader{
+		glossaries: glossaries,
+		terms:      terms,
+		segments:   segments,
+	}
+}
+
+func (r *Reader) Get(ctx context.Context, id uuid.UUID) (*domain.Glossary, Error) {
+	in, err := r.glossaries.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrGlossaryNotFound) {
+			return nil, ErrGlossaryNotFound
+		}
+		return nil, err
+	}
+	var g = new(domain.Glossary)
+	return g, g.FromStorage(in)
+}
+
+// List lists the glossaries of an organization, oldest first.
+func (r *Reader) List(ctx context.Context, organizationID uuid.UUID) ([]*domain.Glossary, Error) {
+	glossaries, err := r.glossaries.List(ctx, storage.Pagination{}, storage.GlossaryOrderBy{
+		{Field: storage.GlossaryCreateTime, Direction: storage.ASC},
+	}, storage.GlossaryByOrganizationIDCondition{OrganizationID: organizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Glossary, 0, len(glossaries))
+	for _, in := range glossaries {
+		var g domain.Glossary
+		if err = g.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &g)
+	}
+
+	return out, nil
+}
+
+func (r *Reader) GetTerm(ctx context.Context, id uuid.UUID) (*domain.GlossaryTerm, Error) {
+	in, err := r.terms.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrGlossaryTermNotFound) {
+			return nil, ErrTermNotFound
+		}
+		return nil, err
+	}
+	var t = new(domain.GlossaryTerm)
+	return t, t.FromStorage(in)
+}
+
+// ListTerms lists the terms of a glossary in alphabetical order.
+func (r *Reader) ListTerms(ctx context.Context, glossaryID uuid.UUID, p storage.Pagination) ([]*domain.GlossaryTerm, Error) {
+	return r.listTerms(ctx, p, storage.GlossaryTermByGlossaryIDCondition{GlossaryID: glossaryID})
+}
+
+// ListTermsForLanguage lists the terms of all glossaries of an organization that apply to translations
+// into a language: the terms translated into a variant of the language and the terms that are not translated.
+func (r *Reader) ListTermsForLanguage(ctx context.Context, organizationID uuid.UUID, language string) ([]*domain.GlossaryTerm, Error) {
+	return r.listTerms(ctx, storage.Pagination{},
+		storage.GlossaryTermByOrganizationIDCondition{OrganizationID: organizationID},
+		storage.GlossaryTermForLanguageCondition{Language: translation.BaseLanguage(language)},
+	)
+}
+
+func (r *Reader) listTerms(ctx context.Context, p storage.Pagination, c ...storage.Condition) ([]*domain.GlossaryTerm, Error) {
+	terms, err := r.terms.List(ctx, p, storage.GlossaryTermOrderBy{
+		{Field: storage.GlossaryTermTerm, Direction: storage.ASC},
+		{Field: storage.GlossaryTermLanguage, Direction: storage.ASC},
+		{Field: storage.GlossaryTermID, Direction: storage.ASC},
+	}, c...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.GlossaryTerm, 0, len(terms))
+	for _, in := range terms {
+		var t domain.GlossaryTerm
+		if err = t.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &t)
+	}
+
+	return out, nil
+}
+
+func (r *Reader) GetSegment(ctx context.Context, id uuid.UUID) (*domain.GlossarySegment, Error) {
+	in, err := r.segments.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrGlossarySegmentNotFound) {
+			return nil, ErrSegmentNotFound
+		}
+		return nil, err
+	}
+	var s = new(domain.GlossarySegment)
+	return s, s.FromStorage(in)
+}
+
+// ListSegments lists the segments of a glossary, oldest first.
+func (r *Reader) ListSegments(ctx context.Context, glossaryID uuid.UUID, p storage.Pagination) ([]*domain.GlossarySegment, Error) {
+	segments, err := r.segments.List(ctx, p, storage.GlossarySegmentOrderBy{
+		{Field: storage.GlossarySegmentCreateTime, Direction: storage.ASC},
+		{Field: storage.GlossarySegmentID, Direction: storage.ASC},
+	}, storage.GlossarySegmentByGlossaryIDCondition{GlossaryID: glossaryID})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.GlossarySegment, 0, len(segments))
+	for _, in := range segments {
+		var s domain.GlossarySegment
+		if err = s.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &s)
+	}
+
+	return out, nil
+}
+
+// Search searches the segments of a glossary, best match first.
+func (r *Reader) Search(ctx context.Context, glossaryID uuid.UUID, q Query) ([]*domain.GlossarySegmentMatch, Error) {
+	return r.search(ctx, q, storage.GlossarySegmentByGlossaryIDCondition{GlossaryID: glossaryID})
+}
+
+// SearchOrganization searches the segments of all glossaries of an organization, best match first.
+func (r *Reader) SearchOrganization(ctx context.Context, organizationID uuid.UUID, q Query) ([]*domain.GlossarySegmentMatch, Error) {
+	return r.search(ctx, q, storage.GlossarySegmentByOrganizationIDCondition{OrganizationID: organizationID})
+}
+
+func (r *Reader) search(ctx context.Context, q Query, c storage.Condition) ([]*domain.GlossarySegmentMatch, Error) {
+	matches, err := r.segments.Search(ctx, storage.GlossarySegmentSearch{
+		Text:           q.Text,
+		SourceLanguage: translation.BaseLanguage(q.SourceLanguage),
+		TargetLanguage: translation.BaseLanguage(q.TargetLanguage),
+		MinScore:       q.MinScore,
+		Limit:          q.Limit,
+	}, c)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.GlossarySegmentMatch, 0, len(matches))
+	for _, in := range matches {
+		var s domain.GlossarySegment
+		if err = s.FromStorage(in.Segment); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &domain.GlossarySegmentMatch{Segment: &s, Score: in.Score})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,149 @@
+package glossary
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/validate"
+)
+
+// allLanguages is the source language of a TMX translation unit that can be translated from any of its variants.
+const allLanguages = "*all*"
+
+var ErrInvalidTMX Error = errors.New("invalid glossary tmx")
+
+// tmx is a TMX 1.4 document, only the parts the translation memory uses.
+type tmx struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Units   []tmxUnit `xml:"body>tu"`
+}
+
+type tmxHeader struct {
+	CreationTool        string `xml:"creationtool,attr"`
+	CreationToolVersion string `xml:"creationtoolversion,attr"`
+	SegType             string `xml:"segtype,attr"`
+	OTMF                string `xml:"o-tmf,attr"`
+	AdminLang           string `xml:"adminlang,attr"`
+	SrcLang             string `xml:"srclang,attr"`
+	DataType            string `xml:"datatype,attr"`
+}
+
+type tmxUnit struct {
+	SrcLang  string       `xml:"srclang,attr,omitempty"`
+	Variants []tmxVariant `xml:"tuv"`
+}
+
+type tmxVariant struct {
+	Lang       string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	LegacyLang string `xml:"lang,attr,omitempty"` // LegacyLang is the language attribute of TMX 1.1.
+	Seg        string `xml:"seg"`
+}
+
+// language returns the language of the variant.
+func (v tmxVariant) language() string {
+	if v.Lang != "" {
+		return v.Lang
+	}
+	return v.LegacyLang
+}
+
+// ReadTMX reads the segments of a TMX file. A translation unit becomes a segment from its source language
+// into each of its other languages; when the source language is "*all*" or missing, its first variant is
+// the source. Inline markup in a segment is left out. Every segment is validated, one invalid translation
+// unit fails the whole file.
+func ReadTMX(r io.Reader) ([]*domain.GlossarySegment, error) {
+	var doc tmx
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, validate.NewError("content", fmt.Sprintf("not a tmx file: %s", err), ErrInvalidTMX)
+	}
+
+	var segments []*domain.GlossarySegment
+	for i, u := range doc.Units {
+		srcLang := u.SrcLang
+		if srcLang == "" {
+			srcLang = doc.Header.SrcLang
+		}
+
+		source := -1
+		for j, v := range u.Variants {
+			if strings.EqualFold(v.language(), srcLang) {
+				source = j
+				break
+			}
+		}
+		if source < 0 && (srcLang == "" || srcLang == allLanguages) && len(u.Variants) > 0 {
+			source = 0
+		}
+		if source < 0 {
+			return nil, invalidTMXErr(i, fmt.Errorf("no variant in the source language %q", srcLang))
+		}
+
+		for j, v := range u.Variants {
+			if j == source {
+				continue
+			}
+
+			s := &domain.GlossarySegment{
+				SourceLanguage: u.Variants[source].language(),
+				SourceText:     strings.TrimSpace(u.Variants[source].Seg),
+				TargetLanguage: v.language(),
+				TargetText:     strings.TrimSpace(v.Seg),
+			}
+			if err := ValidateSegment(s); err != nil {
+				return nil, invalidTMXErr(i, err)
+			}
+
+			segments = append(segments, s)
+		}
+	}
+
+	return segments, nil
+}
+
+// WriteTMX writes the segments as a TMX 1.4 file, a translation unit per segment.
+func WriteTMX(w io.Writer, segments []*domain.GlossarySegment) error {
+	doc := tmx{
+		Version: "1.4",
+		Header: tmxHeader{
+			CreationTool:        "lingo",
+			CreationToolVersion: "1",
+			SegType:             "sentence",
+			OTMF:                "lingo",
+			AdminLang:           "en",
+			SrcLang:             allLanguages,
+			DataType:            "plaintext",
+		},
+		Units: make([]tmxUnit, 0, len(segments)),
+	}
+	for _, s := range segments {
+		doc.Units = append(doc.Units, tmxUnit{
+			SrcLang: s.SourceLanguage,
+			Variants: []tmxVariant{
+				{Lang: s.SourceLanguage, Seg: s.SourceText},
+				{Lang: s.TargetLanguage, Seg: s.TargetText},
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(doc); err != nil {
+		return err
+	}
+	return e.Close()
+}
+
+// invalidTMXErr returns a validation error of the content for a translation unit of a TMX file.
+func invalidTMXErr(unit int, err error) error {
+	return validate.NewError("content", fmt.Sprintf("translation unit %d: %s", unit+1, err), ErrInvalidTMX)
+}
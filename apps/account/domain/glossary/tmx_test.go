@@ -0,0 +1,81 @@
+package glossary_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadTMX(t *testing.T) {
+	t.Run("should read a segment per target language", func(t *testing.T) {
+		got, err := glossary.ReadTMX(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<tmx version="1.4">
+  <header creationtool="test" creationtoolversion="1" segtype="sentence" o-tmf="test" adminlang="en" srclang="en" datatype="plaintext"/>
+  <body>
+    <tu>
+      <tuv xml:lang="nl"><seg>Goedemorgen</seg></tuv>
+      <tuv xml:lang="en"><seg>Good morning</seg></tuv>
+      <tuv xml:lang="de"><seg>Guten Morgen</seg></tuv>
+    </tu>
+    <tu srclang="*all*">
+      <tuv lang="fr"><seg>Merci</seg></tuv>
+      <tuv lang="en"><seg>Thank you</seg></tuv>
+    </tu>
+  </body>
+</tmx>`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*domain.GlossarySegment{
+			{SourceLanguage: "en", SourceText: "Good morning", TargetLanguage: "nl", TargetText: "Goedemorgen"},
+			{SourceLanguage: "en", SourceText: "Good morning", TargetLanguage: "de", TargetText: "Guten Morgen"},
+			{SourceLanguage: "fr", SourceText: "Merci", TargetLanguage: "en", TargetText: "Thank you"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ReadTMX() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "a file that is not xml", content: "term,language"},
+		{name: "a missing source variant", content: `<tmx version="1.4"><header srclang="en"/><body><tu><tuv xml:lang="nl"><seg>hallo</seg></tuv></tu></body></tmx>`},
+		{name: "an empty segment", content: `<tmx version="1.4"><header srclang="en"/><body><tu><tuv xml:lang="en"><seg>hello</seg></tuv><tuv xml:lang="nl"><seg></seg></tuv></tu></body></tmx>`},
+	}
+	for _, tt := range tests {
+		t.Run("should fail on "+tt.name, func(t *testing.T) {
+			if _, err := glossary.ReadTMX(strings.NewReader(tt.content)); !errors.Is(err, glossary.ErrInvalidTMX) {
+				t.Errorf("ReadTMX() error = %v, want %v", err, glossary.ErrInvalidTMX)
+			}
+		})
+	}
+}
+
+func TestWriteTMX(t *testing.T) {
+	segments := []*domain.GlossarySegment{
+		{SourceLanguage: "en", SourceText: "Fish & chips", TargetLanguage: "nl", TargetText: "Vis <en> friet"},
+		{SourceLanguage: "de", SourceText: "Guten Morgen", TargetLanguage: "en", TargetText: "Good morning"},
+	}
+
+	var buf bytes.Buffer
+	if err := glossary.WriteTMX(&buf, segments); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := glossary.ReadTMX(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(segments, got); diff != "" {
+		t.Errorf("WriteTMX() did not round trip (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,169 @@
+package glossary
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/pkg/validate"
+)
+
+const (
+	// maxDisplayNameLength is the maximum length of the display name of a glossary.
+	maxDisplayNameLength = 255
+	// maxTermLength is the maximum length of a term and its translation.
+	maxTermLength = 255
+	// maxSegmentLength is the maximum length of the texts of a segment, the maximum length of a message.
+	maxSegmentLength = 4096
+	// minMatchScore is the similarity threshold of pg_trgm, segments below it are never found.
+	minMatchScore = 0.3
+)
+
+var (
+	ErrTranslatedTerm     Error = errors.New("a term that is not translated has a translation")
+	ErrSameLanguage       Error = errors.New("the source and target language are the same")
+	ErrMissingLanguage    Error = errors.New("language is required")
+	ErrMissingTranslation Error = errors.New("translation is required")
+	ErrInvalidMatchScore  Error = errors.New("invalid match score")
+)
+
+var displayNameValidator = validate.StringValidator{
+	validate.StringNotEmpty("display_name"),
+	validate.StringUtf8("display_name"),
+	validate.StringMaxLength("display_name", maxDisplayNameLength),
+}
+
+var termValidator = validate.StringValidator{
+	validate.StringNotEmpty("term"),
+	validate.StringUtf8("term"),
+	validate.StringMaxLength("term", maxTermLength),
+}
+
+var translationValidator = validate.StringValidator{
+	validate.StringUtf8("translation"),
+	validate.StringMaxLength("translation", maxTermLength),
+}
+
+var sourceTextValidator = validate.StringValidator{
+	validate.StringNotEmpty("source_text"),
+	validate.StringUtf8("source_text"),
+	validate.StringMaxLength("source_text", maxSegmentLength),
+}
+
+var targetTextValidator = validate.StringValidator{
+	validate.StringNotEmpty("target_text"),
+	validate.StringUtf8("target_text"),
+	validate.StringMaxLength("target_text", maxSegmentLength),
+}
+
+// Validate checks the display name of a glossary.
+func Validate(g *domain.Glossary) error {
+	if err := displayNameValidator.Validate(g.DisplayName); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateTerm checks a term and puts its language in canonical form.
+// A term is either translated into a language, or not translated at all.
+func ValidateTerm(t *domain.GlossaryTerm) error {
+	if err := termValidator.Validate(t.Term); err != nil {
+		return err
+	}
+
+	if t.DoNotTranslate {
+		if t.Language != "" || t.Translation != "" {
+			return validate.NewError("translation", "a term that is not translated has no language and translation", ErrTranslatedTerm)
+		}
+		return nil
+	}
+
+	language, err := translation.ParseLanguage("language", t.Language)
+	if err != nil {
+		return err
+	}
+	if language == "" {
+		return validate.NewError("language", "language is required for a translated term", ErrMissingLanguage)
+	}
+	t.Language = language
+
+	if t.Translation == "" {
+		return validate.NewError("translation", "translation is required for a translated term", ErrMissingTranslation)
+	}
+	if err := translationValidator.Validate(t.Translation); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateSegment checks a segment and puts its languages in canonical form.
+func ValidateSegment(s *domain.GlossarySegment) error {
+	source, err := parseRequiredLanguage("source_language", s.SourceLanguage)
+	if err != nil {
+		return err
+	}
+
+	target, err := parseRequiredLanguage("target_language", s.TargetLanguage)
+	if err != nil {
+		return err
+	}
+
+	if !translation.NeedsTranslation(source, target) {
+		return validate.NewError("target_language", "the target language is the same as the source language", ErrSameLanguage)
+	}
+	s.SourceLanguage, s.TargetLanguage = source, target
+
+	if err := sourceTextValidator.Validate(s.SourceText); err != nil {
+		return err
+	}
+
+	if err := targetTextValidator.Validate(s.TargetText); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var queryTextValidator = validate.StringValidator{
+	validate.StringNotEmpty("text"),
+	validate.StringUtf8("text"),
+	validate.StringMaxLength("text", maxSegmentLength),
+}
+
+// ValidateQuery checks a search of the translation memory and puts its languages in canonical form.
+func ValidateQuery(q *Query) error {
+	if err := queryTextValidator.Validate(q.Text); err != nil {
+		return err
+	}
+
+	source, err := parseRequiredLanguage("source_language", q.SourceLanguage)
+	if err != nil {
+		return err
+	}
+
+	target, err := parseRequiredLanguage("target_language", q.TargetLanguage)
+	if err != nil {
+		return err
+	}
+	q.SourceLanguage, q.TargetLanguage = source, target
+
+	if q.MinScore < minMatchScore || q.MinScore > 1 {
+		return validate.NewError("min_score", fmt.Sprintf("min_score should be from %v to 1", minMatchScore), ErrInvalidMatchScore)
+	}
+
+	return nil
+}
+
+// parseRequiredLanguage parses a language tag that can not be empty.
+func parseRequiredLanguage(field, tag string) (string, error) {
+	language, err := translation.ParseLanguage(field, tag)
+	if err != nil {
+		return "", err
+	}
+	if language == "" {
+		return "", validate.NewError(field, field+" is required", ErrMissingLanguage)
+	}
+	return language, nil
+}
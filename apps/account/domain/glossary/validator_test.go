@@ -0,0 +1,95 @@
+package glossary_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/pkg/validate"
+)
+
+func TestValidateTerm(t *testing.T) {
+	tests := []struct {
+		name     string
+		term     *domain.GlossaryTerm
+		language string
+		err      error
+	}{
+		{
+			name:     "translated term",
+			term:     &domain.GlossaryTerm{Term: "workspace", Language: "pt_br", Translation: "espaço de trabalho"},
+			language: "pt-BR",
+		},
+		{
+			name: "term that is not translated",
+			term: &domain.GlossaryTerm{Term: "Lingo", DoNotTranslate: true},
+		},
+		{
+			name: "term that is not translated with a translation",
+			term: &domain.GlossaryTerm{Term: "Lingo", Language: "nl", Translation: "Lingo", DoNotTranslate: true},
+			err:  glossary.ErrTranslatedTerm,
+		},
+		{
+			name: "translated term without a language",
+			term: &domain.GlossaryTerm{Term: "workspace", Translation: "werkruimte"},
+			err:  glossary.ErrMissingLanguage,
+		},
+		{
+			name: "translated term without a translation",
+			term: &domain.GlossaryTerm{Term: "workspace", Language: "nl"},
+			err:  glossary.ErrMissingTranslation,
+		},
+		{
+			name: "empty term",
+			term: &domain.GlossaryTerm{Language: "nl", Translation: "werkruimte"},
+			err:  validate.ErrEmptyString,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := glossary.ValidateTerm(tt.term); !errors.Is(err, tt.err) {
+				t.Errorf("ValidateTerm() error = %v, want %v", err, tt.err)
+			}
+
+			if tt.err == nil && tt.term.Language != tt.language {
+				t.Errorf("expected language %q, got %q", tt.language, tt.term.Language)
+			}
+		})
+	}
+}
+
+func TestValidateSegment(t *testing.T) {
+	tests := []struct {
+		name    string
+		segment *domain.GlossarySegment
+		err     error
+	}{
+		{
+			name:    "valid",
+			segment: &domain.GlossarySegment{SourceLanguage: "en", SourceText: "hello", TargetLanguage: "nl", TargetText: "hallo"},
+		},
+		{
+			name:    "same language",
+			segment: &domain.GlossarySegment{SourceLanguage: "en-US", SourceText: "hello", TargetLanguage: "en-GB", TargetText: "hello"},
+			err:     glossary.ErrSameLanguage,
+		},
+		{
+			name:    "missing source language",
+			segment: &domain.GlossarySegment{SourceText: "hello", TargetLanguage: "nl", TargetText: "hallo"},
+			err:     glossary.ErrMissingLanguage,
+		},
+		{
+			name:    "missing target text",
+			segment: &domain.GlossarySegment{SourceLanguage: "en", SourceText: "hello", TargetLanguage: "nl"},
+			err:     validate.ErrEmptyString,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := glossary.ValidateSegment(tt.segment); !errors.Is(err, tt.err) {
+				t.Errorf("ValidateSegment() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
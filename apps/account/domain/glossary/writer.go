@@ -0,0 +1,200 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+	"github.com/google/uuid"
+)
+
+// Writer writes glossaries, their terms and their segments. An import runs in its own transaction.
+type Writer struct {
+	c         func() time.Time // c is the clock function.
+	genUUID   uuidgen.Generator
+	dbManager storage.DBManager
+}
+
+func NewWriter(c func() time.Time, genUUID uuidgen.Generator, dbManager storage.DBManager) *Writer {
+	return &Writer{
+		c:         c,
+		genUUID:   genUUID,
+		dbManager: dbManager,
+	}
+}
+
+// Create creates a glossary with a new id.
+func (w *Writer) Create(ctx context.Context, g *domain.Glossary) (*domain.Glossary, Error) {
+	g.ID = w.genUUID()
+	g.CreateTime = w.c()
+	g.UpdateTime = g.CreateTime
+
+	var in = new(storage.Glossary)
+	if err := g.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err := w.dbManager.Op().Glossary.Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.Glossary{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update updates the fields of a glossary and sets its update time.
+func (w *Writer) Update(ctx context.Context, g *domain.Glossary, fields []storage.GlossaryField) (*domain.Glossary, Error) {
+	g.UpdateTime = w.c()
+	var err error
+	in := &storage.Glossary{}
+	if err = g.ToStorage(in); err != nil {
+		return nil, err
+	}
+	fields = append(fields, storage.GlossaryUpdateTime)
+	slices.Sort(fields)
+	in, err = w.dbManager.Op().Glossary.Update(ctx, in, slices.Compact(fields))
+	if err != nil {
+		if errors.Is(err, storage.ErrGlossaryNotFound) {
+			return nil, ErrGlossaryNotFound
+		}
+		return nil, err
+	}
+	result := &domain.Glossary{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete deletes a glossary with its terms and segments.
+func (w *Writer) Delete(ctx context.Context, id uuid.UUID) Error {
+	if err := w.dbManager.Op().Glossary.Delete(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrGlossaryNotFound) {
+			return ErrGlossaryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateTerm adds a term with a new id to its glossary.
+func (w *Writer) CreateTerm(ctx context.Context, t *domain.GlossaryTerm) (*domain.GlossaryTerm, Error) {
+	return createTerm(ctx, w.dbManager.Op(), w.genUUID(), w.c(), t)
+}
+
+// UpdateTerm updates the fields of a term and sets its update time.
+func (w *Writer) UpdateTerm(ctx context.Context, t *domain.GlossaryTerm, fields []storage.GlossaryTermField) (*domain.GlossaryTerm, Error) {
+	t.UpdateTime = w.c()
+	var err error
+	in := &storage.GlossaryTerm{}
+	if err = t.ToStorage(in); err != nil {
+		return nil, err
+	}
+	fields = append(fields, storage.GlossaryTermUpdateTime)
+	slices.Sort(fields)
+	in, err = w.dbManager.Op().GlossaryTerm.Update(ctx, in, slices.Compact(fields))
+	if err != nil {
+		if errors.Is(err, storage.ErrGlossaryTermNotFound) {
+			return nil, ErrTermNotFound
+		}
+		return nil, err
+	}
+	result := &domain.GlossaryTerm{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (w *Writer) DeleteTerm(ctx context.Context, id uuid.UUID) Error {
+	if err := w.dbManager.Op().GlossaryTerm.Delete(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrGlossaryTermNotFound) {
+			return ErrTermNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateSegment adds a segment with a new id to the translation memory of its glossary.
+func (w *Writer) CreateSegment(ctx context.Context, s *domain.GlossarySegment) (*domain.GlossarySegment, Error) {
+	return createSegment(ctx, w.dbManager.Op(), w.genUUID(), w.c(), s)
+}
+
+func (w *Writer) DeleteSegment(ctx context.Context, id uuid.UUID) Error {
+	if err := w.dbManager.Op().GlossarySegment.Delete(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrGlossarySegmentNotFound) {
+			return ErrSegmentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Import adds terms and segments to a glossary, either all of them or none.
+// The terms and segments that are already in the glossary are kept.
+func (w *Writer) Import(ctx context.Context, glossaryID uuid.UUID, terms []*domain.GlossaryTerm, segments []*domain.GlossarySegment) Error {
+	now := w.c()
+	return w.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		for _, t := range terms {
+			t.GlossaryID = glossaryID
+			if _, err := createTerm(ctx, r, w.genUUID(), now, t); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range segments {
+			s.GlossaryID = glossaryID
+			if _, err := createSegment(ctx, r, w.genUUID(), now, s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func createTerm(ctx context.Context, r storage.Repositories, id uuid.UUID, now time.Time, t *domain.GlossaryTerm) (*domain.GlossaryTerm, error) {
+	t.ID = id
+	t.CreateTime = now
+	t.UpdateTime = now
+
+	var in = new(storage.GlossaryTerm)
+	if err := t.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err := r.GlossaryTerm.Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.GlossaryTerm{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func createSegment(ctx context.Context, r storage.Repositories, id uuid.UUID, now time.Time, s *domain.GlossarySegment) (*domain.GlossarySegment, error) {
+	s.ID = id
+	s.CreateTime = now
+
+	var in = new(storage.GlossarySegment)
+	if err := s.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err := r.GlossarySegment.Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.GlossarySegment{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
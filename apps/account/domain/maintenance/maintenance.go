@@ -0,0 +1,168 @@
+// Package maintenance holds the background jobs that keep the account database tidy and
+// relay its outbox. They run in the jobs command, see jobs.Runner.
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+const (
+	defaultSessionRetention = 30 * 24 * time.Hour
+	defaultOutboxRetention  = 7 * 24 * time.Hour
+)
+
+// CleanupTokensArgs deletes the sessions whose refresh tokens can no longer be used, and the used
+// registration tokens that expired.
+type CleanupTokensArgs struct{}
+
+func (CleanupTokensArgs) Kind() string { return "tokens.cleanup" }
+
+// PurgeOutboxArgs deletes the published outbox events.
+type PurgeOutboxArgs struct{}
+
+func (PurgeOutboxArgs) Kind() string { return "outbox.purge" }
+
+// Publisher publishes a batch of outbox events and returns how many were published, such as an outbox.Relay.
+type Publisher interface {
+	Publish(ctx context.Context) (int, error)
+}
+
+// Jobs runs the maintenance jobs.
+type Jobs struct {
+	logger           *slog.Logger
+	clock            func() time.Time
+	dbManager        storage.DBManager
+	publisher        Publisher
+	sessionRetention time.Duration
+	outboxRetention  time.Duration
+}
+
+type Config struct {
+	Logger           *slog.Logger
+	Clock            func() time.Time
+	DBManager        storage.DBManager
+	Publisher        Publisher
+	SessionRetention time.Duration // SessionRetention is how long expired and revoked sessions are kept, defaults to 30 days.
+	OutboxRetention  time.Duration // OutboxRetention is how long published outbox events are kept, defaults to 7 days.
+}
+
+func (c Config) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Clock == nil {
+		return errors.New("clock is required")
+	}
+
+	if c.DBManager == nil {
+		return errors.New("db manager is required")
+	}
+
+	if c.Publisher == nil {
+		return errors.New("publisher is required")
+	}
+
+	return nil
+}
+
+func New(c Config) (*Jobs, error) {
+	j := &Jobs{
+		logger:           c.Logger,
+		clock:            c.Clock,
+		dbManager:        c.DBManager,
+		publisher:        c.Publisher,
+		sessionRetention: c.SessionRetention,
+		outboxRetention:  c.OutboxRetention,
+	}
+
+	if j.sessionRetention <= 0 {
+		j.sessionRetention = defaultSessionRetention
+	}
+
+	if j.outboxRetention <= 0 {
+		j.outboxRetention = defaultOutboxRetention
+	}
+
+	return j, c.Validate()
+}
+
+// Register registers the handlers of the maintenance jobs.
+func (j *Jobs) Register(r *jobs.Registry) error {
+	return errors.Join(
+		jobs.Handle(r, j.CleanupTokens),
+		jobs.Handle(r, j.PurgeOutbox),
+		jobs.Handle(r, j.RelayOutbox),
+	)
+}
+
+// Schedules returns the schedules of the maintenance jobs. The outbox is relayed by the job the
+// outbox.Writer enqueues with the events, its schedule only retries the events the relay could
+// not publish right away.
+func (j *Jobs) Schedules() []jobs.Schedule {
+	return []jobs.Schedule{
+		{Name: "cleanup-tokens", Spec: "@hourly", Args: CleanupTokensArgs{}},
+		{Name: "purge-outbox", Spec: "30 3 * * *", Args: PurgeOutboxArgs{}},
+		{Name: "relay-outbox", Spec: "@every 1m", Args: outbox.RelayArgs{}},
+	}
+}
+
+// CleanupTokens deletes the sessions that expired or were revoked longer than the retention ago, and
+// the used registration tokens that expired. An expired registration token is rejected without them.
+func (j *Jobs) CleanupTokens(ctx context.Context, _ CleanupTokensArgs) error {
+	repos := j.dbManager.Op()
+	n, err := repos.Session.Purge(ctx, j.clock().Add(-j.sessionRetention))
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		j.logger.InfoContext(ctx, "cleaned up sessions", slog.Int64("count", n))
+	}
+
+	if n, err = repos.RegistrationToken.Purge(ctx, j.clock()); err != nil {
+		return err
+	}
+
+	if n > 0 {
+		j.logger.InfoContext(ctx, "cleaned up registration tokens", slog.Int64("count", n))
+	}
+
+	return nil
+}
+
+// PurgeOutbox deletes the outbox events that were published longer than the retention ago.
+func (j *Jobs) PurgeOutbox(ctx context.Context, _ PurgeOutboxArgs) error {
+	n, err := j.dbManager.Op().OutboxEvent.Purge(ctx, j.clock().Add(-j.outboxRetention))
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		j.logger.InfoContext(ctx, "purged outbox events", slog.Int64("count", n))
+	}
+
+	return nil
+}
+
+// RelayOutbox publishes batches of outbox events until there are none left to publish.
+func (j *Jobs) RelayOutbox(ctx context.Context, _ outbox.RelayArgs) error {
+	for {
+		n, err := j.publisher.Publish(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to relay outbox events: %w", err)
+		}
+
+		if n == 0 || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
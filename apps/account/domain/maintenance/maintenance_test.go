@@ -0,0 +1,129 @@
+package maintenance_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain/maintenance"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/jobs"
+
+	managerMock "github.com/extreme-business/lingo/apps/account/storage/mock/manager"
+	outboxMock "github.com/extreme-business/lingo/apps/account/storage/mock/outbox"
+	registrationTokenMock "github.com/extreme-business/lingo/apps/account/storage/mock/registrationtoken"
+	sessionMock "github.com/extreme-business/lingo/apps/account/storage/mock/session"
+)
+
+var now = time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+// publisherFunc adapts a function to a Publisher.
+type publisherFunc func(ctx context.Context) (int, error)
+
+func (f publisherFunc) Publish(ctx context.Context) (int, error) { return f(ctx) }
+
+func newJobs(t *testing.T, repos storage.Repositories, publisher maintenance.Publisher) *maintenance.Jobs {
+	t.Helper()
+
+	j, err := maintenance.New(maintenance.Config{
+		Logger:    slog.Default(),
+		Clock:     func() time.Time { return now },
+		DBManager: managerMock.New(repos),
+		Publisher: publisher,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return j
+}
+
+func TestJobs_Register(t *testing.T) {
+	j := newJobs(t, storage.Repositories{}, publisherFunc(func(context.Context) (int, error) { return 0, nil }))
+
+	r := jobs.NewRegistry()
+	if err := j.Register(r); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := jobs.NewRunner(jobs.Config{
+		Logger:    slog.Default(),
+		Clock:     time.Now,
+		DB:        &database.DBWrapper{},
+		Registry:  r,
+		Schedules: j.Schedules(),
+	}); err != nil {
+		t.Errorf("expected the schedules to be valid, got %v", err)
+	}
+}
+
+func TestJobs_CleanupTokens(t *testing.T) {
+	var before, expired time.Time
+	j := newJobs(t, storage.Repositories{
+		Session: &sessionMock.Repository{
+			PurgeFunc: func(_ context.Context, t time.Time) (int64, error) {
+				before = t
+				return 2, nil
+			},
+		},
+		RegistrationToken: &registrationTokenMock.Repository{
+			PurgeFunc: func(_ context.Context, t time.Time) (int64, error) {
+				expired = t
+				return 1, nil
+			},
+		},
+	}, publisherFunc(func(context.Context) (int, error) { return 0, nil }))
+
+	if err := j.CleanupTokens(context.Background(), maintenance.CleanupTokensArgs{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := now.AddDate(0, 0, -30); !before.Equal(want) {
+		t.Errorf("expected the sessions before %v to be purged, got %v", want, before)
+	}
+
+	if !expired.Equal(now) {
+		t.Errorf("expected the registration tokens that expired before %v to be purged, got %v", now, expired)
+	}
+}
+
+func TestJobs_PurgeOutbox(t *testing.T) {
+	var before time.Time
+	j := newJobs(t, storage.Repositories{
+		OutboxEvent: &outboxMock.Repository{
+			PurgeFunc: func(_ context.Context, t time.Time) (int64, error) {
+				before = t
+				return 0, nil
+			},
+		},
+	}, publisherFunc(func(context.Context) (int, error) { return 0, nil }))
+
+	if err := j.PurgeOutbox(context.Background(), maintenance.PurgeOutboxArgs{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := now.AddDate(0, 0, -7); !before.Equal(want) {
+		t.Errorf("expected the events before %v to be purged, got %v", want, before)
+	}
+}
+
+func TestJobs_RelayOutbox(t *testing.T) {
+	batches := []int{100, 100, 3, 0}
+	var calls int
+	j := newJobs(t, storage.Repositories{}, publisherFunc(func(context.Context) (int, error) {
+		n := batches[calls]
+		calls++
+		return n, nil
+	}))
+
+	if err := j.RelayOutbox(context.Background(), outbox.RelayArgs{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 4 {
+		t.Errorf("expected the outbox to be relayed until it is empty, got %d batches", calls)
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/extreme-business/lingo/apps/account/storage"
@@ -19,6 +20,11 @@ type Organization struct {
 	UpdateTime time.Time
 }
 
+// OrganizationName returns the resource name of an organization.
+func OrganizationName(organizationID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s", OrganizationCollection, organizationID)
+}
+
 func (o *Organization) FromStorage(s *storage.Organization) error {
 	o.ID = s.ID
 	o.LegalName = s.LegalName
@@ -6,19 +6,24 @@ import (
 	"time"
 
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
 	"github.com/extreme-business/lingo/apps/account/storage"
 	"github.com/google/uuid"
 )
 
+// Writer writes organizations and adds the matching domain events to the outbox.
+// The organization writer and the outbox writer must use the same transaction.
 type Writer struct {
-	c  func() time.Time // c is the clock function.
-	uw storage.OrganizationWriter
+	c      func() time.Time // c is the clock function.
+	uw     storage.OrganizationWriter
+	events *outbox.Writer
 }
 
-func NewWriter(c func() time.Time, w storage.OrganizationWriter) *Writer {
+func NewWriter(c func() time.Time, w storage.OrganizationWriter, events *outbox.Writer) *Writer {
 	return &Writer{
-		c:  c,
-		uw: w,
+		c:      c,
+		uw:     w,
+		events: events,
 	}
 }
 
@@ -36,6 +41,9 @@ func (w *Writer) Create(ctx context.Context, o *domain.Organization) (*domain.Or
 	if err = result.FromStorage(s); err != nil {
 		return nil, err
 	}
+	if err = w.events.Add(ctx, domain.OrganizationCreated{Organization: domain.NewOrganizationState(result)}); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -51,7 +59,8 @@ func (w *Writer) Update(ctx context.Context, o *domain.Organization, fields []st
 	}
 	fields = append(fields, storage.OrganizationUpdateTime)
 	slices.Sort(fields)
-	s, err = w.uw.Update(ctx, s, slices.Compact(fields))
+	fields = slices.Compact(fields)
+	s, err = w.uw.Update(ctx, s, fields)
 	if err != nil {
 		return nil, err
 	}
@@ -59,9 +68,27 @@ func (w *Writer) Update(ctx context.Context, o *domain.Organization, fields []st
 	if err = result.FromStorage(s); err != nil {
 		return nil, err
 	}
+	if err = w.events.Add(ctx, domain.OrganizationUpdated{Organization: domain.NewOrganizationState(result), Fields: changedFields(fields)}); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
 func (w *Writer) Delete(ctx context.Context, id uuid.UUID) error {
-	return w.uw.Delete(ctx, id)
+	if err := w.uw.Delete(ctx, id); err != nil {
+		return err
+	}
+	return w.events.Add(ctx, domain.OrganizationDeleted{OrganizationID: id})
+}
+
+// changedFields returns the names of the changed fields for an event.
+// The update time is left out, it changes with every update.
+func changedFields(fields []storage.OrganizationField) []string {
+	changed := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != storage.OrganizationUpdateTime {
+			changed = append(changed, string(f))
+		}
+	}
+	return changed
 }
@@ -0,0 +1,4 @@
+package outbox
+
+// Error defines the outbox domain errors.
+type Error error
@@ -0,0 +1,199 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+// NotifyChannel is the database notification channel that announces new events.
+// The outbox_events_position trigger sends the position of every new event to it when the transaction commits.
+const NotifyChannel = "outbox_events"
+
+const replayBatchSize = 100
+
+// ListenFunc calls notify with the payload of every notification on the NotifyChannel until the
+// context is canceled. An empty payload means notifications may have been lost.
+type ListenFunc func(ctx context.Context, notify func(payload string)) error
+
+// Feed fans out new events to in-process subscribers as soon as they are committed.
+//
+// Unlike the relay, which publishes every event once, a feed runs in every process and learns about
+// new events from database notifications, so its subscribers see the events of all processes.
+// The feed publishes the events in position order without gaps. Subscribers that must not miss
+// an event, such as change streams, use Replay to catch up on the events before they subscribed.
+type Feed struct {
+	logger *slog.Logger
+	reader storage.OutboxEventReader
+	listen ListenFunc
+	sink   *SubscriberSink
+	last   int64 // last is the position of the latest event published by the feed.
+	ready  chan struct{}
+	done   chan struct{}
+}
+
+type FeedConfig struct {
+	Logger *slog.Logger
+	Reader storage.OutboxEventReader
+	Listen ListenFunc
+}
+
+func (c FeedConfig) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Reader == nil {
+		return errors.New("reader is required")
+	}
+
+	if c.Listen == nil {
+		return errors.New("listen is required")
+	}
+
+	return nil
+}
+
+func NewFeed(c FeedConfig) (*Feed, error) {
+	return &Feed{
+		logger: c.Logger,
+		reader: c.Reader,
+		listen: c.Listen,
+		sink:   NewSubscriberSink(),
+		ready:  make(chan struct{}),
+		done:   make(chan struct{}),
+	}, c.Validate()
+}
+
+// Run publishes new events to the subscribers until the context is canceled.
+// Ready is closed once the feed publishes every new event, Done is closed when Run returns.
+func (f *Feed) Run(ctx context.Context) error {
+	defer close(f.done)
+
+	last, err := f.Latest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the latest event: %w", err)
+	}
+	f.last = last
+	close(f.ready)
+
+	return f.listen(ctx, func(payload string) {
+		f.notify(ctx, payload)
+	})
+}
+
+// Ready is closed when the feed knows the latest event. Subscribers that replay the events
+// before they subscribed wait for it, otherwise the feed may start after their replay and
+// skip the events in between.
+func (f *Feed) Ready() <-chan struct{} { return f.ready }
+
+// Done is closed when the feed stops, subscribers will not receive any more events.
+func (f *Feed) Done() <-chan struct{} { return f.done }
+
+// Subscribe registers fn for all events published after the call and returns a function to unsubscribe.
+// fn is called by the feed itself, so it must not block.
+func (f *Feed) Subscribe(fn SinkFunc) (unsubscribe func()) {
+	return f.sink.Subscribe(fn)
+}
+
+// Latest returns the position of the latest event, or 0 when there are no events yet.
+func (f *Feed) Latest(ctx context.Context) (int64, error) {
+	events, err := f.reader.List(ctx, storage.Pagination{Limit: 1}, storage.OutboxEventOrderBy{
+		{Field: storage.OutboxEventPosition, Direction: storage.DESC},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	return events[0].Position, nil
+}
+
+// Oldest returns the position of the oldest event that is kept, or 0 when there are no events yet.
+// The events after it are all kept, see storage.OutboxEventWriter.Purge.
+func (f *Feed) Oldest(ctx context.Context) (int64, error) {
+	events, err := f.reader.List(ctx, storage.Pagination{Limit: 1}, storage.OutboxEventOrderBy{
+		{Field: storage.OutboxEventPosition, Direction: storage.ASC},
+	}, storage.OutboxEventAfterPositionCondition{Position: 0})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	return events[0].Position, nil
+}
+
+// Replay calls fn for every event after the position, in position order. When aggregateType is
+// not empty only the events of that aggregate type are replayed.
+func (f *Feed) Replay(ctx context.Context, after int64, aggregateType string, fn func(Message) error) error {
+	for {
+		conditions := []storage.Condition{storage.OutboxEventAfterPositionCondition{Position: after}}
+		if aggregateType != "" {
+			conditions = append(conditions, storage.OutboxEventByAggregateTypeCondition{AggregateType: aggregateType})
+		}
+
+		events, err := f.reader.List(ctx, storage.Pagination{Limit: replayBatchSize}, storage.OutboxEventOrderBy{
+			{Field: storage.OutboxEventPosition, Direction: storage.ASC},
+		}, conditions...)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			if err = fn(newMessage(e)); err != nil {
+				return err
+			}
+			after = e.Position
+		}
+
+		if len(events) < replayBatchSize {
+			return nil
+		}
+	}
+}
+
+// notify publishes the events up to the position of a notification. An event is only
+// announced once all events before it are visible, so the feed publishes every event after
+// the latest one it published, also those of lost notifications.
+func (f *Feed) notify(ctx context.Context, payload string) {
+	if payload != "" {
+		position, err := strconv.ParseInt(payload, 10, 64)
+		if err != nil {
+			f.logger.Warn("invalid outbox event notification", slog.String("payload", payload))
+			return
+		}
+
+		if position <= f.last {
+			return
+		}
+	}
+
+	if err := f.Replay(ctx, f.last, "", func(m Message) error {
+		f.publish(ctx, m)
+		return nil
+	}); err != nil {
+		f.logger.Error("failed to catch up on outbox events", slog.String("error", err.Error()))
+	}
+}
+
+func (f *Feed) publish(ctx context.Context, m Message) {
+	f.last = m.Position
+
+	if err := f.sink.Publish(ctx, m); err != nil {
+		f.logger.Error("failed to publish outbox event to subscribers",
+			slog.Int64("id", m.ID),
+			slog.Int64("position", m.Position),
+			slog.String("error", err.Error()),
+		)
+	}
+}
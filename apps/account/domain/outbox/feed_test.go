@@ -0,0 +1,190 @@
+package outbox_test
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+
+	outboxMock "github.com/extreme-business/lingo/apps/account/storage/mock/outbox"
+)
+
+// newFeedReader returns a mock reader over the events that supports the conditions used by the feed.
+func newFeedReader(events *[]*storage.OutboxEvent) *outboxMock.Repository {
+	return &outboxMock.Repository{
+		ListFunc: func(_ context.Context, p storage.Pagination, s storage.OutboxEventOrderBy, conditions ...storage.Condition) ([]*storage.OutboxEvent, error) {
+			var out []*storage.OutboxEvent
+			for _, e := range *events {
+				match := true
+				for _, c := range conditions {
+					switch c := c.(type) {
+					case storage.OutboxEventAfterPositionCondition:
+						match = match && e.Position > c.Position
+					case storage.OutboxEventByAggregateTypeCondition:
+						match = match && e.AggregateType == c.AggregateType
+					}
+				}
+				if match {
+					out = append(out, e)
+				}
+			}
+
+			slices.SortFunc(out, func(a, b *storage.OutboxEvent) int { return int(a.Position - b.Position) })
+			if len(s) > 0 && s[0].Direction == storage.DESC {
+				slices.Reverse(out)
+			}
+
+			if len(out) > p.Limit {
+				out = out[:p.Limit]
+			}
+			return out, nil
+		},
+	}
+}
+
+func addEvents(t *testing.T, repo *outboxMock.Repository, events ...domain.Event) {
+	t.Helper()
+	if err := outbox.NewWriter(time.Now, repo, newJobs()).Add(context.Background(), events...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func ids(messages []outbox.Message) []int64 {
+	var out []int64
+	for _, m := range messages {
+		out = append(out, m.ID)
+	}
+	return out
+}
+
+func TestFeed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should publish notified events and catch up after lost notifications", func(t *testing.T) {
+		repo, events := newOutbox()
+		reader := newFeedReader(events)
+		addEvents(t, repo, userUpdated(alice, "email")) // before the feed started.
+
+		feed, err := outbox.NewFeed(outbox.FeedConfig{
+			Logger: slog.Default(),
+			Reader: reader,
+			Listen: func(_ context.Context, notify func(string)) error {
+				addEvents(t, repo, userUpdated(bob, "email"))
+				notify("2")
+				addEvents(t, repo, userUpdated(alice, "display_name"), userUpdated(bob, "display_name"))
+				notify("") // notifications 3 and 4 were lost.
+				notify("3")
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []outbox.Message
+		feed.Subscribe(func(_ context.Context, m outbox.Message) error {
+			got = append(got, m)
+			return nil
+		})
+
+		if err = feed.Run(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]int64{2, 3, 4}, ids(got)); diff != "" {
+			t.Errorf("published mismatch (-want +got):\n%s", diff)
+		}
+
+		for _, c := range []<-chan struct{}{feed.Ready(), feed.Done()} {
+			select {
+			case <-c:
+			default:
+				t.Error("expected the feed to be ready and done")
+			}
+		}
+	})
+
+	t.Run("should publish the events in commit order", func(t *testing.T) {
+		// the event with id 1 committed after the event with id 2.
+		events := []*storage.OutboxEvent{
+			{ID: 1, Position: 2, AggregateType: domain.UserAggregate},
+			{ID: 2, Position: 1, AggregateType: domain.UserAggregate},
+		}
+
+		var all []*storage.OutboxEvent
+		feed, err := outbox.NewFeed(outbox.FeedConfig{
+			Logger: slog.Default(),
+			Reader: newFeedReader(&all),
+			Listen: func(_ context.Context, notify func(string)) error {
+				all = append(all, events[1])
+				notify("1")
+				all = append(all, events[0])
+				notify("2")
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []outbox.Message
+		feed.Subscribe(func(_ context.Context, m outbox.Message) error {
+			got = append(got, m)
+			return nil
+		})
+
+		if err = feed.Run(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]int64{2, 1}, ids(got)); diff != "" {
+			t.Errorf("published mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should replay the events of an aggregate type after a position", func(t *testing.T) {
+		repo, events := newOutbox()
+		addEvents(t, repo,
+			userUpdated(alice, "email"),
+			domain.OrganizationDeleted{OrganizationID: bob},
+			userUpdated(alice, "display_name"),
+			userUpdated(bob, "email"),
+		)
+
+		feed, err := outbox.NewFeed(outbox.FeedConfig{
+			Logger: slog.Default(),
+			Reader: newFeedReader(events),
+			Listen: func(context.Context, func(string)) error { return nil },
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []outbox.Message
+		if err = feed.Replay(ctx, 1, domain.UserAggregate, func(m outbox.Message) error {
+			got = append(got, m)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]int64{3, 4}, ids(got)); diff != "" {
+			t.Errorf("Replay() mismatch (-want +got):\n%s", diff)
+		}
+
+		latest, err := feed.Latest(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest != 4 {
+			t.Errorf("expected latest event 4, got %d", latest)
+		}
+	})
+}
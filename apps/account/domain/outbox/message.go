@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+// ErrUnknownEventType is returned when a message can not be decoded into a domain event.
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// Message is an event as it is published to sinks.
+// Consumers can use the id to deduplicate deliveries. The position orders the events by commit,
+// see storage.OutboxEvent.
+type Message struct {
+	ID            int64
+	Position      int64
+	AggregateType string
+	AggregateID   uuid.UUID
+	Type          domain.EventType
+	Payload       json.RawMessage
+	CreateTime    time.Time
+}
+
+// newMessage maps a storage.OutboxEvent to a Message.
+func newMessage(e *storage.OutboxEvent) Message {
+	return Message{
+		ID:            e.ID,
+		Position:      e.Position,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Type:          domain.EventType(e.Type),
+		Payload:       e.Payload,
+		CreateTime:    e.CreateTime,
+	}
+}
+
+// Decode decodes the payload into the domain event of its type, such as domain.UserCreated.
+func (m Message) Decode() (domain.Event, error) {
+	switch m.Type {
+	case domain.EventUserCreated:
+		return decode[domain.UserCreated](m)
+	case domain.EventUserUpdated:
+		return decode[domain.UserUpdated](m)
+	case domain.EventUserDeleted:
+		return decode[domain.UserDeleted](m)
+	case domain.EventOrganizationCreated:
+		return decode[domain.OrganizationCreated](m)
+	case domain.EventOrganizationUpdated:
+		return decode[domain.OrganizationUpdated](m)
+	case domain.EventOrganizationDeleted:
+		return decode[domain.OrganizationDeleted](m)
+	default:
+		return nil, fmt.Errorf("%s: %w", m.Type, ErrUnknownEventType)
+	}
+}
+
+func decode[E domain.Event](m Message) (domain.Event, error) {
+	var e E
+	if err := json.Unmarshal(m.Payload, &e); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", m.Type, err)
+	}
+	return e, nil
+}
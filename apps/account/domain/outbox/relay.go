@@ -0,0 +1,231 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultMaxAttempts = 10
+)
+
+// RelayArgs are the arguments of the job that publishes the pending events with a Relay.
+// The Writer enqueues it with the events.
+type RelayArgs struct{}
+
+func (RelayArgs) Kind() string { return "outbox.relay" }
+
+// Relay publishes the events in the outbox to the sinks.
+//
+// Events are published in the order they were committed. When an event can not be published, the later events
+// of the same aggregate are held back until it succeeds, so consumers see the changes of an
+// aggregate in the order they were made. A failed event is attempted again after a backoff, see jobs.Backoff,
+// and parked after MaxAttempts. A parked event keeps holding back its aggregate until it is redriven.
+// Only one relay publishes at a time.
+type Relay struct {
+	logger      *slog.Logger
+	clock       func() time.Time
+	dbManager   storage.DBManager
+	sinks       []Sink
+	batchSize   int
+	maxAttempts int
+}
+
+type Config struct {
+	Logger      *slog.Logger
+	Clock       func() time.Time
+	DBManager   storage.DBManager
+	Sinks       []Sink
+	BatchSize   int // BatchSize is the maximum number of events published per call to Publish, defaults to 100.
+	MaxAttempts int // MaxAttempts is how often an event is attempted before it is parked, defaults to 10.
+}
+
+func (c Config) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Clock == nil {
+		return errors.New("clock is required")
+	}
+
+	if c.DBManager == nil {
+		return errors.New("db manager is required")
+	}
+
+	if len(c.Sinks) == 0 {
+		return errors.New("at least one sink is required")
+	}
+
+	return nil
+}
+
+func NewRelay(c Config) (*Relay, error) {
+	r := &Relay{
+		logger:      c.Logger,
+		clock:       c.Clock,
+		dbManager:   c.DBManager,
+		sinks:       c.Sinks,
+		batchSize:   c.BatchSize,
+		maxAttempts: c.MaxAttempts,
+	}
+
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBatchSize
+	}
+
+	if r.maxAttempts <= 0 {
+		r.maxAttempts = defaultMaxAttempts
+	}
+
+	return r, c.Validate()
+}
+
+// Publish publishes a batch of pending events and returns how many were published. It pages past the
+// events of blocked aggregates, so they do not hold back the events of other aggregates behind them.
+func (r *Relay) Publish(ctx context.Context) (int, error) {
+	var n int
+	err := r.dbManager.BeginOp(ctx, func(ctx context.Context, repos storage.Repositories) error {
+		if err := repos.OutboxEvent.Lock(ctx); err != nil {
+			return err
+		}
+
+		now := r.clock()
+		blocked := map[uuid.UUID]struct{}{} // aggregates with an event that could not be published
+		var after int64                     // position of the last listed event
+		for n < r.batchSize {
+			conditions := []storage.Condition{storage.OutboxEventUnpublishedCondition{}}
+			if after > 0 {
+				conditions = append(conditions, storage.OutboxEventAfterPositionCondition{Position: after})
+			}
+
+			events, err := repos.OutboxEvent.List(ctx, storage.Pagination{Limit: r.batchSize}, storage.OutboxEventOrderBy{
+				{Field: storage.OutboxEventPosition, Direction: storage.ASC},
+			}, conditions...)
+			if err != nil {
+				return fmt.Errorf("failed to list outbox events: %w", err)
+			}
+
+			for _, e := range events {
+				after = e.Position
+				if _, ok := blocked[e.AggregateID]; ok {
+					continue
+				}
+
+				// a parked event or one that waits for its next attempt holds back its aggregate.
+				if e.FailTime.Valid || (e.NextAttemptTime.Valid && e.NextAttemptTime.Time.After(now)) {
+					blocked[e.AggregateID] = struct{}{}
+					continue
+				}
+
+				if n == r.batchSize {
+					return nil
+				}
+
+				ok, err := r.attempt(ctx, repos.OutboxEvent, e)
+				if err != nil {
+					return err
+				}
+
+				if ok {
+					n++
+				} else {
+					blocked[e.AggregateID] = struct{}{}
+				}
+			}
+
+			if len(events) < r.batchSize {
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// attempt publishes an event and stores the outcome. It reports whether the event was published.
+func (r *Relay) attempt(ctx context.Context, w storage.OutboxEventWriter, e *storage.OutboxEvent) (bool, error) {
+	fields := []storage.OutboxEventField{storage.OutboxEventAttempts, storage.OutboxEventLastError, storage.OutboxEventNextAttemptTime}
+	e.Attempts++
+	e.LastError = ""
+	pErr := r.publish(ctx, newMessage(e))
+	if pErr != nil {
+		e.LastError = pErr.Error()
+		attrs := []any{
+			slog.Int64("id", e.ID),
+			slog.String("type", e.Type),
+			slog.String("aggregate_id", e.AggregateID.String()),
+			slog.Int("attempts", e.Attempts),
+			slog.String("error", pErr.Error()),
+		}
+
+		if e.Attempts >= r.maxAttempts {
+			e.FailTime = sql.NullTime{Time: r.clock(), Valid: true}
+			e.NextAttemptTime = sql.NullTime{}
+			fields = append(fields, storage.OutboxEventFailTime)
+			r.logger.Error("gave up on publishing outbox event, it is parked until it is redriven", attrs...)
+		} else {
+			e.NextAttemptTime = sql.NullTime{Time: r.clock().Add(jobs.Backoff(e.Attempts)), Valid: true}
+			r.logger.Warn("failed to publish outbox event", append(attrs, slog.Time("next_attempt_time", e.NextAttemptTime.Time))...)
+		}
+	} else {
+		e.PublishTime = sql.NullTime{Time: r.clock(), Valid: true}
+		e.NextAttemptTime = sql.NullTime{}
+		fields = append(fields, storage.OutboxEventPublishTime)
+	}
+
+	if _, err := w.Update(ctx, e, fields); err != nil {
+		return false, fmt.Errorf("failed to update outbox event %d: %w", e.ID, err)
+	}
+
+	return pErr == nil, nil
+}
+
+// Redrive hands the parked events with the ids back to the relay, all parked events without ids, and
+// enqueues the relay job to publish them. Their attempts start over. It returns how many were redriven.
+func (r *Relay) Redrive(ctx context.Context, ids ...int64) (int64, error) {
+	var n int64
+	err := r.dbManager.BeginOp(ctx, func(ctx context.Context, repos storage.Repositories) error {
+		var err error
+		if n, err = repos.OutboxEvent.Redrive(ctx, ids...); err != nil {
+			return err
+		}
+
+		if n == 0 {
+			return nil
+		}
+
+		return enqueueRelay(ctx, repos.Jobs)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// publish publishes the message to all sinks.
+func (r *Relay) publish(ctx context.Context, m Message) error {
+	for _, s := range r.sinks {
+		if err := s.Publish(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	jobMock "github.com/extreme-business/lingo/apps/account/storage/mock/job"
+	managerMock "github.com/extreme-business/lingo/apps/account/storage/mock/manager"
+	outboxMock "github.com/extreme-business/lingo/apps/account/storage/mock/outbox"
+)
+
+var (
+	alice = uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770")
+	bob   = uuid.MustParse("a3c8e7a1-4b4e-4f4b-9c1f-6c9f3f1e2d7b")
+)
+
+// newOutbox returns a mock repository that keeps the outbox in a slice.
+func newOutbox() (*outboxMock.Repository, *[]*storage.OutboxEvent) {
+	var events []*storage.OutboxEvent
+	return &outboxMock.Repository{
+		LockFunc: func(context.Context) error { return nil },
+		CreateFunc: func(_ context.Context, e *storage.OutboxEvent) (*storage.OutboxEvent, error) {
+			e.ID = int64(len(events) + 1)
+			e.Position = e.ID // the mock commits right away.
+			events = append(events, e)
+			return e, nil
+		},
+		UpdateFunc: func(_ context.Context, e *storage.OutboxEvent, _ []storage.OutboxEventField) (*storage.OutboxEvent, error) {
+			events[e.ID-1] = e
+			return e, nil
+		},
+		ListFunc: func(_ context.Context, p storage.Pagination, _ storage.OutboxEventOrderBy, conditions ...storage.Condition) ([]*storage.OutboxEvent, error) {
+			var after int64
+			for _, c := range conditions {
+				if c, ok := c.(storage.OutboxEventAfterPositionCondition); ok {
+					after = c.Position
+				}
+			}
+
+			var out []*storage.OutboxEvent
+			for _, e := range events {
+				if e.Position > after && !e.PublishTime.Valid && len(out) < p.Limit {
+					c := *e
+					out = append(out, &c)
+				}
+			}
+			return out, nil
+		},
+		RedriveFunc: func(_ context.Context, ids ...int64) (int64, error) {
+			var n int64
+			for _, e := range events {
+				if e.FailTime.Valid && (len(ids) == 0 || slices.Contains(ids, e.ID)) {
+					e.FailTime, e.NextAttemptTime, e.Attempts = sql.NullTime{}, sql.NullTime{}, 0
+					n++
+				}
+			}
+			return n, nil
+		},
+	}, &events
+}
+
+// newJobs returns a mock enqueuer that accepts every job.
+func newJobs() *jobMock.Enqueuer {
+	return &jobMock.Enqueuer{
+		EnqueueFunc: func(context.Context, jobs.Args, ...jobs.EnqueueOption) (bool, error) { return true, nil },
+	}
+}
+
+func userUpdated(id uuid.UUID, field string) domain.UserUpdated {
+	return domain.UserUpdated{User: domain.UserState{ID: id}, Fields: []string{field}}
+}
+
+// relayConfig returns the config of a relay that publishes the events of the repository to the sinks.
+func relayConfig(repo storage.OutboxEventRepository, sinks ...outbox.Sink) outbox.Config {
+	return outbox.Config{
+		Logger:    slog.Default(),
+		Clock:     time.Now,
+		DBManager: managerMock.New(storage.Repositories{OutboxEvent: repo}),
+		Sinks:     sinks,
+	}
+}
+
+func newRelay(t *testing.T, repo storage.OutboxEventRepository, sinks ...outbox.Sink) *outbox.Relay {
+	t.Helper()
+	return newRelayWith(t, relayConfig(repo, sinks...))
+}
+
+func newRelayWith(t *testing.T, c outbox.Config) *outbox.Relay {
+	t.Helper()
+
+	r, err := outbox.NewRelay(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestRelay_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should publish events in order and only once", func(t *testing.T) {
+		repo, _ := newOutbox()
+		w := outbox.NewWriter(time.Now, repo, newJobs())
+		if err := w.Add(ctx, userUpdated(alice, "email"), userUpdated(bob, "email"), userUpdated(alice, "display_name")); err != nil {
+			t.Fatal(err)
+		}
+
+		sink := outbox.NewSubscriberSink()
+		var got []int64
+		sink.Subscribe(func(_ context.Context, m outbox.Message) error {
+			got = append(got, m.ID)
+			return nil
+		})
+
+		r := newRelay(t, repo, sink)
+		for i := 0; i < 2; i++ {
+			if _, err := r.Publish(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if diff := cmp.Diff([]int64{1, 2, 3}, got); diff != "" {
+			t.Errorf("Publish() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should hold back later events of an aggregate that failed", func(t *testing.T) {
+		repo, events := newOutbox()
+		w := outbox.NewWriter(time.Now, repo, newJobs())
+		if err := w.Add(ctx, userUpdated(alice, "email"), userUpdated(bob, "email"), userUpdated(alice, "display_name")); err != nil {
+			t.Fatal(err)
+		}
+
+		fail := true
+		var got []int64
+		now := time.Now()
+		c := relayConfig(repo, outbox.SinkFunc(func(_ context.Context, m outbox.Message) error {
+			if fail && m.AggregateID == alice {
+				return errors.New("sink unavailable")
+			}
+			got = append(got, m.ID)
+			return nil
+		}))
+		c.Clock = func() time.Time { return now }
+		r := newRelayWith(t, c)
+
+		n, err := r.Publish(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 1 || !slices.Equal(got, []int64{2}) {
+			t.Errorf("expected only the event of the other aggregate to be published, got %v", got)
+		}
+
+		if e := (*events)[0]; e.Attempts != 1 || e.LastError == "" {
+			t.Errorf("expected the failed attempt to be recorded, got %+v", e)
+		}
+
+		if e := (*events)[2]; e.Attempts != 0 {
+			t.Errorf("expected the later event to be held back, got %+v", e)
+		}
+
+		fail = false
+		if _, err = r.Publish(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if e := (*events)[0]; e.Attempts != 1 || e.PublishTime.Valid {
+			t.Errorf("expected the failed event to wait for its next attempt, got %+v", e)
+		}
+
+		now = now.Add(jobs.Backoff(1))
+		if _, err = r.Publish(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]int64{2, 1, 3}, got); diff != "" {
+			t.Errorf("Publish() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should park an event after the max attempts and hold back its aggregate until it is redriven", func(t *testing.T) {
+		repo, events := newOutbox()
+		w := outbox.NewWriter(time.Now, repo, newJobs())
+		if err := w.Add(ctx, userUpdated(alice, "email"), userUpdated(alice, "display_name")); err != nil {
+			t.Fatal(err)
+		}
+
+		fail := true
+		var got []int64
+		now := time.Now()
+		c := relayConfig(repo, outbox.SinkFunc(func(_ context.Context, m outbox.Message) error {
+			if fail && m.ID == 1 {
+				return errors.New("sink unavailable")
+			}
+			got = append(got, m.ID)
+			return nil
+		}))
+		c.Clock = func() time.Time { return now }
+		c.MaxAttempts = 2
+		c.DBManager = managerMock.New(storage.Repositories{OutboxEvent: repo, Jobs: newJobs()})
+		r := newRelayWith(t, c)
+
+		for range 2 {
+			if _, err := r.Publish(ctx); err != nil {
+				t.Fatal(err)
+			}
+			now = now.Add(jobs.Backoff(1))
+		}
+
+		if e := (*events)[0]; e.Attempts != 2 || !e.FailTime.Valid || e.PublishTime.Valid {
+			t.Errorf("expected the event to be parked, got %+v", e)
+		}
+
+		n, err := r.Publish(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 0 || len(got) != 0 || (*events)[0].Attempts != 2 {
+			t.Errorf("expected the parked event to hold back its aggregate, got %v published", got)
+		}
+
+		fail = false
+		redriven, err := r.Redrive(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if redriven != 1 {
+			t.Errorf("expected 1 event to be redriven, got %d", redriven)
+		}
+
+		if _, err = r.Publish(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]int64{1, 2}, got); diff != "" {
+			t.Errorf("Publish() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should page past a batch of blocked events", func(t *testing.T) {
+		repo, _ := newOutbox()
+		w := outbox.NewWriter(time.Now, repo, newJobs())
+		if err := w.Add(ctx,
+			userUpdated(alice, "email"),
+			userUpdated(alice, "display_name"),
+			userUpdated(alice, "status"),
+			userUpdated(bob, "email"),
+			userUpdated(bob, "display_name"),
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []int64
+		c := relayConfig(repo, outbox.SinkFunc(func(_ context.Context, m outbox.Message) error {
+			if m.AggregateID == alice {
+				return errors.New("sink unavailable")
+			}
+			got = append(got, m.ID)
+			return nil
+		}))
+		c.BatchSize = 2
+		r := newRelayWith(t, c)
+
+		n, err := r.Publish(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 2 {
+			t.Errorf("expected a batch of 2 events, got %d", n)
+		}
+
+		if diff := cmp.Diff([]int64{4, 5}, got); diff != "" {
+			t.Errorf("Publish() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestMessage_Decode(t *testing.T) {
+	t.Run("should decode the event of the message type", func(t *testing.T) {
+		repo, events := newOutbox()
+		want := userUpdated(alice, "email")
+		if err := outbox.NewWriter(time.Now, repo, newJobs()).Add(context.Background(), want); err != nil {
+			t.Fatal(err)
+		}
+
+		var m outbox.Message
+		r := newRelay(t, repo, outbox.SinkFunc(func(_ context.Context, got outbox.Message) error {
+			m = got
+			return nil
+		}))
+		if _, err := r.Publish(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := m.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(domain.Event(want), got); diff != "" {
+			t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+		}
+
+		if (*events)[0].Type != domain.EventUserUpdated.String() {
+			t.Errorf("expected type %s, got %s", domain.EventUserUpdated, (*events)[0].Type)
+		}
+	})
+
+	t.Run("should return an error for an unknown type", func(t *testing.T) {
+		if _, err := (outbox.Message{Type: "unknown"}).Decode(); !errors.Is(err, outbox.ErrUnknownEventType) {
+			t.Errorf("expected %v, got %v", outbox.ErrUnknownEventType, err)
+		}
+	})
+}
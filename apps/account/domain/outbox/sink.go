@@ -0,0 +1,81 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Sink receives published events. Delivery is at-least-once: a sink may see the same
+// message again when publishing failed or the relay stopped before recording it, so
+// sinks should be idempotent on Message.ID.
+type Sink interface {
+	Publish(context.Context, Message) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(context.Context, Message) error
+
+func (f SinkFunc) Publish(ctx context.Context, m Message) error { return f(ctx, m) }
+
+// LogSink logs every published event.
+type LogSink struct {
+	Logger *slog.Logger
+}
+
+func (s LogSink) Publish(ctx context.Context, m Message) error {
+	s.Logger.InfoContext(ctx, "event published",
+		slog.Int64("id", m.ID),
+		slog.String("type", m.Type.String()),
+		slog.String("aggregate_type", m.AggregateType),
+		slog.String("aggregate_id", m.AggregateID.String()),
+	)
+	return nil
+}
+
+// SubscriberSink fans out events to in-process subscribers.
+// A failing subscriber fails the publish, so the event is delivered again to all subscribers.
+type SubscriberSink struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[int]SinkFunc
+}
+
+func NewSubscriberSink() *SubscriberSink {
+	return &SubscriberSink{
+		subscribers: map[int]SinkFunc{},
+	}
+}
+
+// Subscribe registers f for all events published after the call and returns a function to unsubscribe.
+func (s *SubscriberSink) Subscribe(f SinkFunc) (unsubscribe func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = f
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+func (s *SubscriberSink) Publish(ctx context.Context, m Message) error {
+	s.mu.RLock()
+	subscribers := make([]SinkFunc, 0, len(s.subscribers))
+	for _, f := range s.subscribers {
+		subscribers = append(subscribers, f)
+	}
+	s.mu.RUnlock()
+
+	for _, f := range subscribers {
+		if err := f(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+// Writer adds domain events to the outbox and enqueues the relay job that publishes them.
+// It must use the repositories of the transaction that makes the change, so the events and
+// the job are only stored when the change is committed.
+type Writer struct {
+	c  func() time.Time // c is the clock function.
+	ow storage.OutboxEventWriter
+	j  storage.JobEnqueuer
+}
+
+func NewWriter(c func() time.Time, w storage.OutboxEventWriter, j storage.JobEnqueuer) *Writer {
+	return &Writer{
+		c:  c,
+		ow: w,
+		j:  j,
+	}
+}
+
+// Add stores the events in the outbox in the given order.
+func (w *Writer) Add(ctx context.Context, events ...domain.Event) Error {
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", e.EventType(), err)
+		}
+
+		if _, err = w.ow.Create(ctx, &storage.OutboxEvent{
+			AggregateType: e.AggregateType(),
+			AggregateID:   e.AggregateID(),
+			Type:          e.EventType().String(),
+			Payload:       payload,
+			CreateTime:    w.c(),
+		}); err != nil {
+			return fmt.Errorf("failed to add %s event to the outbox: %w", e.EventType(), err)
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	return enqueueRelay(ctx, w.j)
+}
+
+// enqueueRelay enqueues the relay job. A relay job that is still waiting publishes the new events as well.
+// While a relay job runs, one more waits for it, so events committed during the run are not left to the schedule.
+func enqueueRelay(ctx context.Context, j storage.JobEnqueuer) error {
+	if _, err := j.Enqueue(ctx, RelayArgs{}, jobs.WithUniqueKey(RelayArgs{}.Kind())); err != nil {
+		return fmt.Errorf("failed to enqueue the outbox relay: %w", err)
+	}
+
+	return nil
+}
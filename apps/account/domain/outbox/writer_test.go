@@ -0,0 +1,48 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/pkg/jobs"
+
+	jobMock "github.com/extreme-business/lingo/apps/account/storage/mock/job"
+)
+
+func TestWriter_Add(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should enqueue the relay job with the events", func(t *testing.T) {
+		repo, events := newOutbox()
+		var kinds []string
+		w := outbox.NewWriter(time.Now, repo, &jobMock.Enqueuer{
+			EnqueueFunc: func(_ context.Context, args jobs.Args, _ ...jobs.EnqueueOption) (bool, error) {
+				kinds = append(kinds, args.Kind())
+				return true, nil
+			},
+		})
+
+		if err := w.Add(ctx, userUpdated(alice, "email"), userUpdated(bob, "email")); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(*events) != 2 {
+			t.Errorf("expected 2 events, got %d", len(*events))
+		}
+
+		if len(kinds) != 1 || kinds[0] != (outbox.RelayArgs{}).Kind() {
+			t.Errorf("expected one %s job, got %v", outbox.RelayArgs{}.Kind(), kinds)
+		}
+	})
+
+	t.Run("should not enqueue the relay job without events", func(t *testing.T) {
+		repo, _ := newOutbox()
+		w := outbox.NewWriter(time.Now, repo, &jobMock.Enqueuer{})
+
+		if err := w.Add(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SessionCollection is the name of the session collection.
+const SessionCollection = "sessions"
+
+// Session is a login of a user on a device. It lives as long as its refresh token is kept fresh.
+type Session struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	UserAgent       string
+	IPAddress       string
+	RefreshFamily   uuid.UUID
+	RefreshTokenID  uuid.UUID
+	CreateTime      time.Time
+	LastRefreshTime time.Time
+	ExpireTime      time.Time
+	RevokeTime      time.Time
+	Impersonator    string // Impersonator is the resource name of the user acting as the session user, if any.
+}
+
+// SessionName returns the resource name of a session.
+func SessionName(organizationID, userID, sessionID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", UserName(organizationID, userID), SessionCollection, sessionID)
+}
+
+// Active reports whether the session can still be used at t.
+func (s *Session) Active(t time.Time) bool {
+	return s.RevokeTime.IsZero() && t.Before(s.ExpireTime)
+}
+
+// Impersonated reports whether the session was started by someone acting as the user.
+func (s *Session) Impersonated() bool {
+	return s.Impersonator != ""
+}
+
+// ToProto maps the session to its proto representation.
+// The organization is needed to build the resource name.
+func (s *Session) ToProto(organizationID uuid.UUID, in *protoaccount.Session) error {
+	in.Name = SessionName(organizationID, s.UserID, s.ID)
+	in.UserAgent = s.UserAgent
+	in.IpAddress = s.IPAddress
+	in.Impersonator = s.Impersonator
+	in.CreateTime = timestamppb.New(s.CreateTime)
+	in.LastRefreshTime = timestamppb.New(s.LastRefreshTime)
+	in.ExpireTime = timestamppb.New(s.ExpireTime)
+	return nil
+}
+
+// ToStorage maps a Session to a storage.Session.
+func (s *Session) ToStorage(out *storage.Session) error {
+	for _, field := range storage.SessionFields() {
+		switch field {
+		case storage.SessionID:
+			out.ID = s.ID
+		case storage.SessionUserID:
+			out.UserID = s.UserID
+		case storage.SessionUserAgent:
+			out.UserAgent = s.UserAgent
+		case storage.SessionIPAddress:
+			out.IPAddress = s.IPAddress
+		case storage.SessionRefreshFamily:
+			out.RefreshFamily = s.RefreshFamily
+		case storage.SessionRefreshTokenID:
+			out.RefreshTokenID = s.RefreshTokenID
+		case storage.SessionCreateTime:
+			out.CreateTime = s.CreateTime
+		case storage.SessionLastRefreshTime:
+			out.LastRefreshTime = s.LastRefreshTime
+		case storage.SessionExpireTime:
+			out.ExpireTime = s.ExpireTime
+		case storage.SessionRevokeTime:
+			out.RevokeTime.Time = s.RevokeTime
+			out.RevokeTime.Valid = !s.RevokeTime.IsZero()
+		case storage.SessionImpersonator:
+			out.Impersonator = s.Impersonator
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.Session to a Session.
+func (s *Session) FromStorage(in *storage.Session) error {
+	for _, field := range storage.SessionFields() {
+		switch field {
+		case storage.SessionID:
+			s.ID = in.ID
+		case storage.SessionUserID:
+			s.UserID = in.UserID
+		case storage.SessionUserAgent:
+			s.UserAgent = in.UserAgent
+		case storage.SessionIPAddress:
+			s.IPAddress = in.IPAddress
+		case storage.SessionRefreshFamily:
+			s.RefreshFamily = in.RefreshFamily
+		case storage.SessionRefreshTokenID:
+			s.RefreshTokenID = in.RefreshTokenID
+		case storage.SessionCreateTime:
+			s.CreateTime = in.CreateTime
+		case storage.SessionLastRefreshTime:
+			s.LastRefreshTime = in.LastRefreshTime
+		case storage.SessionExpireTime:
+			s.ExpireTime = in.ExpireTime
+		case storage.SessionRevokeTime:
+			s.RevokeTime = in.RevokeTime.Time
+		case storage.SessionImpersonator:
+			s.Impersonator = in.Impersonator
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
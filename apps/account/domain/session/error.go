@@ -0,0 +1,4 @@
+package session
+
+// Error defines the session domain errors.
+type Error error
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound Error = errors.New("session not found")
+)
+
+type Reader struct {
+	c      func() time.Time // c is the clock function.
+	reader storage.SessionReader
+}
+
+func NewReader(c func() time.Time, storage storage.SessionReader) *Reader {
+	return &Reader{c: c, reader: storage}
+}
+
+func (r *Reader) Get(ctx context.Context, id uuid.UUID) (*domain.Session, Error) {
+	session, err := r.reader.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	var s = new(domain.Session)
+	return s, s.FromStorage(session)
+}
+
+// ListActive lists the sessions of a user that are neither revoked nor expired, newest first.
+func (r *Reader) ListActive(ctx context.Context, userID uuid.UUID) ([]*domain.Session, Error) {
+	sessions, err := r.reader.List(ctx, storage.Pagination{}, storage.SessionOrderBy{
+		{Field: storage.SessionCreateTime, Direction: storage.DESC},
+	},
+		storage.SessionByUserIDCondition{UserID: userID},
+		storage.SessionActiveCondition{Time: r.c()},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*domain.Session
+	for _, session := range sessions {
+		var s domain.Session
+		if err = s.FromStorage(session); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &s)
+	}
+
+	return out, nil
+}
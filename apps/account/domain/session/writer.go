@@ -0,0 +1,132 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStaleRefreshToken Error = errors.New("refresh token was rotated or the session was revoked")
+)
+
+type Writer struct {
+	c  func() time.Time // c is the clock function.
+	sr storage.SessionReader
+	sw storage.SessionWriter
+}
+
+// NewWriter creates a new session writer.
+// The repository is used both to look up the sessions to revoke and to write them.
+func NewWriter(c func() time.Time, r storage.SessionRepository) *Writer {
+	return &Writer{
+		c:  c,
+		sr: r,
+		sw: r,
+	}
+}
+
+// Create creates a session. The create and refresh times are set to the current time.
+func (w *Writer) Create(ctx context.Context, s *domain.Session) (*domain.Session, Error) {
+	s.CreateTime = w.c()
+	s.LastRefreshTime = s.CreateTime
+	var err error
+	var in = new(storage.Session)
+	if err = s.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err = w.sw.Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.Session{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update updates the session.
+// fields are sorted before updating the session and deduplicated.
+func (w *Writer) Update(ctx context.Context, s *domain.Session, fields []storage.SessionField) (*domain.Session, Error) {
+	var err error
+	in := &storage.Session{}
+	if err = s.ToStorage(in); err != nil {
+		return nil, err
+	}
+	slices.Sort(fields)
+	in, err = w.sw.Update(ctx, in, slices.Compact(fields))
+	if err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	result := &domain.Session{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RotateRefreshToken stores the new refresh token id, last refresh time and expire time of the session,
+// if its refresh token is still previousTokenID and it is not revoked. Otherwise it returns ErrStaleRefreshToken.
+func (w *Writer) RotateRefreshToken(ctx context.Context, s *domain.Session, previousTokenID uuid.UUID) (*domain.Session, Error) {
+	var err error
+	in := &storage.Session{}
+	if err = s.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err = w.sw.RotateRefreshToken(ctx, in, previousTokenID)
+	if err != nil {
+		if errors.Is(err, storage.ErrStaleSessionRefreshToken) {
+			return nil, ErrStaleRefreshToken
+		}
+		return nil, err
+	}
+	result := &domain.Session{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Revoke revokes the session. Revoking an already revoked session is a no-op.
+func (w *Writer) Revoke(ctx context.Context, s *domain.Session) (*domain.Session, Error) {
+	if !s.RevokeTime.IsZero() {
+		return s, nil
+	}
+
+	s.RevokeTime = w.c()
+	return w.Update(ctx, s, []storage.SessionField{storage.SessionRevokeTime})
+}
+
+// RevokeAll revokes all active sessions of a user and returns how many were revoked.
+func (w *Writer) RevokeAll(ctx context.Context, userID uuid.UUID) (int, Error) {
+	sessions, err := w.sr.List(ctx, storage.Pagination{}, storage.SessionOrderBy{},
+		storage.SessionByUserIDCondition{UserID: userID},
+		storage.SessionActiveCondition{Time: w.c()},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, in := range sessions {
+		var s domain.Session
+		if err = s.FromStorage(in); err != nil {
+			return 0, err
+		}
+
+		if _, err = w.Revoke(ctx, &s); err != nil {
+			return 0, fmt.Errorf("failed to revoke session %s: %w", s.ID, err)
+		}
+	}
+
+	return len(sessions), nil
+}
@@ -0,0 +1,148 @@
+package translation
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Entry is a word and its translation from one language into another.
+type Entry struct {
+	Source      string // Source is the language of the word.
+	Target      string // Target is the language of the translation.
+	Word        string
+	Translation string
+}
+
+// Dictionary is a deterministic Translator for tests and local development.
+// It translates word by word and keeps the words it does not know. The text is prefixed with
+// the target language, such as "[nl] ", so a translation is easy to tell from the original.
+type Dictionary struct {
+	words map[[2]string]map[string]string // words by base source and target language.
+}
+
+var _ TermTranslator = &Dictionary{}
+
+func NewDictionary(entries ...Entry) *Dictionary {
+	d := &Dictionary{words: make(map[[2]string]map[string]string)}
+	for _, e := range entries {
+		pair := [2]string{BaseLanguage(e.Source), BaseLanguage(e.Target)}
+		if d.words[pair] == nil {
+			d.words[pair] = make(map[string]string)
+		}
+		d.words[pair][strings.ToLower(e.Word)] = e.Translation
+	}
+	return d
+}
+
+// Translate translates the words of text it knows. A word that starts with a capital keeps it.
+func (d *Dictionary) Translate(ctx context.Context, text, source, target string) (string, error) {
+	return d.TranslateTerms(ctx, text, source, target, nil)
+}
+
+// TranslateTerms translates like Translate, but follows the terms: a term is replaced by its translation,
+// or kept as it is when it is not translated. Terms match whole words, ignoring case, the longest term wins.
+func (d *Dictionary) TranslateTerms(_ context.Context, text, source, target string, terms []Term) (string, error) {
+	words := d.words[[2]string{BaseLanguage(source), BaseLanguage(target)}]
+
+	phrases := make([][]string, len(terms)) // phrases are the lower case words of the terms.
+	for i, t := range terms {
+		for _, s := range wordSpans(t.Term) {
+			phrases[i] = append(phrases[i], strings.ToLower(t.Term[s.start:s.end]))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("[" + target + "] ")
+
+	spans := wordSpans(text)
+	prev := 0 // prev is the end of the last word written.
+	for i := 0; i < len(spans); {
+		b.WriteString(text[prev:spans[i].start])
+
+		t, n := matchTerm(text, spans[i:], terms, phrases)
+		switch {
+		case n == 0:
+			b.WriteString(translateWord(words, text[spans[i].start:spans[i].end]))
+			n = 1
+		case t.DoNotTranslate:
+			b.WriteString(text[spans[i].start:spans[i+n-1].end])
+		default:
+			b.WriteString(t.Translation)
+		}
+
+		prev = spans[i+n-1].end
+		i += n
+	}
+	b.WriteString(text[prev:])
+
+	return b.String(), nil
+}
+
+// span is the position of a word in a text.
+type span struct {
+	start, end int
+}
+
+// wordSpans returns the positions of the words in text.
+func wordSpans(text string) []span {
+	var spans []span
+	start := -1 // start of the current word, -1 between words.
+	for i, r := range text {
+		switch {
+		case isWordRune(r) && start < 0:
+			start = i
+		case !isWordRune(r) && start >= 0:
+			spans = append(spans, span{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start, len(text)})
+	}
+	return spans
+}
+
+// matchTerm returns the longest term that starts at the first word, and its number of words. It returns 0 words
+// when no term matches.
+func matchTerm(text string, spans []span, terms []Term, phrases [][]string) (Term, int) {
+	var best Term
+	var n int
+	for i, phrase := range phrases {
+		if len(phrase) <= n || len(phrase) > len(spans) {
+			continue
+		}
+
+		matches := true
+		for j, w := range phrase {
+			if strings.ToLower(text[spans[j].start:spans[j].end]) != w {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			best, n = terms[i], len(phrase)
+		}
+	}
+	return best, n
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+}
+
+// translateWord looks up a word, ignoring its case.
+func translateWord(words map[string]string, word string) string {
+	t, ok := words[strings.ToLower(word)]
+	if !ok || t == "" {
+		return word
+	}
+
+	if first, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(first) {
+		r, size := utf8.DecodeRuneInString(t)
+		return string(unicode.ToUpper(r)) + t[size:]
+	}
+	return t
+}
@@ -0,0 +1,88 @@
+package translation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+)
+
+func TestDictionary_Translate(t *testing.T) {
+	d := translation.NewDictionary(
+		translation.Entry{Source: "en", Target: "nl", Word: "hello", Translation: "hallo"},
+		translation.Entry{Source: "en", Target: "nl", Word: "world", Translation: "wereld"},
+		translation.Entry{Source: "en", Target: "de", Word: "hello", Translation: "hallo"},
+	)
+
+	tests := []struct {
+		name           string
+		text           string
+		source, target string
+		want           string
+	}{
+		{
+			name:   "should translate the known words",
+			text:   "hello world",
+			source: "en", target: "nl",
+			want: "[nl] hallo wereld",
+		},
+		{
+			name:   "should keep punctuation, capitals and unknown words",
+			text:   "Hello, big world!",
+			source: "en", target: "nl",
+			want: "[nl] Hallo, big wereld!",
+		},
+		{
+			name:   "should look up the base languages",
+			text:   "hello",
+			source: "en-US", target: "nl-BE",
+			want: "[nl-BE] hallo",
+		},
+		{
+			name:   "should only use the words of the language pair",
+			text:   "hello world",
+			source: "en", target: "de",
+			want: "[de] hallo world",
+		},
+		{
+			name:   "should mark text without known words",
+			text:   "héllo",
+			source: "fr", target: "nl",
+			want: "[nl] héllo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.Translate(context.Background(), tt.text, tt.source, tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDictionary_TranslateTerms(t *testing.T) {
+	d := translation.NewDictionary(
+		translation.Entry{Source: "en", Target: "nl", Word: "open", Translation: "open"},
+		translation.Entry{Source: "en", Target: "nl", Word: "the", Translation: "de"},
+		translation.Entry{Source: "en", Target: "nl", Word: "cloud", Translation: "wolk"},
+	)
+	terms := []translation.Term{
+		{Term: "workspace", Translation: "werkruimte"},
+		{Term: "Lingo Cloud", DoNotTranslate: true},
+		{Term: "Lingo", Translation: "Taaltje"},
+	}
+
+	got, err := d.TranslateTerms(context.Background(), "Open the lingo  cloud workspace, not the cloud.", "en", "nl", terms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "[nl] Open de lingo  cloud werkruimte, not de wolk."; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
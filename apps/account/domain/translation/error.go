@@ -0,0 +1,4 @@
+package translation
+
+// Error defines the translation domain errors.
+type Error error
@@ -0,0 +1,45 @@
+package translation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/extreme-business/lingo/pkg/validate"
+	"golang.org/x/text/language"
+)
+
+// maxLanguageLength is the maximum length of a language tag, as stored.
+const maxLanguageLength = 35
+
+var ErrInvalidLanguage Error = errors.New("invalid language")
+
+// ParseLanguage checks that tag is a BCP 47 language tag and returns it in its canonical form,
+// "EN-us" becomes "en-US". An empty tag is returned as it is, it means the language is unknown.
+func ParseLanguage(field, tag string) (string, error) {
+	if tag == "" {
+		return "", nil
+	}
+
+	t, err := language.Parse(tag)
+	if err != nil || len(tag) > maxLanguageLength || t == language.Und {
+		return "", validate.NewError(field, fmt.Sprintf("%q is not a BCP 47 language tag", tag), ErrInvalidLanguage)
+	}
+
+	return t.String(), nil
+}
+
+// NeedsTranslation reports whether text in the source language has to be translated to be read in the target language.
+// Variants of one language, such as "en-US" and "en-GB", are read without translation.
+// Text in an unknown language is not translated.
+func NeedsTranslation(source, target string) bool {
+	if source == "" || target == "" {
+		return false
+	}
+	return BaseLanguage(source) != BaseLanguage(target)
+}
+
+// BaseLanguage returns the base language of a tag, "pt" for "pt-BR".
+func BaseLanguage(tag string) string {
+	b, _ := language.Make(tag).Base()
+	return b.String()
+}
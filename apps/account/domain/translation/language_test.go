@@ -0,0 +1,57 @@
+package translation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+)
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		err  error
+	}{
+		{tag: "", want: ""},
+		{tag: "en", want: "en"},
+		{tag: "EN-us", want: "en-US"},
+		{tag: "pt-BR", want: "pt-BR"},
+		{tag: "english", err: translation.ErrInvalidLanguage},
+		{tag: "und", err: translation.ErrInvalidLanguage},
+		{tag: "en_US!", err: translation.ErrInvalidLanguage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := translation.ParseLanguage("preferred_language", tt.tag)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNeedsTranslation(t *testing.T) {
+	tests := []struct {
+		source, target string
+		want           bool
+	}{
+		{source: "en", target: "nl", want: true},
+		{source: "en", target: "en", want: false},
+		{source: "en-US", target: "en-GB", want: false},
+		{source: "pt-BR", target: "es", want: true},
+		{source: "", target: "nl", want: false},
+		{source: "en", target: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source+"->"+tt.target, func(t *testing.T) {
+			if got := translation.NeedsTranslation(tt.source, tt.target); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package translation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Term is a word or phrase of an organization that is translated in a fixed way, or not at all.
+type Term struct {
+	Term           string
+	Translation    string
+	DoNotTranslate bool
+}
+
+// Memory holds the translations an organization approved. It is consulted before the translator.
+type Memory interface {
+	// Match returns the approved translation of a text that is close enough to the text, if there is one.
+	Match(ctx context.Context, organizationID uuid.UUID, text, source, target string) (string, bool, error)
+	// Terms returns the terms of the organization that apply to translations into the target language.
+	Terms(ctx context.Context, organizationID uuid.UUID, target string) ([]Term, error)
+}
+
+// TermTranslator is a Translator that follows the terms of a glossary.
+// The terms are ignored when the translator of the Service does not implement it.
+type TermTranslator interface {
+	Translator
+	TranslateTerms(ctx context.Context, text, source, target string, terms []Term) (string, error)
+}
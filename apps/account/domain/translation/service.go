@@ -0,0 +1,274 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultQueueSize = 1024
+	defaultWorkers   = 4
+	defaultTimeout   = 10 * time.Second
+)
+
+// job is a message of an organization to translate into languages.
+type job struct {
+	organizationID uuid.UUID
+	message        *domain.Message
+	languages      []string
+}
+
+// Service translates messages and caches the translations per message and language.
+//
+// Translations are made in the background by Run for the messages that are enqueued. The queue is
+// in memory, a message that is dropped because the queue is full or the server stops is translated
+// when it is first read instead.
+type Service struct {
+	logger     *slog.Logger
+	clock      func() time.Time
+	translator Translator
+	memory     Memory
+	dbManager  storage.DBManager
+	workers    int
+	timeout    time.Duration
+	queue      chan job
+}
+
+type Config struct {
+	Logger     *slog.Logger
+	Clock      func() time.Time
+	Translator Translator
+	Memory     Memory // Memory is consulted before the translator, it is optional.
+	DBManager  storage.DBManager
+	QueueSize  int           // QueueSize is the number of messages waiting to be translated, defaults to 1024.
+	Workers    int           // Workers is the number of messages translated at once, defaults to 4.
+	Timeout    time.Duration // Timeout limits a single translation, defaults to 10 seconds.
+}
+
+func (c Config) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Clock == nil {
+		return errors.New("clock is required")
+	}
+
+	if c.Translator == nil {
+		return errors.New("translator is required")
+	}
+
+	if c.DBManager == nil {
+		return errors.New("db manager is required")
+	}
+
+	return nil
+}
+
+func NewService(c Config) (*Service, error) {
+	s := &Service{
+		logger:     c.Logger,
+		clock:      c.Clock,
+		translator: c.Translator,
+		memory:     c.Memory,
+		dbManager:  c.DBManager,
+		workers:    c.Workers,
+		timeout:    c.Timeout,
+	}
+
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultQueueSize
+	}
+	s.queue = make(chan job, c.QueueSize)
+
+	if s.workers <= 0 {
+		s.workers = defaultWorkers
+	}
+
+	if s.timeout <= 0 {
+		s.timeout = defaultTimeout
+	}
+
+	return s, c.Validate()
+}
+
+// Enqueue queues a message of an organization to be translated into the languages that differ from its own.
+// It never blocks.
+func (s *Service) Enqueue(organizationID uuid.UUID, m *domain.Message, languages []string) {
+	if m.Deleted() {
+		return
+	}
+
+	var targets []string
+	for _, l := range languages {
+		if NeedsTranslation(m.Language, l) && !slices.Contains(targets, l) {
+			targets = append(targets, l)
+		}
+	}
+
+	if len(targets) == 0 {
+		return
+	}
+
+	select {
+	case s.queue <- job{organizationID: organizationID, message: m, languages: targets}:
+	default:
+		s.logger.Warn("translation queue is full, the message is translated when it is read",
+			slog.String("message_id", m.ID.String()),
+		)
+	}
+}
+
+// Run translates the queued messages until the context is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-s.queue:
+					for _, l := range j.languages {
+						if _, err := s.translate(ctx, j.organizationID, j.message, l); err != nil {
+							s.logger.Warn("failed to translate message",
+								slog.String("message_id", j.message.ID.String()),
+								slog.String("language", l),
+								slog.String("error", err.Error()),
+							)
+						}
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// Translate sets the translation into the language of the messages of an organization that are written in
+// another language. Cached translations of the current version of a message are used, the others are translated now.
+// A message that can not be translated is left without a translation, so it is read in its own language.
+func (s *Service) Translate(ctx context.Context, organizationID uuid.UUID, messages []*domain.Message, language string) error {
+	var ids []uuid.UUID
+	for _, m := range messages {
+		if !m.Deleted() && NeedsTranslation(m.Language, language) {
+			ids = append(ids, m.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	translations, err := s.dbManager.Op().MessageTranslation.List(ctx, storage.Pagination{}, storage.MessageTranslationOrderBy{},
+		storage.MessageTranslationByMessageIDsCondition{MessageIDs: ids},
+		storage.MessageTranslationByLanguageCondition{Language: language},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list message translations: %w", err)
+	}
+
+	cached := make(map[uuid.UUID]*domain.MessageTranslation, len(translations))
+	for _, in := range translations {
+		var t domain.MessageTranslation
+		if err = t.FromStorage(in); err != nil {
+			return err
+		}
+		cached[t.MessageID] = &t
+	}
+
+	for _, m := range messages {
+		if !slices.Contains(ids, m.ID) {
+			continue
+		}
+
+		if t, ok := cached[m.ID]; ok && t.Current(m) {
+			m.Translation = t
+			continue
+		}
+
+		t, err := s.translate(ctx, organizationID, m, language)
+		if err != nil {
+			s.logger.WarnContext(ctx, "failed to translate message",
+				slog.String("message_id", m.ID.String()),
+				slog.String("language", language),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		m.Translation = t
+	}
+
+	return nil
+}
+
+// translate translates a message and caches the translation.
+func (s *Service) translate(ctx context.Context, organizationID uuid.UUID, m *domain.Message, language string) (*domain.MessageTranslation, error) {
+	tctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	body, err := s.translateText(tctx, organizationID, m.Body, m.Language, language)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &domain.MessageTranslation{
+		MessageID:         m.ID,
+		Language:          language,
+		Body:              body,
+		MessageUpdateTime: m.UpdateTime,
+		CreateTime:        s.clock(),
+	}
+
+	var in storage.MessageTranslation
+	if err = t.ToStorage(&in); err != nil {
+		return nil, err
+	}
+
+	if err = s.dbManager.Op().MessageTranslation.Upsert(ctx, &in); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// translateText translates a text with the approved translation from the memory of the organization,
+// or with the translator following the terms of the organization.
+func (s *Service) translateText(ctx context.Context, organizationID uuid.UUID, text, source, target string) (string, error) {
+	if s.memory == nil {
+		return s.translator.Translate(ctx, text, source, target)
+	}
+
+	approved, ok, err := s.memory.Match(ctx, organizationID, text, source, target)
+	if err != nil {
+		return "", fmt.Errorf("failed to search the translation memory: %w", err)
+	}
+	if ok {
+		return approved, nil
+	}
+
+	t, ok := s.translator.(TermTranslator)
+	if !ok {
+		return s.translator.Translate(ctx, text, source, target)
+	}
+
+	terms, err := s.memory.Terms(ctx, organizationID, target)
+	if err != nil {
+		return "", fmt.Errorf("failed to list the glossary terms: %w", err)
+	}
+
+	return t.TranslateTerms(ctx, text, source, target, terms)
+}
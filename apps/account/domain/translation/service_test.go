@@ -0,0 +1,253 @@
+package translation_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/translation"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+
+	managerMock "github.com/extreme-business/lingo/apps/account/storage/mock/manager"
+	translationMock "github.com/extreme-business/lingo/apps/account/storage/mock/messagetranslation"
+)
+
+var (
+	sendTime       = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	organizationID = uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+)
+
+// translatorFunc adapts a function to a Translator.
+type translatorFunc func(ctx context.Context, text, source, target string) (string, error)
+
+func (f translatorFunc) Translate(ctx context.Context, text, source, target string) (string, error) {
+	return f(ctx, text, source, target)
+}
+
+// newCache returns a mock repository that keeps the translations in a map.
+func newCache() (*translationMock.Repository, chan *storage.MessageTranslation) {
+	var mu sync.Mutex
+	cache := map[[2]string]*storage.MessageTranslation{}
+	upserts := make(chan *storage.MessageTranslation, 16)
+	return &translationMock.Repository{
+		UpsertFunc: func(_ context.Context, t *storage.MessageTranslation) error {
+			mu.Lock()
+			defer mu.Unlock()
+			cache[[2]string{t.MessageID.String(), t.Language}] = t
+			upserts <- t
+			return nil
+		},
+		ListFunc: func(_ context.Context, _ storage.Pagination, _ storage.MessageTranslationOrderBy, c ...storage.Condition) ([]*storage.MessageTranslation, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ids := c[0].(storage.MessageTranslationByMessageIDsCondition).MessageIDs
+			language := c[1].(storage.MessageTranslationByLanguageCondition).Language
+			var out []*storage.MessageTranslation
+			for _, t := range cache {
+				if slices.Contains(ids, t.MessageID) && t.Language == language {
+					out = append(out, t)
+				}
+			}
+			return out, nil
+		},
+	}, upserts
+}
+
+// memory is a Memory with a single approved translation and the terms of an organization.
+type memory struct {
+	text, translation string
+	terms             []translation.Term
+}
+
+func (m memory) Match(_ context.Context, id uuid.UUID, text, _, _ string) (string, bool, error) {
+	if id != organizationID || text != m.text {
+		return "", false, nil
+	}
+	return m.translation, true, nil
+}
+
+func (m memory) Terms(_ context.Context, id uuid.UUID, _ string) ([]translation.Term, error) {
+	if id != organizationID {
+		return nil, nil
+	}
+	return m.terms, nil
+}
+
+func newService(t *testing.T, translator translation.Translator, repo storage.MessageTranslationRepository) *translation.Service {
+	t.Helper()
+	return newServiceWithMemory(t, translator, nil, repo)
+}
+
+func newServiceWithMemory(t *testing.T, translator translation.Translator, m translation.Memory, repo storage.MessageTranslationRepository) *translation.Service {
+	t.Helper()
+
+	s, err := translation.NewService(translation.Config{
+		Logger:     slog.Default(),
+		Clock:      func() time.Time { return sendTime },
+		Translator: translator,
+		Memory:     m,
+		DBManager:  managerMock.New(storage.Repositories{MessageTranslation: repo}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func newMessage(body, language string) *domain.Message {
+	return &domain.Message{ID: uuid.New(), Body: body, Language: language, CreateTime: sendTime, UpdateTime: sendTime}
+}
+
+func TestService_Translate(t *testing.T) {
+	ctx := context.Background()
+	dictionary := translation.NewDictionary(translation.Entry{Source: "en", Target: "nl", Word: "hello", Translation: "hallo"})
+
+	t.Run("should translate the messages in another language once", func(t *testing.T) {
+		var calls int
+		repo, _ := newCache()
+		s := newService(t, translatorFunc(func(ctx context.Context, text, source, target string) (string, error) {
+			calls++
+			return dictionary.Translate(ctx, text, source, target)
+		}), repo)
+
+		english, dutch := newMessage("hello", "en"), newMessage("hallo", "nl")
+		deleted := newMessage("", "en")
+		deleted.DeleteTime = sendTime
+
+		for range 2 {
+			english.Translation = nil
+			if err := s.Translate(ctx, organizationID, []*domain.Message{english, dutch, deleted}, "nl"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if english.Translation == nil || english.Translation.Body != "[nl] hallo" {
+			t.Errorf("expected the english message to be translated, got %+v", english.Translation)
+		}
+
+		if dutch.Translation != nil || deleted.Translation != nil {
+			t.Error("expected the dutch and deleted messages not to be translated")
+		}
+
+		if calls != 1 {
+			t.Errorf("expected the translation to be cached, got %d calls", calls)
+		}
+	})
+
+	t.Run("should translate an edited message again", func(t *testing.T) {
+		repo, _ := newCache()
+		s := newService(t, dictionary, repo)
+
+		m := newMessage("hello", "en")
+		if err := s.Translate(ctx, organizationID, []*domain.Message{m}, "nl"); err != nil {
+			t.Fatal(err)
+		}
+
+		m.Body = "hello hello"
+		m.UpdateTime = sendTime.Add(time.Minute)
+		m.Translation = nil
+		if err := s.Translate(ctx, organizationID, []*domain.Message{m}, "nl"); err != nil {
+			t.Fatal(err)
+		}
+
+		if m.Translation == nil || m.Translation.Body != "[nl] hallo hallo" {
+			t.Errorf("expected the edit to be translated, got %+v", m.Translation)
+		}
+	})
+
+	t.Run("should leave a message untranslated when the translator fails", func(t *testing.T) {
+		repo, _ := newCache()
+		s := newService(t, translatorFunc(func(context.Context, string, string, string) (string, error) {
+			return "", errors.New("unavailable")
+		}), repo)
+
+		m := newMessage("hello", "en")
+		if err := s.Translate(ctx, organizationID, []*domain.Message{m}, "nl"); err != nil {
+			t.Fatal(err)
+		}
+
+		if m.Translation != nil {
+			t.Errorf("expected no translation, got %+v", m.Translation)
+		}
+	})
+}
+
+func TestService_Translate_memory(t *testing.T) {
+	ctx := context.Background()
+	dictionary := translation.NewDictionary(translation.Entry{Source: "en", Target: "nl", Word: "hello", Translation: "hallo"})
+	m := memory{
+		text:        "hello team",
+		translation: "hallo allemaal",
+		terms:       []translation.Term{{Term: "Lingo", DoNotTranslate: true}, {Term: "team", Translation: "ploeg"}},
+	}
+
+	t.Run("should use the approved translation", func(t *testing.T) {
+		repo, _ := newCache()
+		s := newServiceWithMemory(t, dictionary, m, repo)
+
+		msg := newMessage("hello team", "en")
+		if err := s.Translate(ctx, organizationID, []*domain.Message{msg}, "nl"); err != nil {
+			t.Fatal(err)
+		}
+
+		if msg.Translation == nil || msg.Translation.Body != "hallo allemaal" {
+			t.Errorf("expected the approved translation, got %+v", msg.Translation)
+		}
+	})
+
+	t.Run("should follow the terms of the glossary", func(t *testing.T) {
+		repo, _ := newCache()
+		s := newServiceWithMemory(t, dictionary, m, repo)
+
+		msg := newMessage("hello Lingo team", "en")
+		if err := s.Translate(ctx, organizationID, []*domain.Message{msg}, "nl"); err != nil {
+			t.Fatal(err)
+		}
+
+		if msg.Translation == nil || msg.Translation.Body != "[nl] hallo Lingo ploeg" {
+			t.Errorf("expected the terms to be followed, got %+v", msg.Translation)
+		}
+	})
+}
+
+func TestService_Run(t *testing.T) {
+	repo, upserts := newCache()
+	s := newService(t, translation.NewDictionary(), repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	m := newMessage("hello", "en")
+	s.Enqueue(organizationID, m, []string{"nl", "en-GB", "nl", "de", ""})
+
+	var languages []string
+	for range 2 {
+		select {
+		case u := <-upserts:
+			if u.MessageID != m.ID || !u.MessageUpdateTime.Equal(m.UpdateTime) {
+				t.Errorf("expected a translation of the message, got %+v", u)
+			}
+			languages = append(languages, u.Language)
+		case <-time.After(time.Second):
+			t.Fatal("expected the message to be translated")
+		}
+	}
+
+	slices.Sort(languages)
+	if !slices.Equal(languages, []string{"de", "nl"}) {
+		t.Errorf("expected translations into de and nl, got %v", languages)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("expected Run to stop without an error, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+// Package translation translates the messages of conversations into the languages of their readers.
+//
+// Messages are stored in the language they were written in. Translations are made in the background
+// for the preferred languages of the participants when a message is sent or edited, and cached per
+// message and language. A translation that is missing when a message is read is made on the spot.
+// An approved translation from the memory of the organization is preferred over the translator,
+// which follows the terms of the organization when it can.
+package translation
+
+import "context"
+
+// Translator translates text from one language into another. Languages are BCP 47 tags such as "en" or "pt-BR".
+type Translator interface {
+	Translate(ctx context.Context, text, source, target string) (string, error)
+}
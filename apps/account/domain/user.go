@@ -23,6 +23,17 @@ const (
 	UserStatusInactive UserStatus = "inactive"
 )
 
+// UserRole is the role of a user. It determines what the user is allowed to do.
+type UserRole string
+
+func (r UserRole) String() string { return string(r) }
+
+const (
+	UserRoleUser   UserRole = "user"   // UserRoleUser can only manage itself.
+	UserRoleAdmin  UserRole = "admin"  // UserRoleAdmin can manage the users of its organization.
+	UserRoleSystem UserRole = "system" // UserRoleSystem can manage all users.
+)
+
 // User is a user who uses or operates the system.
 type User struct {
 	ID             uuid.UUID
@@ -31,10 +42,18 @@ type User struct {
 	Email          string
 	HashedPassword string
 	Status         UserStatus
-	CreateTime     time.Time
-	UpdateTime     time.Time
-	DeleteTime     time.Time
-	Organization   *Organization // Organization is the primary organization the user belongs to.
+	Role           UserRole
+	// PreferredLanguage is the language the user reads and writes, a BCP 47 tag. Empty when unknown.
+	PreferredLanguage string
+	CreateTime        time.Time
+	UpdateTime        time.Time
+	DeleteTime        time.Time
+	Organization      *Organization // Organization is the primary organization the user belongs to.
+}
+
+// UserName returns the resource name of a user.
+func UserName(organizationID, userID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s/%s", OrganizationCollection, organizationID, UserCollection, userID)
 }
 
 func (u *User) FromProto(in *protoaccount.User) error {
@@ -69,6 +88,7 @@ func (u *User) FromProto(in *protoaccount.User) error {
 	u.OrganizationID = organizationUUID
 	u.DisplayName = in.GetDisplayName()
 	u.Email = in.GetEmail()
+	u.PreferredLanguage = in.GetPreferredLanguage()
 
 	if in.GetCreateTime() != nil {
 		u.CreateTime = in.GetCreateTime().AsTime()
@@ -84,9 +104,10 @@ func (u *User) FromProto(in *protoaccount.User) error {
 }
 
 func (u *User) ToProto(in *protoaccount.User) error {
-	in.Name = fmt.Sprintf("organizations/%s/users/%s", u.OrganizationID.String(), u.ID.String())
+	in.Name = UserName(u.OrganizationID, u.ID)
 	in.DisplayName = u.DisplayName
 	in.Email = u.Email
+	in.PreferredLanguage = u.PreferredLanguage
 	in.CreateTime = timestamppb.New(u.CreateTime)
 	in.UpdateTime = timestamppb.New(u.UpdateTime)
 	return nil
@@ -108,6 +129,10 @@ func (u *User) ToStorage(out *storage.User) error {
 			out.HashedPassword = u.HashedPassword
 		case storage.UserStatus:
 			out.Status = string(u.Status)
+		case storage.UserRole:
+			out.Role = string(u.Role)
+		case storage.UserPreferredLanguage:
+			out.PreferredLanguage = u.PreferredLanguage
 		case storage.UserCreateTime:
 			out.CreateTime = u.CreateTime
 		case storage.UserUpdateTime:
@@ -139,6 +164,10 @@ func (u *User) FromStorage(in *storage.User) error {
 			u.HashedPassword = in.HashedPassword
 		case storage.UserStatus:
 			u.Status = UserStatus(in.Status)
+		case storage.UserRole:
+			u.Role = UserRole(in.Role)
+		case storage.UserPreferredLanguage:
+			u.PreferredLanguage = in.PreferredLanguage
 		case storage.UserCreateTime:
 			u.CreateTime = in.CreateTime
 		case storage.UserUpdateTime:
@@ -72,3 +72,52 @@ func (r *Reader) List(ctx context.Context, page uint) ([]*domain.User, Error) {
 
 	return out, nil
 }
+
+// ListByOrganization lists the users of an organization, oldest first.
+// page starts from 0.
+func (r *Reader) ListByOrganization(ctx context.Context, organizationID uuid.UUID, page uint) ([]*domain.User, Error) {
+	users, err := r.reader.List(ctx, storage.Pagination{
+		Limit:  perPage,
+		Offset: int(page) * perPage,
+	}, storage.UserOrderBy{
+		{Field: storage.UserCreateTime, Direction: storage.ASC},
+		{Field: storage.UserID, Direction: storage.ASC},
+	}, storage.UserByOrganizationIDCondition{OrganizationID: organizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		var u domain.User
+		if err = u.FromStorage(user); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &u)
+	}
+
+	return out, nil
+}
+
+// ListByIDs lists the users with the ids. Unknown ids are left out.
+func (r *Reader) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.User, Error) {
+	users, err := r.reader.List(ctx, storage.Pagination{}, storage.UserOrderBy{},
+		storage.UserByIDsCondition{IDs: ids},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		var u domain.User
+		if err = u.FromStorage(user); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &u)
+	}
+
+	return out, nil
+}
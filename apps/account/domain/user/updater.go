@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+// Updater updates users in their own transaction, together with the matching domain events.
+type Updater struct {
+	c         func() time.Time // c is the clock function.
+	dbManager storage.DBManager
+}
+
+func NewUpdater(c func() time.Time, dbManager storage.DBManager) *Updater {
+	return &Updater{
+		c:         c,
+		dbManager: dbManager,
+	}
+}
+
+// Update updates the fields of the user.
+func (u *Updater) Update(ctx context.Context, in *domain.User, fields []storage.UserField) (*domain.User, Error) {
+	var result *domain.User
+	if err := u.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		var err error
+		result, err = NewWriter(u.c, r.User, outbox.NewWriter(u.c, r.OutboxEvent, r.Jobs)).Update(ctx, in, fields)
+		return err
+	}); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -6,19 +6,23 @@ import (
 	"time"
 
 	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
 	"github.com/extreme-business/lingo/apps/account/storage"
-	"github.com/google/uuid"
 )
 
+// Writer writes users and adds the matching domain events to the outbox.
+// The user writer and the outbox writer must use the same transaction.
 type Writer struct {
-	c  func() time.Time // c is the clock function.
-	uw storage.UserWriter
+	c      func() time.Time // c is the clock function.
+	uw     storage.UserWriter
+	events *outbox.Writer
 }
 
-func NewWriter(c func() time.Time, w storage.UserWriter) *Writer {
+func NewWriter(c func() time.Time, w storage.UserWriter, events *outbox.Writer) *Writer {
 	return &Writer{
-		c:  c,
-		uw: w,
+		c:      c,
+		uw:     w,
+		events: events,
 	}
 }
 
@@ -38,6 +42,9 @@ func (w *Writer) Create(ctx context.Context, u *domain.User) (*domain.User, Erro
 	if err = result.FromStorage(s); err != nil {
 		return nil, err
 	}
+	if err = w.events.Add(ctx, domain.UserCreated{User: domain.NewUserState(result)}); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -53,7 +60,8 @@ func (w *Writer) Update(ctx context.Context, u *domain.User, fields []storage.Us
 	}
 	fields = append(fields, storage.UserUpdateTime)
 	slices.Sort(fields)
-	s, err = w.uw.Update(ctx, s, slices.Compact(fields))
+	fields = slices.Compact(fields)
+	s, err = w.uw.Update(ctx, s, fields)
 	if err != nil {
 		return nil, err
 	}
@@ -61,9 +69,27 @@ func (w *Writer) Update(ctx context.Context, u *domain.User, fields []storage.Us
 	if err = result.FromStorage(s); err != nil {
 		return nil, err
 	}
+	if err = w.events.Add(ctx, domain.UserUpdated{User: domain.NewUserState(result), Fields: changedFields(fields)}); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
-func (w *Writer) Delete(ctx context.Context, id uuid.UUID) Error {
-	return w.uw.Delete(ctx, id)
+func (w *Writer) Delete(ctx context.Context, u *domain.User) Error {
+	if err := w.uw.Delete(ctx, u.ID); err != nil {
+		return err
+	}
+	return w.events.Add(ctx, domain.UserDeleted{User: domain.NewUserState(u)})
+}
+
+// changedFields returns the names of the changed fields for an event.
+// The update time is left out, it changes with every update.
+func changedFields(fields []storage.UserField) []string {
+	changed := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != storage.UserUpdateTime {
+			changed = append(changed, string(f))
+		}
+	}
+	return changed
 }
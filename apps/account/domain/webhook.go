@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WebhookCollection is the name of the webhook collection.
+const WebhookCollection = "webhooks"
+
+// WebhookDeliveryCollection is the name of the webhook delivery collection.
+const WebhookDeliveryCollection = "deliveries"
+
+// Webhook is a subscription of an organization to events, which are posted to its URL.
+type Webhook struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	URL            string
+	EventTypes     []EventType
+	Secret         string // Secret is the key the deliveries are signed with.
+	FailureCount   int    // FailureCount is the number of failed delivery attempts since the last success.
+	CreateTime     time.Time
+	UpdateTime     time.Time
+	DisableTime    time.Time
+}
+
+// WebhookName returns the resource name of a webhook.
+func WebhookName(organizationID, webhookID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", OrganizationName(organizationID), WebhookCollection, webhookID)
+}
+
+// Disabled reports whether deliveries to the webhook are stopped.
+func (w *Webhook) Disabled() bool {
+	return !w.DisableTime.IsZero()
+}
+
+// Subscribed reports whether the webhook wants events of type t.
+func (w *Webhook) Subscribed(t EventType) bool {
+	return slices.Contains(w.EventTypes, t)
+}
+
+// ToProto maps the webhook to its proto representation. The secret is left out,
+// it is only handed out when the webhook is created.
+func (w *Webhook) ToProto(in *protoaccount.Webhook) error {
+	in.Name = WebhookName(w.OrganizationID, w.ID)
+	in.Url = w.URL
+	in.EventTypes = make([]string, 0, len(w.EventTypes))
+	for _, t := range w.EventTypes {
+		in.EventTypes = append(in.EventTypes, t.String())
+	}
+	in.Disabled = w.Disabled()
+	in.FailureCount = int32(w.FailureCount)
+	in.CreateTime = timestamppb.New(w.CreateTime)
+	in.UpdateTime = timestamppb.New(w.UpdateTime)
+	if w.Disabled() {
+		in.DisableTime = timestamppb.New(w.DisableTime)
+	}
+	return nil
+}
+
+// ToStorage maps a Webhook to a storage.Webhook.
+func (w *Webhook) ToStorage(out *storage.Webhook) error {
+	for _, field := range storage.WebhookFields() {
+		switch field {
+		case storage.WebhookID:
+			out.ID = w.ID
+		case storage.WebhookOrganizationID:
+			out.OrganizationID = w.OrganizationID
+		case storage.WebhookURL:
+			out.URL = w.URL
+		case storage.WebhookEventTypes:
+			out.EventTypes = make([]string, 0, len(w.EventTypes))
+			for _, t := range w.EventTypes {
+				out.EventTypes = append(out.EventTypes, t.String())
+			}
+		case storage.WebhookSecret:
+			out.Secret = w.Secret
+		case storage.WebhookFailureCount:
+			out.FailureCount = w.FailureCount
+		case storage.WebhookCreateTime:
+			out.CreateTime = w.CreateTime
+		case storage.WebhookUpdateTime:
+			out.UpdateTime = w.UpdateTime
+		case storage.WebhookDisableTime:
+			out.DisableTime.Time = w.DisableTime
+			out.DisableTime.Valid = !w.DisableTime.IsZero()
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.Webhook to a Webhook.
+func (w *Webhook) FromStorage(in *storage.Webhook) error {
+	for _, field := range storage.WebhookFields() {
+		switch field {
+		case storage.WebhookID:
+			w.ID = in.ID
+		case storage.WebhookOrganizationID:
+			w.OrganizationID = in.OrganizationID
+		case storage.WebhookURL:
+			w.URL = in.URL
+		case storage.WebhookEventTypes:
+			w.EventTypes = make([]EventType, 0, len(in.EventTypes))
+			for _, t := range in.EventTypes {
+				w.EventTypes = append(w.EventTypes, EventType(t))
+			}
+		case storage.WebhookSecret:
+			w.Secret = in.Secret
+		case storage.WebhookFailureCount:
+			w.FailureCount = in.FailureCount
+		case storage.WebhookCreateTime:
+			w.CreateTime = in.CreateTime
+		case storage.WebhookUpdateTime:
+			w.UpdateTime = in.UpdateTime
+		case storage.WebhookDisableTime:
+			w.DisableTime = in.DisableTime.Time
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// WebhookDeliveryStatus is the outcome of a delivery attempt.
+type WebhookDeliveryStatus string
+
+func (s WebhookDeliveryStatus) String() string { return string(s) }
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a single attempt to post an event to a webhook.
+type WebhookDelivery struct {
+	ID           uuid.UUID
+	WebhookID    uuid.UUID
+	EventID      int64
+	EventType    EventType
+	Payload      []byte // Payload is the request body, it is the same for every attempt.
+	Attempt      int
+	Status       WebhookDeliveryStatus
+	ScheduleTime time.Time
+	AttemptTime  time.Time
+	ResponseCode int
+	ErrorMessage string
+	CreateTime   time.Time
+}
+
+// WebhookDeliveryName returns the resource name of a webhook delivery.
+func WebhookDeliveryName(organizationID, webhookID, deliveryID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", WebhookName(organizationID, webhookID), WebhookDeliveryCollection, deliveryID)
+}
+
+// ToProto maps the delivery to its proto representation.
+// The organization is needed to build the resource name.
+func (d *WebhookDelivery) ToProto(organizationID uuid.UUID, in *protoaccount.WebhookDelivery) error {
+	in.Name = WebhookDeliveryName(organizationID, d.WebhookID, d.ID)
+	in.EventId = d.EventID
+	in.EventType = d.EventType.String()
+	in.Attempt = int32(d.Attempt)
+	in.Status = d.Status.String()
+	in.ResponseCode = int32(d.ResponseCode)
+	in.ErrorMessage = d.ErrorMessage
+	in.ScheduleTime = timestamppb.New(d.ScheduleTime)
+	if !d.AttemptTime.IsZero() {
+		in.AttemptTime = timestamppb.New(d.AttemptTime)
+	}
+	in.CreateTime = timestamppb.New(d.CreateTime)
+	return nil
+}
+
+// ToStorage maps a WebhookDelivery to a storage.WebhookDelivery.
+func (d *WebhookDelivery) ToStorage(out *storage.WebhookDelivery) error {
+	for _, field := range storage.WebhookDeliveryFields() {
+		switch field {
+		case storage.WebhookDeliveryID:
+			out.ID = d.ID
+		case storage.WebhookDeliveryWebhookID:
+			out.WebhookID = d.WebhookID
+		case storage.WebhookDeliveryEventID:
+			out.EventID = d.EventID
+		case storage.WebhookDeliveryEventType:
+			out.EventType = d.EventType.String()
+		case storage.WebhookDeliveryPayload:
+			out.Payload = d.Payload
+		case storage.WebhookDeliveryAttempt:
+			out.Attempt = d.Attempt
+		case storage.WebhookDeliveryStatus:
+			out.Status = d.Status.String()
+		case storage.WebhookDeliveryScheduleTime:
+			out.ScheduleTime = d.ScheduleTime
+		case storage.WebhookDeliveryAttemptTime:
+			out.AttemptTime.Time = d.AttemptTime
+			out.AttemptTime.Valid = !d.AttemptTime.IsZero()
+		case storage.WebhookDeliveryResponseCode:
+			out.ResponseCode = d.ResponseCode
+		case storage.WebhookDeliveryErrorMessage:
+			out.ErrorMessage = d.ErrorMessage
+		case storage.WebhookDeliveryCreateTime:
+			out.CreateTime = d.CreateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// FromStorage maps a storage.WebhookDelivery to a WebhookDelivery.
+func (d *WebhookDelivery) FromStorage(in *storage.WebhookDelivery) error {
+	for _, field := range storage.WebhookDeliveryFields() {
+		switch field {
+		case storage.WebhookDeliveryID:
+			d.ID = in.ID
+		case storage.WebhookDeliveryWebhookID:
+			d.WebhookID = in.WebhookID
+		case storage.WebhookDeliveryEventID:
+			d.EventID = in.EventID
+		case storage.WebhookDeliveryEventType:
+			d.EventType = EventType(in.EventType)
+		case storage.WebhookDeliveryPayload:
+			d.Payload = in.Payload
+		case storage.WebhookDeliveryAttempt:
+			d.Attempt = in.Attempt
+		case storage.WebhookDeliveryStatus:
+			d.Status = WebhookDeliveryStatus(in.Status)
+		case storage.WebhookDeliveryScheduleTime:
+			d.ScheduleTime = in.ScheduleTime
+		case storage.WebhookDeliveryAttemptTime:
+			d.AttemptTime = in.AttemptTime.Time
+		case storage.WebhookDeliveryResponseCode:
+			d.ResponseCode = in.ResponseCode
+		case storage.WebhookDeliveryErrorMessage:
+			d.ErrorMessage = in.ErrorMessage
+		case storage.WebhookDeliveryCreateTime:
+			d.CreateTime = in.CreateTime
+		default:
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return nil
+}
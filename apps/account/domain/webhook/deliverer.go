@@ -0,0 +1,319 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBatchSize    = 20
+	defaultInterval     = 5 * time.Second
+	defaultMaxAttempts  = 8
+	defaultDisableAfter = 20
+	defaultTimeout      = 10 * time.Second
+
+	// BackoffBase is the delay before the second attempt, it doubles with every attempt after that.
+	BackoffBase = 30 * time.Second
+	// BackoffMax is the longest delay between two attempts.
+	BackoffMax = time.Hour
+
+	// maxErrorBody is how much of a failed response is kept in the error message.
+	maxErrorBody = 256
+)
+
+// Backoff returns how long to wait after a failed attempt before the next one.
+func Backoff(attempt int) time.Duration {
+	d := BackoffBase
+	for i := 1; i < attempt && d < BackoffMax; i++ {
+		d *= 2
+	}
+	return min(d, BackoffMax)
+}
+
+// Deliverer sends the pending deliveries to their webhooks.
+//
+// A failed attempt is retried with exponential backoff until MaxAttempts is reached.
+// Every failed attempt counts towards the failures of the webhook, a successful one resets
+// them; a webhook with DisableAfter failures in a row is disabled. Only one deliverer sends at a time.
+type Deliverer struct {
+	logger       *slog.Logger
+	clock        func() time.Time
+	genUUID      uuidgen.Generator
+	dbManager    storage.DBManager
+	client       *http.Client
+	batchSize    int
+	interval     time.Duration
+	maxAttempts  int
+	disableAfter int
+}
+
+type DelivererConfig struct {
+	Logger       *slog.Logger
+	Clock        func() time.Time
+	GenUUID      uuidgen.Generator
+	DBManager    storage.DBManager
+	Client       *http.Client  // Client sends the requests, by default redirects are not followed and requests time out after 10 seconds.
+	BatchSize    int           // BatchSize is the maximum number of deliveries sent per run, defaults to 20.
+	Interval     time.Duration // Interval is the time between runs, defaults to 5 seconds.
+	MaxAttempts  int           // MaxAttempts is how often an event is attempted before giving up, defaults to 8.
+	DisableAfter int           // DisableAfter is the number of failed attempts in a row after which a webhook is disabled, defaults to 20.
+}
+
+func (c DelivererConfig) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Clock == nil {
+		return errors.New("clock is required")
+	}
+
+	if c.GenUUID == nil {
+		return errors.New("uuid generator is required")
+	}
+
+	if c.DBManager == nil {
+		return errors.New("db manager is required")
+	}
+
+	return nil
+}
+
+func NewDeliverer(c DelivererConfig) (*Deliverer, error) {
+	d := &Deliverer{
+		logger:       c.Logger,
+		clock:        c.Clock,
+		genUUID:      c.GenUUID,
+		dbManager:    c.DBManager,
+		client:       c.Client,
+		batchSize:    c.BatchSize,
+		interval:     c.Interval,
+		maxAttempts:  c.MaxAttempts,
+		disableAfter: c.DisableAfter,
+	}
+
+	if d.client == nil {
+		d.client = &http.Client{
+			Timeout: defaultTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+
+	if d.batchSize <= 0 {
+		d.batchSize = defaultBatchSize
+	}
+
+	if d.interval <= 0 {
+		d.interval = defaultInterval
+	}
+
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = defaultMaxAttempts
+	}
+
+	if d.disableAfter <= 0 {
+		d.disableAfter = defaultDisableAfter
+	}
+
+	return d, c.Validate()
+}
+
+// Run sends deliveries every interval until the context is canceled.
+func (d *Deliverer) Run(ctx context.Context) error {
+	t := time.NewTicker(d.interval)
+	defer t.Stop()
+
+	for {
+		n, err := d.Deliver(ctx)
+		if err != nil {
+			d.logger.Error("failed to deliver webhooks", slog.String("error", err.Error()))
+		}
+
+		// keep going without waiting while there is a backlog.
+		if err == nil && n == d.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// Deliver sends a batch of due deliveries and returns how many were attempted.
+func (d *Deliverer) Deliver(ctx context.Context) (int, error) {
+	var n int
+	err := d.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		if err := r.WebhookDelivery.Lock(ctx); err != nil {
+			return err
+		}
+
+		due, err := r.WebhookDelivery.List(ctx, storage.Pagination{Limit: d.batchSize}, storage.WebhookDeliveryOrderBy{
+			{Field: storage.WebhookDeliveryScheduleTime, Direction: storage.ASC},
+		},
+			storage.WebhookDeliveryByStatusCondition{Status: domain.WebhookDeliveryStatusPending.String()},
+			storage.WebhookDeliveryDueCondition{Time: d.clock()},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to list due webhook deliveries: %w", err)
+		}
+
+		reader := NewReader(r.Webhook, r.WebhookDelivery)
+		deliveries := NewDeliveryWriter(d.clock, d.genUUID, r.WebhookDelivery)
+		webhooks := map[uuid.UUID]*domain.Webhook{} // webhooks of the batch, failures are counted across it
+		for _, in := range due {
+			var delivery domain.WebhookDelivery
+			if err = delivery.FromStorage(in); err != nil {
+				return err
+			}
+
+			w, ok := webhooks[delivery.WebhookID]
+			if !ok {
+				if w, err = reader.Get(ctx, delivery.WebhookID); err != nil {
+					return fmt.Errorf("failed to get webhook %s: %w", delivery.WebhookID, err)
+				}
+				webhooks[w.ID] = w
+			}
+
+			if err = d.attempt(ctx, r, deliveries, w, &delivery); err != nil {
+				return err
+			}
+			n++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// attempt sends a delivery and records the outcome for the delivery and the webhook.
+func (d *Deliverer) attempt(ctx context.Context, r storage.Repositories, deliveries *DeliveryWriter, w *domain.Webhook, delivery *domain.WebhookDelivery) error {
+	fields := []storage.WebhookDeliveryField{
+		storage.WebhookDeliveryStatus,
+		storage.WebhookDeliveryAttemptTime,
+		storage.WebhookDeliveryResponseCode,
+		storage.WebhookDeliveryErrorMessage,
+	}
+
+	if w.Disabled() {
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+		delivery.ErrorMessage = "webhook is disabled"
+		_, err := deliveries.Update(ctx, delivery, fields)
+		return err
+	}
+
+	now := d.clock()
+	code, sendErr := d.send(ctx, w, delivery, now)
+	delivery.AttemptTime = now
+	delivery.ResponseCode = code
+	delivery.Status = domain.WebhookDeliveryStatusSucceeded
+	if sendErr != nil {
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+		delivery.ErrorMessage = sendErr.Error()
+	}
+
+	if _, err := deliveries.Update(ctx, delivery, fields); err != nil {
+		return fmt.Errorf("failed to update webhook delivery %s: %w", delivery.ID, err)
+	}
+
+	if sendErr == nil {
+		if w.FailureCount == 0 {
+			return nil
+		}
+		w.FailureCount = 0
+		return d.updateWebhook(ctx, r, w)
+	}
+
+	w.FailureCount++
+	if w.FailureCount >= d.disableAfter {
+		w.DisableTime = now
+		d.logger.Warn("webhook disabled after repeated failures",
+			slog.String("webhook", w.ID.String()),
+			slog.Int("failures", w.FailureCount),
+		)
+	}
+
+	if err := d.updateWebhook(ctx, r, w); err != nil {
+		return err
+	}
+
+	if w.Disabled() || delivery.Attempt >= d.maxAttempts {
+		return nil
+	}
+
+	if _, err := deliveries.Retry(ctx, delivery, now.Add(Backoff(delivery.Attempt))); err != nil {
+		return fmt.Errorf("failed to schedule retry of webhook delivery %s: %w", delivery.ID, err)
+	}
+
+	return nil
+}
+
+// updateWebhook stores the failures of a webhook, without touching its update time.
+func (d *Deliverer) updateWebhook(ctx context.Context, r storage.Repositories, w *domain.Webhook) error {
+	in := &storage.Webhook{}
+	if err := w.ToStorage(in); err != nil {
+		return err
+	}
+
+	if _, err := r.Webhook.Update(ctx, in, []storage.WebhookField{storage.WebhookFailureCount, storage.WebhookDisableTime}); err != nil {
+		return fmt.Errorf("failed to update webhook %s: %w", w.ID, err)
+	}
+
+	return nil
+}
+
+// send posts the delivery to the webhook and returns the response code.
+// Any response other than 2xx is an error.
+func (d *Deliverer) send(ctx context.Context, w *domain.Webhook, delivery *domain.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Lingo-Webhook/1.0")
+	req.Header.Set(HeaderEventID, strconv.FormatInt(delivery.EventID, 10))
+	req.Header.Set(HeaderEventType, delivery.EventType.String())
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(w.Secret, now, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		if b := strings.TrimSpace(string(body)); b != "" {
+			msg += ": " + b
+		}
+		return resp.StatusCode, errors.New(msg)
+	}
+
+	return resp.StatusCode, nil
+}
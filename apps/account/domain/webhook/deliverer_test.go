@@ -0,0 +1,443 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+
+	managerMock "github.com/extreme-business/lingo/apps/account/storage/mock/manager"
+	webhookMock "github.com/extreme-business/lingo/apps/account/storage/mock/webhook"
+	deliveryMock "github.com/extreme-business/lingo/apps/account/storage/mock/webhookdelivery"
+)
+
+var (
+	orgID  = uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	userID = uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770")
+)
+
+// clock is a clock that only moves when told to.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time          { return c.now }
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// store keeps webhooks and deliveries in memory.
+type store struct {
+	webhooks   map[uuid.UUID]*storage.Webhook
+	deliveries []*storage.WebhookDelivery
+}
+
+func newStore(webhooks ...*storage.Webhook) *store {
+	s := &store{webhooks: map[uuid.UUID]*storage.Webhook{}}
+	for _, w := range webhooks {
+		s.webhooks[w.ID] = w
+	}
+	return s
+}
+
+func (s *store) manager() storage.DBManager {
+	return managerMock.New(storage.Repositories{
+		Webhook: &webhookMock.Repository{
+			GetFunc: func(_ context.Context, id uuid.UUID) (*storage.Webhook, error) {
+				w, ok := s.webhooks[id]
+				if !ok {
+					return nil, storage.ErrWebhookNotFound
+				}
+				c := *w
+				return &c, nil
+			},
+			UpdateFunc: func(_ context.Context, w *storage.Webhook, _ []storage.WebhookField) (*storage.Webhook, error) {
+				c := *w
+				s.webhooks[w.ID] = &c
+				return w, nil
+			},
+			ListFunc: func(_ context.Context, _ storage.Pagination, _ storage.WebhookOrderBy, conditions ...storage.Condition) ([]*storage.Webhook, error) {
+				var out []*storage.Webhook
+				for _, w := range s.webhooks {
+					if matchWebhook(w, conditions) {
+						c := *w
+						out = append(out, &c)
+					}
+				}
+				return out, nil
+			},
+		},
+		WebhookDelivery: &deliveryMock.Repository{
+			LockFunc: func(context.Context) error { return nil },
+			CreateFunc: func(_ context.Context, d *storage.WebhookDelivery) (*storage.WebhookDelivery, error) {
+				for _, e := range s.deliveries {
+					if e.WebhookID == d.WebhookID && e.EventID == d.EventID && e.Attempt == d.Attempt {
+						return nil, storage.ErrConflictWebhookDeliveryAttempt
+					}
+				}
+				c := *d
+				s.deliveries = append(s.deliveries, &c)
+				return d, nil
+			},
+			UpdateFunc: func(_ context.Context, d *storage.WebhookDelivery, _ []storage.WebhookDeliveryField) (*storage.WebhookDelivery, error) {
+				for i, e := range s.deliveries {
+					if e.ID == d.ID {
+						c := *d
+						s.deliveries[i] = &c
+						return d, nil
+					}
+				}
+				return nil, storage.ErrWebhookDeliveryNotFound
+			},
+			ListFunc: func(_ context.Context, p storage.Pagination, _ storage.WebhookDeliveryOrderBy, conditions ...storage.Condition) ([]*storage.WebhookDelivery, error) {
+				var out []*storage.WebhookDelivery
+				for _, d := range s.deliveries {
+					if matchDelivery(d, conditions) && (p.Limit == 0 || len(out) < p.Limit) {
+						c := *d
+						out = append(out, &c)
+					}
+				}
+				return out, nil
+			},
+		},
+	})
+}
+
+func matchWebhook(w *storage.Webhook, conditions []storage.Condition) bool {
+	for _, c := range conditions {
+		switch c := c.(type) {
+		case storage.WebhookByOrganizationIDCondition:
+			if w.OrganizationID != c.OrganizationID {
+				return false
+			}
+		case storage.WebhookEnabledCondition:
+			if w.DisableTime.Valid {
+				return false
+			}
+		case storage.WebhookByEventTypeCondition:
+			if !slices.Contains(w.EventTypes, c.EventType) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchDelivery(d *storage.WebhookDelivery, conditions []storage.Condition) bool {
+	for _, c := range conditions {
+		switch c := c.(type) {
+		case storage.WebhookDeliveryByWebhookIDCondition:
+			if d.WebhookID != c.WebhookID {
+				return false
+			}
+		case storage.WebhookDeliveryByEventIDCondition:
+			if d.EventID != c.EventID {
+				return false
+			}
+		case storage.WebhookDeliveryByStatusCondition:
+			if d.Status != c.Status {
+				return false
+			}
+		case storage.WebhookDeliveryDueCondition:
+			if d.ScheduleTime.After(c.Time) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// receiver is a local webhook endpoint that verifies the signature of every request.
+type receiver struct {
+	*httptest.Server
+	status   atomic.Int32 // status is the response code of the next requests
+	requests atomic.Int32
+}
+
+func newReceiver(t *testing.T, secret string, c *clock) *receiver {
+	t.Helper()
+
+	r := &receiver{}
+	r.status.Store(http.StatusNoContent)
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.requests.Add(1)
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if err = webhook.Verify(secret, req.Header, body, c.Now(), 5*time.Minute); err != nil {
+			t.Errorf("expected a valid signature, got %v", err)
+		}
+
+		var p webhook.Payload
+		if err = json.Unmarshal(body, &p); err != nil {
+			t.Errorf("expected a JSON payload, got %v", err)
+		}
+
+		if got := req.Header.Get(webhook.HeaderEventType); got != p.Type.String() {
+			t.Errorf("expected event type header %s, got %s", p.Type, got)
+		}
+
+		w.WriteHeader(int(r.status.Load()))
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func newWebhook(url string, eventTypes ...string) *storage.Webhook {
+	return &storage.Webhook{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		URL:            url,
+		EventTypes:     eventTypes,
+		Secret:         "secret",
+	}
+}
+
+func userCreated(t *testing.T, id int64) outbox.Message {
+	t.Helper()
+
+	payload, err := json.Marshal(domain.UserCreated{User: domain.UserState{ID: userID, OrganizationID: orgID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return outbox.Message{
+		ID:            id,
+		AggregateType: domain.UserAggregate,
+		AggregateID:   userID,
+		Type:          domain.EventUserCreated,
+		Payload:       payload,
+	}
+}
+
+func newDeliverer(t *testing.T, s *store, c *clock, maxAttempts, disableAfter int) *webhook.Deliverer {
+	t.Helper()
+
+	d, err := webhook.NewDeliverer(webhook.DelivererConfig{
+		Logger:       slog.Default(),
+		Clock:        c.Now,
+		GenUUID:      uuid.New,
+		DBManager:    s.manager(),
+		MaxAttempts:  maxAttempts,
+		DisableAfter: disableAfter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	t.Run("should schedule a delivery once for each subscribed and enabled webhook", func(t *testing.T) {
+		subscribed := newWebhook("http://subscribed", "user.created")
+		other := newWebhook("http://other", "user.deleted")
+		disabled := newWebhook("http://disabled", "user.created")
+		disabled.DisableTime.Valid = true
+		s := newStore(subscribed, other, disabled)
+
+		c := &clock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+		d := webhook.NewDispatcher(c.Now, uuid.New, s.manager())
+		for i := 0; i < 2; i++ {
+			if err := d.Publish(context.Background(), userCreated(t, 1)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if len(s.deliveries) != 1 {
+			t.Fatalf("expected 1 delivery, got %d", len(s.deliveries))
+		}
+
+		got := s.deliveries[0]
+		if got.WebhookID != subscribed.ID || got.Status != "pending" || got.Attempt != 1 || !got.ScheduleTime.Equal(c.now) {
+			t.Errorf("expected a pending first attempt to the subscribed webhook, got %+v", got)
+		}
+
+		var p webhook.Payload
+		if err := json.Unmarshal(got.Payload, &p); err != nil {
+			t.Fatal(err)
+		}
+
+		if p.ID != 1 || p.Organization != domain.OrganizationName(orgID) {
+			t.Errorf("unexpected payload %+v", p)
+		}
+	})
+}
+
+func TestDeliverer_Deliver(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*store, *clock, *receiver) {
+		c := &clock{now: time.Now()}
+		r := newReceiver(t, "secret", c)
+		s := newStore(newWebhook(r.URL, "user.created"))
+		if err := webhook.NewDispatcher(c.Now, uuid.New, s.manager()).Publish(ctx, userCreated(t, 1)); err != nil {
+			t.Fatal(err)
+		}
+		return s, c, r
+	}
+
+	t.Run("should deliver a signed request", func(t *testing.T) {
+		s, c, r := setup(t)
+
+		n, err := newDeliverer(t, s, c, 0, 0).Deliver(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 1 || r.requests.Load() != 1 {
+			t.Fatalf("expected 1 request, got %d", r.requests.Load())
+		}
+
+		if got := s.deliveries[0]; got.Status != "succeeded" || got.ResponseCode != http.StatusNoContent || !got.AttemptTime.Valid {
+			t.Errorf("expected a succeeded delivery, got %+v", got)
+		}
+	})
+
+	t.Run("should retry a failed delivery with backoff", func(t *testing.T) {
+		s, c, r := setup(t)
+		d := newDeliverer(t, s, c, 0, 0)
+
+		r.status.Store(http.StatusInternalServerError)
+		if _, err := d.Deliver(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := s.deliveries[0]; got.Status != "failed" || !strings.Contains(got.ErrorMessage, "unexpected status 500") {
+			t.Errorf("expected a failed delivery, got %+v", got)
+		}
+
+		if len(s.deliveries) != 2 {
+			t.Fatalf("expected a retry to be scheduled, got %d deliveries", len(s.deliveries))
+		}
+
+		retry := s.deliveries[1]
+		if retry.Attempt != 2 || retry.Status != "pending" || !retry.ScheduleTime.Equal(c.now.Add(webhook.BackoffBase)) {
+			t.Errorf("expected the second attempt after the backoff, got %+v", retry)
+		}
+
+		for _, w := range s.webhooks {
+			if w.FailureCount != 1 {
+				t.Errorf("expected 1 failure, got %d", w.FailureCount)
+			}
+		}
+
+		if n, err := d.Deliver(ctx); err != nil || n != 0 {
+			t.Errorf("expected the retry not to be due yet, got %d deliveries (%v)", n, err)
+		}
+
+		c.Advance(webhook.BackoffBase)
+		r.status.Store(http.StatusOK)
+		if _, err := d.Deliver(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := s.deliveries[1]; got.Status != "succeeded" {
+			t.Errorf("expected the retry to succeed, got %+v", got)
+		}
+
+		for _, w := range s.webhooks {
+			if w.FailureCount != 0 {
+				t.Errorf("expected the failures to be reset, got %d", w.FailureCount)
+			}
+		}
+	})
+
+	t.Run("should give up after the last attempt", func(t *testing.T) {
+		s, c, r := setup(t)
+		d := newDeliverer(t, s, c, 2, 0)
+
+		r.status.Store(http.StatusBadGateway)
+		for i := 0; i < 3; i++ {
+			if _, err := d.Deliver(ctx); err != nil {
+				t.Fatal(err)
+			}
+			c.Advance(webhook.BackoffMax)
+		}
+
+		if r.requests.Load() != 2 || len(s.deliveries) != 2 {
+			t.Errorf("expected 2 attempts, got %d requests and %d deliveries", r.requests.Load(), len(s.deliveries))
+		}
+	})
+
+	t.Run("should disable the webhook after repeated failures", func(t *testing.T) {
+		s, c, r := setup(t)
+		d := newDeliverer(t, s, c, 0, 2)
+
+		r.status.Store(http.StatusNotFound)
+		for i := 0; i < 3; i++ {
+			if _, err := d.Deliver(ctx); err != nil {
+				t.Fatal(err)
+			}
+			c.Advance(webhook.BackoffMax)
+		}
+
+		for _, w := range s.webhooks {
+			if !w.DisableTime.Valid || w.FailureCount != 2 {
+				t.Errorf("expected the webhook to be disabled after 2 failures, got %+v", w)
+			}
+		}
+
+		if r.requests.Load() != 2 {
+			t.Errorf("expected no requests after the webhook was disabled, got %d", r.requests.Load())
+		}
+
+		if err := webhook.NewDispatcher(c.Now, uuid.New, s.manager()).Publish(ctx, userCreated(t, 2)); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(s.deliveries) != 2 {
+			t.Errorf("expected no deliveries for a disabled webhook, got %d", len(s.deliveries))
+		}
+	})
+}
+
+func TestDeliveryWriter_Redeliver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should schedule the next attempt once", func(t *testing.T) {
+		c := &clock{now: time.Now()}
+		r := newReceiver(t, "secret", c)
+		r.status.Store(http.StatusInternalServerError)
+		s := newStore(newWebhook(r.URL, "user.created"))
+		if err := webhook.NewDispatcher(c.Now, uuid.New, s.manager()).Publish(ctx, userCreated(t, 1)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := newDeliverer(t, s, c, 1, 0).Deliver(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		var failed domain.WebhookDelivery
+		if err := failed.FromStorage(s.deliveries[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		w := webhook.NewDeliveryWriter(c.Now, uuid.New, s.manager().Op().WebhookDelivery)
+		got, err := w.Redeliver(ctx, &failed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Attempt != 2 || got.Status != domain.WebhookDeliveryStatusPending || !got.ScheduleTime.Equal(c.now) {
+			t.Errorf("expected a pending second attempt, got %+v", got)
+		}
+
+		if _, err = w.Redeliver(ctx, &failed); !errors.Is(err, webhook.ErrDeliveryPending) {
+			t.Errorf("expected %v, got %v", webhook.ErrDeliveryPending, err)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+	"github.com/google/uuid"
+)
+
+// ErrDeliveryPending is returned when an event is redelivered while an attempt to deliver it is still pending.
+var ErrDeliveryPending Error = errors.New("webhook delivery is already pending")
+
+// DeliveryWriter schedules and records delivery attempts.
+type DeliveryWriter struct {
+	c       func() time.Time // c is the clock function.
+	genUUID uuidgen.Generator
+	dr      storage.WebhookDeliveryReader
+	dw      storage.WebhookDeliveryWriter
+}
+
+// NewDeliveryWriter creates a new delivery writer.
+// The repository is used both to look up earlier attempts and to write new ones.
+func NewDeliveryWriter(c func() time.Time, genUUID uuidgen.Generator, r storage.WebhookDeliveryRepository) *DeliveryWriter {
+	return &DeliveryWriter{
+		c:       c,
+		genUUID: genUUID,
+		dr:      r,
+		dw:      r,
+	}
+}
+
+// Schedule schedules a pending attempt. The id and create time are set by the writer.
+func (w *DeliveryWriter) Schedule(ctx context.Context, d *domain.WebhookDelivery) (*domain.WebhookDelivery, Error) {
+	d.ID = w.genUUID()
+	d.Status = domain.WebhookDeliveryStatusPending
+	d.AttemptTime = time.Time{}
+	d.ResponseCode = 0
+	d.ErrorMessage = ""
+	d.CreateTime = w.c()
+	if d.ScheduleTime.IsZero() {
+		d.ScheduleTime = d.CreateTime
+	}
+
+	var err error
+	var in = new(storage.WebhookDelivery)
+	if err = d.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err = w.dw.Create(ctx, in)
+	if err != nil {
+		if errors.Is(err, storage.ErrConflictWebhookDeliveryAttempt) {
+			return nil, ErrDeliveryPending
+		}
+		return nil, err
+	}
+	result := &domain.WebhookDelivery{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Retry schedules the attempt after d at t.
+func (w *DeliveryWriter) Retry(ctx context.Context, d *domain.WebhookDelivery, t time.Time) (*domain.WebhookDelivery, Error) {
+	return w.Schedule(ctx, &domain.WebhookDelivery{
+		WebhookID:    d.WebhookID,
+		EventID:      d.EventID,
+		EventType:    d.EventType,
+		Payload:      d.Payload,
+		Attempt:      d.Attempt + 1,
+		ScheduleTime: t,
+	})
+}
+
+// Redeliver schedules the event of d to be delivered again right away, as the attempt after the latest one.
+func (w *DeliveryWriter) Redeliver(ctx context.Context, d *domain.WebhookDelivery) (*domain.WebhookDelivery, Error) {
+	attempts, err := w.attempts(ctx, d.WebhookID, d.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := d
+	for _, a := range attempts {
+		if a.Status == domain.WebhookDeliveryStatusPending {
+			return nil, ErrDeliveryPending
+		}
+		if a.Attempt > latest.Attempt {
+			latest = a
+		}
+	}
+
+	return w.Retry(ctx, latest, w.c())
+}
+
+// Update records the outcome of an attempt.
+func (w *DeliveryWriter) Update(ctx context.Context, d *domain.WebhookDelivery, fields []storage.WebhookDeliveryField) (*domain.WebhookDelivery, Error) {
+	var err error
+	in := &storage.WebhookDelivery{}
+	if err = d.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err = w.dw.Update(ctx, in, fields)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookDeliveryNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	result := &domain.WebhookDelivery{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// attempts returns the attempts to deliver an event to a webhook.
+func (w *DeliveryWriter) attempts(ctx context.Context, webhookID uuid.UUID, eventID int64) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := w.dr.List(ctx, storage.Pagination{}, storage.WebhookDeliveryOrderBy{},
+		storage.WebhookDeliveryByWebhookIDCondition{WebhookID: webhookID},
+		storage.WebhookDeliveryByEventIDCondition{EventID: eventID},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	out := make([]*domain.WebhookDelivery, 0, len(deliveries))
+	for _, in := range deliveries {
+		var d domain.WebhookDelivery
+		if err = d.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &d)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+	"github.com/google/uuid"
+)
+
+// Payload is the JSON body of a delivery request.
+type Payload struct {
+	ID           int64            `json:"id"`
+	Type         domain.EventType `json:"type"`
+	Organization string           `json:"organization"`
+	CreateTime   time.Time        `json:"create_time"`
+	Data         json.RawMessage  `json:"data"` // Data is the event, such as domain.UserCreated.
+}
+
+var _ outbox.Sink = &Dispatcher{}
+
+// Dispatcher is an outbox sink that schedules a delivery of every event
+// to the enabled webhooks of its organization that subscribe to it.
+type Dispatcher struct {
+	clock     func() time.Time
+	genUUID   uuidgen.Generator
+	dbManager storage.DBManager
+}
+
+func NewDispatcher(clock func() time.Time, genUUID uuidgen.Generator, dbManager storage.DBManager) *Dispatcher {
+	return &Dispatcher{
+		clock:     clock,
+		genUUID:   genUUID,
+		dbManager: dbManager,
+	}
+}
+
+// Publish implements outbox.Sink. Messages the relay publishes again are not scheduled twice.
+func (d *Dispatcher) Publish(ctx context.Context, m outbox.Message) error {
+	e, err := m.Decode()
+	if err != nil {
+		return err
+	}
+
+	organizationID := organizationOf(e)
+	body, err := json.Marshal(Payload{
+		ID:           m.ID,
+		Type:         m.Type,
+		Organization: domain.OrganizationName(organizationID),
+		CreateTime:   m.CreateTime,
+		Data:         m.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	return d.dbManager.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+		webhooks, err := r.Webhook.List(ctx, storage.Pagination{}, storage.WebhookOrderBy{},
+			storage.WebhookByOrganizationIDCondition{OrganizationID: organizationID},
+			storage.WebhookEnabledCondition{},
+			storage.WebhookByEventTypeCondition{EventType: m.Type.String()},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		deliveries := NewDeliveryWriter(d.clock, d.genUUID, r.WebhookDelivery)
+		for _, w := range webhooks {
+			attempts, err := deliveries.attempts(ctx, w.ID, m.ID)
+			if err != nil {
+				return err
+			}
+
+			if len(attempts) > 0 {
+				continue
+			}
+
+			if _, err = deliveries.Schedule(ctx, &domain.WebhookDelivery{
+				WebhookID: w.ID,
+				EventID:   m.ID,
+				EventType: m.Type,
+				Payload:   body,
+				Attempt:   1,
+			}); err != nil {
+				return fmt.Errorf("failed to schedule delivery to webhook %s: %w", w.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// organizationOf returns the organization an event belongs to.
+func organizationOf(e domain.Event) uuid.UUID {
+	switch e := e.(type) {
+	case domain.UserCreated:
+		return e.User.OrganizationID
+	case domain.UserUpdated:
+		return e.User.OrganizationID
+	case domain.UserDeleted:
+		return e.User.OrganizationID
+	default:
+		// organization events are their own aggregate.
+		return e.AggregateID()
+	}
+}
@@ -0,0 +1,4 @@
+package webhook
+
+// Error defines the webhook domain errors.
+type Error error
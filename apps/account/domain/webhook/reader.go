@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound  Error = errors.New("webhook not found")
+	ErrDeliveryNotFound Error = errors.New("webhook delivery not found")
+)
+
+type Reader struct {
+	webhooks   storage.WebhookReader
+	deliveries storage.WebhookDeliveryReader
+}
+
+func NewReader(webhooks storage.WebhookReader, deliveries storage.WebhookDeliveryReader) *Reader {
+	return &Reader{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+	}
+}
+
+func (r *Reader) Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, Error) {
+	in, err := r.webhooks.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	var w = new(domain.Webhook)
+	return w, w.FromStorage(in)
+}
+
+// List lists the webhooks of an organization, oldest first.
+func (r *Reader) List(ctx context.Context, organizationID uuid.UUID) ([]*domain.Webhook, Error) {
+	webhooks, err := r.webhooks.List(ctx, storage.Pagination{}, storage.WebhookOrderBy{
+		{Field: storage.WebhookCreateTime, Direction: storage.ASC},
+	}, storage.WebhookByOrganizationIDCondition{OrganizationID: organizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Webhook, 0, len(webhooks))
+	for _, in := range webhooks {
+		var w domain.Webhook
+		if err = w.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &w)
+	}
+
+	return out, nil
+}
+
+func (r *Reader) GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, Error) {
+	in, err := r.deliveries.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookDeliveryNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	var d = new(domain.WebhookDelivery)
+	return d, d.FromStorage(in)
+}
+
+// ListDeliveries lists the delivery attempts of a webhook, newest first.
+func (r *Reader) ListDeliveries(ctx context.Context, webhookID uuid.UUID, p storage.Pagination) ([]*domain.WebhookDelivery, Error) {
+	deliveries, err := r.deliveries.List(ctx, p, storage.WebhookDeliveryOrderBy{
+		{Field: storage.WebhookDeliveryCreateTime, Direction: storage.DESC},
+		{Field: storage.WebhookDeliveryAttempt, Direction: storage.DESC},
+	}, storage.WebhookDeliveryByWebhookIDCondition{WebhookID: webhookID})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.WebhookDelivery, 0, len(deliveries))
+	for _, in := range deliveries {
+		var d domain.WebhookDelivery
+		if err = d.FromStorage(in); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &d)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of a delivery request.
+const (
+	HeaderEventID   = "Lingo-Event-Id"   // HeaderEventID is the same for every attempt, receivers can use it to deduplicate.
+	HeaderEventType = "Lingo-Event-Type" // HeaderEventType is the type of the delivered event.
+	HeaderDelivery  = "Lingo-Delivery"   // HeaderDelivery is the id of the delivery attempt.
+	HeaderTimestamp = "Lingo-Timestamp"  // HeaderTimestamp is the unix time the request was signed at.
+	HeaderSignature = "Lingo-Signature"  // HeaderSignature is the signature of the timestamp and body.
+)
+
+const (
+	signatureVersion = "v1"
+	secretPrefix     = "whsec_"
+	secretSize       = 32
+)
+
+var (
+	ErrSignatureMissing  Error = errors.New("signature is missing")
+	ErrSignatureMismatch Error = errors.New("signature does not match")
+	ErrTimestampInvalid  Error = errors.New("timestamp is invalid or outside the tolerance")
+)
+
+// NewSecret returns a random secret to sign deliveries with.
+func NewSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Sign returns the signature header value for a body sent at t.
+// The signature is the hex encoded HMAC-SHA256 of "{unix timestamp}.{body}" keyed with the secret.
+// Signing the timestamp keeps a captured request from being replayed later.
+func Sign(secret string, t time.Time, body []byte) string {
+	return signatureVersion + "=" + hex.EncodeToString(mac(secret, strconv.FormatInt(t.Unix(), 10), body))
+}
+
+// Verify checks the signature of a delivery as a receiver would.
+// Requests signed more than tolerance before or after now are rejected.
+func Verify(secret string, h http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	timestamp := h.Get(HeaderTimestamp)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrTimestampInvalid
+	}
+
+	if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return ErrTimestampInvalid
+	}
+
+	signature, ok := strings.CutPrefix(h.Get(HeaderSignature), signatureVersion+"=")
+	if !ok || signature == "" {
+		return ErrSignatureMissing
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, mac(secret, timestamp, body)) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+	m := hmac.New(sha256.New, []byte(secret))
+	m.Write([]byte(timestamp))
+	m.Write([]byte("."))
+	m.Write(body)
+	return m.Sum(nil)
+}
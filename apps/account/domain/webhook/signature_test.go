@@ -0,0 +1,101 @@
+package webhook_test
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	body := []byte(`{"id": 1}`)
+	header := func(secret string, signedAt time.Time) http.Header {
+		h := http.Header{}
+		h.Set(webhook.HeaderTimestamp, strconv.FormatInt(signedAt.Unix(), 10))
+		h.Set(webhook.HeaderSignature, webhook.Sign(secret, signedAt, body))
+		return h
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   []byte
+		err    error
+	}{
+		{
+			name:   "should accept a valid signature",
+			header: header("secret", now),
+			body:   body,
+		},
+		{
+			name:   "should reject another secret",
+			header: header("other", now),
+			body:   body,
+			err:    webhook.ErrSignatureMismatch,
+		},
+		{
+			name:   "should reject a changed body",
+			header: header("secret", now),
+			body:   []byte(`{"id": 2}`),
+			err:    webhook.ErrSignatureMismatch,
+		},
+		{
+			name:   "should reject an old request",
+			header: header("secret", now.Add(-10*time.Minute)),
+			body:   body,
+			err:    webhook.ErrTimestampInvalid,
+		},
+		{
+			name:   "should reject a missing signature",
+			header: http.Header{webhook.HeaderTimestamp: {strconv.FormatInt(now.Unix(), 10)}},
+			body:   body,
+			err:    webhook.ErrSignatureMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := webhook.Verify("secret", tt.header, tt.body, now, 5*time.Minute); !errors.Is(err, tt.err) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	t.Run("should return a different secret every time", func(t *testing.T) {
+		a, err := webhook.NewSecret()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := webhook.NewSecret()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a == b {
+			t.Error("expected different secrets")
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: webhook.BackoffBase},
+		{attempt: 2, want: 2 * webhook.BackoffBase},
+		{attempt: 4, want: 8 * webhook.BackoffBase},
+		{attempt: 100, want: webhook.BackoffMax},
+	}
+	for _, tt := range tests {
+		if got := webhook.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
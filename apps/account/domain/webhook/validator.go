@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/validate"
+)
+
+// maxURLLength is the maximum length of a webhook URL.
+const maxURLLength = 2048
+
+var (
+	ErrInvalidURL       Error = errors.New("invalid webhook url")
+	ErrNoEventTypes     Error = errors.New("no event types")
+	ErrUnknownEventType Error = errors.New("unknown event type")
+)
+
+// Validate checks the URL and event types of a webhook.
+// The URL must be an absolute http or https URL.
+func Validate(w *domain.Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil || len(w.URL) > maxURLLength || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return validate.NewError("url", "url should be an absolute http or https url", ErrInvalidURL)
+	}
+
+	if len(w.EventTypes) == 0 {
+		return validate.NewError("event_types", "at least one event type is required", ErrNoEventTypes)
+	}
+
+	known := domain.EventTypes()
+	for _, t := range w.EventTypes {
+		if !slices.Contains(known, t) {
+			return validate.NewError("event_types", fmt.Sprintf("unknown event type %q", t), ErrUnknownEventType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package webhook_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/webhook"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook *domain.Webhook
+		err     error
+	}{
+		{
+			name:    "valid",
+			webhook: &domain.Webhook{URL: "https://example.com/hook", EventTypes: []domain.EventType{domain.EventUserCreated}},
+		},
+		{
+			name:    "relative url",
+			webhook: &domain.Webhook{URL: "/hook", EventTypes: []domain.EventType{domain.EventUserCreated}},
+			err:     webhook.ErrInvalidURL,
+		},
+		{
+			name:    "unsupported scheme",
+			webhook: &domain.Webhook{URL: "ftp://example.com", EventTypes: []domain.EventType{domain.EventUserCreated}},
+			err:     webhook.ErrInvalidURL,
+		},
+		{
+			name:    "no event types",
+			webhook: &domain.Webhook{URL: "https://example.com/hook"},
+			err:     webhook.ErrNoEventTypes,
+		},
+		{
+			name:    "unknown event type",
+			webhook: &domain.Webhook{URL: "https://example.com/hook", EventTypes: []domain.EventType{"user.renamed"}},
+			err:     webhook.ErrUnknownEventType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := webhook.Validate(tt.webhook); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
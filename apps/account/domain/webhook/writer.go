@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+	"github.com/google/uuid"
+)
+
+type Writer struct {
+	c       func() time.Time // c is the clock function.
+	genUUID uuidgen.Generator
+	ww      storage.WebhookWriter
+}
+
+func NewWriter(c func() time.Time, genUUID uuidgen.Generator, w storage.WebhookWriter) *Writer {
+	return &Writer{
+		c:       c,
+		genUUID: genUUID,
+		ww:      w,
+	}
+}
+
+// Create creates a webhook with a new id and secret.
+func (w *Writer) Create(ctx context.Context, h *domain.Webhook) (*domain.Webhook, Error) {
+	secret, err := NewSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	h.ID = w.genUUID()
+	h.Secret = secret
+	h.FailureCount = 0
+	h.DisableTime = time.Time{}
+	h.CreateTime = w.c()
+	h.UpdateTime = h.CreateTime
+
+	var in = new(storage.Webhook)
+	if err = h.ToStorage(in); err != nil {
+		return nil, err
+	}
+	in, err = w.ww.Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.Webhook{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update updates the webhook.
+// its sets the update time to the current time before updating the webhook.
+// fields are sorted before updating the webhook and deduplicated.
+func (w *Writer) Update(ctx context.Context, h *domain.Webhook, fields []storage.WebhookField) (*domain.Webhook, Error) {
+	h.UpdateTime = w.c()
+	var err error
+	in := &storage.Webhook{}
+	if err = h.ToStorage(in); err != nil {
+		return nil, err
+	}
+	fields = append(fields, storage.WebhookUpdateTime)
+	slices.Sort(fields)
+	in, err = w.ww.Update(ctx, in, slices.Compact(fields))
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	result := &domain.Webhook{}
+	if err = result.FromStorage(in); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetDisabled stops or resumes deliveries to the webhook, resuming also resets its failures.
+// It returns the changed fields to pass to Update.
+func (w *Writer) SetDisabled(h *domain.Webhook, disabled bool) []storage.WebhookField {
+	h.DisableTime = time.Time{}
+	if disabled {
+		h.DisableTime = w.c()
+	}
+	h.FailureCount = 0
+	return []storage.WebhookField{storage.WebhookDisableTime, storage.WebhookFailureCount}
+}
+
+func (w *Writer) Delete(ctx context.Context, id uuid.UUID) Error {
+	if err := w.ww.Delete(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return ErrWebhookNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package gateway
+
+import "time"
+
+// limiter is a token bucket that allows rate events per second, with bursts of up to burst events.
+// It is not safe for concurrent use, every connection reads from a single goroutine.
+type limiter struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newLimiter(rate float64, burst int, now func() time.Time) *limiter {
+	return &limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// allow reports whether an event may happen now, and takes a token if it may.
+func (l *limiter) allow() bool {
+	now := l.now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens < 1 {
+		return false
+	}
+
+	l.tokens--
+	return true
+}
@@ -0,0 +1,376 @@
+// Package gateway serves the streaming RPCs of the account service to browsers over WebSocket.
+//
+// Every frame the server sends is a JSON object with either a "result", the JSON encoded response,
+// or an "error", the JSON encoded status that ended the stream, like the streams of the grpc-gateway.
+// Clients of the chat stream send JSON encoded requests.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// tokenCookie is the cookie with the access token, it is set by the gateway on login.
+	tokenCookie = "token"
+	// tokenMetadataKey is the metadata the account service reads the access token from.
+	tokenMetadataKey = "token"
+
+	defaultPingInterval = 30 * time.Second
+	defaultRate         = 10
+	defaultBurst        = 20
+	maxFrameSize        = 64 << 10
+	writeWait           = 10 * time.Second
+)
+
+var (
+	errRateLimited  = errors.New("rate limit exceeded")
+	errBinaryFrame  = errors.New("binary frames are not supported")
+	errUnexpected   = errors.New("the stream accepts no messages")
+	errInvalidFrame = errors.New("invalid frame")
+)
+
+type Config struct {
+	Logger *slog.Logger
+	Client protoaccount.AccountServiceClient
+	// AllowedOrigins may connect besides the origin of the gateway itself, such as "https://app.example.com".
+	AllowedOrigins []string
+	PingInterval   time.Duration // PingInterval defaults to 30 seconds, a connection that does not answer two pings is closed.
+	Rate           float64       // Rate is the number of frames per second a connection may send, defaults to 10.
+	Burst          int           // Burst is the number of frames a connection may send at once, defaults to 20.
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is nil")
+	}
+	if c.Client == nil {
+		return errors.New("client is nil")
+	}
+	if c.PingInterval < 0 {
+		return errors.New("ping interval is negative")
+	}
+	if c.Rate < 0 {
+		return errors.New("rate is negative")
+	}
+	if c.Burst < 0 {
+		return errors.New("burst is negative")
+	}
+	return nil
+}
+
+// Bridge bridges WebSocket connections to the streaming RPCs of the account service.
+type Bridge struct {
+	logger         *slog.Logger
+	client         protoaccount.AccountServiceClient
+	allowedOrigins []string
+	pingInterval   time.Duration
+	rate           float64
+	burst          int
+	upgrader       websocket.Upgrader
+	mux            *http.ServeMux
+}
+
+func New(c Config) (*Bridge, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	b := &Bridge{
+		logger:         c.Logger,
+		client:         c.Client,
+		allowedOrigins: c.AllowedOrigins,
+		pingInterval:   orDefault(c.PingInterval, defaultPingInterval),
+		rate:           orDefault(c.Rate, defaultRate),
+		burst:          orDefault(c.Burst, defaultBurst),
+		mux:            http.NewServeMux(),
+	}
+	b.upgrader = websocket.Upgrader{CheckOrigin: b.checkOrigin}
+
+	b.mux.HandleFunc("GET /v1/ws/connect", b.connect)
+	b.mux.HandleFunc("GET /v1/ws/organizations/{organization}/users:watch", b.watchUsers)
+
+	return b, nil
+}
+
+// orDefault returns v, or the default when v is zero.
+func orDefault[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
+
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mux.ServeHTTP(w, r)
+}
+
+// checkOrigin allows the origin of the gateway itself and the allowed origins.
+// Requests without an origin do not come from a browser, so they can not be forged by another site.
+func (b *Bridge) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host) || slices.Contains(b.allowedOrigins, origin)
+}
+
+// connect bridges a connection to the Connect stream.
+func (b *Bridge) connect(w http.ResponseWriter, r *http.Request) {
+	c, ctx, ok := b.upgrade(w, r)
+	if !ok {
+		return
+	}
+	defer c.close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := b.client.Connect(ctx)
+	if err != nil {
+		c.fail(err)
+		return
+	}
+
+	b.pump(ctx, cancel, c, func(data []byte) error {
+		var req protoaccount.ConnectRequest
+		if err := protojson.Unmarshal(data, &req); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidFrame, err)
+		}
+
+		// the error of the stream is returned by Recv.
+		_ = stream.Send(&req)
+		return nil
+	}, func() (proto.Message, error) {
+		return stream.Recv()
+	})
+}
+
+// watchUsers bridges a connection to the WatchUsers stream. The resume token is read from the query.
+func (b *Bridge) watchUsers(w http.ResponseWriter, r *http.Request) {
+	c, ctx, ok := b.upgrade(w, r)
+	if !ok {
+		return
+	}
+	defer c.close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := b.client.WatchUsers(ctx, &protoaccount.WatchUsersRequest{
+		Parent:      "organizations/" + r.PathValue("organization"),
+		ResumeToken: r.URL.Query().Get("resume_token"),
+	})
+	if err != nil {
+		c.fail(err)
+		return
+	}
+
+	b.pump(ctx, cancel, c, func([]byte) error {
+		return errUnexpected
+	}, func() (proto.Message, error) {
+		return stream.Recv()
+	})
+}
+
+// upgrade authenticates the request and upgrades it to a WebSocket connection.
+// The returned context carries the access token and client of the request as outgoing metadata.
+func (b *Bridge) upgrade(w http.ResponseWriter, r *http.Request) (*conn, context.Context, bool) {
+	cookie, err := r.Cookie(tokenCookie)
+	if err != nil || cookie.Value == "" {
+		http.Error(w, "access token is required", http.StatusUnauthorized)
+		return nil, nil, false
+	}
+
+	ws, err := b.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has responded with an error.
+		b.logger.InfoContext(r.Context(), "websocket upgrade failed", slog.String("error", err.Error()))
+		return nil, nil, false
+	}
+
+	md := metadata.Pairs(
+		tokenMetadataKey, cookie.Value,
+		"grpcgateway-user-agent", r.UserAgent(),
+	)
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		md.Set("x-forwarded-for", host)
+	}
+
+	c := &conn{
+		ws:           ws,
+		limiter:      newLimiter(b.rate, b.burst, time.Now),
+		pingInterval: b.pingInterval,
+	}
+	c.setup()
+
+	return c, metadata.NewOutgoingContext(r.Context(), md), true
+}
+
+// pump reads the frames of the connection on a separate goroutine and passes them to handle,
+// and writes the responses of recv to the connection until the stream or the connection ends.
+func (b *Bridge) pump(ctx context.Context, cancel context.CancelFunc, c *conn, handle func([]byte) error, recv func() (proto.Message, error)) {
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- c.readFrames(handle)
+		cancel()
+	}()
+
+	go c.ping(ctx)
+
+	for {
+		resp, err := recv()
+		if err != nil {
+			select {
+			case err := <-readErr:
+				c.closeFor(err)
+			default:
+				c.fail(err)
+			}
+			return
+		}
+
+		if err = c.writeResult(resp); err != nil {
+			b.logger.InfoContext(ctx, "websocket write failed", slog.String("error", err.Error()))
+			return
+		}
+	}
+}
+
+// conn is a WebSocket connection. Frames are written by a single goroutine,
+// control frames may be written concurrently.
+type conn struct {
+	ws           *websocket.Conn
+	limiter      *limiter
+	pingInterval time.Duration
+}
+
+// setup limits the frame size and closes the connection when it does not answer two pings.
+func (c *conn) setup() {
+	c.ws.SetReadLimit(maxFrameSize)
+	_ = c.ws.SetReadDeadline(time.Now().Add(2 * c.pingInterval))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(2 * c.pingInterval))
+	})
+}
+
+// ping sends a ping at the ping interval until the context is done.
+func (c *conn) ping(ctx context.Context) {
+	t := time.NewTicker(c.pingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readFrames reads text frames and passes them to handle, until reading fails or the client sends too much.
+func (c *conn) readFrames(handle func([]byte) error) error {
+	for {
+		t, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		if !c.limiter.allow() {
+			return errRateLimited
+		}
+
+		if t != websocket.TextMessage {
+			return errBinaryFrame
+		}
+
+		if err = handle(data); err != nil {
+			return err
+		}
+	}
+}
+
+// frame is the JSON object of a frame sent to the client.
+type frame struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+func (c *conn) writeResult(m proto.Message) error {
+	result, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(frame{Result: result})
+}
+
+func (c *conn) writeFrame(f frame) error {
+	_ = c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.ws.WriteJSON(f)
+}
+
+// fail ends the connection with the status of a stream. A stream that ended normally is closed without an error frame.
+func (c *conn) fail(err error) {
+	if errors.Is(err, io.EOF) {
+		c.closeWith(websocket.CloseNormalClosure, "")
+		return
+	}
+
+	s, _ := status.FromError(err)
+	if e, err := protojson.Marshal(s.Proto()); err == nil {
+		_ = c.writeFrame(frame{Error: e})
+	}
+
+	c.closeWith(websocket.CloseNormalClosure, s.Code().String())
+}
+
+// closeFor ends the connection after reading from it failed.
+func (c *conn) closeFor(err error) {
+	switch {
+	case errors.Is(err, errRateLimited), errors.Is(err, errUnexpected):
+		c.closeWith(websocket.ClosePolicyViolation, err.Error())
+	case errors.Is(err, errBinaryFrame):
+		c.closeWith(websocket.CloseUnsupportedData, err.Error())
+	case errors.Is(err, errInvalidFrame):
+		c.closeWith(websocket.CloseInvalidFramePayloadData, errInvalidFrame.Error())
+	default:
+		// the client closed the connection or stopped answering pings.
+		c.closeWith(websocket.CloseNormalClosure, "")
+	}
+}
+
+func (c *conn) closeWith(code int, reason string) {
+	_ = c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+}
+
+func (c *conn) close() {
+	_ = c.ws.Close()
+}
@@ -0,0 +1,290 @@
+package gateway_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/gateway"
+	"github.com/extreme-business/lingo/pkg/grpctest"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const accessToken = "access-token"
+
+// accountServer echoes the subscriptions of the Connect stream and sends a single change on WatchUsers.
+type accountServer struct {
+	protoaccount.UnimplementedAccountServiceServer
+}
+
+func authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("token"); len(v) == 0 || v[0] != accessToken {
+		return status.Error(codes.Unauthenticated, "access token is invalid")
+	}
+	return nil
+}
+
+func (s *accountServer) Connect(stream protoaccount.AccountService_ConnectServer) error {
+	if err := authenticate(stream.Context()); err != nil {
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = stream.Send(&protoaccount.ConnectResponse{Event: &protoaccount.ConnectResponse_Subscribed_{
+			Subscribed: &protoaccount.ConnectResponse_Subscribed{Conversations: req.GetSubscribe().GetConversations()},
+		}}); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *accountServer) WatchUsers(req *protoaccount.WatchUsersRequest, stream protoaccount.AccountService_WatchUsersServer) error {
+	if err := authenticate(stream.Context()); err != nil {
+		return err
+	}
+
+	return stream.Send(&protoaccount.WatchUsersResponse{
+		ChangeType:  protoaccount.WatchUsersResponse_CHANGE_TYPE_SNAPSHOT_END,
+		ResumeToken: req.GetParent(),
+	})
+}
+
+// newGateway serves a bridge to an in-process account server.
+func newGateway(t *testing.T, c gateway.Config) *httptest.Server {
+	t.Helper()
+
+	s := grpctest.New(t, func(s grpc.ServiceRegistrar) {
+		protoaccount.RegisterAccountServiceServer(s, &accountServer{})
+	})
+
+	c.Logger = slog.Default()
+	c.Client = grpctest.NewClient(s, protoaccount.NewAccountServiceClient)
+	b, err := gateway.New(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// dial connects to a path of the gateway with the access token cookie and the given headers.
+func dial(t *testing.T, srv *httptest.Server, path string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+
+	if header == nil {
+		header = http.Header{}
+	}
+	if header.Get("Cookie") == "" {
+		header.Set("Cookie", "token="+accessToken)
+	}
+
+	ws, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path, header)
+	if err == nil {
+		t.Cleanup(func() { _ = ws.Close() })
+	}
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	return ws, resp, err
+}
+
+type frame struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func readFrame(t *testing.T, ws *websocket.Conn) frame {
+	t.Helper()
+	_ = ws.SetReadDeadline(time.Now().Add(time.Second))
+
+	var f frame
+	if err := ws.ReadJSON(&f); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// readUntilClosed skips the frames before the connection closes and returns the close error.
+func readUntilClosed(ws *websocket.Conn) error {
+	_ = ws.SetReadDeadline(time.Now().Add(time.Second))
+	for {
+		if _, _, err := ws.ReadMessage(); err != nil {
+			return err
+		}
+	}
+}
+
+// compact removes the whitespace protojson adds at random.
+func compact(t *testing.T, data []byte) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := json.Compact(&b, data); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestBridge_Connect(t *testing.T) {
+	t.Run("should bridge requests and responses", func(t *testing.T) {
+		srv := newGateway(t, gateway.Config{})
+		ws, _, err := dial(t, srv, "/v1/ws/connect", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = ws.WriteMessage(websocket.TextMessage, []byte(`{"subscribe":{"conversations":["organizations/1/conversations/2"]}}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		f := readFrame(t, ws)
+		if want := `{"subscribed":{"conversations":["organizations/1/conversations/2"]}}`; compact(t, f.Result) != want {
+			t.Errorf("expected %s, got %s", want, f.Result)
+		}
+	})
+
+	t.Run("should send the error that ended the stream", func(t *testing.T) {
+		srv := newGateway(t, gateway.Config{})
+		ws, _, err := dial(t, srv, "/v1/ws/connect", http.Header{"Cookie": {"token=invalid"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f := readFrame(t, ws)
+		if f.Error == nil || codes.Code(f.Error.Code) != codes.Unauthenticated {
+			t.Errorf("expected an unauthenticated error, got %+v", f)
+		}
+	})
+
+	t.Run("should require the access token cookie", func(t *testing.T) {
+		srv := newGateway(t, gateway.Config{})
+		_, resp, err := dial(t, srv, "/v1/ws/connect", http.Header{"Cookie": {"other=1"}})
+		if err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %v", http.StatusUnauthorized, resp)
+		}
+	})
+
+	t.Run("should reject other origins", func(t *testing.T) {
+		srv := newGateway(t, gateway.Config{AllowedOrigins: []string{"https://app.example.com"}})
+
+		_, resp, err := dial(t, srv, "/v1/ws/connect", http.Header{"Origin": {"https://evil.example.com"}})
+		if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+			t.Errorf("expected status %d, got %v", http.StatusForbidden, resp)
+		}
+
+		if _, _, err = dial(t, srv, "/v1/ws/connect", http.Header{"Origin": {"https://app.example.com"}}); err != nil {
+			t.Errorf("expected an allowed origin to connect, got %v", err)
+		}
+
+		if _, _, err = dial(t, srv, "/v1/ws/connect", http.Header{"Origin": {srv.URL}}); err != nil {
+			t.Errorf("expected the origin of the gateway to connect, got %v", err)
+		}
+	})
+
+	t.Run("should close connections that send too many frames", func(t *testing.T) {
+		srv := newGateway(t, gateway.Config{Rate: 0.001, Burst: 1})
+		ws, _, err := dial(t, srv, "/v1/ws/connect", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for range 2 {
+			if err = ws.WriteMessage(websocket.TextMessage, []byte(`{"ping":{}}`)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err = readUntilClosed(ws); !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+			t.Errorf("expected a policy violation, got %v", err)
+		}
+	})
+
+	t.Run("should close connections that send invalid frames", func(t *testing.T) {
+		srv := newGateway(t, gateway.Config{})
+		ws, _, err := dial(t, srv, "/v1/ws/connect", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = ws.WriteMessage(websocket.TextMessage, []byte(`{"unknown":1}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = readUntilClosed(ws); !websocket.IsCloseError(err, websocket.CloseInvalidFramePayloadData) {
+			t.Errorf("expected an invalid payload error, got %v", err)
+		}
+	})
+
+	t.Run("should ping the client", func(t *testing.T) {
+		srv := newGateway(t, gateway.Config{PingInterval: 10 * time.Millisecond})
+		ws, _, err := dial(t, srv, "/v1/ws/connect", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pinged := make(chan struct{}, 1)
+		ws.SetPingHandler(func(data string) error {
+			select {
+			case pinged <- struct{}{}:
+			default:
+			}
+			return ws.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		go func() {
+			for {
+				if _, _, err := ws.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		select {
+		case <-pinged:
+		case <-time.After(time.Second):
+			t.Error("expected a ping")
+		}
+	})
+}
+
+func TestBridge_WatchUsers(t *testing.T) {
+	srv := newGateway(t, gateway.Config{})
+	ws, _, err := dial(t, srv, "/v1/ws/organizations/1/users:watch", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := readFrame(t, ws)
+	if want := `{"changeType":"CHANGE_TYPE_SNAPSHOT_END","resumeToken":"organizations/1"}`; compact(t, f.Result) != want {
+		t.Errorf("expected %s, got %s", want, f.Result)
+	}
+
+	if err = readUntilClosed(ws); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("expected the connection to close normally, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+-- Create enum type for user role
+CREATE TYPE user_role AS ENUM ('user', 'admin', 'system');
+
+-- Add role to users
+ALTER TABLE users ADD COLUMN role user_role NOT NULL DEFAULT 'user';
+
+-- Create sessions table
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    refresh_family UUID NOT NULL,
+    refresh_token_id UUID NOT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_refresh_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expire_time TIMESTAMP NOT NULL,
+    revoke_time TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Create index to list the sessions of a user
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
@@ -0,0 +1,28 @@
+-- Create audit events table
+CREATE TABLE audit_events (
+    id BIGINT PRIMARY KEY,
+    organization_id UUID,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    action VARCHAR(64) NOT NULL,
+    resource VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    previous_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE
+);
+
+-- Create index to list the audit events of an organization
+CREATE INDEX audit_events_organization_id_create_time_idx ON audit_events (organization_id, create_time);
+
+-- Audit events are append-only
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,2 @@
+-- Add the user that impersonates the session user, empty for regular logins
+ALTER TABLE sessions ADD COLUMN impersonator VARCHAR(255) NOT NULL DEFAULT '';
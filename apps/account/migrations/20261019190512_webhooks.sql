@@ -0,0 +1,37 @@
+-- Create webhooks table
+CREATE TABLE webhooks (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    disable_time TIMESTAMP,
+    FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+);
+
+-- Create index to list the webhooks of an organization
+CREATE INDEX webhooks_organization_id_idx ON webhooks (organization_id);
+
+-- Create webhook deliveries table, every attempt to deliver an event is a row
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL,
+    event_id BIGINT NOT NULL,
+    event_type VARCHAR(128) NOT NULL,
+    payload JSONB NOT NULL,
+    attempt INTEGER NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    schedule_time TIMESTAMP NOT NULL,
+    attempt_time TIMESTAMP,
+    response_code INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE,
+    CONSTRAINT webhook_deliveries_attempt_key UNIQUE (webhook_id, event_id, attempt)
+);
+
+-- Create index to find the deliveries that are due
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (schedule_time) WHERE status = 'pending';
@@ -0,0 +1,46 @@
+-- Create conversations table
+CREATE TABLE conversations (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    -- the creator is not a foreign key, conversations stay when their creator is deleted
+    creator_id UUID NOT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+);
+
+-- Create index to list the conversations of an organization, most recently active first
+CREATE INDEX conversations_organization_id_update_time_idx ON conversations (organization_id, update_time DESC);
+
+-- Create conversation participants table
+CREATE TABLE conversation_participants (
+    conversation_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    join_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (conversation_id, user_id),
+    FOREIGN KEY (conversation_id) REFERENCES conversations (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Create index to find the conversations of a user
+CREATE INDEX conversation_participants_user_id_idx ON conversation_participants (user_id);
+
+-- Create messages table, the sequence orders the messages and is used for paging
+CREATE TABLE messages (
+    id UUID PRIMARY KEY,
+    sequence BIGSERIAL NOT NULL UNIQUE,
+    conversation_id UUID NOT NULL,
+    -- the sender is not a foreign key, messages stay when their sender is deleted
+    sender_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edit_time TIMESTAMP,
+    delete_time TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES conversations (id) ON DELETE CASCADE
+);
+
+-- Create index to list the messages of a conversation, newest first
+CREATE INDEX messages_conversation_id_sequence_idx ON messages (conversation_id, sequence DESC);
@@ -0,0 +1,16 @@
+-- Add the preferred language of a user, a BCP 47 tag such as "en" or "pt-BR", empty when unknown
+ALTER TABLE users ADD COLUMN preferred_language VARCHAR(35) NOT NULL DEFAULT '';
+
+-- Add the language a message was written in, empty when unknown
+ALTER TABLE messages ADD COLUMN language VARCHAR(35) NOT NULL DEFAULT '';
+
+-- Create message translations table, a translation is stale when the message was updated after it was made
+CREATE TABLE message_translations (
+    message_id UUID NOT NULL,
+    language VARCHAR(35) NOT NULL,
+    body TEXT NOT NULL,
+    message_update_time TIMESTAMP NOT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, language),
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE
+);
@@ -0,0 +1,49 @@
+-- Enable trigram matching for the translation memory
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Create glossaries table
+CREATE TABLE glossaries (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    display_name VARCHAR(255) NOT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+);
+
+-- Create index to list the glossaries of an organization
+CREATE INDEX glossaries_organization_id_idx ON glossaries (organization_id);
+
+-- Create glossary terms table, a term without a language is not translated
+CREATE TABLE glossary_terms (
+    id UUID PRIMARY KEY,
+    glossary_id UUID NOT NULL,
+    term VARCHAR(255) NOT NULL,
+    language VARCHAR(35) NOT NULL DEFAULT '',
+    translation VARCHAR(255) NOT NULL DEFAULT '',
+    do_not_translate BOOLEAN NOT NULL DEFAULT FALSE,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (glossary_id) REFERENCES glossaries (id) ON DELETE CASCADE
+);
+
+-- Create index to list the terms of a glossary
+CREATE INDEX glossary_terms_glossary_id_idx ON glossary_terms (glossary_id, term);
+
+-- Create glossary segments table, the translation memory of approved translations
+CREATE TABLE glossary_segments (
+    id UUID PRIMARY KEY,
+    glossary_id UUID NOT NULL,
+    source_language VARCHAR(35) NOT NULL,
+    source_text TEXT NOT NULL,
+    target_language VARCHAR(35) NOT NULL,
+    target_text TEXT NOT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (glossary_id) REFERENCES glossaries (id) ON DELETE CASCADE
+);
+
+-- Create index to list the segments of a glossary
+CREATE INDEX glossary_segments_glossary_id_idx ON glossary_segments (glossary_id, create_time);
+
+-- Create trigram index to find segments with a similar source text
+CREATE INDEX glossary_segments_source_text_trgm_idx ON glossary_segments USING GIN (source_text gin_trgm_ops);
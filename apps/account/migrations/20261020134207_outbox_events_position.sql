@@ -0,0 +1,42 @@
+-- Order outbox events by commit. Ids are taken when an event is inserted, so a transaction that
+-- commits later can hold a lower id and readers that follow the ids skip its events.
+CREATE SEQUENCE outbox_events_position_seq;
+
+ALTER TABLE outbox_events ADD COLUMN position BIGINT;
+
+-- Number the existing events in id order
+UPDATE outbox_events e SET position = o.position
+FROM (SELECT id, row_number() OVER (ORDER BY id) AS position FROM outbox_events) o
+WHERE e.id = o.id;
+
+SELECT setval('outbox_events_position_seq', COALESCE(max(position), 0) + 1, false) FROM outbox_events;
+
+CREATE UNIQUE INDEX outbox_events_position_idx ON outbox_events (position);
+
+-- The relay publishes the events in position order
+DROP INDEX outbox_events_unpublished_idx;
+CREATE INDEX outbox_events_unpublished_idx ON outbox_events (position) WHERE publish_time IS NULL;
+
+-- Stamp the position of an event when its transaction commits and notify listeners about it, the
+-- payload is the position. The advisory lock is held until the transaction ends, so the next
+-- transaction takes its positions after this one is visible: once a position is visible, all
+-- lower positions are visible too. The lock key is "outp".
+CREATE FUNCTION stamp_outbox_event_position() RETURNS trigger AS $$
+DECLARE
+    p BIGINT;
+BEGIN
+    PERFORM pg_advisory_xact_lock(1869968496);
+    p := nextval('outbox_events_position_seq');
+    UPDATE outbox_events SET position = p WHERE id = NEW.id;
+    PERFORM pg_notify('outbox_events', p::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER outbox_events_position AFTER INSERT ON outbox_events
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION stamp_outbox_event_position();
+
+-- The position trigger notifies listeners instead
+DROP TRIGGER outbox_events_notify ON outbox_events;
+DROP FUNCTION notify_outbox_event();
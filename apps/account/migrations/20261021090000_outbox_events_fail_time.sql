@@ -0,0 +1,6 @@
+-- Park the events the relay gave up on, so the later events of their aggregates are published
+ALTER TABLE outbox_events ADD COLUMN fail_time TIMESTAMP;
+
+-- The relay only publishes the events that are neither published nor parked
+DROP INDEX outbox_events_unpublished_idx;
+CREATE INDEX outbox_events_unpublished_idx ON outbox_events (position) WHERE publish_time IS NULL AND fail_time IS NULL;
@@ -0,0 +1,7 @@
+-- Copy of migrations/002_jobs_unique_waiting.sql of pkg/jobs, see TestJobsMigrations.
+-- Recreate the unique index so a unique job can wait while another job with the key runs
+DROP INDEX jobs_unique_key_idx;
+CREATE UNIQUE INDEX jobs_unique_key_idx ON jobs (unique_key) WHERE state = 'available';
+
+-- Create index to hold a waiting unique job back while another job with the key runs
+CREATE INDEX jobs_running_unique_key_idx ON jobs (unique_key) WHERE state = 'running';
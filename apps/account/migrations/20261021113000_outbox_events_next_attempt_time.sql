@@ -0,0 +1,6 @@
+-- Back off between the attempts to publish an event
+ALTER TABLE outbox_events ADD COLUMN next_attempt_time TIMESTAMP;
+
+-- The relay lists the parked events as well, they hold back the later events of their aggregates
+DROP INDEX outbox_events_unpublished_idx;
+CREATE INDEX outbox_events_unpublished_idx ON outbox_events (position) WHERE publish_time IS NULL;
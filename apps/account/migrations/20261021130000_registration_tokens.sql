@@ -0,0 +1,10 @@
+-- Create registration_tokens table, it keeps the used registration tokens until they expire so each is used once
+CREATE TABLE registration_tokens (
+    id UUID PRIMARY KEY,
+    email VARCHAR(128) NOT NULL,
+    use_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expire_time TIMESTAMP NOT NULL
+);
+
+-- Create index to purge the expired registration tokens
+CREATE INDEX registration_tokens_expire_time_idx ON registration_tokens (expire_time);
@@ -0,0 +1,42 @@
+-- Order the changes to messages by commit. Sequences are taken when a message is inserted, so a
+-- transaction that commits later can hold a lower sequence, and edits and deletes keep the sequence
+-- of the message. A chat stream that resumes after a sequence misses both.
+CREATE SEQUENCE messages_position_seq;
+
+-- create_position is the position of the commit that created the message, position the position of
+-- the last commit that changed it. Both are 0 until the transaction commits.
+ALTER TABLE messages ADD COLUMN create_position BIGINT NOT NULL DEFAULT 0,
+                     ADD COLUMN position BIGINT NOT NULL DEFAULT 0;
+
+-- Number the existing messages in sequence order
+UPDATE messages m SET create_position = o.position, position = o.position
+FROM (SELECT id, row_number() OVER (ORDER BY sequence) AS position FROM messages) o
+WHERE m.id = o.id;
+
+SELECT setval('messages_position_seq', COALESCE(max(position), 0) + 1, false) FROM messages;
+
+-- Create index to replay the changes to the messages of a conversation
+CREATE INDEX messages_conversation_id_position_idx ON messages (conversation_id, position);
+
+-- Stamp the position of a message when its transaction commits. Like the outbox event positions the
+-- advisory lock is held until the transaction ends, so once a position is visible, all lower
+-- positions are visible too. The lock key is "msgp".
+CREATE FUNCTION stamp_message_position() RETURNS trigger AS $$
+DECLARE
+    p BIGINT;
+BEGIN
+    PERFORM pg_advisory_xact_lock(1836279664);
+    p := nextval('messages_position_seq');
+    IF TG_OP = 'INSERT' THEN
+        UPDATE messages SET create_position = p, position = p WHERE id = NEW.id;
+    ELSE
+        UPDATE messages SET position = p WHERE id = NEW.id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The trigger only follows the columns a change writes, so its own update does not fire it again
+CREATE CONSTRAINT TRIGGER messages_position AFTER INSERT OR UPDATE OF body, language, edit_time, delete_time, update_time ON messages
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION stamp_message_position();
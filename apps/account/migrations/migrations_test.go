@@ -0,0 +1,63 @@
+package migrations_test
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/migrations"
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+// TestJobsMigrations checks that every migration of the job queue is copied into a migration of the
+// account app with the same name after the version.
+func TestJobsMigrations(t *testing.T) {
+	own, err := fs.Glob(migrations.FS, "*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := fs.ReadDir(jobs.Migrations, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range entries {
+		t.Run(e.Name(), func(t *testing.T) {
+			want, err := fs.ReadFile(jobs.Migrations, path.Join("migrations", e.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, name, _ := strings.Cut(e.Name(), "_")
+			var found []string
+			for _, f := range own {
+				if _, ownName, _ := strings.Cut(f, "_"); ownName == name {
+					found = append(found, f)
+				}
+			}
+
+			if len(found) != 1 {
+				t.Fatalf("expected one migration named %s, got %v", name, found)
+			}
+
+			got, err := fs.ReadFile(migrations.FS, found[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stripHeader(string(got)) != stripHeader(string(want)) {
+				t.Errorf("expected %s to be a copy of %s of pkg/jobs", found[0], e.Name())
+			}
+		})
+	}
+}
+
+// stripHeader removes the comment that says where a migration is copied from.
+func stripHeader(s string) string {
+	if strings.HasPrefix(s, "-- Copy of ") {
+		_, s, _ = strings.Cut(s, "\n")
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/audit"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const requestIDMetadataKey = "x-request-id"
+
+// RequestInterceptor stores the source of a request in the context, so audit events can refer to it.
+// It also makes the request read its own writes when reads go to read replicas.
+// A request id is generated when the caller does not provide one, and is returned as a header.
+func (s *Server) RequestInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(s.requestContext(ctx), req)
+	}
+}
+
+// RequestStreamInterceptor is the RequestInterceptor for streaming methods.
+func (s *Server) RequestStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: s.requestContext(ss.Context())})
+	}
+}
+
+// requestContext returns the context with the source of the request.
+func (s *Server) requestContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := firstMetadataValue(md, requestIDMetadataKey)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	// the header can not be set when the interceptor is called outside of a grpc server, such as in tests.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+
+	ctx = database.NewReadYourWritesContext(ctx)
+
+	return audit.NewContext(ctx, audit.Source{
+		RequestID: requestID,
+		IPAddress: s.clientFromContext(ctx).IPAddress,
+	})
+}
+
+func (s *Server) ListAuditEvents(ctx context.Context, req *protoaccount.ListAuditEventsRequest) (*protoaccount.ListAuditEventsResponse, error) {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	before, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, grpcerrors.NewFieldViolationErr("invalid page token", []grpcerrors.FieldViolation{
+			{
+				Field:       "page_token",
+				Description: "page token is malformed",
+			},
+		})
+	}
+
+	q := audit.ListQuery{
+		OrganizationID: orgID,
+		PageSize:       int(req.GetPageSize()),
+		Before:         before,
+	}
+	if req.GetStartTime() != nil {
+		q.Start = req.GetStartTime().AsTime()
+	}
+	if req.GetEndTime() != nil {
+		q.End = req.GetEndTime().AsTime()
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	events, next, err := s.account.ListAuditEvents(ctx, p, q)
+	if err != nil {
+		if errors.Is(err, app.ErrPermissionDenied) {
+			return nil, grpcerrors.NewPermissionDeniedErr("not allowed to read the audit log of this organization")
+		}
+		return nil, err
+	}
+
+	out := make([]*protoaccount.AuditEvent, 0, len(events))
+	for _, event := range events {
+		var eventOut protoaccount.AuditEvent
+		if err = event.ToProto(&eventOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &eventOut)
+	}
+
+	return &protoaccount.ListAuditEventsResponse{
+		AuditEvents:   out,
+		NextPageToken: encodePageToken(next),
+	}, nil
+}
+
+// encodePageToken encodes the id to continue listing from, 0 means there is no next page.
+func encodePageToken(before int64) string {
+	if before == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(before, 10)))
+}
+
+// decodePageToken decodes a token created by encodePageToken. An empty token starts at the first page.
+func decodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+
+	before, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || before <= 0 {
+		return 0, errors.New("invalid page token")
+	}
+
+	return before, nil
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	tokenMetadataKey             = "token"              // tokenMetadataKey is set by the gateway from the token cookie.
+	refreshTokenMetadataKey      = "refresh_token"      // refreshTokenMetadataKey is set by the gateway from the refresh_token cookie.
+	registrationTokenMetadataKey = "registration_token" // registrationTokenMetadataKey is set by the relay.
+	authorizationHeader          = "authorization"
+	bearerPrefix                 = "Bearer "
+)
+
+// publicMethods can be called without an access token.
+var publicMethods = map[string]struct{}{
+	protoaccount.AccountService_LoginUser_FullMethodName:    {},
+	protoaccount.AccountService_RefreshToken_FullMethodName: {},
+}
+
+// registrationMethods can be called with a registration token instead of an access token. The method
+// validates the registration token itself.
+var registrationMethods = map[string]struct{}{
+	protoaccount.AccountService_CreateUser_FullMethodName: {},
+}
+
+// requiresAuthentication reports whether the method needs a valid access token.
+// Only account service methods are protected, so health checks and reflection stay reachable.
+func requiresAuthentication(fullMethod string) bool {
+	if !strings.HasPrefix(fullMethod, "/"+protoaccount.AccountService_ServiceDesc.ServiceName+"/") {
+		return false
+	}
+
+	_, ok := publicMethods[fullMethod]
+	return !ok
+}
+
+// AuthInterceptor validates the access token of protected methods and stores the principal in the context.
+func (s *Server) AuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := s.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor is the AuthInterceptor for streaming methods.
+func (s *Server) AuthStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate validates the access token of a protected method and returns the context with the principal.
+func (s *Server) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if !requiresAuthentication(fullMethod) {
+		return ctx, nil
+	}
+
+	accessToken := accessTokenFromContext(ctx)
+	if accessToken == "" {
+		if _, ok := registrationMethods[fullMethod]; ok && registrationTokenFromContext(ctx) != "" {
+			return ctx, nil
+		}
+		return nil, grpcerrors.NewUnauthenticatedErr("access token is required")
+	}
+
+	p, err := s.account.Authenticate(ctx, accessToken)
+	if err != nil {
+		if errors.Is(err, app.ErrInvalidToken) {
+			return nil, grpcerrors.NewUnauthenticatedErr("access token is invalid")
+		}
+		return nil, err
+	}
+
+	return authentication.NewContext(ctx, p), nil
+}
+
+// serverStream is a grpc.ServerStream with a different context, so stream interceptors can add values to it.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// firstMetadataValue returns the first value of the metadata key, or an empty string.
+func firstMetadataValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// accessTokenFromContext reads the access token from the token metadata or the authorization header.
+func accessTokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if t := firstMetadataValue(md, tokenMetadataKey); t != "" {
+		return t
+	}
+
+	if a := firstMetadataValue(md, authorizationHeader); strings.HasPrefix(a, bearerPrefix) {
+		return strings.TrimPrefix(a, bearerPrefix)
+	}
+
+	return ""
+}
+
+// registrationTokenFromContext reads the registration token from the metadata.
+func registrationTokenFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return firstMetadataValue(md, registrationTokenMetadataKey)
+}
+
+// clientFromContext describes the device a request originates from.
+// Requests through the gateway carry the original user agent and address as metadata.
+func (s *Server) clientFromContext(ctx context.Context) app.Client {
+	var c app.Client
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	c.UserAgent = firstMetadataValue(md, "grpcgateway-user-agent")
+	if c.UserAgent == "" {
+		c.UserAgent = firstMetadataValue(md, "user-agent")
+	}
+
+	c.IPAddress = s.clientAddress(ctx, md)
+	return c
+}
+
+// clientAddress returns the address of the client. The x-forwarded-for metadata is only honoured
+// when it is set by a trusted proxy, it is read from the right so a client can not spoof its address
+// by sending the header itself.
+func (s *Server) clientAddress(ctx context.Context, md metadata.MD) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	var hops []string
+	for _, v := range md.Get("x-forwarded-for") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+
+	for i := len(hops) - 1; i >= 0 && s.trustedProxy(addr); i-- {
+		addr = strings.TrimSpace(hops[i])
+	}
+
+	return addr
+}
+
+// trustedProxy reports whether the address belongs to a trusted proxy.
+func (s *Server) trustedProxy(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+
+	ip = ip.Unmap()
+	for _, p := range s.trustedProxies {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseTrustedProxies parses the addresses and networks, such as 10.0.0.1 or 10.0.0.0/8, of the proxies
+// that may forward the address of a client.
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		if strings.Contains(v, "/") {
+			p, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+
+		ip, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+		}
+		ip = ip.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+
+	return prefixes, nil
+}
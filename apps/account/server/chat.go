@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/conversation"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) Connect(stream protoaccount.AccountService_ConnectServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// the requests are received on their own goroutine, all responses are sent by the app.
+	requests := make(chan app.ChatRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(requests)
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					recvErr <- err
+				}
+				return
+			}
+
+			req, err := s.chatRequest(in)
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	p, _ := authentication.FromContext(ctx)
+	err := s.account.Chat(ctx, p, requests, func(e app.ChatEvent) error {
+		out, err := chatResponse(p.OrganizationID, e)
+		if err != nil {
+			return err
+		}
+		return stream.Send(out)
+	})
+
+	if err == nil {
+		select {
+		case err = <-recvErr:
+		default:
+		}
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, app.ErrChatLagged):
+		return status.Error(codes.Aborted, "the stream fell behind, resume after the last message")
+	case errors.Is(err, app.ErrChatStopped):
+		return status.Error(codes.Unavailable, "the server is shutting down, resume after the last message")
+	case errors.Is(err, app.ErrChatIdle):
+		return status.Error(codes.DeadlineExceeded, "no request was received within two heartbeats")
+	default:
+		return conversationError(err)
+	}
+}
+
+// chatRequest parses a request of the Connect stream.
+func (s *Server) chatRequest(in *protoaccount.ConnectRequest) (app.ChatRequest, error) {
+	switch r := in.GetRequest().(type) {
+	case *protoaccount.ConnectRequest_Subscribe_:
+		req, err := s.chatConversations(app.ChatSubscribe, "subscribe.conversations", r.Subscribe.GetConversations())
+		if err != nil {
+			return app.ChatRequest{}, err
+		}
+
+		if name := r.Subscribe.GetResumeAfter(); name != "" {
+			orgID, _, messageID, err := s.parseMessageName("subscribe.resume_after", name)
+			if err != nil {
+				return app.ChatRequest{}, err
+			}
+
+			if orgID != req.OrganizationID {
+				return app.ChatRequest{}, invalidNameErr("subscribe.resume_after", name)
+			}
+			req.MessageID = messageID
+		}
+
+		return req, nil
+	case *protoaccount.ConnectRequest_Unsubscribe_:
+		return s.chatConversations(app.ChatUnsubscribe, "unsubscribe.conversations", r.Unsubscribe.GetConversations())
+	case *protoaccount.ConnectRequest_Typing_:
+		orgID, conversationID, err := s.parseConversationName("typing.conversation", r.Typing.GetConversation())
+		if err != nil {
+			return app.ChatRequest{}, err
+		}
+
+		return app.ChatRequest{
+			Type:           app.ChatTyping,
+			OrganizationID: orgID,
+			ConversationID: conversationID,
+		}, nil
+	case *protoaccount.ConnectRequest_MarkRead_:
+		orgID, conversationID, messageID, err := s.parseMessageName("mark_read.message", r.MarkRead.GetMessage())
+		if err != nil {
+			return app.ChatRequest{}, err
+		}
+
+		return app.ChatRequest{
+			Type:           app.ChatMarkRead,
+			OrganizationID: orgID,
+			ConversationID: conversationID,
+			MessageID:      messageID,
+		}, nil
+	case *protoaccount.ConnectRequest_Ping_:
+		return app.ChatRequest{Type: app.ChatPing}, nil
+	default:
+		return app.ChatRequest{}, grpcerrors.NewFieldViolationErr("request", []grpcerrors.FieldViolation{
+			{
+				Field:       "request",
+				Description: "request is required",
+			},
+		})
+	}
+}
+
+// chatConversations parses the conversation names of a (un)subscribe request, they must belong to one organization.
+func (s *Server) chatConversations(t app.ChatRequestType, field string, names []string) (app.ChatRequest, error) {
+	if len(names) == 0 {
+		return app.ChatRequest{}, grpcerrors.NewFieldViolationErr(field, []grpcerrors.FieldViolation{
+			{
+				Field:       field,
+				Description: "at least one conversation is required",
+			},
+		})
+	}
+
+	req := app.ChatRequest{Type: t, ConversationIDs: make([]uuid.UUID, 0, len(names))}
+	for i, name := range names {
+		orgID, conversationID, err := s.parseConversationName(field, name)
+		if err != nil {
+			return app.ChatRequest{}, err
+		}
+
+		if i == 0 {
+			req.OrganizationID = orgID
+		} else if orgID != req.OrganizationID {
+			return app.ChatRequest{}, invalidNameErr(field, name)
+		}
+
+		req.ConversationIDs = append(req.ConversationIDs, conversationID)
+	}
+
+	return req, nil
+}
+
+// chatResponse maps an app.ChatEvent to a response of the Connect stream.
+func chatResponse(organizationID uuid.UUID, e app.ChatEvent) (*protoaccount.ConnectResponse, error) {
+	switch e.Type {
+	case app.ChatEventSubscribed:
+		names := make([]string, 0, len(e.ConversationIDs))
+		for _, id := range e.ConversationIDs {
+			names = append(names, domain.ConversationName(organizationID, id))
+		}
+
+		return &protoaccount.ConnectResponse{Event: &protoaccount.ConnectResponse_Subscribed_{
+			Subscribed: &protoaccount.ConnectResponse_Subscribed{Conversations: names},
+		}}, nil
+	case app.ChatEventHeartbeat:
+		return &protoaccount.ConnectResponse{Event: &protoaccount.ConnectResponse_Heartbeat_{
+			Heartbeat: &protoaccount.ConnectResponse_Heartbeat{Time: timestamppb.New(e.Time)},
+		}}, nil
+	case app.ChatEventConversation:
+		return conversationEventResponse(organizationID, e.Event)
+	default:
+		return nil, errors.New("unknown chat event type")
+	}
+}
+
+// conversationEventResponse maps an event of a conversation to a response of the Connect stream.
+func conversationEventResponse(organizationID uuid.UUID, e conversation.Event) (*protoaccount.ConnectResponse, error) {
+	switch e.Type {
+	case conversation.EventMessageCreated, conversation.EventMessageUpdated, conversation.EventMessageDeleted:
+		var message protoaccount.Message
+		if err := e.Message.ToProto(organizationID, &message); err != nil {
+			return nil, err
+		}
+
+		return &protoaccount.ConnectResponse{Event: &protoaccount.ConnectResponse_MessageChange_{
+			MessageChange: &protoaccount.ConnectResponse_MessageChange{
+				ChangeType: messageChangeType(e.Type),
+				Message:    &message,
+			},
+		}}, nil
+	case conversation.EventTyping:
+		return &protoaccount.ConnectResponse{Event: &protoaccount.ConnectResponse_Typing_{
+			Typing: &protoaccount.ConnectResponse_Typing{
+				Conversation: domain.ConversationName(organizationID, e.ConversationID),
+				User:         domain.UserName(organizationID, e.UserID),
+			},
+		}}, nil
+	case conversation.EventRead:
+		return &protoaccount.ConnectResponse{Event: &protoaccount.ConnectResponse_ReadReceipt_{
+			ReadReceipt: &protoaccount.ConnectResponse_ReadReceipt{
+				Message: domain.MessageName(organizationID, e.ConversationID, e.MessageID),
+				User:    domain.UserName(organizationID, e.UserID),
+			},
+		}}, nil
+	default:
+		return nil, errors.New("unknown conversation event type")
+	}
+}
+
+// messageChangeType maps a message event type to its proto enum.
+func messageChangeType(t conversation.EventType) protoaccount.ConnectResponse_MessageChange_ChangeType {
+	switch t {
+	case conversation.EventMessageCreated:
+		return protoaccount.ConnectResponse_MessageChange_CHANGE_TYPE_CREATED
+	case conversation.EventMessageUpdated:
+		return protoaccount.ConnectResponse_MessageChange_CHANGE_TYPE_UPDATED
+	case conversation.EventMessageDeleted:
+		return protoaccount.ConnectResponse_MessageChange_CHANGE_TYPE_DELETED
+	default:
+		return protoaccount.ConnectResponse_MessageChange_CHANGE_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,358 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	"github.com/extreme-business/lingo/pkg/validate"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+)
+
+func (s *Server) CreateConversation(ctx context.Context, req *protoaccount.CreateConversationRequest) (*protoaccount.CreateConversationResponse, error) {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	in := req.GetConversation()
+	if in == nil {
+		return nil, grpcerrors.NewFieldViolationErr("conversation", []grpcerrors.FieldViolation{
+			{
+				Field:       "conversation",
+				Description: "conversation is required",
+			},
+		})
+	}
+
+	participants := make([]uuid.UUID, 0, len(in.GetParticipants()))
+	for _, name := range in.GetParticipants() {
+		userOrgID, userID, err := s.parseUserName("conversation.participants", name)
+		if err != nil {
+			return nil, err
+		}
+
+		if userOrgID != orgID {
+			return nil, grpcerrors.NewFieldViolationErr("invalid participant", []grpcerrors.FieldViolation{
+				{
+					Field:       "conversation.participants",
+					Description: fmt.Sprintf("%q is not a user of %q", name, req.GetParent()),
+				},
+			})
+		}
+
+		participants = append(participants, userID)
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	c, err := s.account.CreateConversation(ctx, p, &domain.Conversation{
+		OrganizationID: orgID,
+		Type:           domain.ConversationType(in.GetType()),
+		Title:          in.GetTitle(),
+		Participants:   participants,
+	})
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	var out protoaccount.Conversation
+	if err = c.ToProto(&out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.CreateConversationResponse{
+		Conversation: &out,
+	}, nil
+}
+
+func (s *Server) ListConversations(ctx context.Context, req *protoaccount.ListConversationsRequest) (*protoaccount.ListConversationsResponse, error) {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, grpcerrors.NewFieldViolationErr("invalid page token", []grpcerrors.FieldViolation{
+			{
+				Field:       "page_token",
+				Description: "page token is malformed",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	conversations, next, err := s.account.ListConversations(ctx, p, orgID, int(req.GetPageSize()), int(offset))
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	out := make([]*protoaccount.Conversation, 0, len(conversations))
+	for _, c := range conversations {
+		var conversationOut protoaccount.Conversation
+		if err = c.ToProto(&conversationOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &conversationOut)
+	}
+
+	return &protoaccount.ListConversationsResponse{
+		Conversations: out,
+		NextPageToken: encodePageToken(int64(next)),
+	}, nil
+}
+
+func (s *Server) GetConversation(ctx context.Context, req *protoaccount.GetConversationRequest) (*protoaccount.GetConversationResponse, error) {
+	orgID, conversationID, err := s.parseConversationName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	c, err := s.account.GetConversation(ctx, p, orgID, conversationID)
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	var out protoaccount.Conversation
+	if err = c.ToProto(&out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.GetConversationResponse{
+		Conversation: &out,
+	}, nil
+}
+
+func (s *Server) CreateMessage(ctx context.Context, req *protoaccount.CreateMessageRequest) (*protoaccount.CreateMessageResponse, error) {
+	orgID, conversationID, err := s.parseConversationName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	in := req.GetMessage()
+	if in == nil {
+		return nil, grpcerrors.NewFieldViolationErr("message", []grpcerrors.FieldViolation{
+			{
+				Field:       "message",
+				Description: "message is required",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	m, err := s.account.CreateMessage(ctx, p, orgID, conversationID, in.GetBody())
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	var out protoaccount.Message
+	if err = m.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.CreateMessageResponse{
+		Message: &out,
+	}, nil
+}
+
+func (s *Server) ListMessages(ctx context.Context, req *protoaccount.ListMessagesRequest) (*protoaccount.ListMessagesResponse, error) {
+	orgID, conversationID, err := s.parseConversationName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, grpcerrors.NewFieldViolationErr("invalid page token", []grpcerrors.FieldViolation{
+			{
+				Field:       "page_token",
+				Description: "page token is malformed",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	messages, next, err := s.account.ListMessages(ctx, p, orgID, conversationID, int(req.GetPageSize()), before, req.GetLanguage())
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	out := make([]*protoaccount.Message, 0, len(messages))
+	for _, m := range messages {
+		var messageOut protoaccount.Message
+		if err = m.ToProto(orgID, &messageOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &messageOut)
+	}
+
+	return &protoaccount.ListMessagesResponse{
+		Messages:      out,
+		NextPageToken: encodePageToken(next),
+	}, nil
+}
+
+func (s *Server) GetMessage(ctx context.Context, req *protoaccount.GetMessageRequest) (*protoaccount.GetMessageResponse, error) {
+	orgID, conversationID, messageID, err := s.parseMessageName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	m, err := s.account.GetMessage(ctx, p, orgID, conversationID, messageID, req.GetLanguage())
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	var out protoaccount.Message
+	if err = m.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.GetMessageResponse{
+		Message: &out,
+	}, nil
+}
+
+func (s *Server) UpdateMessage(ctx context.Context, req *protoaccount.UpdateMessageRequest) (*protoaccount.UpdateMessageResponse, error) {
+	in := req.GetMessage()
+	orgID, conversationID, messageID, err := s.parseMessageName("message.name", in.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) != 1 || paths[0] != "body" {
+		return nil, grpcerrors.NewFieldViolationErr("invalid update mask", []grpcerrors.FieldViolation{
+			{
+				Field:       "update_mask",
+				Description: `only "body" can be updated`,
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	m, err := s.account.UpdateMessage(ctx, p, orgID, conversationID, messageID, in.GetBody())
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	var out protoaccount.Message
+	if err = m.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.UpdateMessageResponse{
+		Message: &out,
+	}, nil
+}
+
+func (s *Server) DeleteMessage(ctx context.Context, req *protoaccount.DeleteMessageRequest) (*protoaccount.DeleteMessageResponse, error) {
+	orgID, conversationID, messageID, err := s.parseMessageName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	m, err := s.account.DeleteMessage(ctx, p, orgID, conversationID, messageID)
+	if err != nil {
+		return nil, conversationError(err)
+	}
+
+	var out protoaccount.Message
+	if err = m.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.DeleteMessageResponse{
+		Message: &out,
+	}, nil
+}
+
+// parseConversationName parses the organization and conversation ids from a resource name
+// such as "organizations/1/conversations/2" or one of its children.
+func (s *Server) parseConversationName(field, name string) (uuid.UUID, uuid.UUID, error) {
+	r, err := s.resourceParser.Parse(name)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	org := r.Find(domain.OrganizationCollection)
+	conversation := r.Find(domain.ConversationCollection)
+	if org == nil || conversation == nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	orgID, err := org.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	conversationID, err := conversation.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	return orgID, conversationID, nil
+}
+
+// parseMessageName parses the organization, conversation and message ids from a resource name
+// such as "organizations/1/conversations/2/messages/3".
+func (s *Server) parseMessageName(field, name string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	r, err := s.resourceParser.Parse(name)
+	if err != nil || r.CollectionID != domain.MessageCollection {
+		return uuid.Nil, uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	messageID, err := r.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	orgID, conversationID, err := s.parseConversationName(field, name)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	return orgID, conversationID, messageID, nil
+}
+
+// conversationError maps app errors of the conversation operations to gRPC errors.
+func conversationError(err error) error {
+	var vErr *validate.Error
+	switch {
+	case errors.As(err, &vErr):
+		return grpcerrors.NewFieldViolationErr("validation error", []grpcerrors.FieldViolation{
+			{
+				Field:       vErr.Field(),
+				Description: vErr.Error(),
+			},
+		})
+	case errors.Is(err, app.ErrPermissionDenied):
+		return grpcerrors.NewPermissionDeniedErr("not allowed to access this conversation")
+	case errors.Is(err, app.ErrConversationNotFound):
+		return grpcerrors.NewNotFoundErr("conversation not found")
+	case errors.Is(err, app.ErrMessageNotFound):
+		return grpcerrors.NewNotFoundErr("message not found")
+	case errors.Is(err, app.ErrMessageDeleted):
+		return grpcerrors.NewFailedPreconditionErr("the message is deleted")
+	default:
+		return err
+	}
+}
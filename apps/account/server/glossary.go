@@ -0,0 +1,549 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/apps/account/domain/glossary"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	"github.com/extreme-business/lingo/pkg/validate"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+)
+
+func (s *Server) CreateGlossary(ctx context.Context, req *protoaccount.CreateGlossaryRequest) (*protoaccount.CreateGlossaryResponse, error) {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	in := req.GetGlossary()
+	if in == nil {
+		return nil, grpcerrors.NewFieldViolationErr("glossary", []grpcerrors.FieldViolation{
+			{
+				Field:       "glossary",
+				Description: "glossary is required",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	g, err := s.account.CreateGlossary(ctx, p, &domain.Glossary{
+		OrganizationID: orgID,
+		DisplayName:    in.GetDisplayName(),
+	})
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	var out protoaccount.Glossary
+	if err = g.ToProto(&out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.CreateGlossaryResponse{
+		Glossary: &out,
+	}, nil
+}
+
+func (s *Server) ListGlossaries(ctx context.Context, req *protoaccount.ListGlossariesRequest) (*protoaccount.ListGlossariesResponse, error) {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	glossaries, err := s.account.ListGlossaries(ctx, p, orgID)
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	out := make([]*protoaccount.Glossary, 0, len(glossaries))
+	for _, g := range glossaries {
+		var glossaryOut protoaccount.Glossary
+		if err = g.ToProto(&glossaryOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &glossaryOut)
+	}
+
+	return &protoaccount.ListGlossariesResponse{
+		Glossaries: out,
+	}, nil
+}
+
+func (s *Server) GetGlossary(ctx context.Context, req *protoaccount.GetGlossaryRequest) (*protoaccount.GetGlossaryResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	g, err := s.account.GetGlossary(ctx, p, orgID, glossaryID)
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	var out protoaccount.Glossary
+	if err = g.ToProto(&out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.GetGlossaryResponse{
+		Glossary: &out,
+	}, nil
+}
+
+func (s *Server) UpdateGlossary(ctx context.Context, req *protoaccount.UpdateGlossaryRequest) (*protoaccount.UpdateGlossaryResponse, error) {
+	in := req.GetGlossary()
+	orgID, glossaryID, err := s.parseGlossaryName("glossary.name", in.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	u := app.GlossaryUpdate{
+		OrganizationID: orgID,
+		GlossaryID:     glossaryID,
+	}
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "display_name":
+			displayName := in.GetDisplayName()
+			u.DisplayName = &displayName
+		default:
+			return nil, invalidUpdateMaskErr(path)
+		}
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	g, err := s.account.UpdateGlossary(ctx, p, u)
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	var out protoaccount.Glossary
+	if err = g.ToProto(&out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.UpdateGlossaryResponse{
+		Glossary: &out,
+	}, nil
+}
+
+func (s *Server) DeleteGlossary(ctx context.Context, req *protoaccount.DeleteGlossaryRequest) (*protoaccount.DeleteGlossaryResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	if err = s.account.DeleteGlossary(ctx, p, orgID, glossaryID); err != nil {
+		return nil, glossaryError(err)
+	}
+
+	return &protoaccount.DeleteGlossaryResponse{}, nil
+}
+
+func (s *Server) CreateGlossaryTerm(ctx context.Context, req *protoaccount.CreateGlossaryTermRequest) (*protoaccount.CreateGlossaryTermResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	in := req.GetTerm()
+	if in == nil {
+		return nil, grpcerrors.NewFieldViolationErr("term", []grpcerrors.FieldViolation{
+			{
+				Field:       "term",
+				Description: "term is required",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	t, err := s.account.CreateGlossaryTerm(ctx, p, orgID, &domain.GlossaryTerm{
+		GlossaryID:     glossaryID,
+		Term:           in.GetTerm(),
+		Language:       in.GetLanguage(),
+		Translation:    in.GetTranslation(),
+		DoNotTranslate: in.GetDoNotTranslate(),
+	})
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	var out protoaccount.GlossaryTerm
+	if err = t.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.CreateGlossaryTermResponse{
+		Term: &out,
+	}, nil
+}
+
+func (s *Server) ListGlossaryTerms(ctx context.Context, req *protoaccount.ListGlossaryTermsRequest) (*protoaccount.ListGlossaryTermsResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, invalidPageTokenErr()
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	terms, next, err := s.account.ListGlossaryTerms(ctx, p, orgID, glossaryID, int(req.GetPageSize()), int(offset))
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	out := make([]*protoaccount.GlossaryTerm, 0, len(terms))
+	for _, t := range terms {
+		var termOut protoaccount.GlossaryTerm
+		if err = t.ToProto(orgID, &termOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &termOut)
+	}
+
+	return &protoaccount.ListGlossaryTermsResponse{
+		Terms:         out,
+		NextPageToken: encodePageToken(int64(next)),
+	}, nil
+}
+
+func (s *Server) UpdateGlossaryTerm(ctx context.Context, req *protoaccount.UpdateGlossaryTermRequest) (*protoaccount.UpdateGlossaryTermResponse, error) {
+	in := req.GetTerm()
+	orgID, glossaryID, termID, err := s.parseGlossaryChildName("term.name", in.GetName(), domain.GlossaryTermCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	u := app.GlossaryTermUpdate{
+		OrganizationID: orgID,
+		GlossaryID:     glossaryID,
+		TermID:         termID,
+	}
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "term":
+			term := in.GetTerm()
+			u.Term = &term
+		case "language":
+			language := in.GetLanguage()
+			u.Language = &language
+		case "translation":
+			translation := in.GetTranslation()
+			u.Translation = &translation
+		case "do_not_translate":
+			doNotTranslate := in.GetDoNotTranslate()
+			u.DoNotTranslate = &doNotTranslate
+		default:
+			return nil, invalidUpdateMaskErr(path)
+		}
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	t, err := s.account.UpdateGlossaryTerm(ctx, p, u)
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	var out protoaccount.GlossaryTerm
+	if err = t.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.UpdateGlossaryTermResponse{
+		Term: &out,
+	}, nil
+}
+
+func (s *Server) DeleteGlossaryTerm(ctx context.Context, req *protoaccount.DeleteGlossaryTermRequest) (*protoaccount.DeleteGlossaryTermResponse, error) {
+	orgID, glossaryID, termID, err := s.parseGlossaryChildName("name", req.GetName(), domain.GlossaryTermCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	if err = s.account.DeleteGlossaryTerm(ctx, p, orgID, glossaryID, termID); err != nil {
+		return nil, glossaryError(err)
+	}
+
+	return &protoaccount.DeleteGlossaryTermResponse{}, nil
+}
+
+func (s *Server) CreateSegment(ctx context.Context, req *protoaccount.CreateSegmentRequest) (*protoaccount.CreateSegmentResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	in := req.GetSegment()
+	if in == nil {
+		return nil, grpcerrors.NewFieldViolationErr("segment", []grpcerrors.FieldViolation{
+			{
+				Field:       "segment",
+				Description: "segment is required",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	seg, err := s.account.CreateSegment(ctx, p, orgID, &domain.GlossarySegment{
+		GlossaryID:     glossaryID,
+		SourceLanguage: in.GetSourceLanguage(),
+		SourceText:     in.GetSourceText(),
+		TargetLanguage: in.GetTargetLanguage(),
+		TargetText:     in.GetTargetText(),
+	})
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	var out protoaccount.Segment
+	if err = seg.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.CreateSegmentResponse{
+		Segment: &out,
+	}, nil
+}
+
+func (s *Server) ListSegments(ctx context.Context, req *protoaccount.ListSegmentsRequest) (*protoaccount.ListSegmentsResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, invalidPageTokenErr()
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	segments, next, err := s.account.ListSegments(ctx, p, orgID, glossaryID, int(req.GetPageSize()), int(offset))
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	out := make([]*protoaccount.Segment, 0, len(segments))
+	for _, seg := range segments {
+		var segmentOut protoaccount.Segment
+		if err = seg.ToProto(orgID, &segmentOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &segmentOut)
+	}
+
+	return &protoaccount.ListSegmentsResponse{
+		Segments:      out,
+		NextPageToken: encodePageToken(int64(next)),
+	}, nil
+}
+
+func (s *Server) DeleteSegment(ctx context.Context, req *protoaccount.DeleteSegmentRequest) (*protoaccount.DeleteSegmentResponse, error) {
+	orgID, glossaryID, segmentID, err := s.parseGlossaryChildName("name", req.GetName(), domain.GlossarySegmentCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	if err = s.account.DeleteSegment(ctx, p, orgID, glossaryID, segmentID); err != nil {
+		return nil, glossaryError(err)
+	}
+
+	return &protoaccount.DeleteSegmentResponse{}, nil
+}
+
+func (s *Server) SearchSegments(ctx context.Context, req *protoaccount.SearchSegmentsRequest) (*protoaccount.SearchSegmentsResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	matches, err := s.account.SearchSegments(ctx, p, orgID, glossaryID, glossary.Query{
+		Text:           req.GetText(),
+		SourceLanguage: req.GetSourceLanguage(),
+		TargetLanguage: req.GetTargetLanguage(),
+		MinScore:       req.GetMinScore(),
+		Limit:          int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	out := make([]*protoaccount.SegmentMatch, 0, len(matches))
+	for _, m := range matches {
+		var segmentOut protoaccount.Segment
+		if err = m.Segment.ToProto(orgID, &segmentOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &protoaccount.SegmentMatch{
+			Segment: &segmentOut,
+			Score:   m.Score,
+		})
+	}
+
+	return &protoaccount.SearchSegmentsResponse{
+		Matches: out,
+	}, nil
+}
+
+func (s *Server) ImportGlossary(ctx context.Context, req *protoaccount.ImportGlossaryRequest) (*protoaccount.ImportGlossaryResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	terms, segments, err := s.account.ImportGlossary(ctx, p, orgID, glossaryID, glossaryFormat(req.GetFormat()), req.GetContent())
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	return &protoaccount.ImportGlossaryResponse{
+		TermCount:    int32(terms),
+		SegmentCount: int32(segments),
+	}, nil
+}
+
+func (s *Server) ExportGlossary(ctx context.Context, req *protoaccount.ExportGlossaryRequest) (*protoaccount.ExportGlossaryResponse, error) {
+	orgID, glossaryID, err := s.parseGlossaryName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	content, err := s.account.ExportGlossary(ctx, p, orgID, glossaryID, glossaryFormat(req.GetFormat()))
+	if err != nil {
+		return nil, glossaryError(err)
+	}
+
+	return &protoaccount.ExportGlossaryResponse{
+		Content: content,
+	}, nil
+}
+
+// parseGlossaryName parses the organization and glossary ids from a resource name
+// such as "organizations/1/glossaries/2" or one of its children.
+func (s *Server) parseGlossaryName(field, name string) (uuid.UUID, uuid.UUID, error) {
+	r, err := s.resourceParser.Parse(name)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	org := r.Find(domain.OrganizationCollection)
+	g := r.Find(domain.GlossaryCollection)
+	if org == nil || g == nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	orgID, err := org.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	glossaryID, err := g.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	return orgID, glossaryID, nil
+}
+
+// parseGlossaryChildName parses the organization, glossary and child ids from the resource name of a term or segment,
+// such as "organizations/1/glossaries/2/terms/3".
+func (s *Server) parseGlossaryChildName(field, name, collection string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	r, err := s.resourceParser.Parse(name)
+	if err != nil || r.CollectionID != collection {
+		return uuid.Nil, uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	childID, err := r.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	orgID, glossaryID, err := s.parseGlossaryName(field, name)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	return orgID, glossaryID, childID, nil
+}
+
+// glossaryFormat maps the format of a request, an unknown format is rejected by the app.
+func glossaryFormat(f protoaccount.GlossaryFormat) glossary.Format {
+	switch f {
+	case protoaccount.GlossaryFormat_GLOSSARY_FORMAT_CSV:
+		return glossary.FormatCSV
+	case protoaccount.GlossaryFormat_GLOSSARY_FORMAT_TMX:
+		return glossary.FormatTMX
+	default:
+		return 0
+	}
+}
+
+// invalidUpdateMaskErr returns an error for a field in the update mask that can not be updated.
+func invalidUpdateMaskErr(path string) error {
+	return grpcerrors.NewFieldViolationErr("invalid update mask", []grpcerrors.FieldViolation{
+		{
+			Field:       "update_mask",
+			Description: fmt.Sprintf("field %q can not be updated", path),
+		},
+	})
+}
+
+// invalidPageTokenErr returns an error for a page token that was not created by the server.
+func invalidPageTokenErr() error {
+	return grpcerrors.NewFieldViolationErr("invalid page token", []grpcerrors.FieldViolation{
+		{
+			Field:       "page_token",
+			Description: "page token is malformed",
+		},
+	})
+}
+
+// glossaryError maps app errors of the glossary operations to gRPC errors.
+func glossaryError(err error) error {
+	var vErr *validate.Error
+	switch {
+	case errors.As(err, &vErr):
+		return grpcerrors.NewFieldViolationErr("validation error", []grpcerrors.FieldViolation{
+			{
+				Field:       vErr.Field(),
+				Description: vErr.Error(),
+			},
+		})
+	case errors.Is(err, app.ErrPermissionDenied):
+		return grpcerrors.NewPermissionDeniedErr("not allowed to access the glossaries of this organization")
+	case errors.Is(err, app.ErrGlossaryNotFound):
+		return grpcerrors.NewNotFoundErr("glossary not found")
+	case errors.Is(err, app.ErrGlossaryTermNotFound):
+		return grpcerrors.NewNotFoundErr("glossary term not found")
+	case errors.Is(err, app.ErrGlossarySegmentNotFound):
+		return grpcerrors.NewNotFoundErr("glossary segment not found")
+	default:
+		return err
+	}
+}
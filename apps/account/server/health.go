@@ -8,10 +8,22 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// HealthCheck returns an error when the server can not serve requests, for example because the
+// database schema does not match the migrations of the build.
+type HealthCheck func(ctx context.Context) error
+
 // Server is the health server.
 var _ grpc_health_v1.HealthServer = &Server{}
 
-func (s *Server) Check(_ context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+func (s *Server) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.healthCheck != nil {
+		if err := s.healthCheck(ctx); err != nil {
+			return &grpc_health_v1.HealthCheckResponse{
+				Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			}, nil
+		}
+	}
+
 	return &grpc_health_v1.HealthCheckResponse{
 		Status: grpc_health_v1.HealthCheckResponse_SERVING,
 	}, nil
@@ -3,8 +3,10 @@ package server
 import (
 	"context"
 	"errors"
+	"net/netip"
 
 	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
 	"github.com/extreme-business/lingo/apps/account/domain"
 	"github.com/extreme-business/lingo/pkg/grpcerrors"
 	"github.com/extreme-business/lingo/pkg/resource"
@@ -17,12 +19,19 @@ type Server struct {
 	protoaccount.UnimplementedAccountServiceServer
 	account        *app.App
 	resourceParser *resource.Parser
+	healthCheck    HealthCheck
+	trustedProxies []netip.Prefix
 }
 
-func New(account *app.App, resourceParser *resource.Parser) *Server {
+// New returns the account server. The health check is optional, without it the server is always serving.
+// The address of the client is only taken from the x-forwarded-for metadata of the trusted proxies,
+// such as the gateway, see ParseTrustedProxies.
+func New(account *app.App, resourceParser *resource.Parser, healthCheck HealthCheck, trustedProxies []netip.Prefix) *Server {
 	return &Server{
 		account:        account,
 		resourceParser: resourceParser,
+		healthCheck:    healthCheck,
+		trustedProxies: trustedProxies,
 	}
 }
 
@@ -60,31 +69,91 @@ func (s *Server) CreateUser(ctx context.Context, req *protoaccount.CreateUserReq
 	}
 
 	user, err := s.account.RegisterUser(ctx, app.RegisterUser{
-		OrganizationID: orgID,
-		DisplayName:    userIn.GetDisplayName(),
-		Email:          userIn.GetEmail(),
-		Password:       userIn.GetPassword(),
+		OrganizationID:    orgID,
+		DisplayName:       userIn.GetDisplayName(),
+		Email:             userIn.GetEmail(),
+		Password:          userIn.GetPassword(),
+		PreferredLanguage: userIn.GetPreferredLanguage(),
+		RegistrationToken: registrationTokenFromContext(ctx),
 	})
 	if err != nil {
 		var vErr *validate.Error
-		if errors.As(err, &vErr) {
+		switch {
+		case errors.As(err, &vErr):
 			return nil, grpcerrors.NewFieldViolationErr("validation error", []grpcerrors.FieldViolation{
 				{
 					Field:       vErr.Field(),
 					Description: vErr.Error(),
 				},
 			})
+		case errors.Is(err, app.ErrInvalidRegistrationToken):
+			return nil, grpcerrors.NewUnauthenticatedErr("registration token is invalid or was already used")
+		case errors.Is(err, app.ErrPermissionDenied):
+			return nil, grpcerrors.NewPermissionDeniedErr("not allowed to create users in this organization")
+		default:
+			return nil, err
 		}
+	}
+
+	var userOut protoaccount.User
+	if err = user.ToProto(&userOut); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.CreateUserResponse{
+		User: &userOut,
+	}, nil
+}
 
+func (s *Server) UpdateUser(ctx context.Context, req *protoaccount.UpdateUserRequest) (*protoaccount.UpdateUserResponse, error) {
+	in := req.GetUser()
+	orgID, userID, err := s.parseUserName("user.name", in.GetName())
+	if err != nil {
 		return nil, err
 	}
 
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) != 1 || paths[0] != "preferred_language" {
+		return nil, grpcerrors.NewFieldViolationErr("invalid update mask", []grpcerrors.FieldViolation{
+			{
+				Field:       "update_mask",
+				Description: `only "preferred_language" can be updated`,
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	preferredLanguage := in.GetPreferredLanguage()
+	user, err := s.account.UpdateUser(ctx, p, app.UserUpdate{
+		OrganizationID:    orgID,
+		UserID:            userID,
+		PreferredLanguage: &preferredLanguage,
+	})
+	if err != nil {
+		var vErr *validate.Error
+		switch {
+		case errors.As(err, &vErr):
+			return nil, grpcerrors.NewFieldViolationErr("validation error", []grpcerrors.FieldViolation{
+				{
+					Field:       "user." + vErr.Field(),
+					Description: vErr.Error(),
+				},
+			})
+		case errors.Is(err, app.ErrPermissionDenied):
+			return nil, grpcerrors.NewPermissionDeniedErr("not allowed to update this user")
+		case errors.Is(err, app.ErrUserNotFound):
+			return nil, grpcerrors.NewNotFoundErr("user not found")
+		default:
+			return nil, err
+		}
+	}
+
 	var userOut protoaccount.User
 	if err = user.ToProto(&userOut); err != nil {
 		return nil, err
 	}
 
-	return &protoaccount.CreateUserResponse{
+	return &protoaccount.UpdateUserResponse{
 		User: &userOut,
 	}, nil
 }
@@ -94,11 +163,15 @@ func (s *Server) LoginUser(ctx context.Context, req *protoaccount.LoginUserReque
 		ctx,
 		req.GetEmail(),
 		req.GetPassword(),
+		s.clientFromContext(ctx),
 	)
 	if err != nil {
 		if errors.Is(err, app.ErrUserNotFound) {
 			return nil, grpcerrors.NewNotFoundErr("user not found")
 		}
+		if errors.Is(err, app.ErrInvalidCredentials) {
+			return nil, grpcerrors.NewUnauthenticatedErr("invalid credentials")
+		}
 		return nil, err
 	}
 
@@ -114,10 +187,6 @@ func (s *Server) LoginUser(ctx context.Context, req *protoaccount.LoginUserReque
 	}, nil
 }
 
-func (s *Server) RefreshToken(ctx context.Context, req *protoaccount.RefreshTokenRequest) (*protoaccount.RefreshTokenResponse, error) {
-	return nil, errors.New("not implemented")
-}
-
 func (s *Server) ListUsers(ctx context.Context, req *protoaccount.ListUsersRequest) (*protoaccount.ListUsersResponse, error) {
 	users, err := s.account.ListUsers(ctx, 0)
 	if err != nil {
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) RefreshToken(ctx context.Context, req *protoaccount.RefreshTokenRequest) (*protoaccount.RefreshTokenResponse, error) {
+	refreshToken := req.GetRefreshToken()
+	if refreshToken == "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		refreshToken = firstMetadataValue(md, refreshTokenMetadataKey)
+	}
+
+	if refreshToken == "" {
+		return nil, grpcerrors.NewUnauthenticatedErr("refresh token is required")
+	}
+
+	login, err := s.account.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, app.ErrInvalidToken) {
+			return nil, grpcerrors.NewUnauthenticatedErr("refresh token is invalid")
+		}
+		return nil, err
+	}
+
+	return &protoaccount.RefreshTokenResponse{
+		AccessToken:  login.AccessToken,
+		RefreshToken: login.RefreshToken,
+	}, nil
+}
+
+func (s *Server) LogoutUser(ctx context.Context, _ *protoaccount.LogoutUserRequest) (*protoaccount.LogoutUserResponse, error) {
+	p, ok := authentication.FromContext(ctx)
+	if !ok {
+		return nil, grpcerrors.NewUnauthenticatedErr("access token is required")
+	}
+
+	if err := s.account.LogoutUser(ctx, p); err != nil && !errors.Is(err, app.ErrSessionNotFound) {
+		return nil, err
+	}
+
+	return &protoaccount.LogoutUserResponse{}, nil
+}
+
+func (s *Server) ListSessions(ctx context.Context, req *protoaccount.ListSessionsRequest) (*protoaccount.ListSessionsResponse, error) {
+	orgID, userID, err := s.parseUserName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	sessions, err := s.account.ListSessions(ctx, p, orgID, userID)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+
+	out := make([]*protoaccount.Session, 0, len(sessions))
+	for _, session := range sessions {
+		var sessionOut protoaccount.Session
+		if err = session.ToProto(orgID, &sessionOut); err != nil {
+			return nil, err
+		}
+		sessionOut.Current = p != nil && p.SessionID == session.ID
+		out = append(out, &sessionOut)
+	}
+
+	return &protoaccount.ListSessionsResponse{
+		Sessions: out,
+	}, nil
+}
+
+func (s *Server) RevokeSession(ctx context.Context, req *protoaccount.RevokeSessionRequest) (*protoaccount.RevokeSessionResponse, error) {
+	name, err := s.resourceParser.Parse(req.GetName())
+	if err != nil || name.CollectionID != domain.SessionCollection || name.Parent == nil {
+		return nil, invalidNameErr("name", req.GetName())
+	}
+
+	sessionID, err := name.UUID()
+	if err != nil {
+		return nil, invalidNameErr("name", req.GetName())
+	}
+
+	orgID, userID, err := s.parseUserName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	if err = s.account.RevokeSession(ctx, p, orgID, userID, sessionID); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return &protoaccount.RevokeSessionResponse{}, nil
+}
+
+func (s *Server) RevokeAllSessions(ctx context.Context, req *protoaccount.RevokeAllSessionsRequest) (*protoaccount.RevokeAllSessionsResponse, error) {
+	orgID, userID, err := s.parseUserName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	n, err := s.account.RevokeAllSessions(ctx, p, orgID, userID)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+
+	return &protoaccount.RevokeAllSessionsResponse{
+		RevokedCount: int32(n),
+	}, nil
+}
+
+// parseUserName parses the organization and user ids from a resource name
+// such as "organizations/1/users/2" or one of its children.
+func (s *Server) parseUserName(field, name string) (uuid.UUID, uuid.UUID, error) {
+	r, err := s.resourceParser.Parse(name)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	org := r.Find(domain.OrganizationCollection)
+	user := r.Find(domain.UserCollection)
+	if org == nil || user == nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	orgID, err := org.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	userID, err := user.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	return orgID, userID, nil
+}
+
+// invalidNameErr returns a field violation for a malformed resource name.
+func invalidNameErr(field, name string) error {
+	return grpcerrors.NewFieldViolationErr("invalid resource name", []grpcerrors.FieldViolation{
+		{
+			Field:       field,
+			Description: fmt.Sprintf("%q is not a valid resource name", name),
+		},
+	})
+}
+
+// sessionError maps app errors of the session operations to gRPC errors.
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, app.ErrPermissionDenied):
+		return grpcerrors.NewPermissionDeniedErr("not allowed to manage the sessions of this user")
+	case errors.Is(err, app.ErrUserNotFound):
+		return grpcerrors.NewNotFoundErr("user not found")
+	case errors.Is(err, app.ErrSessionNotFound):
+		return grpcerrors.NewNotFoundErr("session not found")
+	default:
+		return err
+	}
+}
+
+func (s *Server) ImpersonateUser(ctx context.Context, req *protoaccount.ImpersonateUserRequest) (*protoaccount.ImpersonateUserResponse, error) {
+	orgID, userID, err := s.parseUserName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	result, err := s.account.ImpersonateUser(ctx, p, app.Impersonation{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Reason:         req.GetReason(),
+		TTL:            req.GetTtl().AsDuration(),
+		Client:         s.clientFromContext(ctx),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrReasonRequired):
+			return nil, grpcerrors.NewFieldViolationErr("validation error", []grpcerrors.FieldViolation{
+				{
+					Field:       "reason",
+					Description: "reason is required",
+				},
+			})
+		case errors.Is(err, app.ErrPermissionDenied):
+			return nil, grpcerrors.NewPermissionDeniedErr("not allowed to impersonate this user")
+		case errors.Is(err, app.ErrUserNotFound):
+			return nil, grpcerrors.NewNotFoundErr("user not found")
+		default:
+			return nil, err
+		}
+	}
+
+	var sessionOut protoaccount.Session
+	if err = result.Session.ToProto(orgID, &sessionOut); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.ImpersonateUserResponse{
+		AccessToken: result.AccessToken,
+		Session:     &sessionOut,
+	}, nil
+}
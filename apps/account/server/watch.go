@@ -0,0 +1,88 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) WatchUsers(req *protoaccount.WatchUsersRequest, stream protoaccount.AccountService_WatchUsersServer) error {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return invalidNameErr("parent", req.GetParent())
+	}
+
+	// resume tokens are encoded like page tokens.
+	resumeToken, err := decodePageToken(req.GetResumeToken())
+	if err != nil {
+		return grpcerrors.NewFieldViolationErr("invalid resume token", []grpcerrors.FieldViolation{
+			{
+				Field:       "resume_token",
+				Description: "resume token is malformed",
+			},
+		})
+	}
+
+	ctx := stream.Context()
+	p, _ := authentication.FromContext(ctx)
+	err = s.account.WatchUsers(ctx, p, orgID, resumeToken, func(c app.UserChange) error {
+		out := &protoaccount.WatchUsersResponse{
+			ChangeType:  changeType(c.Type),
+			ResumeToken: encodePageToken(c.ResumeToken),
+		}
+
+		if c.User != nil {
+			var userOut protoaccount.User
+			if err := c.User.ToProto(&userOut); err != nil {
+				return err
+			}
+			out.User = &userOut
+		}
+
+		return stream.Send(out)
+	})
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, app.ErrPermissionDenied):
+		return grpcerrors.NewPermissionDeniedErr("not allowed to watch the users of this organization")
+	case errors.Is(err, app.ErrWatchLagged):
+		return status.Error(codes.Aborted, "the stream fell behind, resume after the last change")
+	case errors.Is(err, app.ErrWatchStopped):
+		return status.Error(codes.Unavailable, "the server is shutting down, resume after the last change")
+	case errors.Is(err, app.ErrResumeTokenExpired):
+		return status.Error(codes.OutOfRange, "the changes after the resume token were purged, watch again without a resume token")
+	default:
+		return err
+	}
+}
+
+// changeType maps an app.UserChangeType to its proto enum.
+func changeType(t app.UserChangeType) protoaccount.WatchUsersResponse_ChangeType {
+	switch t {
+	case app.UserChangeSnapshot:
+		return protoaccount.WatchUsersResponse_CHANGE_TYPE_SNAPSHOT
+	case app.UserChangeSnapshotEnd:
+		return protoaccount.WatchUsersResponse_CHANGE_TYPE_SNAPSHOT_END
+	case app.UserChangeCreated:
+		return protoaccount.WatchUsersResponse_CHANGE_TYPE_CREATED
+	case app.UserChangeUpdated:
+		return protoaccount.WatchUsersResponse_CHANGE_TYPE_UPDATED
+	case app.UserChangeDeleted:
+		return protoaccount.WatchUsersResponse_CHANGE_TYPE_DELETED
+	default:
+		return protoaccount.WatchUsersResponse_CHANGE_TYPE_UNSPECIFIED
+	}
+}
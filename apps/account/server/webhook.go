@@ -0,0 +1,307 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/extreme-business/lingo/apps/account/app"
+	"github.com/extreme-business/lingo/apps/account/auth/authentication"
+	"github.com/extreme-business/lingo/apps/account/domain"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	"github.com/extreme-business/lingo/pkg/validate"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+)
+
+func (s *Server) CreateWebhook(ctx context.Context, req *protoaccount.CreateWebhookRequest) (*protoaccount.CreateWebhookResponse, error) {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	in := req.GetWebhook()
+	if in == nil {
+		return nil, grpcerrors.NewFieldViolationErr("webhook", []grpcerrors.FieldViolation{
+			{
+				Field:       "webhook",
+				Description: "webhook is required",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	w, err := s.account.CreateWebhook(ctx, p, &domain.Webhook{
+		OrganizationID: orgID,
+		URL:            in.GetUrl(),
+		EventTypes:     eventTypes(in.GetEventTypes()),
+	})
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	var out protoaccount.Webhook
+	if err = w.ToProto(&out); err != nil {
+		return nil, err
+	}
+	out.Secret = w.Secret
+
+	return &protoaccount.CreateWebhookResponse{
+		Webhook: &out,
+	}, nil
+}
+
+func (s *Server) ListWebhooks(ctx context.Context, req *protoaccount.ListWebhooksRequest) (*protoaccount.ListWebhooksResponse, error) {
+	parent, err := s.resourceParser.Parse(req.GetParent())
+	if err != nil || parent.CollectionID != domain.OrganizationCollection {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	orgID, err := parent.UUID()
+	if err != nil {
+		return nil, invalidNameErr("parent", req.GetParent())
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	webhooks, err := s.account.ListWebhooks(ctx, p, orgID)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	out := make([]*protoaccount.Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		var webhookOut protoaccount.Webhook
+		if err = w.ToProto(&webhookOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &webhookOut)
+	}
+
+	return &protoaccount.ListWebhooksResponse{
+		Webhooks: out,
+	}, nil
+}
+
+func (s *Server) GetWebhook(ctx context.Context, req *protoaccount.GetWebhookRequest) (*protoaccount.GetWebhookResponse, error) {
+	orgID, webhookID, err := s.parseWebhookName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	w, err := s.account.GetWebhook(ctx, p, orgID, webhookID)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	var out protoaccount.Webhook
+	if err = w.ToProto(&out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.GetWebhookResponse{
+		Webhook: &out,
+	}, nil
+}
+
+func (s *Server) UpdateWebhook(ctx context.Context, req *protoaccount.UpdateWebhookRequest) (*protoaccount.UpdateWebhookResponse, error) {
+	in := req.GetWebhook()
+	orgID, webhookID, err := s.parseWebhookName("webhook.name", in.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	u := app.WebhookUpdate{
+		OrganizationID: orgID,
+		WebhookID:      webhookID,
+	}
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "url":
+			url := in.GetUrl()
+			u.URL = &url
+		case "event_types":
+			u.EventTypes = eventTypes(in.GetEventTypes())
+			if u.EventTypes == nil {
+				u.EventTypes = []domain.EventType{}
+			}
+		case "disabled":
+			disabled := in.GetDisabled()
+			u.Disabled = &disabled
+		default:
+			return nil, grpcerrors.NewFieldViolationErr("invalid update mask", []grpcerrors.FieldViolation{
+				{
+					Field:       "update_mask",
+					Description: fmt.Sprintf("field %q can not be updated", path),
+				},
+			})
+		}
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	w, err := s.account.UpdateWebhook(ctx, p, u)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	var out protoaccount.Webhook
+	if err = w.ToProto(&out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.UpdateWebhookResponse{
+		Webhook: &out,
+	}, nil
+}
+
+func (s *Server) DeleteWebhook(ctx context.Context, req *protoaccount.DeleteWebhookRequest) (*protoaccount.DeleteWebhookResponse, error) {
+	orgID, webhookID, err := s.parseWebhookName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	if err = s.account.DeleteWebhook(ctx, p, orgID, webhookID); err != nil {
+		return nil, webhookError(err)
+	}
+
+	return &protoaccount.DeleteWebhookResponse{}, nil
+}
+
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *protoaccount.ListWebhookDeliveriesRequest) (*protoaccount.ListWebhookDeliveriesResponse, error) {
+	orgID, webhookID, err := s.parseWebhookName("parent", req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, grpcerrors.NewFieldViolationErr("invalid page token", []grpcerrors.FieldViolation{
+			{
+				Field:       "page_token",
+				Description: "page token is malformed",
+			},
+		})
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	deliveries, next, err := s.account.ListWebhookDeliveries(ctx, p, orgID, webhookID, int(req.GetPageSize()), int(offset))
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	out := make([]*protoaccount.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		var deliveryOut protoaccount.WebhookDelivery
+		if err = d.ToProto(orgID, &deliveryOut); err != nil {
+			return nil, err
+		}
+		out = append(out, &deliveryOut)
+	}
+
+	return &protoaccount.ListWebhookDeliveriesResponse{
+		WebhookDeliveries: out,
+		NextPageToken:     encodePageToken(int64(next)),
+	}, nil
+}
+
+func (s *Server) RedeliverWebhookDelivery(ctx context.Context, req *protoaccount.RedeliverWebhookDeliveryRequest) (*protoaccount.RedeliverWebhookDeliveryResponse, error) {
+	name, err := s.resourceParser.Parse(req.GetName())
+	if err != nil || name.CollectionID != domain.WebhookDeliveryCollection {
+		return nil, invalidNameErr("name", req.GetName())
+	}
+
+	deliveryID, err := name.UUID()
+	if err != nil {
+		return nil, invalidNameErr("name", req.GetName())
+	}
+
+	orgID, webhookID, err := s.parseWebhookName("name", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := authentication.FromContext(ctx)
+	d, err := s.account.RedeliverWebhookDelivery(ctx, p, orgID, webhookID, deliveryID)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	var out protoaccount.WebhookDelivery
+	if err = d.ToProto(orgID, &out); err != nil {
+		return nil, err
+	}
+
+	return &protoaccount.RedeliverWebhookDeliveryResponse{
+		WebhookDelivery: &out,
+	}, nil
+}
+
+// parseWebhookName parses the organization and webhook ids from a resource name
+// such as "organizations/1/webhooks/2" or one of its children.
+func (s *Server) parseWebhookName(field, name string) (uuid.UUID, uuid.UUID, error) {
+	r, err := s.resourceParser.Parse(name)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	org := r.Find(domain.OrganizationCollection)
+	webhook := r.Find(domain.WebhookCollection)
+	if org == nil || webhook == nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	orgID, err := org.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	webhookID, err := webhook.UUID()
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidNameErr(field, name)
+	}
+
+	return orgID, webhookID, nil
+}
+
+// eventTypes maps the event types of a request.
+func eventTypes(in []string) []domain.EventType {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]domain.EventType, 0, len(in))
+	for _, t := range in {
+		out = append(out, domain.EventType(t))
+	}
+	return out
+}
+
+// webhookError maps app errors of the webhook operations to gRPC errors.
+func webhookError(err error) error {
+	var vErr *validate.Error
+	switch {
+	case errors.As(err, &vErr):
+		return grpcerrors.NewFieldViolationErr("validation error", []grpcerrors.FieldViolation{
+			{
+				Field:       vErr.Field(),
+				Description: vErr.Error(),
+			},
+		})
+	case errors.Is(err, app.ErrPermissionDenied):
+		return grpcerrors.NewPermissionDeniedErr("not allowed to manage the webhooks of this organization")
+	case errors.Is(err, app.ErrWebhookNotFound):
+		return grpcerrors.NewNotFoundErr("webhook not found")
+	case errors.Is(err, app.ErrWebhookDeliveryNotFound):
+		return grpcerrors.NewNotFoundErr("webhook delivery not found")
+	case errors.Is(err, app.ErrWebhookDeliveryPending):
+		return grpcerrors.NewFailedPreconditionErr("the event is already being delivered")
+	default:
+		return err
+	}
+}
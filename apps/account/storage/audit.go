@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEventError error
+
+var (
+	ErrAuditEventNotFound AuditEventError = errors.New("audit event not found")
+	// Fields.
+	ErrAuditEventUnknownField AuditEventError = errors.New("unknown audit event field")
+	// sort errors.
+	ErrEmptyAuditEventSortField       AuditEventError = errors.New("audit event field is empty")
+	ErrInvalidAuditEventSortDirection AuditEventError = errors.New("invalid audit event sort direction")
+	// Unique constraint errors.
+	ErrConflictAuditEventID AuditEventError = errors.New("unique id conflict")
+)
+
+type AuditEventField string
+
+const (
+	AuditEventID             AuditEventField = "id"
+	AuditEventOrganizationID AuditEventField = "organization_id"
+	AuditEventActor          AuditEventField = "actor"
+	AuditEventAction         AuditEventField = "action"
+	AuditEventResource       AuditEventField = "resource"
+	AuditEventIPAddress      AuditEventField = "ip_address"
+	AuditEventRequestID      AuditEventField = "request_id"
+	AuditEventDetails        AuditEventField = "details"
+	AuditEventCreateTime     AuditEventField = "create_time"
+	AuditEventPreviousHash   AuditEventField = "previous_hash"
+	AuditEventHash           AuditEventField = "hash"
+)
+
+// AuditEventFields returns all audit event fields.
+func AuditEventFields() []AuditEventField {
+	return []AuditEventField{
+		AuditEventID,
+		AuditEventOrganizationID,
+		AuditEventActor,
+		AuditEventAction,
+		AuditEventResource,
+		AuditEventIPAddress,
+		AuditEventRequestID,
+		AuditEventDetails,
+		AuditEventCreateTime,
+		AuditEventPreviousHash,
+		AuditEventHash,
+	}
+}
+
+// AuditEvent is an entry in the append-only audit log.
+// The id is a gapless sequence number, each event contains the hash of the event before it.
+type AuditEvent struct {
+	ID             int64
+	OrganizationID uuid.NullUUID
+	Actor          string
+	Action         string
+	Resource       string
+	IPAddress      string
+	RequestID      string
+	Details        map[string]string
+	CreateTime     time.Time
+	PreviousHash   string
+	Hash           string
+}
+
+// AuditEventSort pairs a field with a direction.
+type AuditEventSort struct {
+	Field     AuditEventField
+	Direction Direction
+}
+
+type AuditEventOrderBy []AuditEventSort
+
+// Validate checks if the sort fields are valid.
+func (o AuditEventOrderBy) Validate() error {
+	fields := AuditEventFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyAuditEventSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrAuditEventUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidAuditEventSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type AuditEventReader interface {
+	// Last returns the most recent audit event, or ErrAuditEventNotFound if the log is empty.
+	Last(context.Context) (*AuditEvent, error)
+	List(context.Context, Pagination, AuditEventOrderBy, ...Condition) ([]*AuditEvent, error)
+}
+
+// AuditEventWriter appends audit events. Audit events can not be updated or deleted.
+type AuditEventWriter interface {
+	// Lock serializes appends until the surrounding transaction ends.
+	Lock(context.Context) error
+	Create(context.Context, *AuditEvent) (*AuditEvent, error)
+}
+
+// AuditEventRepository is a reader and writer for audit events.
+type AuditEventRepository interface {
+	AuditEventReader
+	AuditEventWriter
+}
+
+// AuditEventByOrganizationIDCondition is a search condition for audit events by organization ID.
+type AuditEventByOrganizationIDCondition struct {
+	OrganizationID uuid.UUID
+}
+
+func (AuditEventByOrganizationIDCondition) condition() {}
+
+// AuditEventCreateTimeCondition is a search condition for audit events created in [Start, End).
+// A zero Start or End leaves that side of the range open.
+type AuditEventCreateTimeCondition struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (AuditEventCreateTimeCondition) condition() {}
+
+// AuditEventIDBeforeCondition is a search condition for audit events with an id lower than ID.
+type AuditEventIDBeforeCondition struct {
+	ID int64
+}
+
+func (AuditEventIDBeforeCondition) condition() {}
+
+// AuditEventIDAfterCondition is a search condition for audit events with an id higher than ID.
+type AuditEventIDAfterCondition struct {
+	ID int64
+}
+
+func (AuditEventIDAfterCondition) condition() {}
@@ -0,0 +1,72 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAuditEventFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.AuditEventFields()
+		want := []storage.AuditEventField{
+			storage.AuditEventID,
+			storage.AuditEventOrganizationID,
+			storage.AuditEventActor,
+			storage.AuditEventAction,
+			storage.AuditEventResource,
+			storage.AuditEventIPAddress,
+			storage.AuditEventRequestID,
+			storage.AuditEventDetails,
+			storage.AuditEventCreateTime,
+			storage.AuditEventPreviousHash,
+			storage.AuditEventHash,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("AuditEventFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestAuditEventOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.AuditEventOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.AuditEventOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.AuditEventOrderBy{{Field: "invalid"}},
+			err:  storage.ErrAuditEventUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.AuditEventOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyAuditEventSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.AuditEventOrderBy{{Field: storage.AuditEventID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.AuditEventOrderBy{{Field: storage.AuditEventID, Direction: "invalid"}},
+			err:  storage.ErrInvalidAuditEventSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("AuditEventOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConversationError error
+
+var (
+	ErrConversationNotFound ConversationError = errors.New("conversation not found")
+	// Update.
+	ErrNoConversationFieldsToUpdate ConversationError = errors.New("no fields to update")
+	// Fields.
+	ErrConversationUnknownField ConversationError = errors.New("unknown conversation field")
+	// sort errors.
+	ErrEmptyConversationSortField       ConversationError = errors.New("conversation field is empty")
+	ErrInvalidConversationSortDirection ConversationError = errors.New("invalid conversation sort direction")
+	// Unique constraint errors.
+	ErrConflictConversationID ConversationError = errors.New("unique id conflict")
+	// Immutable errors.
+	ErrImmutableConversationID             ConversationError = errors.New("field id is read-only")
+	ErrImmutableConversationOrganizationID ConversationError = errors.New("field organization_id is read-only")
+	ErrImmutableConversationType           ConversationError = errors.New("field type is read-only")
+	ErrImmutableConversationCreatorID      ConversationError = errors.New("field creator_id is read-only")
+	ErrImmutableConversationCreateTime     ConversationError = errors.New("field create_time is read-only")
+)
+
+type ConversationField string
+
+const (
+	ConversationID             ConversationField = "id"
+	ConversationOrganizationID ConversationField = "organization_id"
+	ConversationType           ConversationField = "type"
+	ConversationTitle          ConversationField = "title"
+	ConversationCreatorID      ConversationField = "creator_id"
+	ConversationCreateTime     ConversationField = "create_time"
+	ConversationUpdateTime     ConversationField = "update_time"
+)
+
+// ConversationFields returns all conversation fields.
+func ConversationFields() []ConversationField {
+	return []ConversationField{
+		ConversationID,
+		ConversationOrganizationID,
+		ConversationType,
+		ConversationTitle,
+		ConversationCreatorID,
+		ConversationCreateTime,
+		ConversationUpdateTime,
+	}
+}
+
+// Conversation is a chat between users of an organization.
+type Conversation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Type           string
+	Title          string
+	CreatorID      uuid.UUID
+	CreateTime     time.Time
+	UpdateTime     time.Time // UpdateTime is set when a message is added.
+}
+
+// ConversationSort pairs a field with a direction.
+type ConversationSort struct {
+	Field     ConversationField
+	Direction Direction
+}
+
+type ConversationOrderBy []ConversationSort
+
+// Validate checks if the sort fields are valid.
+func (o ConversationOrderBy) Validate() error {
+	fields := ConversationFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyConversationSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrConversationUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidConversationSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type ConversationReader interface {
+	Get(context.Context, uuid.UUID) (*Conversation, error)
+	List(context.Context, Pagination, ConversationOrderBy, ...Condition) ([]*Conversation, error)
+}
+
+type ConversationWriter interface {
+	Create(context.Context, *Conversation) (*Conversation, error)
+	Update(context.Context, *Conversation, []ConversationField) (*Conversation, error)
+}
+
+// ConversationRepository is a reader and writer for conversations.
+type ConversationRepository interface {
+	ConversationReader
+	ConversationWriter
+}
+
+// ConversationByOrganizationIDCondition is a search condition for conversations by organization ID.
+type ConversationByOrganizationIDCondition struct {
+	OrganizationID uuid.UUID
+}
+
+func (ConversationByOrganizationIDCondition) condition() {}
+
+// ConversationByParticipantCondition is a search condition for the conversations a user participates in.
+type ConversationByParticipantCondition struct {
+	UserID uuid.UUID
+}
+
+func (ConversationByParticipantCondition) condition() {}
@@ -0,0 +1,68 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConversationFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.ConversationFields()
+		want := []storage.ConversationField{
+			storage.ConversationID,
+			storage.ConversationOrganizationID,
+			storage.ConversationType,
+			storage.ConversationTitle,
+			storage.ConversationCreatorID,
+			storage.ConversationCreateTime,
+			storage.ConversationUpdateTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("ConversationFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestConversationOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.ConversationOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.ConversationOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.ConversationOrderBy{{Field: "invalid"}},
+			err:  storage.ErrConversationUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.ConversationOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyConversationSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.ConversationOrderBy{{Field: storage.ConversationID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.ConversationOrderBy{{Field: storage.ConversationID, Direction: "invalid"}},
+			err:  storage.ErrInvalidConversationSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("ConversationOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
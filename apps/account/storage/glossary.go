@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GlossaryError error
+
+var (
+	ErrGlossaryNotFound GlossaryError = errors.New("glossary not found")
+	// Update.
+	ErrNoGlossaryFieldsToUpdate GlossaryError = errors.New("no fields to update")
+	// Fields.
+	ErrGlossaryUnknownField GlossaryError = errors.New("unknown glossary field")
+	// sort errors.
+	ErrEmptyGlossarySortField       GlossaryError = errors.New("glossary field is empty")
+	ErrInvalidGlossarySortDirection GlossaryError = errors.New("invalid glossary sort direction")
+	// Unique constraint errors.
+	ErrConflictGlossaryID GlossaryError = errors.New("unique id conflict")
+	// Immutable errors.
+	ErrImmutableGlossaryID             GlossaryError = errors.New("field id is read-only")
+	ErrImmutableGlossaryOrganizationID GlossaryError = errors.New("field organization_id is read-only")
+	ErrImmutableGlossaryCreateTime     GlossaryError = errors.New("field create_time is read-only")
+)
+
+type GlossaryField string
+
+const (
+	GlossaryID             GlossaryField = "id"
+	GlossaryOrganizationID GlossaryField = "organization_id"
+	GlossaryDisplayName    GlossaryField = "display_name"
+	GlossaryCreateTime     GlossaryField = "create_time"
+	GlossaryUpdateTime     GlossaryField = "update_time"
+)
+
+// GlossaryFields returns all glossary fields.
+func GlossaryFields() []GlossaryField {
+	return []GlossaryField{
+		GlossaryID,
+		GlossaryOrganizationID,
+		GlossaryDisplayName,
+		GlossaryCreateTime,
+		GlossaryUpdateTime,
+	}
+}
+
+// Glossary keeps the terms and the translation memory of an organization.
+type Glossary struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	DisplayName    string
+	CreateTime     time.Time
+	UpdateTime     time.Time
+}
+
+// GlossarySort pairs a field with a direction.
+type GlossarySort struct {
+	Field     GlossaryField
+	Direction Direction
+}
+
+type GlossaryOrderBy []GlossarySort
+
+// Validate checks if the sort fields are valid.
+func (o GlossaryOrderBy) Validate() error {
+	fields := GlossaryFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyGlossarySortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrGlossaryUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidGlossarySortDirection)
+		}
+	}
+
+	return nil
+}
+
+type GlossaryReader interface {
+	Get(context.Context, uuid.UUID) (*Glossary, error)
+	List(context.Context, Pagination, GlossaryOrderBy, ...Condition) ([]*Glossary, error)
+}
+
+type GlossaryWriter interface {
+	Create(context.Context, *Glossary) (*Glossary, error)
+	Update(context.Context, *Glossary, []GlossaryField) (*Glossary, error)
+	Delete(context.Context, uuid.UUID) error
+}
+
+// GlossaryRepository is a reader and writer for glossaries.
+type GlossaryRepository interface {
+	GlossaryReader
+	GlossaryWriter
+}
+
+// GlossaryByOrganizationIDCondition is a search condition for glossaries by organization ID.
+type GlossaryByOrganizationIDCondition struct {
+	OrganizationID uuid.UUID
+}
+
+func (GlossaryByOrganizationIDCondition) condition() {}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GlossarySegmentError error
+
+var (
+	ErrGlossarySegmentNotFound GlossarySegmentError = errors.New("glossary segment not found")
+	// Fields.
+	ErrGlossarySegmentUnknownField GlossarySegmentError = errors.New("unknown glossary segment field")
+	// sort errors.
+	ErrEmptyGlossarySegmentSortField       GlossarySegmentError = errors.New("glossary segment field is empty")
+	ErrInvalidGlossarySegmentSortDirection GlossarySegmentError = errors.New("invalid glossary segment sort direction")
+	// Unique constraint errors.
+	ErrConflictGlossarySegmentID GlossarySegmentError = errors.New("unique id conflict")
+)
+
+type GlossarySegmentField string
+
+const (
+	GlossarySegmentID             GlossarySegmentField = "id"
+	GlossarySegmentGlossaryID     GlossarySegmentField = "glossary_id"
+	GlossarySegmentSourceLanguage GlossarySegmentField = "source_language"
+	GlossarySegmentSourceText     GlossarySegmentField = "source_text"
+	GlossarySegmentTargetLanguage GlossarySegmentField = "target_language"
+	GlossarySegmentTargetText     GlossarySegmentField = "target_text"
+	GlossarySegmentCreateTime     GlossarySegmentField = "create_time"
+)
+
+// GlossarySegmentFields returns all glossary segment fields.
+func GlossarySegmentFields() []GlossarySegmentField {
+	return []GlossarySegmentField{
+		GlossarySegmentID,
+		GlossarySegmentGlossaryID,
+		GlossarySegmentSourceLanguage,
+		GlossarySegmentSourceText,
+		GlossarySegmentTargetLanguage,
+		GlossarySegmentTargetText,
+		GlossarySegmentCreateTime,
+	}
+}
+
+// GlossarySegment is a text and its approved translation in the translation memory of a glossary.
+type GlossarySegment struct {
+	ID             uuid.UUID
+	GlossaryID     uuid.UUID
+	SourceLanguage string
+	SourceText     string
+	TargetLanguage string
+	TargetText     string
+	CreateTime     time.Time
+}
+
+// GlossarySegmentMatch is a segment with a source text similar to a searched text.
+type GlossarySegmentMatch struct {
+	Segment *GlossarySegment
+	Score   float64 // Score is the similarity of the source text and the searched text, from 0 to 1.
+}
+
+// GlossarySegmentSearch searches segments by the similarity of their source text.
+// Languages are base languages such as "pt", segments in variants of them match as well.
+type GlossarySegmentSearch struct {
+	Text           string
+	SourceLanguage string
+	TargetLanguage string
+	MinScore       float64
+	Limit          int
+}
+
+// GlossarySegmentSort pairs a field with a direction.
+type GlossarySegmentSort struct {
+	Field     GlossarySegmentField
+	Direction Direction
+}
+
+type GlossarySegmentOrderBy []GlossarySegmentSort
+
+// Validate checks if the sort fields are valid.
+func (o GlossarySegmentOrderBy) Validate() error {
+	fields := GlossarySegmentFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyGlossarySegmentSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrGlossarySegmentUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidGlossarySegmentSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type GlossarySegmentReader interface {
+	Get(context.Context, uuid.UUID) (*GlossarySegment, error)
+	List(context.Context, Pagination, GlossarySegmentOrderBy, ...Condition) ([]*GlossarySegment, error)
+	// Search lists the segments whose source text is similar to the searched text, best match first.
+	Search(context.Context, GlossarySegmentSearch, ...Condition) ([]*GlossarySegmentMatch, error)
+}
+
+type GlossarySegmentWriter interface {
+	Create(context.Context, *GlossarySegment) (*GlossarySegment, error)
+	Delete(context.Context, uuid.UUID) error
+}
+
+// GlossarySegmentRepository is a reader and writer for glossary segments.
+type GlossarySegmentRepository interface {
+	GlossarySegmentReader
+	GlossarySegmentWriter
+}
+
+// GlossarySegmentByGlossaryIDCondition is a search condition for the segments of a glossary.
+type GlossarySegmentByGlossaryIDCondition struct {
+	GlossaryID uuid.UUID
+}
+
+func (GlossarySegmentByGlossaryIDCondition) condition() {}
+
+// GlossarySegmentByOrganizationIDCondition is a search condition for the segments of all glossaries of an organization.
+type GlossarySegmentByOrganizationIDCondition struct {
+	OrganizationID uuid.UUID
+}
+
+func (GlossarySegmentByOrganizationIDCondition) condition() {}
@@ -0,0 +1,68 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGlossarySegmentFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.GlossarySegmentFields()
+		want := []storage.GlossarySegmentField{
+			storage.GlossarySegmentID,
+			storage.GlossarySegmentGlossaryID,
+			storage.GlossarySegmentSourceLanguage,
+			storage.GlossarySegmentSourceText,
+			storage.GlossarySegmentTargetLanguage,
+			storage.GlossarySegmentTargetText,
+			storage.GlossarySegmentCreateTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("GlossarySegmentFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestGlossarySegmentOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.GlossarySegmentOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.GlossarySegmentOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.GlossarySegmentOrderBy{{Field: "invalid"}},
+			err:  storage.ErrGlossarySegmentUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.GlossarySegmentOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyGlossarySegmentSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.GlossarySegmentOrderBy{{Field: storage.GlossarySegmentID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.GlossarySegmentOrderBy{{Field: storage.GlossarySegmentID, Direction: "invalid"}},
+			err:  storage.ErrInvalidGlossarySegmentSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("GlossarySegmentOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GlossaryTermError error
+
+var (
+	ErrGlossaryTermNotFound GlossaryTermError = errors.New("glossary term not found")
+	// Update.
+	ErrNoGlossaryTermFieldsToUpdate GlossaryTermError = errors.New("no fields to update")
+	// Fields.
+	ErrGlossaryTermUnknownField GlossaryTermError = errors.New("unknown glossary term field")
+	// sort errors.
+	ErrEmptyGlossaryTermSortField       GlossaryTermError = errors.New("glossary term field is empty")
+	ErrInvalidGlossaryTermSortDirection GlossaryTermError = errors.New("invalid glossary term sort direction")
+	// Unique constraint errors.
+	ErrConflictGlossaryTermID GlossaryTermError = errors.New("unique id conflict")
+	// Immutable errors.
+	ErrImmutableGlossaryTermID         GlossaryTermError = errors.New("field id is read-only")
+	ErrImmutableGlossaryTermGlossaryID GlossaryTermError = errors.New("field glossary_id is read-only")
+	ErrImmutableGlossaryTermCreateTime GlossaryTermError = errors.New("field create_time is read-only")
+)
+
+type GlossaryTermField string
+
+const (
+	GlossaryTermID             GlossaryTermField = "id"
+	GlossaryTermGlossaryID     GlossaryTermField = "glossary_id"
+	GlossaryTermTerm           GlossaryTermField = "term"
+	GlossaryTermLanguage       GlossaryTermField = "language"
+	GlossaryTermTranslation    GlossaryTermField = "translation"
+	GlossaryTermDoNotTranslate GlossaryTermField = "do_not_translate"
+	GlossaryTermCreateTime     GlossaryTermField = "create_time"
+	GlossaryTermUpdateTime     GlossaryTermField = "update_time"
+)
+
+// GlossaryTermFields returns all glossary term fields.
+func GlossaryTermFields() []GlossaryTermField {
+	return []GlossaryTermField{
+		GlossaryTermID,
+		GlossaryTermGlossaryID,
+		GlossaryTermTerm,
+		GlossaryTermLanguage,
+		GlossaryTermTranslation,
+		GlossaryTermDoNotTranslate,
+		GlossaryTermCreateTime,
+		GlossaryTermUpdateTime,
+	}
+}
+
+// GlossaryTerm is the approved translation of a word or phrase into a language.
+type GlossaryTerm struct {
+	ID             uuid.UUID
+	GlossaryID     uuid.UUID
+	Term           string
+	Language       string // Language is the language of the translation, empty when the term is not translated.
+	Translation    string
+	DoNotTranslate bool // DoNotTranslate keeps the term as it is in every language.
+	CreateTime     time.Time
+	UpdateTime     time.Time
+}
+
+// GlossaryTermSort pairs a field with a direction.
+type GlossaryTermSort struct {
+	Field     GlossaryTermField
+	Direction Direction
+}
+
+type GlossaryTermOrderBy []GlossaryTermSort
+
+// Validate checks if the sort fields are valid.
+func (o GlossaryTermOrderBy) Validate() error {
+	fields := GlossaryTermFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyGlossaryTermSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrGlossaryTermUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidGlossaryTermSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type GlossaryTermReader interface {
+	Get(context.Context, uuid.UUID) (*GlossaryTerm, error)
+	List(context.Context, Pagination, GlossaryTermOrderBy, ...Condition) ([]*GlossaryTerm, error)
+}
+
+type GlossaryTermWriter interface {
+	Create(context.Context, *GlossaryTerm) (*GlossaryTerm, error)
+	Update(context.Context, *GlossaryTerm, []GlossaryTermField) (*GlossaryTerm, error)
+	Delete(context.Context, uuid.UUID) error
+}
+
+// GlossaryTermRepository is a reader and writer for glossary terms.
+type GlossaryTermRepository interface {
+	GlossaryTermReader
+	GlossaryTermWriter
+}
+
+// GlossaryTermByGlossaryIDCondition is a search condition for the terms of a glossary.
+type GlossaryTermByGlossaryIDCondition struct {
+	GlossaryID uuid.UUID
+}
+
+func (GlossaryTermByGlossaryIDCondition) condition() {}
+
+// GlossaryTermByOrganizationIDCondition is a search condition for the terms of all glossaries of an organization.
+type GlossaryTermByOrganizationIDCondition struct {
+	OrganizationID uuid.UUID
+}
+
+func (GlossaryTermByOrganizationIDCondition) condition() {}
+
+// GlossaryTermForLanguageCondition is a search condition for the terms that apply to translations into a language:
+// the terms translated into the language or one of its variants, and the terms that are not translated.
+// The language is a base language such as "pt".
+type GlossaryTermForLanguageCondition struct {
+	Language string
+}
+
+func (GlossaryTermForLanguageCondition) condition() {}
@@ -0,0 +1,69 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGlossaryTermFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.GlossaryTermFields()
+		want := []storage.GlossaryTermField{
+			storage.GlossaryTermID,
+			storage.GlossaryTermGlossaryID,
+			storage.GlossaryTermTerm,
+			storage.GlossaryTermLanguage,
+			storage.GlossaryTermTranslation,
+			storage.GlossaryTermDoNotTranslate,
+			storage.GlossaryTermCreateTime,
+			storage.GlossaryTermUpdateTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("GlossaryTermFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestGlossaryTermOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.GlossaryTermOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.GlossaryTermOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.GlossaryTermOrderBy{{Field: "invalid"}},
+			err:  storage.ErrGlossaryTermUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.GlossaryTermOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyGlossaryTermSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.GlossaryTermOrderBy{{Field: storage.GlossaryTermID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.GlossaryTermOrderBy{{Field: storage.GlossaryTermID, Direction: "invalid"}},
+			err:  storage.ErrInvalidGlossaryTermSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("GlossaryTermOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
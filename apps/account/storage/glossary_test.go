@@ -0,0 +1,66 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGlossaryFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.GlossaryFields()
+		want := []storage.GlossaryField{
+			storage.GlossaryID,
+			storage.GlossaryOrganizationID,
+			storage.GlossaryDisplayName,
+			storage.GlossaryCreateTime,
+			storage.GlossaryUpdateTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("GlossaryFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestGlossaryOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.GlossaryOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.GlossaryOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.GlossaryOrderBy{{Field: "invalid"}},
+			err:  storage.ErrGlossaryUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.GlossaryOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyGlossarySortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.GlossaryOrderBy{{Field: storage.GlossaryID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.GlossaryOrderBy{{Field: storage.GlossaryID, Direction: "invalid"}},
+			err:  storage.ErrInvalidGlossarySortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("GlossaryOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+// JobEnqueuer enqueues background jobs, such as a jobs.Client. The jobs of the repositories of an
+// operation are enqueued in its transaction, so they only exist when the operation commits.
+type JobEnqueuer interface {
+	// Enqueue adds a job and reports whether it was added, see jobs.Client.
+	Enqueue(ctx context.Context, args jobs.Args, opts ...jobs.EnqueueOption) (bool, error)
+}
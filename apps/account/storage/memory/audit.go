@@ -0,0 +1,211 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	auditEventActorLength     = 255
+	auditEventActionLength    = 64
+	auditEventResourceLength  = 255
+	auditEventIPAddressLength = 45
+	auditEventRequestIDLength = 64
+	auditEventHashLength      = 64
+	// auditEventLock is the advisory lock that serializes the appends to the audit log.
+	auditEventLock = "audit_events"
+)
+
+var _ storage.AuditEventRepository = (*auditEventRepository)(nil)
+
+type auditEventRepository struct {
+	c conn
+}
+
+// checkAuditEvent checks the constraints of the audit_events table on e.
+func checkAuditEvent(s *state, e *storage.AuditEvent) error {
+	for _, err := range []error{
+		maxLength(string(storage.AuditEventActor), e.Actor, auditEventActorLength),
+		maxLength(string(storage.AuditEventAction), e.Action, auditEventActionLength),
+		maxLength(string(storage.AuditEventResource), e.Resource, auditEventResourceLength),
+		maxLength(string(storage.AuditEventIPAddress), e.IPAddress, auditEventIPAddressLength),
+		maxLength(string(storage.AuditEventRequestID), e.RequestID, auditEventRequestIDLength),
+		maxLength(string(storage.AuditEventPreviousHash), e.PreviousHash, auditEventHashLength),
+		maxLength(string(storage.AuditEventHash), e.Hash, auditEventHashLength),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, other := range s.auditEvents {
+		if other.Hash == e.Hash {
+			return fmt.Errorf("%s: %w", storage.AuditEventHash, ErrUniqueViolation)
+		}
+	}
+
+	return nil
+}
+
+// copyAuditEvent returns a copy of the event that does not share its details.
+func copyAuditEvent(e storage.AuditEvent) *storage.AuditEvent {
+	e.Details = maps.Clone(e.Details)
+	if e.Details == nil {
+		e.Details = map[string]string{}
+	}
+	return &e
+}
+
+// Lock serializes appends until the surrounding transaction ends.
+func (r *auditEventRepository) Lock(_ context.Context) error {
+	return r.c.lock(auditEventLock)
+}
+
+// Last returns the event with the highest id.
+func (r *auditEventRepository) Last(_ context.Context) (*storage.AuditEvent, error) {
+	var last *storage.AuditEvent
+	r.c.read(func(s *state) {
+		for _, e := range s.auditEvents {
+			if last == nil || e.ID > last.ID {
+				last = copyAuditEvent(e)
+			}
+		}
+	})
+	if last == nil {
+		return nil, storage.ErrAuditEventNotFound
+	}
+
+	return last, nil
+}
+
+// Create appends an event to the audit log.
+func (r *auditEventRepository) Create(_ context.Context, e *storage.AuditEvent) (*storage.AuditEvent, error) {
+	v := copyAuditEvent(*e) // the transaction applies the mutation again when it commits.
+	err := r.c.write(func(s *state) error {
+		if _, ok := s.auditEvents[v.ID]; ok {
+			return storage.ErrConflictAuditEventID
+		}
+
+		if err := checkAuditEvent(s, v); err != nil {
+			return err
+		}
+
+		s.auditEvents[v.ID] = *v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return copyAuditEvent(*v), nil
+}
+
+// auditEventFilter returns whether an event matches the conditions.
+func auditEventFilter(conditions []storage.Condition) (func(e *storage.AuditEvent) bool, error) {
+	var filters []func(e *storage.AuditEvent) bool
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.AuditEventByOrganizationIDCondition:
+			filters = append(filters, func(e *storage.AuditEvent) bool {
+				return e.OrganizationID.Valid && e.OrganizationID.UUID == t.OrganizationID
+			})
+		case storage.AuditEventCreateTimeCondition:
+			filters = append(filters, func(e *storage.AuditEvent) bool {
+				return (t.Start.IsZero() || !e.CreateTime.Before(t.Start)) && (t.End.IsZero() || e.CreateTime.Before(t.End))
+			})
+		case storage.AuditEventIDBeforeCondition:
+			filters = append(filters, func(e *storage.AuditEvent) bool { return e.ID < t.ID })
+		case storage.AuditEventIDAfterCondition:
+			filters = append(filters, func(e *storage.AuditEvent) bool { return e.ID > t.ID })
+		default:
+			return nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return func(e *storage.AuditEvent) bool {
+		for _, f := range filters {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// compareNullUUIDs compares nullable UUIDs, NULL sorts after every UUID like in Postgres.
+func compareNullUUIDs(a, b uuid.NullUUID) int {
+	switch {
+	case a.Valid && b.Valid:
+		return compareUUIDs(a.UUID, b.UUID)
+	case a.Valid:
+		return -1
+	case b.Valid:
+		return 1
+	}
+	return 0
+}
+
+// compareAuditEvents compares two events by a field. The details can not be sorted on.
+func compareAuditEvents(a, b *storage.AuditEvent, f storage.AuditEventField) int {
+	switch f {
+	case storage.AuditEventID:
+		return cmp.Compare(a.ID, b.ID)
+	case storage.AuditEventOrganizationID:
+		return compareNullUUIDs(a.OrganizationID, b.OrganizationID)
+	case storage.AuditEventActor:
+		return cmp.Compare(a.Actor, b.Actor)
+	case storage.AuditEventAction:
+		return cmp.Compare(a.Action, b.Action)
+	case storage.AuditEventResource:
+		return cmp.Compare(a.Resource, b.Resource)
+	case storage.AuditEventIPAddress:
+		return cmp.Compare(a.IPAddress, b.IPAddress)
+	case storage.AuditEventRequestID:
+		return cmp.Compare(a.RequestID, b.RequestID)
+	case storage.AuditEventCreateTime:
+		return a.CreateTime.Compare(b.CreateTime)
+	case storage.AuditEventPreviousHash:
+		return cmp.Compare(a.PreviousHash, b.PreviousHash)
+	case storage.AuditEventHash:
+		return cmp.Compare(a.Hash, b.Hash)
+	}
+	return 0
+}
+
+// List audit events.
+func (r *auditEventRepository) List(_ context.Context, pagination storage.Pagination, sorting storage.AuditEventOrderBy, conditions ...storage.Condition) ([]*storage.AuditEvent, error) {
+	match, err := auditEventFilter(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	var events []*storage.AuditEvent
+	r.c.read(func(s *state) {
+		for _, e := range s.auditEvents {
+			if match(&e) {
+				events = append(events, copyAuditEvent(e))
+			}
+		}
+	})
+
+	slices.SortFunc(events, func(a, b *storage.AuditEvent) int {
+		for _, sort := range sorting {
+			if c := direction(compareAuditEvents(a, b, sort.Field), sort.Direction); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return paginate(events, pagination), nil
+}
@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+var _ storage.JobEnqueuer = discardJobs{}
+
+// discardJobs drops the enqueued jobs, the memory storage has no job runner. The jobs that follow up on
+// changes, such as relaying the outbox, are not needed without a database: the event feed reads the
+// outbox of the memory storage directly.
+type discardJobs struct{}
+
+func (discardJobs) Enqueue(context.Context, jobs.Args, ...jobs.EnqueueOption) (bool, error) {
+	return false, nil
+}
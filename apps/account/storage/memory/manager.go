@@ -0,0 +1,446 @@
+// Package memory is an in-memory storage backend, for fast tests and running without Postgres.
+//
+// It implements the user, organization, session, audit event, outbox event and registration token
+// repositories with the constraints of the SQL schema; the other repositories of storage.Repositories
+// return ErrUnsupported. Operations started with BeginOp run in a transaction on a snapshot of the
+// data: they see their own changes, and their changes become visible to others only when they
+// commit. A commit applies the changes again to the latest data, so a commit that conflicts with a
+// change committed in the meantime fails with the constraint error of the conflict, and changes
+// nothing.
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/google/uuid"
+)
+
+var _ storage.DBManager = (*Manager)(nil)
+
+// userRow is a stored user, seq orders the rows by insertion like the heap of a table.
+type userRow struct {
+	storage.User
+	seq int64
+}
+
+type organizationRow struct {
+	storage.Organization
+	seq int64
+}
+
+type sessionRow struct {
+	storage.Session
+	seq int64
+}
+
+// state is the data of the database, or the data a transaction sees.
+type state struct {
+	seq                int64
+	users              map[uuid.UUID]userRow
+	organizations      map[uuid.UUID]organizationRow
+	sessions           map[uuid.UUID]sessionRow
+	auditEvents        map[int64]storage.AuditEvent
+	outboxEvents       map[int64]storage.OutboxEvent
+	outboxPosition     int64 // outboxPosition is the position of the latest committed outbox event.
+	registrationTokens map[uuid.UUID]storage.RegistrationToken
+}
+
+func newState() *state {
+	return &state{
+		users:              map[uuid.UUID]userRow{},
+		organizations:      map[uuid.UUID]organizationRow{},
+		sessions:           map[uuid.UUID]sessionRow{},
+		auditEvents:        map[int64]storage.AuditEvent{},
+		outboxEvents:       map[int64]storage.OutboxEvent{},
+		registrationTokens: map[uuid.UUID]storage.RegistrationToken{},
+	}
+}
+
+func (s *state) clone() *state {
+	return &state{
+		seq:                s.seq,
+		users:              maps.Clone(s.users),
+		organizations:      maps.Clone(s.organizations),
+		sessions:           maps.Clone(s.sessions),
+		auditEvents:        maps.Clone(s.auditEvents),
+		outboxEvents:       maps.Clone(s.outboxEvents),
+		outboxPosition:     s.outboxPosition,
+		registrationTokens: maps.Clone(s.registrationTokens),
+	}
+}
+
+// mutation changes the state. It checks the constraints before it changes anything, so a mutation
+// that fails leaves the state as it was.
+type mutation func(s *state) error
+
+// conn is where the repositories read and write: the committed data or a transaction.
+type conn interface {
+	read(f func(s *state))
+	write(m mutation) error
+	// lock takes an advisory lock, a transaction holds it until it ends.
+	lock(key string) error
+	// nextOutboxEventID takes the next outbox event id. Like a sequence in Postgres it is not
+	// transactional, the id of an event that is rolled back is not used again.
+	nextOutboxEventID() int64
+}
+
+// Manager is an in-memory storage.DBManager.
+type Manager struct {
+	mu        sync.Mutex
+	state     *state
+	outboxIDs atomic.Int64
+	locks     map[string]*sync.Mutex
+	listeners map[chan struct{}]struct{}
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		state:     newState(),
+		locks:     map[string]*sync.Mutex{},
+		listeners: map[chan struct{}]struct{}{},
+	}
+}
+
+// Op returns the repositories without a transaction, every write is committed right away.
+func (m *Manager) Op() storage.Repositories {
+	return repositories(m)
+}
+
+// BeginOp runs the operation in a transaction, which commits when the operation returns nil and
+// rolls back otherwise. An operation started with the context of another operation is nested in its
+// transaction, its changes are undone when it fails and kept when the outer transaction commits.
+//
+// The options are accepted for compatibility with database.Manager: transactions are serializable
+// and never need a retry. Functions registered with database.AfterCommit run after the transaction
+// commits, those of a nested operation that fails are dropped. Advisory locks are held until the
+// outer transaction ends.
+func (m *Manager) BeginOp(ctx context.Context, operation func(context.Context, storage.Repositories) error, _ ...database.OpOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	parent, nested := ctx.Value(txKey{m}).(*tx)
+
+	var t *tx
+	if nested {
+		t = parent.begin()
+	} else {
+		t = m.begin()
+		ctx = database.WithHooks(ctx, t.hooks)
+		defer t.locks.release()
+	}
+
+	hooks := t.hooks.Len()
+	if err := operation(context.WithValue(ctx, txKey{m}, t), repositories(t)); err != nil {
+		t.hooks.Truncate(hooks)
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		t.hooks.Truncate(hooks)
+		return err
+	}
+
+	if nested {
+		parent.merge(t)
+		return nil
+	}
+
+	if err := m.commit(t); err != nil {
+		return err
+	}
+
+	t.locks.release()
+	t.hooks.Run(ctx)
+	return nil
+}
+
+// Listen calls notify with the position of the latest outbox event when outbox events are
+// committed, until the context is canceled. It is the outbox.ListenFunc of the manager: the
+// notifications of commits that follow each other quickly are merged into one.
+func (m *Manager) Listen(ctx context.Context, notify func(payload string)) error {
+	wake := make(chan struct{}, 1)
+
+	m.mu.Lock()
+	m.listeners[wake] = struct{}{}
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.listeners, wake)
+		m.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+			var position int64
+			m.read(func(s *state) { position = s.outboxPosition })
+			notify(strconv.FormatInt(position, 10))
+		}
+	}
+}
+
+func (m *Manager) read(f func(s *state)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f(m.state)
+}
+
+func (m *Manager) write(f mutation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := f(m.state); err != nil {
+		return err
+	}
+
+	m.stampOutboxEvents(m.state)
+	return nil
+}
+
+// lock waits until no transaction holds the lock. Without a transaction the lock is released right
+// away, like an advisory lock of Postgres is at the end of the statement.
+func (m *Manager) lock(key string) error {
+	l := m.advisoryLock(key)
+	l.Lock()
+	defer l.Unlock()
+	return nil
+}
+
+func (m *Manager) nextOutboxEventID() int64 {
+	return m.outboxIDs.Add(1)
+}
+
+// advisoryLock returns the mutex of an advisory lock.
+func (m *Manager) advisoryLock(key string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		m.locks[key] = l
+	}
+	return l
+}
+
+// begin starts a transaction on a snapshot of the committed data.
+func (m *Manager) begin() *tx {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &tx{m: m, state: m.state.clone(), hooks: &database.Hooks{}, locks: &heldLocks{}}
+}
+
+// commit applies the changes of the transaction to the committed data, all or nothing.
+func (m *Manager) commit(t *tx) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.journal) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next := m.state.clone()
+	for _, f := range t.journal {
+		if err := f(next); err != nil {
+			return err
+		}
+	}
+
+	m.stampOutboxEvents(next)
+	m.state = next
+	return nil
+}
+
+// stampOutboxEvents gives the new outbox events of s a position, in the order they were created,
+// and wakes the listeners. Like the outbox_events_position trigger it runs when the events are
+// committed, which happens one commit at a time, so the positions have no gaps. m.mu must be held.
+func (m *Manager) stampOutboxEvents(s *state) {
+	var ids []int64
+	for id, e := range s.outboxEvents {
+		if e.Position == 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	slices.Sort(ids)
+	for _, id := range ids {
+		e := s.outboxEvents[id]
+		s.outboxPosition++
+		e.Position = s.outboxPosition
+		s.outboxEvents[id] = e
+	}
+
+	for wake := range m.listeners {
+		select {
+		case wake <- struct{}{}:
+		default: // the listener is already woken.
+		}
+	}
+}
+
+// txKey is the context key of the transaction of an operation of the manager.
+type txKey struct {
+	m *Manager
+}
+
+// tx is a transaction. It reads and writes its own copy of the data and keeps a journal of its
+// changes, to apply them to the committed data when it commits.
+type tx struct {
+	m       *Manager
+	parent  *tx // parent is the transaction a nested transaction is nested in.
+	mu      sync.Mutex
+	state   *state
+	journal []mutation
+	hooks   *database.Hooks // hooks is shared with the nested transactions.
+	locks   *heldLocks      // locks is shared with the nested transactions.
+}
+
+func (t *tx) read(f func(s *state)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f(t.state)
+}
+
+func (t *tx) write(f mutation) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := f(t.state); err != nil {
+		return err
+	}
+
+	t.journal = append(t.journal, f)
+	return nil
+}
+
+// lock takes an advisory lock until the outer transaction ends. The transaction then sees the
+// changes committed before it got the lock, like the next statement of a transaction in Postgres
+// does, so the changes made under the lock are made to the latest data.
+func (t *tx) lock(key string) error {
+	if !t.locks.holds(key) {
+		l := t.m.advisoryLock(key)
+		l.Lock()
+		t.locks.add(key, l)
+	}
+
+	return t.refresh()
+}
+
+func (t *tx) nextOutboxEventID() int64 {
+	return t.m.nextOutboxEventID()
+}
+
+// refresh applies the changes of the transaction again to the latest committed data.
+func (t *tx) refresh() error {
+	var next *state
+	if t.parent != nil {
+		if err := t.parent.refresh(); err != nil {
+			return err
+		}
+		t.parent.read(func(s *state) { next = s.clone() })
+	} else {
+		t.m.read(func(s *state) { next = s.clone() })
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, f := range t.journal {
+		if err := f(next); err != nil {
+			return err
+		}
+	}
+
+	t.state = next
+	return nil
+}
+
+// begin starts a nested transaction.
+func (t *tx) begin() *tx {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &tx{m: t.m, parent: t, state: t.state.clone(), hooks: t.hooks, locks: t.locks}
+}
+
+// merge keeps the changes of a nested transaction.
+func (t *tx) merge(nested *tx) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nested.mu.Lock()
+	defer nested.mu.Unlock()
+
+	t.state = nested.state
+	t.journal = append(t.journal, nested.journal...)
+}
+
+// heldLocks are the advisory locks a transaction holds.
+type heldLocks struct {
+	mu   sync.Mutex
+	held map[string]*sync.Mutex
+}
+
+func (l *heldLocks) holds(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.held[key]
+	return ok
+}
+
+func (l *heldLocks) add(key string, lock *sync.Mutex) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held == nil {
+		l.held = map[string]*sync.Mutex{}
+	}
+	l.held[key] = lock
+}
+
+// release releases all locks, it may be called more than once.
+func (l *heldLocks) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, lock := range l.held {
+		lock.Unlock()
+	}
+	l.held = nil
+}
+
+func repositories(c conn) storage.Repositories {
+	return storage.Repositories{
+		User:               &userRepository{c: c},
+		Organization:       &organizationRepository{c: c},
+		Session:            &sessionRepository{c: c},
+		AuditEvent:         &auditEventRepository{c: c},
+		OutboxEvent:        &outboxEventRepository{c: c},
+		Webhook:            unsupportedWebhookRepository{},
+		WebhookDelivery:    unsupportedWebhookDeliveryRepository{},
+		Conversation:       unsupportedConversationRepository{},
+		Participant:        unsupportedParticipantRepository{},
+		Message:            unsupportedMessageRepository{},
+		MessageTranslation: unsupportedMessageTranslationRepository{},
+		Glossary:           unsupportedGlossaryRepository{},
+		GlossaryTerm:       unsupportedGlossaryTermRepository{},
+		GlossarySegment:    unsupportedGlossarySegmentRepository{},
+		RegistrationToken:  &registrationTokenRepository{c: c},
+		Jobs:               discardJobs{},
+	}
+}
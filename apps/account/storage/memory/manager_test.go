@@ -0,0 +1,219 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/memory"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var (
+	orgID = uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	now   = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// newManager returns a manager with an organization.
+func newManager(t *testing.T) *memory.Manager {
+	t.Helper()
+	m := memory.NewManager()
+	if _, err := m.Op().Organization.Create(context.Background(), &storage.Organization{
+		ID:         orgID,
+		LegalName:  "test",
+		Slug:       "test",
+		CreateTime: now,
+		UpdateTime: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func newUser(id, email string) *storage.User {
+	return &storage.User{
+		ID:             uuid.MustParse(id),
+		OrganizationID: orgID,
+		DisplayName:    "test",
+		Email:          email,
+		HashedPassword: "password",
+		Status:         "active",
+		Role:           "user",
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+}
+
+func TestManager_BeginOp(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.MustParse("957b12c5-1071-40d9-8bec-6ed195c8cfbf")
+
+	t.Run("should hide the changes until the operation commits", func(t *testing.T) {
+		m := newManager(t)
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if _, err := r.User.Create(ctx, newUser(userID.String(), "test@test.com")); err != nil {
+				return err
+			}
+
+			if _, err := r.User.Get(ctx, userID); err != nil {
+				t.Errorf("expected the operation to see its own changes, got %v", err)
+			}
+
+			if _, err := m.Op().User.Get(ctx, userID); !errors.Is(err, storage.ErrUserNotFound) {
+				t.Errorf("expected %v outside the operation, got %v", storage.ErrUserNotFound, err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("should fail to commit a change that conflicts with a committed change", func(t *testing.T) {
+		m := newManager(t)
+		otherID := uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770")
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if _, err := r.User.Create(ctx, newUser(userID.String(), "test@test.com")); err != nil {
+				return err
+			}
+
+			_, err := m.Op().User.Create(ctx, newUser(otherID.String(), "test@test.com"))
+			return err
+		})
+		if !errors.Is(err, storage.ErrConflictUserEmail) {
+			t.Errorf("expected %v, got %v", storage.ErrConflictUserEmail, err)
+		}
+
+		if _, err = m.Op().User.Get(ctx, userID); !errors.Is(err, storage.ErrUserNotFound) {
+			t.Errorf("expected nothing of the operation to be committed, got %v", err)
+		}
+	})
+
+	t.Run("should run the after commit hooks only after the commit", func(t *testing.T) {
+		m := newManager(t)
+		var hooks []string
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			database.AfterCommit(ctx, func(context.Context) {
+				if _, err := m.Op().User.Get(ctx, userID); err != nil {
+					t.Errorf("expected the user to be committed when the hook runs, got %v", err)
+				}
+				hooks = append(hooks, "outer")
+			})
+
+			if _, err := r.User.Create(ctx, newUser(userID.String(), "test@test.com")); err != nil {
+				return err
+			}
+
+			_ = m.BeginOp(ctx, func(ctx context.Context, _ storage.Repositories) error {
+				database.AfterCommit(ctx, func(context.Context) { hooks = append(hooks, "failed nested") })
+				return errors.New("failed")
+			})
+
+			if len(hooks) != 0 {
+				t.Errorf("expected no hooks before the commit, got %v", hooks)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]string{"outer"}, hooks); diff != "" {
+			t.Errorf("hooks mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should not run the after commit hooks of a failed operation", func(t *testing.T) {
+		m := newManager(t)
+		called := false
+		_ = m.BeginOp(ctx, func(ctx context.Context, _ storage.Repositories) error {
+			database.AfterCommit(ctx, func(context.Context) { called = true })
+			return errors.New("failed")
+		})
+
+		if called {
+			t.Error("expected the hook not to be called")
+		}
+	})
+
+	t.Run("should not commit when the context is canceled", func(t *testing.T) {
+		m := newManager(t)
+		ctx, cancel := context.WithCancel(ctx)
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			_, err := r.User.Create(ctx, newUser(userID.String(), "test@test.com"))
+			cancel()
+			return err
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected %v, got %v", context.Canceled, err)
+		}
+
+		if _, err = m.Op().User.Get(context.Background(), userID); !errors.Is(err, storage.ErrUserNotFound) {
+			t.Errorf("expected the user to be rolled back, got %v", err)
+		}
+	})
+
+	t.Run("should serialize the operations that take a lock and show them the latest data", func(t *testing.T) {
+		m := newManager(t)
+
+		// appendEvent appends an audit event after the last one, like the audit writer does.
+		appendEvent := func() error {
+			return m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+				if err := r.AuditEvent.Lock(ctx); err != nil {
+					return err
+				}
+
+				var id int64 = 1
+				last, err := r.AuditEvent.Last(ctx)
+				switch {
+				case err == nil:
+					id = last.ID + 1
+				case !errors.Is(err, storage.ErrAuditEventNotFound):
+					return err
+				}
+
+				_, err = r.AuditEvent.Create(ctx, &storage.AuditEvent{
+					ID:           id,
+					Action:       "test",
+					CreateTime:   now,
+					PreviousHash: fmt.Sprint(id - 1),
+					Hash:         fmt.Sprint(id),
+				})
+				return err
+			})
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- appendEvent()
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Errorf("expected the appends to succeed, got %v", err)
+			}
+		}
+
+		last, err := m.Op().AuditEvent.Last(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if last.ID != 10 {
+			t.Errorf("expected the last event to be 10, got %d", last.ID)
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	organizationLegalNameLength = 255
+	organizationSlugLength      = 100
+)
+
+// organizationSlug is the format of the slug of an organization, as checked by organizations_chk_slug.
+var organizationSlug = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+var _ storage.OrganizationRepository = (*organizationRepository)(nil)
+
+type organizationRepository struct {
+	c conn
+}
+
+// check checks the constraints of the organizations table on o, which is stored as id.
+func (o organizationRow) check(s *state, id uuid.UUID) error {
+	if err := maxLength(string(storage.OrganizationLegalName), o.LegalName, organizationLegalNameLength); err != nil {
+		return err
+	}
+
+	if err := maxLength(string(storage.OrganizationSlug), o.Slug, organizationSlugLength); err != nil {
+		return err
+	}
+
+	if !organizationSlug.MatchString(o.Slug) {
+		return storage.ErrInvalidOrganizationSlug
+	}
+
+	for otherID, other := range s.organizations {
+		if otherID == id {
+			continue
+		}
+
+		if other.LegalName == o.LegalName {
+			return storage.ErrConflictOrganizationLegalName
+		}
+
+		if other.Slug == o.Slug {
+			return storage.ErrConflictOrganizationSlug
+		}
+	}
+
+	return nil
+}
+
+// Create a new organization.
+func (r *organizationRepository) Create(_ context.Context, o *storage.Organization) (*storage.Organization, error) {
+	in := *o // the transaction applies the mutation again when it commits.
+	var created storage.Organization
+	err := r.c.write(func(s *state) error {
+		if _, ok := s.organizations[in.ID]; ok {
+			return storage.ErrConflictOrganizationID
+		}
+
+		row := organizationRow{Organization: in, seq: s.seq + 1}
+		if err := row.check(s, in.ID); err != nil {
+			return err
+		}
+
+		s.seq++
+		s.organizations[in.ID] = row
+		created = row.Organization
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Get an organization by id.
+func (r *organizationRepository) Get(_ context.Context, id uuid.UUID) (*storage.Organization, error) {
+	var o storage.Organization
+	var ok bool
+	r.c.read(func(s *state) {
+		var row organizationRow
+		row, ok = s.organizations[id]
+		o = row.Organization
+	})
+	if !ok {
+		return nil, storage.ErrOrganizationNotFound
+	}
+
+	return &o, nil
+}
+
+// Update the fields of an organization.
+func (r *organizationRepository) Update(_ context.Context, o *storage.Organization, fields []storage.OrganizationField) (*storage.Organization, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoOrganizationFieldsToUpdate
+	}
+
+	for _, f := range fields {
+		switch f {
+		case storage.OrganizationLegalName, storage.OrganizationSlug, storage.OrganizationUpdateTime:
+		case storage.OrganizationID:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrImmutableOrganizationID)
+		case storage.OrganizationCreateTime:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrImmutableOrganizationCreateTime)
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrUnknownOrganizationField)
+		}
+	}
+
+	in := *o // the transaction applies the mutation again when it commits.
+	fields = slices.Clone(fields)
+	var updated storage.Organization
+	err := r.c.write(func(s *state) error {
+		row, ok := s.organizations[in.ID]
+		if !ok {
+			return storage.ErrOrganizationNotFound
+		}
+
+		for _, f := range fields {
+			switch f {
+			case storage.OrganizationLegalName:
+				row.LegalName = in.LegalName
+			case storage.OrganizationSlug:
+				row.Slug = in.Slug
+			case storage.OrganizationUpdateTime:
+				row.UpdateTime = in.UpdateTime
+			}
+		}
+
+		if err := row.check(s, in.ID); err != nil {
+			return err
+		}
+
+		s.organizations[in.ID] = row
+		updated = row.Organization
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// Delete an organization, which fails while it has users.
+func (r *organizationRepository) Delete(_ context.Context, id uuid.UUID) error {
+	return r.c.write(func(s *state) error {
+		if _, ok := s.organizations[id]; !ok {
+			return storage.ErrOrganizationNotFound
+		}
+
+		for _, u := range s.users {
+			if u.OrganizationID == id {
+				return storage.ErrOrganizationHasUsers
+			}
+		}
+
+		delete(s.organizations, id)
+		return nil
+	})
+}
+
+// organizationFilter returns whether an organization matches the conditions.
+func organizationFilter(conditions []storage.Condition) (func(o *storage.Organization) bool, error) {
+	var filters []func(o *storage.Organization) bool
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.OrganizationByLegalNameCondition:
+			if t.Wildcard {
+				filters = append(filters, func(o *storage.Organization) bool { return strings.Contains(o.LegalName, t.LegalName) })
+			} else {
+				filters = append(filters, func(o *storage.Organization) bool { return o.LegalName == t.LegalName })
+			}
+		default:
+			return nil, fmt.Errorf("unknown condition type: %T", c)
+		}
+	}
+
+	return func(o *storage.Organization) bool {
+		for _, f := range filters {
+			if !f(o) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// compareOrganizations compares two organizations by a field.
+func compareOrganizations(a, b *storage.Organization, f storage.OrganizationField) int {
+	switch f {
+	case storage.OrganizationID:
+		return compareUUIDs(a.ID, b.ID)
+	case storage.OrganizationLegalName:
+		return cmp.Compare(a.LegalName, b.LegalName)
+	case storage.OrganizationSlug:
+		return cmp.Compare(a.Slug, b.Slug)
+	case storage.OrganizationCreateTime:
+		return a.CreateTime.Compare(b.CreateTime)
+	case storage.OrganizationUpdateTime:
+		return a.UpdateTime.Compare(b.UpdateTime)
+	}
+	return 0
+}
+
+// List organizations.
+func (r *organizationRepository) List(_ context.Context, pagination storage.Pagination, sorting storage.OrganizationOrderBy, conditions ...storage.Condition) ([]*storage.Organization, error) {
+	match, err := organizationFilter(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	var rows []organizationRow
+	r.c.read(func(s *state) {
+		for _, row := range s.organizations {
+			if match(&row.Organization) {
+				rows = append(rows, row)
+			}
+		}
+	})
+
+	slices.SortFunc(rows, func(a, b organizationRow) int {
+		for _, sort := range sorting {
+			if c := direction(compareOrganizations(&a.Organization, &b.Organization, sort.Field), sort.Direction); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	var organizations []*storage.Organization
+	for _, row := range paginate(rows, pagination) {
+		o := row.Organization
+		organizations = append(organizations, &o)
+	}
+
+	return organizations, nil
+}
@@ -0,0 +1,318 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+const (
+	outboxEventAggregateTypeLength = 64
+	outboxEventTypeLength          = 128
+	// outboxEventLock is the advisory lock that makes sure only one relay publishes events at a time.
+	outboxEventLock = "outbox_events"
+)
+
+var _ storage.OutboxEventRepository = (*outboxEventRepository)(nil)
+
+type outboxEventRepository struct {
+	c conn
+}
+
+// checkOutboxEvent checks the constraints of the outbox_events table on e.
+func checkOutboxEvent(e *storage.OutboxEvent) error {
+	if err := maxLength(string(storage.OutboxEventAggregateType), e.AggregateType, outboxEventAggregateTypeLength); err != nil {
+		return err
+	}
+
+	if err := maxLength(string(storage.OutboxEventType), e.Type, outboxEventTypeLength); err != nil {
+		return err
+	}
+
+	if !json.Valid(e.Payload) {
+		return fmt.Errorf("%s: %w", storage.OutboxEventPayload, ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// copyOutboxEvent returns a copy of the event that does not share its payload.
+func copyOutboxEvent(e storage.OutboxEvent) *storage.OutboxEvent {
+	e.Payload = bytes.Clone(e.Payload)
+	return &e
+}
+
+// Lock makes sure only one relay publishes events until the surrounding transaction ends.
+func (r *outboxEventRepository) Lock(_ context.Context) error {
+	return r.c.lock(outboxEventLock)
+}
+
+// Create adds an event to the outbox. The id is assigned right away, the position when the
+// transaction commits.
+func (r *outboxEventRepository) Create(_ context.Context, e *storage.OutboxEvent) (*storage.OutboxEvent, error) {
+	v := storage.OutboxEvent{ // the transaction applies the mutation again when it commits.
+		ID:            r.c.nextOutboxEventID(),
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Type:          e.Type,
+		Payload:       bytes.Clone(e.Payload),
+		CreateTime:    e.CreateTime,
+	}
+	if err := checkOutboxEvent(&v); err != nil {
+		return nil, err
+	}
+
+	err := r.c.write(func(s *state) error {
+		s.outboxEvents[v.ID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return copyOutboxEvent(v), nil
+}
+
+// Get gets an event by id.
+func (r *outboxEventRepository) Get(_ context.Context, id int64) (*storage.OutboxEvent, error) {
+	var e storage.OutboxEvent
+	var ok bool
+	r.c.read(func(s *state) { e, ok = s.outboxEvents[id] })
+	if !ok {
+		return nil, storage.ErrOutboxEventNotFound
+	}
+
+	return copyOutboxEvent(e), nil
+}
+
+// Update updates the delivery state of an event. The event itself can not be changed.
+func (r *outboxEventRepository) Update(_ context.Context, in *storage.OutboxEvent, fields []storage.OutboxEventField) (*storage.OutboxEvent, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoOutboxEventFieldsToUpdate
+	}
+
+	for _, f := range fields {
+		switch f {
+		case storage.OutboxEventPublishTime, storage.OutboxEventAttempts, storage.OutboxEventLastError, storage.OutboxEventFailTime,
+			storage.OutboxEventNextAttemptTime:
+		case storage.OutboxEventID:
+			return nil, storage.ErrImmutableOutboxEventID
+		case storage.OutboxEventPosition:
+			return nil, storage.ErrImmutableOutboxEventPosition
+		case storage.OutboxEventAggregateType:
+			return nil, storage.ErrImmutableOutboxEventAggregateType
+		case storage.OutboxEventAggregateID:
+			return nil, storage.ErrImmutableOutboxEventAggregateID
+		case storage.OutboxEventType:
+			return nil, storage.ErrImmutableOutboxEventType
+		case storage.OutboxEventPayload:
+			return nil, storage.ErrImmutableOutboxEventPayload
+		case storage.OutboxEventCreateTime:
+			return nil, storage.ErrImmutableOutboxEventCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrOutboxEventUnknownField)
+		}
+	}
+
+	v := *in // the transaction applies the mutation again when it commits.
+	fields = slices.Clone(fields)
+	var updated *storage.OutboxEvent
+	err := r.c.write(func(s *state) error {
+		e, ok := s.outboxEvents[v.ID]
+		if !ok {
+			return storage.ErrOutboxEventNotFound
+		}
+
+		for _, f := range fields {
+			switch f {
+			case storage.OutboxEventPublishTime:
+				e.PublishTime = sql.NullTime{Time: v.PublishTime.Time, Valid: !v.PublishTime.Time.IsZero()}
+			case storage.OutboxEventAttempts:
+				e.Attempts = v.Attempts
+			case storage.OutboxEventLastError:
+				e.LastError = v.LastError
+			case storage.OutboxEventFailTime:
+				e.FailTime = sql.NullTime{Time: v.FailTime.Time, Valid: !v.FailTime.Time.IsZero()}
+			case storage.OutboxEventNextAttemptTime:
+				e.NextAttemptTime = sql.NullTime{Time: v.NextAttemptTime.Time, Valid: !v.NextAttemptTime.Time.IsZero()}
+			}
+		}
+
+		s.outboxEvents[v.ID] = e
+		updated = copyOutboxEvent(e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Purge deletes the events published before the time. It keeps the latest event and the events after
+// the oldest one that is not published, such as an event the relay gave up on.
+func (r *outboxEventRepository) Purge(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	err := r.c.write(func(s *state) error {
+		n = 0
+		var latest int64
+		keep := int64(math.MaxInt64) // the events from this position on are kept.
+		for _, e := range s.outboxEvents {
+			if e.Position == 0 {
+				continue
+			}
+
+			latest = max(latest, e.Position)
+			if !e.PublishTime.Valid {
+				keep = min(keep, e.Position)
+			}
+		}
+		keep = min(keep, latest)
+
+		for id, e := range s.outboxEvents {
+			if e.Position != 0 && e.Position < keep && e.PublishTime.Valid && e.PublishTime.Time.Before(before) {
+				delete(s.outboxEvents, id)
+				n++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// Redrive hands the parked events with the ids back to the relay, all parked events without ids.
+// Their attempts start over, the last error is kept until the next attempt.
+func (r *outboxEventRepository) Redrive(_ context.Context, ids ...int64) (int64, error) {
+	ids = slices.Clone(ids)
+	var n int64
+	err := r.c.write(func(s *state) error {
+		n = 0
+		for id, e := range s.outboxEvents {
+			if !e.FailTime.Valid || (len(ids) > 0 && !slices.Contains(ids, id)) {
+				continue
+			}
+
+			e.FailTime = sql.NullTime{}
+			e.NextAttemptTime = sql.NullTime{}
+			e.Attempts = 0
+			s.outboxEvents[id] = e
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// outboxEventFilter returns whether an event matches the conditions.
+func outboxEventFilter(conditions []storage.Condition) (func(e *storage.OutboxEvent) bool, error) {
+	var filters []func(e *storage.OutboxEvent) bool
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.OutboxEventUnpublishedCondition:
+			filters = append(filters, func(e *storage.OutboxEvent) bool { return !e.PublishTime.Valid })
+		case storage.OutboxEventAfterPositionCondition:
+			// an event without a position is not committed yet, its position is NULL in Postgres.
+			filters = append(filters, func(e *storage.OutboxEvent) bool { return e.Position != 0 && e.Position > t.Position })
+		case storage.OutboxEventByAggregateTypeCondition:
+			filters = append(filters, func(e *storage.OutboxEvent) bool { return e.AggregateType == t.AggregateType })
+		default:
+			return nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return func(e *storage.OutboxEvent) bool {
+		for _, f := range filters {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// compareOutboxEvents compares two events by a field.
+func compareOutboxEvents(a, b *storage.OutboxEvent, f storage.OutboxEventField) int {
+	switch f {
+	case storage.OutboxEventID:
+		return cmp.Compare(a.ID, b.ID)
+	case storage.OutboxEventPosition:
+		// NULL sorts after every position.
+		switch {
+		case a.Position != 0 && b.Position != 0:
+			return cmp.Compare(a.Position, b.Position)
+		case a.Position != 0:
+			return -1
+		case b.Position != 0:
+			return 1
+		}
+	case storage.OutboxEventAggregateType:
+		return cmp.Compare(a.AggregateType, b.AggregateType)
+	case storage.OutboxEventAggregateID:
+		return compareUUIDs(a.AggregateID, b.AggregateID)
+	case storage.OutboxEventType:
+		return cmp.Compare(a.Type, b.Type)
+	case storage.OutboxEventPayload:
+		return bytes.Compare(a.Payload, b.Payload)
+	case storage.OutboxEventCreateTime:
+		return a.CreateTime.Compare(b.CreateTime)
+	case storage.OutboxEventPublishTime:
+		return compareNullTimes(a.PublishTime, b.PublishTime)
+	case storage.OutboxEventAttempts:
+		return cmp.Compare(a.Attempts, b.Attempts)
+	case storage.OutboxEventLastError:
+		return cmp.Compare(a.LastError, b.LastError)
+	case storage.OutboxEventFailTime:
+		return compareNullTimes(a.FailTime, b.FailTime)
+	case storage.OutboxEventNextAttemptTime:
+		return compareNullTimes(a.NextAttemptTime, b.NextAttemptTime)
+	}
+	return 0
+}
+
+// List outbox events.
+func (r *outboxEventRepository) List(_ context.Context, pagination storage.Pagination, sorting storage.OutboxEventOrderBy, conditions ...storage.Condition) ([]*storage.OutboxEvent, error) {
+	match, err := outboxEventFilter(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox events: %w", err)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	var events []*storage.OutboxEvent
+	r.c.read(func(s *state) {
+		for _, e := range s.outboxEvents {
+			if match(&e) {
+				events = append(events, copyOutboxEvent(e))
+			}
+		}
+	})
+
+	slices.SortFunc(events, func(a, b *storage.OutboxEvent) int {
+		for _, sort := range sorting {
+			if c := direction(compareOutboxEvents(a, b, sort.Field), sort.Direction); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return paginate(events, pagination), nil
+}
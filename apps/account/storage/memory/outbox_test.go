@@ -0,0 +1,216 @@
+package memory_test
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/memory"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func newOutboxEvent(eventType string) *storage.OutboxEvent {
+	return &storage.OutboxEvent{
+		AggregateType: "user",
+		AggregateID:   uuid.MustParse("957b12c5-1071-40d9-8bec-6ed195c8cfbf"),
+		Type:          eventType,
+		Payload:       []byte(`{}`),
+		CreateTime:    now,
+	}
+}
+
+func TestOutboxEventRepository_positions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should order the events by commit", func(t *testing.T) {
+		m := memory.NewManager()
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			created, err := r.OutboxEvent.Create(ctx, newOutboxEvent("first"))
+			if err != nil {
+				return err
+			}
+
+			if created.Position != 0 {
+				t.Errorf("expected no position before the commit, got %d", created.Position)
+			}
+
+			// the event of the operation is created first, but committed last.
+			_, err = m.Op().OutboxEvent.Create(ctx, newOutboxEvent("second"))
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		events, err := m.Op().OutboxEvent.List(ctx, storage.Pagination{}, storage.OutboxEventOrderBy{
+			{Field: storage.OutboxEventPosition, Direction: storage.ASC},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, e := range events {
+			got = append(got, e.Type+":"+strconv.FormatInt(e.ID, 10)+":"+strconv.FormatInt(e.Position, 10))
+		}
+
+		if diff := cmp.Diff([]string{"second:2:1", "first:1:2"}, got); diff != "" {
+			t.Errorf("events mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should not list the events of an operation after a position before it commits", func(t *testing.T) {
+		m := memory.NewManager()
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if _, err := r.OutboxEvent.Create(ctx, newOutboxEvent("test")); err != nil {
+				return err
+			}
+
+			events, err := r.OutboxEvent.List(ctx, storage.Pagination{}, nil, storage.OutboxEventAfterPositionCondition{})
+			if err != nil {
+				return err
+			}
+
+			if len(events) != 0 {
+				t.Errorf("expected no events, got %d", len(events))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("should notify the listeners of the latest position", func(t *testing.T) {
+		m := memory.NewManager()
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		payloads := make(chan string, 10)
+		go func() {
+			_ = m.Listen(ctx, func(payload string) { payloads <- payload })
+		}()
+
+		// the listener may not be registered yet, keep creating events until it is notified.
+		var got string
+		for got == "" {
+			if _, err := m.Op().OutboxEvent.Create(ctx, newOutboxEvent("test")); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case got = <-payloads:
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+
+		position, err := strconv.ParseInt(got, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		events, err := m.Op().OutboxEvent.List(ctx, storage.Pagination{}, nil, storage.OutboxEventAfterPositionCondition{Position: position})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if position == 0 || len(events) != 0 {
+			t.Errorf("expected the position of the latest event, got %d with %d events after it", position, len(events))
+		}
+	})
+}
+
+func TestOutboxEventRepository_Redrive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should hand the parked events back to the relay", func(t *testing.T) {
+		r := memory.NewManager().Op().OutboxEvent
+		var ids []int64
+		for i := range 3 {
+			e, err := r.Create(ctx, newOutboxEvent("user.updated"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, e.ID)
+
+			if i == 0 {
+				continue
+			}
+
+			e.FailTime = sql.NullTime{Time: now, Valid: true}
+			e.Attempts = 10
+			if _, err = r.Update(ctx, e, []storage.OutboxEventField{storage.OutboxEventFailTime, storage.OutboxEventAttempts}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		n, err := r.Redrive(ctx, ids[0], ids[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 1 {
+			t.Errorf("expected only the parked event to be redriven, got %d", n)
+		}
+
+		e, err := r.Get(ctx, ids[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if e.FailTime.Valid || e.Attempts != 0 {
+			t.Errorf("expected the attempts of the event to start over, got %+v", e)
+		}
+
+		if n, err = r.Redrive(ctx); err != nil || n != 1 {
+			t.Errorf("expected the other parked event to be redriven, got %d, %v", n, err)
+		}
+	})
+}
+
+func TestOutboxEventRepository_Purge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep the events after the oldest unpublished one and the latest event", func(t *testing.T) {
+		m := memory.NewManager()
+		r := m.Op().OutboxEvent
+		var ids []int64
+		for range 5 {
+			e, err := r.Create(ctx, newOutboxEvent("user.updated"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, e.ID)
+		}
+
+		// all but the third event are published.
+		for i, id := range ids {
+			if i == 2 {
+				continue
+			}
+
+			if _, err := r.Update(ctx, &storage.OutboxEvent{ID: id, PublishTime: sql.NullTime{Time: now, Valid: true}}, []storage.OutboxEventField{storage.OutboxEventPublishTime}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		n, err := r.Purge(ctx, now.Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 2 {
+			t.Errorf("expected the 2 events before the unpublished one to be purged, got %d", n)
+		}
+
+		for _, id := range ids[2:] {
+			if _, err = r.Get(ctx, id); err != nil {
+				t.Errorf("expected event %d to be kept, got %v", id, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+const registrationTokenEmailLength = 128
+
+var _ storage.RegistrationTokenRepository = (*registrationTokenRepository)(nil)
+
+type registrationTokenRepository struct {
+	c conn
+}
+
+// Use stores the token as used. It returns storage.ErrRegistrationTokenUsed when the token was used before.
+func (r *registrationTokenRepository) Use(_ context.Context, in *storage.RegistrationToken) error {
+	v := *in // the transaction applies the mutation again when it commits.
+	return r.c.write(func(s *state) error {
+		if _, ok := s.registrationTokens[v.ID]; ok {
+			return storage.ErrRegistrationTokenUsed
+		}
+
+		if err := maxLength("email", v.Email, registrationTokenEmailLength); err != nil {
+			return err
+		}
+
+		s.registrationTokens[v.ID] = v
+		return nil
+	})
+}
+
+// Purge deletes the tokens that expired before the time.
+func (r *registrationTokenRepository) Purge(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	err := r.c.write(func(s *state) error {
+		n = 0
+		for id, t := range s.registrationTokens {
+			if t.ExpireTime.Before(before) {
+				delete(s.registrationTokens, id)
+				n++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
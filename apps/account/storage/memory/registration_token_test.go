@@ -0,0 +1,65 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+func TestRegistrationTokenRepository(t *testing.T) {
+	ctx := context.Background()
+	token := &storage.RegistrationToken{
+		ID:         uuid.MustParse("3f0d9a4e-2b1c-4e7d-9a8b-6c5d4e3f2a19"),
+		Email:      "jane@example.com",
+		UseTime:    now,
+		ExpireTime: now.Add(24 * time.Hour),
+	}
+
+	t.Run("should use a token only once", func(t *testing.T) {
+		m := newManager(t)
+		if err := m.Op().RegistrationToken.Use(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Op().RegistrationToken.Use(ctx, token); !errors.Is(err, storage.ErrRegistrationTokenUsed) {
+			t.Errorf("Use() error = %v, want %v", err, storage.ErrRegistrationTokenUsed)
+		}
+	})
+
+	t.Run("should not use the token of a rolled back registration", func(t *testing.T) {
+		m := newManager(t)
+		failed := errors.New("failed to create user")
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if err := r.RegistrationToken.Use(ctx, token); err != nil {
+				return err
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("BeginOp() error = %v, want %v", err, failed)
+		}
+
+		if err = m.Op().RegistrationToken.Use(ctx, token); err != nil {
+			t.Errorf("Use() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("should purge the expired tokens", func(t *testing.T) {
+		m := newManager(t)
+		if err := m.Op().RegistrationToken.Use(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+
+		if n, err := m.Op().RegistrationToken.Purge(ctx, token.ExpireTime); err != nil || n != 0 {
+			t.Errorf("Purge() = %d, %v, want 0, nil", n, err)
+		}
+
+		if n, err := m.Op().RegistrationToken.Purge(ctx, token.ExpireTime.Add(time.Second)); err != nil || n != 1 {
+			t.Errorf("Purge() = %d, %v, want 1, nil", n, err)
+		}
+	})
+}
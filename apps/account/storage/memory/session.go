@@ -0,0 +1,296 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	sessionUserAgentLength    = 512
+	sessionIPAddressLength    = 45
+	sessionImpersonatorLength = 255
+)
+
+var _ storage.SessionRepository = (*sessionRepository)(nil)
+
+type sessionRepository struct {
+	c conn
+}
+
+// check checks the constraints of the sessions table on s.
+func (r sessionRow) check(s *state) error {
+	for _, err := range []error{
+		maxLength(string(storage.SessionUserAgent), r.UserAgent, sessionUserAgentLength),
+		maxLength(string(storage.SessionIPAddress), r.IPAddress, sessionIPAddressLength),
+		maxLength(string(storage.SessionImpersonator), r.Impersonator, sessionImpersonatorLength),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, ok := s.users[r.UserID]; !ok {
+		return fmt.Errorf("%s %s: %w", storage.SessionUserID, r.UserID, ErrForeignKeyViolation)
+	}
+
+	return nil
+}
+
+// Create a new session.
+func (r *sessionRepository) Create(_ context.Context, in *storage.Session) (*storage.Session, error) {
+	v := *in // the transaction applies the mutation again when it commits.
+	var created storage.Session
+	err := r.c.write(func(s *state) error {
+		if _, ok := s.sessions[v.ID]; ok {
+			return storage.ErrConflictSessionID
+		}
+
+		row := sessionRow{Session: v, seq: s.seq + 1}
+		if err := row.check(s); err != nil {
+			return err
+		}
+
+		s.seq++
+		s.sessions[v.ID] = row
+		created = row.Session
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Get a session by id.
+func (r *sessionRepository) Get(_ context.Context, id uuid.UUID) (*storage.Session, error) {
+	var row sessionRow
+	var ok bool
+	r.c.read(func(s *state) { row, ok = s.sessions[id] })
+	if !ok {
+		return nil, storage.ErrSessionNotFound
+	}
+
+	return &row.Session, nil
+}
+
+// Update the fields of a session.
+func (r *sessionRepository) Update(_ context.Context, in *storage.Session, fields []storage.SessionField) (*storage.Session, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoSessionFieldsToUpdate
+	}
+
+	for _, f := range fields {
+		switch f {
+		case storage.SessionUserAgent, storage.SessionIPAddress, storage.SessionRefreshFamily,
+			storage.SessionRefreshTokenID, storage.SessionLastRefreshTime, storage.SessionExpireTime,
+			storage.SessionRevokeTime:
+		case storage.SessionID:
+			return nil, storage.ErrImmutableSessionID
+		case storage.SessionUserID:
+			return nil, storage.ErrImmutableSessionUserID
+		case storage.SessionCreateTime:
+			return nil, storage.ErrImmutableSessionCreateTime
+		case storage.SessionImpersonator:
+			return nil, storage.ErrImmutableSessionImpersonator
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrSessionUnknownField)
+		}
+	}
+
+	v := *in // the transaction applies the mutation again when it commits.
+	fields = slices.Clone(fields)
+	var updated storage.Session
+	err := r.c.write(func(s *state) error {
+		row, ok := s.sessions[v.ID]
+		if !ok {
+			return storage.ErrSessionNotFound
+		}
+
+		for _, f := range fields {
+			switch f {
+			case storage.SessionUserAgent:
+				row.UserAgent = v.UserAgent
+			case storage.SessionIPAddress:
+				row.IPAddress = v.IPAddress
+			case storage.SessionRefreshFamily:
+				row.RefreshFamily = v.RefreshFamily
+			case storage.SessionRefreshTokenID:
+				row.RefreshTokenID = v.RefreshTokenID
+			case storage.SessionLastRefreshTime:
+				row.LastRefreshTime = v.LastRefreshTime
+			case storage.SessionExpireTime:
+				row.ExpireTime = v.ExpireTime
+			case storage.SessionRevokeTime:
+				row.RevokeTime = sql.NullTime{Time: v.RevokeTime.Time, Valid: !v.RevokeTime.Time.IsZero()}
+			}
+		}
+
+		if err := row.check(s); err != nil {
+			return err
+		}
+
+		s.sessions[v.ID] = row
+		updated = row.Session
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// RotateRefreshToken replaces the refresh token of the session if it is still previousTokenID.
+func (r *sessionRepository) RotateRefreshToken(_ context.Context, in *storage.Session, previousTokenID uuid.UUID) (*storage.Session, error) {
+	v := *in // the transaction applies the mutation again when it commits.
+	var rotated storage.Session
+	err := r.c.write(func(s *state) error {
+		row, ok := s.sessions[v.ID]
+		if !ok || row.RefreshTokenID != previousTokenID || row.RevokeTime.Valid {
+			return storage.ErrStaleSessionRefreshToken
+		}
+
+		row.RefreshTokenID = v.RefreshTokenID
+		row.LastRefreshTime = v.LastRefreshTime
+		row.ExpireTime = v.ExpireTime
+		s.sessions[v.ID] = row
+		rotated = row.Session
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rotated, nil
+}
+
+// Delete a session.
+func (r *sessionRepository) Delete(_ context.Context, id uuid.UUID) error {
+	return r.c.write(func(s *state) error {
+		if _, ok := s.sessions[id]; !ok {
+			return storage.ErrSessionNotFound
+		}
+
+		delete(s.sessions, id)
+		return nil
+	})
+}
+
+// Purge deletes the sessions that expired or were revoked before the time.
+func (r *sessionRepository) Purge(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	err := r.c.write(func(s *state) error {
+		n = 0
+		for id, row := range s.sessions {
+			if row.ExpireTime.Before(before) || (row.RevokeTime.Valid && row.RevokeTime.Time.Before(before)) {
+				delete(s.sessions, id)
+				n++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// sessionFilter returns whether a session matches the conditions.
+func sessionFilter(conditions []storage.Condition) (func(s *storage.Session) bool, error) {
+	var filters []func(s *storage.Session) bool
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.SessionByUserIDCondition:
+			filters = append(filters, func(s *storage.Session) bool { return s.UserID == t.UserID })
+		case storage.SessionActiveCondition:
+			filters = append(filters, func(s *storage.Session) bool { return !s.RevokeTime.Valid && s.ExpireTime.After(t.Time) })
+		default:
+			return nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return func(s *storage.Session) bool {
+		for _, f := range filters {
+			if !f(s) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// compareSessions compares two sessions by a field.
+func compareSessions(a, b *storage.Session, f storage.SessionField) int {
+	switch f {
+	case storage.SessionID:
+		return compareUUIDs(a.ID, b.ID)
+	case storage.SessionUserID:
+		return compareUUIDs(a.UserID, b.UserID)
+	case storage.SessionUserAgent:
+		return cmp.Compare(a.UserAgent, b.UserAgent)
+	case storage.SessionIPAddress:
+		return cmp.Compare(a.IPAddress, b.IPAddress)
+	case storage.SessionRefreshFamily:
+		return compareUUIDs(a.RefreshFamily, b.RefreshFamily)
+	case storage.SessionRefreshTokenID:
+		return compareUUIDs(a.RefreshTokenID, b.RefreshTokenID)
+	case storage.SessionCreateTime:
+		return a.CreateTime.Compare(b.CreateTime)
+	case storage.SessionLastRefreshTime:
+		return a.LastRefreshTime.Compare(b.LastRefreshTime)
+	case storage.SessionExpireTime:
+		return a.ExpireTime.Compare(b.ExpireTime)
+	case storage.SessionRevokeTime:
+		return compareNullTimes(a.RevokeTime, b.RevokeTime)
+	case storage.SessionImpersonator:
+		return cmp.Compare(a.Impersonator, b.Impersonator)
+	}
+	return 0
+}
+
+// List sessions.
+func (r *sessionRepository) List(_ context.Context, pagination storage.Pagination, sorting storage.SessionOrderBy, conditions ...storage.Condition) ([]*storage.Session, error) {
+	match, err := sessionFilter(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	var rows []sessionRow
+	r.c.read(func(s *state) {
+		for _, row := range s.sessions {
+			if match(&row.Session) {
+				rows = append(rows, row)
+			}
+		}
+	})
+
+	slices.SortFunc(rows, func(a, b sessionRow) int {
+		for _, sort := range sorting {
+			if c := direction(compareSessions(&a.Session, &b.Session, sort.Field), sort.Direction); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	var sessions []*storage.Session
+	for _, row := range paginate(rows, pagination) {
+		s := row.Session
+		sessions = append(sessions, &s)
+	}
+
+	return sessions, nil
+}
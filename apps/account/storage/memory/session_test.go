@@ -0,0 +1,53 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/memory"
+	"github.com/google/uuid"
+)
+
+func TestSessionRepository_user(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.MustParse("957b12c5-1071-40d9-8bec-6ed195c8cfbf")
+	sessionID := uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770")
+	session := &storage.Session{
+		ID:              sessionID,
+		UserID:          userID,
+		RefreshFamily:   uuid.New(),
+		RefreshTokenID:  uuid.New(),
+		CreateTime:      now,
+		LastRefreshTime: now,
+		ExpireTime:      now.Add(time.Hour),
+	}
+
+	t.Run("should return an error if the user does not exist", func(t *testing.T) {
+		m := newManager(t)
+		if _, err := m.Op().Session.Create(ctx, session); !errors.Is(err, memory.ErrForeignKeyViolation) {
+			t.Errorf("Create() error = %v, want %v", err, memory.ErrForeignKeyViolation)
+		}
+	})
+
+	t.Run("should delete the sessions of a deleted user", func(t *testing.T) {
+		m := newManager(t)
+		if _, err := m.Op().User.Create(ctx, newUser(userID.String(), "test@test.com")); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.Op().Session.Create(ctx, session); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Op().User.Delete(ctx, userID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.Op().Session.Get(ctx, sessionID); !errors.Is(err, storage.ErrSessionNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, storage.ErrSessionNotFound)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+// Errors of the column types and constraints of the schema, which Postgres reports without a storage error.
+var (
+	ErrValueTooLong        = errors.New("value too long")
+	ErrInvalidValue        = errors.New("invalid value")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrUniqueViolation     = errors.New("unique violation")
+)
+
+// maxLength checks the length of a VARCHAR(n) column, which counts characters.
+func maxLength(column, v string, n int) error {
+	if utf8.RuneCountInString(v) > n {
+		return fmt.Errorf("%s is longer than %d characters: %w", column, n, ErrValueTooLong)
+	}
+	return nil
+}
+
+// enum checks the value of an enum column.
+func enum(column, v string, values []string) error {
+	if !slices.Contains(values, v) {
+		return fmt.Errorf("%s %q: %w", column, v, ErrInvalidValue)
+	}
+	return nil
+}
+
+// compareEnums compares enum values by their order in the type, like Postgres does.
+func compareEnums(values []string, a, b string) int {
+	return slices.Index(values, a) - slices.Index(values, b)
+}
+
+// compareUUIDs compares UUIDs by their bytes, like Postgres does.
+func compareUUIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// compareNullTimes compares nullable times, NULL sorts after every time like in Postgres.
+func compareNullTimes(a, b sql.NullTime) int {
+	switch {
+	case a.Valid && b.Valid:
+		return a.Time.Compare(b.Time)
+	case a.Valid:
+		return -1
+	case b.Valid:
+		return 1
+	}
+	return 0
+}
+
+// direction turns an ascending comparison into one in the direction.
+func direction(c int, d storage.Direction) int {
+	if d == storage.DESC {
+		return -c
+	}
+	return c
+}
+
+// paginate returns the page of the rows, a limit or offset of 0 is no limit or offset.
+func paginate[T any](rows []T, p storage.Pagination) []T {
+	if p.Offset > 0 {
+		rows = rows[min(p.Offset, len(rows)):]
+	}
+
+	if p.Limit > 0 {
+		rows = rows[:min(p.Limit, len(rows))]
+	}
+
+	return rows
+}
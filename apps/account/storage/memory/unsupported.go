@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+// ErrUnsupported is returned by the repositories the memory backend does not implement.
+var ErrUnsupported = errors.New("not supported by the memory storage")
+
+var (
+	_ storage.WebhookRepository            = unsupportedWebhookRepository{}
+	_ storage.WebhookDeliveryRepository    = unsupportedWebhookDeliveryRepository{}
+	_ storage.ConversationRepository       = unsupportedConversationRepository{}
+	_ storage.ParticipantRepository        = unsupportedParticipantRepository{}
+	_ storage.MessageRepository            = unsupportedMessageRepository{}
+	_ storage.MessageTranslationRepository = unsupportedMessageTranslationRepository{}
+	_ storage.GlossaryRepository           = unsupportedGlossaryRepository{}
+	_ storage.GlossaryTermRepository       = unsupportedGlossaryTermRepository{}
+	_ storage.GlossarySegmentRepository    = unsupportedGlossarySegmentRepository{}
+)
+
+type unsupportedWebhookRepository struct{}
+
+func (unsupportedWebhookRepository) Get(context.Context, uuid.UUID) (*storage.Webhook, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedWebhookRepository) List(context.Context, storage.Pagination, storage.WebhookOrderBy, ...storage.Condition) ([]*storage.Webhook, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedWebhookRepository) Create(context.Context, *storage.Webhook) (*storage.Webhook, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedWebhookRepository) Update(context.Context, *storage.Webhook, []storage.WebhookField) (*storage.Webhook, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedWebhookRepository) Delete(context.Context, uuid.UUID) error {
+	return ErrUnsupported
+}
+
+type unsupportedWebhookDeliveryRepository struct{}
+
+func (unsupportedWebhookDeliveryRepository) Get(context.Context, uuid.UUID) (*storage.WebhookDelivery, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedWebhookDeliveryRepository) List(context.Context, storage.Pagination, storage.WebhookDeliveryOrderBy, ...storage.Condition) ([]*storage.WebhookDelivery, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedWebhookDeliveryRepository) Lock(context.Context) error {
+	return ErrUnsupported
+}
+
+func (unsupportedWebhookDeliveryRepository) Create(context.Context, *storage.WebhookDelivery) (*storage.WebhookDelivery, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedWebhookDeliveryRepository) Update(context.Context, *storage.WebhookDelivery, []storage.WebhookDeliveryField) (*storage.WebhookDelivery, error) {
+	return nil, ErrUnsupported
+}
+
+type unsupportedConversationRepository struct{}
+
+func (unsupportedConversationRepository) Get(context.Context, uuid.UUID) (*storage.Conversation, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedConversationRepository) List(context.Context, storage.Pagination, storage.ConversationOrderBy, ...storage.Condition) ([]*storage.Conversation, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedConversationRepository) Create(context.Context, *storage.Conversation) (*storage.Conversation, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedConversationRepository) Update(context.Context, *storage.Conversation, []storage.ConversationField) (*storage.Conversation, error) {
+	return nil, ErrUnsupported
+}
+
+type unsupportedParticipantRepository struct{}
+
+func (unsupportedParticipantRepository) Get(context.Context, uuid.UUID, uuid.UUID) (*storage.Participant, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedParticipantRepository) List(context.Context, storage.Pagination, storage.ParticipantOrderBy, ...storage.Condition) ([]*storage.Participant, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedParticipantRepository) Create(context.Context, *storage.Participant) (*storage.Participant, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedParticipantRepository) Delete(context.Context, uuid.UUID, uuid.UUID) error {
+	return ErrUnsupported
+}
+
+type unsupportedMessageRepository struct{}
+
+func (unsupportedMessageRepository) Get(context.Context, uuid.UUID) (*storage.Message, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedMessageRepository) List(context.Context, storage.Pagination, storage.MessageOrderBy, ...storage.Condition) ([]*storage.Message, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedMessageRepository) Create(context.Context, *storage.Message) (*storage.Message, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedMessageRepository) Update(context.Context, *storage.Message, []storage.MessageField) (*storage.Message, error) {
+	return nil, ErrUnsupported
+}
+
+type unsupportedMessageTranslationRepository struct{}
+
+func (unsupportedMessageTranslationRepository) Get(context.Context, uuid.UUID, string) (*storage.MessageTranslation, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedMessageTranslationRepository) List(context.Context, storage.Pagination, storage.MessageTranslationOrderBy, ...storage.Condition) ([]*storage.MessageTranslation, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedMessageTranslationRepository) Upsert(context.Context, *storage.MessageTranslation) error {
+	return ErrUnsupported
+}
+
+type unsupportedGlossaryRepository struct{}
+
+func (unsupportedGlossaryRepository) Get(context.Context, uuid.UUID) (*storage.Glossary, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryRepository) List(context.Context, storage.Pagination, storage.GlossaryOrderBy, ...storage.Condition) ([]*storage.Glossary, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryRepository) Create(context.Context, *storage.Glossary) (*storage.Glossary, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryRepository) Update(context.Context, *storage.Glossary, []storage.GlossaryField) (*storage.Glossary, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryRepository) Delete(context.Context, uuid.UUID) error {
+	return ErrUnsupported
+}
+
+type unsupportedGlossaryTermRepository struct{}
+
+func (unsupportedGlossaryTermRepository) Get(context.Context, uuid.UUID) (*storage.GlossaryTerm, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryTermRepository) List(context.Context, storage.Pagination, storage.GlossaryTermOrderBy, ...storage.Condition) ([]*storage.GlossaryTerm, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryTermRepository) Create(context.Context, *storage.GlossaryTerm) (*storage.GlossaryTerm, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryTermRepository) Update(context.Context, *storage.GlossaryTerm, []storage.GlossaryTermField) (*storage.GlossaryTerm, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossaryTermRepository) Delete(context.Context, uuid.UUID) error {
+	return ErrUnsupported
+}
+
+type unsupportedGlossarySegmentRepository struct{}
+
+func (unsupportedGlossarySegmentRepository) Get(context.Context, uuid.UUID) (*storage.GlossarySegment, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossarySegmentRepository) List(context.Context, storage.Pagination, storage.GlossarySegmentOrderBy, ...storage.Condition) ([]*storage.GlossarySegment, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossarySegmentRepository) Search(context.Context, storage.GlossarySegmentSearch, ...storage.Condition) ([]*storage.GlossarySegmentMatch, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossarySegmentRepository) Create(context.Context, *storage.GlossarySegment) (*storage.GlossarySegment, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedGlossarySegmentRepository) Delete(context.Context, uuid.UUID) error {
+	return ErrUnsupported
+}
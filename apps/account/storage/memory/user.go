@@ -0,0 +1,308 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	userDisplayNameLength       = 16
+	userEmailLength             = 128
+	userPreferredLanguageLength = 35
+	// userActive is the status of the users whose email is unique.
+	userActive = "active"
+)
+
+var (
+	// userStatuses and userRoles are the values of the user_status and user_role enums, in order.
+	userStatuses = []string{"active", "inactive"}
+	userRoles    = []string{"user", "admin", "system"}
+)
+
+var _ storage.UserRepository = (*userRepository)(nil)
+
+type userRepository struct {
+	c conn
+}
+
+// check checks the constraints of the users table on u, which is stored as id.
+func (u userRow) check(s *state, id uuid.UUID) error {
+	for _, err := range []error{
+		maxLength(string(storage.UserDisplayName), u.DisplayName, userDisplayNameLength),
+		maxLength(string(storage.UserEmail), u.Email, userEmailLength),
+		maxLength(string(storage.UserPreferredLanguage), u.PreferredLanguage, userPreferredLanguageLength),
+		enum(string(storage.UserStatus), u.Status, userStatuses),
+		enum(string(storage.UserRole), u.Role, userRoles),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	// users_unique_active_email
+	if u.active() {
+		for otherID, other := range s.users {
+			if otherID != id && other.active() && other.Email == u.Email {
+				return storage.ErrConflictUserEmail
+			}
+		}
+	}
+
+	if _, ok := s.organizations[u.OrganizationID]; !ok {
+		return storage.ErrUserOrganizationNotFound
+	}
+
+	return nil
+}
+
+// active reports whether the user counts for the unique email of active users.
+func (u userRow) active() bool {
+	return u.Status == userActive && !u.DeleteTime.Valid
+}
+
+// withoutPassword returns the user as the queries that do not select the hashed password return it.
+func (u userRow) withoutPassword() *storage.User {
+	user := u.User
+	user.HashedPassword = ""
+	return &user
+}
+
+// Create a new user.
+func (r *userRepository) Create(_ context.Context, u *storage.User) (*storage.User, error) {
+	in := *u // the transaction applies the mutation again when it commits.
+	var created *storage.User
+	err := r.c.write(func(s *state) error {
+		if _, ok := s.users[in.ID]; ok {
+			return storage.ErrConflictUserID
+		}
+
+		row := userRow{User: in, seq: s.seq + 1}
+		if err := row.check(s, in.ID); err != nil {
+			return err
+		}
+
+		s.seq++
+		s.users[in.ID] = row
+		created = row.withoutPassword()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Get a user by id.
+func (r *userRepository) Get(_ context.Context, id uuid.UUID) (*storage.User, error) {
+	var u storage.User
+	var ok bool
+	r.c.read(func(s *state) {
+		var row userRow
+		row, ok = s.users[id]
+		u = row.User
+	})
+	if !ok {
+		return nil, storage.ErrUserNotFound
+	}
+
+	return &u, nil
+}
+
+// GetByEmail gets the first user with the email, including the hashed password.
+func (r *userRepository) GetByEmail(_ context.Context, email string) (*storage.User, error) {
+	var found *userRow
+	r.c.read(func(s *state) {
+		for _, row := range s.users {
+			if row.Email == email && (found == nil || row.seq < found.seq) {
+				found = &row
+			}
+		}
+	})
+	if found == nil {
+		return nil, storage.ErrUserNotFound
+	}
+
+	u := found.User
+	return &u, nil
+}
+
+// Update the fields of a user.
+func (r *userRepository) Update(_ context.Context, u *storage.User, fields []storage.UserField) (*storage.User, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoUserFieldsToUpdate
+	}
+
+	for _, f := range fields {
+		switch f {
+		case storage.UserDisplayName, storage.UserEmail, storage.UserHashedPassword, storage.UserUpdateTime,
+			storage.UserOrganizationID, storage.UserStatus, storage.UserRole, storage.UserPreferredLanguage,
+			storage.UserDeleteTime:
+		case storage.UserID:
+			return nil, storage.ErrImmutableUserID
+		case storage.UserCreateTime:
+			return nil, storage.ErrImmutableUserCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrUserUnknownField)
+		}
+	}
+
+	in := *u // the transaction applies the mutation again when it commits.
+	fields = slices.Clone(fields)
+	var updated *storage.User
+	err := r.c.write(func(s *state) error {
+		row, ok := s.users[in.ID]
+		if !ok {
+			return storage.ErrUserNotFound
+		}
+
+		for _, f := range fields {
+			switch f {
+			case storage.UserDisplayName:
+				row.DisplayName = in.DisplayName
+			case storage.UserEmail:
+				row.Email = in.Email
+			case storage.UserHashedPassword:
+				row.HashedPassword = in.HashedPassword
+			case storage.UserUpdateTime:
+				row.UpdateTime = in.UpdateTime
+			case storage.UserOrganizationID:
+				row.OrganizationID = in.OrganizationID
+			case storage.UserStatus:
+				row.Status = in.Status
+			case storage.UserRole:
+				row.Role = in.Role
+			case storage.UserPreferredLanguage:
+				row.PreferredLanguage = in.PreferredLanguage
+			case storage.UserDeleteTime:
+				row.DeleteTime = sql.NullTime{Time: in.DeleteTime.Time, Valid: !in.DeleteTime.Time.IsZero()}
+			}
+		}
+
+		if err := row.check(s, in.ID); err != nil {
+			return err
+		}
+
+		s.users[in.ID] = row
+		updated = row.withoutPassword()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Delete a user and, like the foreign key of the sessions table, their sessions.
+func (r *userRepository) Delete(_ context.Context, id uuid.UUID) error {
+	return r.c.write(func(s *state) error {
+		if _, ok := s.users[id]; !ok {
+			return storage.ErrUserNotFound
+		}
+
+		delete(s.users, id)
+		for sessionID, session := range s.sessions {
+			if session.UserID == id {
+				delete(s.sessions, sessionID)
+			}
+		}
+		return nil
+	})
+}
+
+// userFilter returns whether a user matches the conditions.
+func userFilter(conditions []storage.Condition) (func(u *storage.User) bool, error) {
+	var filters []func(u *storage.User) bool
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.UserByOrganizationIDCondition:
+			filters = append(filters, func(u *storage.User) bool { return u.OrganizationID == t.OrganizationID })
+		case storage.UserByIDsCondition:
+			filters = append(filters, func(u *storage.User) bool { return slices.Contains(t.IDs, u.ID) })
+		default:
+			return nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return func(u *storage.User) bool {
+		for _, f := range filters {
+			if !f(u) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// compareUsers compares two users by a field.
+func compareUsers(a, b *storage.User, f storage.UserField) int {
+	switch f {
+	case storage.UserID:
+		return compareUUIDs(a.ID, b.ID)
+	case storage.UserOrganizationID:
+		return compareUUIDs(a.OrganizationID, b.OrganizationID)
+	case storage.UserDisplayName:
+		return cmp.Compare(a.DisplayName, b.DisplayName)
+	case storage.UserEmail:
+		return cmp.Compare(a.Email, b.Email)
+	case storage.UserHashedPassword:
+		return cmp.Compare(a.HashedPassword, b.HashedPassword)
+	case storage.UserStatus:
+		return compareEnums(userStatuses, a.Status, b.Status)
+	case storage.UserRole:
+		return compareEnums(userRoles, a.Role, b.Role)
+	case storage.UserPreferredLanguage:
+		return cmp.Compare(a.PreferredLanguage, b.PreferredLanguage)
+	case storage.UserCreateTime:
+		return a.CreateTime.Compare(b.CreateTime)
+	case storage.UserUpdateTime:
+		return a.UpdateTime.Compare(b.UpdateTime)
+	case storage.UserDeleteTime:
+		return compareNullTimes(a.DeleteTime, b.DeleteTime)
+	}
+	return 0
+}
+
+// List users.
+func (r *userRepository) List(_ context.Context, pagination storage.Pagination, sorting storage.UserOrderBy, conditions ...storage.Condition) ([]*storage.User, error) {
+	match, err := userFilter(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	var rows []userRow
+	r.c.read(func(s *state) {
+		for _, row := range s.users {
+			if match(&row.User) {
+				rows = append(rows, row)
+			}
+		}
+	})
+
+	slices.SortFunc(rows, func(a, b userRow) int {
+		for _, sort := range sorting {
+			if c := direction(compareUsers(&a.User, &b.User, sort.Field), sort.Direction); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	var users []*storage.User
+	for _, row := range paginate(rows, pagination) {
+		users = append(users, row.withoutPassword())
+	}
+
+	return users, nil
+}
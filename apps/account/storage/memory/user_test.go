@@ -0,0 +1,45 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/memory"
+)
+
+func TestUserRepository_columns(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		user func(u *storage.User)
+		err  error
+	}{
+		{
+			name: "should return an error if the status is unknown",
+			user: func(u *storage.User) { u.Status = "banned" },
+			err:  memory.ErrInvalidValue,
+		},
+		{
+			name: "should return an error if the display name is too long",
+			user: func(u *storage.User) { u.DisplayName = "a display name that is too long" },
+			err:  memory.ErrValueTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newManager(t)
+			if _, err := m.Op().User.Create(ctx, newUser("957b12c5-1071-40d9-8bec-6ed195c8cfbf", "test@test.com")); err != nil {
+				t.Fatal(err)
+			}
+
+			u := newUser("35297169-89d8-444d-8499-c6341e3a0770", "test@test.com")
+			tt.user(u)
+			if _, err := m.Op().User.Create(ctx, u); !errors.Is(err, tt.err) {
+				t.Errorf("Create() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
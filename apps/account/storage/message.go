@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageError error
+
+var (
+	ErrMessageNotFound MessageError = errors.New("message not found")
+	// Update.
+	ErrNoMessageFieldsToUpdate MessageError = errors.New("no fields to update")
+	// Fields.
+	ErrMessageUnknownField MessageError = errors.New("unknown message field")
+	// sort errors.
+	ErrEmptyMessageSortField       MessageError = errors.New("message field is empty")
+	ErrInvalidMessageSortDirection MessageError = errors.New("invalid message sort direction")
+	// Unique constraint errors.
+	ErrConflictMessageID MessageError = errors.New("unique id conflict")
+	// Immutable errors.
+	ErrImmutableMessageID             MessageError = errors.New("field id is read-only")
+	ErrImmutableMessageSequence       MessageError = errors.New("field sequence is read-only")
+	ErrImmutableMessageConversationID MessageError = errors.New("field conversation_id is read-only")
+	ErrImmutableMessageSenderID       MessageError = errors.New("field sender_id is read-only")
+	ErrImmutableMessageCreateTime     MessageError = errors.New("field create_time is read-only")
+	ErrImmutableMessageCreatePosition MessageError = errors.New("field create_position is read-only")
+	ErrImmutableMessagePosition       MessageError = errors.New("field position is read-only")
+)
+
+type MessageField string
+
+const (
+	MessageID             MessageField = "id"
+	MessageSequence       MessageField = "sequence"
+	MessageConversationID MessageField = "conversation_id"
+	MessageSenderID       MessageField = "sender_id"
+	MessageBody           MessageField = "body"
+	MessageLanguage       MessageField = "language"
+	MessageCreateTime     MessageField = "create_time"
+	MessageUpdateTime     MessageField = "update_time"
+	MessageEditTime       MessageField = "edit_time"
+	MessageDeleteTime     MessageField = "delete_time"
+	MessageCreatePosition MessageField = "create_position"
+	MessagePosition       MessageField = "position"
+)
+
+// MessageFields returns all message fields.
+func MessageFields() []MessageField {
+	return []MessageField{
+		MessageID,
+		MessageSequence,
+		MessageConversationID,
+		MessageSenderID,
+		MessageBody,
+		MessageLanguage,
+		MessageCreateTime,
+		MessageUpdateTime,
+		MessageEditTime,
+		MessageDeleteTime,
+		MessageCreatePosition,
+		MessagePosition,
+	}
+}
+
+// Message is a message sent in a conversation.
+type Message struct {
+	ID             uuid.UUID
+	Sequence       int64 // Sequence is assigned by the database and orders the messages.
+	ConversationID uuid.UUID
+	SenderID       uuid.UUID
+	Body           string
+	Language       string // Language is the language the body is written in.
+	CreateTime     time.Time
+	UpdateTime     time.Time
+	EditTime       sql.NullTime
+	DeleteTime     sql.NullTime
+	// CreatePosition and Position are the commit positions of the creation and of the last change of the message.
+	// They are stamped by the database when the transaction commits and are 0 before.
+	CreatePosition int64
+	Position       int64
+}
+
+// MessageSort pairs a field with a direction.
+type MessageSort struct {
+	Field     MessageField
+	Direction Direction
+}
+
+type MessageOrderBy []MessageSort
+
+// Validate checks if the sort fields are valid.
+func (o MessageOrderBy) Validate() error {
+	fields := MessageFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyMessageSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrMessageUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidMessageSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type MessageReader interface {
+	Get(context.Context, uuid.UUID) (*Message, error)
+	List(context.Context, Pagination, MessageOrderBy, ...Condition) ([]*Message, error)
+}
+
+type MessageWriter interface {
+	Create(context.Context, *Message) (*Message, error)
+	Update(context.Context, *Message, []MessageField) (*Message, error)
+}
+
+// MessageRepository is a reader and writer for messages.
+type MessageRepository interface {
+	MessageReader
+	MessageWriter
+}
+
+// MessageByConversationIDCondition is a search condition for the messages of a conversation.
+type MessageByConversationIDCondition struct {
+	ConversationID uuid.UUID
+}
+
+func (MessageByConversationIDCondition) condition() {}
+
+// MessageBeforeSequenceCondition is a search condition for messages with a sequence lower than Sequence.
+type MessageBeforeSequenceCondition struct {
+	Sequence int64
+}
+
+func (MessageBeforeSequenceCondition) condition() {}
+
+// MessageAfterPositionCondition is a search condition for messages that changed after Position.
+type MessageAfterPositionCondition struct {
+	Position int64
+}
+
+func (MessageAfterPositionCondition) condition() {}
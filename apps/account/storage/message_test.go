@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMessageFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.MessageFields()
+		want := []storage.MessageField{
+			storage.MessageID,
+			storage.MessageSequence,
+			storage.MessageConversationID,
+			storage.MessageSenderID,
+			storage.MessageBody,
+			storage.MessageLanguage,
+			storage.MessageCreateTime,
+			storage.MessageUpdateTime,
+			storage.MessageEditTime,
+			storage.MessageDeleteTime,
+			storage.MessageCreatePosition,
+			storage.MessagePosition,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("MessageFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestMessageOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.MessageOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.MessageOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.MessageOrderBy{{Field: "invalid"}},
+			err:  storage.ErrMessageUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.MessageOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyMessageSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.MessageOrderBy{{Field: storage.MessageID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.MessageOrderBy{{Field: storage.MessageID, Direction: "invalid"}},
+			err:  storage.ErrInvalidMessageSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("MessageOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
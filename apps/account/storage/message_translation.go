@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageTranslationError error
+
+var (
+	ErrMessageTranslationNotFound MessageTranslationError = errors.New("message translation not found")
+	// Fields.
+	ErrMessageTranslationUnknownField MessageTranslationError = errors.New("unknown message translation field")
+	// sort errors.
+	ErrEmptyMessageTranslationSortField       MessageTranslationError = errors.New("message translation field is empty")
+	ErrInvalidMessageTranslationSortDirection MessageTranslationError = errors.New("invalid message translation sort direction")
+)
+
+type MessageTranslationField string
+
+const (
+	MessageTranslationMessageID         MessageTranslationField = "message_id"
+	MessageTranslationLanguage          MessageTranslationField = "language"
+	MessageTranslationBody              MessageTranslationField = "body"
+	MessageTranslationMessageUpdateTime MessageTranslationField = "message_update_time"
+	MessageTranslationCreateTime        MessageTranslationField = "create_time"
+)
+
+// MessageTranslationFields returns all message translation fields.
+func MessageTranslationFields() []MessageTranslationField {
+	return []MessageTranslationField{
+		MessageTranslationMessageID,
+		MessageTranslationLanguage,
+		MessageTranslationBody,
+		MessageTranslationMessageUpdateTime,
+		MessageTranslationCreateTime,
+	}
+}
+
+// MessageTranslation is the body of a message translated into a language.
+type MessageTranslation struct {
+	MessageID         uuid.UUID
+	Language          string
+	Body              string
+	MessageUpdateTime time.Time // MessageUpdateTime is the update time of the message that was translated.
+	CreateTime        time.Time
+}
+
+// MessageTranslationSort pairs a field with a direction.
+type MessageTranslationSort struct {
+	Field     MessageTranslationField
+	Direction Direction
+}
+
+type MessageTranslationOrderBy []MessageTranslationSort
+
+// Validate checks if the sort fields are valid.
+func (o MessageTranslationOrderBy) Validate() error {
+	fields := MessageTranslationFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyMessageTranslationSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrMessageTranslationUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidMessageTranslationSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type MessageTranslationReader interface {
+	Get(ctx context.Context, messageID uuid.UUID, language string) (*MessageTranslation, error)
+	List(context.Context, Pagination, MessageTranslationOrderBy, ...Condition) ([]*MessageTranslation, error)
+}
+
+type MessageTranslationWriter interface {
+	// Upsert creates the translation of a message into a language, or replaces it when it was made
+	// from an older version of the message. A translation of an older version is ignored.
+	Upsert(context.Context, *MessageTranslation) error
+}
+
+// MessageTranslationRepository is a reader and writer for message translations.
+type MessageTranslationRepository interface {
+	MessageTranslationReader
+	MessageTranslationWriter
+}
+
+// MessageTranslationByMessageIDsCondition is a search condition for the translations of messages.
+type MessageTranslationByMessageIDsCondition struct {
+	MessageIDs []uuid.UUID
+}
+
+func (MessageTranslationByMessageIDsCondition) condition() {}
+
+// MessageTranslationByLanguageCondition is a search condition for the translations into a language.
+type MessageTranslationByLanguageCondition struct {
+	Language string
+}
+
+func (MessageTranslationByLanguageCondition) condition() {}
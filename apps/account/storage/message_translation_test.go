@@ -0,0 +1,66 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMessageTranslationFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.MessageTranslationFields()
+		want := []storage.MessageTranslationField{
+			storage.MessageTranslationMessageID,
+			storage.MessageTranslationLanguage,
+			storage.MessageTranslationBody,
+			storage.MessageTranslationMessageUpdateTime,
+			storage.MessageTranslationCreateTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("MessageTranslationFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestMessageTranslationOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.MessageTranslationOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.MessageTranslationOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.MessageTranslationOrderBy{{Field: "invalid"}},
+			err:  storage.ErrMessageTranslationUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.MessageTranslationOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyMessageTranslationSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.MessageTranslationOrderBy{{Field: storage.MessageTranslationMessageID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.MessageTranslationOrderBy{{Field: storage.MessageTranslationMessageID, Direction: "invalid"}},
+			err:  storage.ErrInvalidMessageTranslationSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("MessageTranslationOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
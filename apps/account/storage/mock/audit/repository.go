@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+type Repository struct {
+	LockFunc   func(context.Context) error
+	LastFunc   func(context.Context) (*storage.AuditEvent, error)
+	CreateFunc func(context.Context, *storage.AuditEvent) (*storage.AuditEvent, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.AuditEventOrderBy, ...storage.Condition) ([]*storage.AuditEvent, error)
+}
+
+func (m *Repository) Lock(ctx context.Context) error {
+	if m.LockFunc == nil {
+		panic("LockFunc is not implemented")
+	}
+	return m.LockFunc(ctx)
+}
+
+func (m *Repository) Last(ctx context.Context) (*storage.AuditEvent, error) {
+	if m.LastFunc == nil {
+		panic("LastFunc is not implemented")
+	}
+	return m.LastFunc(ctx)
+}
+
+func (m *Repository) Create(ctx context.Context, e *storage.AuditEvent) (*storage.AuditEvent, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, e)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.AuditEventOrderBy, c ...storage.Condition) ([]*storage.AuditEvent, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
@@ -0,0 +1,43 @@
+package conversation
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.Conversation) (*storage.Conversation, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.Conversation, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.ConversationOrderBy, ...storage.Condition) ([]*storage.Conversation, error)
+	UpdateFunc func(context.Context, *storage.Conversation, []storage.ConversationField) (*storage.Conversation, error)
+}
+
+func (m *Repository) Create(ctx context.Context, c *storage.Conversation) (*storage.Conversation, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, c)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Conversation, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.ConversationOrderBy, c ...storage.Condition) ([]*storage.Conversation, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Update(ctx context.Context, c *storage.Conversation, fields []storage.ConversationField) (*storage.Conversation, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, c, fields)
+}
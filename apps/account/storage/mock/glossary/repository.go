@@ -0,0 +1,51 @@
+package glossary
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.Glossary) (*storage.Glossary, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.Glossary, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.GlossaryOrderBy, ...storage.Condition) ([]*storage.Glossary, error)
+	UpdateFunc func(context.Context, *storage.Glossary, []storage.GlossaryField) (*storage.Glossary, error)
+	DeleteFunc func(context.Context, uuid.UUID) error
+}
+
+func (m *Repository) Create(ctx context.Context, g *storage.Glossary) (*storage.Glossary, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, g)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Glossary, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.GlossaryOrderBy, c ...storage.Condition) ([]*storage.Glossary, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Update(ctx context.Context, g *storage.Glossary, fields []storage.GlossaryField) (*storage.Glossary, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, g, fields)
+}
+
+func (m *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFunc == nil {
+		panic("DeleteFunc is not implemented")
+	}
+	return m.DeleteFunc(ctx, id)
+}
@@ -0,0 +1,51 @@
+package glossarysegment
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.GlossarySegment) (*storage.GlossarySegment, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.GlossarySegment, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.GlossarySegmentOrderBy, ...storage.Condition) ([]*storage.GlossarySegment, error)
+	SearchFunc func(context.Context, storage.GlossarySegmentSearch, ...storage.Condition) ([]*storage.GlossarySegmentMatch, error)
+	DeleteFunc func(context.Context, uuid.UUID) error
+}
+
+func (m *Repository) Create(ctx context.Context, s *storage.GlossarySegment) (*storage.GlossarySegment, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, s)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.GlossarySegment, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, o storage.GlossarySegmentOrderBy, c ...storage.Condition) ([]*storage.GlossarySegment, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, o, c...)
+}
+
+func (m *Repository) Search(ctx context.Context, q storage.GlossarySegmentSearch, c ...storage.Condition) ([]*storage.GlossarySegmentMatch, error) {
+	if m.SearchFunc == nil {
+		panic("SearchFunc is not implemented")
+	}
+	return m.SearchFunc(ctx, q, c...)
+}
+
+func (m *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFunc == nil {
+		panic("DeleteFunc is not implemented")
+	}
+	return m.DeleteFunc(ctx, id)
+}
@@ -0,0 +1,51 @@
+package glossaryterm
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.GlossaryTerm) (*storage.GlossaryTerm, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.GlossaryTerm, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.GlossaryTermOrderBy, ...storage.Condition) ([]*storage.GlossaryTerm, error)
+	UpdateFunc func(context.Context, *storage.GlossaryTerm, []storage.GlossaryTermField) (*storage.GlossaryTerm, error)
+	DeleteFunc func(context.Context, uuid.UUID) error
+}
+
+func (m *Repository) Create(ctx context.Context, t *storage.GlossaryTerm) (*storage.GlossaryTerm, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, t)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.GlossaryTerm, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.GlossaryTermOrderBy, c ...storage.Condition) ([]*storage.GlossaryTerm, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Update(ctx context.Context, t *storage.GlossaryTerm, fields []storage.GlossaryTermField) (*storage.GlossaryTerm, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, t, fields)
+}
+
+func (m *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFunc == nil {
+		panic("DeleteFunc is not implemented")
+	}
+	return m.DeleteFunc(ctx, id)
+}
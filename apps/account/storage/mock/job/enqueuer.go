@@ -0,0 +1,21 @@
+package job
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+var _ storage.JobEnqueuer = &Enqueuer{}
+
+type Enqueuer struct {
+	EnqueueFunc func(context.Context, jobs.Args, ...jobs.EnqueueOption) (bool, error)
+}
+
+func (m *Enqueuer) Enqueue(ctx context.Context, args jobs.Args, opts ...jobs.EnqueueOption) (bool, error) {
+	if m.EnqueueFunc == nil {
+		panic("EnqueueFunc is not implemented")
+	}
+	return m.EnqueueFunc(ctx, args, opts...)
+}
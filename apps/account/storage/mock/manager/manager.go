@@ -0,0 +1,39 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+)
+
+var _ storage.DBManager = &Manager{}
+
+type Manager struct {
+	OpFunc      func() storage.Repositories
+	BeginOpFunc func(context.Context, func(context.Context, storage.Repositories) error, ...database.OpOption) error
+}
+
+// New returns a manager that runs every operation directly on the given repositories, without a transaction.
+func New(r storage.Repositories) *Manager {
+	return &Manager{
+		OpFunc: func() storage.Repositories { return r },
+		BeginOpFunc: func(ctx context.Context, operation func(context.Context, storage.Repositories) error, _ ...database.OpOption) error {
+			return operation(ctx, r)
+		},
+	}
+}
+
+func (m *Manager) Op() storage.Repositories {
+	if m.OpFunc == nil {
+		panic("OpFunc is not implemented")
+	}
+	return m.OpFunc()
+}
+
+func (m *Manager) BeginOp(ctx context.Context, operation func(context.Context, storage.Repositories) error, opts ...database.OpOption) error {
+	if m.BeginOpFunc == nil {
+		panic("BeginOpFunc is not implemented")
+	}
+	return m.BeginOpFunc(ctx, operation, opts...)
+}
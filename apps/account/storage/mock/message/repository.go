@@ -0,0 +1,43 @@
+package message
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.Message) (*storage.Message, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.Message, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.MessageOrderBy, ...storage.Condition) ([]*storage.Message, error)
+	UpdateFunc func(context.Context, *storage.Message, []storage.MessageField) (*storage.Message, error)
+}
+
+func (m *Repository) Create(ctx context.Context, msg *storage.Message) (*storage.Message, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, msg)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Message, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.MessageOrderBy, c ...storage.Condition) ([]*storage.Message, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Update(ctx context.Context, msg *storage.Message, fields []storage.MessageField) (*storage.Message, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, msg, fields)
+}
@@ -0,0 +1,35 @@
+package messagetranslation
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	UpsertFunc func(context.Context, *storage.MessageTranslation) error
+	GetFunc    func(context.Context, uuid.UUID, string) (*storage.MessageTranslation, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.MessageTranslationOrderBy, ...storage.Condition) ([]*storage.MessageTranslation, error)
+}
+
+func (m *Repository) Upsert(ctx context.Context, t *storage.MessageTranslation) error {
+	if m.UpsertFunc == nil {
+		panic("UpsertFunc is not implemented")
+	}
+	return m.UpsertFunc(ctx, t)
+}
+
+func (m *Repository) Get(ctx context.Context, messageID uuid.UUID, language string) (*storage.MessageTranslation, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, messageID, language)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.MessageTranslationOrderBy, c ...storage.Condition) ([]*storage.MessageTranslation, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
@@ -0,0 +1,67 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+type Repository struct {
+	LockFunc    func(context.Context) error
+	CreateFunc  func(context.Context, *storage.OutboxEvent) (*storage.OutboxEvent, error)
+	GetFunc     func(context.Context, int64) (*storage.OutboxEvent, error)
+	UpdateFunc  func(context.Context, *storage.OutboxEvent, []storage.OutboxEventField) (*storage.OutboxEvent, error)
+	ListFunc    func(context.Context, storage.Pagination, storage.OutboxEventOrderBy, ...storage.Condition) ([]*storage.OutboxEvent, error)
+	PurgeFunc   func(context.Context, time.Time) (int64, error)
+	RedriveFunc func(context.Context, ...int64) (int64, error)
+}
+
+func (m *Repository) Lock(ctx context.Context) error {
+	if m.LockFunc == nil {
+		panic("LockFunc is not implemented")
+	}
+	return m.LockFunc(ctx)
+}
+
+func (m *Repository) Create(ctx context.Context, e *storage.OutboxEvent) (*storage.OutboxEvent, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, e)
+}
+
+func (m *Repository) Get(ctx context.Context, id int64) (*storage.OutboxEvent, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) Update(ctx context.Context, e *storage.OutboxEvent, fields []storage.OutboxEventField) (*storage.OutboxEvent, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, e, fields)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.OutboxEventOrderBy, c ...storage.Condition) ([]*storage.OutboxEvent, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if m.PurgeFunc == nil {
+		panic("PurgeFunc is not implemented")
+	}
+	return m.PurgeFunc(ctx, before)
+}
+
+func (m *Repository) Redrive(ctx context.Context, ids ...int64) (int64, error) {
+	if m.RedriveFunc == nil {
+		panic("RedriveFunc is not implemented")
+	}
+	return m.RedriveFunc(ctx, ids...)
+}
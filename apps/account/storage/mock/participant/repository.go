@@ -0,0 +1,43 @@
+package participant
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.Participant) (*storage.Participant, error)
+	GetFunc    func(context.Context, uuid.UUID, uuid.UUID) (*storage.Participant, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.ParticipantOrderBy, ...storage.Condition) ([]*storage.Participant, error)
+	DeleteFunc func(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+func (m *Repository) Create(ctx context.Context, p *storage.Participant) (*storage.Participant, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, p)
+}
+
+func (m *Repository) Get(ctx context.Context, conversationID, userID uuid.UUID) (*storage.Participant, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, conversationID, userID)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.ParticipantOrderBy, c ...storage.Condition) ([]*storage.Participant, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Delete(ctx context.Context, conversationID, userID uuid.UUID) error {
+	if m.DeleteFunc == nil {
+		panic("DeleteFunc is not implemented")
+	}
+	return m.DeleteFunc(ctx, conversationID, userID)
+}
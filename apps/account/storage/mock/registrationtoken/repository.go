@@ -0,0 +1,27 @@
+package registrationtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+)
+
+type Repository struct {
+	UseFunc   func(context.Context, *storage.RegistrationToken) error
+	PurgeFunc func(context.Context, time.Time) (int64, error)
+}
+
+func (m *Repository) Use(ctx context.Context, t *storage.RegistrationToken) error {
+	if m.UseFunc == nil {
+		panic("UseFunc is not implemented")
+	}
+	return m.UseFunc(ctx, t)
+}
+
+func (m *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if m.PurgeFunc == nil {
+		panic("PurgeFunc is not implemented")
+	}
+	return m.PurgeFunc(ctx, before)
+}
@@ -0,0 +1,69 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.Session) (*storage.Session, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.Session, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.SessionOrderBy, ...storage.Condition) ([]*storage.Session, error)
+	UpdateFunc func(context.Context, *storage.Session, []storage.SessionField) (*storage.Session, error)
+	DeleteFunc func(context.Context, uuid.UUID) error
+	PurgeFunc  func(context.Context, time.Time) (int64, error)
+
+	RotateRefreshTokenFunc func(context.Context, *storage.Session, uuid.UUID) (*storage.Session, error)
+}
+
+func (m *Repository) Create(ctx context.Context, s *storage.Session) (*storage.Session, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, s)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Session, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.SessionOrderBy, c ...storage.Condition) ([]*storage.Session, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Update(ctx context.Context, s *storage.Session, fields []storage.SessionField) (*storage.Session, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, s, fields)
+}
+
+func (m *Repository) RotateRefreshToken(ctx context.Context, s *storage.Session, previousTokenID uuid.UUID) (*storage.Session, error) {
+	if m.RotateRefreshTokenFunc == nil {
+		panic("RotateRefreshTokenFunc is not implemented")
+	}
+	return m.RotateRefreshTokenFunc(ctx, s, previousTokenID)
+}
+
+func (m *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFunc == nil {
+		panic("DeleteFunc is not implemented")
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if m.PurgeFunc == nil {
+		panic("PurgeFunc is not implemented")
+	}
+	return m.PurgeFunc(ctx, before)
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	CreateFunc func(context.Context, *storage.Webhook) (*storage.Webhook, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.Webhook, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.WebhookOrderBy, ...storage.Condition) ([]*storage.Webhook, error)
+	UpdateFunc func(context.Context, *storage.Webhook, []storage.WebhookField) (*storage.Webhook, error)
+	DeleteFunc func(context.Context, uuid.UUID) error
+}
+
+func (m *Repository) Create(ctx context.Context, w *storage.Webhook) (*storage.Webhook, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, w)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Webhook, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.WebhookOrderBy, c ...storage.Condition) ([]*storage.Webhook, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Update(ctx context.Context, w *storage.Webhook, fields []storage.WebhookField) (*storage.Webhook, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, w, fields)
+}
+
+func (m *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFunc == nil {
+		panic("DeleteFunc is not implemented")
+	}
+	return m.DeleteFunc(ctx, id)
+}
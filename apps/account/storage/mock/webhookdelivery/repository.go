@@ -0,0 +1,51 @@
+package webhookdelivery
+
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	LockFunc   func(context.Context) error
+	CreateFunc func(context.Context, *storage.WebhookDelivery) (*storage.WebhookDelivery, error)
+	GetFunc    func(context.Context, uuid.UUID) (*storage.WebhookDelivery, error)
+	ListFunc   func(context.Context, storage.Pagination, storage.WebhookDeliveryOrderBy, ...storage.Condition) ([]*storage.WebhookDelivery, error)
+	UpdateFunc func(context.Context, *storage.WebhookDelivery, []storage.WebhookDeliveryField) (*storage.WebhookDelivery, error)
+}
+
+func (m *Repository) Lock(ctx context.Context) error {
+	if m.LockFunc == nil {
+		panic("LockFunc is not implemented")
+	}
+	return m.LockFunc(ctx)
+}
+
+func (m *Repository) Create(ctx context.Context, d *storage.WebhookDelivery) (*storage.WebhookDelivery, error) {
+	if m.CreateFunc == nil {
+		panic("CreateFunc is not implemented")
+	}
+	return m.CreateFunc(ctx, d)
+}
+
+func (m *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.WebhookDelivery, error) {
+	if m.GetFunc == nil {
+		panic("GetFunc is not implemented")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Repository) List(ctx context.Context, p storage.Pagination, s storage.WebhookDeliveryOrderBy, c ...storage.Condition) ([]*storage.WebhookDelivery, error) {
+	if m.ListFunc == nil {
+		panic("ListFunc is not implemented")
+	}
+	return m.ListFunc(ctx, p, s, c...)
+}
+
+func (m *Repository) Update(ctx context.Context, d *storage.WebhookDelivery, fields []storage.WebhookDeliveryField) (*storage.WebhookDelivery, error) {
+	if m.UpdateFunc == nil {
+		panic("UpdateFunc is not implemented")
+	}
+	return m.UpdateFunc(ctx, d, fields)
+}
@@ -31,6 +31,7 @@ var (
 	ErrConflictOrganizationLegalName = errors.New("unique legal_name conflict")
 	ErrConflictOrganizationSlug      = errors.New("unique slug conflict")
 	ErrInvalidOrganizationSlug       = errors.New("incorrect slug format")
+	ErrOrganizationHasUsers          = errors.New("organization has users")
 	// Immutable errors.
 	ErrImmutableOrganizationID         = errors.New("field id is read-only")
 	ErrImmutableOrganizationCreateTime = errors.New("field create_time is read-only")
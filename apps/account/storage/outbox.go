@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxEventError error
+
+var (
+	ErrOutboxEventNotFound OutboxEventError = errors.New("outbox event not found")
+	// Update.
+	ErrNoOutboxEventFieldsToUpdate OutboxEventError = errors.New("no fields to update")
+	// Fields.
+	ErrOutboxEventUnknownField OutboxEventError = errors.New("unknown outbox event field")
+	// sort errors.
+	ErrEmptyOutboxEventSortField       OutboxEventError = errors.New("outbox event field is empty")
+	ErrInvalidOutboxEventSortDirection OutboxEventError = errors.New("invalid outbox event sort direction")
+	// Immutable errors.
+	ErrImmutableOutboxEventID            OutboxEventError = errors.New("field id is read-only")
+	ErrImmutableOutboxEventPosition      OutboxEventError = errors.New("field position is read-only")
+	ErrImmutableOutboxEventAggregateType OutboxEventError = errors.New("field aggregate_type is read-only")
+	ErrImmutableOutboxEventAggregateID   OutboxEventError = errors.New("field aggregate_id is read-only")
+	ErrImmutableOutboxEventType          OutboxEventError = errors.New("field event_type is read-only")
+	ErrImmutableOutboxEventPayload       OutboxEventError = errors.New("field payload is read-only")
+	ErrImmutableOutboxEventCreateTime    OutboxEventError = errors.New("field create_time is read-only")
+)
+
+type OutboxEventField string
+
+const (
+	OutboxEventID              OutboxEventField = "id"
+	OutboxEventPosition        OutboxEventField = "position"
+	OutboxEventAggregateType   OutboxEventField = "aggregate_type"
+	OutboxEventAggregateID     OutboxEventField = "aggregate_id"
+	OutboxEventType            OutboxEventField = "event_type"
+	OutboxEventPayload         OutboxEventField = "payload"
+	OutboxEventCreateTime      OutboxEventField = "create_time"
+	OutboxEventPublishTime     OutboxEventField = "publish_time"
+	OutboxEventAttempts        OutboxEventField = "attempts"
+	OutboxEventLastError       OutboxEventField = "last_error"
+	OutboxEventFailTime        OutboxEventField = "fail_time"
+	OutboxEventNextAttemptTime OutboxEventField = "next_attempt_time"
+)
+
+// OutboxEventFields returns all outbox event fields.
+func OutboxEventFields() []OutboxEventField {
+	return []OutboxEventField{
+		OutboxEventID,
+		OutboxEventPosition,
+		OutboxEventAggregateType,
+		OutboxEventAggregateID,
+		OutboxEventType,
+		OutboxEventPayload,
+		OutboxEventCreateTime,
+		OutboxEventPublishTime,
+		OutboxEventAttempts,
+		OutboxEventLastError,
+		OutboxEventFailTime,
+		OutboxEventNextAttemptTime,
+	}
+}
+
+// OutboxEvent is a domain event that is stored in the same transaction as the change it describes,
+// and published afterwards.
+//
+// The id is taken when the event is stored, so a transaction that commits later can hold a lower id.
+// The position is taken when the transaction commits instead: once an event is visible, all events
+// with a lower position are visible too, so the position orders the events without gaps.
+type OutboxEvent struct {
+	ID              int64
+	Position        int64 // Position is 0 until the transaction that stores the event commits.
+	AggregateType   string
+	AggregateID     uuid.UUID
+	Type            string
+	Payload         []byte // Payload is the JSON encoded event.
+	CreateTime      time.Time
+	PublishTime     sql.NullTime
+	Attempts        int
+	LastError       string
+	FailTime        sql.NullTime // FailTime is set when the relay gave up on publishing the event, it is parked until it is redriven.
+	NextAttemptTime sql.NullTime // NextAttemptTime is when the relay attempts the event again after a failed attempt.
+}
+
+// OutboxEventSort pairs a field with a direction.
+type OutboxEventSort struct {
+	Field     OutboxEventField
+	Direction Direction
+}
+
+type OutboxEventOrderBy []OutboxEventSort
+
+// Validate checks if the sort fields are valid.
+func (o OutboxEventOrderBy) Validate() error {
+	fields := OutboxEventFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyOutboxEventSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrOutboxEventUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidOutboxEventSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type OutboxEventReader interface {
+	Get(context.Context, int64) (*OutboxEvent, error)
+	List(context.Context, Pagination, OutboxEventOrderBy, ...Condition) ([]*OutboxEvent, error)
+}
+
+type OutboxEventWriter interface {
+	// Lock makes sure only one relay publishes events until the surrounding transaction ends.
+	Lock(context.Context) error
+	Create(context.Context, *OutboxEvent) (*OutboxEvent, error)
+	Update(context.Context, *OutboxEvent, []OutboxEventField) (*OutboxEvent, error)
+	// Purge deletes the events published before the time and returns how many were deleted. It keeps the
+	// latest event and the events after the oldest one that is not published, so the events after the
+	// oldest one that is kept are all kept.
+	Purge(context.Context, time.Time) (int64, error)
+	// Redrive hands the parked events with the ids back to the relay, all parked events without ids.
+	// It returns how many were redriven.
+	Redrive(context.Context, ...int64) (int64, error)
+}
+
+// OutboxEventRepository is a reader and writer for outbox events.
+type OutboxEventRepository interface {
+	OutboxEventReader
+	OutboxEventWriter
+}
+
+// OutboxEventUnpublishedCondition is a search condition for events that are not published yet,
+// including the parked events.
+type OutboxEventUnpublishedCondition struct{}
+
+func (OutboxEventUnpublishedCondition) condition() {}
+
+// OutboxEventAfterPositionCondition is a search condition for events with a position greater than Position.
+type OutboxEventAfterPositionCondition struct {
+	Position int64
+}
+
+func (OutboxEventAfterPositionCondition) condition() {}
+
+// OutboxEventByAggregateTypeCondition is a search condition for the events of one aggregate type.
+type OutboxEventByAggregateTypeCondition struct {
+	AggregateType string
+}
+
+func (OutboxEventByAggregateTypeCondition) condition() {}
@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOutboxEventFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.OutboxEventFields()
+		want := []storage.OutboxEventField{
+			storage.OutboxEventID,
+			storage.OutboxEventPosition,
+			storage.OutboxEventAggregateType,
+			storage.OutboxEventAggregateID,
+			storage.OutboxEventType,
+			storage.OutboxEventPayload,
+			storage.OutboxEventCreateTime,
+			storage.OutboxEventPublishTime,
+			storage.OutboxEventAttempts,
+			storage.OutboxEventLastError,
+			storage.OutboxEventFailTime,
+			storage.OutboxEventNextAttemptTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("OutboxEventFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestOutboxEventOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.OutboxEventOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.OutboxEventOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.OutboxEventOrderBy{{Field: "invalid"}},
+			err:  storage.ErrOutboxEventUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.OutboxEventOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyOutboxEventSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.OutboxEventOrderBy{{Field: storage.OutboxEventID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.OutboxEventOrderBy{{Field: storage.OutboxEventID, Direction: "invalid"}},
+			err:  storage.ErrInvalidOutboxEventSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("OutboxEventOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
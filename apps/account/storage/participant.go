@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ParticipantError error
+
+var (
+	ErrParticipantNotFound ParticipantError = errors.New("participant not found")
+	// Fields.
+	ErrParticipantUnknownField ParticipantError = errors.New("unknown participant field")
+	// sort errors.
+	ErrEmptyParticipantSortField       ParticipantError = errors.New("participant field is empty")
+	ErrInvalidParticipantSortDirection ParticipantError = errors.New("invalid participant sort direction")
+	// Unique constraint errors.
+	ErrConflictParticipant ParticipantError = errors.New("user already participates in the conversation")
+)
+
+type ParticipantField string
+
+const (
+	ParticipantConversationID ParticipantField = "conversation_id"
+	ParticipantUserID         ParticipantField = "user_id"
+	ParticipantJoinTime       ParticipantField = "join_time"
+)
+
+// ParticipantFields returns all participant fields.
+func ParticipantFields() []ParticipantField {
+	return []ParticipantField{
+		ParticipantConversationID,
+		ParticipantUserID,
+		ParticipantJoinTime,
+	}
+}
+
+// Participant is a user taking part in a conversation.
+type Participant struct {
+	ConversationID uuid.UUID
+	UserID         uuid.UUID
+	JoinTime       time.Time
+}
+
+// ParticipantSort pairs a field with a direction.
+type ParticipantSort struct {
+	Field     ParticipantField
+	Direction Direction
+}
+
+type ParticipantOrderBy []ParticipantSort
+
+// Validate checks if the sort fields are valid.
+func (o ParticipantOrderBy) Validate() error {
+	fields := ParticipantFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyParticipantSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrParticipantUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidParticipantSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type ParticipantReader interface {
+	Get(ctx context.Context, conversationID, userID uuid.UUID) (*Participant, error)
+	List(context.Context, Pagination, ParticipantOrderBy, ...Condition) ([]*Participant, error)
+}
+
+type ParticipantWriter interface {
+	Create(context.Context, *Participant) (*Participant, error)
+	Delete(ctx context.Context, conversationID, userID uuid.UUID) error
+}
+
+// ParticipantRepository is a reader and writer for participants.
+type ParticipantRepository interface {
+	ParticipantReader
+	ParticipantWriter
+}
+
+// ParticipantByConversationIDCondition is a search condition for the participants of a conversation.
+type ParticipantByConversationIDCondition struct {
+	ConversationID uuid.UUID
+}
+
+func (ParticipantByConversationIDCondition) condition() {}
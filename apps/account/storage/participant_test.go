@@ -0,0 +1,64 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParticipantFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.ParticipantFields()
+		want := []storage.ParticipantField{
+			storage.ParticipantConversationID,
+			storage.ParticipantUserID,
+			storage.ParticipantJoinTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("ParticipantFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestParticipantOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.ParticipantOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.ParticipantOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.ParticipantOrderBy{{Field: "invalid"}},
+			err:  storage.ErrParticipantUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.ParticipantOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyParticipantSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.ParticipantOrderBy{{Field: storage.ParticipantUserID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.ParticipantOrderBy{{Field: storage.ParticipantUserID, Direction: "invalid"}},
+			err:  storage.ErrInvalidParticipantSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("ParticipantOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
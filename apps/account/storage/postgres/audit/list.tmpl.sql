@@ -0,0 +1,18 @@
+SELECT a.id, a.organization_id, a.actor, a.action, a.resource, a.ip_address, a.request_id, a.details, a.create_time, a.previous_hash, a.hash
+FROM audit_events a 
+{{- if .Predicates }}
+WHERE {{- range $i, $v := .Predicates }}
+	{{- if $i}} AND {{- end }} {{$v -}}
+{{- end }}
+{{- end -}}
+{{- if .Sorting }}
+ORDER BY {{- range $i, $v := .Sorting }}
+		{{- if $i}}, {{- end }} a.{{$v.Field }} {{$v.Direction -}}
+	{{- end }}
+{{- end -}}
+{{- if .LimitParam }}
+LIMIT {{.LimitParam -}}
+{{- end -}}
+{{- if .OffsetParam }}
+OFFSET {{.OffsetParam -}}
+{{- end -}};
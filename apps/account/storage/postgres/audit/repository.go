@@ -0,0 +1,262 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+
+	_ "embed"
+)
+
+const (
+	auditEventIDConstraint = "audit_events_pkey"
+	// appendLockKey is the advisory lock that serializes appends to the audit log.
+	appendLockKey = 0x61756469 // "audi"
+)
+
+var _ storage.AuditEventRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans an audit event from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - organization_id
+//   - actor
+//   - action
+//   - resource
+//   - ip_address
+//   - request_id
+//   - details
+//   - create_time
+//   - previous_hash
+//   - hash
+func scan(f func(dest ...any) error, e *storage.AuditEvent) error {
+	var details []byte
+	if err := f(
+		&e.ID,
+		&e.OrganizationID,
+		&e.Actor,
+		&e.Action,
+		&e.Resource,
+		&e.IPAddress,
+		&e.RequestID,
+		&details,
+		&e.CreateTime,
+		&e.PreviousHash,
+		&e.Hash,
+	); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(details, &e.Details); err != nil {
+		return fmt.Errorf("failed to unmarshal details: %w", err)
+	}
+
+	return nil
+}
+
+const lockQuery = `SELECT pg_advisory_xact_lock($1);`
+
+// Lock takes a transaction level advisory lock. It must run inside a transaction,
+// otherwise the lock is released as soon as the statement finishes.
+func (r *Repository) Lock(ctx context.Context) error {
+	if _, err := r.dbConn.Exec(ctx, lockQuery, appendLockKey); err != nil {
+		return fmt.Errorf("failed to lock audit log: %w", err)
+	}
+
+	return nil
+}
+
+const lastQuery = `SELECT id, organization_id, actor, action, resource, ip_address, request_id, details, create_time, previous_hash, hash
+FROM audit_events
+ORDER BY id DESC
+LIMIT 1
+;`
+
+// Last returns the most recent audit event.
+func (r *Repository) Last(ctx context.Context) (*storage.AuditEvent, error) {
+	row := r.dbConn.QueryRow(ctx, lastQuery)
+	var e storage.AuditEvent
+	if err := scan(row.Scan, &e); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrAuditEventNotFound
+		}
+
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+const createQuery = `INSERT INTO audit_events (id, organization_id, actor, action, resource, ip_address, request_id, details, create_time, previous_hash, hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, organization_id, actor, action, resource, ip_address, request_id, details, create_time, previous_hash, hash
+;`
+
+// Create appends an audit event.
+func (r *Repository) Create(ctx context.Context, e *storage.AuditEvent) (*storage.AuditEvent, error) {
+	details := e.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+
+	d, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal details: %w", err)
+	}
+
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		e.ID,
+		e.OrganizationID,
+		e.Actor,
+		e.Action,
+		e.Resource,
+		e.IPAddress,
+		e.RequestID,
+		d,
+		e.CreateTime,
+		e.PreviousHash,
+		e.Hash,
+	)
+
+	var n storage.AuditEvent
+	if err = scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == auditEventIDConstraint {
+			return nil, storage.ErrConflictAuditEventID
+		}
+
+		return nil, fmt.Errorf("failed to insert audit event: %w", err)
+	}
+
+	return &n, nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.AuditEventByOrganizationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("a.organization_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.OrganizationID)
+		case storage.AuditEventCreateTimeCondition:
+			if !t.Start.IsZero() {
+				predicates = append(predicates, fmt.Sprintf("a.create_time >= $%d", len(args)+argOffset+1))
+				args = append(args, t.Start)
+			}
+			if !t.End.IsZero() {
+				predicates = append(predicates, fmt.Sprintf("a.create_time < $%d", len(args)+argOffset+1))
+				args = append(args, t.End)
+			}
+		case storage.AuditEventIDBeforeCondition:
+			predicates = append(predicates, fmt.Sprintf("a.id < $%d", len(args)+argOffset+1))
+			args = append(args, t.ID)
+		case storage.AuditEventIDAfterCondition:
+			predicates = append(predicates, fmt.Sprintf("a.id > $%d", len(args)+argOffset+1))
+			args = append(args, t.ID)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.AuditEventSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.AuditEventReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.AuditEventOrderBy, conditions ...storage.Condition) ([]*storage.AuditEvent, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*storage.AuditEvent
+	for rows.Next() {
+		var e storage.AuditEvent
+		if err = scan(rows.Scan, &e); err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+
+		events = append(events, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, nil
+}
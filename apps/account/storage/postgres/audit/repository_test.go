@@ -0,0 +1,131 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/audit"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	return dbc
+}
+
+func newEvent(id int64, organizationID string, createTime time.Time) *storage.AuditEvent {
+	return &storage.AuditEvent{
+		ID:             id,
+		OrganizationID: uuid.NullUUID{UUID: uuid.MustParse(organizationID), Valid: true},
+		Actor:          "system",
+		Action:         "user.updated",
+		Resource:       "organizations/" + organizationID + "/users/35297169-89d8-444d-8499-c6341e3a0770",
+		Details:        map[string]string{"fields": "email"},
+		CreateTime:     createTime,
+		PreviousHash:   "0000000000000000000000000000000000000000000000000000000000000000",
+		Hash:           uuid.NewString() + "0000000000000000000000000000",
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := audit.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "audit")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := audit.New(database.NewDBWrapper(db))
+
+	const org = "7bb443e5-8974-44c2-8b7c-b95124205264"
+
+	t.Run("Last should return an error if the log is empty", func(t *testing.T) {
+		if _, err := repo.Last(ctx); !errors.Is(err, storage.ErrAuditEventNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrAuditEventNotFound, err)
+		}
+	})
+
+	t.Run("Create should append an event", func(t *testing.T) {
+		for i := int64(1); i <= 3; i++ {
+			e := newEvent(i, org, time.Date(2020, 1, int(i), 0, 0, 0, 0, time.UTC))
+			got, err := repo.Create(ctx, e)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(e, got); diff != "" {
+				t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+			}
+		}
+
+		last, err := repo.Last(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if last.ID != 3 {
+			t.Errorf("expected last id 3, got %d", last.ID)
+		}
+	})
+
+	t.Run("Create should return an error if the id already exists", func(t *testing.T) {
+		_, err := repo.Create(ctx, newEvent(1, org, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+		if !errors.Is(err, storage.ErrConflictAuditEventID) {
+			t.Errorf("expected %q, got %q", storage.ErrConflictAuditEventID, err)
+		}
+	})
+
+	t.Run("List should filter on organization and time range", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{Limit: 10}, storage.AuditEventOrderBy{
+			{Field: storage.AuditEventID, Direction: storage.DESC},
+		},
+			storage.AuditEventByOrganizationIDCondition{OrganizationID: uuid.MustParse(org)},
+			storage.AuditEventCreateTimeCondition{
+				Start: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2020, 1, 4, 0, 0, 0, 0, time.UTC),
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []int64
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+
+		if diff := cmp.Diff([]int64{3, 2}, ids); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("events can not be changed", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, `UPDATE audit_events SET actor = 'someone' WHERE id = 1`); err == nil {
+			t.Error("expected an error when updating an audit event")
+		}
+
+		if _, err := db.ExecContext(ctx, `DELETE FROM audit_events WHERE id = 1`); err == nil {
+			t.Error("expected an error when deleting an audit event")
+		}
+	})
+}
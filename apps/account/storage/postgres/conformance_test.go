@@ -0,0 +1,35 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/apps/account/storage/storagetest"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+)
+
+func TestConformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName("conformance"))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+
+	// All tests share the container, every test starts with empty tables.
+	storagetest.Run(t, func(t *testing.T) storage.DBManager {
+		if _, err := db.ExecContext(ctx, "TRUNCATE organizations, users CASCADE;"); err != nil {
+			t.Fatalf("failed to truncate tables: %v", err)
+		}
+
+		return postgres.NewManager(db)
+	})
+}
@@ -0,0 +1,18 @@
+SELECT c.id, c.organization_id, c.type, c.title, c.creator_id, c.create_time, c.update_time
+FROM conversations c
+{{- if .Predicates }}
+WHERE {{- range $i, $v := .Predicates }}
+	{{- if $i}} AND {{- end }} {{$v -}}
+{{- end }}
+{{- end -}}
+{{- if .Sorting }}
+ORDER BY {{- range $i, $v := .Sorting }}
+		{{- if $i}}, {{- end }} c.{{$v.Field }} {{$v.Direction -}}
+	{{- end }}
+{{- end -}}
+{{- if .LimitParam }}
+LIMIT {{.LimitParam -}}
+{{- end -}}
+{{- if .OffsetParam }}
+OFFSET {{.OffsetParam -}}
+{{- end -}};
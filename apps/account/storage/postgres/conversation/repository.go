@@ -0,0 +1,270 @@
+package conversation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	conversationIDConstraint = "conversations_pkey"
+)
+
+var _ storage.ConversationRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a conversation from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - organization_id
+//   - type
+//   - title
+//   - creator_id
+//   - create_time
+//   - update_time
+func scan(f func(dest ...any) error, c *storage.Conversation) error {
+	return f(
+		&c.ID,
+		&c.OrganizationID,
+		&c.Type,
+		&c.Title,
+		&c.CreatorID,
+		&c.CreateTime,
+		&c.UpdateTime,
+	)
+}
+
+const createQuery = `INSERT INTO conversations (id, organization_id, type, title, creator_id, create_time, update_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, organization_id, type, title, creator_id, create_time, update_time
+;`
+
+// Create a new conversation.
+func (r *Repository) Create(ctx context.Context, c *storage.Conversation) (*storage.Conversation, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		c.ID,
+		c.OrganizationID,
+		c.Type,
+		c.Title,
+		c.CreatorID,
+		c.CreateTime,
+		c.UpdateTime,
+	)
+
+	var n storage.Conversation
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == conversationIDConstraint {
+			return nil, storage.ErrConflictConversationID
+		}
+
+		return nil, fmt.Errorf("failed to insert conversation: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, organization_id, type, title, creator_id, create_time, update_time
+FROM conversations
+WHERE id = $1
+;`
+
+// Get a conversation by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Conversation, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var c storage.Conversation
+	if err := scan(row.Scan, &c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrConversationNotFound
+		}
+
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+const updateQueryTemplate = `UPDATE conversations
+SET %s
+WHERE id = $%d
+RETURNING id, organization_id, type, title, creator_id, create_time, update_time;`
+
+func (r *Repository) Update(ctx context.Context, in *storage.Conversation, fields []storage.ConversationField) (*storage.Conversation, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoConversationFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "title = $1", "update_time = $2"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.ConversationTitle:
+			set = append(set, fmt.Sprintf("title = $%d", index))
+			args = append(args, in.Title)
+		case storage.ConversationUpdateTime:
+			set = append(set, fmt.Sprintf("update_time = $%d", index))
+			args = append(args, in.UpdateTime)
+		case storage.ConversationID:
+			return nil, storage.ErrImmutableConversationID
+		case storage.ConversationOrganizationID:
+			return nil, storage.ErrImmutableConversationOrganizationID
+		case storage.ConversationType:
+			return nil, storage.ErrImmutableConversationType
+		case storage.ConversationCreatorID:
+			return nil, storage.ErrImmutableConversationCreatorID
+		case storage.ConversationCreateTime:
+			return nil, storage.ErrImmutableConversationCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrConversationUnknownField)
+		}
+	}
+
+	// Add the conversation ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the conversation ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var c storage.Conversation
+	if err := scan(row.Scan, &c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrConversationNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan conversation: %w", err)
+	}
+
+	return &c, nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.ConversationByOrganizationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("c.organization_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.OrganizationID)
+		case storage.ConversationByParticipantCondition:
+			predicates = append(predicates, fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM conversation_participants p WHERE p.conversation_id = c.id AND p.user_id = $%d)",
+				len(args)+argOffset+1,
+			))
+			args = append(args, t.UserID)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.ConversationSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.ConversationReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.ConversationOrderBy, conditions ...storage.Condition) ([]*storage.Conversation, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var conversations []*storage.Conversation
+	for rows.Next() {
+		var c storage.Conversation
+		if err = scan(rows.Scan, &c); err != nil {
+			return nil, fmt.Errorf("failed to scan conversation: %w", err)
+		}
+
+		conversations = append(conversations, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+
+	return conversations, nil
+}
@@ -0,0 +1,142 @@
+package conversation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/conversation"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/participant"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization("7bb443e5-8974-44c2-8b7c-b95124205264", "test", "test", createTime, createTime),
+		},
+		Users: []*storage.User{
+			seed.NewUser(
+				"7bb443e5-8974-44c2-8b7c-b95124205265",
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"jane", "active", "jane@example.com", "password",
+				createTime, createTime, time.Time{},
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := conversation.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "conversation")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := conversation.New(database.NewDBWrapper(db))
+	participants := participant.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	orgID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	userID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205265")
+	in := &storage.Conversation{
+		ID:             uuid.MustParse("2a7d1c3e-5f6b-4a8c-9d0e-1f2a3b4c5d6e"),
+		OrganizationID: orgID,
+		Type:           "group",
+		Title:          "general",
+		CreatorID:      userID,
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}
+
+	t.Run("Create should create a conversation", func(t *testing.T) {
+		got, err := repo.Create(ctx, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Create should return a conflict for an existing id", func(t *testing.T) {
+		if _, err := repo.Create(ctx, in); !errors.Is(err, storage.ErrConflictConversationID) {
+			t.Errorf("expected %q, got %q", storage.ErrConflictConversationID, err)
+		}
+	})
+
+	t.Run("List should only return the conversations of a participant", func(t *testing.T) {
+		list := func() []*storage.Conversation {
+			got, err := repo.List(ctx, storage.Pagination{}, storage.ConversationOrderBy{},
+				storage.ConversationByOrganizationIDCondition{OrganizationID: orgID},
+				storage.ConversationByParticipantCondition{UserID: userID},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return got
+		}
+
+		if got := list(); len(got) != 0 {
+			t.Errorf("expected no conversations, got %d", len(got))
+		}
+
+		if _, err := participants.Create(ctx, &storage.Participant{ConversationID: in.ID, UserID: userID, JoinTime: createTime}); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := list(); len(got) != 1 {
+			t.Errorf("expected 1 conversation, got %d", len(got))
+		}
+	})
+
+	t.Run("Update should update the update time", func(t *testing.T) {
+		in.UpdateTime = createTime.Add(time.Hour)
+		got, err := repo.Update(ctx, in, []storage.ConversationField{storage.ConversationUpdateTime})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Update() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Update should not change the type", func(t *testing.T) {
+		_, err := repo.Update(ctx, in, []storage.ConversationField{storage.ConversationType})
+		if !errors.Is(err, storage.ErrImmutableConversationType) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableConversationType, err)
+		}
+	})
+
+	t.Run("Get should return ErrConversationNotFound for an unknown id", func(t *testing.T) {
+		if _, err := repo.Get(ctx, uuid.New()); !errors.Is(err, storage.ErrConversationNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrConversationNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+SELECT g.id, g.organization_id, g.display_name, g.create_time, g.update_time
+FROM glossaries g
+{{- if .Predicates }}
+WHERE {{- range $i, $v := .Predicates }}
+	{{- if $i}} AND {{- end }} {{$v -}}
+{{- end }}
+{{- end -}}
+{{- if .Sorting }}
+ORDER BY {{- range $i, $v := .Sorting }}
+		{{- if $i}}, {{- end }} g.{{$v.Field }} {{$v.Direction -}}
+	{{- end }}
+{{- end -}}
+{{- if .LimitParam }}
+LIMIT {{.LimitParam -}}
+{{- end -}}
+{{- if .OffsetParam }}
+OFFSET {{.OffsetParam -}}
+{{- end -}};
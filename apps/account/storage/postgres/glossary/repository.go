@@ -0,0 +1,275 @@
+package glossary
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	glossaryIDConstraint = "glossaries_pkey"
+)
+
+var _ storage.GlossaryRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a glossary from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - organization_id
+//   - display_name
+//   - create_time
+//   - update_time
+func scan(f func(dest ...any) error, g *storage.Glossary) error {
+	return f(
+		&g.ID,
+		&g.OrganizationID,
+		&g.DisplayName,
+		&g.CreateTime,
+		&g.UpdateTime,
+	)
+}
+
+const createQuery = `INSERT INTO glossaries (id, organization_id, display_name, create_time, update_time)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, organization_id, display_name, create_time, update_time
+;`
+
+// Create a new glossary.
+func (r *Repository) Create(ctx context.Context, g *storage.Glossary) (*storage.Glossary, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		g.ID,
+		g.OrganizationID,
+		g.DisplayName,
+		g.CreateTime,
+		g.UpdateTime,
+	)
+
+	var n storage.Glossary
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == glossaryIDConstraint {
+			return nil, storage.ErrConflictGlossaryID
+		}
+
+		return nil, fmt.Errorf("failed to insert glossary: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, organization_id, display_name, create_time, update_time
+FROM glossaries
+WHERE id = $1
+;`
+
+// Get a glossary by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Glossary, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var g storage.Glossary
+	if err := scan(row.Scan, &g); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrGlossaryNotFound
+		}
+
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+const updateQueryTemplate = `UPDATE glossaries
+SET %s
+WHERE id = $%d
+RETURNING id, organization_id, display_name, create_time, update_time;`
+
+func (r *Repository) Update(ctx context.Context, in *storage.Glossary, fields []storage.GlossaryField) (*storage.Glossary, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoGlossaryFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "display_name = $1"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.GlossaryDisplayName:
+			set = append(set, fmt.Sprintf("display_name = $%d", index))
+			args = append(args, in.DisplayName)
+		case storage.GlossaryUpdateTime:
+			set = append(set, fmt.Sprintf("update_time = $%d", index))
+			args = append(args, in.UpdateTime)
+		case storage.GlossaryID:
+			return nil, storage.ErrImmutableGlossaryID
+		case storage.GlossaryOrganizationID:
+			return nil, storage.ErrImmutableGlossaryOrganizationID
+		case storage.GlossaryCreateTime:
+			return nil, storage.ErrImmutableGlossaryCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrGlossaryUnknownField)
+		}
+	}
+
+	// Add the glossary ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the glossary ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var g storage.Glossary
+	if err := scan(row.Scan, &g); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrGlossaryNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan glossary: %w", err)
+	}
+
+	return &g, nil
+}
+
+const deleteQuery = `DELETE FROM glossaries WHERE id = $1;`
+
+// Delete a glossary and, through the foreign keys, its terms and segments.
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.dbConn.Exec(ctx, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete glossary: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if n == 0 {
+		return storage.ErrGlossaryNotFound
+	}
+
+	return nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.GlossaryByOrganizationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("g.organization_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.OrganizationID)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.GlossarySort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.GlossaryReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.GlossaryOrderBy, conditions ...storage.Condition) ([]*storage.Glossary, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossaries: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossaries: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.Glossary
+	for rows.Next() {
+		var g storage.Glossary
+		if err = scan(rows.Scan, &g); err != nil {
+			return nil, fmt.Errorf("failed to scan glossary: %w", err)
+		}
+
+		out = append(out, &g)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list glossaries: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,129 @@
+package glossary_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossary"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization(
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"test",
+				"test",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := glossary.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "glossary")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := glossary.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	orgID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	in := &storage.Glossary{
+		ID:             uuid.MustParse("5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"),
+		OrganizationID: orgID,
+		DisplayName:    "Product",
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}
+
+	t.Run("Create should create a glossary", func(t *testing.T) {
+		got, err := repo.Create(ctx, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Create should return a conflict for an existing id", func(t *testing.T) {
+		if _, err := repo.Create(ctx, in); !errors.Is(err, storage.ErrConflictGlossaryID) {
+			t.Errorf("expected %q, got %q", storage.ErrConflictGlossaryID, err)
+		}
+	})
+
+	t.Run("List should list the glossaries of the organization", func(t *testing.T) {
+		for _, id := range []uuid.UUID{orgID, uuid.New()} {
+			got, err := repo.List(ctx, storage.Pagination{}, storage.GlossaryOrderBy{
+				{Field: storage.GlossaryCreateTime, Direction: storage.ASC},
+			}, storage.GlossaryByOrganizationIDCondition{OrganizationID: id})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := id == orgID; (len(got) == 1) != want {
+				t.Errorf("%s: expected the glossary to be listed: %v, got %d glossaries", id, want, len(got))
+			}
+		}
+	})
+
+	t.Run("Update should rename a glossary", func(t *testing.T) {
+		in.DisplayName = "Marketing"
+		in.UpdateTime = createTime.Add(time.Hour)
+		got, err := repo.Update(ctx, in, []storage.GlossaryField{storage.GlossaryDisplayName, storage.GlossaryUpdateTime})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Update() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Update should not change the organization", func(t *testing.T) {
+		_, err := repo.Update(ctx, in, []storage.GlossaryField{storage.GlossaryOrganizationID})
+		if !errors.Is(err, storage.ErrImmutableGlossaryOrganizationID) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableGlossaryOrganizationID, err)
+		}
+	})
+
+	t.Run("Delete should delete a glossary", func(t *testing.T) {
+		if err := repo.Delete(ctx, in.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repo.Get(ctx, in.ID); !errors.Is(err, storage.ErrGlossaryNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrGlossaryNotFound, err)
+		}
+	})
+}
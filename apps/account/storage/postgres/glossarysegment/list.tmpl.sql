@@ -0,0 +1,18 @@
+SELECT s.id, s.glossary_id, s.source_language, s.source_text, s.target_language, s.target_text, s.create_time
+FROM glossary_segments s
+{{- if .Predicates }}
+WHERE {{- range $i, $v := .Predicates }}
+	{{- if $i}} AND {{- end }} {{$v -}}
+{{- end }}
+{{- end -}}
+{{- if .Sorting }}
+ORDER BY {{- range $i, $v := .Sorting }}
+		{{- if $i}}, {{- end }} s.{{$v.Field }} {{$v.Direction -}}
+	{{- end }}
+{{- end -}}
+{{- if .LimitParam }}
+LIMIT {{.LimitParam -}}
+{{- end -}}
+{{- if .OffsetParam }}
+OFFSET {{.OffsetParam -}}
+{{- end -}};
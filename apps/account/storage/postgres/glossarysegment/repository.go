@@ -0,0 +1,276 @@
+package glossarysegment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	glossarySegmentIDConstraint = "glossary_segments_pkey"
+)
+
+var _ storage.GlossarySegmentRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a glossary segment from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - glossary_id
+//   - source_language
+//   - source_text
+//   - target_language
+//   - target_text
+//   - create_time
+func scan(f func(dest ...any) error, s *storage.GlossarySegment) error {
+	return f(
+		&s.ID,
+		&s.GlossaryID,
+		&s.SourceLanguage,
+		&s.SourceText,
+		&s.TargetLanguage,
+		&s.TargetText,
+		&s.CreateTime,
+	)
+}
+
+const createQuery = `INSERT INTO glossary_segments (id, glossary_id, source_language, source_text, target_language, target_text, create_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, glossary_id, source_language, source_text, target_language, target_text, create_time
+;`
+
+// Create a new glossary segment.
+func (r *Repository) Create(ctx context.Context, s *storage.GlossarySegment) (*storage.GlossarySegment, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		s.ID,
+		s.GlossaryID,
+		s.SourceLanguage,
+		s.SourceText,
+		s.TargetLanguage,
+		s.TargetText,
+		s.CreateTime,
+	)
+
+	var n storage.GlossarySegment
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == glossarySegmentIDConstraint {
+			return nil, storage.ErrConflictGlossarySegmentID
+		}
+
+		return nil, fmt.Errorf("failed to insert glossary segment: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, glossary_id, source_language, source_text, target_language, target_text, create_time
+FROM glossary_segments
+WHERE id = $1
+;`
+
+// Get a glossary segment by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.GlossarySegment, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var s storage.GlossarySegment
+	if err := scan(row.Scan, &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrGlossarySegmentNotFound
+		}
+
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+const deleteQuery = `DELETE FROM glossary_segments WHERE id = $1;`
+
+// Delete a glossary segment.
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.dbConn.Exec(ctx, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete glossary segment: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if n == 0 {
+		return storage.ErrGlossarySegmentNotFound
+	}
+
+	return nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.GlossarySegmentByGlossaryIDCondition:
+			predicates = append(predicates, fmt.Sprintf("s.glossary_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.GlossaryID)
+		case storage.GlossarySegmentByOrganizationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("s.glossary_id IN (SELECT id FROM glossaries WHERE organization_id = $%d)", len(args)+argOffset+1))
+			args = append(args, t.OrganizationID)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.GlossarySegmentSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.GlossarySegmentReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.GlossarySegmentOrderBy, conditions ...storage.Condition) ([]*storage.GlossarySegment, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossary segments: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossary segments: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.GlossarySegment
+	for rows.Next() {
+		var s storage.GlossarySegment
+		if err = scan(rows.Scan, &s); err != nil {
+			return nil, fmt.Errorf("failed to scan glossary segment: %w", err)
+		}
+
+		out = append(out, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list glossary segments: %w", err)
+	}
+
+	return out, nil
+}
+
+// searchQueryTemplate finds the candidates with the trigram index on the source text.
+const searchQueryTemplate = `SELECT s.id, s.glossary_id, s.source_language, s.source_text, s.target_language, s.target_text, s.create_time,
+	similarity(s.source_text, $1) AS score
+FROM glossary_segments s
+WHERE s.source_text %% $1
+AND similarity(s.source_text, $1) >= $2
+AND split_part(s.source_language, '-', 1) = $3
+AND split_part(s.target_language, '-', 1) = $4%s
+ORDER BY score DESC, s.create_time DESC
+LIMIT $5
+;`
+
+// Search implements storage.GlossarySegmentReader. A match is always at least as similar as the
+// pg_trgm.similarity_threshold setting, 0.3 by default, even when the MinScore is lower.
+func (r *Repository) Search(ctx context.Context, q storage.GlossarySegmentSearch, conditions ...storage.Condition) ([]*storage.GlossarySegmentMatch, error) {
+	args := []interface{}{q.Text, q.MinScore, q.SourceLanguage, q.TargetLanguage, q.Limit}
+	predicates, predicateArgs, err := generatePredicates(len(args), conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search glossary segments: %w", err)
+	}
+	args = append(args, predicateArgs...)
+
+	var where string
+	for _, p := range predicates {
+		where += "\nAND " + p
+	}
+
+	rows, err := r.dbConn.Query(ctx, fmt.Sprintf(searchQueryTemplate, where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search glossary segments: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.GlossarySegmentMatch
+	for rows.Next() {
+		var m storage.GlossarySegmentMatch
+		m.Segment = &storage.GlossarySegment{}
+		if err = scan(func(dest ...any) error { return rows.Scan(append(dest, &m.Score)...) }, m.Segment); err != nil {
+			return nil, fmt.Errorf("failed to scan glossary segment: %w", err)
+		}
+
+		out = append(out, &m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search glossary segments: %w", err)
+	}
+
+	return out, nil
+}
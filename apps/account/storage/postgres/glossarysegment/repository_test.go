@@ -0,0 +1,152 @@
+package glossarysegment_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossary"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossarysegment"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization(
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"test",
+				"test",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := glossarysegment.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "glossarysegment")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := glossarysegment.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	orgID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	glossaryID := uuid.MustParse("5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f")
+	if _, err := glossary.New(database.NewDBWrapper(db)).Create(ctx, &storage.Glossary{
+		ID:             glossaryID,
+		OrganizationID: orgID,
+		DisplayName:    "Product",
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	segments := []*storage.GlossarySegment{
+		{ID: uuid.New(), GlossaryID: glossaryID, SourceLanguage: "en", SourceText: "Open the workspace settings", TargetLanguage: "nl", TargetText: "Open de instellingen van de werkruimte"},
+		{ID: uuid.New(), GlossaryID: glossaryID, SourceLanguage: "en-US", SourceText: "Close the workspace", TargetLanguage: "nl-BE", TargetText: "Sluit de werkruimte"},
+		{ID: uuid.New(), GlossaryID: glossaryID, SourceLanguage: "en", SourceText: "Open the workspace settings", TargetLanguage: "de", TargetText: "Öffne die Einstellungen des Arbeitsbereichs"},
+	}
+
+	t.Run("Create should create a glossary segment", func(t *testing.T) {
+		for i, in := range segments {
+			in.CreateTime = createTime.Add(time.Duration(i) * time.Minute)
+			got, err := repo.Create(ctx, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(in, got); diff != "" {
+				t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("List should list the segments of the glossary", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{}, storage.GlossarySegmentOrderBy{
+			{Field: storage.GlossarySegmentCreateTime, Direction: storage.ASC},
+		}, storage.GlossarySegmentByGlossaryIDCondition{GlossaryID: glossaryID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(segments, got); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Search should find similar segments in the languages, best first", func(t *testing.T) {
+		got, err := repo.Search(ctx, storage.GlossarySegmentSearch{
+			Text:           "open the workspace settings",
+			SourceLanguage: "en",
+			TargetLanguage: "nl",
+			MinScore:       0.3,
+			Limit:          10,
+		}, storage.GlossarySegmentByOrganizationIDCondition{OrganizationID: orgID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 2 || got[0].Segment.ID != segments[0].ID || got[1].Segment.ID != segments[1].ID {
+			t.Fatalf("expected the dutch segments, got %+v", got)
+		}
+
+		if got[0].Score != 1 || got[1].Score >= 1 {
+			t.Errorf("expected an exact and a fuzzy match, got scores %v and %v", got[0].Score, got[1].Score)
+		}
+	})
+
+	t.Run("Search should leave out matches below the minimum score", func(t *testing.T) {
+		got, err := repo.Search(ctx, storage.GlossarySegmentSearch{
+			Text:           "Open the workspace settings",
+			SourceLanguage: "en",
+			TargetLanguage: "nl",
+			MinScore:       1,
+			Limit:          10,
+		}, storage.GlossarySegmentByGlossaryIDCondition{GlossaryID: glossaryID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 || got[0].Segment.ID != segments[0].ID {
+			t.Errorf("expected only the exact match, got %+v", got)
+		}
+	})
+
+	t.Run("Delete should delete a glossary segment", func(t *testing.T) {
+		if err := repo.Delete(ctx, segments[2].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repo.Get(ctx, segments[2].ID); !errors.Is(err, storage.ErrGlossarySegmentNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrGlossarySegmentNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+SELECT t.id, t.glossary_id, t.term, t.language, t.translation, t.do_not_translate, t.create_time, t.update_time
+FROM glossary_terms t
+{{- if .Predicates }}
+WHERE {{- range $i, $v := .Predicates }}
+	{{- if $i}} AND {{- end }} {{$v -}}
+{{- end }}
+{{- end -}}
+{{- if .Sorting }}
+ORDER BY {{- range $i, $v := .Sorting }}
+		{{- if $i}}, {{- end }} t.{{$v.Field }} {{$v.Direction -}}
+	{{- end }}
+{{- end -}}
+{{- if .LimitParam }}
+LIMIT {{.LimitParam -}}
+{{- end -}}
+{{- if .OffsetParam }}
+OFFSET {{.OffsetParam -}}
+{{- end -}};
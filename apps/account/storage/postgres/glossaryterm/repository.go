@@ -0,0 +1,299 @@
+package glossaryterm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	glossaryTermIDConstraint = "glossary_terms_pkey"
+)
+
+var _ storage.GlossaryTermRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a glossary term from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - glossary_id
+//   - term
+//   - language
+//   - translation
+//   - do_not_translate
+//   - create_time
+//   - update_time
+func scan(f func(dest ...any) error, t *storage.GlossaryTerm) error {
+	return f(
+		&t.ID,
+		&t.GlossaryID,
+		&t.Term,
+		&t.Language,
+		&t.Translation,
+		&t.DoNotTranslate,
+		&t.CreateTime,
+		&t.UpdateTime,
+	)
+}
+
+const createQuery = `INSERT INTO glossary_terms (id, glossary_id, term, language, translation, do_not_translate, create_time, update_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, glossary_id, term, language, translation, do_not_translate, create_time, update_time
+;`
+
+// Create a new glossary term.
+func (r *Repository) Create(ctx context.Context, t *storage.GlossaryTerm) (*storage.GlossaryTerm, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		t.ID,
+		t.GlossaryID,
+		t.Term,
+		t.Language,
+		t.Translation,
+		t.DoNotTranslate,
+		t.CreateTime,
+		t.UpdateTime,
+	)
+
+	var n storage.GlossaryTerm
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == glossaryTermIDConstraint {
+			return nil, storage.ErrConflictGlossaryTermID
+		}
+
+		return nil, fmt.Errorf("failed to insert glossary term: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, glossary_id, term, language, translation, do_not_translate, create_time, update_time
+FROM glossary_terms
+WHERE id = $1
+;`
+
+// Get a glossary term by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.GlossaryTerm, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var t storage.GlossaryTerm
+	if err := scan(row.Scan, &t); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrGlossaryTermNotFound
+		}
+
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+const updateQueryTemplate = `UPDATE glossary_terms
+SET %s
+WHERE id = $%d
+RETURNING id, glossary_id, term, language, translation, do_not_translate, create_time, update_time;`
+
+func (r *Repository) Update(ctx context.Context, in *storage.GlossaryTerm, fields []storage.GlossaryTermField) (*storage.GlossaryTerm, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoGlossaryTermFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "term = $1"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.GlossaryTermTerm:
+			set = append(set, fmt.Sprintf("term = $%d", index))
+			args = append(args, in.Term)
+		case storage.GlossaryTermLanguage:
+			set = append(set, fmt.Sprintf("language = $%d", index))
+			args = append(args, in.Language)
+		case storage.GlossaryTermTranslation:
+			set = append(set, fmt.Sprintf("translation = $%d", index))
+			args = append(args, in.Translation)
+		case storage.GlossaryTermDoNotTranslate:
+			set = append(set, fmt.Sprintf("do_not_translate = $%d", index))
+			args = append(args, in.DoNotTranslate)
+		case storage.GlossaryTermUpdateTime:
+			set = append(set, fmt.Sprintf("update_time = $%d", index))
+			args = append(args, in.UpdateTime)
+		case storage.GlossaryTermID:
+			return nil, storage.ErrImmutableGlossaryTermID
+		case storage.GlossaryTermGlossaryID:
+			return nil, storage.ErrImmutableGlossaryTermGlossaryID
+		case storage.GlossaryTermCreateTime:
+			return nil, storage.ErrImmutableGlossaryTermCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrGlossaryTermUnknownField)
+		}
+	}
+
+	// Add the glossary term ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the glossary term ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var t storage.GlossaryTerm
+	if err := scan(row.Scan, &t); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrGlossaryTermNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan glossary term: %w", err)
+	}
+
+	return &t, nil
+}
+
+const deleteQuery = `DELETE FROM glossary_terms WHERE id = $1;`
+
+// Delete a glossary term.
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.dbConn.Exec(ctx, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete glossary term: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if n == 0 {
+		return storage.ErrGlossaryTermNotFound
+	}
+
+	return nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.GlossaryTermByGlossaryIDCondition:
+			predicates = append(predicates, fmt.Sprintf("t.glossary_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.GlossaryID)
+		case storage.GlossaryTermByOrganizationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("t.glossary_id IN (SELECT id FROM glossaries WHERE organization_id = $%d)", len(args)+argOffset+1))
+			args = append(args, t.OrganizationID)
+		case storage.GlossaryTermForLanguageCondition:
+			predicates = append(predicates, fmt.Sprintf("(split_part(t.language, '-', 1) = $%d OR t.do_not_translate)", len(args)+argOffset+1))
+			args = append(args, t.Language)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.GlossaryTermSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.GlossaryTermReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.GlossaryTermOrderBy, conditions ...storage.Condition) ([]*storage.GlossaryTerm, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossary terms: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossary terms: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*storage.GlossaryTerm
+	for rows.Next() {
+		var t storage.GlossaryTerm
+		if err = scan(rows.Scan, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan glossary term: %w", err)
+		}
+
+		out = append(out, &t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list glossary terms: %w", err)
+	}
+
+	return out, nil
+}
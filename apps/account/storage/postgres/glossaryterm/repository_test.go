@@ -0,0 +1,139 @@
+package glossaryterm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossary"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossaryterm"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization(
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"test",
+				"test",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := glossaryterm.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "glossaryterm")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := glossaryterm.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	orgID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	glossaryID := uuid.MustParse("5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f")
+	if _, err := glossary.New(database.NewDBWrapper(db)).Create(ctx, &storage.Glossary{
+		ID:             glossaryID,
+		OrganizationID: orgID,
+		DisplayName:    "Product",
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	terms := []*storage.GlossaryTerm{
+		{ID: uuid.New(), GlossaryID: glossaryID, Term: "workspace", Language: "nl-BE", Translation: "werkruimte"},
+		{ID: uuid.New(), GlossaryID: glossaryID, Term: "workspace", Language: "de", Translation: "Arbeitsbereich"},
+		{ID: uuid.New(), GlossaryID: glossaryID, Term: "Lingo", DoNotTranslate: true},
+	}
+
+	t.Run("Create should create a glossary term", func(t *testing.T) {
+		for _, in := range terms {
+			in.CreateTime = createTime
+			in.UpdateTime = createTime
+			got, err := repo.Create(ctx, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(in, got); diff != "" {
+				t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("List should list the terms that apply to a language", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{}, storage.GlossaryTermOrderBy{
+			{Field: storage.GlossaryTermTerm, Direction: storage.ASC},
+		},
+			storage.GlossaryTermByOrganizationIDCondition{OrganizationID: orgID},
+			storage.GlossaryTermForLanguageCondition{Language: "nl"},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]*storage.GlossaryTerm{terms[2], terms[0]}, got); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Update should change the translation", func(t *testing.T) {
+		in := terms[0]
+		in.Translation = "werkplek"
+		in.UpdateTime = createTime.Add(time.Hour)
+		got, err := repo.Update(ctx, in, []storage.GlossaryTermField{storage.GlossaryTermTranslation, storage.GlossaryTermUpdateTime})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Update() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Update should not move a term to another glossary", func(t *testing.T) {
+		_, err := repo.Update(ctx, terms[0], []storage.GlossaryTermField{storage.GlossaryTermGlossaryID})
+		if !errors.Is(err, storage.ErrImmutableGlossaryTermGlossaryID) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableGlossaryTermGlossaryID, err)
+		}
+	})
+
+	t.Run("Delete should delete a glossary term", func(t *testing.T) {
+		if err := repo.Delete(ctx, terms[1].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repo.Get(ctx, terms[1].ID); !errors.Is(err, storage.ErrGlossaryTermNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrGlossaryTermNotFound, err)
+		}
+	})
+}
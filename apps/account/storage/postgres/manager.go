@@ -2,17 +2,46 @@ package postgres
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/audit"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/conversation"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossary"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossarysegment"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/glossaryterm"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/message"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/messagetranslation"
 	"github.com/extreme-business/lingo/apps/account/storage/postgres/organization"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/participant"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/registrationtoken"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/session"
 	"github.com/extreme-business/lingo/apps/account/storage/postgres/user"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/webhook"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/webhookdelivery"
 	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/jobs"
 )
 
 func Factory(c database.Conn) storage.Repositories {
 	return storage.Repositories{
-		User:         user.New(c),
-		Organization: organization.New(c),
+		User:               user.New(c),
+		Organization:       organization.New(c),
+		Session:            session.New(c),
+		AuditEvent:         audit.New(c),
+		OutboxEvent:        outbox.New(c),
+		Webhook:            webhook.New(c),
+		WebhookDelivery:    webhookdelivery.New(c),
+		Conversation:       conversation.New(c),
+		Participant:        participant.New(c),
+		Message:            message.New(c),
+		MessageTranslation: messagetranslation.New(c),
+		Glossary:           glossary.New(c),
+		GlossaryTerm:       glossaryterm.New(c),
+		GlossarySegment:    glossarysegment.New(c),
+		RegistrationToken:  registrationtoken.New(c),
+		Jobs:               jobs.NewClient(time.Now, c),
 	}
 }
 
@@ -0,0 +1,303 @@
+package message
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	messageIDConstraint = "messages_pkey"
+)
+
+var _ storage.MessageRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a message from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - sequence
+//   - conversation_id
+//   - sender_id
+//   - body
+//   - language
+//   - create_time
+//   - update_time
+//   - edit_time
+//   - delete_time
+//   - create_position
+//   - position
+func scan(f func(dest ...any) error, m *storage.Message) error {
+	return f(
+		&m.ID,
+		&m.Sequence,
+		&m.ConversationID,
+		&m.SenderID,
+		&m.Body,
+		&m.Language,
+		&m.CreateTime,
+		&m.UpdateTime,
+		&m.EditTime,
+		&m.DeleteTime,
+		&m.CreatePosition,
+		&m.Position,
+	)
+}
+
+const createQuery = `INSERT INTO messages (id, conversation_id, sender_id, body, language, create_time, update_time, edit_time, delete_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, sequence, conversation_id, sender_id, body, language, create_time, update_time, edit_time, delete_time, create_position, position
+;`
+
+// Create a new message. The sequence is assigned by the database, the positions when the transaction commits.
+func (r *Repository) Create(ctx context.Context, m *storage.Message) (*storage.Message, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		m.ID,
+		m.ConversationID,
+		m.SenderID,
+		m.Body,
+		m.Language,
+		m.CreateTime,
+		m.UpdateTime,
+		m.EditTime,
+		m.DeleteTime,
+	)
+
+	var n storage.Message
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == messageIDConstraint {
+			return nil, storage.ErrConflictMessageID
+		}
+
+		return nil, fmt.Errorf("failed to insert message: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, sequence, conversation_id, sender_id, body, language, create_time, update_time, edit_time, delete_time, create_position, position
+FROM messages
+WHERE id = $1
+;`
+
+// Get a message by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Message, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var m storage.Message
+	if err := scan(row.Scan, &m); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrMessageNotFound
+		}
+
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+const updateQueryTemplate = `UPDATE messages
+SET %s
+WHERE id = $%d
+RETURNING id, sequence, conversation_id, sender_id, body, language, create_time, update_time, edit_time, delete_time, create_position, position;`
+
+func (r *Repository) Update(ctx context.Context, in *storage.Message, fields []storage.MessageField) (*storage.Message, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoMessageFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "body = $1", "edit_time = $2"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.MessageBody:
+			set = append(set, fmt.Sprintf("body = $%d", index))
+			args = append(args, in.Body)
+		case storage.MessageLanguage:
+			set = append(set, fmt.Sprintf("language = $%d", index))
+			args = append(args, in.Language)
+		case storage.MessageUpdateTime:
+			set = append(set, fmt.Sprintf("update_time = $%d", index))
+			args = append(args, in.UpdateTime)
+		case storage.MessageEditTime:
+			if in.EditTime.Time.IsZero() {
+				set = append(set, "edit_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("edit_time = $%d", index))
+				args = append(args, in.EditTime.Time)
+			}
+		case storage.MessageDeleteTime:
+			if in.DeleteTime.Time.IsZero() {
+				set = append(set, "delete_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("delete_time = $%d", index))
+				args = append(args, in.DeleteTime.Time)
+			}
+		case storage.MessageID:
+			return nil, storage.ErrImmutableMessageID
+		case storage.MessageSequence:
+			return nil, storage.ErrImmutableMessageSequence
+		case storage.MessageConversationID:
+			return nil, storage.ErrImmutableMessageConversationID
+		case storage.MessageSenderID:
+			return nil, storage.ErrImmutableMessageSenderID
+		case storage.MessageCreateTime:
+			return nil, storage.ErrImmutableMessageCreateTime
+		case storage.MessageCreatePosition:
+			return nil, storage.ErrImmutableMessageCreatePosition
+		case storage.MessagePosition:
+			return nil, storage.ErrImmutableMessagePosition
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrMessageUnknownField)
+		}
+	}
+
+	// Add the message ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the message ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var m storage.Message
+	if err := scan(row.Scan, &m); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrMessageNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan message: %w", err)
+	}
+
+	return &m, nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.MessageByConversationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("m.conversation_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.ConversationID)
+		case storage.MessageBeforeSequenceCondition:
+			predicates = append(predicates, fmt.Sprintf("m.sequence < $%d", len(args)+argOffset+1))
+			args = append(args, t.Sequence)
+		case storage.MessageAfterPositionCondition:
+			predicates = append(predicates, fmt.Sprintf("m.position > $%d", len(args)+argOffset+1))
+			args = append(args, t.Position)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.MessageSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.MessageReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.MessageOrderBy, conditions ...storage.Condition) ([]*storage.Message, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*storage.Message
+	for rows.Next() {
+		var m storage.Message
+		if err = scan(rows.Scan, &m); err != nil {
+			return nil, fmt.Errorf("failed to scan message: %w", err)
+		}
+
+		messages = append(messages, &m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	return messages, nil
+}
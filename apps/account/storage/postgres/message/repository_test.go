@@ -0,0 +1,182 @@
+package message_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/conversation"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/message"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization("7bb443e5-8974-44c2-8b7c-b95124205264", "test", "test", createTime, createTime),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := message.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "message")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := message.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	conversationID := uuid.MustParse("2a7d1c3e-5f6b-4a8c-9d0e-1f2a3b4c5d6e")
+	senderID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205265")
+	if _, err := conversation.New(database.NewDBWrapper(db)).Create(ctx, &storage.Conversation{
+		ID:             conversationID,
+		OrganizationID: uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264"),
+		Type:           "group",
+		CreatorID:      senderID,
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var created []*storage.Message
+	t.Run("Create should assign increasing sequences", func(t *testing.T) {
+		for i, body := range []string{"hello", "world"} {
+			in := &storage.Message{
+				ID:             uuid.New(),
+				ConversationID: conversationID,
+				SenderID:       senderID,
+				Body:           body,
+				CreateTime:     createTime.Add(time.Duration(i) * time.Minute),
+				UpdateTime:     createTime.Add(time.Duration(i) * time.Minute),
+			}
+			got, err := repo.Create(ctx, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(in, got, cmpopts.IgnoreFields(storage.Message{}, "Sequence")); diff != "" {
+				t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+			}
+
+			if len(created) > 0 && got.Sequence <= created[len(created)-1].Sequence {
+				t.Errorf("expected sequence after %d, got %d", created[len(created)-1].Sequence, got.Sequence)
+			}
+
+			created = append(created, got)
+		}
+	})
+
+	t.Run("List should page through the messages newest first", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{Limit: 1}, storage.MessageOrderBy{
+			{Field: storage.MessageSequence, Direction: storage.DESC},
+		},
+			storage.MessageByConversationIDCondition{ConversationID: conversationID},
+			storage.MessageBeforeSequenceCondition{Sequence: created[1].Sequence},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the positions are stamped when the insert commits, after it returned the message
+		if diff := cmp.Diff([]*storage.Message{created[0]}, got, cmpopts.IgnoreFields(storage.Message{}, "CreatePosition", "Position")); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Update should mark a message as deleted", func(t *testing.T) {
+		in := created[0]
+		in.Body = ""
+		in.DeleteTime = sql.NullTime{Time: createTime.Add(time.Hour), Valid: true}
+		got, err := repo.Update(ctx, in, []storage.MessageField{storage.MessageBody, storage.MessageDeleteTime})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got, cmpopts.IgnoreFields(storage.Message{}, "CreatePosition", "Position")); diff != "" {
+			t.Errorf("Update() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("List should list the messages changed after a position in commit order", func(t *testing.T) {
+		first, err := repo.Get(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		second, err := repo.Get(ctx, created[1].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if first.CreatePosition == 0 || first.CreatePosition >= second.CreatePosition {
+			t.Fatalf("expected increasing create positions, got %d and %d", first.CreatePosition, second.CreatePosition)
+		}
+
+		if first.Position <= second.Position {
+			t.Fatalf("expected the deletion to move the position after %d, got %d", second.Position, first.Position)
+		}
+
+		got, err := repo.List(ctx, storage.Pagination{}, storage.MessageOrderBy{
+			{Field: storage.MessagePosition, Direction: storage.ASC},
+		},
+			storage.MessageByConversationIDCondition{ConversationID: conversationID},
+			storage.MessageAfterPositionCondition{Position: second.CreatePosition},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]*storage.Message{first}, got); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Update should not change the position", func(t *testing.T) {
+		_, err := repo.Update(ctx, created[1], []storage.MessageField{storage.MessagePosition})
+		if !errors.Is(err, storage.ErrImmutableMessagePosition) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableMessagePosition, err)
+		}
+	})
+
+	t.Run("Update should not change the sender", func(t *testing.T) {
+		_, err := repo.Update(ctx, created[1], []storage.MessageField{storage.MessageSenderID})
+		if !errors.Is(err, storage.ErrImmutableMessageSenderID) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableMessageSenderID, err)
+		}
+	})
+
+	t.Run("Get should return ErrMessageNotFound for an unknown id", func(t *testing.T) {
+		if _, err := repo.Get(ctx, uuid.New()); !errors.Is(err, storage.ErrMessageNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrMessageNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package messagetranslation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	_ "embed"
+)
+
+var _ storage.MessageTranslationRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a message translation from a sql.Row or sql.Rows.
+// cols:
+//   - message_id
+//   - language
+//   - body
+//   - message_update_time
+//   - create_time
+func scan(f func(dest ...any) error, t *storage.MessageTranslation) error {
+	return f(
+		&t.MessageID,
+		&t.Language,
+		&t.Body,
+		&t.MessageUpdateTime,
+		&t.CreateTime,
+	)
+}
+
+// upsertQuery replaces a translation only with one of the same or a newer version of the message,
+// so a slow translation of an edited message can not overwrite the translation of the edit.
+const upsertQuery = `INSERT INTO message_translations (message_id, language, body, message_update_time, create_time)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (message_id, language) DO UPDATE
+SET body = EXCLUDED.body, message_update_time = EXCLUDED.message_update_time, create_time = EXCLUDED.create_time
+WHERE message_translations.message_update_time <= EXCLUDED.message_update_time
+;`
+
+// Upsert creates or replaces the translation of a message into a language.
+func (r *Repository) Upsert(ctx context.Context, t *storage.MessageTranslation) error {
+	if _, err := r.dbConn.Exec(
+		ctx,
+		upsertQuery,
+		t.MessageID,
+		t.Language,
+		t.Body,
+		t.MessageUpdateTime,
+		t.CreateTime,
+	); err != nil {
+		return fmt.Errorf("failed to upsert message translation: %w", err)
+	}
+
+	return nil
+}
+
+const getQuery = `SELECT message_id, language, body, message_update_time, create_time
+FROM message_translations
+WHERE message_id = $1 AND language = $2
+;`
+
+// Get the translation of a message into a language.
+func (r *Repository) Get(ctx context.Context, messageID uuid.UUID, language string) (*storage.MessageTranslation, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, messageID, language)
+	var t storage.MessageTranslation
+	if err := scan(row.Scan, &t); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrMessageTranslationNotFound
+		}
+
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.MessageTranslationByMessageIDsCondition:
+			predicates = append(predicates, fmt.Sprintf("t.message_id = ANY($%d)", len(args)+argOffset+1))
+			args = append(args, pq.Array(t.MessageIDs))
+		case storage.MessageTranslationByLanguageCondition:
+			predicates = append(predicates, fmt.Sprintf("t.language = $%d", len(args)+argOffset+1))
+			args = append(args, t.Language)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.MessageTranslationSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.MessageTranslationReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.MessageTranslationOrderBy, conditions ...storage.Condition) ([]*storage.MessageTranslation, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message translations: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message translations: %w", err)
+	}
+	defer rows.Close()
+
+	var translations []*storage.MessageTranslation
+	for rows.Next() {
+		var t storage.MessageTranslation
+		if err = scan(rows.Scan, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan message translation: %w", err)
+		}
+
+		translations = append(translations, &t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list message translations: %w", err)
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,167 @@
+package messagetranslation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/conversation"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/message"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/messagetranslation"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization("7bb443e5-8974-44c2-8b7c-b95124205264", "test", "test", createTime, createTime),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := messagetranslation.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "messagetranslation")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := messagetranslation.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	conversationID := uuid.MustParse("2a7d1c3e-5f6b-4a8c-9d0e-1f2a3b4c5d6e")
+	senderID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205265")
+	if _, err := conversation.New(database.NewDBWrapper(db)).Create(ctx, &storage.Conversation{
+		ID:             conversationID,
+		OrganizationID: uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264"),
+		Type:           "group",
+		CreatorID:      senderID,
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := message.New(database.NewDBWrapper(db)).Create(ctx, &storage.Message{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Body:           "hello",
+		Language:       "en",
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited := &storage.MessageTranslation{
+		MessageID:         m.ID,
+		Language:          "nl",
+		Body:              "hallo wereld",
+		MessageUpdateTime: createTime.Add(time.Minute),
+		CreateTime:        createTime.Add(time.Minute),
+	}
+
+	t.Run("Upsert should create a translation", func(t *testing.T) {
+		in := &storage.MessageTranslation{
+			MessageID:         m.ID,
+			Language:          "nl",
+			Body:              "hallo",
+			MessageUpdateTime: createTime,
+			CreateTime:        createTime,
+		}
+		if err := repo.Upsert(ctx, in); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.Get(ctx, m.ID, "nl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Upsert should replace a translation of an older version", func(t *testing.T) {
+		if err := repo.Upsert(ctx, edited); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.Get(ctx, m.ID, "nl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(edited, got); diff != "" {
+			t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Upsert should ignore a translation of an older version", func(t *testing.T) {
+		if err := repo.Upsert(ctx, &storage.MessageTranslation{
+			MessageID:         m.ID,
+			Language:          "nl",
+			Body:              "hallo",
+			MessageUpdateTime: createTime,
+			CreateTime:        createTime.Add(time.Hour),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.Get(ctx, m.ID, "nl")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(edited, got); diff != "" {
+			t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("List should list the translations of messages into a language", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{}, storage.MessageTranslationOrderBy{},
+			storage.MessageTranslationByMessageIDsCondition{MessageIDs: []uuid.UUID{m.ID, uuid.New()}},
+			storage.MessageTranslationByLanguageCondition{Language: "nl"},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]*storage.MessageTranslation{edited}, got); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Get should return ErrMessageTranslationNotFound for an unknown language", func(t *testing.T) {
+		if _, err := repo.Get(ctx, m.ID, "de"); !errors.Is(err, storage.ErrMessageTranslationNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrMessageTranslationNotFound, err)
+		}
+	})
+}
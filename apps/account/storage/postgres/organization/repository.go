@@ -11,8 +11,8 @@ import (
 
 	"github.com/extreme-business/lingo/apps/account/storage"
 	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
 	"github.com/google/uuid"
-	"github.com/lib/pq"
 
 	_ "embed"
 )
@@ -22,6 +22,7 @@ const (
 	orgLegalNameConstraint  = "organizations_legal_name_key"
 	orgSlugUniqueConstraint = "organizations_slug_key"
 	orgSlugFormatConstraint = "organizations_chk_slug"
+	userOrgConstraint       = "users_organization_id_fkey"
 )
 
 var _ storage.OrganizationRepository = &Repository{}
@@ -58,6 +59,26 @@ VALUES ($1, $2, $3, $4, $5)
 RETURNING id,  legal_name, slug, create_time, update_time
 ;`
 
+// constraintError maps a violated constraint of an organization to its storage error.
+func constraintError(err error) error {
+	if constraint, ok := postgres.UniqueViolation(err); ok {
+		switch constraint {
+		case orgIDConstraint:
+			return storage.ErrConflictOrganizationID
+		case orgLegalNameConstraint:
+			return storage.ErrConflictOrganizationLegalName
+		case orgSlugUniqueConstraint:
+			return storage.ErrConflictOrganizationSlug
+		}
+	}
+
+	if constraint, ok := postgres.CheckViolation(err); ok && constraint == orgSlugFormatConstraint {
+		return storage.ErrInvalidOrganizationSlug
+	}
+
+	return nil
+}
+
 // Create a new organization.
 func (r *Repository) Create(ctx context.Context, u *storage.Organization) (*storage.Organization, error) {
 	row := r.dbConn.QueryRow(
@@ -72,20 +93,8 @@ func (r *Repository) Create(ctx context.Context, u *storage.Organization) (*stor
 
 	var n storage.Organization
 	if err := scan(row.Scan, &n); err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) {
-			if pqErr.Code.Name() == "unique_violation" {
-				switch pqErr.Constraint {
-				case orgIDConstraint:
-					return nil, storage.ErrConflictOrganizationID
-				case orgLegalNameConstraint:
-					return nil, storage.ErrConflictOrganizationLegalName
-				case orgSlugUniqueConstraint:
-					return nil, storage.ErrConflictOrganizationSlug
-				case orgSlugFormatConstraint:
-					return nil, storage.ErrInvalidOrganizationSlug
-				}
-			}
+		if cErr := constraintError(err); cErr != nil {
+			return nil, cErr
 		}
 
 		return nil, err
@@ -140,9 +149,9 @@ func (r *Repository) Update(ctx context.Context, in *storage.Organization, field
 			set = append(set, fmt.Sprintf("update_time = $%d", len(args)+1))
 			args = append(args, in.UpdateTime)
 		case storage.OrganizationID:
-			return nil, fmt.Errorf("field %s: %w", f, storage.ErrImmutableOrganizationCreateTime)
-		case storage.OrganizationCreateTime:
 			return nil, fmt.Errorf("field %s: %w", f, storage.ErrImmutableOrganizationID)
+		case storage.OrganizationCreateTime:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrImmutableOrganizationCreateTime)
 		default:
 			return nil, fmt.Errorf("field %s: %w", f, storage.ErrUnknownOrganizationField)
 		}
@@ -164,6 +173,10 @@ func (r *Repository) Update(ctx context.Context, in *storage.Organization, field
 			return nil, storage.ErrOrganizationNotFound
 		}
 
+		if cErr := constraintError(err); cErr != nil {
+			return nil, cErr
+		}
+
 		return nil, err
 	}
 
@@ -175,6 +188,10 @@ const deleteQuery = `DELETE FROM organizations WHERE id = $1;`
 func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 	result, err := r.dbConn.Exec(ctx, deleteQuery, id)
 	if err != nil {
+		if constraint, ok := postgres.ForeignKeyViolation(err); ok && constraint == userOrgConstraint {
+			return storage.ErrOrganizationHasUsers
+		}
+
 		return fmt.Errorf("failed to delete organization: %w", err)
 	}
 
@@ -184,7 +201,7 @@ func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if n == 0 {
-		return storage.ErrUserNotFound
+		return storage.ErrOrganizationNotFound
 	}
 
 	return nil
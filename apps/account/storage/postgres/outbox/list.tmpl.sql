@@ -0,0 +1,18 @@
+SELECT o.id, COALESCE(o.position, 0), o.aggregate_type, o.aggregate_id, o.event_type, o.payload, o.create_time, o.publish_time, o.attempts, o.last_error, o.fail_time, o.next_attempt_time
+FROM outbox_events o 
+{{- if .Predicates }}
+WHERE {{- range $i, $v := .Predicates }}
+	{{- if $i}} AND {{- end }} {{$v -}}
+{{- end }}
+{{- end -}}
+{{- if .Sorting }}
+ORDER BY {{- range $i, $v := .Sorting }}
+		{{- if $i}}, {{- end }} o.{{$v.Field }} {{$v.Direction -}}
+	{{- end }}
+{{- end -}}
+{{- if .LimitParam }}
+LIMIT {{.LimitParam -}}
+{{- end -}}
+{{- if .OffsetParam }}
+OFFSET {{.OffsetParam -}}
+{{- end -}};
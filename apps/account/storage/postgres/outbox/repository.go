@@ -0,0 +1,358 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/lib/pq"
+
+	_ "embed"
+)
+
+const (
+	// relayLockKey is the advisory lock that makes sure only one relay publishes events at a time.
+	relayLockKey = 0x6f757462 // "outb"
+)
+
+var _ storage.OutboxEventRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans an outbox event from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - position
+//   - aggregate_type
+//   - aggregate_id
+//   - event_type
+//   - payload
+//   - create_time
+//   - publish_time
+//   - attempts
+//   - last_error
+//   - fail_time
+//   - next_attempt_time
+func scan(f func(dest ...any) error, e *storage.OutboxEvent) error {
+	return f(
+		&e.ID,
+		&e.Position,
+		&e.AggregateType,
+		&e.AggregateID,
+		&e.Type,
+		&e.Payload,
+		&e.CreateTime,
+		&e.PublishTime,
+		&e.Attempts,
+		&e.LastError,
+		&e.FailTime,
+		&e.NextAttemptTime,
+	)
+}
+
+const lockQuery = `SELECT pg_advisory_xact_lock($1);`
+
+// Lock takes a transaction level advisory lock. It must run inside a transaction,
+// otherwise the lock is released as soon as the statement finishes.
+func (r *Repository) Lock(ctx context.Context) error {
+	if _, err := r.dbConn.Exec(ctx, lockQuery, relayLockKey); err != nil {
+		return fmt.Errorf("failed to lock outbox: %w", err)
+	}
+
+	return nil
+}
+
+const createQuery = `INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload, create_time)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, COALESCE(position, 0), aggregate_type, aggregate_id, event_type, payload, create_time, publish_time, attempts, last_error, fail_time, next_attempt_time
+;`
+
+// Create adds an event to the outbox. The id is assigned by the database, the position
+// when the transaction commits.
+func (r *Repository) Create(ctx context.Context, e *storage.OutboxEvent) (*storage.OutboxEvent, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		e.AggregateType,
+		e.AggregateID,
+		e.Type,
+		e.Payload,
+		e.CreateTime,
+	)
+
+	var n storage.OutboxEvent
+	if err := scan(row.Scan, &n); err != nil {
+		return nil, fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, COALESCE(position, 0), aggregate_type, aggregate_id, event_type, payload, create_time, publish_time, attempts, last_error, fail_time, next_attempt_time
+FROM outbox_events
+WHERE id = $1;`
+
+// Get gets an event by id.
+func (r *Repository) Get(ctx context.Context, id int64) (*storage.OutboxEvent, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var e storage.OutboxEvent
+	if err := scan(row.Scan, &e); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrOutboxEventNotFound
+		}
+
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+const updateQueryTemplate = `UPDATE outbox_events
+SET %s
+WHERE id = $%d
+RETURNING id, COALESCE(position, 0), aggregate_type, aggregate_id, event_type, payload, create_time, publish_time, attempts, last_error, fail_time, next_attempt_time;`
+
+// Update updates the delivery state of an event. The event itself can not be changed.
+func (r *Repository) Update(ctx context.Context, in *storage.OutboxEvent, fields []storage.OutboxEventField) (*storage.OutboxEvent, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoOutboxEventFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "attempts = $1", "last_error = $2"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.OutboxEventPublishTime:
+			if in.PublishTime.Time.IsZero() {
+				set = append(set, "publish_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("publish_time = $%d", index))
+				args = append(args, in.PublishTime.Time)
+			}
+		case storage.OutboxEventAttempts:
+			set = append(set, fmt.Sprintf("attempts = $%d", index))
+			args = append(args, in.Attempts)
+		case storage.OutboxEventLastError:
+			set = append(set, fmt.Sprintf("last_error = $%d", index))
+			args = append(args, in.LastError)
+		case storage.OutboxEventFailTime:
+			if in.FailTime.Time.IsZero() {
+				set = append(set, "fail_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("fail_time = $%d", index))
+				args = append(args, in.FailTime.Time)
+			}
+		case storage.OutboxEventNextAttemptTime:
+			if in.NextAttemptTime.Time.IsZero() {
+				set = append(set, "next_attempt_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("next_attempt_time = $%d", index))
+				args = append(args, in.NextAttemptTime.Time)
+			}
+		case storage.OutboxEventID:
+			return nil, storage.ErrImmutableOutboxEventID
+		case storage.OutboxEventPosition:
+			return nil, storage.ErrImmutableOutboxEventPosition
+		case storage.OutboxEventAggregateType:
+			return nil, storage.ErrImmutableOutboxEventAggregateType
+		case storage.OutboxEventAggregateID:
+			return nil, storage.ErrImmutableOutboxEventAggregateID
+		case storage.OutboxEventType:
+			return nil, storage.ErrImmutableOutboxEventType
+		case storage.OutboxEventPayload:
+			return nil, storage.ErrImmutableOutboxEventPayload
+		case storage.OutboxEventCreateTime:
+			return nil, storage.ErrImmutableOutboxEventCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrOutboxEventUnknownField)
+		}
+	}
+
+	// Add the event ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the event ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var e storage.OutboxEvent
+	if err := scan(row.Scan, &e); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrOutboxEventNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan outbox event: %w", err)
+	}
+
+	return &e, nil
+}
+
+const purgeQuery = `DELETE FROM outbox_events
+WHERE publish_time < $1
+AND position < (SELECT MAX(position) FROM outbox_events)
+AND position < COALESCE((SELECT MIN(position) FROM outbox_events WHERE publish_time IS NULL), 9223372036854775807)
+;`
+
+// Purge deletes the events published before the time. It keeps the latest event and the events after
+// the oldest one that is not published, such as an event the relay gave up on.
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.dbConn.Exec(ctx, purgeQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return n, nil
+}
+
+const redriveQuery = `UPDATE outbox_events SET fail_time = NULL, next_attempt_time = NULL, attempts = 0
+WHERE fail_time IS NOT NULL AND (cardinality($1::BIGINT[]) = 0 OR id = ANY($1))
+;`
+
+// Redrive hands the parked events with the ids back to the relay, all parked events without ids.
+// Their attempts start over, the last error is kept until the next attempt.
+func (r *Repository) Redrive(ctx context.Context, ids ...int64) (int64, error) {
+	if ids == nil {
+		ids = []int64{} // a nil slice is NULL, which would match no event.
+	}
+
+	result, err := r.dbConn.Exec(ctx, redriveQuery, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to redrive outbox events: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return n, nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch c := c.(type) {
+		case storage.OutboxEventUnpublishedCondition:
+			predicates = append(predicates, "o.publish_time IS NULL")
+		case storage.OutboxEventAfterPositionCondition:
+			predicates = append(predicates, fmt.Sprintf("o.position > $%d", argOffset+len(args)+1))
+			args = append(args, c.Position)
+		case storage.OutboxEventByAggregateTypeCondition:
+			predicates = append(predicates, fmt.Sprintf("o.aggregate_type = $%d", argOffset+len(args)+1))
+			args = append(args, c.AggregateType)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.OutboxEventSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.OutboxEventReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.OutboxEventOrderBy, conditions ...storage.Condition) ([]*storage.OutboxEvent, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox events: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*storage.OutboxEvent
+	for rows.Next() {
+		var e storage.OutboxEvent
+		if err = scan(rows.Scan, &e); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+
+		events = append(events, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list outbox events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,257 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/outbox"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := outbox.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "outbox")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := outbox.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	aggregateID := uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770")
+
+	t.Run("Create should assign increasing ids", func(t *testing.T) {
+		for i := int64(1); i <= 3; i++ {
+			got, err := repo.Create(ctx, &storage.OutboxEvent{
+				AggregateType: "user",
+				AggregateID:   aggregateID,
+				Type:          "user.updated",
+				Payload:       []byte(`{"fields": ["email"]}`),
+				CreateTime:    createTime,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.ID != i {
+				t.Errorf("expected id %d, got %d", i, got.ID)
+			}
+		}
+	})
+
+	t.Run("Update should mark an event as published", func(t *testing.T) {
+		got, err := repo.Update(ctx, &storage.OutboxEvent{
+			ID:          1,
+			PublishTime: sql.NullTime{Time: createTime, Valid: true},
+			Attempts:    1,
+		}, []storage.OutboxEventField{storage.OutboxEventPublishTime, storage.OutboxEventAttempts})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.PublishTime.Valid || got.Attempts != 1 {
+			t.Errorf("expected the event to be published after one attempt, got %+v", got)
+		}
+	})
+
+	t.Run("Update should not change the event itself", func(t *testing.T) {
+		_, err := repo.Update(ctx, &storage.OutboxEvent{ID: 1}, []storage.OutboxEventField{storage.OutboxEventPayload})
+		if !errors.Is(err, storage.ErrImmutableOutboxEventPayload) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableOutboxEventPayload, err)
+		}
+	})
+
+	t.Run("Update should park an event", func(t *testing.T) {
+		got, err := repo.Update(ctx, &storage.OutboxEvent{
+			ID:        3,
+			FailTime:  sql.NullTime{Time: createTime, Valid: true},
+			Attempts:  10,
+			LastError: "sink unavailable",
+		}, []storage.OutboxEventField{storage.OutboxEventFailTime, storage.OutboxEventAttempts, storage.OutboxEventLastError})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.FailTime.Valid || got.PublishTime.Valid {
+			t.Errorf("expected the event to be parked, got %+v", got)
+		}
+	})
+
+	t.Run("List should return the unpublished events in order", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{Limit: 10}, storage.OutboxEventOrderBy{
+			{Field: storage.OutboxEventID, Direction: storage.ASC},
+		}, storage.OutboxEventUnpublishedCondition{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []int64
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+
+		if diff := cmp.Diff([]int64{2, 3}, ids); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Get should return the event", func(t *testing.T) {
+		got, err := repo.Get(ctx, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.ID != 2 || got.AggregateID != aggregateID {
+			t.Errorf("expected event 2 of %s, got %+v", aggregateID, got)
+		}
+	})
+
+	t.Run("Get should return not found", func(t *testing.T) {
+		_, err := repo.Get(ctx, 100)
+		if !errors.Is(err, storage.ErrOutboxEventNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrOutboxEventNotFound, err)
+		}
+	})
+
+	t.Run("Create should assign positions when the transaction commits", func(t *testing.T) {
+		insert := `INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload) VALUES ('user', $1, 'user.updated', '{}') RETURNING id;`
+
+		first, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var firstID int64
+		if err = first.QueryRowContext(ctx, insert, aggregateID).Scan(&firstID); err != nil {
+			t.Fatal(err)
+		}
+
+		// the event with the higher id commits first.
+		var secondID int64
+		if err = db.QueryRowContext(ctx, insert, aggregateID).Scan(&secondID); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = first.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		a, err := repo.Get(ctx, firstID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := repo.Get(ctx, secondID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.Position <= b.Position {
+			t.Errorf("expected event %d committed last to have the higher position, got %d and %d", firstID, a.Position, b.Position)
+		}
+
+		if _, err = db.ExecContext(ctx, `DELETE FROM outbox_events WHERE id IN ($1, $2);`, firstID, secondID); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("List should return the events after a position", func(t *testing.T) {
+		if _, err := repo.Create(ctx, &storage.OutboxEvent{
+			AggregateType: "organization",
+			AggregateID:   aggregateID,
+			Type:          "organization.updated",
+			Payload:       []byte(`{"fields": ["slug"]}`),
+			CreateTime:    createTime,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.List(ctx, storage.Pagination{Limit: 10}, storage.OutboxEventOrderBy{
+			{Field: storage.OutboxEventPosition, Direction: storage.ASC},
+		}, storage.OutboxEventAfterPositionCondition{Position: 1}, storage.OutboxEventByAggregateTypeCondition{AggregateType: "user"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []int64
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+
+		if diff := cmp.Diff([]int64{2, 3}, ids); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Redrive should hand the parked events back to the relay", func(t *testing.T) {
+		n, err := repo.Redrive(ctx, 2, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 1 {
+			t.Errorf("expected only the parked event to be redriven, got %d", n)
+		}
+
+		got, err := repo.Get(ctx, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.FailTime.Valid || got.Attempts != 0 || got.LastError != "sink unavailable" {
+			t.Errorf("expected the attempts of the event to start over, got %+v", got)
+		}
+
+		if n, err = repo.Redrive(ctx); err != nil || n != 0 {
+			t.Errorf("expected no parked events to be left, got %d, %v", n, err)
+		}
+	})
+
+	t.Run("Purge should delete the published events", func(t *testing.T) {
+		n, err := repo.Purge(ctx, createTime.Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 1 {
+			t.Errorf("expected 1 event to be purged, got %d", n)
+		}
+
+		if _, err = repo.Get(ctx, 1); !errors.Is(err, storage.ErrOutboxEventNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrOutboxEventNotFound, err)
+		}
+
+		if _, err = repo.Get(ctx, 2); err != nil {
+			t.Errorf("expected the unpublished event to be kept, got %q", err)
+		}
+
+	})
+
+}
@@ -0,0 +1,204 @@
+package participant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	participantConstraint = "conversation_participants_pkey"
+)
+
+var _ storage.ParticipantRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a participant from a sql.Row or sql.Rows.
+// cols:
+//   - conversation_id
+//   - user_id
+//   - join_time
+func scan(f func(dest ...any) error, p *storage.Participant) error {
+	return f(
+		&p.ConversationID,
+		&p.UserID,
+		&p.JoinTime,
+	)
+}
+
+const createQuery = `INSERT INTO conversation_participants (conversation_id, user_id, join_time)
+VALUES ($1, $2, $3)
+RETURNING conversation_id, user_id, join_time
+;`
+
+// Create adds a user to a conversation.
+func (r *Repository) Create(ctx context.Context, p *storage.Participant) (*storage.Participant, error) {
+	row := r.dbConn.QueryRow(ctx, createQuery, p.ConversationID, p.UserID, p.JoinTime)
+
+	var n storage.Participant
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == participantConstraint {
+			return nil, storage.ErrConflictParticipant
+		}
+
+		return nil, fmt.Errorf("failed to insert participant: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT conversation_id, user_id, join_time
+FROM conversation_participants
+WHERE conversation_id = $1 AND user_id = $2
+;`
+
+// Get a participant of a conversation.
+func (r *Repository) Get(ctx context.Context, conversationID, userID uuid.UUID) (*storage.Participant, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, conversationID, userID)
+	var p storage.Participant
+	if err := scan(row.Scan, &p); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrParticipantNotFound
+		}
+
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+const deleteQuery = `DELETE FROM conversation_participants WHERE conversation_id = $1 AND user_id = $2;`
+
+// Delete removes a user from a conversation.
+func (r *Repository) Delete(ctx context.Context, conversationID, userID uuid.UUID) error {
+	result, err := r.dbConn.Exec(ctx, deleteQuery, conversationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete participant: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if n == 0 {
+		return storage.ErrParticipantNotFound
+	}
+
+	return nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.ParticipantByConversationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("p.conversation_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.ConversationID)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.ParticipantSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.ParticipantReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.ParticipantOrderBy, conditions ...storage.Condition) ([]*storage.Participant, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+	defer rows.Close()
+
+	var participants []*storage.Participant
+	for rows.Next() {
+		var p storage.Participant
+		if err = scan(rows.Scan, &p); err != nil {
+			return nil, fmt.Errorf("failed to scan participant: %w", err)
+		}
+
+		participants = append(participants, &p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	return participants, nil
+}
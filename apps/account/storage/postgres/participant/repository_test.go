@@ -0,0 +1,117 @@
+package participant_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/conversation"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/participant"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization("7bb443e5-8974-44c2-8b7c-b95124205264", "test", "test", createTime, createTime),
+		},
+		Users: []*storage.User{
+			seed.NewUser(
+				"7bb443e5-8974-44c2-8b7c-b95124205265",
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"jane", "active", "jane@example.com", "password",
+				createTime, createTime, time.Time{},
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := participant.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "participant")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := participant.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	conversationID := uuid.MustParse("2a7d1c3e-5f6b-4a8c-9d0e-1f2a3b4c5d6e")
+	userID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205265")
+	if _, err := conversation.New(database.NewDBWrapper(db)).Create(ctx, &storage.Conversation{
+		ID:             conversationID,
+		OrganizationID: uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264"),
+		Type:           "direct",
+		CreatorID:      userID,
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	in := &storage.Participant{ConversationID: conversationID, UserID: userID, JoinTime: createTime}
+
+	t.Run("Create should add a participant", func(t *testing.T) {
+		got, err := repo.Create(ctx, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Create should return a conflict for an existing participant", func(t *testing.T) {
+		if _, err := repo.Create(ctx, in); !errors.Is(err, storage.ErrConflictParticipant) {
+			t.Errorf("expected %q, got %q", storage.ErrConflictParticipant, err)
+		}
+	})
+
+	t.Run("List should return the participants of the conversation", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{}, storage.ParticipantOrderBy{
+			{Field: storage.ParticipantJoinTime, Direction: storage.ASC},
+		}, storage.ParticipantByConversationIDCondition{ConversationID: conversationID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]*storage.Participant{in}, got); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Delete should remove a participant", func(t *testing.T) {
+		if err := repo.Delete(ctx, conversationID, userID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repo.Get(ctx, conversationID, userID); !errors.Is(err, storage.ErrParticipantNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrParticipantNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package registrationtoken
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+)
+
+const (
+	registrationTokenIDConstraint = "registration_tokens_pkey"
+)
+
+var _ storage.RegistrationTokenRepository = &Repository{}
+
+type Repository struct {
+	dbConn database.Conn
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+const useQuery = `INSERT INTO registration_tokens (id, email, use_time, expire_time)
+VALUES ($1, $2, $3, $4);`
+
+// Use stores the token as used. It returns storage.ErrRegistrationTokenUsed when the token was used before.
+func (r *Repository) Use(ctx context.Context, t *storage.RegistrationToken) error {
+	if _, err := r.dbConn.Exec(ctx, useQuery, t.ID, t.Email, t.UseTime, t.ExpireTime); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == registrationTokenIDConstraint {
+			return storage.ErrRegistrationTokenUsed
+		}
+
+		return fmt.Errorf("failed to insert registration token: %w", err)
+	}
+
+	return nil
+}
+
+const purgeQuery = `DELETE FROM registration_tokens WHERE expire_time < $1;`
+
+// Purge deletes the tokens that expired before the time. They are rejected without a record anyway.
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.dbConn.Exec(ctx, purgeQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge registration tokens: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return n, nil
+}
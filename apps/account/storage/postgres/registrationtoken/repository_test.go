@@ -0,0 +1,74 @@
+package registrationtoken_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/registrationtoken"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/uuid"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := registrationtoken.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName("registrationtoken"))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := registrationtoken.New(database.NewDBWrapper(db))
+
+	token := &storage.RegistrationToken{
+		ID:         uuid.MustParse("3f0d9a4e-2b1c-4e7d-9a8b-6c5d4e3f2a19"),
+		Email:      "jane@example.com",
+		UseTime:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpireTime: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Use should use a token only once", func(t *testing.T) {
+		if err := repo.Use(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repo.Use(ctx, token); !errors.Is(err, storage.ErrRegistrationTokenUsed) {
+			t.Errorf("expected %q, got %q", storage.ErrRegistrationTokenUsed, err)
+		}
+	})
+
+	t.Run("Purge should delete the tokens that expired before the time", func(t *testing.T) {
+		n, err := repo.Purge(ctx, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 0 {
+			t.Errorf("expected the token to be kept until it expired, purged %d", n)
+		}
+
+		if n, err = repo.Purge(ctx, time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)); err != nil || n != 1 {
+			t.Errorf("Purge() = %d, %v, want 1, nil", n, err)
+		}
+
+		if err = repo.Use(ctx, token); err != nil {
+			t.Errorf("expected a purged token to be stored again, got %v", err)
+		}
+	})
+}
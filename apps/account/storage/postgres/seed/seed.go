@@ -11,6 +11,7 @@ import (
 type State struct {
 	Organizations []*storage.Organization
 	Users         []*storage.User
+	Sessions      []*storage.Session
 }
 
 // Run runs the seed.
@@ -40,6 +41,12 @@ func Run(t *testing.T, connectionString string, s State) {
 		}
 	}
 
+	for _, session := range s.Sessions {
+		if err = InsertSession(context.Background(), tx, session); err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		t.Fatal(err)
 	}
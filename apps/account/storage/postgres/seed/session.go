@@ -0,0 +1,59 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+func NewSession(
+	id string,
+	userID string,
+	refreshTokenID string,
+	createTime time.Time,
+	expireTime time.Time,
+	revokeTime time.Time,
+) *storage.Session {
+	return &storage.Session{
+		ID:              uuid.MustParse(id),
+		UserID:          uuid.MustParse(userID),
+		RefreshFamily:   uuid.MustParse(id),
+		RefreshTokenID:  uuid.MustParse(refreshTokenID),
+		CreateTime:      createTime,
+		LastRefreshTime: createTime,
+		ExpireTime:      expireTime,
+		RevokeTime: sql.NullTime{
+			Time:  revokeTime,
+			Valid: !revokeTime.IsZero(),
+		},
+	}
+}
+
+func InsertSession(ctx context.Context, db *sql.Tx, s *storage.Session) error {
+	_, err := db.ExecContext(
+		ctx,
+		`INSERT INTO sessions (id, user_id, user_agent, ip_address, refresh_family, refresh_token_id, create_time, last_refresh_time, expire_time, revoke_time, impersonator)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		s.ID,
+		s.UserID,
+		s.UserAgent,
+		s.IPAddress,
+		s.RefreshFamily,
+		s.RefreshTokenID,
+		s.CreateTime,
+		s.LastRefreshTime,
+		s.ExpireTime,
+		s.RevokeTime,
+		s.Impersonator,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	return nil
+}
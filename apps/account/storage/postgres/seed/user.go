@@ -26,6 +26,7 @@ func NewUser(
 		OrganizationID: uuid.MustParse(organizationID),
 		DisplayName:    displayName,
 		Status:         status,
+		Role:           "user",
 		Email:          email,
 		HashedPassword: password,
 		CreateTime:     createTime,
@@ -40,13 +41,14 @@ func NewUser(
 func InsertUser(ctx context.Context, db *sql.Tx, u *storage.User) error {
 	_, err := db.ExecContext(
 		ctx,
-		`INSERT INTO users (id, organization_id, display_name, email, hashed_password, create_time, update_time) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		`INSERT INTO users (id, organization_id, display_name, email, hashed_password, role, create_time, update_time) 
+		VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), 'user')::user_role, $7, $8)`,
 		u.ID,
 		u.OrganizationID,
 		u.DisplayName,
 		u.Email,
 		u.HashedPassword,
+		u.Role,
 		u.CreateTime,
 		u.UpdateTime,
 	)
@@ -0,0 +1,18 @@
+SELECT s.id, s.user_id, s.user_agent, s.ip_address, s.refresh_family, s.refresh_token_id, s.create_time, s.last_refresh_time, s.expire_time, s.revoke_time, s.impersonator
+FROM sessions s 
+{{- if .Predicates }}
+WHERE {{- range $i, $v := .Predicates }}
+	{{- if $i}} AND {{- end }} {{$v -}}
+{{- end }}
+{{- end -}}
+{{- if .Sorting }}
+ORDER BY {{- range $i, $v := .Sorting }}
+		{{- if $i}}, {{- end }} s.{{$v.Field }} {{$v.Direction -}}
+	{{- end }}
+{{- end -}}
+{{- if .LimitParam }}
+LIMIT {{.LimitParam -}}
+{{- end -}}
+{{- if .OffsetParam }}
+OFFSET {{.OffsetParam -}}
+{{- end -}};
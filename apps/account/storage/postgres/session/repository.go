@@ -0,0 +1,364 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	sessionIDConstraint = "sessions_pkey"
+)
+
+var _ storage.SessionRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a session from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - user_id
+//   - user_agent
+//   - ip_address
+//   - refresh_family
+//   - refresh_token_id
+//   - create_time
+//   - last_refresh_time
+//   - expire_time
+//   - revoke_time
+//   - impersonator
+func scan(f func(dest ...any) error, s *storage.Session) error {
+	return f(
+		&s.ID,
+		&s.UserID,
+		&s.UserAgent,
+		&s.IPAddress,
+		&s.RefreshFamily,
+		&s.RefreshTokenID,
+		&s.CreateTime,
+		&s.LastRefreshTime,
+		&s.ExpireTime,
+		&s.RevokeTime,
+		&s.Impersonator,
+	)
+}
+
+const createQuery = `INSERT INTO sessions (id, user_id, user_agent, ip_address, refresh_family, refresh_token_id, create_time, last_refresh_time, expire_time, revoke_time, impersonator)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, user_id, user_agent, ip_address, refresh_family, refresh_token_id, create_time, last_refresh_time, expire_time, revoke_time, impersonator
+;`
+
+// Create a new session.
+func (r *Repository) Create(ctx context.Context, s *storage.Session) (*storage.Session, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		s.ID,
+		s.UserID,
+		s.UserAgent,
+		s.IPAddress,
+		s.RefreshFamily,
+		s.RefreshTokenID,
+		s.CreateTime,
+		s.LastRefreshTime,
+		s.ExpireTime,
+		s.RevokeTime,
+		s.Impersonator,
+	)
+
+	var n storage.Session
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == sessionIDConstraint {
+			return nil, storage.ErrConflictSessionID
+		}
+
+		return nil, fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, user_id, user_agent, ip_address, refresh_family, refresh_token_id, create_time, last_refresh_time, expire_time, revoke_time, impersonator
+FROM sessions
+WHERE id = $1
+;`
+
+// Get a session by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Session, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var s storage.Session
+	if err := scan(row.Scan, &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSessionNotFound
+		}
+
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+const updateQueryTemplate = `UPDATE sessions
+SET %s
+WHERE id = $%d
+RETURNING id, user_id, user_agent, ip_address, refresh_family, refresh_token_id, create_time, last_refresh_time, expire_time, revoke_time, impersonator;`
+
+func (r *Repository) Update(ctx context.Context, in *storage.Session, fields []storage.SessionField) (*storage.Session, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoSessionFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "user_agent = $1", "ip_address = $2"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.SessionUserAgent:
+			set = append(set, fmt.Sprintf("user_agent = $%d", index))
+			args = append(args, in.UserAgent)
+		case storage.SessionIPAddress:
+			set = append(set, fmt.Sprintf("ip_address = $%d", index))
+			args = append(args, in.IPAddress)
+		case storage.SessionRefreshFamily:
+			set = append(set, fmt.Sprintf("refresh_family = $%d", index))
+			args = append(args, in.RefreshFamily)
+		case storage.SessionRefreshTokenID:
+			set = append(set, fmt.Sprintf("refresh_token_id = $%d", index))
+			args = append(args, in.RefreshTokenID)
+		case storage.SessionLastRefreshTime:
+			set = append(set, fmt.Sprintf("last_refresh_time = $%d", index))
+			args = append(args, in.LastRefreshTime)
+		case storage.SessionExpireTime:
+			set = append(set, fmt.Sprintf("expire_time = $%d", index))
+			args = append(args, in.ExpireTime)
+		case storage.SessionRevokeTime:
+			if in.RevokeTime.Time.IsZero() {
+				set = append(set, "revoke_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("revoke_time = $%d", index))
+				args = append(args, in.RevokeTime.Time)
+			}
+		case storage.SessionID:
+			return nil, storage.ErrImmutableSessionID
+		case storage.SessionUserID:
+			return nil, storage.ErrImmutableSessionUserID
+		case storage.SessionCreateTime:
+			return nil, storage.ErrImmutableSessionCreateTime
+		case storage.SessionImpersonator:
+			return nil, storage.ErrImmutableSessionImpersonator
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrSessionUnknownField)
+		}
+	}
+
+	// Add the session ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the session ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var s storage.Session
+	if err := scan(row.Scan, &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSessionNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan session: %w", err)
+	}
+
+	return &s, nil
+}
+
+const rotateRefreshTokenQuery = `UPDATE sessions
+SET refresh_token_id = $1, last_refresh_time = $2, expire_time = $3
+WHERE id = $4 AND refresh_token_id = $5 AND revoke_time IS NULL
+RETURNING id, user_id, user_agent, ip_address, refresh_family, refresh_token_id, create_time, last_refresh_time, expire_time, revoke_time, impersonator;`
+
+// RotateRefreshToken replaces the refresh token of the session if it is still previousTokenID.
+// The check and the update are one statement, so concurrent rotations with the same token can not both succeed.
+func (r *Repository) RotateRefreshToken(ctx context.Context, in *storage.Session, previousTokenID uuid.UUID) (*storage.Session, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		rotateRefreshTokenQuery,
+		in.RefreshTokenID,
+		in.LastRefreshTime,
+		in.ExpireTime,
+		in.ID,
+		previousTokenID,
+	)
+
+	var s storage.Session
+	if err := scan(row.Scan, &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrStaleSessionRefreshToken
+		}
+
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return &s, nil
+}
+
+const deleteQuery = `DELETE FROM sessions WHERE id = $1;`
+
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.dbConn.Exec(ctx, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if n == 0 {
+		return storage.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+const purgeQuery = `DELETE FROM sessions WHERE expire_time < $1 OR revoke_time < $1;`
+
+// Purge deletes the sessions that expired or were revoked before the time. Their refresh tokens can no longer be used.
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.dbConn.Exec(ctx, purgeQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge sessions: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return n, nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.SessionByUserIDCondition:
+			predicates = append(predicates, fmt.Sprintf("s.user_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.UserID)
+		case storage.SessionActiveCondition:
+			predicates = append(predicates, fmt.Sprintf("s.revoke_time IS NULL AND s.expire_time > $%d", len(args)+argOffset+1))
+			args = append(args, t.Time)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.SessionSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.SessionReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.SessionOrderBy, conditions ...storage.Condition) ([]*storage.Session, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*storage.Session
+	for rows.Next() {
+		var s storage.Session
+		if err = scan(rows.Scan, &s); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+
+		sessions = append(sessions, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,247 @@
+package session_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/session"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization(
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"test",
+				"test",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			),
+		},
+		Users: []*storage.User{
+			seed.NewUser(
+				"35297169-89d8-444d-8499-c6341e3a0770",
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"test",
+				"active",
+				"test@test.com",
+				"password",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Time{},
+			),
+		},
+		Sessions: []*storage.Session{
+			seed.NewSession(
+				"0e3ac0a3-8c4d-4d9e-9a4e-7f3b8ea62d11",
+				"35297169-89d8-444d-8499-c6341e3a0770",
+				"5c2ff6d4-1f7e-4ad0-9fd8-6c7c1b0c7a49",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+				time.Time{},
+			),
+			seed.NewSession(
+				"7a7c6a10-5f2b-4e0c-8bd5-0e6b8c1a5b32",
+				"35297169-89d8-444d-8499-c6341e3a0770",
+				"a3b0e2f4-9c1d-4e8f-8a7b-6c5d4e3f2a10",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 10, 0, 0, time.UTC),
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := session.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "session")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := session.New(database.NewDBWrapper(db))
+
+	t.Run("Create should create a new session", func(t *testing.T) {
+		s := seed.NewSession(
+			"c1e0f5a2-3b4d-4c6e-8f7a-9b0c1d2e3f40",
+			"35297169-89d8-444d-8499-c6341e3a0770",
+			"d2f1a6b3-4c5e-4d7f-9a8b-0c1d2e3f4a51",
+			time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+			time.Time{},
+		)
+		s.UserAgent = "test-agent"
+		s.IPAddress = "127.0.0.1"
+
+		got, err := repo.Create(ctx, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(s, got); diff != "" {
+			t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Create should return an error if the id already exists", func(t *testing.T) {
+		_, err := repo.Create(ctx, seed.NewSession(
+			"0e3ac0a3-8c4d-4d9e-9a4e-7f3b8ea62d11",
+			"35297169-89d8-444d-8499-c6341e3a0770",
+			"5c2ff6d4-1f7e-4ad0-9fd8-6c7c1b0c7a49",
+			time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+			time.Time{},
+		))
+		if !errors.Is(err, storage.ErrConflictSessionID) {
+			t.Errorf("expected %q, got %q", storage.ErrConflictSessionID, err)
+		}
+	})
+
+	t.Run("Get should return an error if the session does not exist", func(t *testing.T) {
+		_, err := repo.Get(ctx, uuid.MustParse("82651da9-c2ff-4152-8eae-7555d5a42aad"))
+		if !errors.Is(err, storage.ErrSessionNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrSessionNotFound, err)
+		}
+	})
+
+	t.Run("Update should rotate the refresh token", func(t *testing.T) {
+		s, err := repo.Get(ctx, uuid.MustParse("0e3ac0a3-8c4d-4d9e-9a4e-7f3b8ea62d11"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s.RefreshTokenID = uuid.MustParse("e3a2b7c4-5d6f-4e8a-8b9c-1d2e3f4a5b62")
+		s.LastRefreshTime = time.Date(2020, 1, 1, 0, 5, 0, 0, time.UTC)
+		got, err := repo.Update(ctx, s, []storage.SessionField{
+			storage.SessionRefreshTokenID,
+			storage.SessionLastRefreshTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(s, got); diff != "" {
+			t.Errorf("Update() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("RotateRefreshToken should rotate the token only once", func(t *testing.T) {
+		s, err := repo.Get(ctx, uuid.MustParse("0e3ac0a3-8c4d-4d9e-9a4e-7f3b8ea62d11"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		previous := s.RefreshTokenID
+		s.RefreshTokenID = uuid.MustParse("f4b3c8d5-6e7a-4f9b-9cad-2e3f4a5b6c73")
+		s.LastRefreshTime = time.Date(2020, 1, 1, 0, 6, 0, 0, time.UTC)
+		s.ExpireTime = time.Date(2020, 1, 1, 0, 36, 0, 0, time.UTC)
+		got, err := repo.RotateRefreshToken(ctx, s, previous)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(s, got); diff != "" {
+			t.Errorf("RotateRefreshToken() mismatch (-want +got):\n%s", diff)
+		}
+
+		if _, err = repo.RotateRefreshToken(ctx, s, previous); !errors.Is(err, storage.ErrStaleSessionRefreshToken) {
+			t.Errorf("expected %q, got %q", storage.ErrStaleSessionRefreshToken, err)
+		}
+	})
+
+	t.Run("RotateRefreshToken should not rotate the token of a revoked session", func(t *testing.T) {
+		s, err := repo.Get(ctx, uuid.MustParse("7a7c6a10-5f2b-4e0c-8bd5-0e6b8c1a5b32"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		previous := s.RefreshTokenID
+		s.RefreshTokenID = uuid.MustParse("a5c4d9e6-7f8b-4a0c-8dbe-3f4a5b6c7d84")
+		if _, err = repo.RotateRefreshToken(ctx, s, previous); !errors.Is(err, storage.ErrStaleSessionRefreshToken) {
+			t.Errorf("expected %q, got %q", storage.ErrStaleSessionRefreshToken, err)
+		}
+	})
+
+	t.Run("Update should not update immutable fields", func(t *testing.T) {
+		_, err := repo.Update(ctx, &storage.Session{}, []storage.SessionField{storage.SessionUserID})
+		if !errors.Is(err, storage.ErrImmutableSessionUserID) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableSessionUserID, err)
+		}
+	})
+
+	t.Run("List should only return active sessions of the user", func(t *testing.T) {
+		got, err := repo.List(ctx, storage.Pagination{}, storage.SessionOrderBy{
+			{Field: storage.SessionID, Direction: storage.ASC},
+		},
+			storage.SessionByUserIDCondition{UserID: uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770")},
+			storage.SessionActiveCondition{Time: time.Date(2020, 1, 1, 0, 15, 0, 0, time.UTC)},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []string
+		for _, s := range got {
+			if s.RevokeTime != (sql.NullTime{}) {
+				t.Errorf("expected no revoked sessions, got %v", s)
+			}
+			ids = append(ids, s.ID.String())
+		}
+
+		want := []string{
+			"0e3ac0a3-8c4d-4d9e-9a4e-7f3b8ea62d11",
+			"c1e0f5a2-3b4d-4c6e-8f7a-9b0c1d2e3f40",
+		}
+		if diff := cmp.Diff(want, ids); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Delete should return an error if the session does not exist", func(t *testing.T) {
+		err := repo.Delete(ctx, uuid.MustParse("82651da9-c2ff-4152-8eae-7555d5a42aad"))
+		if !errors.Is(err, storage.ErrSessionNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrSessionNotFound, err)
+		}
+	})
+
+	t.Run("Purge should delete the sessions that expired before the time", func(t *testing.T) {
+		n, err := repo.Purge(ctx, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n == 0 {
+			t.Error("expected the expired sessions to be purged")
+		}
+
+		if _, err = repo.Get(ctx, uuid.MustParse("c1e0f5a2-3b4d-4c6e-8f7a-9b0c1d2e3f40")); !errors.Is(err, storage.ErrSessionNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrSessionNotFound, err)
+		}
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/extreme-business/lingo/apps/account/storage"
 	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 
@@ -21,6 +22,7 @@ const (
 	userIDConstraint          = "users_pkey"
 	userDisplayNameConstraint = "users_display_name_key"
 	userEmailConstraint       = "users_unique_active_email"
+	userOrgConstraint         = "users_organization_id_fkey"
 )
 
 var _ storage.UserRepository = &Repository{}
@@ -44,13 +46,15 @@ func New(dbConn database.Conn) *Repository {
 //   - display_name
 //   - email
 //   - status
+//   - role
+//   - preferred_language
 //   - create_time
 //   - update_time
 //   - delete_time
 //
 // example query:
 //
-//	SELECT id, organization_id,  display_name, email, status, create_time, update_time, delete_time FROM users;
+//	SELECT id, organization_id,  display_name, email, status, role, preferred_language, create_time, update_time, delete_time FROM users;
 func scan(f func(dest ...any) error, u *storage.User) error {
 	return f(
 		&u.ID,
@@ -58,17 +62,37 @@ func scan(f func(dest ...any) error, u *storage.User) error {
 		&u.DisplayName,
 		&u.Email,
 		&u.Status,
+		&u.Role,
+		&u.PreferredLanguage,
 		&u.CreateTime,
 		&u.UpdateTime,
 		&u.DeleteTime,
 	)
 }
 
-const createQuery = `INSERT INTO users (id, organization_id, display_name, email, hashed_password, status, create_time, update_time, delete_time)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, organization_id,  display_name, email, status, create_time, update_time, delete_time
+const createQuery = `INSERT INTO users (id, organization_id, display_name, email, hashed_password, status, role, preferred_language, create_time, update_time, delete_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, organization_id,  display_name, email, status, role, preferred_language, create_time, update_time, delete_time
 ;`
 
+// constraintError maps a violated constraint of a user to its storage error.
+func constraintError(err error) error {
+	if constraint, ok := postgres.UniqueViolation(err); ok {
+		switch constraint {
+		case userIDConstraint:
+			return storage.ErrConflictUserID
+		case userEmailConstraint:
+			return storage.ErrConflictUserEmail
+		}
+	}
+
+	if constraint, ok := postgres.ForeignKeyViolation(err); ok && constraint == userOrgConstraint {
+		return storage.ErrUserOrganizationNotFound
+	}
+
+	return nil
+}
+
 // Create a new user.
 func (r *Repository) Create(ctx context.Context, u *storage.User) (*storage.User, error) {
 	row := r.dbConn.QueryRow(
@@ -80,6 +104,8 @@ func (r *Repository) Create(ctx context.Context, u *storage.User) (*storage.User
 		u.Email,
 		u.HashedPassword,
 		u.Status,
+		u.Role,
+		u.PreferredLanguage,
 		u.CreateTime,
 		u.UpdateTime,
 		u.DeleteTime,
@@ -87,16 +113,8 @@ func (r *Repository) Create(ctx context.Context, u *storage.User) (*storage.User
 
 	var n storage.User
 	if err := scan(row.Scan, &n); err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) {
-			if pqErr.Code.Name() == "unique_violation" {
-				switch pqErr.Constraint {
-				case userIDConstraint:
-					return nil, storage.ErrConflictUserID
-				case userEmailConstraint:
-					return nil, storage.ErrConflictUserEmail
-				}
-			}
+		if cErr := constraintError(err); cErr != nil {
+			return nil, cErr
 		}
 
 		return nil, fmt.Errorf("failed to insert user: %w", err)
@@ -105,7 +123,7 @@ func (r *Repository) Create(ctx context.Context, u *storage.User) (*storage.User
 	return &n, nil
 }
 
-const getByIDQuery = `SELECT id, organization_id, display_name, hashed_password, email, status, create_time, update_time, delete_time
+const getByIDQuery = `SELECT id, organization_id, display_name, hashed_password, email, status, role, preferred_language, create_time, update_time, delete_time
 FROM users
 WHERE id = $1
 ;`
@@ -121,6 +139,8 @@ func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.User, erro
 		&u.HashedPassword,
 		&u.Email,
 		&u.Status,
+		&u.Role,
+		&u.PreferredLanguage,
 		&u.CreateTime,
 		&u.UpdateTime,
 		&u.DeleteTime,
@@ -134,7 +154,7 @@ func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.User, erro
 	return &u, nil
 }
 
-const getByEmailQuery = `SELECT id, organization_id, display_name, hashed_password, email, status, create_time, update_time, delete_time
+const getByEmailQuery = `SELECT id, organization_id, display_name, hashed_password, email, status, role, preferred_language, create_time, update_time, delete_time
 FROM users
 WHERE email = $1
 ;`
@@ -151,6 +171,8 @@ func (r *Repository) GetByEmail(ctx context.Context, email string) (*storage.Use
 		&u.HashedPassword,
 		&u.Email,
 		&u.Status,
+		&u.Role,
+		&u.PreferredLanguage,
 		&u.CreateTime,
 		&u.UpdateTime,
 		&u.DeleteTime,
@@ -166,7 +188,7 @@ func (r *Repository) GetByEmail(ctx context.Context, email string) (*storage.Use
 const updateQueryTemplate = `UPDATE users
 SET %s
 WHERE id = $%d
-RETURNING id, organization_id,  display_name, email, status, create_time, update_time, delete_time;`
+RETURNING id, organization_id,  display_name, email, status, role, preferred_language, create_time, update_time, delete_time;`
 
 func (r *Repository) Update(ctx context.Context, in *storage.User, fields []storage.UserField) (*storage.User, error) {
 	if len(fields) == 0 {
@@ -197,6 +219,12 @@ func (r *Repository) Update(ctx context.Context, in *storage.User, fields []stor
 		case storage.UserStatus:
 			set = append(set, fmt.Sprintf("status = $%d", index))
 			args = append(args, in.Status)
+		case storage.UserRole:
+			set = append(set, fmt.Sprintf("role = $%d", index))
+			args = append(args, in.Role)
+		case storage.UserPreferredLanguage:
+			set = append(set, fmt.Sprintf("preferred_language = $%d", index))
+			args = append(args, in.PreferredLanguage)
 		case storage.UserDeleteTime:
 			if in.DeleteTime.Time.IsZero() {
 				set = append(set, "delete_time = NULL")
@@ -224,6 +252,10 @@ func (r *Repository) Update(ctx context.Context, in *storage.User, fields []stor
 
 	row := r.dbConn.QueryRow(ctx, query, args...)
 	if err := row.Err(); err != nil {
+		if cErr := constraintError(err); cErr != nil {
+			return nil, cErr
+		}
+
 		return nil, fmt.Errorf("failed to run update query: %w", err)
 	}
 
@@ -233,6 +265,10 @@ func (r *Repository) Update(ctx context.Context, in *storage.User, fields []stor
 			return nil, storage.ErrUserNotFound
 		}
 
+		if cErr := constraintError(err); cErr != nil {
+			return nil, cErr
+		}
+
 		return nil, fmt.Errorf("failed scan user: %w", err)
 	}
 
@@ -269,6 +305,9 @@ func generatePredicates(argOffset int, conditions []storage.Condition) ([]string
 		case storage.UserByOrganizationIDCondition:
 			predicates = append(predicates, fmt.Sprintf("u.organization_id = $%d", len(args)+argOffset+1))
 			args = append(args, t.OrganizationID)
+		case storage.UserByIDsCondition:
+			predicates = append(predicates, fmt.Sprintf("u.id = ANY($%d)", len(args)+argOffset+1))
+			args = append(args, pq.Array(t.IDs))
 		default:
 			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
 		}
@@ -772,7 +772,7 @@ func TestRepository_List(t *testing.T) {
 					time.Time{},
 				),
 			},
-			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.create_time, u.update_time, u.delete_time FROM users u;",
+			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.role, u.preferred_language, u.create_time, u.update_time, u.delete_time FROM users u;",
 		},
 		{
 			name:       "should list users with organization id predicate",
@@ -819,7 +819,7 @@ func TestRepository_List(t *testing.T) {
 					time.Time{},
 				),
 			},
-			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.create_time, u.update_time, u.delete_time FROM users u WHERE u.organization_id = $1;",
+			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.role, u.preferred_language, u.create_time, u.update_time, u.delete_time FROM users u WHERE u.organization_id = $1;",
 		},
 		{
 			name:       "should list users with limit",
@@ -850,7 +850,7 @@ func TestRepository_List(t *testing.T) {
 					time.Time{},
 				),
 			},
-			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.create_time, u.update_time, u.delete_time FROM users u LIMIT $1;",
+			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.role, u.preferred_language, u.create_time, u.update_time, u.delete_time FROM users u LIMIT $1;",
 		},
 		{
 			name:       "should list users with offset",
@@ -881,7 +881,7 @@ func TestRepository_List(t *testing.T) {
 					time.Time{},
 				),
 			},
-			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.create_time, u.update_time, u.delete_time FROM users u OFFSET $1;",
+			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.role, u.preferred_language, u.create_time, u.update_time, u.delete_time FROM users u OFFSET $1;",
 		},
 		{
 			name:       "should list users with limit and offset",
@@ -901,7 +901,7 @@ func TestRepository_List(t *testing.T) {
 					time.Time{},
 				),
 			},
-			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.create_time, u.update_time, u.delete_time FROM users u LIMIT $1 OFFSET $2;",
+			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.role, u.preferred_language, u.create_time, u.update_time, u.delete_time FROM users u LIMIT $1 OFFSET $2;",
 		},
 		{
 			name: "should list users with sort",
@@ -946,7 +946,7 @@ func TestRepository_List(t *testing.T) {
 					time.Time{},
 				),
 			},
-			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.create_time, u.update_time, u.delete_time FROM users u ORDER BY u.display_name DESC, u.create_time DESC;",
+			expectedQuery: "SELECT u.id, u.organization_id, u.display_name, u.email, u.status, u.role, u.preferred_language, u.create_time, u.update_time, u.delete_time FROM users u ORDER BY u.display_name DESC, u.create_time DESC;",
 		},
 		{
 			name: "should return error if sorting field is unknown",
@@ -0,0 +1,309 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	_ "embed"
+)
+
+const (
+	webhookIDConstraint = "webhooks_pkey"
+)
+
+var _ storage.WebhookRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a webhook from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - organization_id
+//   - url
+//   - event_types
+//   - secret
+//   - failure_count
+//   - create_time
+//   - update_time
+//   - disable_time
+func scan(f func(dest ...any) error, w *storage.Webhook) error {
+	return f(
+		&w.ID,
+		&w.OrganizationID,
+		&w.URL,
+		pq.Array(&w.EventTypes),
+		&w.Secret,
+		&w.FailureCount,
+		&w.CreateTime,
+		&w.UpdateTime,
+		&w.DisableTime,
+	)
+}
+
+const createQuery = `INSERT INTO webhooks (id, organization_id, url, event_types, secret, failure_count, create_time, update_time, disable_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, organization_id, url, event_types, secret, failure_count, create_time, update_time, disable_time
+;`
+
+// Create a new webhook.
+func (r *Repository) Create(ctx context.Context, w *storage.Webhook) (*storage.Webhook, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		w.ID,
+		w.OrganizationID,
+		w.URL,
+		pq.Array(w.EventTypes),
+		w.Secret,
+		w.FailureCount,
+		w.CreateTime,
+		w.UpdateTime,
+		w.DisableTime,
+	)
+
+	var n storage.Webhook
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok && constraint == webhookIDConstraint {
+			return nil, storage.ErrConflictWebhookID
+		}
+
+		return nil, fmt.Errorf("failed to insert webhook: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, organization_id, url, event_types, secret, failure_count, create_time, update_time, disable_time
+FROM webhooks
+WHERE id = $1
+;`
+
+// Get a webhook by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.Webhook, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var w storage.Webhook
+	if err := scan(row.Scan, &w); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrWebhookNotFound
+		}
+
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+const updateQueryTemplate = `UPDATE webhooks
+SET %s
+WHERE id = $%d
+RETURNING id, organization_id, url, event_types, secret, failure_count, create_time, update_time, disable_time;`
+
+func (r *Repository) Update(ctx context.Context, in *storage.Webhook, fields []storage.WebhookField) (*storage.Webhook, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoWebhookFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "url = $1", "secret = $2"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.WebhookURL:
+			set = append(set, fmt.Sprintf("url = $%d", index))
+			args = append(args, in.URL)
+		case storage.WebhookEventTypes:
+			set = append(set, fmt.Sprintf("event_types = $%d", index))
+			args = append(args, pq.Array(in.EventTypes))
+		case storage.WebhookSecret:
+			set = append(set, fmt.Sprintf("secret = $%d", index))
+			args = append(args, in.Secret)
+		case storage.WebhookFailureCount:
+			set = append(set, fmt.Sprintf("failure_count = $%d", index))
+			args = append(args, in.FailureCount)
+		case storage.WebhookUpdateTime:
+			set = append(set, fmt.Sprintf("update_time = $%d", index))
+			args = append(args, in.UpdateTime)
+		case storage.WebhookDisableTime:
+			if in.DisableTime.Time.IsZero() {
+				set = append(set, "disable_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("disable_time = $%d", index))
+				args = append(args, in.DisableTime.Time)
+			}
+		case storage.WebhookID:
+			return nil, storage.ErrImmutableWebhookID
+		case storage.WebhookOrganizationID:
+			return nil, storage.ErrImmutableWebhookOrganizationID
+		case storage.WebhookCreateTime:
+			return nil, storage.ErrImmutableWebhookCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrWebhookUnknownField)
+		}
+	}
+
+	// Add the webhook ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the webhook ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var w storage.Webhook
+	if err := scan(row.Scan, &w); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrWebhookNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan webhook: %w", err)
+	}
+
+	return &w, nil
+}
+
+const deleteQuery = `DELETE FROM webhooks WHERE id = $1;`
+
+// Delete a webhook and, through the foreign key, its deliveries.
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.dbConn.Exec(ctx, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if n == 0 {
+		return storage.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.WebhookByOrganizationIDCondition:
+			predicates = append(predicates, fmt.Sprintf("w.organization_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.OrganizationID)
+		case storage.WebhookEnabledCondition:
+			predicates = append(predicates, "w.disable_time IS NULL")
+		case storage.WebhookByEventTypeCondition:
+			predicates = append(predicates, fmt.Sprintf("$%d = ANY(w.event_types)", len(args)+argOffset+1))
+			args = append(args, t.EventType)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.WebhookSort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.WebhookReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.WebhookOrderBy, conditions ...storage.Condition) ([]*storage.Webhook, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*storage.Webhook
+	for rows.Next() {
+		var h storage.Webhook
+		if err = scan(rows.Scan, &h); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, &h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,143 @@
+package webhook_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/webhook"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization(
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"test",
+				"test",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := webhook.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "webhook")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	repo := webhook.New(database.NewDBWrapper(db))
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	orgID := uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	in := &storage.Webhook{
+		ID:             uuid.MustParse("1f0e6c8a-3b7d-4e59-a2c4-8d9e0f1a2b3c"),
+		OrganizationID: orgID,
+		URL:            "https://example.com/hook",
+		EventTypes:     []string{"user.created", "user.deleted"},
+		Secret:         "secret",
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}
+
+	t.Run("Create should create a webhook", func(t *testing.T) {
+		got, err := repo.Create(ctx, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Create should return a conflict for an existing id", func(t *testing.T) {
+		if _, err := repo.Create(ctx, in); !errors.Is(err, storage.ErrConflictWebhookID) {
+			t.Errorf("expected %q, got %q", storage.ErrConflictWebhookID, err)
+		}
+	})
+
+	t.Run("List should only return enabled webhooks for the event type", func(t *testing.T) {
+		for _, eventType := range []string{"user.created", "user.updated"} {
+			got, err := repo.List(ctx, storage.Pagination{}, storage.WebhookOrderBy{},
+				storage.WebhookByOrganizationIDCondition{OrganizationID: orgID},
+				storage.WebhookEnabledCondition{},
+				storage.WebhookByEventTypeCondition{EventType: eventType},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := eventType == "user.created"; (len(got) == 1) != want {
+				t.Errorf("%s: expected the webhook to be listed: %v, got %d webhooks", eventType, want, len(got))
+			}
+		}
+	})
+
+	t.Run("Update should disable a webhook", func(t *testing.T) {
+		in.FailureCount = 3
+		in.DisableTime = sql.NullTime{Time: createTime, Valid: true}
+		got, err := repo.Update(ctx, in, []storage.WebhookField{storage.WebhookDisableTime, storage.WebhookFailureCount})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("Update() mismatch (-want +got):\n%s", diff)
+		}
+
+		listed, err := repo.List(ctx, storage.Pagination{}, storage.WebhookOrderBy{}, storage.WebhookEnabledCondition{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(listed) != 0 {
+			t.Errorf("expected no enabled webhooks, got %d", len(listed))
+		}
+	})
+
+	t.Run("Update should not change the organization", func(t *testing.T) {
+		_, err := repo.Update(ctx, in, []storage.WebhookField{storage.WebhookOrganizationID})
+		if !errors.Is(err, storage.ErrImmutableWebhookOrganizationID) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableWebhookOrganizationID, err)
+		}
+	})
+
+	t.Run("Delete should delete a webhook", func(t *testing.T) {
+		if err := repo.Delete(ctx, in.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repo.Get(ctx, in.ID); !errors.Is(err, storage.ErrWebhookNotFound) {
+			t.Errorf("expected %q, got %q", storage.ErrWebhookNotFound, err)
+		}
+	})
+}
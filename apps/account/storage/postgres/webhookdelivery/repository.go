@@ -0,0 +1,326 @@
+package webhookdelivery
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/google/uuid"
+
+	_ "embed"
+)
+
+const (
+	webhookDeliveryIDConstraint      = "webhook_deliveries_pkey"
+	webhookDeliveryAttemptConstraint = "webhook_deliveries_attempt_key"
+	// delivererLockKey is the advisory lock that makes sure only one deliverer sends deliveries at a time.
+	delivererLockKey = 0x77686b64 // "whkd"
+)
+
+var _ storage.WebhookDeliveryRepository = &Repository{}
+
+type Repository struct {
+	dbConn           database.Conn
+	listTemplateFunc sync.Once          // compile the list template only once
+	listTemplate     *template.Template // compiled list template
+}
+
+func New(dbConn database.Conn) *Repository {
+	return &Repository{
+		dbConn: dbConn,
+	}
+}
+
+// scan scans a webhook delivery from a sql.Row or sql.Rows.
+// cols:
+//   - id
+//   - webhook_id
+//   - event_id
+//   - event_type
+//   - payload
+//   - attempt
+//   - status
+//   - schedule_time
+//   - attempt_time
+//   - response_code
+//   - error_message
+//   - create_time
+func scan(f func(dest ...any) error, d *storage.WebhookDelivery) error {
+	return f(
+		&d.ID,
+		&d.WebhookID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Attempt,
+		&d.Status,
+		&d.ScheduleTime,
+		&d.AttemptTime,
+		&d.ResponseCode,
+		&d.ErrorMessage,
+		&d.CreateTime,
+	)
+}
+
+const lockQuery = `SELECT pg_advisory_xact_lock($1);`
+
+// Lock takes a transaction level advisory lock. It must run inside a transaction,
+// otherwise the lock is released as soon as the statement finishes.
+func (r *Repository) Lock(ctx context.Context) error {
+	if _, err := r.dbConn.Exec(ctx, lockQuery, delivererLockKey); err != nil {
+		return fmt.Errorf("failed to lock webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+const createQuery = `INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, attempt, status, schedule_time, attempt_time, response_code, error_message, create_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, webhook_id, event_id, event_type, payload, attempt, status, schedule_time, attempt_time, response_code, error_message, create_time
+;`
+
+// Create a new webhook delivery.
+func (r *Repository) Create(ctx context.Context, d *storage.WebhookDelivery) (*storage.WebhookDelivery, error) {
+	row := r.dbConn.QueryRow(
+		ctx,
+		createQuery,
+		d.ID,
+		d.WebhookID,
+		d.EventID,
+		d.EventType,
+		d.Payload,
+		d.Attempt,
+		d.Status,
+		d.ScheduleTime,
+		d.AttemptTime,
+		d.ResponseCode,
+		d.ErrorMessage,
+		d.CreateTime,
+	)
+
+	var n storage.WebhookDelivery
+	if err := scan(row.Scan, &n); err != nil {
+		if constraint, ok := postgres.UniqueViolation(err); ok {
+			switch constraint {
+			case webhookDeliveryIDConstraint:
+				return nil, storage.ErrConflictWebhookDeliveryID
+			case webhookDeliveryAttemptConstraint:
+				return nil, storage.ErrConflictWebhookDeliveryAttempt
+			}
+		}
+
+		return nil, fmt.Errorf("failed to insert webhook delivery: %w", err)
+	}
+
+	return &n, nil
+}
+
+const getQuery = `SELECT id, webhook_id, event_id, event_type, payload, attempt, status, schedule_time, attempt_time, response_code, error_message, create_time
+FROM webhook_deliveries
+WHERE id = $1
+;`
+
+// Get a webhook delivery by id.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*storage.WebhookDelivery, error) {
+	row := r.dbConn.QueryRow(ctx, getQuery, id)
+	var d storage.WebhookDelivery
+	if err := scan(row.Scan, &d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrWebhookDeliveryNotFound
+		}
+
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+const updateQueryTemplate = `UPDATE webhook_deliveries
+SET %s
+WHERE id = $%d
+RETURNING id, webhook_id, event_id, event_type, payload, attempt, status, schedule_time, attempt_time, response_code, error_message, create_time;`
+
+// Update updates the outcome of a delivery. What is delivered can not be changed.
+func (r *Repository) Update(ctx context.Context, in *storage.WebhookDelivery, fields []storage.WebhookDeliveryField) (*storage.WebhookDelivery, error) {
+	if len(fields) == 0 {
+		return nil, storage.ErrNoWebhookDeliveryFieldsToUpdate
+	}
+
+	set := make([]string, 0, len(fields)) // set clauses, e.g. "status = $1", "response_code = $2"
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for _, f := range fields {
+		index := len(args) + 1
+		switch f {
+		case storage.WebhookDeliveryStatus:
+			set = append(set, fmt.Sprintf("status = $%d", index))
+			args = append(args, in.Status)
+		case storage.WebhookDeliveryScheduleTime:
+			set = append(set, fmt.Sprintf("schedule_time = $%d", index))
+			args = append(args, in.ScheduleTime)
+		case storage.WebhookDeliveryAttemptTime:
+			if in.AttemptTime.Time.IsZero() {
+				set = append(set, "attempt_time = NULL")
+			} else {
+				set = append(set, fmt.Sprintf("attempt_time = $%d", index))
+				args = append(args, in.AttemptTime.Time)
+			}
+		case storage.WebhookDeliveryResponseCode:
+			set = append(set, fmt.Sprintf("response_code = $%d", index))
+			args = append(args, in.ResponseCode)
+		case storage.WebhookDeliveryErrorMessage:
+			set = append(set, fmt.Sprintf("error_message = $%d", index))
+			args = append(args, in.ErrorMessage)
+		case storage.WebhookDeliveryID:
+			return nil, storage.ErrImmutableWebhookDeliveryID
+		case storage.WebhookDeliveryWebhookID:
+			return nil, storage.ErrImmutableWebhookDeliveryWebhookID
+		case storage.WebhookDeliveryEventID:
+			return nil, storage.ErrImmutableWebhookDeliveryEventID
+		case storage.WebhookDeliveryEventType:
+			return nil, storage.ErrImmutableWebhookDeliveryEventType
+		case storage.WebhookDeliveryPayload:
+			return nil, storage.ErrImmutableWebhookDeliveryPayload
+		case storage.WebhookDeliveryAttempt:
+			return nil, storage.ErrImmutableWebhookDeliveryAttempt
+		case storage.WebhookDeliveryCreateTime:
+			return nil, storage.ErrImmutableWebhookDeliveryCreateTime
+		default:
+			return nil, fmt.Errorf("field %s: %w", f, storage.ErrWebhookDeliveryUnknownField)
+		}
+	}
+
+	// Add the delivery ID to the end of the args slice
+	args = append(args, in.ID)
+
+	query := fmt.Sprintf(
+		updateQueryTemplate,
+		strings.Join(set, ", "),
+		len(args), // the parameter number for the delivery ID
+	)
+
+	row := r.dbConn.QueryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run update query: %w", err)
+	}
+
+	var d storage.WebhookDelivery
+	if err := scan(row.Scan, &d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrWebhookDeliveryNotFound
+		}
+
+		return nil, fmt.Errorf("failed scan webhook delivery: %w", err)
+	}
+
+	return &d, nil
+}
+
+// generatePredicates generates the WHERE clause predicates for the list query.
+func generatePredicates(argOffset int, conditions []storage.Condition) ([]string, []interface{}, error) {
+	var predicates []string
+	var args []interface{}
+
+	for _, c := range conditions {
+		switch t := c.(type) {
+		case storage.WebhookDeliveryByWebhookIDCondition:
+			predicates = append(predicates, fmt.Sprintf("d.webhook_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.WebhookID)
+		case storage.WebhookDeliveryByEventIDCondition:
+			predicates = append(predicates, fmt.Sprintf("d.event_id = $%d", len(args)+argOffset+1))
+			args = append(args, t.EventID)
+		case storage.WebhookDeliveryByStatusCondition:
+			predicates = append(predicates, fmt.Sprintf("d.status = $%d", len(args)+argOffset+1))
+			args = append(args, t.Status)
+		case storage.WebhookDeliveryDueCondition:
+			predicates = append(predicates, fmt.Sprintf("d.schedule_time <= $%d", len(args)+argOffset+1))
+			args = append(args, t.Time)
+		default:
+			return nil, nil, fmt.Errorf("unknown or non allowed condition: %T", c)
+		}
+	}
+
+	return predicates, args, nil
+}
+
+//go:embed list.tmpl.sql
+var listQueryTemplate []byte
+
+type listQueryTemplateParams struct {
+	Predicates  []string
+	Sorting     []storage.WebhookDeliverySort
+	LimitParam  string
+	OffsetParam string
+}
+
+// List implements storage.WebhookDeliveryReader.
+func (r *Repository) List(ctx context.Context, pagination storage.Pagination, sorting storage.WebhookDeliveryOrderBy, conditions ...storage.Condition) ([]*storage.WebhookDelivery, error) {
+	predicates, args, err := generatePredicates(0, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	var limitParam, offsetParam string
+	if pagination.Limit > 0 {
+		limitParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		offsetParam = fmt.Sprintf("$%d", len(args)+1)
+		args = append(args, pagination.Offset)
+	}
+
+	if err = sorting.Validate(); err != nil {
+		return nil, fmt.Errorf("sorting validation failed: %w", err)
+	}
+
+	// Compile the list template only once
+	r.listTemplateFunc.Do(func() {
+		r.listTemplate, err = template.New("list").Parse(string(listQueryTemplate))
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list query template: %w", err)
+	}
+
+	w := &strings.Builder{}
+	err = r.listTemplate.Execute(w, listQueryTemplateParams{
+		Predicates:  predicates,
+		Sorting:     sorting,
+		LimitParam:  limitParam,
+		OffsetParam: offsetParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute list query template: %w", err)
+	}
+
+	rows, err := r.dbConn.Query(ctx, w.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*storage.WebhookDelivery
+	for rows.Next() {
+		var d storage.WebhookDelivery
+		if err = scan(rows.Scan, &d); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+
+		deliveries = append(deliveries, &d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,151 @@
+package webhookdelivery_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/seed"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/webhook"
+	"github.com/extreme-business/lingo/apps/account/storage/postgres/webhookdelivery"
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var webhookID = uuid.MustParse("1f0e6c8a-3b7d-4e59-a2c4-8d9e0f1a2b3c")
+
+func setupTestDB(ctx context.Context, t *testing.T, name string) *dbtest.PostgresContainer {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, dbtest.SanitizeDBName(name))
+	if err := seed.RunMigrations(ctx, t, dbc.ConnectionString); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	seed.Run(t, dbc.ConnectionString, seed.State{
+		Organizations: []*storage.Organization{
+			seed.NewOrganization(
+				"7bb443e5-8974-44c2-8b7c-b95124205264",
+				"test",
+				"test",
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			),
+		},
+	})
+
+	return dbc
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return a new repository", func(t *testing.T) {
+		if got := webhookdelivery.New(nil); got == nil {
+			t.Error("expected repository")
+		}
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := setupTestDB(ctx, t, "webhookdelivery")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	conn := database.NewDBWrapper(db)
+	repo := webhookdelivery.New(conn)
+
+	createTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := webhook.New(conn).Create(ctx, &storage.Webhook{
+		ID:             webhookID,
+		OrganizationID: uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264"),
+		URL:            "https://example.com/hook",
+		EventTypes:     []string{"user.created"},
+		Secret:         "secret",
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	in := &storage.WebhookDelivery{
+		ID:           uuid.MustParse("6b1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"),
+		WebhookID:    webhookID,
+		EventID:      1,
+		EventType:    "user.created",
+		Payload:      []byte(`{"id": 1}`),
+		Attempt:      1,
+		Status:       "pending",
+		ScheduleTime: createTime,
+		CreateTime:   createTime,
+	}
+
+	t.Run("Create should create a delivery", func(t *testing.T) {
+		got, err := repo.Create(ctx, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.ID != in.ID || got.Status != in.Status || got.Attempt != in.Attempt {
+			t.Errorf("expected %+v, got %+v", in, got)
+		}
+	})
+
+	t.Run("Create should return a conflict for the same attempt", func(t *testing.T) {
+		again := *in
+		again.ID = uuid.MustParse("7c2e3f4a-5b6c-4d7e-9f8a-0b1c2d3e4f5a")
+		if _, err := repo.Create(ctx, &again); !errors.Is(err, storage.ErrConflictWebhookDeliveryAttempt) {
+			t.Errorf("expected %q, got %q", storage.ErrConflictWebhookDeliveryAttempt, err)
+		}
+	})
+
+	t.Run("List should return the due deliveries", func(t *testing.T) {
+		for _, now := range []time.Time{createTime.Add(-time.Second), createTime} {
+			got, err := repo.List(ctx, storage.Pagination{}, storage.WebhookDeliveryOrderBy{
+				{Field: storage.WebhookDeliveryScheduleTime, Direction: storage.ASC},
+			},
+				storage.WebhookDeliveryByStatusCondition{Status: "pending"},
+				storage.WebhookDeliveryDueCondition{Time: now},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := now.Equal(createTime); (len(got) == 1) != want {
+				t.Errorf("%v: expected the delivery to be due: %v, got %d deliveries", now, want, len(got))
+			}
+		}
+	})
+
+	t.Run("Update should record the outcome", func(t *testing.T) {
+		in.Status = "failed"
+		in.AttemptTime = sql.NullTime{Time: createTime, Valid: true}
+		in.ResponseCode = 500
+		in.ErrorMessage = "internal server error"
+		got, err := repo.Update(ctx, in, []storage.WebhookDeliveryField{
+			storage.WebhookDeliveryStatus,
+			storage.WebhookDeliveryAttemptTime,
+			storage.WebhookDeliveryResponseCode,
+			storage.WebhookDeliveryErrorMessage,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(in.ErrorMessage, got.ErrorMessage); diff != "" || got.ResponseCode != 500 || !got.AttemptTime.Valid {
+			t.Errorf("Update() mismatch, got %+v", got)
+		}
+	})
+
+	t.Run("Update should not change the payload", func(t *testing.T) {
+		_, err := repo.Update(ctx, in, []storage.WebhookDeliveryField{storage.WebhookDeliveryPayload})
+		if !errors.Is(err, storage.ErrImmutableWebhookDeliveryPayload) {
+			t.Errorf("expected %q, got %q", storage.ErrImmutableWebhookDeliveryPayload, err)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RegistrationTokenError error
+
+var (
+	// ErrRegistrationTokenUsed is returned when a registration token is used a second time.
+	ErrRegistrationTokenUsed RegistrationTokenError = errors.New("registration token was already used")
+)
+
+// RegistrationToken is a registration token that was used to create an account. It is kept until it
+// expires, so it can not be used again.
+type RegistrationToken struct {
+	ID         uuid.UUID // ID is the token id, the jti claim of the token.
+	Email      string
+	UseTime    time.Time
+	ExpireTime time.Time
+}
+
+// RegistrationTokenRepository stores the used registration tokens.
+type RegistrationTokenRepository interface {
+	// Use stores the token as used. It returns ErrRegistrationTokenUsed when the token was used before.
+	Use(context.Context, *RegistrationToken) error
+	// Purge deletes the tokens that expired before the time and returns how many were deleted.
+	Purge(context.Context, time.Time) (int64, error)
+}
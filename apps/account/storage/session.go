@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionError error
+
+var (
+	ErrSessionNotFound SessionError = errors.New("session not found")
+	// Update.
+	ErrNoSessionFieldsToUpdate SessionError = errors.New("no fields to update")
+	// Fields.
+	ErrSessionUnknownField SessionError = errors.New("unknown session field")
+	// sort errors.
+	ErrEmptySessionSortField       SessionError = errors.New("session field is empty")
+	ErrInvalidSessionSortDirection SessionError = errors.New("invalid session sort direction")
+	// Unique constraint errors.
+	ErrConflictSessionID SessionError = errors.New("unique id conflict")
+	// Refresh token rotation.
+	ErrStaleSessionRefreshToken SessionError = errors.New("refresh token was rotated or the session was revoked")
+	// Immutable errors.
+	ErrImmutableSessionID           SessionError = errors.New("field id is read-only")
+	ErrImmutableSessionUserID       SessionError = errors.New("field user_id is read-only")
+	ErrImmutableSessionCreateTime   SessionError = errors.New("field create_time is read-only")
+	ErrImmutableSessionImpersonator SessionError = errors.New("field impersonator is read-only")
+)
+
+type SessionField string
+
+const (
+	SessionID              SessionField = "id"
+	SessionUserID          SessionField = "user_id"
+	SessionUserAgent       SessionField = "user_agent"
+	SessionIPAddress       SessionField = "ip_address"
+	SessionRefreshFamily   SessionField = "refresh_family"
+	SessionRefreshTokenID  SessionField = "refresh_token_id"
+	SessionCreateTime      SessionField = "create_time"
+	SessionLastRefreshTime SessionField = "last_refresh_time"
+	SessionExpireTime      SessionField = "expire_time"
+	SessionRevokeTime      SessionField = "revoke_time"
+	SessionImpersonator    SessionField = "impersonator"
+)
+
+// SessionFields returns all session fields.
+func SessionFields() []SessionField {
+	return []SessionField{
+		SessionID,
+		SessionUserID,
+		SessionUserAgent,
+		SessionIPAddress,
+		SessionRefreshFamily,
+		SessionRefreshTokenID,
+		SessionCreateTime,
+		SessionLastRefreshTime,
+		SessionExpireTime,
+		SessionRevokeTime,
+		SessionImpersonator,
+	}
+}
+
+// Session is a login of a user on a device.
+type Session struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	UserAgent       string
+	IPAddress       string
+	RefreshFamily   uuid.UUID // RefreshFamily is shared by all refresh tokens issued for the session.
+	RefreshTokenID  uuid.UUID // RefreshTokenID is the id of the only refresh token that may still be used.
+	CreateTime      time.Time
+	LastRefreshTime time.Time
+	ExpireTime      time.Time
+	RevokeTime      sql.NullTime
+	Impersonator    string // Impersonator is the resource name of the user acting as the session user, if any.
+}
+
+// SessionSort pairs a field with a direction.
+type SessionSort struct {
+	Field     SessionField
+	Direction Direction
+}
+
+type SessionOrderBy []SessionSort
+
+// Validate checks if the sort fields are valid.
+func (o SessionOrderBy) Validate() error {
+	fields := SessionFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptySessionSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrSessionUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidSessionSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type SessionReader interface {
+	Get(context.Context, uuid.UUID) (*Session, error)
+	List(context.Context, Pagination, SessionOrderBy, ...Condition) ([]*Session, error)
+}
+
+type SessionWriter interface {
+	Create(context.Context, *Session) (*Session, error)
+	Update(context.Context, *Session, []SessionField) (*Session, error)
+	// RotateRefreshToken sets the refresh token id, last refresh time and expire time of the session, if
+	// it is not revoked and its refresh token id is still previousTokenID. Otherwise it changes nothing
+	// and returns ErrStaleSessionRefreshToken, so only one of two refreshes with the same token succeeds.
+	RotateRefreshToken(ctx context.Context, s *Session, previousTokenID uuid.UUID) (*Session, error)
+	Delete(context.Context, uuid.UUID) error
+	// Purge deletes the sessions that expired or were revoked before the time and returns how many were deleted.
+	Purge(context.Context, time.Time) (int64, error)
+}
+
+// SessionRepository is a reader and writer for sessions.
+type SessionRepository interface {
+	SessionReader
+	SessionWriter
+}
+
+// SessionByUserIDCondition is a search condition for sessions by user ID.
+type SessionByUserIDCondition struct {
+	UserID uuid.UUID
+}
+
+func (SessionByUserIDCondition) condition() {}
+
+// SessionActiveCondition is a search condition for sessions that are neither revoked nor expired at Time.
+type SessionActiveCondition struct {
+	Time time.Time
+}
+
+func (SessionActiveCondition) condition() {}
@@ -0,0 +1,72 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSessionFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.SessionFields()
+		want := []storage.SessionField{
+			storage.SessionID,
+			storage.SessionUserID,
+			storage.SessionUserAgent,
+			storage.SessionIPAddress,
+			storage.SessionRefreshFamily,
+			storage.SessionRefreshTokenID,
+			storage.SessionCreateTime,
+			storage.SessionLastRefreshTime,
+			storage.SessionExpireTime,
+			storage.SessionRevokeTime,
+			storage.SessionImpersonator,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("SessionFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestSessionOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.SessionOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.SessionOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.SessionOrderBy{{Field: "invalid"}},
+			err:  storage.ErrSessionUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.SessionOrderBy{{Field: ""}},
+			err:  storage.ErrEmptySessionSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.SessionOrderBy{{Field: storage.SessionCreateTime, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.SessionOrderBy{{Field: storage.SessionCreateTime, Direction: "invalid"}},
+			err:  storage.ErrInvalidSessionSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("SessionOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
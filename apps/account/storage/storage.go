@@ -1,11 +1,29 @@
 package storage
 
-import "context"
+import (
+	"context"
+
+	"github.com/extreme-business/lingo/pkg/database"
+)
 
 // Repositories is a collection of repositories.
 type Repositories struct {
-	User         UserRepository
-	Organization OrganizationRepository
+	User               UserRepository
+	Organization       OrganizationRepository
+	Session            SessionRepository
+	AuditEvent         AuditEventRepository
+	OutboxEvent        OutboxEventRepository
+	Webhook            WebhookRepository
+	WebhookDelivery    WebhookDeliveryRepository
+	Conversation       ConversationRepository
+	Participant        ParticipantRepository
+	Message            MessageRepository
+	MessageTranslation MessageTranslationRepository
+	Glossary           GlossaryRepository
+	GlossaryTerm       GlossaryTermRepository
+	GlossarySegment    GlossarySegmentRepository
+	RegistrationToken  RegistrationTokenRepository
+	Jobs               JobEnqueuer
 }
 
 // DBManager is a database manager. It is used to manage the repositories.
@@ -14,7 +32,8 @@ type DBManager interface {
 	Op() Repositories
 	// BeginTX starts a new transaction, performs the repository operations within that transaction,
 	// and commits the transaction if all operations succeed; it rolls back the transaction otherwise.
-	BeginOp(ctx context.Context, operation func(context.Context, Repositories) error) error
+	// An operation started with the context of another operation is nested in its transaction, see database.Manager.
+	BeginOp(ctx context.Context, operation func(context.Context, Repositories) error, opts ...database.OpOption) error
 }
 
 type Pagination struct {
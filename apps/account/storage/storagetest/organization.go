@@ -0,0 +1,196 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+func runOrganization(t *testing.T, f Factory) {
+	ctx := context.Background()
+
+	t.Run("Create should return the organization", func(t *testing.T) {
+		m := f(t)
+		got, err := m.Op().Organization.Create(ctx, newOrganization(orgID, "test", 1))
+		assertError(t, nil, err)
+		assertDiff(t, newOrganization(orgID, "test", 1), got)
+	})
+
+	t.Run("Create should enforce the constraints", func(t *testing.T) {
+		tests := []struct {
+			name string
+			org  *storage.Organization
+			err  error
+		}{
+			{"id", &storage.Organization{ID: orgID, LegalName: "other", Slug: "other", CreateTime: date(1), UpdateTime: date(1)}, storage.ErrConflictOrganizationID},
+			{"legal name", &storage.Organization{ID: otherOrgID, LegalName: "test", Slug: "other", CreateTime: date(1), UpdateTime: date(1)}, storage.ErrConflictOrganizationLegalName},
+			{"slug", &storage.Organization{ID: otherOrgID, LegalName: "other", Slug: "test", CreateTime: date(1), UpdateTime: date(1)}, storage.ErrConflictOrganizationSlug},
+			{"slug format", &storage.Organization{ID: otherOrgID, LegalName: "other", Slug: "Other Slug", CreateTime: date(1), UpdateTime: date(1)}, storage.ErrInvalidOrganizationSlug},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				m := seeded(t, f)
+				_, err := m.Op().Organization.Create(ctx, tt.org)
+				assertError(t, tt.err, err)
+			})
+		}
+	})
+
+	t.Run("Get should return the organization", func(t *testing.T) {
+		m := seeded(t, f)
+		got, err := m.Op().Organization.Get(ctx, orgID)
+		assertError(t, nil, err)
+		assertDiff(t, newOrganization(orgID, "test", 1), got)
+
+		_, err = m.Op().Organization.Get(ctx, unknownID)
+		assertError(t, storage.ErrOrganizationNotFound, err)
+	})
+
+	t.Run("Update should update only the fields", func(t *testing.T) {
+		m := seeded(t, f)
+		in := newOrganization(orgID, "changed", 2)
+		got, err := m.Op().Organization.Update(ctx, in, []storage.OrganizationField{storage.OrganizationLegalName, storage.OrganizationUpdateTime})
+		assertError(t, nil, err)
+
+		want := newOrganization(orgID, "test", 1)
+		want.LegalName = "changed"
+		want.UpdateTime = date(2)
+		assertDiff(t, want, got)
+	})
+
+	t.Run("Update should return an error", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			org    *storage.Organization
+			fields []storage.OrganizationField
+			err    error
+		}{
+			{"without fields", newOrganization(orgID, "test", 1), nil, storage.ErrNoOrganizationFieldsToUpdate},
+			{"for the id", newOrganization(orgID, "test", 1), []storage.OrganizationField{storage.OrganizationID}, storage.ErrImmutableOrganizationID},
+			{"for the create time", newOrganization(orgID, "test", 1), []storage.OrganizationField{storage.OrganizationCreateTime}, storage.ErrImmutableOrganizationCreateTime},
+			{"for an unknown field", newOrganization(orgID, "test", 1), []storage.OrganizationField{"unknown"}, storage.ErrUnknownOrganizationField},
+			{"if the organization does not exist", newOrganization(unknownID, "unknown", 1), []storage.OrganizationField{storage.OrganizationLegalName}, storage.ErrOrganizationNotFound},
+			{"if the legal name is taken", newOrganization(orgID, "other", 1), []storage.OrganizationField{storage.OrganizationLegalName}, storage.ErrConflictOrganizationLegalName},
+			{"if the slug is taken", newOrganization(orgID, "other", 1), []storage.OrganizationField{storage.OrganizationSlug}, storage.ErrConflictOrganizationSlug},
+			{"if the slug has an invalid format", newOrganization(orgID, "Other Slug", 1), []storage.OrganizationField{storage.OrganizationSlug}, storage.ErrInvalidOrganizationSlug},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				m := setup(t, f, []*storage.Organization{newOrganization(orgID, "test", 1), newOrganization(otherOrgID, "other", 1)}, nil)
+				_, err := m.Op().Organization.Update(ctx, tt.org, tt.fields)
+				assertError(t, tt.err, err)
+
+				got, err := m.Op().Organization.Get(ctx, orgID)
+				assertError(t, nil, err)
+				assertDiff(t, newOrganization(orgID, "test", 1), got)
+			})
+		}
+	})
+
+	t.Run("Delete should delete the organization", func(t *testing.T) {
+		m := setup(t, f, []*storage.Organization{newOrganization(orgID, "test", 1)}, nil)
+		assertError(t, nil, m.Op().Organization.Delete(ctx, orgID))
+
+		_, err := m.Op().Organization.Get(ctx, orgID)
+		assertError(t, storage.ErrOrganizationNotFound, err)
+
+		assertError(t, storage.ErrOrganizationNotFound, m.Op().Organization.Delete(ctx, orgID))
+	})
+
+	t.Run("Delete should return an error if the organization has users", func(t *testing.T) {
+		m := seeded(t, f)
+		assertError(t, storage.ErrOrganizationHasUsers, m.Op().Organization.Delete(ctx, orgID))
+	})
+
+	t.Run("List", func(t *testing.T) { runOrganizationList(t, f) })
+}
+
+func runOrganizationList(t *testing.T, f Factory) {
+	ctx := context.Background()
+	ids := []uuid.UUID{orgID, otherOrgID, unknownID}
+	orgs := []*storage.Organization{
+		newOrganization(ids[0], "bravo", 2),
+		newOrganization(ids[1], "alpha", 3),
+		newOrganization(ids[2], "charlie-bravo", 1),
+	}
+
+	m := setup(t, f, orgs, nil)
+
+	tests := []struct {
+		name       string
+		pagination storage.Pagination
+		orderBy    storage.OrganizationOrderBy
+		conditions []storage.Condition
+		want       []*storage.Organization
+		err        error
+	}{
+		{
+			name:    "should sort ascending",
+			orderBy: storage.OrganizationOrderBy{{Field: storage.OrganizationLegalName, Direction: storage.ASC}},
+			want:    []*storage.Organization{orgs[1], orgs[0], orgs[2]},
+		},
+		{
+			name:    "should sort descending",
+			orderBy: storage.OrganizationOrderBy{{Field: storage.OrganizationCreateTime, Direction: storage.DESC}},
+			want:    []*storage.Organization{orgs[1], orgs[0], orgs[2]},
+		},
+		{
+			name:       "should filter by legal name",
+			conditions: []storage.Condition{storage.OrganizationByLegalNameCondition{LegalName: "bravo"}},
+			want:       []*storage.Organization{orgs[0]},
+		},
+		{
+			name:       "should filter by a part of the legal name",
+			orderBy:    storage.OrganizationOrderBy{{Field: storage.OrganizationLegalName, Direction: storage.ASC}},
+			conditions: []storage.Condition{storage.OrganizationByLegalNameCondition{LegalName: "bravo", Wildcard: true}},
+			want:       []*storage.Organization{orgs[0], orgs[2]},
+		},
+		{
+			name:       "should limit and offset",
+			pagination: storage.Pagination{Limit: 1, Offset: 1},
+			orderBy:    storage.OrganizationOrderBy{{Field: storage.OrganizationLegalName, Direction: storage.ASC}},
+			want:       []*storage.Organization{orgs[0]},
+		},
+		{
+			name:       "should return the rest if the limit exceeds the organizations",
+			pagination: storage.Pagination{Limit: 10, Offset: 2},
+			orderBy:    storage.OrganizationOrderBy{{Field: storage.OrganizationLegalName, Direction: storage.ASC}},
+			want:       []*storage.Organization{orgs[2]},
+		},
+		{
+			name:       "should return nothing if the offset exceeds the organizations",
+			pagination: storage.Pagination{Offset: 3},
+			want:       nil,
+		},
+		{
+			name:    "should return an error for an empty sort field",
+			orderBy: storage.OrganizationOrderBy{{Direction: storage.ASC}},
+			err:     storage.ErrEmptyOrganizationSortField,
+		},
+		{
+			name:    "should return an error for an unknown sort field",
+			orderBy: storage.OrganizationOrderBy{{Field: "unknown", Direction: storage.ASC}},
+			err:     storage.ErrUnknownOrganizationField,
+		},
+		{
+			name:    "should return an error for an invalid sort direction",
+			orderBy: storage.OrganizationOrderBy{{Field: storage.OrganizationSlug, Direction: "UP"}},
+			err:     storage.ErrInvalidOrganizationSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Op().Organization.List(ctx, tt.pagination, tt.orderBy, tt.conditions...)
+			assertError(t, tt.err, err)
+			assertDiff(t, tt.want, got)
+		})
+	}
+
+	t.Run("should return an error for an unknown condition", func(t *testing.T) {
+		if _, err := m.Op().Organization.List(ctx, storage.Pagination{}, nil, storage.UserByOrganizationIDCondition{}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
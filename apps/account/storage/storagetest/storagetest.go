@@ -0,0 +1,209 @@
+// Package storagetest is a conformance suite for the storage backends. Every backend runs the same
+// tests, so the backends behave the same:
+//
+//	func TestConformance(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.DBManager {
+//			return memory.NewManager()
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+// Factory returns an empty backend. It is called for every test, the backends may share their
+// storage as long as it is empty again.
+type Factory func(t *testing.T) storage.DBManager
+
+var (
+	orgID      = uuid.MustParse("7bb443e5-8974-44c2-8b7c-b95124205264")
+	otherOrgID = uuid.MustParse("c105ca54-68f0-4bc4-aca1-b54065b4e9b4")
+	userIDs    = []uuid.UUID{
+		uuid.MustParse("957b12c5-1071-40d9-8bec-6ed195c8cfbf"),
+		uuid.MustParse("35297169-89d8-444d-8499-c6341e3a0770"),
+		uuid.MustParse("82651da9-c2ff-4152-8eae-7555d5a42aad"),
+	}
+	unknownID = uuid.MustParse("44756c0a-28b7-40c7-a066-f8db23d7dbe3")
+)
+
+// date returns a time on a day of 2020.
+func date(day int) time.Time {
+	return time.Date(2020, 1, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newOrganization(id uuid.UUID, name string, day int) *storage.Organization {
+	return &storage.Organization{
+		ID:         id,
+		LegalName:  name,
+		Slug:       name,
+		CreateTime: date(day),
+		UpdateTime: date(day),
+	}
+}
+
+func newUser(id uuid.UUID, email string, day int) *storage.User {
+	return &storage.User{
+		ID:             id,
+		OrganizationID: orgID,
+		DisplayName:    "test",
+		Email:          email,
+		HashedPassword: "password",
+		Status:         "active",
+		Role:           "user",
+		CreateTime:     date(day),
+		UpdateTime:     date(day),
+	}
+}
+
+// withoutPassword returns the user as Create, Update and List return it.
+func withoutPassword(u *storage.User) *storage.User {
+	c := *u
+	c.HashedPassword = ""
+	return &c
+}
+
+// setup returns a new backend with the organizations and users.
+func setup(t *testing.T, f Factory, orgs []*storage.Organization, users []*storage.User) storage.DBManager {
+	t.Helper()
+	ctx := context.Background()
+	m := f(t)
+	r := m.Op()
+
+	for _, o := range orgs {
+		if _, err := r.Organization.Create(ctx, o); err != nil {
+			t.Fatalf("failed to create organization: %v", err)
+		}
+	}
+
+	for _, u := range users {
+		if _, err := r.User.Create(ctx, u); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+
+	return m
+}
+
+// seeded returns a new backend with an organization and a user of it.
+func seeded(t *testing.T, f Factory) storage.DBManager {
+	t.Helper()
+	return setup(t, f,
+		[]*storage.Organization{newOrganization(orgID, "test", 1)},
+		[]*storage.User{newUser(userIDs[0], "test@test.com", 1)},
+	)
+}
+
+func assertError(t *testing.T, want, got error) {
+	t.Helper()
+	if !errors.Is(got, want) {
+		t.Errorf("error = %v, want %v", got, want)
+	}
+}
+
+func assertDiff[T any](t *testing.T, want, got T) {
+	t.Helper()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// Run runs the conformance suite against the backends of the factory.
+func Run(t *testing.T, f Factory) {
+	t.Run("Organization", func(t *testing.T) { runOrganization(t, f) })
+	t.Run("User", func(t *testing.T) { runUser(t, f) })
+	t.Run("BeginOp", func(t *testing.T) { runBeginOp(t, f) })
+}
+
+func runBeginOp(t *testing.T, f Factory) {
+	ctx := context.Background()
+	failed := errors.New("failed")
+
+	t.Run("should commit the changes of the operation", func(t *testing.T) {
+		m := f(t)
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if _, err := r.Organization.Create(ctx, newOrganization(orgID, "test", 1)); err != nil {
+				return err
+			}
+			_, err := r.User.Create(ctx, newUser(userIDs[0], "test@test.com", 1))
+			return err
+		})
+		assertError(t, nil, err)
+
+		_, err = m.Op().User.Get(ctx, userIDs[0])
+		assertError(t, nil, err)
+	})
+
+	t.Run("should roll back the changes of a failed operation", func(t *testing.T) {
+		m := f(t)
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if _, err := r.Organization.Create(ctx, newOrganization(orgID, "test", 1)); err != nil {
+				return err
+			}
+			if _, err := r.User.Create(ctx, newUser(userIDs[0], "test@test.com", 1)); err != nil {
+				return err
+			}
+			return failed
+		})
+		assertError(t, failed, err)
+
+		_, err = m.Op().Organization.Get(ctx, orgID)
+		assertError(t, storage.ErrOrganizationNotFound, err)
+
+		_, err = m.Op().User.Get(ctx, userIDs[0])
+		assertError(t, storage.ErrUserNotFound, err)
+	})
+
+	t.Run("should roll back the operation when a write fails", func(t *testing.T) {
+		m := seeded(t, f)
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if _, err := r.Organization.Create(ctx, newOrganization(otherOrgID, "other", 1)); err != nil {
+				return err
+			}
+			_, err := r.User.Create(ctx, newUser(userIDs[1], "test@test.com", 1))
+			return err
+		})
+		assertError(t, storage.ErrConflictUserEmail, err)
+
+		_, err = m.Op().Organization.Get(ctx, otherOrgID)
+		assertError(t, storage.ErrOrganizationNotFound, err)
+	})
+
+	t.Run("should roll back a failed nested operation only", func(t *testing.T) {
+		m := seeded(t, f)
+		err := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+			if _, err := r.User.Create(ctx, newUser(userIDs[1], "other@test.com", 1)); err != nil {
+				return err
+			}
+
+			nErr := m.BeginOp(ctx, func(ctx context.Context, r storage.Repositories) error {
+				if _, err := r.User.Create(ctx, newUser(userIDs[2], "nested@test.com", 1)); err != nil {
+					return err
+				}
+				return failed
+			})
+			assertError(t, failed, nErr)
+			return nil
+		})
+		assertError(t, nil, err)
+
+		_, err = m.Op().User.Get(ctx, userIDs[1])
+		assertError(t, nil, err)
+
+		_, err = m.Op().User.Get(ctx, userIDs[2])
+		assertError(t, storage.ErrUserNotFound, err)
+	})
+}
+
+// nullTime returns a valid sql.NullTime.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: true}
+}
@@ -0,0 +1,269 @@
+package storagetest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/uuid"
+)
+
+func runUser(t *testing.T, f Factory) {
+	ctx := context.Background()
+
+	t.Run("Create should return the user without the password", func(t *testing.T) {
+		m := setup(t, f, []*storage.Organization{newOrganization(orgID, "test", 1)}, nil)
+		got, err := m.Op().User.Create(ctx, newUser(userIDs[0], "test@test.com", 1))
+		assertError(t, nil, err)
+		assertDiff(t, withoutPassword(newUser(userIDs[0], "test@test.com", 1)), got)
+	})
+
+	t.Run("Create should enforce the constraints", func(t *testing.T) {
+		tests := []struct {
+			name string
+			user func(u *storage.User)
+			err  error
+		}{
+			{
+				name: "should return an error if the id is taken",
+				user: func(u *storage.User) {
+					u.ID = userIDs[0]
+					u.Email = "other@test.com"
+				},
+				err: storage.ErrConflictUserID,
+			},
+			{
+				name: "should return an error if the email of an active user is taken",
+				user: func(*storage.User) {},
+				err:  storage.ErrConflictUserEmail,
+			},
+			{
+				name: "should allow the email of an active user for an inactive user",
+				user: func(u *storage.User) { u.Status = "inactive" },
+			},
+			{
+				name: "should allow the email of an active user for a deleted user",
+				user: func(u *storage.User) { u.DeleteTime = nullTime(date(2)) },
+			},
+			{
+				name: "should return an error if the organization does not exist",
+				user: func(u *storage.User) {
+					u.Email = "other@test.com"
+					u.OrganizationID = unknownID
+				},
+				err: storage.ErrUserOrganizationNotFound,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				m := seeded(t, f)
+				u := newUser(userIDs[1], "test@test.com", 1)
+				tt.user(u)
+				_, err := m.Op().User.Create(ctx, u)
+				assertError(t, tt.err, err)
+			})
+		}
+	})
+
+	t.Run("Get should return the user with the password", func(t *testing.T) {
+		m := seeded(t, f)
+		got, err := m.Op().User.Get(ctx, userIDs[0])
+		assertError(t, nil, err)
+		assertDiff(t, newUser(userIDs[0], "test@test.com", 1), got)
+
+		_, err = m.Op().User.Get(ctx, unknownID)
+		assertError(t, storage.ErrUserNotFound, err)
+	})
+
+	t.Run("GetByEmail should return the user with the password", func(t *testing.T) {
+		m := seeded(t, f)
+		got, err := m.Op().User.GetByEmail(ctx, "test@test.com")
+		assertError(t, nil, err)
+		assertDiff(t, newUser(userIDs[0], "test@test.com", 1), got)
+
+		_, err = m.Op().User.GetByEmail(ctx, "unknown@test.com")
+		assertError(t, storage.ErrUserNotFound, err)
+	})
+
+	t.Run("Update should update only the fields", func(t *testing.T) {
+		m := seeded(t, f)
+		in := newUser(userIDs[0], "changed@test.com", 2)
+		in.DisplayName = "changed"
+		got, err := m.Op().User.Update(ctx, in, []storage.UserField{storage.UserDisplayName, storage.UserUpdateTime})
+		assertError(t, nil, err)
+
+		want := withoutPassword(newUser(userIDs[0], "test@test.com", 1))
+		want.DisplayName = "changed"
+		want.UpdateTime = date(2)
+		assertDiff(t, want, got)
+	})
+
+	t.Run("Update should set and clear the delete time", func(t *testing.T) {
+		m := seeded(t, f)
+		in := newUser(userIDs[0], "test@test.com", 1)
+		in.DeleteTime = nullTime(date(2))
+		got, err := m.Op().User.Update(ctx, in, []storage.UserField{storage.UserDeleteTime})
+		assertError(t, nil, err)
+		assertDiff(t, nullTime(date(2)), got.DeleteTime)
+
+		in.DeleteTime = sql.NullTime{}
+		got, err = m.Op().User.Update(ctx, in, []storage.UserField{storage.UserDeleteTime})
+		assertError(t, nil, err)
+		assertDiff(t, false, got.DeleteTime.Valid)
+	})
+
+	t.Run("Update should return an error", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			user   *storage.User
+			fields []storage.UserField
+			err    error
+		}{
+			{"without fields", newUser(userIDs[0], "test@test.com", 1), nil, storage.ErrNoUserFieldsToUpdate},
+			{"for the id", newUser(userIDs[0], "test@test.com", 1), []storage.UserField{storage.UserID}, storage.ErrImmutableUserID},
+			{"for the create time", newUser(userIDs[0], "test@test.com", 1), []storage.UserField{storage.UserCreateTime}, storage.ErrImmutableUserCreateTime},
+			{"for an unknown field", newUser(userIDs[0], "test@test.com", 1), []storage.UserField{"unknown"}, storage.ErrUserUnknownField},
+			{"if the user does not exist", newUser(unknownID, "unknown@test.com", 1), []storage.UserField{storage.UserDisplayName}, storage.ErrUserNotFound},
+			{"if the email of an active user is taken", newUser(userIDs[0], "other@test.com", 1), []storage.UserField{storage.UserEmail}, storage.ErrConflictUserEmail},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				m := setup(t, f,
+					[]*storage.Organization{newOrganization(orgID, "test", 1)},
+					[]*storage.User{newUser(userIDs[0], "test@test.com", 1), newUser(userIDs[1], "other@test.com", 1)},
+				)
+				_, err := m.Op().User.Update(ctx, tt.user, tt.fields)
+				assertError(t, tt.err, err)
+
+				got, err := m.Op().User.Get(ctx, userIDs[0])
+				assertError(t, nil, err)
+				assertDiff(t, newUser(userIDs[0], "test@test.com", 1), got)
+			})
+		}
+	})
+
+	t.Run("Delete should delete the user", func(t *testing.T) {
+		m := seeded(t, f)
+		assertError(t, nil, m.Op().User.Delete(ctx, userIDs[0]))
+
+		_, err := m.Op().User.Get(ctx, userIDs[0])
+		assertError(t, storage.ErrUserNotFound, err)
+
+		assertError(t, storage.ErrUserNotFound, m.Op().User.Delete(ctx, userIDs[0]))
+	})
+
+	t.Run("List", func(t *testing.T) { runUserList(t, f) })
+}
+
+func runUserList(t *testing.T, f Factory) {
+	ctx := context.Background()
+	users := []*storage.User{
+		newUser(userIDs[0], "b@test.com", 2),
+		newUser(userIDs[1], "c@test.com", 3),
+		newUser(userIDs[2], "a@test.com", 1),
+	}
+	users[1].OrganizationID = otherOrgID
+
+	m := setup(t, f,
+		[]*storage.Organization{newOrganization(orgID, "test", 1), newOrganization(otherOrgID, "other", 1)},
+		users,
+	)
+
+	// want returns the users as List returns them.
+	want := func(i ...int) []*storage.User {
+		var r []*storage.User
+		for _, i := range i {
+			r = append(r, withoutPassword(users[i]))
+		}
+		return r
+	}
+
+	byEmail := storage.UserOrderBy{{Field: storage.UserEmail, Direction: storage.ASC}}
+
+	tests := []struct {
+		name       string
+		pagination storage.Pagination
+		orderBy    storage.UserOrderBy
+		conditions []storage.Condition
+		want       []*storage.User
+		err        error
+	}{
+		{
+			name:    "should sort ascending",
+			orderBy: byEmail,
+			want:    want(2, 0, 1),
+		},
+		{
+			name:    "should sort descending",
+			orderBy: storage.UserOrderBy{{Field: storage.UserCreateTime, Direction: storage.DESC}},
+			want:    want(1, 0, 2),
+		},
+		{
+			name:       "should filter by organization",
+			orderBy:    byEmail,
+			conditions: []storage.Condition{storage.UserByOrganizationIDCondition{OrganizationID: orgID}},
+			want:       want(2, 0),
+		},
+		{
+			name:       "should filter by ids",
+			orderBy:    byEmail,
+			conditions: []storage.Condition{storage.UserByIDsCondition{IDs: []uuid.UUID{userIDs[0], userIDs[1], unknownID}}},
+			want:       want(0, 1),
+		},
+		{
+			name:    "should combine the conditions",
+			orderBy: byEmail,
+			conditions: []storage.Condition{
+				storage.UserByOrganizationIDCondition{OrganizationID: orgID},
+				storage.UserByIDsCondition{IDs: []uuid.UUID{userIDs[0], userIDs[1]}},
+			},
+			want: want(0),
+		},
+		{
+			name:       "should limit and offset",
+			pagination: storage.Pagination{Limit: 1, Offset: 1},
+			orderBy:    byEmail,
+			want:       want(0),
+		},
+		{
+			name:       "should return the rest if the limit exceeds the users",
+			pagination: storage.Pagination{Limit: 10, Offset: 2},
+			orderBy:    byEmail,
+			want:       want(1),
+		},
+		{
+			name:       "should return nothing if the offset exceeds the users",
+			pagination: storage.Pagination{Offset: 3},
+			want:       nil,
+		},
+		{
+			name:    "should return an error for an empty sort field",
+			orderBy: storage.UserOrderBy{{Direction: storage.ASC}},
+			err:     storage.ErrEmptyUserSortField,
+		},
+		{
+			name:    "should return an error for an unknown sort field",
+			orderBy: storage.UserOrderBy{{Field: "unknown", Direction: storage.ASC}},
+			err:     storage.ErrUserUnknownField,
+		},
+		{
+			name:    "should return an error for an invalid sort direction",
+			orderBy: storage.UserOrderBy{{Field: storage.UserEmail, Direction: "UP"}},
+			err:     storage.ErrInvalidUserSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Op().User.List(ctx, tt.pagination, tt.orderBy, tt.conditions...)
+			assertError(t, tt.err, err)
+			assertDiff(t, tt.want, got)
+		})
+	}
+
+	t.Run("should return an error for an unknown condition", func(t *testing.T) {
+		if _, err := m.Op().User.List(ctx, storage.Pagination{}, nil, storage.OrganizationByLegalNameCondition{}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
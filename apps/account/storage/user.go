@@ -25,6 +25,8 @@ var (
 	ErrConflictUserID          UserError = errors.New("unique id conflict")
 	ErrConflictUserDisplayName UserError = errors.New("unique display_name conflict")
 	ErrConflictUserEmail       UserError = errors.New("unique email conflict")
+	// Foreign key errors.
+	ErrUserOrganizationNotFound UserError = errors.New("organization of user not found")
 	// Immutable errors.
 	ErrImmutableUserID         UserError = errors.New("field id is read-only")
 	ErrImmutableUserCreateTime UserError = errors.New("field create_time is read-only")
@@ -33,15 +35,17 @@ var (
 type UserField string
 
 const (
-	UserID             UserField = "id"
-	UserOrganizationID UserField = "organization_id"
-	UserDisplayName    UserField = "display_name"
-	UserEmail          UserField = "email"
-	UserHashedPassword UserField = "hashed_password"
-	UserStatus         UserField = "status"
-	UserCreateTime     UserField = "create_time"
-	UserUpdateTime     UserField = "update_time"
-	UserDeleteTime     UserField = "delete_time"
+	UserID                UserField = "id"
+	UserOrganizationID    UserField = "organization_id"
+	UserDisplayName       UserField = "display_name"
+	UserEmail             UserField = "email"
+	UserHashedPassword    UserField = "hashed_password"
+	UserStatus            UserField = "status"
+	UserRole              UserField = "role"
+	UserPreferredLanguage UserField = "preferred_language"
+	UserCreateTime        UserField = "create_time"
+	UserUpdateTime        UserField = "update_time"
+	UserDeleteTime        UserField = "delete_time"
 )
 
 // UserFields returns all user fields.
@@ -53,6 +57,8 @@ func UserFields() []UserField {
 		UserEmail,
 		UserHashedPassword,
 		UserStatus,
+		UserRole,
+		UserPreferredLanguage,
 		UserCreateTime,
 		UserUpdateTime,
 		UserDeleteTime,
@@ -60,15 +66,17 @@ func UserFields() []UserField {
 }
 
 type User struct {
-	ID             uuid.UUID
-	OrganizationID uuid.UUID
-	DisplayName    string
-	Email          string
-	HashedPassword string
-	Status         string
-	CreateTime     time.Time
-	UpdateTime     time.Time
-	DeleteTime     sql.NullTime
+	ID                uuid.UUID
+	OrganizationID    uuid.UUID
+	DisplayName       string
+	Email             string
+	HashedPassword    string
+	Status            string
+	Role              string
+	PreferredLanguage string
+	CreateTime        time.Time
+	UpdateTime        time.Time
+	DeleteTime        sql.NullTime
 }
 
 // UserSort pairs a field with a direction.
@@ -131,3 +139,10 @@ type UserByOrganizationIDCondition struct {
 }
 
 func (UserByOrganizationIDCondition) condition() {}
+
+// UserByIDsCondition is a search condition for users by their IDs.
+type UserByIDsCondition struct {
+	IDs []uuid.UUID
+}
+
+func (UserByIDsCondition) condition() {}
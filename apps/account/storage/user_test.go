@@ -18,6 +18,8 @@ func TestUserFields(t *testing.T) {
 			storage.UserEmail,
 			storage.UserHashedPassword,
 			storage.UserStatus,
+			storage.UserRole,
+			storage.UserPreferredLanguage,
 			storage.UserCreateTime,
 			storage.UserUpdateTime,
 			storage.UserDeleteTime,
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookError error
+
+var (
+	ErrWebhookNotFound WebhookError = errors.New("webhook not found")
+	// Update.
+	ErrNoWebhookFieldsToUpdate WebhookError = errors.New("no fields to update")
+	// Fields.
+	ErrWebhookUnknownField WebhookError = errors.New("unknown webhook field")
+	// sort errors.
+	ErrEmptyWebhookSortField       WebhookError = errors.New("webhook field is empty")
+	ErrInvalidWebhookSortDirection WebhookError = errors.New("invalid webhook sort direction")
+	// Unique constraint errors.
+	ErrConflictWebhookID WebhookError = errors.New("unique id conflict")
+	// Immutable errors.
+	ErrImmutableWebhookID             WebhookError = errors.New("field id is read-only")
+	ErrImmutableWebhookOrganizationID WebhookError = errors.New("field organization_id is read-only")
+	ErrImmutableWebhookCreateTime     WebhookError = errors.New("field create_time is read-only")
+)
+
+type WebhookField string
+
+const (
+	WebhookID             WebhookField = "id"
+	WebhookOrganizationID WebhookField = "organization_id"
+	WebhookURL            WebhookField = "url"
+	WebhookEventTypes     WebhookField = "event_types"
+	WebhookSecret         WebhookField = "secret"
+	WebhookFailureCount   WebhookField = "failure_count"
+	WebhookCreateTime     WebhookField = "create_time"
+	WebhookUpdateTime     WebhookField = "update_time"
+	WebhookDisableTime    WebhookField = "disable_time"
+)
+
+// WebhookFields returns all webhook fields.
+func WebhookFields() []WebhookField {
+	return []WebhookField{
+		WebhookID,
+		WebhookOrganizationID,
+		WebhookURL,
+		WebhookEventTypes,
+		WebhookSecret,
+		WebhookFailureCount,
+		WebhookCreateTime,
+		WebhookUpdateTime,
+		WebhookDisableTime,
+	}
+}
+
+// Webhook is a subscription of an organization to domain events, which are posted to its URL.
+type Webhook struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	URL            string
+	EventTypes     []string
+	Secret         string // Secret is the key the deliveries are signed with.
+	FailureCount   int    // FailureCount is the number of failed delivery attempts since the last success.
+	CreateTime     time.Time
+	UpdateTime     time.Time
+	DisableTime    sql.NullTime
+}
+
+// WebhookSort pairs a field with a direction.
+type WebhookSort struct {
+	Field     WebhookField
+	Direction Direction
+}
+
+type WebhookOrderBy []WebhookSort
+
+// Validate checks if the sort fields are valid.
+func (o WebhookOrderBy) Validate() error {
+	fields := WebhookFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyWebhookSortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrWebhookUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidWebhookSortDirection)
+		}
+	}
+
+	return nil
+}
+
+type WebhookReader interface {
+	Get(context.Context, uuid.UUID) (*Webhook, error)
+	List(context.Context, Pagination, WebhookOrderBy, ...Condition) ([]*Webhook, error)
+}
+
+type WebhookWriter interface {
+	Create(context.Context, *Webhook) (*Webhook, error)
+	Update(context.Context, *Webhook, []WebhookField) (*Webhook, error)
+	Delete(context.Context, uuid.UUID) error
+}
+
+// WebhookRepository is a reader and writer for webhooks.
+type WebhookRepository interface {
+	WebhookReader
+	WebhookWriter
+}
+
+// WebhookByOrganizationIDCondition is a search condition for webhooks by organization ID.
+type WebhookByOrganizationIDCondition struct {
+	OrganizationID uuid.UUID
+}
+
+func (WebhookByOrganizationIDCondition) condition() {}
+
+// WebhookEnabledCondition is a search condition for webhooks that are not disabled.
+type WebhookEnabledCondition struct{}
+
+func (WebhookEnabledCondition) condition() {}
+
+// WebhookByEventTypeCondition is a search condition for webhooks subscribed to an event type.
+type WebhookByEventTypeCondition struct {
+	EventType string
+}
+
+func (WebhookByEventTypeCondition) condition() {}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryError error
+
+var (
+	ErrWebhookDeliveryNotFound WebhookDeliveryError = errors.New("webhook delivery not found")
+	// Update.
+	ErrNoWebhookDeliveryFieldsToUpdate WebhookDeliveryError = errors.New("no fields to update")
+	// Fields.
+	ErrWebhookDeliveryUnknownField WebhookDeliveryError = errors.New("unknown webhook delivery field")
+	// sort errors.
+	ErrEmptyWebhookDeliverySortField       WebhookDeliveryError = errors.New("webhook delivery field is empty")
+	ErrInvalidWebhookDeliverySortDirection WebhookDeliveryError = errors.New("invalid webhook delivery sort direction")
+	// Unique constraint errors.
+	ErrConflictWebhookDeliveryID      WebhookDeliveryError = errors.New("unique id conflict")
+	ErrConflictWebhookDeliveryAttempt WebhookDeliveryError = errors.New("unique webhook_id, event_id and attempt conflict")
+	// Immutable errors.
+	ErrImmutableWebhookDeliveryID         WebhookDeliveryError = errors.New("field id is read-only")
+	ErrImmutableWebhookDeliveryWebhookID  WebhookDeliveryError = errors.New("field webhook_id is read-only")
+	ErrImmutableWebhookDeliveryEventID    WebhookDeliveryError = errors.New("field event_id is read-only")
+	ErrImmutableWebhookDeliveryEventType  WebhookDeliveryError = errors.New("field event_type is read-only")
+	ErrImmutableWebhookDeliveryPayload    WebhookDeliveryError = errors.New("field payload is read-only")
+	ErrImmutableWebhookDeliveryAttempt    WebhookDeliveryError = errors.New("field attempt is read-only")
+	ErrImmutableWebhookDeliveryCreateTime WebhookDeliveryError = errors.New("field create_time is read-only")
+)
+
+type WebhookDeliveryField string
+
+const (
+	WebhookDeliveryID           WebhookDeliveryField = "id"
+	WebhookDeliveryWebhookID    WebhookDeliveryField = "webhook_id"
+	WebhookDeliveryEventID      WebhookDeliveryField = "event_id"
+	WebhookDeliveryEventType    WebhookDeliveryField = "event_type"
+	WebhookDeliveryPayload      WebhookDeliveryField = "payload"
+	WebhookDeliveryAttempt      WebhookDeliveryField = "attempt"
+	WebhookDeliveryStatus       WebhookDeliveryField = "status"
+	WebhookDeliveryScheduleTime WebhookDeliveryField = "schedule_time"
+	WebhookDeliveryAttemptTime  WebhookDeliveryField = "attempt_time"
+	WebhookDeliveryResponseCode WebhookDeliveryField = "response_code"
+	WebhookDeliveryErrorMessage WebhookDeliveryField = "error_message"
+	WebhookDeliveryCreateTime   WebhookDeliveryField = "create_time"
+)
+
+// WebhookDeliveryFields returns all webhook delivery fields.
+func WebhookDeliveryFields() []WebhookDeliveryField {
+	return []WebhookDeliveryField{
+		WebhookDeliveryID,
+		WebhookDeliveryWebhookID,
+		WebhookDeliveryEventID,
+		WebhookDeliveryEventType,
+		WebhookDeliveryPayload,
+		WebhookDeliveryAttempt,
+		WebhookDeliveryStatus,
+		WebhookDeliveryScheduleTime,
+		WebhookDeliveryAttemptTime,
+		WebhookDeliveryResponseCode,
+		WebhookDeliveryErrorMessage,
+		WebhookDeliveryCreateTime,
+	}
+}
+
+// WebhookDelivery is a single attempt to post an event to a webhook.
+// A retry or a redelivery is a new delivery with the next attempt number.
+type WebhookDelivery struct {
+	ID           uuid.UUID
+	WebhookID    uuid.UUID
+	EventID      int64 // EventID is the id of the outbox event that is delivered.
+	EventType    string
+	Payload      []byte // Payload is the JSON request body.
+	Attempt      int
+	Status       string
+	ScheduleTime time.Time // ScheduleTime is the earliest time the attempt is made.
+	AttemptTime  sql.NullTime
+	ResponseCode int
+	ErrorMessage string
+	CreateTime   time.Time
+}
+
+// WebhookDeliverySort pairs a field with a direction.
+type WebhookDeliverySort struct {
+	Field     WebhookDeliveryField
+	Direction Direction
+}
+
+type WebhookDeliveryOrderBy []WebhookDeliverySort
+
+// Validate checks if the sort fields are valid.
+func (o WebhookDeliveryOrderBy) Validate() error {
+	fields := WebhookDeliveryFields()
+	for _, s := range o {
+		if s.Field == "" {
+			return ErrEmptyWebhookDeliverySortField
+		}
+
+		var found bool
+		for _, f := range fields {
+			if s.Field == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %w", s.Field, ErrWebhookDeliveryUnknownField)
+		}
+
+		if s.Direction != ASC && s.Direction != DESC {
+			return fmt.Errorf("%s: %w", s.Direction, ErrInvalidWebhookDeliverySortDirection)
+		}
+	}
+
+	return nil
+}
+
+type WebhookDeliveryReader interface {
+	Get(context.Context, uuid.UUID) (*WebhookDelivery, error)
+	List(context.Context, Pagination, WebhookDeliveryOrderBy, ...Condition) ([]*WebhookDelivery, error)
+}
+
+type WebhookDeliveryWriter interface {
+	// Lock makes sure only one deliverer sends deliveries until the surrounding transaction ends.
+	Lock(context.Context) error
+	Create(context.Context, *WebhookDelivery) (*WebhookDelivery, error)
+	Update(context.Context, *WebhookDelivery, []WebhookDeliveryField) (*WebhookDelivery, error)
+}
+
+// WebhookDeliveryRepository is a reader and writer for webhook deliveries.
+type WebhookDeliveryRepository interface {
+	WebhookDeliveryReader
+	WebhookDeliveryWriter
+}
+
+// WebhookDeliveryByWebhookIDCondition is a search condition for the deliveries of a webhook.
+type WebhookDeliveryByWebhookIDCondition struct {
+	WebhookID uuid.UUID
+}
+
+func (WebhookDeliveryByWebhookIDCondition) condition() {}
+
+// WebhookDeliveryByEventIDCondition is a search condition for the deliveries of an event.
+type WebhookDeliveryByEventIDCondition struct {
+	EventID int64
+}
+
+func (WebhookDeliveryByEventIDCondition) condition() {}
+
+// WebhookDeliveryByStatusCondition is a search condition for deliveries by status.
+type WebhookDeliveryByStatusCondition struct {
+	Status string
+}
+
+func (WebhookDeliveryByStatusCondition) condition() {}
+
+// WebhookDeliveryDueCondition is a search condition for deliveries scheduled at or before Time.
+type WebhookDeliveryDueCondition struct {
+	Time time.Time
+}
+
+func (WebhookDeliveryDueCondition) condition() {}
@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhookDeliveryFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.WebhookDeliveryFields()
+		want := []storage.WebhookDeliveryField{
+			storage.WebhookDeliveryID,
+			storage.WebhookDeliveryWebhookID,
+			storage.WebhookDeliveryEventID,
+			storage.WebhookDeliveryEventType,
+			storage.WebhookDeliveryPayload,
+			storage.WebhookDeliveryAttempt,
+			storage.WebhookDeliveryStatus,
+			storage.WebhookDeliveryScheduleTime,
+			storage.WebhookDeliveryAttemptTime,
+			storage.WebhookDeliveryResponseCode,
+			storage.WebhookDeliveryErrorMessage,
+			storage.WebhookDeliveryCreateTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("WebhookDeliveryFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestWebhookDeliveryOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.WebhookDeliveryOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.WebhookDeliveryOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.WebhookDeliveryOrderBy{{Field: "invalid"}},
+			err:  storage.ErrWebhookDeliveryUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.WebhookDeliveryOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyWebhookDeliverySortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.WebhookDeliveryOrderBy{{Field: storage.WebhookDeliveryID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.WebhookDeliveryOrderBy{{Field: storage.WebhookDeliveryID, Direction: "invalid"}},
+			err:  storage.ErrInvalidWebhookDeliverySortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("WebhookDeliveryOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/extreme-business/lingo/apps/account/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhookFields(t *testing.T) {
+	t.Run("should return the fields", func(t *testing.T) {
+		got := storage.WebhookFields()
+		want := []storage.WebhookField{
+			storage.WebhookID,
+			storage.WebhookOrganizationID,
+			storage.WebhookURL,
+			storage.WebhookEventTypes,
+			storage.WebhookSecret,
+			storage.WebhookFailureCount,
+			storage.WebhookCreateTime,
+			storage.WebhookUpdateTime,
+			storage.WebhookDisableTime,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("WebhookFields() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestWebhookOrderBy_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    storage.WebhookOrderBy
+		err  error
+	}{
+		{
+			name: "empty",
+			o:    storage.WebhookOrderBy{},
+			err:  nil,
+		},
+		{
+			name: "unknown field",
+			o:    storage.WebhookOrderBy{{Field: "invalid"}},
+			err:  storage.ErrWebhookUnknownField,
+		},
+		{
+			name: "empty field",
+			o:    storage.WebhookOrderBy{{Field: ""}},
+			err:  storage.ErrEmptyWebhookSortField,
+		},
+		{
+			name: "valid field and descending direction",
+			o:    storage.WebhookOrderBy{{Field: storage.WebhookID, Direction: storage.DESC}},
+			err:  nil,
+		},
+		{
+			name: "invalid direction",
+			o:    storage.WebhookOrderBy{{Field: storage.WebhookID, Direction: "invalid"}},
+			err:  storage.ErrInvalidWebhookSortDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("WebhookOrderBy.Validate() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package cmd
+
+import (
+	accountcmd "github.com/extreme-business/lingo/apps/account/cmd"
+)
+
+//nolint:gochecknoinits // This is the entry point of the jobs cli.
+func init() {
+	rootCmd.AddCommand(accountcmd.NewJobsCmd())
+}
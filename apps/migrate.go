@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	accountcmd "github.com/extreme-business/lingo/apps/account/cmd"
+	"github.com/spf13/cobra"
+)
+
+func NewMigrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "migrate the databases of lingo services",
+		Long:  `migrate the databases of lingo services.`,
+	}
+}
+
+//nolint:gochecknoinits // This is the entry point of the migrate cli.
+func init() {
+	migrateCmd := NewMigrateCmd()
+	// add a subcommand per app with a database
+	migrateCmd.AddCommand(accountcmd.NewMigrateCmd())
+
+	rootCmd.AddCommand(migrateCmd)
+}
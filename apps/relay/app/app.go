@@ -0,0 +1,152 @@
+// Package app represents the functionality of the relay, which lets people register an account.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+
+	"github.com/extreme-business/lingo/pkg/token"
+	"github.com/extreme-business/lingo/pkg/uuidgen"
+	"github.com/extreme-business/lingo/pkg/validate"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// registrationTokenMetadataKey is the metadata the account service reads the registration token from.
+const registrationTokenMetadataKey = "registration_token"
+
+var (
+	// ErrInvalidEmail is returned when a registration token is requested for an invalid email address.
+	ErrInvalidEmail = errors.New("invalid email address")
+	// ErrInvalidToken is returned when a registration token is malformed, expired, not signed by the relay
+	// or already used.
+	ErrInvalidToken = errors.New("invalid registration token")
+)
+
+// App issues registration tokens and creates the accounts for them.
+type App struct {
+	logger         *slog.Logger
+	tokens         *token.Manager
+	sender         Sender
+	accounts       protoaccount.AccountServiceClient
+	organizationID uuid.UUID
+	genUUID        uuidgen.Generator
+}
+
+type Config struct {
+	Logger         *slog.Logger
+	Tokens         *token.Manager // Tokens signs and validates the registration tokens.
+	Sender         Sender
+	Accounts       protoaccount.AccountServiceClient
+	OrganizationID uuid.UUID // OrganizationID is the organization new accounts are created in.
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is nil")
+	}
+	if c.Tokens == nil {
+		return errors.New("token manager is nil")
+	}
+	if c.Sender == nil {
+		return errors.New("sender is nil")
+	}
+	if c.Accounts == nil {
+		return errors.New("account client is nil")
+	}
+	if c.OrganizationID == uuid.Nil {
+		return errors.New("organization id is not set")
+	}
+	return nil
+}
+
+func New(c Config) (*App, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return &App{
+		logger:         c.Logger,
+		tokens:         c.Tokens,
+		sender:         c.Sender,
+		accounts:       c.Accounts,
+		organizationID: c.OrganizationID,
+		genUUID:        uuidgen.Default(),
+	}, nil
+}
+
+// CreateRegisterToken creates a registration token for the email address and sends it to that address
+// in the language, when it is available. Whether the address already has an account is not checked,
+// so the result does not reveal it. The token is issued for the organization of the relay and has an
+// id, so the account service can use it only once.
+func (a *App) CreateRegisterToken(ctx context.Context, email, language string) error {
+	if err := validateEmail(email); err != nil {
+		return err
+	}
+
+	t, err := a.tokens.Create(email,
+		token.WithTokenID(a.genUUID().String()),
+		token.WithOrganizationID(a.organizationID.String()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create registration token: %w", err)
+	}
+
+	if err = a.sender.Send(ctx, token.Created{Email: email, Token: t}, language); err != nil {
+		return fmt.Errorf("failed to send registration token: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAccount is the account to create for a registration token.
+type CreateAccount struct {
+	Token       string
+	DisplayName string
+	Password    string
+}
+
+// CreateAccount validates the registration token and creates a user for its email address
+// with the account service, which accepts the token in place of an access token and uses it up.
+// It returns the resource name of the user.
+func (a *App) CreateAccount(ctx context.Context, c CreateAccount) (string, error) {
+	claims, err := a.tokens.Validate(c.Token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, registrationTokenMetadataKey, c.Token)
+	resp, err := a.accounts.CreateUser(ctx, &protoaccount.CreateUserRequest{
+		Parent: fmt.Sprintf("organizations/%s", a.organizationID),
+		User: &protoaccount.User{
+			DisplayName: c.DisplayName,
+			Email:       claims.Sub,
+			Password:    c.Password,
+		},
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		}
+		return "", err
+	}
+
+	a.logger.InfoContext(ctx, "account created", slog.String("user", resp.GetUser().GetName()))
+
+	return resp.GetUser().GetName(), nil
+}
+
+// validateEmail checks that the email is a plain address, such as "jane@example.com".
+func validateEmail(email string) error {
+	a, err := mail.ParseAddress(email)
+	if err != nil || a.Address != email {
+		return validate.NewError("email", "email is not a valid address", ErrInvalidEmail)
+	}
+	return nil
+}
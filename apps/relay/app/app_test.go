@@ -0,0 +1,205 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/token"
+	"github.com/extreme-business/lingo/pkg/validate"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var organizationID = uuid.MustParse("7f0b9b4c-4d1e-4c4f-9d0b-0b6a1f3f4c6e")
+
+// accountClient is an account service client that only implements CreateUser.
+type accountClient struct {
+	protoaccount.AccountServiceClient
+	CreateUserFunc func(context.Context, *protoaccount.CreateUserRequest, ...grpc.CallOption) (*protoaccount.CreateUserResponse, error)
+}
+
+func (c *accountClient) CreateUser(ctx context.Context, in *protoaccount.CreateUserRequest, opts ...grpc.CallOption) (*protoaccount.CreateUserResponse, error) {
+	if c.CreateUserFunc == nil {
+		panic("CreateUserFunc is nil")
+	}
+	return c.CreateUserFunc(ctx, in, opts...)
+}
+
+// senderFunc is a Sender that calls the func.
+type senderFunc func(context.Context, token.Created, string) error
+
+func (f senderFunc) Send(ctx context.Context, c token.Created, language string) error {
+	return f(ctx, c, language)
+}
+
+func newApp(t *testing.T, clock func() time.Time, sender Sender, accounts protoaccount.AccountServiceClient) *App {
+	t.Helper()
+	a, err := New(Config{
+		Logger:         slog.Default(),
+		Tokens:         token.NewManager(clock, []byte("secret"), time.Hour),
+		Sender:         sender,
+		Accounts:       accounts,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return an error if the organization id is not set", func(t *testing.T) {
+		_, err := New(Config{
+			Logger:   slog.Default(),
+			Tokens:   token.NewManager(time.Now, []byte("secret"), time.Hour),
+			Sender:   LogSender{Logger: slog.Default()},
+			Accounts: &accountClient{},
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestApp_CreateRegisterToken(t *testing.T) {
+	t.Run("should send a token for the email", func(t *testing.T) {
+		var sent token.Created
+		a := newApp(t, time.Now, senderFunc(func(_ context.Context, c token.Created, _ string) error {
+			sent = c
+			return nil
+		}), &accountClient{})
+
+		if err := a.CreateRegisterToken(context.Background(), "jane@example.com", "nl"); err != nil {
+			t.Fatal(err)
+		}
+
+		if sent.Email != "jane@example.com" {
+			t.Errorf("expected email jane@example.com, got %q", sent.Email)
+		}
+
+		claims, err := a.tokens.Validate(sent.Token)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if claims.Sub != "jane@example.com" {
+			t.Errorf("expected subject jane@example.com, got %q", claims.Sub)
+		}
+
+		if claims.OrganizationID != organizationID.String() {
+			t.Errorf("expected organization %s, got %q", organizationID, claims.OrganizationID)
+		}
+
+		if _, err = uuid.Parse(claims.TokenID); err != nil {
+			t.Errorf("expected a token id, got %q", claims.TokenID)
+		}
+	})
+
+	t.Run("should return a validation error for an invalid email", func(t *testing.T) {
+		a := newApp(t, time.Now, senderFunc(func(context.Context, token.Created, string) error {
+			t.Fatal("no token should be sent")
+			return nil
+		}), &accountClient{})
+
+		for _, email := range []string{"", "jane", "Jane <jane@example.com>"} {
+			err := a.CreateRegisterToken(context.Background(), email, "")
+			if _, ok := validate.AssertError(err); !ok || !errors.Is(err, ErrInvalidEmail) {
+				t.Errorf("%q: expected a validation error, got %v", email, err)
+			}
+		}
+	})
+}
+
+func TestApp_CreateAccount(t *testing.T) {
+	t.Run("should create a user for the email of the token", func(t *testing.T) {
+		var req *protoaccount.CreateUserRequest
+		var md metadata.MD
+		a := newApp(t, time.Now, LogSender{Logger: slog.Default()}, &accountClient{
+			CreateUserFunc: func(ctx context.Context, in *protoaccount.CreateUserRequest, _ ...grpc.CallOption) (*protoaccount.CreateUserResponse, error) {
+				req = in
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return &protoaccount.CreateUserResponse{User: &protoaccount.User{Name: "users/1"}}, nil
+			},
+		})
+
+		tok, err := a.tokens.Create("jane@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := a.CreateAccount(context.Background(), CreateAccount{
+			Token:       tok,
+			DisplayName: "Jane",
+			Password:    "password",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if user != "users/1" {
+			t.Errorf("expected users/1, got %q", user)
+		}
+
+		if req.GetParent() != "organizations/"+organizationID.String() {
+			t.Errorf("unexpected parent %q", req.GetParent())
+		}
+
+		if req.GetUser().GetEmail() != "jane@example.com" || req.GetUser().GetDisplayName() != "Jane" || req.GetUser().GetPassword() != "password" {
+			t.Errorf("unexpected user %v", req.GetUser())
+		}
+
+		if got := md.Get("registration_token"); len(got) != 1 || got[0] != tok {
+			t.Errorf("expected the registration token in the metadata, got %v", got)
+		}
+	})
+
+	t.Run("should return ErrInvalidToken for a token the account service rejects", func(t *testing.T) {
+		a := newApp(t, time.Now, LogSender{Logger: slog.Default()}, &accountClient{
+			CreateUserFunc: func(context.Context, *protoaccount.CreateUserRequest, ...grpc.CallOption) (*protoaccount.CreateUserResponse, error) {
+				return nil, status.Error(codes.Unauthenticated, "registration token is invalid or was already used")
+			},
+		})
+
+		tok, err := a.tokens.Create("jane@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = a.CreateAccount(context.Background(), CreateAccount{Token: tok}); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("should return ErrInvalidToken for an expired token", func(t *testing.T) {
+		a := newApp(t, func() time.Time { return time.Now().Add(-2 * time.Hour) }, LogSender{Logger: slog.Default()}, &accountClient{})
+
+		tok, err := a.tokens.Create("jane@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = a.CreateAccount(context.Background(), CreateAccount{Token: tok}); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("should return ErrInvalidToken for a token with another signature", func(t *testing.T) {
+		a := newApp(t, time.Now, LogSender{Logger: slog.Default()}, &accountClient{})
+
+		tok, err := token.NewManager(time.Now, []byte("other"), time.Hour).Create("jane@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = a.CreateAccount(context.Background(), CreateAccount{Token: tok}); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+
+	"github.com/extreme-business/lingo/pkg/mail"
+	"github.com/extreme-business/lingo/pkg/token"
+)
+
+// defaultMailLanguage is the language of the mail when the language of the registration is not available.
+const defaultMailLanguage = "en"
+
+//go:embed templates
+var templates embed.FS
+
+// MailSender mails the registration tokens. The mail is rendered from the template "register_token".
+type MailSender struct {
+	templates *mail.Templates
+	sender    mail.Sender
+	from      string
+}
+
+// NewMailSender returns a MailSender that sends the mail from the address with the sender,
+// which is usually a mail.Queue so a failing mail server does not fail the registration.
+func NewMailSender(sender mail.Sender, from string) (*MailSender, error) {
+	fsys, err := fs.Sub(templates, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := mail.ParseTemplates(fsys, defaultMailLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailSender{
+		templates: t,
+		sender:    sender,
+		from:      from,
+	}, nil
+}
+
+func (s *MailSender) Send(ctx context.Context, c token.Created, language string) error {
+	m, err := s.templates.Render("register_token", language, c)
+	if err != nil {
+		return err
+	}
+
+	m.From = s.from
+	m.To = []string{c.Email}
+	return s.sender.Send(ctx, m)
+}
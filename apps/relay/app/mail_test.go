@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/extreme-business/lingo/pkg/mail"
+	"github.com/extreme-business/lingo/pkg/token"
+)
+
+func TestMailSender_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		subject  string
+	}{
+		{name: "should mail the token in the language", language: "nl", subject: "Je registratiecode voor Lingo"},
+		{name: "should mail the token in english by default", language: "", subject: "Your Lingo registration code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := mail.NewMemorySender()
+			s, err := NewMailSender(sink, "Lingo <noreply@lingo.example>")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = s.Send(context.Background(), token.Created{Email: "jane@example.com", Token: "abc123"}, tt.language); err != nil {
+				t.Fatal(err)
+			}
+
+			messages := sink.Messages()
+			if len(messages) != 1 {
+				t.Fatalf("expected 1 message, got %d", len(messages))
+			}
+
+			m := messages[0]
+			if m.Subject != tt.subject {
+				t.Errorf("expected subject %q, got %q", tt.subject, m.Subject)
+			}
+
+			if len(m.To) != 1 || m.To[0] != "jane@example.com" || m.From != "Lingo <noreply@lingo.example>" {
+				t.Errorf("expected a mail from lingo to jane, got from %q to %v", m.From, m.To)
+			}
+
+			if !strings.Contains(m.Text, "abc123") || !strings.Contains(m.HTML, "<code>abc123</code>") {
+				t.Errorf("expected the token in the text and html, got %q and %q", m.Text, m.HTML)
+			}
+		})
+	}
+}
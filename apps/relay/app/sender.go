@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/extreme-business/lingo/pkg/token"
+)
+
+// Sender sends a registration token to the email address it was created for, in the language of the email.
+type Sender interface {
+	Send(ctx context.Context, c token.Created, language string) error
+}
+
+// LogSender logs the registration tokens instead of sending them. It is meant for development,
+// since anyone who can read the logs can register with any address.
+type LogSender struct {
+	Logger *slog.Logger
+}
+
+func (s LogSender) Send(ctx context.Context, c token.Created, language string) error {
+	s.Logger.InfoContext(ctx, "registration token created",
+		slog.String("email", c.Email),
+		slog.String("token", c.Token),
+		slog.String("language", language),
+	)
+	return nil
+}
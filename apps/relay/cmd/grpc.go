@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/extreme-business/lingo/apps/relay/app"
+	"github.com/extreme-business/lingo/apps/relay/server"
+	"github.com/extreme-business/lingo/pkg/config"
+	"github.com/extreme-business/lingo/pkg/grpcserver"
+	"github.com/extreme-business/lingo/pkg/mail"
+	"github.com/extreme-business/lingo/pkg/token"
+	protorelay "github.com/extreme-business/lingo/proto/gen/go/private/relay/v1"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// registerTokenExpiry is how long a registration token can be used to create an account.
+const registerTokenExpiry = 24 * time.Hour
+
+// runRelay runs the relay server.
+func runRelay(cmd *cobra.Command, _ []string) error {
+	logger := slog.Default()
+	ctx, cancel := context.WithCancel(cmd.Context())
+
+	// Set up channel to receive signals
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		s := <-sigs
+		logger.Info("Signal received", slog.String("signal", s.String()))
+		cancel()
+	}()
+
+	config := config.New()
+
+	accountConn, err := setupAccountClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to setup account client: %w", err)
+	}
+	defer accountConn.Close()
+
+	sender, queue, err := setupMail(logger, config)
+	if err != nil {
+		return fmt.Errorf("failed to setup mail: %w", err)
+	}
+
+	relay, err := setupRelay(logger, config, sender, protoaccount.NewAccountServiceClient(accountConn))
+	if err != nil {
+		return fmt.Errorf("failed to setup relay app: %w", err)
+	}
+
+	relayServer := server.New(relay)
+	grpcServer, err := setupServer(config, func(s grpc.ServiceRegistrar) {
+		protorelay.RegisterRelayServiceServer(s, relayServer)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to setup grpc server: %w", err)
+	}
+
+	g := new(errgroup.Group)
+	g.Go(func() error { return grpcServer.Serve(ctx) })
+	if queue != nil {
+		g.Go(func() error { return queue.Run(ctx) })
+	}
+
+	logger.Info("Waiting for servers to finish")
+
+	return g.Wait()
+}
+
+// setupMail sets up the sender of the registration tokens. The mail is queued on disk and sent through
+// the SMTP server or written to the Maildir. Without either the tokens are logged and no queue is returned.
+func setupMail(logger *slog.Logger, config *config.Config) (app.Sender, *mail.Queue, error) {
+	var transport mail.Sender
+	switch smtpURL, maildir := config.MailSMTPURL(), config.MailMaildir(); {
+	case smtpURL != "":
+		c, err := mail.ParseSMTPURL(smtpURL)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if transport, err = mail.NewSMTPSender(c); err != nil {
+			return nil, nil, err
+		}
+	case maildir != "":
+		d, err := mail.NewMaildir(maildir, time.Now)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open maildir: %w", err)
+		}
+		transport = d
+	default:
+		logger.Warn("No mail server configured, registration tokens are logged")
+		return app.LogSender{Logger: logger}, nil, nil
+	}
+
+	queueDir, err := config.MailQueueDir()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get mail queue dir: %w", err)
+	}
+
+	from, err := config.MailFrom()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get mail from address: %w", err)
+	}
+
+	queue, err := mail.NewQueue(mail.QueueConfig{
+		Logger: logger,
+		Clock:  time.Now,
+		Dir:    queueDir,
+		Sender: transport,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to setup mail queue: %w", err)
+	}
+
+	sender, err := app.NewMailSender(queue, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sender, queue, nil
+}
+
+// setupAccountClient connects to the account service, which creates the accounts.
+func setupAccountClient(config *config.Config) (*grpc.ClientConn, error) {
+	accountServiceAddr, err := config.AccountServiceURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account service address: %w", err)
+	}
+
+	accountServiceCertFile, err := config.AccountServiceTLSCertFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account service cert file: %w", err)
+	}
+
+	creds, err := credentials.NewClientTLSFromFile(accountServiceCertFile, "lingo")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS keys: %w", err)
+	}
+
+	return grpc.NewClient(accountServiceAddr, grpc.WithTransportCredentials(creds))
+}
+
+func setupRelay(logger *slog.Logger, config *config.Config, sender app.Sender, accounts protoaccount.AccountServiceClient) (*app.App, error) {
+	signingKey, err := config.SigningKeyRegisterToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	organizationID, err := config.RegisterOrganizationID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get register organization id: %w", err)
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse register organization id: %w", err)
+	}
+
+	return app.New(app.Config{
+		Logger:         logger,
+		Tokens:         token.NewManager(time.Now, []byte(signingKey), registerTokenExpiry),
+		Sender:         sender,
+		Accounts:       accounts,
+		OrganizationID: orgID,
+	})
+}
+
+// setupServer sets up the grpc server. The relay uses the same certificate as the account service.
+func setupServer(config *config.Config, serviceRegistrar func(grpc.ServiceRegistrar)) (*grpcserver.Server, error) {
+	grpcPort, err := config.GRPCPort()
+	if err != nil {
+		return nil, err
+	}
+
+	certFile, err := config.AccountServiceTLSCertFile()
+	if err != nil {
+		return nil, err
+	}
+
+	keyFile, err := config.AccountServiceTLSKeyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS keys: %w", err)
+	}
+
+	return grpcserver.New(
+		grpcserver.WithReflection(),
+		grpcserver.WithGrpcServer(grpc.NewServer(grpc.Creds(creds))),
+		grpcserver.WithAddress(fmt.Sprintf(":%d", grpcPort)),
+		grpcserver.WithServiceRegistrar(serviceRegistrar),
+	), nil
+}
+
+func NewGrpcCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "relay",
+		Short: "Start the relay grpc service",
+		RunE:  runRelay,
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/extreme-business/lingo/apps/relay/app"
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	"github.com/extreme-business/lingo/pkg/validate"
+	protorelay "github.com/extreme-business/lingo/proto/gen/go/private/relay/v1"
+)
+
+type Server struct {
+	protorelay.UnimplementedRelayServiceServer
+	relay *app.App
+}
+
+func New(relay *app.App) *Server {
+	return &Server{
+		relay: relay,
+	}
+}
+
+func (s *Server) CreateRegisterToken(ctx context.Context, req *protorelay.CreateRegisterTokenRequest) (*protorelay.CreateRegisterTokenResponse, error) {
+	if err := s.relay.CreateRegisterToken(ctx, req.GetEmail(), req.GetLanguage()); err != nil {
+		return nil, relayError(err)
+	}
+
+	return &protorelay.CreateRegisterTokenResponse{}, nil
+}
+
+func (s *Server) CreateAccount(ctx context.Context, req *protorelay.CreateAccountRequest) (*protorelay.CreateAccountResponse, error) {
+	if req.GetToken() == "" {
+		return nil, grpcerrors.NewFieldViolationErr("token", []grpcerrors.FieldViolation{
+			{
+				Field:       "token",
+				Description: "token is required",
+			},
+		})
+	}
+
+	user, err := s.relay.CreateAccount(ctx, app.CreateAccount{
+		Token:       req.GetToken(),
+		DisplayName: req.GetDisplayName(),
+		Password:    req.GetPassword(),
+	})
+	if err != nil {
+		return nil, relayError(err)
+	}
+
+	return &protorelay.CreateAccountResponse{User: user}, nil
+}
+
+// relayError converts the errors of the relay app to grpc errors.
+// Errors of the account service are already grpc errors, and are returned as they are.
+func relayError(err error) error {
+	var vErr *validate.Error
+	switch {
+	case errors.As(err, &vErr):
+		return grpcerrors.NewFieldViolationErr("validation error", []grpcerrors.FieldViolation{
+			{
+				Field:       vErr.Field(),
+				Description: vErr.Error(),
+			},
+		})
+	case errors.Is(err, app.ErrInvalidToken):
+		return grpcerrors.NewFieldViolationErr("invalid token", []grpcerrors.FieldViolation{
+			{
+				Field:       "token",
+				Description: "token is invalid or expired",
+			},
+		})
+	default:
+		return err
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	accountcmd "github.com/extreme-business/lingo/apps/account/cmd"
 	cmscmd "github.com/extreme-business/lingo/apps/cms/cmd"
+	relaycmd "github.com/extreme-business/lingo/apps/relay/cmd"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,7 @@ func init() {
 	serveCmd.AddCommand(accountcmd.NewGrpcCmd())
 	serveCmd.AddCommand(accountcmd.NewGatewayCmd())
 	serveCmd.AddCommand(cmscmd.NewHTMLCmd())
+	serveCmd.AddCommand(relaycmd.NewGrpcCmd())
 
 	rootCmd.AddCommand(serveCmd)
 }
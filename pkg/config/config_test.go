@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/extreme-business/lingo/pkg/config"
 	"github.com/spf13/viper"
@@ -105,6 +106,36 @@ func TestConfig_SigningKeyRefreshToken(t *testing.T) {
 	})
 }
 
+func TestConfig_SigningKeyRegisterToken(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return error if LINGO_SIGNING_KEY_REGISTER_TOKEN is not set", func(t *testing.T) {
+		t.Setenv("LINGO_SIGNING_KEY_REGISTER_TOKEN", "")
+		v, err := config.New().SigningKeyRegisterToken()
+		if err == nil {
+			t.Errorf("SigningKeyRegisterToken() error = %v, wantErr %v", err, true)
+		}
+
+		if v != "" {
+			t.Errorf("SigningKeyRegisterToken() = %v, want %v", v, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_SIGNING_KEY_REGISTER_TOKEN", func(t *testing.T) {
+		t.Setenv("LINGO_SIGNING_KEY_REGISTER_TOKEN", "test")
+		c := config.New()
+		got, err := c.SigningKeyRegisterToken()
+		if err != nil {
+			t.Errorf("SigningKeyRegisterToken() error = %v, wantErr %v", err, nil)
+		}
+		if got != "test" {
+			t.Errorf("SigningKeyRegisterToken() = %v, want %v", got, "test")
+		}
+	})
+}
+
 func TestConfig_HTTPPort(t *testing.T) {
 	t.Cleanup(func() {
 		viper.Reset()
@@ -453,3 +484,339 @@ func TestConfig_SystemOrganizationLegalName(t *testing.T) {
 		}
 	})
 }
+
+func TestConfig_RegisterOrganizationID(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return error if LINGO_REGISTER_ORGANIZATION_ID is not set", func(t *testing.T) {
+		t.Setenv("LINGO_REGISTER_ORGANIZATION_ID", "")
+		v, err := config.New().RegisterOrganizationID()
+		if err == nil {
+			t.Errorf("RegisterOrganizationID() error = %v, wantErr %v", err, true)
+		}
+
+		if v != "" {
+			t.Errorf("RegisterOrganizationID() = %v, want %v", v, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_REGISTER_ORGANIZATION_ID", func(t *testing.T) {
+		t.Setenv("LINGO_REGISTER_ORGANIZATION_ID", "test")
+		c := config.New()
+		got, err := c.RegisterOrganizationID()
+		if err != nil {
+			t.Errorf("RegisterOrganizationID() error = %v, wantErr %v", err, nil)
+		}
+		if got != "test" {
+			t.Errorf("RegisterOrganizationID() = %v, want %v", got, "test")
+		}
+	})
+}
+
+func TestConfig_RegisterOrganizationLegalName(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return error if LINGO_REGISTER_ORGANIZATION_LEGAL_NAME is not set", func(t *testing.T) {
+		t.Setenv("LINGO_REGISTER_ORGANIZATION_LEGAL_NAME", "")
+		v, err := config.New().RegisterOrganizationLegalName()
+		if err == nil {
+			t.Errorf("RegisterOrganizationLegalName() error = %v, wantErr %v", err, true)
+		}
+
+		if v != "" {
+			t.Errorf("RegisterOrganizationLegalName() = %v, want %v", v, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_REGISTER_ORGANIZATION_LEGAL_NAME", func(t *testing.T) {
+		t.Setenv("LINGO_REGISTER_ORGANIZATION_LEGAL_NAME", "test")
+		c := config.New()
+		got, err := c.RegisterOrganizationLegalName()
+		if err != nil {
+			t.Errorf("RegisterOrganizationLegalName() error = %v, wantErr %v", err, nil)
+		}
+		if got != "test" {
+			t.Errorf("RegisterOrganizationLegalName() = %v, want %v", got, "test")
+		}
+	})
+}
+
+func TestConfig_RegisterOrganizationSlug(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return error if LINGO_REGISTER_ORGANIZATION_SLUG is not set", func(t *testing.T) {
+		t.Setenv("LINGO_REGISTER_ORGANIZATION_SLUG", "")
+		v, err := config.New().RegisterOrganizationSlug()
+		if err == nil {
+			t.Errorf("RegisterOrganizationSlug() error = %v, wantErr %v", err, true)
+		}
+
+		if v != "" {
+			t.Errorf("RegisterOrganizationSlug() = %v, want %v", v, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_REGISTER_ORGANIZATION_SLUG", func(t *testing.T) {
+		t.Setenv("LINGO_REGISTER_ORGANIZATION_SLUG", "test")
+		c := config.New()
+		got, err := c.RegisterOrganizationSlug()
+		if err != nil {
+			t.Errorf("RegisterOrganizationSlug() error = %v, wantErr %v", err, nil)
+		}
+		if got != "test" {
+			t.Errorf("RegisterOrganizationSlug() = %v, want %v", got, "test")
+		}
+	})
+}
+
+func TestConfig_DatabaseReplicaURLs(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return no urls if LINGO_DB_REPLICA_URLS is not set", func(t *testing.T) {
+		t.Setenv("LINGO_DB_REPLICA_URLS", "")
+		if got := config.New().DatabaseReplicaURLs(); len(got) != 0 {
+			t.Errorf("DatabaseReplicaURLs() = %v, want none", got)
+		}
+	})
+
+	t.Run("should return the urls of LINGO_DB_REPLICA_URLS", func(t *testing.T) {
+		t.Setenv("LINGO_DB_REPLICA_URLS", "postgres://replica-1/lingo,postgres://replica-2/lingo")
+		got := config.New().DatabaseReplicaURLs()
+		if len(got) != 2 || got[0] != "postgres://replica-1/lingo" || got[1] != "postgres://replica-2/lingo" {
+			t.Errorf("DatabaseReplicaURLs() = %v, want 2 urls", got)
+		}
+	})
+}
+
+func TestConfig_WebSocketAllowedOrigins(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return no origins if LINGO_WEBSOCKET_ALLOWED_ORIGINS is not set", func(t *testing.T) {
+		t.Setenv("LINGO_WEBSOCKET_ALLOWED_ORIGINS", "")
+		if got := config.New().WebSocketAllowedOrigins(); len(got) != 0 {
+			t.Errorf("WebSocketAllowedOrigins() = %v, want none", got)
+		}
+	})
+
+	t.Run("should return the origins of LINGO_WEBSOCKET_ALLOWED_ORIGINS", func(t *testing.T) {
+		t.Setenv("LINGO_WEBSOCKET_ALLOWED_ORIGINS", "https://a.example.com, https://b.example.com")
+		got := config.New().WebSocketAllowedOrigins()
+		if len(got) != 2 || got[0] != "https://a.example.com" || got[1] != "https://b.example.com" {
+			t.Errorf("WebSocketAllowedOrigins() = %v, want %v", got, []string{"https://a.example.com", "https://b.example.com"})
+		}
+	})
+}
+
+func TestConfig_TrustedProxies(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return no proxies if LINGO_TRUSTED_PROXIES is not set", func(t *testing.T) {
+		t.Setenv("LINGO_TRUSTED_PROXIES", "")
+		if got := config.New().TrustedProxies(); len(got) != 0 {
+			t.Errorf("TrustedProxies() = %v, want none", got)
+		}
+	})
+
+	t.Run("should return the proxies of LINGO_TRUSTED_PROXIES", func(t *testing.T) {
+		t.Setenv("LINGO_TRUSTED_PROXIES", "10.0.0.1, 172.16.0.0/12")
+		got := config.New().TrustedProxies()
+		if len(got) != 2 || got[0] != "10.0.0.1" || got[1] != "172.16.0.0/12" {
+			t.Errorf("TrustedProxies() = %v, want %v", got, []string{"10.0.0.1", "172.16.0.0/12"})
+		}
+	})
+}
+
+func TestConfig_Telemetry(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should not export telemetry if LINGO_TELEMETRY_ENDPOINT is not set", func(t *testing.T) {
+		t.Setenv("LINGO_TELEMETRY_ENDPOINT", "")
+		if got := config.New().TelemetryEndpoint(); got != "" {
+			t.Errorf("TelemetryEndpoint() = %v, want none", got)
+		}
+	})
+
+	t.Run("should return the values of LINGO_TELEMETRY_ENDPOINT and LINGO_TELEMETRY_INSECURE", func(t *testing.T) {
+		t.Setenv("LINGO_TELEMETRY_ENDPOINT", "collector:4317")
+		t.Setenv("LINGO_TELEMETRY_INSECURE", "true")
+		c := config.New()
+		if got := c.TelemetryEndpoint(); got != "collector:4317" {
+			t.Errorf("TelemetryEndpoint() = %v, want %v", got, "collector:4317")
+		}
+		if !c.TelemetryInsecure() {
+			t.Error("TelemetryInsecure() = false, want true")
+		}
+	})
+}
+
+func TestConfig_MailQueueDir(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return error if LINGO_MAIL_QUEUE_DIR is not set", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_QUEUE_DIR", "")
+		v, err := config.New().MailQueueDir()
+		if err == nil {
+			t.Errorf("MailQueueDir() error = %v, wantErr %v", err, true)
+		}
+
+		if v != "" {
+			t.Errorf("MailQueueDir() = %v, want %v", v, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_MAIL_QUEUE_DIR", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_QUEUE_DIR", "test")
+		c := config.New()
+		got, err := c.MailQueueDir()
+		if err != nil {
+			t.Errorf("MailQueueDir() error = %v, wantErr %v", err, nil)
+		}
+		if got != "test" {
+			t.Errorf("MailQueueDir() = %v, want %v", got, "test")
+		}
+	})
+}
+
+func TestConfig_MailFrom(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return error if LINGO_MAIL_FROM is not set", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_FROM", "")
+		v, err := config.New().MailFrom()
+		if err == nil {
+			t.Errorf("MailFrom() error = %v, wantErr %v", err, true)
+		}
+
+		if v != "" {
+			t.Errorf("MailFrom() = %v, want %v", v, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_MAIL_FROM", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_FROM", "test")
+		c := config.New()
+		got, err := c.MailFrom()
+		if err != nil {
+			t.Errorf("MailFrom() error = %v, wantErr %v", err, nil)
+		}
+		if got != "test" {
+			t.Errorf("MailFrom() = %v, want %v", got, "test")
+		}
+	})
+}
+
+func TestConfig_MailSMTPURL(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return nothing if LINGO_MAIL_SMTP_URL is not set", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_SMTP_URL", "")
+		if got := config.New().MailSMTPURL(); got != "" {
+			t.Errorf("MailSMTPURL() = %v, want %v", got, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_MAIL_SMTP_URL", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_SMTP_URL", "test")
+		if got := config.New().MailSMTPURL(); got != "test" {
+			t.Errorf("MailSMTPURL() = %v, want %v", got, "test")
+		}
+	})
+}
+
+func TestConfig_MailMaildir(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return nothing if LINGO_MAIL_MAILDIR is not set", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_MAILDIR", "")
+		if got := config.New().MailMaildir(); got != "" {
+			t.Errorf("MailMaildir() = %v, want %v", got, "")
+		}
+	})
+
+	t.Run("should return the value of LINGO_MAIL_MAILDIR", func(t *testing.T) {
+		t.Setenv("LINGO_MAIL_MAILDIR", "test")
+		if got := config.New().MailMaildir(); got != "test" {
+			t.Errorf("MailMaildir() = %v, want %v", got, "test")
+		}
+	})
+}
+
+func TestConfig_Database(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+
+	t.Run("should return the zero values if the pool settings are not set", func(t *testing.T) {
+		c := config.New()
+		if c.DatabaseDriver() != "" || c.DatabaseMaxOpenConns() != 0 || c.DatabaseConnMaxLifetime() != 0 {
+			t.Error("expected the zero values")
+		}
+	})
+
+	t.Run("should return the pool settings", func(t *testing.T) {
+		t.Setenv("LINGO_DB_DRIVER", "pgx")
+		t.Setenv("LINGO_DB_APPLICATION_NAME", "lingo-account")
+		t.Setenv("LINGO_DB_MAX_OPEN_CONNS", "20")
+		t.Setenv("LINGO_DB_MAX_IDLE_CONNS", "5")
+		t.Setenv("LINGO_DB_CONN_MAX_LIFETIME", "30m")
+		t.Setenv("LINGO_DB_CONN_MAX_IDLE_TIME", "5m")
+		t.Setenv("LINGO_DB_STATEMENT_CACHE", "describe")
+		t.Setenv("LINGO_DB_STATEMENT_CACHE_CAPACITY", "256")
+		t.Setenv("LINGO_DB_INSTRUMENT", "true")
+		t.Setenv("LINGO_DB_SLOW_QUERY_THRESHOLD", "500ms")
+
+		c := config.New()
+		if got := c.DatabaseDriver(); got != "pgx" {
+			t.Errorf("DatabaseDriver() = %v, want %v", got, "pgx")
+		}
+		if got := c.DatabaseApplicationName(); got != "lingo-account" {
+			t.Errorf("DatabaseApplicationName() = %v, want %v", got, "lingo-account")
+		}
+		if got := c.DatabaseMaxOpenConns(); got != 20 {
+			t.Errorf("DatabaseMaxOpenConns() = %v, want %v", got, 20)
+		}
+		if got := c.DatabaseMaxIdleConns(); got != 5 {
+			t.Errorf("DatabaseMaxIdleConns() = %v, want %v", got, 5)
+		}
+		if got := c.DatabaseConnMaxLifetime(); got != 30*time.Minute {
+			t.Errorf("DatabaseConnMaxLifetime() = %v, want %v", got, 30*time.Minute)
+		}
+		if got := c.DatabaseConnMaxIdleTime(); got != 5*time.Minute {
+			t.Errorf("DatabaseConnMaxIdleTime() = %v, want %v", got, 5*time.Minute)
+		}
+		if got := c.DatabaseStatementCache(); got != "describe" {
+			t.Errorf("DatabaseStatementCache() = %v, want %v", got, "describe")
+		}
+		if got := c.DatabaseStatementCacheCapacity(); got != 256 {
+			t.Errorf("DatabaseStatementCacheCapacity() = %v, want %v", got, 256)
+		}
+		if !c.DatabaseInstrument() {
+			t.Error("DatabaseInstrument() = false, want true")
+		}
+		if got := c.DatabaseSlowQueryThreshold(); got != 500*time.Millisecond {
+			t.Errorf("DatabaseSlowQueryThreshold() = %v, want %v", got, 500*time.Millisecond)
+		}
+	})
+}
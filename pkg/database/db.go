@@ -57,7 +57,12 @@ func (d *DBWrapper) Exec(ctx context.Context, query string, args ...interface{})
 
 // Begin starts a new transaction.
 func (d *DBWrapper) Begin(ctx context.Context) (*Tx, error) {
-	return d.handler.BeginTx(ctx, nil)
+	return d.BeginTx(ctx, nil)
+}
+
+// BeginTx starts a new transaction with the given options, such as the isolation level.
+func (d *DBWrapper) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	return d.handler.BeginTx(ctx, opts)
 }
 
 // TXHandler is a database transaction and should comply with *sql.Tx.
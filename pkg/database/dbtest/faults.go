@@ -0,0 +1,323 @@
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database"
+)
+
+// Statements that are recorded and matched for the transaction control of the Faults handler.
+const (
+	Begin    = "BEGIN"
+	Commit   = "COMMIT"
+	Rollback = "ROLLBACK"
+)
+
+// ErrInjected is the error of a fault that was not given one.
+var ErrInjected = errors.New("injected fault")
+
+var (
+	_ database.DBHandler = (*Faults)(nil)
+	_ database.TXHandler = (*faultsTx)(nil)
+)
+
+// Statement is a statement that ran through a Faults handler.
+type Statement struct {
+	Query string
+	Args  []interface{}
+	Err   error // Err is the error the statement returned, injected or not.
+}
+
+// Fault is a scripted failure of the statements that match its pattern.
+type Fault struct {
+	pattern *regexp.Regexp
+	nth     int
+	matches int
+	err     error
+	latency time.Duration
+	cancel  context.CancelFunc
+}
+
+// Nth makes the fault only hit the nth matching statement, counting from 1. Without it every match is hit.
+func (f *Fault) Nth(n int) *Fault {
+	f.nth = n
+	return f
+}
+
+// Return makes the statement fail with err.
+func (f *Fault) Return(err error) *Fault {
+	f.err = err
+	return f
+}
+
+// Fail makes the statement fail with ErrInjected.
+func (f *Fault) Fail() *Fault {
+	return f.Return(ErrInjected)
+}
+
+// Delay adds latency before the statement runs. The delay ends early when the context is canceled.
+func (f *Fault) Delay(d time.Duration) *Fault {
+	f.latency = d
+	return f
+}
+
+// Cancel calls cancel after the delay, before the statement runs. Pass the cancel function of the
+// context of the code under test to cancel it in the middle of its work.
+func (f *Fault) Cancel(cancel context.CancelFunc) *Fault {
+	f.cancel = cancel
+	return f
+}
+
+// hit counts a matching statement and reports whether the fault applies to it.
+func (f *Fault) hit(query string) bool {
+	if !f.pattern.MatchString(query) {
+		return false
+	}
+
+	f.matches++
+	return f.nth == 0 || f.matches == f.nth
+}
+
+// Faults is a DBHandler that injects faults into the statements of another DBHandler, including the
+// statements and the commit and rollback of its transactions, and records every statement.
+//
+// Faults are scripted with On, by a regular expression that is matched against the query. The
+// transaction control is matched as the statements Begin, Commit and Rollback, so
+//
+//	faults.On("^" + dbtest.Rollback + "$").Fail()
+//
+// makes every rollback fail.
+type Faults struct {
+	handler    database.DBHandler
+	mu         sync.Mutex
+	faults     []*Fault
+	statements []Statement
+}
+
+// NewFaults creates a Faults handler that passes the statements on to handler.
+func NewFaults(handler database.DBHandler) *Faults {
+	return &Faults{handler: handler}
+}
+
+// On adds a fault for the statements that match the regular expression pattern. It panics when the
+// pattern does not compile.
+func (f *Faults) On(pattern string) *Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fault := &Fault{pattern: regexp.MustCompile(pattern)}
+	f.faults = append(f.faults, fault)
+	return fault
+}
+
+// Statements returns the statements that ran, in order.
+func (f *Faults) Statements() []Statement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Statement(nil), f.statements...)
+}
+
+// Queries returns the queries of the statements that ran, in order.
+func (f *Faults) Queries() []string {
+	statements := f.Statements()
+	queries := make([]string, len(statements))
+	for i, s := range statements {
+		queries[i] = s.Query
+	}
+	return queries
+}
+
+// QueryContext implements database.DBHandler.
+func (f *Faults) QueryContext(ctx context.Context, query string, args ...interface{}) (*database.Rows, error) {
+	return f.query(ctx, f.handler.QueryContext, query, args)
+}
+
+// QueryRowContext implements database.DBHandler.
+func (f *Faults) QueryRowContext(ctx context.Context, query string, args ...interface{}) *database.Row {
+	return f.queryRow(ctx, f.handler.QueryRowContext, query, args)
+}
+
+// ExecContext implements database.DBHandler.
+func (f *Faults) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.exec(ctx, f.handler.ExecContext, query, args)
+}
+
+// BeginTx implements database.DBHandler.
+func (f *Faults) BeginTx(ctx context.Context, opts *sql.TxOptions) (*database.Tx, error) {
+	var tx *database.Tx
+	err := f.run(ctx, Begin, nil, func(ctx context.Context) (err error) {
+		tx, err = f.handler.BeginTx(ctx, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return database.NewTxWithHandler(&faultsTx{f: f, tx: tx}), nil
+}
+
+func (f *Faults) query(
+	ctx context.Context,
+	next func(context.Context, string, ...interface{}) (*database.Rows, error),
+	query string,
+	args []interface{},
+) (*database.Rows, error) {
+	var rows *database.Rows
+	err := f.run(ctx, query, args, func(ctx context.Context) (err error) {
+		rows, err = next(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (f *Faults) queryRow(
+	ctx context.Context,
+	next func(context.Context, string, ...interface{}) *database.Row,
+	query string,
+	args []interface{},
+) *database.Row {
+	var row *database.Row
+	err := f.run(ctx, query, args, func(ctx context.Context) error {
+		row = next(ctx, query, args...)
+		return nil
+	})
+	if err != nil {
+		return database.NewRow(errRow{err: err})
+	}
+	return row
+}
+
+func (f *Faults) exec(
+	ctx context.Context,
+	next func(context.Context, string, ...interface{}) (sql.Result, error),
+	query string,
+	args []interface{},
+) (sql.Result, error) {
+	var result sql.Result
+	err := f.run(ctx, query, args, func(ctx context.Context) (err error) {
+		result, err = next(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// run applies the faults that hit the statement, runs it unless a fault fails it, and records it.
+func (f *Faults) run(ctx context.Context, query string, args []interface{}, next func(ctx context.Context) error) error {
+	var hits []*Fault
+	f.mu.Lock()
+	for _, fault := range f.faults {
+		if fault.hit(query) {
+			hits = append(hits, fault)
+		}
+	}
+	f.mu.Unlock()
+
+	canceled, err := inject(ctx, hits)
+	if err == nil {
+		err = next(ctx)
+		if err == nil && canceled {
+			// a handler that does not watch the context still fails on the canceled context.
+			err = ctx.Err()
+		}
+	}
+
+	f.mu.Lock()
+	f.statements = append(f.statements, Statement{Query: query, Args: args, Err: err})
+	f.mu.Unlock()
+
+	return err
+}
+
+// inject applies the delays and cancellations of the faults. It reports whether a fault canceled and
+// returns the first error of the faults.
+func inject(ctx context.Context, hits []*Fault) (bool, error) {
+	canceled := false
+	for _, fault := range hits {
+		if fault.latency > 0 {
+			t := time.NewTimer(fault.latency)
+			select {
+			case <-ctx.Done():
+			case <-t.C:
+			}
+			t.Stop()
+		}
+
+		if fault.cancel != nil {
+			fault.cancel()
+			canceled = true
+		}
+	}
+
+	for _, fault := range hits {
+		if fault.err != nil {
+			return canceled, fault.err
+		}
+	}
+
+	return canceled, nil
+}
+
+// faultsTx is the TXHandler of a transaction started by a Faults handler.
+type faultsTx struct {
+	f  *Faults
+	tx *database.Tx
+}
+
+func (t *faultsTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*database.Rows, error) {
+	return t.f.query(ctx, t.tx.Query, query, args)
+}
+
+func (t *faultsTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *database.Row {
+	return t.f.queryRow(ctx, t.tx.QueryRow, query, args)
+}
+
+func (t *faultsTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.f.exec(ctx, t.tx.Exec, query, args)
+}
+
+// Commit implements database.TXHandler. A failed commit leaves the transaction open, so it can
+// still be rolled back.
+func (t *faultsTx) Commit() error {
+	return t.f.run(context.Background(), Commit, nil, func(context.Context) error {
+		return t.tx.Commit()
+	})
+}
+
+// Rollback implements database.TXHandler. A failed rollback still rolls back the wrapped transaction,
+// so it does not leak.
+func (t *faultsTx) Rollback() error {
+	rolledBack := false
+	err := t.f.run(context.Background(), Rollback, nil, func(context.Context) error {
+		rolledBack = true
+		return t.tx.Rollback()
+	})
+	if !rolledBack {
+		_ = t.tx.Rollback()
+	}
+	return err
+}
+
+// errRow is a row of a statement that failed.
+type errRow struct {
+	err error
+}
+
+func (r errRow) Err() error {
+	return r.err
+}
+
+func (r errRow) Scan(...interface{}) error {
+	return r.err
+}
@@ -0,0 +1,141 @@
+package dbtest_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/extreme-business/lingo/pkg/database/mock"
+	"github.com/lib/pq"
+)
+
+// newFaults returns a Faults handler over a database on which every statement succeeds.
+func newFaults() *dbtest.Faults {
+	exec := func(_ context.Context, _ string, _ ...interface{}) (sql.Result, error) {
+		return nil, nil
+	}
+
+	return dbtest.NewFaults(&mock.DBHandler{
+		ExecContextFunc: exec,
+		QueryRowContextFunc: func(_ context.Context, _ string, _ ...interface{}) *database.Row {
+			return database.NewRow(&mock.RowHandler{ScanFunc: func(...interface{}) error { return nil }})
+		},
+		BeginTxFunc: func(_ context.Context, _ *sql.TxOptions) (*database.Tx, error) {
+			return database.NewTxWithHandler(&mock.TxHandler{
+				DBHandler:    mock.DBHandler{ExecContextFunc: exec},
+				CommitFunc:   func() error { return nil },
+				RollbackFunc: func() error { return nil },
+			}), nil
+		},
+	})
+}
+
+func newManager(faults *dbtest.Faults) *database.Manager[database.Conn] {
+	manager := database.NewManager(database.NewDBWithHandler(faults), func(c database.Conn) database.Conn { return c })
+	manager.SetFailingRollbackHandler(func(context.Context, error) {})
+	return manager
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should fail the nth statement that matches", func(t *testing.T) {
+		faults := newFaults()
+		faults.On(`^INSERT INTO users`).Nth(2).Fail()
+
+		db := database.NewDBWithHandler(faults)
+		for range 3 {
+			_, _ = db.Exec(ctx, "INSERT INTO users (id) VALUES ($1)", 1)
+		}
+		_, _ = db.Exec(ctx, "INSERT INTO sessions (id) VALUES ($1)", 1)
+
+		var errs []error
+		for _, s := range faults.Statements() {
+			errs = append(errs, s.Err)
+		}
+
+		if !slices.Equal(errs, []error{nil, dbtest.ErrInjected, nil, nil}) {
+			t.Errorf("unexpected errors %v", errs)
+		}
+	})
+
+	t.Run("should fail a row", func(t *testing.T) {
+		faults := newFaults()
+		faults.On(`^SELECT`).Fail()
+
+		var id int
+		err := database.NewDBWithHandler(faults).QueryRow(ctx, "SELECT id FROM users").Scan(&id)
+		if !errors.Is(err, dbtest.ErrInjected) {
+			t.Errorf("expected ErrInjected, got %v", err)
+		}
+	})
+
+	t.Run("should retry an operation after a serialization failure", func(t *testing.T) {
+		faults := newFaults()
+		faults.On(`^UPDATE`).Nth(1).Return(&pq.Error{Code: "40001"})
+
+		err := newManager(faults).BeginOp(ctx, func(ctx context.Context, c database.Conn) error {
+			_, err := c.Exec(ctx, "UPDATE users SET display_name = $1", "name")
+			return err
+		}, database.WithIsolation(sql.LevelSerializable))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"BEGIN", "UPDATE users SET display_name = $1", "ROLLBACK", "BEGIN", "UPDATE users SET display_name = $1", "COMMIT"}
+		if got := faults.Queries(); !slices.Equal(got, want) {
+			t.Errorf("Queries() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("should report a failing rollback after a failing commit", func(t *testing.T) {
+		faults := newFaults()
+		faults.On(`^COMMIT$`).Fail()
+		rollbackErr := errors.New("connection reset")
+		faults.On(`^ROLLBACK$`).Return(rollbackErr)
+
+		var handled error
+		manager := newManager(faults)
+		manager.SetFailingRollbackHandler(func(_ context.Context, err error) {
+			handled = err
+		})
+
+		err := manager.BeginOp(ctx, func(context.Context, database.Conn) error { return nil })
+		if !errors.Is(err, dbtest.ErrInjected) {
+			t.Errorf("expected ErrInjected, got %v", err)
+		}
+
+		if !errors.Is(handled, rollbackErr) {
+			t.Errorf("expected the failing rollback handler to get the rollback error, got %v", handled)
+		}
+	})
+
+	t.Run("should cancel the context in the middle of an operation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		faults := newFaults()
+		faults.On(`^DELETE`).Delay(time.Millisecond).Cancel(cancel)
+
+		err := newManager(faults).BeginOp(ctx, func(ctx context.Context, c database.Conn) error {
+			if _, err := c.Exec(ctx, "INSERT INTO sessions (id) VALUES ($1)", 1); err != nil {
+				return err
+			}
+			_, err := c.Exec(ctx, "DELETE FROM sessions")
+			return err
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+
+		want := []string{"BEGIN", "INSERT INTO sessions (id) VALUES ($1)", "DELETE FROM sessions", "ROLLBACK"}
+		if got := faults.Queries(); !slices.Equal(got, want) {
+			t.Errorf("Queries() = %v, want %v", got, want)
+		}
+	})
+}
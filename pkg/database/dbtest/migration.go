@@ -4,34 +4,32 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"log/slog"
+	"time"
 
-	"ariga.io/atlas-go-sdk/atlasexec"
+	"github.com/extreme-business/lingo/pkg/database/migrate"
+	dbpostgres "github.com/extreme-business/lingo/pkg/database/postgres"
 )
 
+// Migrate applies the migrations of a directory to the database.
 func Migrate(ctx context.Context, url string, dir fs.FS) error {
-	// Define the execution context, supplying a migration directory
-	// and potentially an `atlas.hcl` configuration file using `atlasexec.WithHCL`.
-	workdir, err := atlasexec.NewWorkingDir(
-		atlasexec.WithMigrations(dir),
-	)
+	db, err := dbpostgres.Connect(ctx, url)
 	if err != nil {
-		return fmt.Errorf("failed to create working directory: %w", err)
+		return err
 	}
+	defer db.Close()
 
-	// atlasexec works on a temporary directory, so we need to close it
-	defer workdir.Close()
-
-	// Initialize the client.
-	client, err := atlasexec.NewClient(workdir.Path(), "atlas")
+	m, err := migrate.New(migrate.Config{
+		Logger: slog.Default(),
+		Clock:  time.Now,
+		DB:     db,
+		FS:     dir,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create client: %w", err)
+		return fmt.Errorf("failed to create migrator: %w", err)
 	}
 
-	// Run `atlas migrate apply`
-	_, err = client.MigrateApply(ctx, &atlasexec.MigrateApplyParams{
-		URL: url,
-	})
-	if err != nil {
+	if _, err = m.Up(ctx); err != nil {
 		return fmt.Errorf("failed to apply migrations: %w", err)
 	}
 
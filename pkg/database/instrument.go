@@ -0,0 +1,284 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultSlowQueryThreshold is the duration after which a query is logged as slow.
+	DefaultSlowQueryThreshold = 200 * time.Millisecond
+	// instrumentationName is the name of the tracer and meter of the instrumented handlers.
+	instrumentationName = "github.com/extreme-business/lingo/pkg/database"
+)
+
+var (
+	_ DBHandler = (*Instrumented)(nil) // Ensure *Instrumented complies with the DBHandler interface.
+	_ TXHandler = (*instrumentedTx)(nil)
+)
+
+// Instrumented is a DBHandler that observes the queries of another DBHandler, including the queries of
+// its transactions. For every query it records a trace span and the latency and errors as metrics, and it
+// logs the queries that take longer than the slow query threshold.
+//
+// Queries are named after their operation and table, such as "SELECT users", unless the query starts
+// with a "-- name: CreateUser" comment. Spans and logs contain the SQL with its literals replaced and
+// the number of arguments, never the values of the arguments.
+type Instrumented struct {
+	logger        *slog.Logger
+	handler       DBHandler
+	tracer        trace.Tracer
+	slowThreshold time.Duration
+	duration      metric.Float64Histogram
+	errors        metric.Int64Counter
+}
+
+type InstrumentedConfig struct {
+	Logger        *slog.Logger
+	Handler       DBHandler
+	Tracer        trace.Tracer  // Tracer defaults to the tracer of the global trace provider.
+	Meter         metric.Meter  // Meter defaults to the meter of the global meter provider.
+	SlowThreshold time.Duration // SlowThreshold defaults to DefaultSlowQueryThreshold.
+}
+
+func (c InstrumentedConfig) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Handler == nil {
+		return errors.New("handler is required")
+	}
+
+	return nil
+}
+
+func NewInstrumented(c InstrumentedConfig) (*Instrumented, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	if c.Tracer == nil {
+		c.Tracer = otel.Tracer(instrumentationName)
+	}
+
+	if c.Meter == nil {
+		c.Meter = otel.Meter(instrumentationName)
+	}
+
+	if c.SlowThreshold <= 0 {
+		c.SlowThreshold = DefaultSlowQueryThreshold
+	}
+
+	duration, err := c.Meter.Float64Histogram(
+		"db.client.query.duration",
+		metric.WithDescription("Duration of database queries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create duration histogram: %w", err)
+	}
+
+	errs, err := c.Meter.Int64Counter(
+		"db.client.query.errors",
+		metric.WithDescription("Number of database queries that failed."),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create error counter: %w", err)
+	}
+
+	return &Instrumented{
+		logger:        c.Logger,
+		handler:       c.Handler,
+		tracer:        c.Tracer,
+		slowThreshold: c.SlowThreshold,
+		duration:      duration,
+		errors:        errs,
+	}, nil
+}
+
+// QueryContext implements DBHandler.
+func (i *Instrumented) QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	ctx, done := i.observe(ctx, query, len(args))
+	rows, err := i.handler.QueryContext(ctx, query, args...)
+	done(err)
+	return rows, err
+}
+
+// QueryRowContext implements DBHandler.
+func (i *Instrumented) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	ctx, done := i.observe(ctx, query, len(args))
+	row := i.handler.QueryRowContext(ctx, query, args...)
+	done(rowErr(row))
+	return row
+}
+
+// ExecContext implements DBHandler.
+func (i *Instrumented) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := i.observe(ctx, query, len(args))
+	result, err := i.handler.ExecContext(ctx, query, args...)
+	done(err)
+	return result, err
+}
+
+// BeginTx implements DBHandler. The queries of the transaction are instrumented as well.
+func (i *Instrumented) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := i.handler.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTxWithHandler(&instrumentedTx{i: i, tx: tx}), nil
+}
+
+// observe starts the observation of a query. The returned context carries the span of the query, the
+// returned function ends the observation with the error of the query.
+func (i *Instrumented) observe(ctx context.Context, query string, argCount int) (context.Context, func(error)) {
+	name, operation := queryName(query)
+	statement := sanitizeQuery(query)
+
+	ctx, span := i.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", statement),
+			attribute.Int("db.args.count", argCount),
+		),
+	)
+
+	start := time.Now()
+	return ctx, func(err error) {
+		elapsed := time.Since(start)
+		attrs := metric.WithAttributes(attribute.String("db.query.name", name))
+
+		i.duration.Record(ctx, elapsed.Seconds(), attrs)
+		if err != nil {
+			i.errors.Add(ctx, 1, attrs)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		if elapsed >= i.slowThreshold {
+			i.logger.WarnContext(ctx, "slow query",
+				slog.String("name", name),
+				slog.String("statement", statement),
+				slog.Int("args", argCount),
+				slog.Duration("duration", elapsed),
+			)
+		}
+	}
+}
+
+// rowErr returns the error of the query of a row. A row without results is not an error.
+func rowErr(row *Row) error {
+	if row == nil {
+		return nil
+	}
+
+	if err := row.Err(); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return nil
+}
+
+// instrumentedTx is a TXHandler that observes the queries of a transaction.
+type instrumentedTx struct {
+	i  *Instrumented
+	tx *Tx
+}
+
+func (t *instrumentedTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	ctx, done := t.i.observe(ctx, query, len(args))
+	rows, err := t.tx.Query(ctx, query, args...)
+	done(err)
+	return rows, err
+}
+
+func (t *instrumentedTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	ctx, done := t.i.observe(ctx, query, len(args))
+	row := t.tx.QueryRow(ctx, query, args...)
+	done(rowErr(row))
+	return row
+}
+
+func (t *instrumentedTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := t.i.observe(ctx, query, len(args))
+	result, err := t.tx.Exec(ctx, query, args...)
+	done(err)
+	return result, err
+}
+
+func (t *instrumentedTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *instrumentedTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+var (
+	nameComment = regexp.MustCompile(`^--\s*name:\s*(\S+)`)
+	// tableOf matches the table a query operates on, after the keyword that precedes it.
+	tableOf = map[string]*regexp.Regexp{
+		"SELECT": regexp.MustCompile(`(?i)\bFROM\s+([\w.]+)`),
+		"INSERT": regexp.MustCompile(`(?i)\bINTO\s+([\w.]+)`),
+		"UPDATE": regexp.MustCompile(`(?i)^UPDATE\s+([\w.]+)`),
+		"DELETE": regexp.MustCompile(`(?i)\bFROM\s+([\w.]+)`),
+	}
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`\$?\b\d+(?:\.\d+)?\b`)
+	whitespace     = regexp.MustCompile(`\s+`)
+)
+
+// queryName returns the name and the operation of a query.
+func queryName(query string) (name, operation string) {
+	q := strings.TrimSpace(query)
+	if m := nameComment.FindStringSubmatch(q); m != nil {
+		name = m[1]
+		q = strings.TrimSpace(q[len(m[0]):])
+	}
+
+	if fields := strings.Fields(q); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	if name != "" {
+		return name, operation
+	}
+
+	if re, ok := tableOf[operation]; ok {
+		if m := re.FindStringSubmatch(q); m != nil {
+			return operation + " " + strings.ToLower(m[1]), operation
+		}
+	}
+
+	return operation, operation
+}
+
+// sanitizeQuery replaces the literals of a query with ? and collapses its whitespace, so the query can
+// be logged without values.
+func sanitizeQuery(query string) string {
+	q := nameComment.ReplaceAllString(strings.TrimSpace(query), "")
+	q = stringLiteral.ReplaceAllString(q, "?")
+	q = numericLiteral.ReplaceAllStringFunc(q, func(s string) string {
+		if strings.HasPrefix(s, "$") { // a placeholder
+			return s
+		}
+		return "?"
+	})
+	return strings.TrimSpace(whitespace.ReplaceAllString(q, " "))
+}
@@ -0,0 +1,154 @@
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// spanRecorder is a tracer that records the names and attributes of the spans it starts.
+type spanRecorder struct {
+	noop.Tracer
+	names []string
+	attrs []map[attribute.Key]attribute.Value
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	attrs := map[attribute.Key]attribute.Value{}
+	config := trace.NewSpanStartConfig(opts...)
+	for _, kv := range config.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	r.names = append(r.names, name)
+	r.attrs = append(r.attrs, attrs)
+	return r.Tracer.Start(ctx, name, opts...)
+}
+
+func newInstrumented(t *testing.T, handler database.DBHandler, slow time.Duration) (*database.Instrumented, *spanRecorder, *bytes.Buffer) {
+	t.Helper()
+	tracer := &spanRecorder{}
+	var logs bytes.Buffer
+	i, err := database.NewInstrumented(database.InstrumentedConfig{
+		Logger:        slog.New(slog.NewTextHandler(&logs, nil)),
+		Handler:       handler,
+		Tracer:        tracer,
+		SlowThreshold: slow,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i, tracer, &logs
+}
+
+func TestInstrumented(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should name the queries and sanitize the statements", func(t *testing.T) {
+		i, tracer, _ := newInstrumented(t, &mock.DBHandler{
+			ExecContextFunc: func(_ context.Context, _ string, _ ...interface{}) (sql.Result, error) {
+				return nil, nil
+			},
+			QueryContextFunc: func(_ context.Context, _ string, _ ...interface{}) (*database.Rows, error) {
+				return nil, nil
+			},
+		}, time.Hour)
+
+		_, _ = i.QueryContext(ctx, "SELECT id\n  FROM users WHERE email = $1 AND status = 'active' LIMIT 10", "a@example.com")
+		_, _ = i.ExecContext(ctx, "-- name: ExpireSessions\nDELETE FROM sessions WHERE expire_time < $1", time.Now())
+		_, _ = i.ExecContext(ctx, "UPDATE users SET display_name = $1 WHERE id = $2", "name", 1)
+
+		if !slices.Equal(tracer.names, []string{"SELECT users", "ExpireSessions", "UPDATE users"}) {
+			t.Errorf("unexpected span names %v", tracer.names)
+		}
+
+		want := "SELECT id FROM users WHERE email = $1 AND status = ? LIMIT ?"
+		if got := tracer.attrs[0]["db.statement"].AsString(); got != want {
+			t.Errorf("db.statement = %q, want %q", got, want)
+		}
+
+		if got := tracer.attrs[1]["db.statement"].AsString(); got != "DELETE FROM sessions WHERE expire_time < $1" {
+			t.Errorf("unexpected db.statement %q", got)
+		}
+
+		if got := tracer.attrs[2]["db.args.count"].AsInt64(); got != 2 {
+			t.Errorf("db.args.count = %d, want 2", got)
+		}
+	})
+
+	t.Run("should log slow queries without the values of the arguments", func(t *testing.T) {
+		i, _, logs := newInstrumented(t, &mock.DBHandler{
+			QueryRowContextFunc: func(_ context.Context, _ string, _ ...interface{}) *database.Row {
+				time.Sleep(time.Millisecond)
+				return database.NewRow(&mock.RowHandler{ErrFunc: func() error { return nil }})
+			},
+		}, time.Nanosecond)
+
+		_ = i.QueryRowContext(ctx, "SELECT id FROM users WHERE email = $1", "secret@example.com")
+
+		out := logs.String()
+		if !strings.Contains(out, "slow query") || !strings.Contains(out, `name="SELECT users"`) {
+			t.Errorf("expected a slow query entry, got %q", out)
+		}
+
+		if strings.Contains(out, "secret@example.com") {
+			t.Errorf("expected the arguments to be left out, got %q", out)
+		}
+	})
+
+	t.Run("should not log fast queries", func(t *testing.T) {
+		i, _, logs := newInstrumented(t, &mock.DBHandler{
+			ExecContextFunc: func(_ context.Context, _ string, _ ...interface{}) (sql.Result, error) {
+				return nil, errors.New("connection refused")
+			},
+		}, time.Hour)
+
+		if _, err := i.ExecContext(ctx, "DELETE FROM sessions"); err == nil {
+			t.Error("expected the error of the handler")
+		}
+
+		if logs.Len() != 0 {
+			t.Errorf("expected no log entries, got %q", logs.String())
+		}
+	})
+
+	t.Run("should instrument the queries of transactions", func(t *testing.T) {
+		i, tracer, _ := newInstrumented(t, &mock.DBHandler{
+			BeginTxFunc: func(_ context.Context, _ *sql.TxOptions) (*database.Tx, error) {
+				return database.NewTxWithHandler(&mock.TxHandler{
+					DBHandler: mock.DBHandler{
+						ExecContextFunc: func(_ context.Context, _ string, _ ...interface{}) (sql.Result, error) {
+							return nil, nil
+						},
+					},
+					CommitFunc: func() error { return nil },
+				}), nil
+			},
+		}, time.Hour)
+
+		manager := database.NewManager(database.NewDBWithHandler(i), func(c database.Conn) database.Conn { return c })
+		err := manager.BeginOp(ctx, func(ctx context.Context, c database.Conn) error {
+			_, err := c.Exec(ctx, "INSERT INTO users (id) VALUES ($1)", 1)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(tracer.names, []string{"INSERT users"}) {
+			t.Errorf("unexpected span names %v", tracer.names)
+		}
+	})
+}
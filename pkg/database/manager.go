@@ -4,7 +4,28 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	// retryBackoffBase is the longest wait before the first retry, it doubles with every retry after that.
+	retryBackoffBase = 10 * time.Millisecond
+	// retryBackoffMax is the longest wait before a retry.
+	retryBackoffMax = time.Second
+)
+
+// Postgres error codes of failures that are resolved by running the transaction again.
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
 )
 
 var (
@@ -27,10 +48,90 @@ type Factory[T any] func(c Conn) T
 type Manager[T any] struct {
 	// db is the database connection.
 	db *DBWrapper
+	// replicas receive the reads of Op, it is nil without read replicas.
+	replicas *Replicas
 	// factory is a function that initializes an operation with a database connection.
 	factory Factory[T]
 	// failingRollbackHandler is a function that is called when a transaction failed to roll back.
 	failingRollbackHandler func(ctx context.Context, err error)
+	// retries counts the operations that were run again, exhausted the operations that failed every attempt.
+	retries, exhausted atomic.Int64
+	// retryCounter and exhaustedCounter export the counters, see Instrument.
+	retryCounter, exhaustedCounter metric.Int64Counter
+}
+
+// Stats are the counters of a Manager.
+type Stats struct {
+	// Retries is the number of times an operation was run again after a serialization failure or deadlock.
+	Retries int64
+	// Exhausted is the number of operations that failed on a serialization failure or deadlock in every attempt.
+	Exhausted int64
+}
+
+// opKey is the context key of the active operation.
+type opKey struct{}
+
+// opState is the state of an operation started with BeginOp, it is shared with the operations nested in it.
+type opState struct {
+	db          *DBWrapper
+	tx          *Tx
+	savepoints  int
+	afterCommit []func(ctx context.Context)
+}
+
+// AfterCommit registers f to be called after the transaction of the operation in the context commits,
+// for example to publish an event about the change only once it is visible. f is not called when the
+// transaction, or the nested operation f was registered in, rolls back. Without an operation in the
+// context f is called right away.
+func AfterCommit(ctx context.Context, f func(ctx context.Context)) {
+	s, ok := ctx.Value(opKey{}).(*opState)
+	if !ok {
+		f(ctx)
+		return
+	}
+
+	s.afterCommit = append(s.afterCommit, f)
+}
+
+// Hooks holds the functions registered with AfterCommit for a transaction that is not run by a
+// Manager, such as one of an in-memory storage.
+type Hooks struct {
+	s opState
+}
+
+// WithHooks returns a context in which AfterCommit registers the functions with h.
+func WithHooks(ctx context.Context, h *Hooks) context.Context {
+	return context.WithValue(ctx, opKey{}, &h.s)
+}
+
+// Len returns the number of registered functions.
+func (h *Hooks) Len() int {
+	return len(h.s.afterCommit)
+}
+
+// Truncate drops the functions registered after the first n, for example those of a nested
+// operation that rolled back.
+func (h *Hooks) Truncate(n int) {
+	h.s.afterCommit = h.s.afterCommit[:n]
+}
+
+// Run calls the registered functions, after the transaction committed.
+func (h *Hooks) Run(ctx context.Context) {
+	for _, f := range h.s.afterCommit {
+		f(ctx)
+	}
+}
+
+// IsRetryable reports whether the error is a Postgres serialization failure or deadlock, which are
+// resolved by running the transaction again.
+func IsRetryable(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	code := pgErr.SQLState()
+	return code == sqlStateSerializationFailure || code == sqlStateDeadlockDetected
 }
 
 // NewManager creates a new Manager instance, initializing it with a database connection,
@@ -42,6 +143,8 @@ func NewManager[T any](db *DBWrapper, f Factory[T]) *Manager[T] {
 		failingRollbackHandler: func(_ context.Context, err error) {
 			log.Printf("failed to rollback transaction: %v", err)
 		},
+		retryCounter:     noop.Int64Counter{},
+		exhaustedCounter: noop.Int64Counter{},
 	}
 }
 
@@ -50,13 +153,64 @@ func (m *Manager[T]) SetFailingRollbackHandler(handler func(ctx context.Context,
 	m.failingRollbackHandler = handler
 }
 
+// Stats returns the counters of the manager.
+func (m *Manager[T]) Stats() Stats {
+	return Stats{
+		Retries:   m.retries.Load(),
+		Exhausted: m.exhausted.Load(),
+	}
+}
+
+// Instrument records the counters of Stats as metrics with the meter, which defaults to the meter of the
+// global meter provider. Managers instrumented with the same meter add to the same metrics.
+func (m *Manager[T]) Instrument(meter metric.Meter) error {
+	if meter == nil {
+		meter = otel.Meter(instrumentationName)
+	}
+
+	retries, err := meter.Int64Counter(
+		"db.client.operation.retries",
+		metric.WithDescription("Number of times an operation was run again after a serialization failure or deadlock."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create retry counter: %w", err)
+	}
+
+	exhausted, err := meter.Int64Counter(
+		"db.client.operation.exhausted",
+		metric.WithDescription("Number of operations that failed on a serialization failure or deadlock in every attempt."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create exhausted counter: %w", err)
+	}
+
+	m.retryCounter, m.exhaustedCounter = retries, exhausted
+	return nil
+}
+
+// SetReplicas sends the reads of Op to read replicas. Operations started with BeginOp always use the primary.
+func (m *Manager[T]) SetReplicas(r *Replicas) {
+	m.replicas = r
+}
+
 // Op initializes a new operation with the database connection.
 func (m *Manager[T]) Op() T {
+	if m.replicas != nil {
+		return m.factory(m.replicas)
+	}
 	return m.factory(m.db)
 }
 
 // BeginOp starts a new operation with a transaction and commits the transaction if all operations succeed; it rolls back the transaction otherwise.
-func (m *Manager[T]) BeginOp(ctx context.Context, operation func(ctx context.Context, r T) error) error {
+//
+// An operation with the serializable or repeatable read isolation level is run again, in a new transaction,
+// when it fails on a serialization failure or deadlock, up to MaxAttempts times and while the context
+// is not done. The operation must not have side effects outside the transaction, use AfterCommit for those.
+//
+// An operation started inside another operation on the same database is nested: it runs in a savepoint
+// of the outer transaction, so its failure only rolls back its own changes. The transaction options of a
+// nested operation are ignored.
+func (m *Manager[T]) BeginOp(ctx context.Context, operation func(ctx context.Context, r T) error, opts ...OpOption) error {
 	if operation == nil {
 		return errors.New("no operation provided")
 	}
@@ -65,7 +219,82 @@ func (m *Manager[T]) BeginOp(ctx context.Context, operation func(ctx context.Con
 		return errors.New("no factory provided")
 	}
 
-	tx, err := m.db.Begin(ctx)
+	var o opOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if s, ok := ctx.Value(opKey{}).(*opState); ok && s.db == m.db {
+		return m.nestedOp(ctx, s, operation)
+	}
+
+	txOpts := &sql.TxOptions{Isolation: o.isolation}
+	for attempt := 1; ; attempt++ {
+		err := m.beginOp(ctx, operation, txOpts)
+		if err == nil || !o.retryable() || !IsRetryable(err) {
+			return err
+		}
+
+		if attempt >= MaxAttempts {
+			m.exhaust(ctx)
+			return err
+		}
+
+		t := time.NewTimer(retryBackoff(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			m.exhaust(ctx)
+			return err
+		case <-t.C:
+		}
+
+		m.retries.Add(1)
+		m.retryCounter.Add(ctx, 1)
+	}
+}
+
+// exhaust counts an operation that failed every attempt.
+func (m *Manager[T]) exhaust(ctx context.Context) {
+	m.exhausted.Add(1)
+	// the context may be done, the metric is recorded regardless.
+	m.exhaustedCounter.Add(context.WithoutCancel(ctx), 1)
+}
+
+// retryBackoff returns a random wait before retrying an operation, up to a limit that grows with every attempt.
+func retryBackoff(attempt int) time.Duration {
+	d := retryBackoffBase
+	for i := 1; i < attempt && d < retryBackoffMax; i++ {
+		d *= 2
+	}
+	return rand.N(min(d, retryBackoffMax)) //nolint:gosec // jitter does not need a secure random number
+}
+
+// nestedOp runs the operation in a savepoint of the transaction of an outer operation.
+func (m *Manager[T]) nestedOp(ctx context.Context, s *opState, operation func(ctx context.Context, r T) error) error {
+	s.savepoints++
+	savepoint := fmt.Sprintf("op_%d", s.savepoints)
+	if _, err := s.tx.Exec(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return err
+	}
+
+	hooks := len(s.afterCommit)
+	if err := operation(ctx, m.factory(s.tx)); err != nil {
+		if _, rErr := s.tx.Exec(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rErr != nil {
+			return errors.Join(err, rErr)
+		}
+		// the changes of the operation are undone, so are its hooks.
+		s.afterCommit = s.afterCommit[:hooks]
+		return err
+	}
+
+	_, err := s.tx.Exec(ctx, "RELEASE SAVEPOINT "+savepoint)
+	return err
+}
+
+// beginOp runs the operation in a single transaction.
+func (m *Manager[T]) beginOp(ctx context.Context, operation func(ctx context.Context, r T) error, txOpts *sql.TxOptions) (err error) {
+	tx, err := m.db.BeginTx(ctx, txOpts)
 	if err != nil {
 		return err
 	}
@@ -92,7 +321,9 @@ func (m *Manager[T]) BeginOp(ctx context.Context, operation func(ctx context.Con
 	r := m.factory(tx)
 
 	// Perform the passed operation; if it fails, return the error to trigger a rollback.
-	if err = operation(ctx, r); err != nil {
+	// Operations started with the context of the operation are nested in it.
+	s := &opState{db: m.db, tx: tx}
+	if err = operation(context.WithValue(ctx, opKey{}, s), r); err != nil {
 		return err
 	}
 
@@ -102,5 +333,11 @@ func (m *Manager[T]) BeginOp(ctx context.Context, operation func(ctx context.Con
 		return err
 	}
 
+	markWrite(ctx)
+
+	for _, f := range s.afterCommit {
+		f(ctx)
+	}
+
 	return nil
 }
@@ -4,11 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/extreme-business/lingo/pkg/database"
 	"github.com/extreme-business/lingo/pkg/database/dbtest"
 	"github.com/extreme-business/lingo/pkg/database/mock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 // TestNewManager tests the NewManager function for proper initialization.
@@ -270,3 +277,307 @@ func TestBeginOp(t *testing.T) {
 		}
 	})
 }
+
+// newRetryManager returns a manager whose transactions commit, and the options the transactions were started with.
+func newRetryManager(t *testing.T) (*database.Manager[int], *[]*sql.TxOptions) {
+	t.Helper()
+	var opts []*sql.TxOptions
+	db := database.NewDBWithHandler(&mock.DBHandler{
+		BeginTxFunc: func(_ context.Context, o *sql.TxOptions) (*database.Tx, error) {
+			opts = append(opts, o)
+			return database.NewTxWithHandler(&mock.TxHandler{
+				CommitFunc:   func() error { return nil },
+				RollbackFunc: func() error { return nil },
+			}), nil
+		},
+	})
+	return database.NewManager(db, func(_ database.Conn) int { return 0 }), &opts
+}
+
+// countingMeter is a meter whose counters add to counts by name.
+type countingMeter struct {
+	noop.Meter
+	counts map[string]int64
+}
+
+func (m *countingMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return countingCounter{name: name, counts: m.counts}, nil
+}
+
+type countingCounter struct {
+	noop.Int64Counter
+	name   string
+	counts map[string]int64
+}
+
+func (c countingCounter) Add(_ context.Context, n int64, _ ...metric.AddOption) {
+	c.counts[c.name] += n
+}
+
+func TestBeginOp_retry(t *testing.T) {
+	serializationFailure := &pq.Error{Code: "40001"}
+
+	t.Run("should start the transaction with the options", func(t *testing.T) {
+		manager, opts := newRetryManager(t)
+
+		if err := manager.BeginOp(context.Background(), func(_ context.Context, _ int) error { return nil },
+			database.WithIsolation(sql.LevelRepeatableRead)); err != nil {
+			t.Fatal(err)
+		}
+
+		if o := (*opts)[0]; o.Isolation != sql.LevelRepeatableRead {
+			t.Errorf("unexpected transaction options %+v", o)
+		}
+	})
+
+	t.Run("should retry a serializable operation on a serialization failure", func(t *testing.T) {
+		manager, opts := newRetryManager(t)
+
+		attempts := 0
+		err := manager.BeginOp(context.Background(), func(_ context.Context, _ int) error {
+			attempts++
+			if attempts < 3 {
+				return fmt.Errorf("failed to update: %w", serializationFailure)
+			}
+			return nil
+		}, database.WithIsolation(sql.LevelSerializable))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if attempts != 3 || len(*opts) != 3 {
+			t.Errorf("expected 3 attempts in 3 transactions, got %d in %d", attempts, len(*opts))
+		}
+
+		if s := manager.Stats(); s.Retries != 2 || s.Exhausted != 0 {
+			t.Errorf("unexpected stats %+v", s)
+		}
+	})
+
+	t.Run("should give up after the last attempt", func(t *testing.T) {
+		manager, _ := newRetryManager(t)
+
+		attempts := 0
+		err := manager.BeginOp(context.Background(), func(_ context.Context, _ int) error {
+			attempts++
+			return &pq.Error{Code: "40P01"}
+		}, database.WithIsolation(sql.LevelSerializable))
+		if !database.IsRetryable(err) {
+			t.Errorf("expected the deadlock, got %v", err)
+		}
+
+		if attempts != database.MaxAttempts {
+			t.Errorf("expected %d attempts, got %d", database.MaxAttempts, attempts)
+		}
+
+		if s := manager.Stats(); s.Retries != database.MaxAttempts-1 || s.Exhausted != 1 {
+			t.Errorf("unexpected stats %+v", s)
+		}
+	})
+
+	t.Run("should record the counters as metrics when instrumented", func(t *testing.T) {
+		manager, _ := newRetryManager(t)
+		meter := &countingMeter{counts: map[string]int64{}}
+		if err := manager.Instrument(meter); err != nil {
+			t.Fatal(err)
+		}
+
+		_ = manager.BeginOp(context.Background(), func(_ context.Context, _ int) error {
+			return serializationFailure
+		}, database.WithIsolation(sql.LevelSerializable))
+
+		want := map[string]int64{"db.client.operation.retries": database.MaxAttempts - 1, "db.client.operation.exhausted": 1}
+		if diff := cmp.Diff(want, meter.counts); diff != "" {
+			t.Errorf("metrics mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should not retry with the default isolation level", func(t *testing.T) {
+		manager, _ := newRetryManager(t)
+
+		attempts := 0
+		err := manager.BeginOp(context.Background(), func(_ context.Context, _ int) error {
+			attempts++
+			return serializationFailure
+		})
+		if !errors.Is(err, serializationFailure) {
+			t.Errorf("expected the serialization failure, got %v", err)
+		}
+
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("should not retry other errors", func(t *testing.T) {
+		manager, _ := newRetryManager(t)
+
+		attempts := 0
+		_ = manager.BeginOp(context.Background(), func(_ context.Context, _ int) error {
+			attempts++
+			return &pq.Error{Code: "23505"}
+		}, database.WithIsolation(sql.LevelSerializable))
+
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("should stop retrying when the context is done", func(t *testing.T) {
+		manager, _ := newRetryManager(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		attempts := 0
+		err := manager.BeginOp(ctx, func(ctx context.Context, _ int) error {
+			attempts++
+			<-ctx.Done()
+			return serializationFailure
+		}, database.WithIsolation(sql.LevelSerializable))
+		if !errors.Is(err, serializationFailure) {
+			t.Errorf("expected the serialization failure, got %v", err)
+		}
+
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+}
+
+// newNestingManager returns a manager of a single transaction that records its statements.
+func newNestingManager(t *testing.T) (*database.Manager[int], *[]string) {
+	t.Helper()
+	var statements []string
+	db := database.NewDBWithHandler(&mock.DBHandler{
+		BeginTxFunc: func(_ context.Context, _ *sql.TxOptions) (*database.Tx, error) {
+			statements = append(statements, "BEGIN")
+			return database.NewTxWithHandler(&mock.TxHandler{
+				DBHandler: mock.DBHandler{
+					ExecContextFunc: func(_ context.Context, query string, _ ...interface{}) (sql.Result, error) {
+						statements = append(statements, query)
+						return nil, nil
+					},
+				},
+				CommitFunc: func() error {
+					statements = append(statements, "COMMIT")
+					return nil
+				},
+				RollbackFunc: func() error {
+					statements = append(statements, "ROLLBACK")
+					return nil
+				},
+			}), nil
+		},
+	})
+	return database.NewManager(db, func(_ database.Conn) int { return 0 }), &statements
+}
+
+func TestBeginOp_nested(t *testing.T) {
+	t.Run("should run a nested operation in a savepoint", func(t *testing.T) {
+		manager, statements := newNestingManager(t)
+
+		if err := manager.BeginOp(context.Background(), func(ctx context.Context, _ int) error {
+			return manager.BeginOp(ctx, func(_ context.Context, _ int) error { return nil })
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"BEGIN", "SAVEPOINT op_1", "RELEASE SAVEPOINT op_1", "COMMIT"}
+		if !slices.Equal(*statements, want) {
+			t.Errorf("got %v, want %v", *statements, want)
+		}
+	})
+
+	t.Run("should only roll back the failed nested operation", func(t *testing.T) {
+		manager, statements := newNestingManager(t)
+		nestedErr := errors.New("nested failed")
+
+		var hooks []string
+		if err := manager.BeginOp(context.Background(), func(ctx context.Context, _ int) error {
+			database.AfterCommit(ctx, func(context.Context) { hooks = append(hooks, "outer") })
+
+			err := manager.BeginOp(ctx, func(ctx context.Context, _ int) error {
+				database.AfterCommit(ctx, func(context.Context) { hooks = append(hooks, "nested") })
+				return nestedErr
+			})
+			if !errors.Is(err, nestedErr) {
+				t.Errorf("expected the nested error, got %v", err)
+			}
+
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"BEGIN", "SAVEPOINT op_1", "ROLLBACK TO SAVEPOINT op_1", "COMMIT"}
+		if !slices.Equal(*statements, want) {
+			t.Errorf("got %v, want %v", *statements, want)
+		}
+
+		if !slices.Equal(hooks, []string{"outer"}) {
+			t.Errorf("expected only the outer hook to run, got %v", hooks)
+		}
+	})
+}
+
+func TestAfterCommit(t *testing.T) {
+	t.Run("should call the hooks after the commit", func(t *testing.T) {
+		manager, statements := newNestingManager(t)
+
+		if err := manager.BeginOp(context.Background(), func(ctx context.Context, _ int) error {
+			database.AfterCommit(ctx, func(context.Context) { *statements = append(*statements, "hook") })
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"BEGIN", "COMMIT", "hook"}
+		if !slices.Equal(*statements, want) {
+			t.Errorf("got %v, want %v", *statements, want)
+		}
+	})
+
+	t.Run("should not call the hooks after a rollback", func(t *testing.T) {
+		manager, _ := newNestingManager(t)
+
+		called := false
+		_ = manager.BeginOp(context.Background(), func(ctx context.Context, _ int) error {
+			database.AfterCommit(ctx, func(context.Context) { called = true })
+			return errors.New("failed")
+		})
+
+		if called {
+			t.Error("expected the hook not to be called")
+		}
+	})
+
+	t.Run("should call the hook right away without an operation", func(t *testing.T) {
+		called := false
+		database.AfterCommit(context.Background(), func(context.Context) { called = true })
+
+		if !called {
+			t.Error("expected the hook to be called")
+		}
+	})
+}
+
+func TestHooks(t *testing.T) {
+	var h database.Hooks
+	ctx := database.WithHooks(context.Background(), &h)
+
+	var called []string
+	database.AfterCommit(ctx, func(context.Context) { called = append(called, "kept") })
+	n := h.Len()
+	database.AfterCommit(ctx, func(context.Context) { called = append(called, "dropped") })
+
+	if len(called) != 0 {
+		t.Fatalf("expected the hooks to wait for Run, got %v", called)
+	}
+
+	h.Truncate(n)
+	h.Run(ctx)
+
+	if want := []string{"kept"}; !slices.Equal(called, want) {
+		t.Errorf("got %v, want %v", called, want)
+	}
+}
@@ -0,0 +1,146 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// SumFile is the name of the file that holds the checksums of a migration directory.
+const SumFile = "atlas.sum"
+
+var (
+	ErrChecksumNotFound = errors.New("checksum file not found")
+	ErrChecksumFormat   = errors.New("checksum file is malformed")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Migration is a SQL file of a migration directory.
+type Migration struct {
+	// Version is the part of the file name before the first underscore, such as 20240411191836.
+	Version string
+	// Description is the rest of the file name without the extension, such as init.
+	Description string
+	// SQL holds the statements of the migration.
+	SQL string
+	// Hash is the checksum of the SQL.
+	Hash string
+}
+
+// ReadDir reads the migrations of a directory ordered by version, and verifies them against the
+// checksum file. The directory uses the layout of the atlas CLI, so migrations created with
+// `atlas migrate new` and hashed with `atlas migrate hash` are read as they are.
+func ReadDir(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(names)
+
+	sum, err := fs.ReadFile(fsys, SumFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrChecksumNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := parseSum(sum)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(names))
+	files := make(map[string][]byte, len(names))
+	for _, name := range names {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = b
+
+		version, description, _ := strings.Cut(strings.TrimSuffix(path.Base(name), ".sql"), "_")
+		h := sha256.Sum256(b)
+		migrations = append(migrations, Migration{
+			Version:     version,
+			Description: description,
+			SQL:         string(b),
+			Hash:        base64.StdEncoding.EncodeToString(h[:]),
+		})
+	}
+
+	if got := hashDir(names, files); !slices.Equal(got, want) {
+		return nil, fmt.Errorf("%w: %s does not match the migrations, rehash the directory", ErrChecksumMismatch, SumFile)
+	}
+
+	return migrations, nil
+}
+
+// entry is a line of the checksum file.
+type entry struct {
+	name, hash string
+}
+
+// hashDir returns the checksum file of the files. Every file is hashed together with the files
+// before it, so changing, adding or removing a file changes the hashes of the files after it.
+// The first entry, with an empty name, is the checksum of the other entries.
+func hashDir(names []string, files map[string][]byte) []entry {
+	h := sha256.New()
+	entries := make([]entry, 0, len(names)+1)
+	entries = append(entries, entry{})
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write(files[name])
+		entries = append(entries, entry{name: name, hash: base64.StdEncoding.EncodeToString(h.Sum(nil))})
+	}
+
+	sum := sha256.New()
+	for _, e := range entries[1:] {
+		sum.Write([]byte(e.name))
+		sum.Write([]byte(e.hash))
+	}
+	entries[0].hash = base64.StdEncoding.EncodeToString(sum.Sum(nil))
+
+	return entries
+}
+
+// parseSum parses a checksum file. Its first line is the checksum of the entries, each line after
+// that is the name of a file and its hash.
+func parseSum(b []byte) ([]entry, error) {
+	var entries []entry
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+
+		i := strings.LastIndex(line, "h1:")
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrChecksumFormat, line)
+		}
+
+		e := entry{name: strings.TrimSpace(line[:i]), hash: line[i+len("h1:"):]}
+		if (len(entries) == 0) != (e.name == "") {
+			return nil, fmt.Errorf("%w: %q", ErrChecksumFormat, line)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", ErrChecksumFormat)
+	}
+
+	return entries, nil
+}
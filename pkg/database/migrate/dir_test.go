@@ -0,0 +1,81 @@
+package migrate_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/extreme-business/lingo/pkg/database/migrate"
+)
+
+// testdata returns the migrations of the testdata directory, hashed with `atlas migrate hash`.
+func testdata(t *testing.T) fstest.MapFS {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"20240101000000_users.sql", "20240102000000_display_name.sql", migrate.SumFile} {
+		b, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys[name] = &fstest.MapFile{Data: b}
+	}
+	return fsys
+}
+
+func TestReadDir(t *testing.T) {
+	t.Run("should read the migrations in order", func(t *testing.T) {
+		migrations, err := migrate.ReadDir(testdata(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(migrations) != 2 {
+			t.Fatalf("expected 2 migrations, got %d", len(migrations))
+		}
+
+		if m := migrations[0]; m.Version != "20240101000000" || m.Description != "users" || m.Hash == "" {
+			t.Errorf("unexpected first migration %+v", m)
+		}
+
+		if m := migrations[1]; m.Version != "20240102000000" || m.Description != "display_name" {
+			t.Errorf("unexpected second migration %+v", m)
+		}
+	})
+
+	t.Run("should fail when a migration is modified", func(t *testing.T) {
+		fsys := testdata(t)
+		fsys["20240101000000_users.sql"].Data = append(fsys["20240101000000_users.sql"].Data, "-- changed\n"...)
+
+		if _, err := migrate.ReadDir(fsys); !errors.Is(err, migrate.ErrChecksumMismatch) {
+			t.Errorf("expected %v, got %v", migrate.ErrChecksumMismatch, err)
+		}
+	})
+
+	t.Run("should fail when a migration is added", func(t *testing.T) {
+		fsys := testdata(t)
+		fsys["20240103000000_more.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;\n")}
+
+		if _, err := migrate.ReadDir(fsys); !errors.Is(err, migrate.ErrChecksumMismatch) {
+			t.Errorf("expected %v, got %v", migrate.ErrChecksumMismatch, err)
+		}
+	})
+
+	t.Run("should fail without a checksum file", func(t *testing.T) {
+		fsys := testdata(t)
+		delete(fsys, migrate.SumFile)
+
+		if _, err := migrate.ReadDir(fsys); !errors.Is(err, migrate.ErrChecksumNotFound) {
+			t.Errorf("expected %v, got %v", migrate.ErrChecksumNotFound, err)
+		}
+	})
+
+	t.Run("should fail on a malformed checksum file", func(t *testing.T) {
+		fsys := testdata(t)
+		fsys[migrate.SumFile].Data = []byte("20240101000000_users.sql\n")
+
+		if _, err := migrate.ReadDir(fsys); !errors.Is(err, migrate.ErrChecksumFormat) {
+			t.Errorf("expected %v, got %v", migrate.ErrChecksumFormat, err)
+		}
+	})
+}
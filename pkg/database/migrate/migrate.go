@@ -0,0 +1,412 @@
+// Package migrate applies the SQL migrations of an app to a Postgres database.
+//
+// The migrations are read from a directory in the layout of the atlas CLI, usually embedded in the
+// binary, and verified against its checksum file. The applied versions are recorded in a table, by
+// default schema_migrations. A Migrator takes an advisory lock while it migrates, so replicas that
+// start at the same time apply every migration once.
+//
+// Migrations only go forward. A change is undone with a new migration.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DefaultTable is the table the applied versions are recorded in.
+const DefaultTable = "schema_migrations"
+
+var (
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrModified       = errors.New("applied migration was modified")
+	ErrOutOfOrder     = errors.New("migration is older than the last applied migration")
+	ErrBaselined      = errors.New("the database already has applied migrations")
+	ErrInvalidTable   = errors.New("invalid table name")
+	ErrSchemaMismatch = errors.New("database schema does not match the migrations")
+)
+
+var tableName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// Status is a migration and whether it is applied.
+type Status struct {
+	Migration
+	Applied bool
+	// Baseline is true for migrations that were marked as applied by Baseline without running them.
+	Baseline  bool
+	ApplyTime time.Time
+}
+
+// record is a row of the migration table.
+type record struct {
+	version   string
+	hash      string
+	baseline  bool
+	applyTime time.Time
+}
+
+type Migrator struct {
+	logger     *slog.Logger
+	clock      func() time.Time
+	db         *sql.DB
+	table      string
+	migrations []Migration
+}
+
+type Config struct {
+	Logger *slog.Logger
+	Clock  func() time.Time
+	DB     *sql.DB
+	FS     fs.FS  // FS is the migration directory, see ReadDir.
+	Table  string // Table records the applied versions, defaults to DefaultTable.
+}
+
+func (c Config) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Clock == nil {
+		return errors.New("clock is required")
+	}
+
+	if c.DB == nil {
+		return errors.New("db is required")
+	}
+
+	if c.FS == nil {
+		return errors.New("fs is required")
+	}
+
+	if c.Table != "" && !tableName.MatchString(c.Table) {
+		return ErrInvalidTable
+	}
+
+	return nil
+}
+
+func New(c Config) (*Migrator, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	migrations, err := ReadDir(c.FS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	m := &Migrator{
+		logger:     c.Logger,
+		clock:      c.Clock,
+		db:         c.DB,
+		table:      c.Table,
+		migrations: migrations,
+	}
+
+	if m.table == "" {
+		m.table = DefaultTable
+	}
+
+	return m, nil
+}
+
+// Migrations returns the migrations of the directory ordered by version.
+func (m *Migrator) Migrations() []Migration {
+	return slices.Clone(m.migrations)
+}
+
+// Up applies the pending migrations in order and returns them. Every migration is applied in its
+// own transaction, so a failing migration leaves the ones before it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		records, err := m.records(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		pending, err := m.pending(records)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range pending {
+			m.logger.InfoContext(ctx, "applying migration",
+				slog.String("version", mig.Version),
+				slog.String("description", mig.Description),
+			)
+
+			if err = m.apply(ctx, conn, mig, false); err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", mig.Version, err)
+			}
+			applied = append(applied, mig)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Baseline marks the migrations up to and including the version as applied without running them.
+// It is used for databases that were migrated before, for example with the atlas CLI, and only
+// works on a database without applied migrations.
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]Migration, error) {
+	i := slices.IndexFunc(m.migrations, func(mig Migration) bool { return mig.Version == version })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownVersion, version)
+	}
+
+	var marked []Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		records, err := m.records(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if len(records) > 0 {
+			return ErrBaselined
+		}
+
+		for _, mig := range m.migrations[:i+1] {
+			if err = m.apply(ctx, conn, mig, true); err != nil {
+				return fmt.Errorf("failed to mark migration %s: %w", mig.Version, err)
+			}
+			marked = append(marked, mig)
+		}
+
+		return nil
+	})
+
+	return marked, err
+}
+
+// Status returns every migration and whether it is applied. It does not change the database.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		r, ok := records[mig.Version]
+		statuses = append(statuses, Status{
+			Migration: mig,
+			Applied:   ok,
+			Baseline:  r.baseline,
+			ApplyTime: r.applyTime,
+		})
+	}
+
+	return statuses, nil
+}
+
+// Report compares the applied migrations with the migrations of the directory.
+type Report struct {
+	// Missing are the migrations that are not applied.
+	Missing []Migration
+	// Unknown are the applied versions that are not in the directory, they were applied by a newer build.
+	Unknown []string
+	// Modified are the applied versions whose migration changed since.
+	Modified []string
+}
+
+// OK reports whether the database has exactly the migrations of the directory.
+func (r Report) OK() bool {
+	return len(r.Missing) == 0 && len(r.Unknown) == 0 && len(r.Modified) == 0
+}
+
+// Err returns an ErrSchemaMismatch that lists the differences, or nil when the report is OK.
+func (r Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrSchemaMismatch, r)
+}
+
+func (r Report) String() string {
+	if r.OK() {
+		return "up to date"
+	}
+
+	var parts []string
+	if len(r.Missing) > 0 {
+		versions := make([]string, 0, len(r.Missing))
+		for _, mig := range r.Missing {
+			versions = append(versions, mig.Version)
+		}
+		parts = append(parts, "missing "+strings.Join(versions, ", "))
+	}
+	if len(r.Unknown) > 0 {
+		parts = append(parts, "unknown "+strings.Join(r.Unknown, ", "))
+	}
+	if len(r.Modified) > 0 {
+		parts = append(parts, "modified "+strings.Join(r.Modified, ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// Check compares the applied migrations with the migrations of the directory. It does not change the database.
+func (m *Migrator) Check(ctx context.Context) (Report, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var r Report
+	for _, mig := range m.migrations {
+		applied, ok := records[mig.Version]
+		switch {
+		case !ok:
+			r.Missing = append(r.Missing, mig)
+		case applied.hash != mig.Hash:
+			r.Modified = append(r.Modified, mig.Version)
+		}
+		delete(records, mig.Version)
+	}
+
+	for version := range records {
+		r.Unknown = append(r.Unknown, version)
+	}
+	slices.Sort(r.Unknown)
+
+	return r, nil
+}
+
+// applied returns the applied migrations by version, it is empty when the migration table does not exist.
+func (m *Migrator) applied(ctx context.Context) (map[string]record, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var exists bool
+	if err = conn.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, m.table).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check the migration table: %w", err)
+	}
+
+	if !exists {
+		return map[string]record{}, nil
+	}
+
+	return m.records(ctx, conn)
+}
+
+// locked runs f on a connection that holds the advisory lock of the migration table, after
+// creating the table if it does not exist.
+func (m *Migrator) locked(ctx context.Context, f func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext($1))`, m.table); err != nil {
+		return fmt.Errorf("failed to lock the migration table: %w", err)
+	}
+	defer func() {
+		// unlock on a context that is not canceled, the lock would otherwise be held until the connection closes
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext($1))`, m.table); err != nil {
+			m.logger.ErrorContext(ctx, "failed to unlock the migration table", slog.String("error", err.Error()))
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		version VARCHAR(255) PRIMARY KEY,
+		description VARCHAR(255) NOT NULL,
+		hash VARCHAR(64) NOT NULL,
+		baseline BOOLEAN NOT NULL DEFAULT FALSE,
+		apply_time TIMESTAMP NOT NULL
+	)`, pq.QuoteIdentifier(m.table))); err != nil {
+		return fmt.Errorf("failed to create the migration table: %w", err)
+	}
+
+	return f(conn)
+}
+
+// records returns the applied migrations by version.
+func (m *Migrator) records(ctx context.Context, conn *sql.Conn) (map[string]record, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`SELECT version, hash, baseline, apply_time FROM %s`, pq.QuoteIdentifier(m.table)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	records := map[string]record{}
+	for rows.Next() {
+		var r record
+		if err = rows.Scan(&r.version, &r.hash, &r.baseline, &r.applyTime); err != nil {
+			return nil, err
+		}
+		records[r.version] = r
+	}
+
+	return records, rows.Err()
+}
+
+// pending returns the migrations that are not applied yet. It fails when the applied migrations do
+// not match the directory, or when a pending migration is older than an applied one.
+func (m *Migrator) pending(records map[string]record) ([]Migration, error) {
+	var last string
+	for version, r := range records {
+		i := slices.IndexFunc(m.migrations, func(mig Migration) bool { return mig.Version == version })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s is applied but not in the directory", ErrUnknownVersion, version)
+		}
+
+		if m.migrations[i].Hash != r.hash {
+			return nil, fmt.Errorf("%w: %s", ErrModified, version)
+		}
+
+		last = max(last, version)
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := records[mig.Version]; ok {
+			continue
+		}
+
+		if mig.Version < last {
+			return nil, fmt.Errorf("%w: %s is not applied, %s is", ErrOutOfOrder, mig.Version, last)
+		}
+
+		pending = append(pending, mig)
+	}
+
+	return pending, nil
+}
+
+// apply runs a migration and records it in one transaction. A baseline migration is only recorded.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration, baseline bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // the rollback fails after a commit
+
+	if !baseline {
+		if _, err = tx.ExecContext(ctx, mig.SQL); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (version, description, hash, baseline, apply_time) VALUES ($1, $2, $3, $4, $5)`,
+		pq.QuoteIdentifier(m.table)), mig.Version, mig.Description, mig.Hash, baseline, m.clock()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,167 @@
+package migrate_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/extreme-business/lingo/pkg/database/migrate"
+)
+
+var applyTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestMigrator(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	dbc := dbtest.SetupPostgres(ctx, t, "migrate")
+
+	newMigrator := func(t *testing.T) *migrate.Migrator {
+		t.Helper()
+		m, err := migrate.New(migrate.Config{
+			Logger: slog.Default(),
+			Clock:  func() time.Time { return applyTime },
+			DB:     dbtest.Connect(ctx, t, dbc.ConnectionString),
+			FS:     testdata(t),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	t.Run("should apply the migrations once when replicas migrate at the same time", func(t *testing.T) {
+		var wg sync.WaitGroup
+		applied := make([]int, 3)
+		for i := range applied {
+			m := newMigrator(t)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				migrations, err := m.Up(ctx)
+				if err != nil {
+					t.Error(err)
+				}
+				applied[i] = len(migrations)
+			}()
+		}
+		wg.Wait()
+
+		if total := applied[0] + applied[1] + applied[2]; total != 2 {
+			t.Errorf("expected 2 migrations to be applied, got %d", total)
+		}
+
+		statuses, err := newMigrator(t).Status(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range statuses {
+			if !s.Applied || s.Baseline || !s.ApplyTime.Equal(applyTime) {
+				t.Errorf("expected %s to be applied, got %+v", s.Version, s)
+			}
+		}
+
+		report, err := newMigrator(t).Check(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !report.OK() {
+			t.Errorf("expected the schema to be up to date, got %s", report)
+		}
+	})
+
+	t.Run("should fail when the applied migrations do not match the directory", func(t *testing.T) {
+		m := newMigrator(t)
+		db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+
+		if _, err := db.ExecContext(ctx, `UPDATE schema_migrations SET hash = 'modified' WHERE version = '20240101000000'`); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.Up(ctx); !errors.Is(err, migrate.ErrModified) {
+			t.Errorf("expected %v, got %v", migrate.ErrModified, err)
+		}
+
+		if _, err := db.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = '20240101000000'`); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.Up(ctx); !errors.Is(err, migrate.ErrOutOfOrder) {
+			t.Errorf("expected %v, got %v", migrate.ErrOutOfOrder, err)
+		}
+
+		if _, err := db.ExecContext(ctx, `INSERT INTO schema_migrations (version, description, hash, apply_time) VALUES ('20240103000000', 'unknown', '', now())`); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.Up(ctx); !errors.Is(err, migrate.ErrUnknownVersion) {
+			t.Errorf("expected %v, got %v", migrate.ErrUnknownVersion, err)
+		}
+	})
+
+	t.Run("should baseline a database that was migrated before", func(t *testing.T) {
+		m, err := migrate.New(migrate.Config{
+			Logger: slog.Default(),
+			Clock:  time.Now,
+			DB:     dbtest.Connect(ctx, t, dbc.ConnectionString),
+			FS:     testdata(t),
+			Table:  "baseline_migrations",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		marked, err := m.Baseline(ctx, "20240101000000")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(marked) != 1 {
+			t.Fatalf("expected 1 migration to be marked, got %d", len(marked))
+		}
+
+		if _, err = m.Baseline(ctx, "20240101000000"); !errors.Is(err, migrate.ErrBaselined) {
+			t.Errorf("expected %v, got %v", migrate.ErrBaselined, err)
+		}
+
+		// the migrations were applied before, so the display name exists already
+		if _, err = m.Up(ctx); err == nil {
+			t.Error("expected the display name to exist already")
+		}
+	})
+}
+
+func TestReport(t *testing.T) {
+	t.Run("should be ok without differences", func(t *testing.T) {
+		var r migrate.Report
+		if !r.OK() || r.Err() != nil {
+			t.Errorf("expected an empty report to be ok, got %s", r)
+		}
+	})
+
+	t.Run("should list the differences", func(t *testing.T) {
+		r := migrate.Report{
+			Missing:  []migrate.Migration{{Version: "20240102000000"}},
+			Unknown:  []string{"20240103000000"},
+			Modified: []string{"20240101000000"},
+		}
+
+		err := r.Err()
+		if !errors.Is(err, migrate.ErrSchemaMismatch) {
+			t.Fatalf("expected %v, got %v", migrate.ErrSchemaMismatch, err)
+		}
+
+		want := "missing 20240102000000; unknown 20240103000000; modified 20240101000000"
+		if r.String() != want {
+			t.Errorf("got %q, want %q", r.String(), want)
+		}
+	})
+}
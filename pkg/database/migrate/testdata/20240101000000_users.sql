@@ -0,0 +1,5 @@
+-- Create users table
+CREATE TABLE users (
+    id UUID PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE
+);
@@ -0,0 +1,2 @@
+-- Add the display name of users
+ALTER TABLE users ADD COLUMN display_name VARCHAR(255) NOT NULL DEFAULT '';
@@ -1,3 +1,33 @@
 package database
 
+import (
+	"database/sql"
+)
+
 type Option func(*DBWrapper)
+
+// MaxAttempts is how often an operation with the serializable or repeatable read isolation
+// level is attempted when it fails on a serialization failure or deadlock.
+const MaxAttempts = 5
+
+// opOptions are the options of an operation started with BeginOp.
+type opOptions struct {
+	isolation sql.IsolationLevel
+}
+
+// OpOption configures an operation started with BeginOp.
+type OpOption func(*opOptions)
+
+// WithIsolation sets the isolation level of the transaction. Operations with the serializable or
+// repeatable read isolation level are retried on serialization failures and deadlocks.
+func WithIsolation(level sql.IsolationLevel) OpOption {
+	return func(o *opOptions) {
+		o.isolation = level
+	}
+}
+
+// retryable reports whether the operation is retried on serialization failures and deadlocks. Only
+// operations that asked for a serializable or repeatable read transaction are expected to run more than once.
+func (o opOptions) retryable() bool {
+	return o.isolation == sql.LevelSerializable || o.isolation == sql.LevelRepeatableRead
+}
@@ -3,21 +3,164 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/lib/pq" // load the postgres driver
 )
 
+// Drivers a connection can use.
+const (
+	// DriverPQ is github.com/lib/pq, the default.
+	DriverPQ = "pq"
+	// DriverPgx is github.com/jackc/pgx.
+	DriverPgx = "pgx"
+)
+
+// Statement cache modes of the pgx driver, see pgx.QueryExecMode.
+const (
+	// StatementCachePrepare prepares every statement once per connection, the default of the pgx driver.
+	StatementCachePrepare = "prepare"
+	// StatementCacheDescribe only caches the description of statements, for poolers such as PgBouncer
+	// that do not keep prepared statements.
+	StatementCacheDescribe = "describe"
+	// StatementCacheNone caches nothing.
+	StatementCacheNone = "none"
+)
+
+var (
+	ErrUnknownDriver         = errors.New("unknown database driver")
+	ErrUnknownStatementCache = errors.New("unknown statement cache mode")
+)
+
+// Config configures the connection pool. The zero value connects with lib/pq and the defaults of database/sql.
+type Config struct {
+	Driver string // Driver is DriverPQ or DriverPgx, defaults to DriverPQ.
+	// ApplicationName is shown in pg_stat_activity, it overrides the application_name of the data source name.
+	ApplicationName string
+	MaxOpenConns    int           // MaxOpenConns limits the size of the pool, 0 is unlimited.
+	MaxIdleConns    int           // MaxIdleConns is the number of connections kept open while idle.
+	ConnMaxLifetime time.Duration // ConnMaxLifetime closes connections after they were open for the duration.
+	ConnMaxIdleTime time.Duration // ConnMaxIdleTime closes connections after they were idle for the duration.
+	// StatementCache is the statement cache mode of the pgx driver, defaults to StatementCachePrepare.
+	// lib/pq does not cache statements.
+	StatementCache string
+	// StatementCacheCapacity is the number of statements cached per connection by the pgx driver.
+	StatementCacheCapacity int
+}
+
+func (c Config) Validate() error {
+	switch c.Driver {
+	case "", DriverPQ, DriverPgx:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownDriver, c.Driver)
+	}
+
+	switch c.StatementCache {
+	case "", StatementCachePrepare, StatementCacheDescribe, StatementCacheNone:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownStatementCache, c.StatementCache)
+	}
+
+	return nil
+}
+
 // SetupDatabase sets up the database connection.
 func Connect(ctx context.Context, dataSourceName string) (*sql.DB, error) {
-	db, err := sql.Open("postgres", dataSourceName)
+	return Open(ctx, dataSourceName, Config{})
+}
+
+// Open connects to the database with the driver and pool settings of the config.
+func Open(ctx context.Context, dataSourceName string, c Config) (*sql.DB, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	var db *sql.DB
+	var err error
+	if c.Driver == DriverPgx {
+		db, err = openPgx(dataSourceName, c)
+	} else {
+		db, err = openPQ(dataSourceName, c)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db: %w", err)
 	}
 
+	if c.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(c.MaxOpenConns)
+	}
+	if c.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(c.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+
 	if err = db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping db: %w", err)
 	}
 
 	return db, nil
 }
+
+func openPQ(dataSourceName string, c Config) (*sql.DB, error) {
+	if c.ApplicationName != "" {
+		var err error
+		if dataSourceName, err = withApplicationName(dataSourceName, c.ApplicationName); err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.Open("postgres", dataSourceName)
+}
+
+func openPgx(dataSourceName string, c Config) (*sql.DB, error) {
+	config, err := pgx.ParseConfig(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ApplicationName != "" {
+		config.RuntimeParams["application_name"] = c.ApplicationName
+	}
+
+	switch c.StatementCache {
+	case StatementCacheDescribe:
+		config.DefaultQueryExecMode = pgx.QueryExecModeCacheDescribe
+	case StatementCacheNone:
+		config.DefaultQueryExecMode = pgx.QueryExecModeDescribeExec
+	}
+
+	if c.StatementCacheCapacity > 0 {
+		config.StatementCacheCapacity = c.StatementCacheCapacity
+		config.DescriptionCacheCapacity = c.StatementCacheCapacity
+	}
+
+	return stdlib.OpenDB(*config), nil
+}
+
+// withApplicationName sets the application_name of a data source name, which is either a URL or
+// key=value pairs.
+func withApplicationName(dataSourceName, name string) (string, error) {
+	if strings.HasPrefix(dataSourceName, "postgres://") || strings.HasPrefix(dataSourceName, "postgresql://") {
+		u, err := url.Parse(dataSourceName)
+		if err != nil {
+			return "", err
+		}
+
+		q := u.Query()
+		q.Set("application_name", name)
+		u.RawQuery = q.Encode()
+		return u.String(), nil
+	}
+
+	// backslashes are escaped before the quotes, so the escapes of the quotes stay intact.
+	name = strings.ReplaceAll(name, `\`, `\\`)
+	return fmt.Sprintf("%s application_name='%s'", dataSourceName, strings.ReplaceAll(name, "'", `\'`)), nil
+}
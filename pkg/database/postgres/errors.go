@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+// Error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	CodeUniqueViolation     = "23505"
+	CodeForeignKeyViolation = "23503"
+	CodeCheckViolation      = "23514"
+)
+
+// Error is a Postgres error, independent of the driver that returned it.
+type Error struct {
+	Code       string
+	Constraint string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// AsError returns the Postgres error in the chain of err, as returned by either driver.
+func AsError(err error) (*Error, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return &Error{Code: string(pqErr.Code), Constraint: pqErr.Constraint, Message: pqErr.Message}, true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return &Error{Code: pgErr.Code, Constraint: pgErr.ConstraintName, Message: pgErr.Message}, true
+	}
+
+	return nil, false
+}
+
+// UniqueViolation returns the constraint a unique violation in the chain of err violated.
+func UniqueViolation(err error) (string, bool) {
+	e, ok := AsError(err)
+	if !ok || e.Code != CodeUniqueViolation {
+		return "", false
+	}
+
+	return e.Constraint, true
+}
+
+// ForeignKeyViolation returns the constraint a foreign key violation in the chain of err violated.
+func ForeignKeyViolation(err error) (string, bool) {
+	e, ok := AsError(err)
+	if !ok || e.Code != CodeForeignKeyViolation {
+		return "", false
+	}
+
+	return e.Constraint, true
+}
+
+// CheckViolation returns the constraint a check violation in the chain of err violated.
+func CheckViolation(err error) (string, bool) {
+	e, ok := AsError(err)
+	if !ok || e.Code != CodeCheckViolation {
+		return "", false
+	}
+
+	return e.Constraint, true
+}
@@ -0,0 +1,63 @@
+package postgres_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/extreme-business/lingo/pkg/database/postgres"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+func TestUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantConstraint string
+		wantOK         bool
+	}{
+		{
+			name:           "lib/pq",
+			err:            fmt.Errorf("failed to create user: %w", &pq.Error{Code: "23505", Constraint: "users_email_key"}),
+			wantConstraint: "users_email_key",
+			wantOK:         true,
+		},
+		{
+			name:           "pgx",
+			err:            fmt.Errorf("failed to create user: %w", &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}),
+			wantConstraint: "users_email_key",
+			wantOK:         true,
+		},
+		{
+			name: "other code",
+			err:  &pgconn.PgError{Code: "23503", ConstraintName: "users_organization_id_fkey"},
+		},
+		{
+			name: "not a postgres error",
+			err:  errors.New("connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraint, ok := postgres.UniqueViolation(tt.err)
+			if constraint != tt.wantConstraint || ok != tt.wantOK {
+				t.Errorf("UniqueViolation() = %v, %v, want %v, %v", constraint, ok, tt.wantConstraint, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := (postgres.Config{}).Validate(); err != nil {
+		t.Errorf("expected the zero value to be valid, got %v", err)
+	}
+
+	if err := (postgres.Config{Driver: "mysql"}).Validate(); !errors.Is(err, postgres.ErrUnknownDriver) {
+		t.Errorf("expected ErrUnknownDriver, got %v", err)
+	}
+
+	if err := (postgres.Config{Driver: postgres.DriverPgx, StatementCache: "all"}).Validate(); !errors.Is(err, postgres.ErrUnknownStatementCache) {
+		t.Errorf("expected ErrUnknownStatementCache, got %v", err)
+	}
+}
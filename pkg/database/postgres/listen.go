@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	listenMinReconnectInterval = time.Second
+	listenMaxReconnectInterval = time.Minute
+	listenPingInterval         = 90 * time.Second
+)
+
+// Listen listens to a notification channel, see LISTEN and NOTIFY, and calls f with the payload of
+// every notification until the context is canceled.
+//
+// The connection is restored when it is lost. Notifications sent in the meantime are lost, so f is
+// called with an empty payload after reconnecting to let the caller catch up.
+func Listen(ctx context.Context, dataSourceName, channel string, f func(payload string)) error {
+	l := pq.NewListener(dataSourceName, listenMinReconnectInterval, listenMaxReconnectInterval, nil)
+	defer l.Close()
+
+	if err := l.Listen(channel); err != nil {
+		return fmt.Errorf("failed to listen to %s: %w", channel, err)
+	}
+
+	t := time.NewTicker(listenPingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-l.Notify:
+			// a nil notification means the connection was restored.
+			if n == nil {
+				f("")
+				continue
+			}
+			f(n.Extra)
+		case <-t.C:
+			// the ping makes sure a broken connection is noticed and restored.
+			go func() { _ = l.Ping() }()
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultPinWindow is how long the reads of a request go to the primary after the request wrote.
+	DefaultPinWindow = 5 * time.Second
+	// DefaultCheckInterval is how often the health of the replicas is checked.
+	DefaultCheckInterval = 5 * time.Second
+)
+
+var _ Conn = (*Replicas)(nil) // Ensure *Replicas complies with the Conn interface.
+
+// replica is a read replica and whether it passed its last health check.
+type replica struct {
+	db      *DBWrapper
+	healthy atomic.Bool
+}
+
+// Replicas is a connection that sends reads to read replicas and everything else to the primary.
+//
+// A query is a read when it is a SELECT without a locking clause or a function with side effects.
+// Reads are spread over the healthy replicas in turn, and go to the primary when no replica is
+// healthy. Replicas lag behind the primary, so the reads of a request context created with
+// NewReadYourWritesContext go to the primary for a while after the request wrote, and the reads of a
+// context created with NewPrimaryContext always do.
+type Replicas struct {
+	logger        *slog.Logger
+	primary       *DBWrapper
+	replicas      []*replica
+	next          atomic.Uint64
+	pinWindow     time.Duration
+	checkInterval time.Duration
+}
+
+type ReplicasConfig struct {
+	Logger        *slog.Logger
+	Primary       *DBWrapper
+	Replicas      []*DBWrapper
+	PinWindow     time.Duration // PinWindow defaults to DefaultPinWindow.
+	CheckInterval time.Duration // CheckInterval defaults to DefaultCheckInterval.
+}
+
+func (c ReplicasConfig) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Primary == nil {
+		return errors.New("primary is required")
+	}
+
+	return nil
+}
+
+func NewReplicas(c ReplicasConfig) (*Replicas, error) {
+	r := &Replicas{
+		logger:        c.Logger,
+		primary:       c.Primary,
+		pinWindow:     c.PinWindow,
+		checkInterval: c.CheckInterval,
+	}
+
+	for _, db := range c.Replicas {
+		rep := &replica{db: db}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+
+	if r.pinWindow <= 0 {
+		r.pinWindow = DefaultPinWindow
+	}
+
+	if r.checkInterval <= 0 {
+		r.checkInterval = DefaultCheckInterval
+	}
+
+	return r, c.Validate()
+}
+
+// Run checks the health of the replicas until the context is canceled. A replica that fails its
+// check gets no reads until it passes again.
+func (r *Replicas) Run(ctx context.Context) error {
+	t := time.NewTicker(r.checkInterval)
+	defer t.Stop()
+
+	for {
+		r.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// Check checks the health of the replicas once.
+func (r *Replicas) Check(ctx context.Context) {
+	for i, rep := range r.replicas {
+		cctx, cancel := context.WithTimeout(ctx, r.checkInterval)
+		var one int
+		err := rep.db.QueryRow(cctx, `SELECT 1`).Scan(&one)
+		cancel()
+
+		if healthy := err == nil; rep.healthy.Swap(healthy) != healthy {
+			if healthy {
+				r.logger.InfoContext(ctx, "replica is healthy", slog.Int("replica", i))
+			} else {
+				r.logger.WarnContext(ctx, "replica is unhealthy", slog.Int("replica", i), slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// Query executes a query that returns rows, typically a SELECT statement.
+func (r *Replicas) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	return r.conn(ctx, query).Query(ctx, query, args...)
+}
+
+// QueryRow executes a query that is expected to return at most one row.
+func (r *Replicas) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
+	return r.conn(ctx, query).QueryRow(ctx, query, args...)
+}
+
+// Exec executes a query without returning any rows. It always goes to the primary.
+func (r *Replicas) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	markWrite(ctx)
+	return r.primary.Exec(ctx, query, args...)
+}
+
+// conn returns the connection the query goes to.
+func (r *Replicas) conn(ctx context.Context, query string) *DBWrapper {
+	if !isRead(query) {
+		markWrite(ctx)
+		return r.primary
+	}
+
+	if primaryOnly(ctx) || pinned(ctx, r.pinWindow) {
+		return r.primary
+	}
+
+	n := len(r.replicas)
+	start := r.next.Add(1)
+	for i := range n {
+		if rep := r.replicas[(start+uint64(i))%uint64(n)]; rep.healthy.Load() {
+			return rep.db
+		}
+	}
+
+	return r.primary
+}
+
+// isRead reports whether the query only reads, so it can go to a replica.
+func isRead(query string) bool {
+	q := strings.ToUpper(strings.TrimSpace(query))
+	if !strings.HasPrefix(q, "SELECT") {
+		return false
+	}
+
+	for _, lock := range []string{"FOR UPDATE", "FOR NO KEY UPDATE", "FOR SHARE", "FOR KEY SHARE"} {
+		if strings.Contains(q, lock) {
+			return false
+		}
+	}
+
+	// these functions write or take locks, which a replica can not do.
+	for _, fn := range []string{"PG_ADVISORY", "PG_TRY_ADVISORY", "PG_NOTIFY", "NEXTVAL", "SETVAL"} {
+		if strings.Contains(q, fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// primaryKey is the context key that sends all reads to the primary.
+type primaryKey struct{}
+
+// NewPrimaryContext returns a context whose reads always go to the primary. Use it for reads that
+// must not miss a recent write of another request, such as checking whether a session was revoked.
+func NewPrimaryContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// primaryOnly reports whether the reads of the context go to the primary.
+func primaryOnly(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
+
+// readYourWritesKey is the context key of the last write of a request.
+type readYourWritesKey struct{}
+
+// NewReadYourWritesContext returns a context whose reads go to the primary for a while after a write
+// made with the context, so the request reads its own writes. Use a new context for every request.
+func NewReadYourWritesContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, new(atomic.Int64))
+}
+
+// markWrite records that the request of the context wrote.
+func markWrite(ctx context.Context) {
+	if last, ok := ctx.Value(readYourWritesKey{}).(*atomic.Int64); ok {
+		last.Store(time.Now().UnixNano())
+	}
+}
+
+// pinned reports whether the request of the context wrote within the window.
+func pinned(ctx context.Context, window time.Duration) bool {
+	last, ok := ctx.Value(readYourWritesKey{}).(*atomic.Int64)
+	if !ok {
+		return false
+	}
+
+	t := last.Load()
+	return t != 0 && time.Since(time.Unix(0, t)) < window
+}
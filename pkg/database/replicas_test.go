@@ -0,0 +1,157 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/mock"
+)
+
+// newNamedDB returns a database that appends its name to the log for every query. Its health
+// check fails when healthy returns false.
+func newNamedDB(name string, log *[]string, healthy func() bool) *database.DBWrapper {
+	return database.NewDBWithHandler(&mock.DBHandler{
+		QueryContextFunc: func(_ context.Context, _ string, _ ...interface{}) (*database.Rows, error) {
+			*log = append(*log, name)
+			return nil, nil
+		},
+		QueryRowContextFunc: func(_ context.Context, query string, _ ...interface{}) *database.Row {
+			if query == "SELECT 1" {
+				return database.NewRow(&mock.RowHandler{ScanFunc: func(...interface{}) error {
+					if !healthy() {
+						return errors.New("connection refused")
+					}
+					return nil
+				}})
+			}
+			*log = append(*log, name)
+			return database.NewRow(&mock.RowHandler{})
+		},
+		ExecContextFunc: func(_ context.Context, _ string, _ ...interface{}) (sql.Result, error) {
+			*log = append(*log, name)
+			return nil, nil
+		},
+	})
+}
+
+func newReplicas(t *testing.T, log *[]string, healthy func() bool) *database.Replicas {
+	t.Helper()
+	r, err := database.NewReplicas(database.ReplicasConfig{
+		Logger:    slog.Default(),
+		Primary:   newNamedDB("primary", log, func() bool { return true }),
+		Replicas:  []*database.DBWrapper{newNamedDB("replica-1", log, healthy), newNamedDB("replica-2", log, func() bool { return true })},
+		PinWindow: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestReplicas(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should spread the reads over the replicas", func(t *testing.T) {
+		var log []string
+		r := newReplicas(t, &log, func() bool { return true })
+
+		for range 4 {
+			_, _ = r.Query(ctx, "SELECT id FROM users")
+		}
+
+		if !slices.Equal(log, []string{"replica-2", "replica-1", "replica-2", "replica-1"}) {
+			t.Errorf("unexpected routing %v", log)
+		}
+	})
+
+	t.Run("should send writes and locking reads to the primary", func(t *testing.T) {
+		var log []string
+		r := newReplicas(t, &log, func() bool { return true })
+
+		_, _ = r.Exec(ctx, "DELETE FROM sessions")
+		_ = r.QueryRow(ctx, "INSERT INTO users (id) VALUES ($1) RETURNING id", 1)
+		_, _ = r.Query(ctx, "SELECT id FROM jobs FOR UPDATE SKIP LOCKED")
+		_ = r.QueryRow(ctx, "SELECT pg_advisory_xact_lock($1)", 1)
+		_ = r.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", 1)
+		_, _ = r.Query(ctx, "SELECT pg_notify('outbox', $1)", "1")
+		_ = r.QueryRow(ctx, "SELECT nextval('outbox_events_position_seq')")
+		_ = r.QueryRow(ctx, "SELECT setval('outbox_events_position_seq', 1)")
+
+		if !slices.Equal(log, []string{"primary", "primary", "primary", "primary", "primary", "primary", "primary", "primary"}) {
+			t.Errorf("unexpected routing %v", log)
+		}
+	})
+
+	t.Run("should skip an unhealthy replica", func(t *testing.T) {
+		var log []string
+		r := newReplicas(t, &log, func() bool { return false })
+		r.Check(ctx)
+
+		for range 2 {
+			_, _ = r.Query(ctx, "SELECT id FROM users")
+		}
+
+		if !slices.Equal(log, []string{"replica-2", "replica-2"}) {
+			t.Errorf("unexpected routing %v", log)
+		}
+	})
+
+	t.Run("should read from the primary after a write of the request", func(t *testing.T) {
+		var log []string
+		r := newReplicas(t, &log, func() bool { return true })
+
+		rctx := database.NewReadYourWritesContext(ctx)
+		_, _ = r.Query(rctx, "SELECT id FROM users")
+		_, _ = r.Exec(rctx, "UPDATE users SET display_name = $1", "name")
+		_, _ = r.Query(rctx, "SELECT id FROM users")
+		_, _ = r.Query(ctx, "SELECT id FROM users")
+
+		if !slices.Equal(log, []string{"replica-2", "primary", "primary", "replica-1"}) {
+			t.Errorf("unexpected routing %v", log)
+		}
+	})
+
+	t.Run("should read from the primary with a primary context", func(t *testing.T) {
+		var log []string
+		r := newReplicas(t, &log, func() bool { return true })
+
+		pctx := database.NewPrimaryContext(ctx)
+		_, _ = r.Query(pctx, "SELECT id FROM sessions")
+		_ = r.QueryRow(pctx, "SELECT id FROM sessions WHERE id = $1", 1)
+		_, _ = r.Query(ctx, "SELECT id FROM sessions")
+
+		if !slices.Equal(log, []string{"primary", "primary", "replica-2"}) {
+			t.Errorf("unexpected routing %v", log)
+		}
+	})
+}
+
+func TestManager_SetReplicas(t *testing.T) {
+	var log []string
+	r := newReplicas(t, &log, func() bool { return true })
+
+	manager := database.NewManager(database.NewDBWithHandler(&mock.DBHandler{
+		BeginTxFunc: func(_ context.Context, _ *sql.TxOptions) (*database.Tx, error) {
+			return database.NewTxWithHandler(&mock.TxHandler{CommitFunc: func() error { return nil }}), nil
+		},
+	}), func(c database.Conn) database.Conn { return c })
+	manager.SetReplicas(r)
+
+	ctx := database.NewReadYourWritesContext(context.Background())
+	_, _ = manager.Op().Query(ctx, "SELECT id FROM users")
+
+	if err := manager.BeginOp(ctx, func(_ context.Context, _ database.Conn) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = manager.Op().Query(ctx, "SELECT id FROM users")
+
+	if !slices.Equal(log, []string{"replica-2", "primary"}) {
+		t.Errorf("expected the reads after the operation to go to the primary, got %v", log)
+	}
+}
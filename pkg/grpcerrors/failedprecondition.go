@@ -0,0 +1,11 @@
+package grpcerrors
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewFailedPreconditionErr(msg string) error {
+	st := status.New(codes.FailedPrecondition, msg)
+	return st.Err()
+}
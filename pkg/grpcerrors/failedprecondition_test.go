@@ -0,0 +1,31 @@
+package grpcerrors_test
+
+import (
+	"testing"
+
+	"github.com/extreme-business/lingo/pkg/grpcerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewFailedPreconditionErr(t *testing.T) {
+	t.Run("error should match expected", func(t *testing.T) {
+		msg := "resource is busy"
+
+		err := grpcerrors.NewFailedPreconditionErr(msg)
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a gRPC status error, got %v", err)
+		}
+
+		// Check the status code
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("expected code %v, got %v", codes.FailedPrecondition, st.Code())
+		}
+
+		// Check the status message
+		if st.Message() != msg {
+			t.Errorf("expected message %q, got %q", msg, st.Message())
+		}
+	})
+}
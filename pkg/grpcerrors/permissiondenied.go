@@ -0,0 +1,11 @@
+package grpcerrors
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewPermissionDeniedErr(msg string) error {
+	st := status.New(codes.PermissionDenied, msg)
+	return st.Err()
+}
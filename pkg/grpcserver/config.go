@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 )
@@ -12,6 +13,8 @@ type Config struct {
 	ServiceRegistrar func(grpc.ServiceRegistrar) // Server registers
 	Reflection       bool                        // Enable reflection
 	GrpcServer       grpcServer                  // Grpc server
+	DrainTimeout     time.Duration               // Time to wait for open calls when stopping, 0 waits until they finish
+	DrainHooks       []func()                    // Called when the server starts draining
 }
 
 func (c *Config) Apply(opts ...Option) {
@@ -62,3 +65,19 @@ func WithGrpcServer(s grpcServer) Option {
 		c.GrpcServer = s
 	})
 }
+
+// WithDrainTimeout sets how long the server waits for open calls to finish when it stops.
+// Calls that are still open after the timeout are canceled.
+func WithDrainTimeout(d time.Duration) Option {
+	return optionFunc(func(c *Config) {
+		c.DrainTimeout = d
+	})
+}
+
+// WithDrainHook adds a function that is called when the server starts draining.
+// Long-lived streams use it to end themselves, so clients can reconnect to another server.
+func WithDrainHook(f func()) Option {
+	return optionFunc(func(c *Config) {
+		c.DrainHooks = append(c.DrainHooks, f)
+	})
+}
@@ -3,6 +3,7 @@ package grpcserver_test
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/extreme-business/lingo/pkg/grpcserver"
 	"google.golang.org/grpc"
@@ -87,3 +88,27 @@ func TestWithGrpcServer(t *testing.T) {
 		}
 	})
 }
+
+func TestWithDrainTimeout(t *testing.T) {
+	t.Run("WithDrainTimeout", func(t *testing.T) {
+		opt := grpcserver.WithDrainTimeout(time.Second)
+
+		c := &grpcserver.Config{}
+		c.Apply(opt)
+
+		if c.DrainTimeout != time.Second {
+			t.Errorf("expected %v, got %v", time.Second, c.DrainTimeout)
+		}
+	})
+}
+
+func TestWithDrainHook(t *testing.T) {
+	t.Run("hooks should be added", func(t *testing.T) {
+		c := &grpcserver.Config{}
+		c.Apply(grpcserver.WithDrainHook(func() {}), grpcserver.WithDrainHook(func() {}))
+
+		if len(c.DrainHooks) != 2 {
+			t.Errorf("expected 2 hooks, got %d", len(c.DrainHooks))
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -15,19 +16,22 @@ var (
 	ErrListenerNotSet       = errors.New("listener is not set")
 )
 
-// grpcServer is an interface that wraps the Serve and GracefulStop methods.
+// grpcServer is an interface that wraps the Serve, GracefulStop and Stop methods.
 type grpcServer interface {
 	grpc.ServiceRegistrar
 	reflection.GRPCServer
 	Serve(net.Listener) error
 	GracefulStop()
+	Stop()
 }
 
 type Server struct {
-	lis        net.Listener
-	grpcServer grpcServer
-	running    bool
-	address    string
+	lis          net.Listener
+	grpcServer   grpcServer
+	running      bool
+	address      string
+	drainTimeout time.Duration
+	drainHooks   []func()
 }
 
 // New creates a new grpc server.
@@ -75,16 +79,18 @@ func New(options ...Option) *Server {
 	}
 
 	return &Server{
-		lis:        c.Lis,
-		grpcServer: c.GrpcServer,
-		address:    address,
+		lis:          c.Lis,
+		grpcServer:   c.GrpcServer,
+		address:      address,
+		drainTimeout: c.DrainTimeout,
+		drainHooks:   c.DrainHooks,
 	}
 }
 
 // Running returns true if the server is running.
 func (s *Server) Running() bool { return s.running }
 
-// Serve starts the grpc server.
+// Serve starts the grpc server. When the context is canceled the server drains before Serve returns.
 func (s *Server) Serve(ctx context.Context) error {
 	if s.running {
 		return ErrServerAlreadyRunning
@@ -114,7 +120,7 @@ func (s *Server) Serve(ctx context.Context) error {
 
 	select {
 	case <-ctx.Done():
-		s.grpcServer.GracefulStop()
+		s.drain()
 		return ctx.Err()
 	case err := <-errChan:
 		if err == nil || errors.Is(err, grpc.ErrServerStopped) {
@@ -124,3 +130,32 @@ func (s *Server) Serve(ctx context.Context) error {
 		return fmt.Errorf("failed to serve: %w", err)
 	}
 }
+
+// drain stops accepting new calls and waits for the open calls to finish. The drain hooks are called first,
+// so streams can end themselves. Calls that are still open after the drain timeout are canceled.
+func (s *Server) drain() {
+	for _, f := range s.drainHooks {
+		f()
+	}
+
+	if s.drainTimeout <= 0 {
+		s.grpcServer.GracefulStop()
+		return
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+	case <-timer.C:
+		s.grpcServer.Stop()
+		<-stopped
+	}
+}
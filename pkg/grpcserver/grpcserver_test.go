@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/extreme-business/lingo/pkg/grpcserver"
 	"google.golang.org/grpc"
@@ -14,6 +15,8 @@ import (
 type MockGrpcServer struct {
 	ServeFunc          func(lis net.Listener) error
 	GetServiceInfoFunc func() map[string]grpc.ServiceInfo
+	GracefulStopFunc   func()
+	StopFunc           func()
 }
 
 func (m *MockGrpcServer) Serve(lis net.Listener) error {
@@ -24,7 +27,16 @@ func (m *MockGrpcServer) Serve(lis net.Listener) error {
 	return m.ServeFunc(lis)
 }
 func (m *MockGrpcServer) RegisterService(_ *grpc.ServiceDesc, _ any) {}
-func (m *MockGrpcServer) GracefulStop()                              {}
+func (m *MockGrpcServer) GracefulStop() {
+	if m.GracefulStopFunc != nil {
+		m.GracefulStopFunc()
+	}
+}
+func (m *MockGrpcServer) Stop() {
+	if m.StopFunc != nil {
+		m.StopFunc()
+	}
+}
 func (m *MockGrpcServer) GetServiceInfo() map[string]grpc.ServiceInfo {
 	if m.GetServiceInfoFunc == nil {
 		panic("GetServiceInfoFunc is not set")
@@ -142,3 +154,78 @@ func TestServer_Serve(t *testing.T) {
 		}
 	})
 }
+
+func TestServer_Drain(t *testing.T) {
+	t.Run("drain hooks should be called before the server stops", func(t *testing.T) {
+		var calls []string
+		serving := make(chan struct{})
+		stopped := make(chan struct{})
+
+		s := grpcserver.New(
+			grpcserver.WithListener(bufconn.Listen(1024)),
+			grpcserver.WithGrpcServer(&MockGrpcServer{
+				ServeFunc: func(_ net.Listener) error {
+					close(serving)
+					<-stopped
+					return grpc.ErrServerStopped
+				},
+				GracefulStopFunc: func() {
+					calls = append(calls, "graceful stop")
+					close(stopped)
+				},
+			}),
+			grpcserver.WithDrainHook(func() { calls = append(calls, "hook") }),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-serving
+			cancel()
+		}()
+
+		if err := s.Serve(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("Serve() = %v, want %v", err, context.Canceled)
+		}
+
+		if len(calls) != 2 || calls[0] != "hook" || calls[1] != "graceful stop" {
+			t.Errorf("calls = %v, want [hook graceful stop]", calls)
+		}
+	})
+
+	t.Run("open calls should be canceled after the drain timeout", func(t *testing.T) {
+		serving := make(chan struct{})
+		stopped := make(chan struct{})
+		var forced bool
+
+		s := grpcserver.New(
+			grpcserver.WithListener(bufconn.Listen(1024)),
+			grpcserver.WithGrpcServer(&MockGrpcServer{
+				ServeFunc: func(_ net.Listener) error {
+					close(serving)
+					<-stopped
+					return grpc.ErrServerStopped
+				},
+				GracefulStopFunc: func() { <-stopped }, // an open call never finishes.
+				StopFunc: func() {
+					forced = true
+					close(stopped)
+				},
+			}),
+			grpcserver.WithDrainTimeout(10*time.Millisecond),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-serving
+			cancel()
+		}()
+
+		if err := s.Serve(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("Serve() = %v, want %v", err, context.Canceled)
+		}
+
+		if !forced {
+			t.Error("expected the server to be stopped")
+		}
+	})
+}
@@ -0,0 +1,134 @@
+// Package grpctest runs gRPC services in process for tests. The server listens on an in-memory
+// bufconn listener, so tests need neither free ports nor certificate files, and a grpc-gateway
+// can be served in front of it with httptest.
+package grpctest
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/extreme-business/lingo/pkg/grpcserver"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	bufSize = 1024 * 1024
+	// ServerName is the name in the certificate of a server started WithTLS, it is the name the services use.
+	ServerName = "lingo"
+)
+
+// Server is a gRPC server running in process, with a client connection to it.
+type Server struct {
+	conn *grpc.ClientConn
+}
+
+type config struct {
+	serverOptions []grpc.ServerOption
+	dialOptions   []grpc.DialOption
+	tls           bool
+}
+
+// Option configures a Server.
+type Option func(*config)
+
+// WithUnaryInterceptors chains the interceptors in front of the unary calls of the server.
+func WithUnaryInterceptors(i ...grpc.UnaryServerInterceptor) Option {
+	return func(c *config) { c.serverOptions = append(c.serverOptions, grpc.ChainUnaryInterceptor(i...)) }
+}
+
+// WithStreamInterceptors chains the interceptors in front of the streaming calls of the server.
+func WithStreamInterceptors(i ...grpc.StreamServerInterceptor) Option {
+	return func(c *config) { c.serverOptions = append(c.serverOptions, grpc.ChainStreamInterceptor(i...)) }
+}
+
+// WithServerOptions adds options to the grpc server.
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(c *config) { c.serverOptions = append(c.serverOptions, opts...) }
+}
+
+// WithDialOptions adds options to the client connection.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *config) { c.dialOptions = append(c.dialOptions, opts...) }
+}
+
+// WithTLS serves TLS with a self-signed certificate for ServerName. The client connection trusts it.
+// Without it the connection is not encrypted.
+func WithTLS() Option {
+	return func(c *config) { c.tls = true }
+}
+
+// New starts a server with the services of the registrar. The server is stopped and the connection is closed
+// when the test finishes.
+func New(t testing.TB, registrar func(grpc.ServiceRegistrar), opts ...Option) *Server {
+	t.Helper()
+
+	c := &config{}
+	for _, o := range opts {
+		o(c)
+	}
+
+	creds := insecure.NewCredentials()
+	if c.tls {
+		cert, pool := selfSigned(t, ServerName)
+		c.serverOptions = append(c.serverOptions, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+		creds = credentials.NewClientTLSFromCert(pool, ServerName)
+	}
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpcserver.New(
+		grpcserver.WithListener(lis),
+		grpcserver.WithGrpcServer(grpc.NewServer(c.serverOptions...)),
+		grpcserver.WithServiceRegistrar(registrar),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(creds),
+	}, c.dialOptions...)...)
+	if err != nil {
+		t.Fatalf("failed to connect to the server: %v", err)
+	}
+	// cleanups run last in first out, so the connection is closed before the server drains.
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &Server{conn: conn}
+}
+
+// Conn returns the client connection to the server.
+func (s *Server) Conn() *grpc.ClientConn { return s.conn }
+
+// NewClient returns a typed client of the server, such as:
+//
+//	client := grpctest.NewClient(s, protoaccount.NewAccountServiceClient)
+func NewClient[T any](s *Server, newClient func(grpc.ClientConnInterface) T) T {
+	return newClient(s.conn)
+}
+
+// Gateway serves a grpc-gateway mux in front of the server. register is a generated handler registration,
+// such as protoaccount.RegisterAccountServiceHandler. The HTTP server is closed when the test finishes.
+func (s *Server) Gateway(t testing.TB, register func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error, opts ...runtime.ServeMuxOption) *httptest.Server {
+	t.Helper()
+
+	mux := runtime.NewServeMux(opts...)
+	if err := register(context.Background(), mux, s.conn); err != nil {
+		t.Fatalf("failed to register the gateway: %v", err)
+	}
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,107 @@
+package grpctest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/extreme-business/lingo/pkg/grpctest"
+	protomock "github.com/extreme-business/lingo/proto/gen/go/private/mock/v1"
+	protoaccount "github.com/extreme-business/lingo/proto/gen/go/public/account/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockServer struct {
+	protomock.UnimplementedMockServiceServer
+}
+
+func (mockServer) HelloWorld(_ context.Context, req *protomock.HelloWorldRequest) (*protomock.HelloWorldResponse, error) {
+	return &protomock.HelloWorldResponse{Answer: "hello " + req.GetName()}, nil
+}
+
+func registerMock(s grpc.ServiceRegistrar) {
+	protomock.RegisterMockServiceServer(s, mockServer{})
+}
+
+// accountServer lists a single user.
+type accountServer struct {
+	protoaccount.UnimplementedAccountServiceServer
+}
+
+func (accountServer) ListUsers(_ context.Context, req *protoaccount.ListUsersRequest) (*protoaccount.ListUsersResponse, error) {
+	return &protoaccount.ListUsersResponse{Users: []*protoaccount.User{
+		{Name: req.GetParent() + "/users/1", DisplayName: "Jane"},
+	}}, nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []grpctest.Option
+	}{
+		{name: "should serve without TLS"},
+		{name: "should serve with TLS", opts: []grpctest.Option{grpctest.WithTLS()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := grpctest.New(t, registerMock, tt.opts...)
+			client := grpctest.NewClient(s, protomock.NewMockServiceClient)
+
+			resp, err := client.HelloWorld(context.Background(), &protomock.HelloWorldRequest{Name: "jane"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.GetAnswer() != "hello jane" {
+				t.Errorf("expected %q, got %q", "hello jane", resp.GetAnswer())
+			}
+		})
+	}
+}
+
+func TestWithUnaryInterceptors(t *testing.T) {
+	s := grpctest.New(t, registerMock, grpctest.WithUnaryInterceptors(
+		func(context.Context, any, *grpc.UnaryServerInfo, grpc.UnaryHandler) (any, error) {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		},
+	))
+
+	_, err := grpctest.NewClient(s, protomock.NewMockServiceClient).HelloWorld(context.Background(), &protomock.HelloWorldRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected %v, got %v", codes.Unauthenticated, err)
+	}
+}
+
+func TestServer_Gateway(t *testing.T) {
+	s := grpctest.New(t, func(s grpc.ServiceRegistrar) {
+		protoaccount.RegisterAccountServiceServer(s, accountServer{})
+	}, grpctest.WithTLS())
+	srv := s.Gateway(t, protoaccount.RegisterAccountServiceHandler)
+
+	resp, err := srv.Client().Get(srv.URL + "/v1/organizations/1/users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var body struct {
+		Users []struct {
+			Name        string `json:"name"`
+			DisplayName string `json:"displayName"`
+		} `json:"users"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(body.Users) != 1 || body.Users[0].Name != "organizations/1/users/1" || body.Users[0].DisplayName != "Jane" {
+		t.Errorf("expected the user of the organization, got %+v", body.Users)
+	}
+}
@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database"
+)
+
+// Client enqueues jobs.
+type Client struct {
+	clock func() time.Time
+	conn  database.Conn
+}
+
+// NewClient returns a client that enqueues jobs on the connection. Pass the transaction of a
+// database.Manager operation to enqueue the jobs only when the operation commits.
+func NewClient(clock func() time.Time, conn database.Conn) *Client {
+	return &Client{
+		clock: clock,
+		conn:  conn,
+	}
+}
+
+type enqueueOptions struct {
+	uniqueKey   string
+	runTime     time.Time
+	maxAttempts int
+}
+
+// EnqueueOption configures an enqueued job.
+type EnqueueOption func(*enqueueOptions)
+
+// WithUniqueKey makes the job unique: it is not enqueued while another job with the key is waiting, and it
+// does not start while another job with the key runs. So a job enqueued while one runs waits for it and
+// then runs once, picking up what changed while the other ran.
+func WithUniqueKey(key string) EnqueueOption {
+	return func(o *enqueueOptions) { o.uniqueKey = key }
+}
+
+// WithRunTime delays the job until the time. By default it runs as soon as possible.
+func WithRunTime(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) { o.runTime = t }
+}
+
+// WithMaxAttempts sets how often the job is attempted before it fails, it defaults to DefaultMaxAttempts.
+func WithMaxAttempts(n int) EnqueueOption {
+	return func(o *enqueueOptions) { o.maxAttempts = n }
+}
+
+const enqueueQuery = `INSERT INTO jobs (kind, args, unique_key, max_attempts, run_time, create_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (unique_key) WHERE state = 'available' DO NOTHING
+;`
+
+// Enqueue adds a job with the arguments to the queue. It reports whether the job was added,
+// which is false when it is a unique job that is already waiting.
+func (c *Client) Enqueue(ctx context.Context, args Args, opts ...EnqueueOption) (bool, error) {
+	kind := args.Kind()
+	if kind == "" {
+		return false, ErrInvalidKind
+	}
+
+	now := c.clock()
+	o := enqueueOptions{runTime: now, maxAttempts: DefaultMaxAttempts}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal %s job: %w", kind, err)
+	}
+
+	result, err := c.conn.Exec(ctx, enqueueQuery,
+		kind,
+		data,
+		sql.NullString{String: o.uniqueKey, Valid: o.uniqueKey != ""},
+		max(o.maxAttempts, 1),
+		o.runTime,
+		now,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue %s job: %w", kind, err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return n == 1, nil
+}
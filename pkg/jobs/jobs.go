@@ -0,0 +1,107 @@
+// Package jobs is a job queue backed by Postgres.
+//
+// Jobs are rows in the jobs table, see Schema. They are enqueued with a Client, usually on the
+// transaction that makes the change the job follows up on, so the job only exists when the change
+// is committed. A Runner claims the jobs that are due with FOR UPDATE SKIP LOCKED, so any number of
+// runners can work the same table, and runs them with the handlers of a Registry.
+//
+// A claimed job is leased to the runner. A job whose runner stopped without finishing it is claimed
+// again when the lease expires, so a job may run more than once and handlers must be idempotent.
+package jobs
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is how often a job is attempted before it fails.
+	DefaultMaxAttempts = 10
+
+	// BackoffBase is the delay before the second attempt, it doubles with every attempt after that.
+	BackoffBase = 15 * time.Second
+	// BackoffMax is the longest delay between two attempts.
+	BackoffMax = time.Hour
+)
+
+var (
+	ErrNoHandler        = errors.New("no handler for job kind")
+	ErrDuplicateHandler = errors.New("a handler for the job kind is already registered")
+	ErrInvalidKind      = errors.New("job kind is required")
+	ErrInvalidSpec      = errors.New("invalid schedule spec")
+)
+
+// Migrations create and change the tables of the queue. Apps copy each of them into a migration of
+// their own, in the order of their versions and with the same name after the version.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// Schema is all Migrations in order, it creates the tables of the queue as they are now.
+var Schema = mustSchema()
+
+func mustSchema() string {
+	entries, err := fs.ReadDir(Migrations, "migrations")
+	if err != nil {
+		panic(err)
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		data, err := fs.ReadFile(Migrations, path.Join("migrations", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// Args are the arguments of a job. They are stored as JSON, the kind selects the handler.
+type Args interface {
+	Kind() string
+}
+
+// State is the state of a job.
+type State string
+
+const (
+	// StateAvailable jobs run when their run time has come.
+	StateAvailable State = "available"
+	// StateRunning jobs are leased to a runner.
+	StateRunning State = "running"
+	// StateCompleted jobs ran successfully.
+	StateCompleted State = "completed"
+	// StateFailed jobs failed every attempt, they are kept for inspection until they are purged.
+	StateFailed State = "failed"
+)
+
+// Job is a job in the queue.
+type Job struct {
+	ID          int64
+	Kind        string
+	Args        json.RawMessage
+	State       State
+	UniqueKey   string
+	Attempts    int
+	MaxAttempts int
+	RunTime     time.Time
+	LastError   string
+	CreateTime  time.Time
+}
+
+// Backoff returns how long to wait after a failed attempt before the next one.
+func Backoff(attempt int) time.Duration {
+	d := BackoffBase
+	for i := 1; i < attempt && d < BackoffMax; i++ {
+		d *= 2
+	}
+	return min(d, BackoffMax)
+}
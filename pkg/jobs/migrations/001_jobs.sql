@@ -0,0 +1,31 @@
+-- Create jobs table
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(128) NOT NULL,
+    args JSONB NOT NULL,
+    state VARCHAR(16) NOT NULL DEFAULT 'available',
+    unique_key VARCHAR(255),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_time TIMESTAMP NOT NULL,
+    lease_expire_time TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finish_time TIMESTAMP
+);
+
+-- Create index to find the jobs that are due
+CREATE INDEX jobs_due_idx ON jobs (run_time, id) WHERE state IN ('available', 'running');
+
+-- Create index so a unique job is enqueued only once while it is waiting or running
+CREATE UNIQUE INDEX jobs_unique_key_idx ON jobs (unique_key) WHERE state IN ('available', 'running');
+
+-- Create index to purge the finished jobs
+CREATE INDEX jobs_finish_time_idx ON jobs (finish_time) WHERE finish_time IS NOT NULL;
+
+-- Create job schedules table, it keeps when the periodic jobs run next
+CREATE TABLE job_schedules (
+    name VARCHAR(128) PRIMARY KEY,
+    spec VARCHAR(128) NOT NULL,
+    next_time TIMESTAMP NOT NULL
+);
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// handler runs a job with its arguments as JSON.
+type handler func(ctx context.Context, args json.RawMessage) error
+
+// Registry holds the handlers of the job kinds a Runner works.
+type Registry struct {
+	handlers map[string]handler
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: map[string]handler{},
+	}
+}
+
+// Handle registers the handler of the jobs with arguments of type T. T is usually a struct,
+// the kind of its zero value is the kind of the jobs.
+func Handle[T Args](r *Registry, f func(ctx context.Context, args T) error) error {
+	var zero T
+	kind := zero.Kind()
+	if kind == "" {
+		return ErrInvalidKind
+	}
+
+	if _, ok := r.handlers[kind]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateHandler, kind)
+	}
+
+	r.handlers[kind] = func(ctx context.Context, data json.RawMessage) error {
+		var args T
+		if err := json.Unmarshal(data, &args); err != nil {
+			return fmt.Errorf("failed to unmarshal %s job: %w", kind, err)
+		}
+		return f(ctx, args)
+	}
+
+	return nil
+}
+
+// Kinds returns the registered job kinds in order.
+func (r *Registry) Kinds() []string {
+	kinds := make([]string, 0, len(r.handlers))
+	for k := range r.handlers {
+		kinds = append(kinds, k)
+	}
+	slices.Sort(kinds)
+	return kinds
+}
+
+// Run runs the job with the handler of its kind.
+func (r *Registry) Run(ctx context.Context, j *Job) error {
+	h, ok := r.handlers[j.Kind]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoHandler, j.Kind)
+	}
+	return h(ctx, j.Args)
+}
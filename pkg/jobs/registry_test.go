@@ -0,0 +1,72 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+type greetArgs struct {
+	Name string `json:"name"`
+}
+
+func (greetArgs) Kind() string { return "greet" }
+
+type noKindArgs struct{}
+
+func (noKindArgs) Kind() string { return "" }
+
+func TestHandle(t *testing.T) {
+	t.Run("should run the handler of the kind with the decoded arguments", func(t *testing.T) {
+		r := jobs.NewRegistry()
+		var got greetArgs
+		if err := jobs.Handle(r, func(_ context.Context, args greetArgs) error {
+			got = args
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := r.Run(context.Background(), &jobs.Job{Kind: "greet", Args: json.RawMessage(`{"name":"jane"}`)}); err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Name != "jane" {
+			t.Errorf("expected the arguments to be decoded, got %+v", got)
+		}
+
+		if kinds := r.Kinds(); !slices.Equal(kinds, []string{"greet"}) {
+			t.Errorf("Kinds() = %v, want [greet]", kinds)
+		}
+	})
+
+	t.Run("should reject a second handler of a kind", func(t *testing.T) {
+		r := jobs.NewRegistry()
+		f := func(context.Context, greetArgs) error { return nil }
+		if err := jobs.Handle(r, f); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := jobs.Handle(r, f); !errors.Is(err, jobs.ErrDuplicateHandler) {
+			t.Errorf("expected %v, got %v", jobs.ErrDuplicateHandler, err)
+		}
+	})
+
+	t.Run("should reject arguments without a kind", func(t *testing.T) {
+		err := jobs.Handle(jobs.NewRegistry(), func(context.Context, noKindArgs) error { return nil })
+		if !errors.Is(err, jobs.ErrInvalidKind) {
+			t.Errorf("expected %v, got %v", jobs.ErrInvalidKind, err)
+		}
+	})
+
+	t.Run("should return ErrNoHandler for an unknown kind", func(t *testing.T) {
+		err := jobs.NewRegistry().Run(context.Background(), &jobs.Job{Kind: "unknown"})
+		if !errors.Is(err, jobs.ErrNoHandler) {
+			t.Errorf("expected %v, got %v", jobs.ErrNoHandler, err)
+		}
+	})
+}
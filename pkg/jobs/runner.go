@@ -0,0 +1,521 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultWorkers         = 4
+	defaultInterval        = time.Second
+	defaultLeaseTime       = 5 * time.Minute
+	defaultShutdownTimeout = 30 * time.Second
+	defaultRetention       = 7 * 24 * time.Hour
+
+	// purgeInterval is the time between purges of the finished jobs.
+	purgeInterval = time.Hour
+	// finishTimeout limits storing the result of a job, which happens after its context may be canceled.
+	finishTimeout = 10 * time.Second
+	// scheduleKeyPrefix prefixes the unique key of the jobs of a schedule, so the jobs of a slow schedule do not pile up.
+	scheduleKeyPrefix = "schedule:"
+)
+
+// Schedule enqueues a job periodically. A job of the schedule is not enqueued while the previous one
+// is still waiting, and does not start before the previous one finished.
+type Schedule struct {
+	Name string // Name identifies the schedule across runners and restarts.
+	Spec string // Spec says when the job runs, see ParseSpec.
+	Args Args
+}
+
+// Runner claims the jobs that are due and runs them with the handlers of its registry.
+// It also enqueues the jobs of its schedules and purges the finished jobs.
+type Runner struct {
+	logger          *slog.Logger
+	clock           func() time.Time
+	db              *database.DBWrapper
+	dbManager       *database.Manager[database.Conn]
+	registry        *Registry
+	schedules       map[string]schedule
+	workers         int
+	interval        time.Duration
+	leaseTime       time.Duration
+	shutdownTimeout time.Duration
+	retention       time.Duration
+	lastPurge       time.Time
+}
+
+// schedule is a parsed Schedule.
+type schedule struct {
+	Schedule
+	spec Spec
+}
+
+type Config struct {
+	Logger          *slog.Logger
+	Clock           func() time.Time
+	DB              *database.DBWrapper
+	Registry        *Registry
+	Schedules       []Schedule
+	Workers         int           // Workers is the number of jobs run at once, defaults to 4.
+	Interval        time.Duration // Interval is the time between polls for due jobs, defaults to a second.
+	LeaseTime       time.Duration // LeaseTime is how long a job is leased, it is extended while the job runs. Defaults to 5 minutes.
+	ShutdownTimeout time.Duration // ShutdownTimeout is how long running jobs may finish after Run is canceled, defaults to 30 seconds.
+	Retention       time.Duration // Retention is how long finished jobs are kept, defaults to 7 days.
+}
+
+func (c Config) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Clock == nil {
+		return errors.New("clock is required")
+	}
+
+	if c.DB == nil {
+		return errors.New("db is required")
+	}
+
+	if c.Registry == nil {
+		return errors.New("registry is required")
+	}
+
+	for _, s := range c.Schedules {
+		if s.Name == "" {
+			return errors.New("schedule name is required")
+		}
+
+		if s.Args == nil {
+			return fmt.Errorf("args of schedule %s are required", s.Name)
+		}
+
+		if _, ok := c.Registry.handlers[s.Args.Kind()]; !ok {
+			return fmt.Errorf("schedule %s: %w: %s", s.Name, ErrNoHandler, s.Args.Kind())
+		}
+	}
+
+	return nil
+}
+
+func NewRunner(c Config) (*Runner, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := &Runner{
+		logger:          c.Logger,
+		clock:           c.Clock,
+		db:              c.DB,
+		dbManager:       database.NewManager(c.DB, func(c database.Conn) database.Conn { return c }),
+		registry:        c.Registry,
+		schedules:       make(map[string]schedule, len(c.Schedules)),
+		workers:         c.Workers,
+		interval:        c.Interval,
+		leaseTime:       c.LeaseTime,
+		shutdownTimeout: c.ShutdownTimeout,
+		retention:       c.Retention,
+	}
+
+	for _, s := range c.Schedules {
+		if _, ok := r.schedules[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate schedule %s", s.Name)
+		}
+
+		spec, err := ParseSpec(s.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", s.Name, err)
+		}
+
+		r.schedules[s.Name] = schedule{Schedule: s, spec: spec}
+	}
+
+	if r.workers <= 0 {
+		r.workers = defaultWorkers
+	}
+
+	if r.interval <= 0 {
+		r.interval = defaultInterval
+	}
+
+	if r.leaseTime <= 0 {
+		r.leaseTime = defaultLeaseTime
+	}
+
+	if r.shutdownTimeout <= 0 {
+		r.shutdownTimeout = defaultShutdownTimeout
+	}
+
+	if r.retention <= 0 {
+		r.retention = defaultRetention
+	}
+
+	return r, nil
+}
+
+// Run runs jobs until the context is canceled. It then stops claiming jobs and waits for the running
+// jobs to finish. Jobs still running after the shutdown timeout are canceled and released, so they
+// are claimed again without using up an attempt.
+func (r *Runner) Run(ctx context.Context) error {
+	if err := r.registerSchedules(ctx); err != nil {
+		return err
+	}
+
+	// jobs keep running for a while after ctx is canceled.
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, r.workers)
+
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+
+	for {
+		r.tick(ctx)
+
+		for free := r.workers - len(slots); free > 0; free = r.workers - len(slots) {
+			jobs, err := r.claim(ctx, free)
+			if err != nil {
+				r.logger.Error("failed to claim jobs", slog.String("error", err.Error()))
+				break
+			}
+
+			for _, j := range jobs {
+				slots <- struct{}{}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-slots }()
+					r.work(jobCtx, j)
+				}()
+			}
+
+			// keep claiming without waiting while there is a backlog.
+			if len(jobs) < free {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return r.shutdown(&wg, cancelJobs)
+		case <-t.C:
+		}
+	}
+}
+
+// shutdown waits for the running jobs and cancels them when they do not finish in time.
+func (r *Runner) shutdown(wg *sync.WaitGroup, cancelJobs context.CancelFunc) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(r.shutdownTimeout):
+		r.logger.Warn("jobs did not finish in time, canceling them")
+		cancelJobs()
+		<-done
+	}
+
+	return nil
+}
+
+// tick enqueues the jobs of the schedules that are due and purges the finished jobs now and then.
+func (r *Runner) tick(ctx context.Context) {
+	if err := r.enqueueScheduled(ctx); err != nil && ctx.Err() == nil {
+		r.logger.Error("failed to enqueue scheduled jobs", slog.String("error", err.Error()))
+	}
+
+	if now := r.clock(); now.Sub(r.lastPurge) >= purgeInterval {
+		n, err := r.Purge(ctx, now.Add(-r.retention))
+		if err != nil {
+			r.logger.Error("failed to purge jobs", slog.String("error", err.Error()))
+			return
+		}
+
+		r.lastPurge = now
+		if n > 0 {
+			r.logger.Info("purged finished jobs", slog.Int64("count", n))
+		}
+	}
+}
+
+const claimQuery = `UPDATE jobs SET state = 'running', attempts = attempts + 1, lease_expire_time = $2
+WHERE id IN (
+	SELECT id FROM jobs j
+	WHERE kind = ANY($3)
+	AND (
+		(state = 'available' AND run_time <= $1 AND NOT EXISTS (
+			SELECT 1 FROM jobs r WHERE r.unique_key = j.unique_key AND r.state = 'running'
+		))
+		OR (state = 'running' AND lease_expire_time <= $1)
+	)
+	ORDER BY run_time, id
+	LIMIT $4
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING id, kind, args, state, COALESCE(unique_key, ''), attempts, max_attempts, run_time, last_error, create_time
+;`
+
+// claim leases at most limit due jobs to the runner. Jobs with an expired lease are due as well,
+// a unique job is not due while another job with its key runs.
+func (r *Runner) claim(ctx context.Context, limit int) ([]*Job, error) {
+	now := r.clock()
+	rows, err := r.db.Query(ctx, claimQuery, now, now.Add(r.leaseTime), pq.Array(r.registry.Kinds()), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*Job
+	for rows.Next() {
+		var j Job
+		if err = rows.Scan(
+			&j.ID,
+			&j.Kind,
+			&j.Args,
+			&j.State,
+			&j.UniqueKey,
+			&j.Attempts,
+			&j.MaxAttempts,
+			&j.RunTime,
+			&j.LastError,
+			&j.CreateTime,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, &j)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// work runs a claimed job and stores the result.
+func (r *Runner) work(ctx context.Context, j *Job) {
+	logger := r.logger.With(slog.Int64("job_id", j.ID), slog.String("kind", j.Kind), slog.Int("attempt", j.Attempts))
+
+	fctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), finishTimeout)
+	defer cancel()
+
+	// the runner of the last attempt stopped before it finished the job.
+	if j.Attempts > j.MaxAttempts {
+		logger.Error("job failed, its lease expired on the last attempt")
+		if err := r.finish(fctx, j, StateFailed, j.LastError); err != nil {
+			logger.Error("failed to store job result", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	jctx, stop := context.WithCancel(ctx)
+	go r.extendLease(jctx, j)
+	err := r.run(jctx, j)
+	stop()
+
+	switch {
+	case err == nil:
+		err = r.finish(fctx, j, StateCompleted, "")
+	case ctx.Err() != nil:
+		logger.Warn("job canceled by shutdown, releasing it", slog.String("error", err.Error()))
+		err = r.release(fctx, j)
+	case j.Attempts >= j.MaxAttempts:
+		logger.Error("job failed", slog.String("error", err.Error()))
+		err = r.finish(fctx, j, StateFailed, err.Error())
+	default:
+		logger.Warn("job attempt failed, retrying", slog.String("error", err.Error()))
+		err = r.retry(fctx, j, r.clock().Add(Backoff(j.Attempts)), err.Error())
+	}
+
+	if err != nil {
+		logger.Error("failed to store job result", slog.String("error", err.Error()))
+	}
+}
+
+// run runs the job with its handler. A panic is returned as an error.
+func (r *Runner) run(ctx context.Context, j *Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+
+	return r.registry.Run(ctx, j)
+}
+
+const extendLeaseQuery = `UPDATE jobs SET lease_expire_time = $3
+WHERE id = $1 AND attempts = $2 AND state = 'running'
+;`
+
+// extendLease extends the lease of a running job until the context is canceled.
+func (r *Runner) extendLease(ctx context.Context, j *Job) {
+	t := time.NewTicker(r.leaseTime / 2)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if _, err := r.db.Exec(ctx, extendLeaseQuery, j.ID, j.Attempts, r.clock().Add(r.leaseTime)); err != nil && ctx.Err() == nil {
+				r.logger.Warn("failed to extend job lease", slog.Int64("job_id", j.ID), slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// The result of an attempt is only stored while the job is still leased for that attempt,
+// a runner that lost its lease to another must not overwrite the result of the other runner.
+
+const finishQuery = `UPDATE jobs SET state = $3, last_error = $4, finish_time = $5, lease_expire_time = NULL
+WHERE id = $1 AND attempts = $2 AND state = 'running'
+;`
+
+func (r *Runner) finish(ctx context.Context, j *Job, state State, lastError string) error {
+	_, err := r.db.Exec(ctx, finishQuery, j.ID, j.Attempts, state, lastError, r.clock())
+	return err
+}
+
+// A unique job is only made available again when no other job with its key is waiting. Otherwise the
+// waiting job does its work, and the job is finished as failed with the reason.
+
+const retryQuery = `UPDATE jobs SET state = 'available', run_time = $3, last_error = $4, lease_expire_time = NULL
+WHERE id = $1 AND attempts = $2 AND state = 'running'
+AND NOT EXISTS (SELECT 1 FROM jobs w WHERE w.unique_key = jobs.unique_key AND w.state = 'available')
+;`
+
+func (r *Runner) retry(ctx context.Context, j *Job, runTime time.Time, lastError string) error {
+	result, err := r.db.Exec(ctx, retryQuery, j.ID, j.Attempts, runTime, lastError)
+	if err != nil {
+		return err
+	}
+
+	return r.finishSuperseded(ctx, j, result, lastError)
+}
+
+const releaseQuery = `UPDATE jobs SET state = 'available', attempts = attempts - 1, lease_expire_time = NULL
+WHERE id = $1 AND attempts = $2 AND state = 'running'
+AND NOT EXISTS (SELECT 1 FROM jobs w WHERE w.unique_key = jobs.unique_key AND w.state = 'available')
+;`
+
+// release makes a job available again without counting the attempt.
+func (r *Runner) release(ctx context.Context, j *Job) error {
+	result, err := r.db.Exec(ctx, releaseQuery, j.ID, j.Attempts)
+	if err != nil {
+		return err
+	}
+
+	return r.finishSuperseded(ctx, j, result, "canceled by shutdown")
+}
+
+// finishSuperseded finishes a job that was not made available again. Nothing is changed when the job
+// is no longer leased for the attempt either.
+func (r *Runner) finishSuperseded(ctx context.Context, j *Job, result sql.Result, lastError string) error {
+	n, err := result.RowsAffected()
+	if err != nil || n > 0 {
+		return err
+	}
+
+	return r.finish(ctx, j, StateFailed, lastError+", a waiting job with the same unique key takes over")
+}
+
+const purgeQuery = `DELETE FROM jobs WHERE finish_time < $1;`
+
+// Purge deletes the jobs that finished before the time and returns how many were deleted.
+func (r *Runner) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, purgeQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge jobs: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// registerScheduleQuery adds a schedule. The next run time of an existing schedule is kept unless its spec changed.
+const registerScheduleQuery = `INSERT INTO job_schedules (name, spec, next_time) VALUES ($1, $2, $3)
+ON CONFLICT (name) DO UPDATE SET
+	spec = EXCLUDED.spec,
+	next_time = CASE WHEN job_schedules.spec = EXCLUDED.spec THEN job_schedules.next_time ELSE EXCLUDED.next_time END
+;`
+
+// registerSchedules stores the schedules of the runner, so the runners agree on when they run next.
+func (r *Runner) registerSchedules(ctx context.Context) error {
+	now := r.clock()
+	for _, s := range r.schedules {
+		if _, err := r.db.Exec(ctx, registerScheduleQuery, s.Name, s.Spec, s.spec.Next(now)); err != nil {
+			return fmt.Errorf("failed to register schedule %s: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+const dueSchedulesQuery = `SELECT name FROM job_schedules
+WHERE name = ANY($1) AND next_time <= $2
+FOR UPDATE SKIP LOCKED
+;`
+
+const updateScheduleQuery = `UPDATE job_schedules SET next_time = $2 WHERE name = $1;`
+
+// enqueueScheduled enqueues the jobs of the schedules that are due. A schedule is locked while its job is
+// enqueued, so when several runners share the table only one of them enqueues it.
+func (r *Runner) enqueueScheduled(ctx context.Context) error {
+	if len(r.schedules) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(r.schedules))
+	for name := range r.schedules {
+		names = append(names, name)
+	}
+
+	return r.dbManager.BeginOp(ctx, func(ctx context.Context, tx database.Conn) error {
+		now := r.clock()
+		rows, err := tx.Query(ctx, dueSchedulesQuery, pq.Array(names), now)
+		if err != nil {
+			return err
+		}
+
+		var due []string
+		for rows.Next() {
+			var name string
+			if err = rows.Scan(&name); err != nil {
+				rows.Close()
+				return err
+			}
+			due = append(due, name)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		client := NewClient(r.clock, tx)
+		for _, name := range due {
+			s := r.schedules[name]
+			if _, err = client.Enqueue(ctx, s.Args, WithUniqueKey(scheduleKeyPrefix+name)); err != nil {
+				return err
+			}
+
+			if _, err = tx.Exec(ctx, updateScheduleQuery, name, s.spec.Next(now)); err != nil {
+				return fmt.Errorf("failed to update schedule %s: %w", name, err)
+			}
+		}
+
+		return nil
+	})
+}
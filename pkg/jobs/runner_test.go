@@ -0,0 +1,206 @@
+package jobs_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/database"
+	"github.com/extreme-business/lingo/pkg/database/dbtest"
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+type failArgs struct{}
+
+func (failArgs) Kind() string { return "fail" }
+
+func setupTestDB(ctx context.Context, t *testing.T) *sql.DB {
+	t.Helper()
+	dbc := dbtest.SetupPostgres(ctx, t, "jobs")
+	db := dbtest.Connect(ctx, t, dbc.ConnectionString)
+	if _, err := db.ExecContext(ctx, jobs.Schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	return db
+}
+
+// jobState returns the state of the only job of a kind.
+func jobState(ctx context.Context, t *testing.T, db *sql.DB, kind string) (jobs.State, int, string) {
+	t.Helper()
+	var state jobs.State
+	var attempts int
+	var lastError string
+	if err := db.QueryRowContext(ctx, `SELECT state, attempts, last_error FROM jobs WHERE kind = $1`, kind).
+		Scan(&state, &attempts, &lastError); err != nil {
+		t.Fatal(err)
+	}
+	return state, attempts, lastError
+}
+
+// waitFor polls until f returns true.
+func waitFor(t *testing.T, f func() bool) {
+	t.Helper()
+	for range 200 {
+		if f() {
+			return
+		}
+		time.Sleep(25 * time.Millisecond)
+	}
+	t.Fatal("condition not met in time")
+}
+
+func TestRunner(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := setupTestDB(ctx, t)
+	dbWrapper := database.NewDBWrapper(db)
+	dbManager := database.NewManager(dbWrapper, func(c database.Conn) *jobs.Client { return jobs.NewClient(time.Now, c) })
+
+	t.Run("Enqueue should only add the job when the transaction commits", func(t *testing.T) {
+		errRollback := errors.New("rollback")
+		if err := dbManager.BeginOp(ctx, func(ctx context.Context, c *jobs.Client) error {
+			if _, err := c.Enqueue(ctx, greetArgs{Name: "jane"}); err != nil {
+				return err
+			}
+			return errRollback
+		}); !errors.Is(err, errRollback) {
+			t.Fatalf("expected the operation to fail, got %v", err)
+		}
+
+		var n int
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM jobs`).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 0 {
+			t.Errorf("expected no jobs, got %d", n)
+		}
+	})
+
+	t.Run("Enqueue should add a unique job once", func(t *testing.T) {
+		c := dbManager.Op()
+		for i, want := range []bool{true, false} {
+			ok, err := c.Enqueue(ctx, greetArgs{Name: "john"}, jobs.WithUniqueKey("greet:john"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ok != want {
+				t.Errorf("enqueue %d: expected %v, got %v", i, want, ok)
+			}
+		}
+	})
+
+	t.Run("Enqueue should add a unique job once while another one runs", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, `UPDATE jobs SET state = 'running' WHERE unique_key = 'greet:john'`); err != nil {
+			t.Fatal(err)
+		}
+
+		c := dbManager.Op()
+		for i, want := range []bool{true, false} {
+			ok, err := c.Enqueue(ctx, greetArgs{Name: "john"}, jobs.WithUniqueKey("greet:john"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ok != want {
+				t.Errorf("enqueue %d: expected %v, got %v", i, want, ok)
+			}
+		}
+
+		// the waiting job runs once the running one finished.
+		if _, err := db.ExecContext(ctx, `UPDATE jobs SET state = 'completed', finish_time = NOW() WHERE unique_key = 'greet:john' AND state = 'running'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Run should run the jobs and enqueue the scheduled jobs", func(t *testing.T) {
+		greeted := make(chan string, 16)
+		r := jobs.NewRegistry()
+		if err := jobs.Handle(r, func(_ context.Context, args greetArgs) error {
+			greeted <- args.Name
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := jobs.Handle(r, func(context.Context, failArgs) error {
+			return errors.New("always fails")
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := dbManager.Op().Enqueue(ctx, failArgs{}, jobs.WithMaxAttempts(1)); err != nil {
+			t.Fatal(err)
+		}
+
+		runner, err := jobs.NewRunner(jobs.Config{
+			Logger:   slog.Default(),
+			Clock:    time.Now,
+			DB:       dbWrapper,
+			Registry: r,
+			Schedules: []jobs.Schedule{
+				{Name: "greet-every-second", Spec: "@every 1s", Args: greetArgs{Name: "scheduled"}},
+			},
+			Interval: 10 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rctx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() { done <- runner.Run(rctx) }()
+
+		names := map[string]bool{}
+		for !names["john"] || !names["scheduled"] {
+			select {
+			case name := <-greeted:
+				names[name] = true
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected the enqueued and scheduled jobs to run, got %v", names)
+			}
+		}
+
+		waitFor(t, func() bool {
+			state, _, _ := jobState(ctx, t, db, "fail")
+			return state == jobs.StateFailed
+		})
+
+		if _, attempts, lastError := jobState(ctx, t, db, "fail"); attempts != 1 || lastError != "always fails" {
+			t.Errorf("expected one failed attempt, got %d attempts with error %q", attempts, lastError)
+		}
+
+		cancel()
+		if err = <-done; err != nil {
+			t.Errorf("expected Run to stop without an error, got %v", err)
+		}
+	})
+
+	t.Run("Purge should delete the finished jobs", func(t *testing.T) {
+		runner, err := jobs.NewRunner(jobs.Config{
+			Logger:   slog.Default(),
+			Clock:    time.Now,
+			DB:       dbWrapper,
+			Registry: jobs.NewRegistry(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := runner.Purge(ctx, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n == 0 {
+			t.Error("expected the finished jobs to be purged")
+		}
+	})
+}
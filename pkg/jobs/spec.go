@@ -0,0 +1,190 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Spec says when a periodic job runs.
+type Spec interface {
+	// Next returns the first run time after t.
+	Next(t time.Time) time.Time
+}
+
+// every runs a job at a fixed interval.
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time { return t.Add(time.Duration(e)) }
+
+// cron runs a job at the times that match all of its fields. The fields are bit sets of the
+// minutes, hours, days of the month, months and days of the week the job runs.
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	// anyDay is set when either the day of the month or the day of the week is "*". Otherwise a
+	// day matches when either field matches, as in crontab(5).
+	anyDay bool
+}
+
+// maxSearchYears bounds the search for a time that matches a cron spec, such as February 30th that never does.
+const maxSearchYears = 5
+
+// Next steps through the wall clock of the location of t with time.Date, so it also works in locations whose
+// offset is not a whole number of hours. A time that is skipped when the clocks go forward does not match,
+// and a time that repeats when they go back matches once.
+func (c *cron) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := forward(after, time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, loc))
+	end := after.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(end) {
+		var next time.Time
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchDay(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+		default:
+			return t
+		}
+		t = forward(t, next)
+	}
+
+	return time.Time{}
+}
+
+// forward returns next when it is after t. When the clocks go back, the wall clock time of next can be
+// resolved to a time before t, forward then returns the next minute after t instead.
+func forward(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Truncate(time.Minute).Add(time.Minute)
+}
+
+func (c *cron) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// descriptors are the shorthands of common cron specs.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSpec parses a schedule spec: a crontab(5) expression with the fields minute, hour, day of the month,
+// month and day of the week, one of the descriptors such as "@daily", or "@every" and a duration such as "@every 5s".
+// Times are matched in the location of the time passed to Next.
+func ParseSpec(s string) (Spec, error) {
+	s = strings.TrimSpace(s)
+	if d, ok := strings.CutPrefix(s, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil || interval < time.Second {
+			return nil, fmt.Errorf("%w: %q: the interval must be a duration of at least a second", ErrInvalidSpec, s)
+		}
+		return every(interval), nil
+	}
+
+	if expr, ok := descriptors[s]; ok {
+		s = expr
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q: expected 5 fields, got %d", ErrInvalidSpec, s, len(fields))
+	}
+
+	c := &cron{anyDay: fields[2] == "*" || fields[4] == "*"}
+	for i, f := range []struct {
+		set      *uint64
+		min, max int
+	}{
+		{&c.minute, 0, 59},
+		{&c.hour, 0, 23},
+		{&c.dom, 1, 31},
+		{&c.month, 1, 12},
+		{&c.dow, 0, 7},
+	} {
+		set, err := parseField(fields[i], f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidSpec, s, err)
+		}
+		*f.set = set
+	}
+
+	// both 0 and 7 are sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	return c, nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps such as "*/15" or "1-5,10".
+func parseField(field string, lo, hi int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		expr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepExpr)
+			}
+		}
+
+		start, end := lo, hi
+		switch from, to, isRange := strings.Cut(expr, "-"); {
+		case expr == "*":
+		case isRange:
+			var err error
+			if start, err = parseValue(from, lo, hi); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(to, lo, hi); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q", expr)
+			}
+		default:
+			v, err := parseValue(expr, lo, hi)
+			if err != nil {
+				return 0, err
+			}
+			start = v
+			if !hasStep {
+				end = v
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(s string, lo, hi int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < lo || v > hi {
+		return 0, fmt.Errorf("invalid value %q, expected %d-%d", s, lo, hi)
+	}
+	return v, nil
+}
@@ -0,0 +1,137 @@
+package jobs_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extreme-business/lingo/pkg/jobs"
+)
+
+func TestParseSpec(t *testing.T) {
+	// a wednesday
+	now := time.Date(2024, 5, 15, 10, 42, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		want time.Time
+	}{
+		{name: "should run every minute", spec: "* * * * *", want: time.Date(2024, 5, 15, 10, 43, 0, 0, time.UTC)},
+		{name: "should run every 15 minutes", spec: "*/15 * * * *", want: time.Date(2024, 5, 15, 10, 45, 0, 0, time.UTC)},
+		{name: "should run at a time of day", spec: "30 3 * * *", want: time.Date(2024, 5, 16, 3, 30, 0, 0, time.UTC)},
+		{name: "should run on a list of hours", spec: "0 8,12,18 * * *", want: time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)},
+		{name: "should run on weekdays", spec: "0 9 * * 1-5", want: time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC)},
+		{name: "should treat 7 as sunday", spec: "0 0 * * 7", want: time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "should run on a day of the month or week", spec: "0 0 1 * 5", want: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+		{name: "should run in a month", spec: "0 0 1 2 *", want: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "should run hourly", spec: "@hourly", want: time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC)},
+		{name: "should run daily", spec: "@daily", want: time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "should run at an interval", spec: "@every 5s", want: now.Add(5 * time.Second)},
+		{name: "should never run on a day that does not exist", spec: "0 0 30 2 *", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := jobs.ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := spec.Next(now); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpec_location(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "should run at a time of day in a location with a half hour offset",
+			spec: "0 12 * * *",
+			now:  time.Date(2024, 5, 15, 10, 43, 0, 0, kolkata),
+			want: time.Date(2024, 5, 15, 12, 0, 0, 0, kolkata),
+		},
+		{
+			name: "should run hourly in a location with a half hour offset",
+			spec: "@hourly",
+			now:  time.Date(2024, 5, 15, 10, 43, 0, 0, kolkata),
+			want: time.Date(2024, 5, 15, 11, 0, 0, 0, kolkata),
+		},
+		{
+			name: "should skip a time the clocks skip",
+			spec: "30 2 * * *",
+			now:  time.Date(2024, 3, 10, 0, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 11, 2, 30, 0, 0, newYork),
+		},
+		{
+			name: "should run after the clocks go forward",
+			spec: "0 3 * * *",
+			now:  time.Date(2024, 3, 10, 1, 30, 0, 0, newYork),
+			want: time.Date(2024, 3, 10, 3, 0, 0, 0, newYork),
+		},
+		{
+			name: "should run a time the clocks repeat once",
+			spec: "30 1 * * *",
+			now:  time.Date(2024, 11, 3, 1, 30, 0, 0, newYork),
+			want: time.Date(2024, 11, 4, 1, 30, 0, 0, newYork),
+		},
+		{
+			name: "should run after a time the clocks repeat",
+			spec: "0 2 * * *",
+			now:  time.Date(2024, 11, 3, 1, 30, 0, 0, newYork).Add(time.Hour),
+			want: time.Date(2024, 11, 3, 2, 0, 0, 0, newYork),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := jobs.ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := spec.Next(tt.now); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpec_invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every 10ms",
+		"@every soon",
+		"@sometimes",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := jobs.ParseSpec(spec); !errors.Is(err, jobs.ErrInvalidSpec) {
+				t.Errorf("expected %v, got %v", jobs.ErrInvalidSpec, err)
+			}
+		})
+	}
+}
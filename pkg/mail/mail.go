@@ -0,0 +1,187 @@
+// Package mail sends email. A Sender delivers a Message, with SMTP, to a Maildir or to memory for tests.
+// Templates render localized messages, and a Queue keeps messages on disk until they are sent.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoSender     = errors.New("message has no sender")
+	ErrNoRecipients = errors.New("message has no recipients")
+	ErrInvalidEmail = errors.New("invalid email address")
+	ErrNoBody       = errors.New("message has no body")
+)
+
+// Sender sends messages.
+type Sender interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+// Message is an email with a plain text body and an optional HTML alternative.
+type Message struct {
+	From    string   // From is the sender, such as "Lingo <no-reply@example.com>".
+	To      []string // To are the recipients.
+	Subject string
+	Text    string
+	HTML    string // HTML is optional, clients that show HTML prefer it over the text.
+}
+
+// Validate checks the addresses and the body of the message.
+func (m *Message) Validate() error {
+	if m.From == "" {
+		return ErrNoSender
+	}
+	if _, err := mail.ParseAddress(m.From); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidEmail, m.From)
+	}
+
+	if len(m.To) == 0 {
+		return ErrNoRecipients
+	}
+	for _, to := range m.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidEmail, to)
+		}
+	}
+
+	if m.Text == "" && m.HTML == "" {
+		return ErrNoBody
+	}
+
+	return nil
+}
+
+// Bytes encodes the message as an RFC 5322 message. A message with an HTML body is sent
+// as multipart/alternative, both parts are quoted-printable.
+func (m *Message) Bytes(date time.Time) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	from, _ := mail.ParseAddress(m.From)
+	id, err := messageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	h := textproto.MIMEHeader{}
+	h.Set("From", from.String())
+	h.Set("To", formatAddresses(m.To))
+	h.Set("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	h.Set("Date", date.Format(time.RFC1123Z))
+	h.Set("Message-ID", id)
+	h.Set("MIME-Version", "1.0")
+
+	if m.HTML == "" {
+		h.Set("Content-Type", "text/plain; charset=utf-8")
+		h.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeader(&buf, h)
+		if err = writeQuotedPrintable(&buf, m.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	h.Set("Content-Type", "multipart/alternative; boundary="+w.Boundary())
+	writeHeader(&buf, h)
+
+	// the preferred alternative comes last.
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = writeQuotedPrintable(pw, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+// writeHeader writes the header in a fixed order, followed by the empty line that ends it.
+func writeHeader(w io.Writer, h textproto.MIMEHeader) {
+	for _, k := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"} {
+		if v := h.Get(k); v != "" {
+			fmt.Fprintf(w, "%s: %s\r\n", k, v)
+		}
+	}
+	fmt.Fprint(w, "\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, s string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := io.WriteString(qp, strings.ReplaceAll(s, "\n", "\r\n")); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// formatAddresses formats the addresses for a header, encoding names that are not ASCII.
+func formatAddresses(addresses []string) string {
+	out := make([]string, 0, len(addresses))
+	for _, a := range addresses {
+		parsed, err := mail.ParseAddress(a)
+		if err != nil {
+			continue
+		}
+		out = append(out, parsed.String())
+	}
+	return strings.Join(out, ", ")
+}
+
+// messageID returns a unique message id in the domain of the address.
+func messageID(address string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		domain = address[i+1:]
+	}
+
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
+
+// addresses returns the plain addresses of the recipients, such as "jane@example.com".
+func addresses(to []string) ([]string, error) {
+	out := make([]string, 0, len(to))
+	for _, a := range to {
+		parsed, err := mail.ParseAddress(a)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidEmail, a)
+		}
+		out = append(out, parsed.Address)
+	}
+	return out, nil
+}
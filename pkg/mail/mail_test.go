@@ -0,0 +1,142 @@
+package mail_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	lingomail "github.com/extreme-business/lingo/pkg/mail"
+)
+
+var sendTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		message lingomail.Message
+		err     error
+	}{
+		{
+			name:    "valid",
+			message: lingomail.Message{From: "Lingo <no-reply@example.com>", To: []string{"jane@example.com"}, Text: "hello"},
+		},
+		{
+			name:    "no sender",
+			message: lingomail.Message{To: []string{"jane@example.com"}, Text: "hello"},
+			err:     lingomail.ErrNoSender,
+		},
+		{
+			name:    "no recipients",
+			message: lingomail.Message{From: "no-reply@example.com", Text: "hello"},
+			err:     lingomail.ErrNoRecipients,
+		},
+		{
+			name:    "invalid recipient",
+			message: lingomail.Message{From: "no-reply@example.com", To: []string{"jane"}, Text: "hello"},
+			err:     lingomail.ErrInvalidEmail,
+		},
+		{
+			name:    "no body",
+			message: lingomail.Message{From: "no-reply@example.com", To: []string{"jane@example.com"}},
+			err:     lingomail.ErrNoBody,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.message.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	t.Run("should encode a multipart message", func(t *testing.T) {
+		m := &lingomail.Message{
+			From:    "Lingo <no-reply@example.com>",
+			To:      []string{"Jäne <jane@example.com>", "john@example.com"},
+			Subject: "Wëlcome",
+			Text:    "Hello Jane,\nwelcome.",
+			HTML:    "<p>Hello Jane,</p>",
+		}
+
+		b, err := m.Bytes(sendTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg, err := mail.ReadMessage(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		if err != nil || subject != "Wëlcome" {
+			t.Errorf("expected the subject to be encoded, got %q", msg.Header.Get("Subject"))
+		}
+
+		to, err := msg.Header.AddressList("To")
+		if err != nil || len(to) != 2 || to[0].Name != "Jäne" || to[1].Address != "john@example.com" {
+			t.Errorf("expected two recipients, got %v, %v", to, err)
+		}
+
+		if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+			t.Errorf("expected a message id in the domain of the sender, got %q", msg.Header.Get("Message-ID"))
+		}
+
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/alternative" {
+			t.Fatalf("expected a multipart message, got %q", msg.Header.Get("Content-Type"))
+		}
+
+		var parts []string
+		r := multipart.NewReader(msg.Body, params["boundary"])
+		for {
+			p, err := r.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body, err := io.ReadAll(quotedprintable.NewReader(p))
+			if err != nil {
+				t.Fatal(err)
+			}
+			parts = append(parts, p.Header.Get("Content-Type")+": "+string(body))
+		}
+
+		want := []string{
+			"text/plain; charset=utf-8: Hello Jane,\r\nwelcome.",
+			"text/html; charset=utf-8: <p>Hello Jane,</p>",
+		}
+		if strings.Join(parts, "|") != strings.Join(want, "|") {
+			t.Errorf("expected parts %q, got %q", want, parts)
+		}
+	})
+
+	t.Run("should encode a text message", func(t *testing.T) {
+		m := &lingomail.Message{From: "no-reply@example.com", To: []string{"jane@example.com"}, Text: "hello"}
+		b, err := m.Bytes(sendTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg, err := mail.ReadMessage(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ct := msg.Header.Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+			t.Errorf("expected a text message, got %q", ct)
+		}
+	})
+}
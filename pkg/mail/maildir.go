@@ -0,0 +1,75 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Maildir writes messages to a Maildir, where any mail client can read them.
+// It is meant for development, to read the mail that would have been sent.
+type Maildir struct {
+	dir   string
+	clock func() time.Time
+}
+
+// NewMaildir returns a Maildir sender for the directory, creating its tmp, new and cur directories.
+func NewMaildir(dir string, clock func() time.Time) (*Maildir, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create maildir: %w", err)
+		}
+	}
+
+	return &Maildir{
+		dir:   dir,
+		clock: clock,
+	}, nil
+}
+
+// Send delivers the message to the new directory. It is written to tmp first, so readers never see a partial message.
+func (d *Maildir) Send(_ context.Context, m *Message) error {
+	now := d.clock()
+	b, err := m.Bytes(now)
+	if err != nil {
+		return err
+	}
+
+	name, err := uniqueName(now)
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(d.dir, "tmp", name)
+	if err = os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp, filepath.Join(d.dir, "new", name)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
+
+// uniqueName returns a file name that is unique within the directories of the sender, sorted by time.
+func uniqueName(now time.Time) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+	host = strings.NewReplacer("/", `\057`, ":", `\072`).Replace(host)
+
+	return fmt.Sprintf("%d.%s.%s", now.UnixNano(), hex.EncodeToString(b), host), nil
+}
@@ -0,0 +1,48 @@
+package mail_test
+
+import (
+	"bytes"
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lingomail "github.com/extreme-business/lingo/pkg/mail"
+)
+
+func TestMaildir_Send(t *testing.T) {
+	dir := t.TempDir()
+	d, err := lingomail.NewMaildir(dir, func() time.Time { return sendTime })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = d.Send(context.Background(), &lingomail.Message{From: "no-reply@example.com", To: []string{"jane@example.com"}, Subject: "hello", Text: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(dir, "new"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected a new message, got %v, %v", files, err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "new", files[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := msg.Header.Get("Subject"); got != "hello" {
+		t.Errorf("expected subject hello, got %q", got)
+	}
+
+	if tmp, _ := os.ReadDir(filepath.Join(dir, "tmp")); len(tmp) != 0 {
+		t.Errorf("expected tmp to be empty, got %d files", len(tmp))
+	}
+}
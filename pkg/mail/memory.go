@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemorySender keeps the messages it is sent, for tests.
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []*Message
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(_ context.Context, m *Message) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	c := *m
+	c.To = slices.Clone(m.To)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, &c)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (s *MemorySender) Messages() []*Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.messages)
+}
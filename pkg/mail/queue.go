@@ -0,0 +1,299 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	defaultQueueInterval    = 5 * time.Second
+	defaultQueueMaxAttempts = 8
+
+	// BackoffBase is the delay before the second attempt, it doubles with every attempt after that.
+	BackoffBase = 30 * time.Second
+	// BackoffMax is the longest delay between two attempts.
+	BackoffMax = time.Hour
+
+	pendingDir = "pending"
+	deadDir    = "dead"
+)
+
+// Backoff returns how long to wait after a failed attempt before the next one.
+func Backoff(attempt int) time.Duration {
+	d := BackoffBase
+	for i := 1; i < attempt && d < BackoffMax; i++ {
+		d *= 2
+	}
+	return min(d, BackoffMax)
+}
+
+// Entry is a message in a Queue.
+type Entry struct {
+	ID          string    `json:"id"`
+	Message     *Message  `json:"message"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	CreateTime  time.Time `json:"create_time"`
+}
+
+// Queue keeps messages on disk until its sender has sent them, so a message survives a restart
+// and a failing mail server does not fail the operation that sends it.
+//
+// A failed attempt is retried with exponential backoff until MaxAttempts is reached, after which
+// the message is moved to the dead letters. Every message is a JSON file in the "pending" or "dead"
+// directory of the queue; a dead letter is sent again by moving its file back to "pending".
+// Only one process may run a queue directory.
+type Queue struct {
+	logger      *slog.Logger
+	clock       func() time.Time
+	dir         string
+	sender      Sender
+	interval    time.Duration
+	maxAttempts int
+}
+
+var _ Sender = (*Queue)(nil)
+
+type QueueConfig struct {
+	Logger      *slog.Logger
+	Clock       func() time.Time
+	Dir         string        // Dir is the directory of the queue, it is created when it does not exist.
+	Sender      Sender        // Sender sends the queued messages.
+	Interval    time.Duration // Interval is the time between runs, defaults to 5 seconds.
+	MaxAttempts int           // MaxAttempts is how often a message is attempted before it is a dead letter, defaults to 8.
+}
+
+func (c QueueConfig) Validate() error {
+	if c.Logger == nil {
+		return errors.New("logger is required")
+	}
+
+	if c.Clock == nil {
+		return errors.New("clock is required")
+	}
+
+	if c.Dir == "" {
+		return errors.New("dir is required")
+	}
+
+	if c.Sender == nil {
+		return errors.New("sender is required")
+	}
+
+	return nil
+}
+
+func NewQueue(c QueueConfig) (*Queue, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	q := &Queue{
+		logger:      c.Logger,
+		clock:       c.Clock,
+		dir:         c.Dir,
+		sender:      c.Sender,
+		interval:    c.Interval,
+		maxAttempts: c.MaxAttempts,
+	}
+
+	if q.interval <= 0 {
+		q.interval = defaultQueueInterval
+	}
+
+	if q.maxAttempts <= 0 {
+		q.maxAttempts = defaultQueueMaxAttempts
+	}
+
+	for _, sub := range []string{pendingDir, deadDir} {
+		if err := os.MkdirAll(filepath.Join(c.Dir, sub), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create mail queue: %w", err)
+		}
+	}
+
+	return q, nil
+}
+
+// Send queues the message. It is sent by Run.
+func (q *Queue) Send(_ context.Context, m *Message) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	now := q.clock()
+	id, err := entryID(now)
+	if err != nil {
+		return err
+	}
+
+	return q.write(pendingDir, &Entry{
+		ID:          id,
+		Message:     m,
+		NextAttempt: now,
+		CreateTime:  now,
+	})
+}
+
+// Run sends the queued messages every interval until the context is canceled.
+func (q *Queue) Run(ctx context.Context) error {
+	t := time.NewTicker(q.interval)
+	defer t.Stop()
+
+	for {
+		if _, err := q.Deliver(ctx); err != nil {
+			q.logger.Error("failed to deliver mail", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// Deliver attempts the messages that are due, oldest first, and returns how many were attempted.
+func (q *Queue) Deliver(ctx context.Context) (int, error) {
+	entries, err := q.list(pendingDir)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	now := q.clock()
+	for _, e := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if e.NextAttempt.After(now) {
+			continue
+		}
+
+		n++
+		if err = q.attempt(ctx, e); err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// Pending returns the messages waiting to be sent, oldest first.
+func (q *Queue) Pending() ([]*Entry, error) {
+	return q.list(pendingDir)
+}
+
+// Dead returns the messages that could not be sent, oldest first.
+func (q *Queue) Dead() ([]*Entry, error) {
+	return q.list(deadDir)
+}
+
+// attempt sends a message, and schedules a retry or moves it to the dead letters when that fails.
+func (q *Queue) attempt(ctx context.Context, e *Entry) error {
+	sendErr := q.sender.Send(ctx, e.Message)
+	if sendErr == nil {
+		return os.Remove(q.path(pendingDir, e.ID))
+	}
+
+	e.Attempts++
+	e.LastError = sendErr.Error()
+	if e.Attempts < q.maxAttempts {
+		e.NextAttempt = q.clock().Add(Backoff(e.Attempts))
+		return q.write(pendingDir, e)
+	}
+
+	q.logger.Warn("mail could not be sent, moved to the dead letters",
+		slog.String("id", e.ID),
+		slog.Int("attempts", e.Attempts),
+		slog.String("error", e.LastError),
+	)
+
+	if err := q.write(deadDir, e); err != nil {
+		return err
+	}
+	return os.Remove(q.path(pendingDir, e.ID))
+}
+
+// write writes an entry to a directory of the queue, replacing the file at once.
+func (q *Queue) write(dir string, e *Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Join(q.dir, dir), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// sync, so a queued message is on disk before Send returns.
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), q.path(dir, e.ID))
+}
+
+// list reads the entries of a directory of the queue, oldest first.
+func (q *Queue) list(dir string) ([]*Entry, error) {
+	files, err := fs.Glob(os.DirFS(filepath.Join(q.dir, dir)), "*.json")
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+
+	entries := make([]*Entry, 0, len(files))
+	for _, f := range files {
+		b, err := os.ReadFile(filepath.Join(q.dir, dir, f))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		var e Entry
+		if err = json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("failed to read queued mail %s: %w", f, err)
+		}
+		entries = append(entries, &e)
+	}
+
+	return entries, nil
+}
+
+func (q *Queue) path(dir, id string) string {
+	return filepath.Join(q.dir, dir, id+".json")
+}
+
+// entryID returns a unique id that sorts by the time it was created.
+func entryID(now time.Time) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%020d-%s", now.UnixNano(), hex.EncodeToString(b)), nil
+}
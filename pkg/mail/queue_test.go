@@ -0,0 +1,143 @@
+package mail_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	lingomail "github.com/extreme-business/lingo/pkg/mail"
+)
+
+// senderFunc adapts a function to a Sender.
+type senderFunc func(context.Context, *lingomail.Message) error
+
+func (f senderFunc) Send(ctx context.Context, m *lingomail.Message) error { return f(ctx, m) }
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 20, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := lingomail.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	message := &lingomail.Message{From: "no-reply@example.com", To: []string{"jane@example.com"}, Subject: "hello", Text: "hello"}
+
+	newQueue := func(t *testing.T, dir string, now *time.Time, sender lingomail.Sender) *lingomail.Queue {
+		t.Helper()
+		q, err := lingomail.NewQueue(lingomail.QueueConfig{
+			Logger:      slog.Default(),
+			Clock:       func() time.Time { return *now },
+			Dir:         dir,
+			Sender:      sender,
+			MaxAttempts: 2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q
+	}
+
+	t.Run("should send a queued message once", func(t *testing.T) {
+		now := sendTime
+		sender := lingomail.NewMemorySender()
+		q := newQueue(t, t.TempDir(), &now, sender)
+
+		if err := q.Send(ctx, message); err != nil {
+			t.Fatal(err)
+		}
+
+		for range 2 {
+			if _, err := q.Deliver(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if got := sender.Messages(); len(got) != 1 || got[0].Subject != "hello" {
+			t.Errorf("expected the message to be sent once, got %+v", got)
+		}
+
+		if pending, _ := q.Pending(); len(pending) != 0 {
+			t.Errorf("expected no pending messages, got %d", len(pending))
+		}
+	})
+
+	t.Run("should keep a message across restarts", func(t *testing.T) {
+		now := sendTime
+		dir := t.TempDir()
+		if err := newQueue(t, dir, &now, lingomail.NewMemorySender()).Send(ctx, message); err != nil {
+			t.Fatal(err)
+		}
+
+		sender := lingomail.NewMemorySender()
+		if n, err := newQueue(t, dir, &now, sender).Deliver(ctx); err != nil || n != 1 {
+			t.Fatalf("expected one attempt, got %d, %v", n, err)
+		}
+
+		if len(sender.Messages()) != 1 {
+			t.Error("expected the message to be sent after a restart")
+		}
+	})
+
+	t.Run("should retry a failed message and then move it to the dead letters", func(t *testing.T) {
+		now := sendTime
+		var attempts int
+		q := newQueue(t, t.TempDir(), &now, senderFunc(func(context.Context, *lingomail.Message) error {
+			attempts++
+			return errors.New("connection refused")
+		}))
+
+		if err := q.Send(ctx, message); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := q.Deliver(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		pending, err := q.Pending()
+		if err != nil || len(pending) != 1 || pending[0].Attempts != 1 || !pending[0].NextAttempt.Equal(now.Add(lingomail.BackoffBase)) {
+			t.Fatalf("expected a retry after the backoff, got %+v, %v", pending, err)
+		}
+
+		// the retry is not due yet.
+		if n, _ := q.Deliver(ctx); n != 0 {
+			t.Errorf("expected no attempts before the backoff, got %d", n)
+		}
+
+		now = now.Add(lingomail.BackoffBase)
+		if _, err = q.Deliver(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		dead, err := q.Dead()
+		if err != nil || len(dead) != 1 || dead[0].LastError != "connection refused" {
+			t.Fatalf("expected a dead letter, got %+v, %v", dead, err)
+		}
+
+		if pending, _ = q.Pending(); len(pending) != 0 || attempts != 2 {
+			t.Errorf("expected 2 attempts and no pending messages, got %d and %d", attempts, len(pending))
+		}
+	})
+
+	t.Run("should not queue an invalid message", func(t *testing.T) {
+		now := sendTime
+		q := newQueue(t, t.TempDir(), &now, lingomail.NewMemorySender())
+		if err := q.Send(ctx, &lingomail.Message{}); !errors.Is(err, lingomail.ErrNoSender) {
+			t.Errorf("Send() error = %v, want %v", err, lingomail.ErrNoSender)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	ErrUnknownTemplate = errors.New("unknown mail template")
+	ErrNoSubject       = errors.New("mail template has no subject")
+)
+
+// localized is a template in one language.
+type localized struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template // html is nil for a template without an HTML body.
+}
+
+// template is a template in all its languages.
+type template struct {
+	tags     []language.Tag
+	matcher  language.Matcher
+	versions []*localized // versions is in the order of tags.
+}
+
+// Templates renders messages from templates in several languages.
+//
+// A template is a text file named "<name>.<language>.txt" with an optional HTML file "<name>.<language>.html"
+// next to it, such as "welcome.en.txt" and "welcome.en.html". The text file defines the subject with
+// {{define "subject"}}...{{end}}, the rest of it is the text body. The HTML file is an html/template.
+// Each template needs a version in the default language, which is used when no other language matches.
+type Templates struct {
+	templates map[string]*template
+}
+
+// ParseTemplates parses the templates in the root of fsys, such as an embed.FS.
+func ParseTemplates(fsys fs.FS, defaultLanguage string) (*Templates, error) {
+	defaultTag, err := language.Parse(defaultLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default language %q: %w", defaultLanguage, err)
+	}
+
+	files, err := fs.Glob(fsys, "*.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]map[language.Tag]*localized{}
+	for _, file := range files {
+		name, lang, ok := strings.Cut(strings.TrimSuffix(file, ".txt"), ".")
+		if !ok {
+			return nil, fmt.Errorf("mail template %q should be named <name>.<language>.txt", file)
+		}
+
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("mail template %q has an invalid language: %w", file, err)
+		}
+
+		l, err := parseLocalized(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if versions[name] == nil {
+			versions[name] = map[language.Tag]*localized{}
+		}
+		versions[name][tag] = l
+	}
+
+	t := &Templates{templates: make(map[string]*template, len(versions))}
+	for name, byTag := range versions {
+		d, ok := byTag[defaultTag]
+		if !ok {
+			return nil, fmt.Errorf("mail template %q has no version in the default language %s", name, defaultTag)
+		}
+
+		// the default comes first, the matcher falls back to it.
+		tmpl := &template{tags: []language.Tag{defaultTag}, versions: []*localized{d}}
+		others := make([]language.Tag, 0, len(byTag))
+		for tag := range byTag {
+			if tag != defaultTag {
+				others = append(others, tag)
+			}
+		}
+		slices.SortFunc(others, func(a, b language.Tag) int { return strings.Compare(a.String(), b.String()) })
+		for _, tag := range others {
+			tmpl.tags = append(tmpl.tags, tag)
+			tmpl.versions = append(tmpl.versions, byTag[tag])
+		}
+		tmpl.matcher = language.NewMatcher(tmpl.tags)
+		t.templates[name] = tmpl
+	}
+
+	return t, nil
+}
+
+// parseLocalized parses the text file of a template and the HTML file next to it, if there is one.
+func parseLocalized(fsys fs.FS, file string) (*localized, error) {
+	text, err := texttemplate.ParseFS(fsys, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mail template %q: %w", file, err)
+	}
+
+	if text.Lookup("subject") == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNoSubject, file)
+	}
+
+	l := &localized{text: text}
+
+	htmlFile := strings.TrimSuffix(file, path.Ext(file)) + ".html"
+	if _, err = fs.Stat(fsys, htmlFile); err == nil {
+		if l.html, err = htmltemplate.ParseFS(fsys, htmlFile); err != nil {
+			return nil, fmt.Errorf("failed to parse mail template %q: %w", htmlFile, err)
+		}
+	}
+
+	return l, nil
+}
+
+// Render renders a template in the language that matches the given language tag best, such as "nl" for "nl-BE".
+// The returned message has no sender and recipients.
+func (t *Templates) Render(name, lang string, data any) (*Message, error) {
+	tmpl, ok := t.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTemplate, name)
+	}
+
+	l := tmpl.versions[0]
+	if tag, err := language.Parse(lang); err == nil {
+		if _, i, c := tmpl.matcher.Match(tag); c != language.No {
+			l = tmpl.versions[i]
+		}
+	}
+
+	var subject, text bytes.Buffer
+	if err := l.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject of %q: %w", name, err)
+	}
+
+	if err := l.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render %q: %w", name, err)
+	}
+
+	m := &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}
+
+	if l.html != nil {
+		var html bytes.Buffer
+		if err := l.html.Execute(&html, data); err != nil {
+			return nil, fmt.Errorf("failed to render html of %q: %w", name, err)
+		}
+		m.HTML = html.String()
+	}
+
+	return m, nil
+}
@@ -0,0 +1,83 @@
+package mail_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	lingomail "github.com/extreme-business/lingo/pkg/mail"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	fsys := fstest.MapFS{
+		"welcome.en.txt":  {Data: []byte(`{{define "subject"}}Welcome {{.Name}}{{end}}Hello {{.Name}},`)},
+		"welcome.en.html": {Data: []byte(`<p>Hello {{.Name}},</p>`)},
+		"welcome.nl.txt":  {Data: []byte(`{{define "subject"}}Welkom {{.Name}}{{end}}Hallo {{.Name}},`)},
+	}
+
+	templates, err := lingomail.ParseTemplates(fsys, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		language string
+		want     lingomail.Message
+	}{
+		{
+			name:     "should render the default language",
+			language: "en",
+			want:     lingomail.Message{Subject: "Welcome <Jane>", Text: "Hello <Jane>,\n", HTML: "<p>Hello &lt;Jane&gt;,</p>"},
+		},
+		{
+			name:     "should render a variant of a language",
+			language: "nl-BE",
+			want:     lingomail.Message{Subject: "Welkom <Jane>", Text: "Hallo <Jane>,\n"},
+		},
+		{
+			name:     "should fall back to the default language",
+			language: "de",
+			want:     lingomail.Message{Subject: "Welcome <Jane>", Text: "Hello <Jane>,\n", HTML: "<p>Hello &lt;Jane&gt;,</p>"},
+		},
+		{
+			name:     "should fall back to the default language for an invalid tag",
+			language: "",
+			want:     lingomail.Message{Subject: "Welcome <Jane>", Text: "Hello <Jane>,\n", HTML: "<p>Hello &lt;Jane&gt;,</p>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := templates.Render("welcome", tt.language, map[string]string{"Name": "<Jane>"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Subject != tt.want.Subject || got.Text != tt.want.Text || got.HTML != tt.want.HTML {
+				t.Errorf("Render() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("should fail on an unknown template", func(t *testing.T) {
+		if _, err := templates.Render("goodbye", "en", nil); !errors.Is(err, lingomail.ErrUnknownTemplate) {
+			t.Errorf("Render() error = %v, want %v", err, lingomail.ErrUnknownTemplate)
+		}
+	})
+}
+
+func TestParseTemplates(t *testing.T) {
+	t.Run("should require the default language", func(t *testing.T) {
+		fsys := fstest.MapFS{"welcome.nl.txt": {Data: []byte(`{{define "subject"}}Welkom{{end}}Hallo`)}}
+		if _, err := lingomail.ParseTemplates(fsys, "en"); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("should require a subject", func(t *testing.T) {
+		fsys := fstest.MapFS{"welcome.en.txt": {Data: []byte(`Hello`)}}
+		if _, err := lingomail.ParseTemplates(fsys, "en"); !errors.Is(err, lingomail.ErrNoSubject) {
+			t.Errorf("ParseTemplates() error = %v, want %v", err, lingomail.ErrNoSubject)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Package telemetry exports the traces and metrics recorded with the global OpenTelemetry providers,
+// such as those of an instrumented database. Without Setup the providers are no-ops and nothing is recorded.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+)
+
+type Config struct {
+	ServiceName string
+	Endpoint    string // Endpoint is the host and port of the OTLP gRPC collector, such as localhost:4317.
+	Insecure    bool   // Insecure sends to the collector without TLS.
+}
+
+func (c Config) Validate() error {
+	if c.ServiceName == "" {
+		return errors.New("service name is required")
+	}
+
+	if c.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+
+	return nil
+}
+
+// Setup installs global trace and meter providers that export to the collector over OTLP.
+// The returned function flushes the pending data and stops the providers, call it before the process exits.
+func Setup(ctx context.Context, c Config) (shutdown func(context.Context) error, err error) {
+	if err = c.Validate(); err != nil {
+		return nil, err
+	}
+
+	res := resource.NewSchemaless(semconv.ServiceName(c.ServiceName))
+
+	traceOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(c.Endpoint)}
+	metricOpts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(c.Endpoint)}
+	if c.Insecure {
+		traceOpts = append(traceOpts, otlptracegrpc.WithInsecure())
+		metricOpts = append(metricOpts, otlpmetricgrpc.WithInsecure())
+	}
+
+	traceExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	metricExporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("failed to create metric exporter: %w", err),
+			traceExporter.Shutdown(ctx),
+		)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)), sdkmetric.WithResource(res))
+
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), mp.Shutdown(ctx))
+	}, nil
+}
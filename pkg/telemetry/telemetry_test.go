@@ -0,0 +1,59 @@
+package telemetry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extreme-business/lingo/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  telemetry.Config
+		wantErr bool
+	}{
+		{name: "valid", config: telemetry.Config{ServiceName: "account", Endpoint: "localhost:4317"}},
+		{name: "without service name", config: telemetry.Config{Endpoint: "localhost:4317"}, wantErr: true},
+		{name: "without endpoint", config: telemetry.Config{ServiceName: "account"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("should install the global providers until shutdown", func(t *testing.T) {
+		t.Cleanup(func() {
+			otel.SetTracerProvider(tracenoop.NewTracerProvider())
+			otel.SetMeterProvider(noop.NewMeterProvider())
+		})
+
+		// the exporters connect lazily, so no collector is needed.
+		shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{
+			ServiceName: "account",
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := otel.GetTracerProvider().(tracenoop.TracerProvider); ok {
+			t.Error("expected the tracer provider to be installed")
+		}
+
+		// without a collector the last export fails, do not wait for it.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = shutdown(ctx)
+	})
+}
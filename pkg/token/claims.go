@@ -0,0 +1,87 @@
+package token
+
+import (
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	claimSessionID      = "sid"
+	claimTokenID        = "jti"
+	claimFamily         = "fam"
+	claimOrganizationID = "org"
+	claimRole           = "role"
+	claimActor          = "act"
+	claimExpiration     = "exp"
+)
+
+// ClaimOption sets an optional claim on a token.
+type ClaimOption func(jwt.MapClaims)
+
+// WithSessionID binds the token to a session.
+func WithSessionID(id string) ClaimOption {
+	return func(c jwt.MapClaims) { c[claimSessionID] = id }
+}
+
+// WithTokenID sets the unique id of the token.
+func WithTokenID(id string) ClaimOption {
+	return func(c jwt.MapClaims) { c[claimTokenID] = id }
+}
+
+// WithFamily sets the refresh token family the token belongs to.
+func WithFamily(family string) ClaimOption {
+	return func(c jwt.MapClaims) { c[claimFamily] = family }
+}
+
+// WithOrganizationID sets the organization of the subject.
+func WithOrganizationID(id string) ClaimOption {
+	return func(c jwt.MapClaims) { c[claimOrganizationID] = id }
+}
+
+// WithRole sets the role of the subject.
+func WithRole(role string) ClaimOption {
+	return func(c jwt.MapClaims) { c[claimRole] = role }
+}
+
+// WithActor sets the party that acts on behalf of the subject, as described in RFC 8693.
+func WithActor(sub string) ClaimOption {
+	return func(c jwt.MapClaims) { c[claimActor] = map[string]any{"sub": sub} }
+}
+
+// WithExpirationTime overrides the expiration time of the token.
+// It can only shorten the lifetime; a later time than the default expiry is ignored.
+func WithExpirationTime(t time.Time) ClaimOption {
+	return func(c jwt.MapClaims) {
+		if exp, ok := c[claimExpiration].(int64); ok && t.Unix() < exp {
+			c[claimExpiration] = t.Unix()
+		}
+	}
+}
+
+// actor returns the subject of the act claim, or an empty string when it is not set.
+func actor(claims jwt.MapClaims) (string, bool) {
+	v, ok := claims[claimActor]
+	if !ok {
+		return "", true
+	}
+
+	act, ok := v.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	sub, ok := act["sub"].(string)
+	return sub, ok
+}
+
+// optionalString returns the claim as a string, or an empty string when it is not set.
+func optionalString(claims jwt.MapClaims, key string) (string, bool) {
+	v, ok := claims[key]
+	if !ok {
+		return "", true
+	}
+
+	s, ok := v.(string)
+	return s, ok
+}
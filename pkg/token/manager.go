@@ -28,8 +28,8 @@ func NewManager(
 	}
 }
 
-func (m *Manager) Create(id string) (string, error) {
-	token, err := m.Tokenizer.Create(id)
+func (m *Manager) Create(id string, opts ...ClaimOption) (string, error) {
+	token, err := m.Tokenizer.Create(id, opts...)
 	if err != nil {
 		return "", err
 	}
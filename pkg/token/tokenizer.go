@@ -13,15 +13,18 @@ type Tokenizer struct {
 	expiry    time.Duration
 }
 
-// Dispatch sends a token to the user.
-func (r *Tokenizer) Create(sub string) (string, error) {
-	token := jwt.NewWithClaims(
-		jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"sub": sub,
-			"exp": r.clock().Add(r.expiry).Unix(),
-		},
-	)
+// Create creates a signed token for the subject.
+func (r *Tokenizer) Create(sub string, opts ...ClaimOption) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":           sub,
+		claimExpiration: r.clock().Add(r.expiry).Unix(),
+	}
+
+	for _, opt := range opts {
+		opt(claims)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	tokenString, err := token.SignedString(r.secretKey)
 	if err != nil {
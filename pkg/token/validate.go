@@ -22,6 +22,12 @@ func NewValidator(secret []byte) *Validator {
 type Claims struct {
 	ExpirationTime time.Time
 	Sub            string
+	SessionID      string
+	TokenID        string
+	Family         string
+	OrganizationID string
+	Role           string
+	Actor          string // Actor is the subject that acts on behalf of Sub, empty unless impersonating.
 }
 
 // Validate validates the token and returns the email hash.
@@ -62,8 +68,28 @@ func (v *Validator) Validate(tokenStr string) (*Claims, error) {
 		return nil, fmt.Errorf("expiration time is not valid: %w", ErrInvalidTokenClaims)
 	}
 
-	return &Claims{
+	c := &Claims{
 		ExpirationTime: expirationTime,
 		Sub:            sub,
-	}, nil
+	}
+
+	for key, dst := range map[string]*string{
+		claimSessionID:      &c.SessionID,
+		claimTokenID:        &c.TokenID,
+		claimFamily:         &c.Family,
+		claimOrganizationID: &c.OrganizationID,
+		claimRole:           &c.Role,
+	} {
+		v, ok := optionalString(claims, key)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a string: %w", key, ErrInvalidTokenClaims)
+		}
+		*dst = v
+	}
+
+	if c.Actor, ok = actor(claims); !ok {
+		return nil, fmt.Errorf("%s is not valid: %w", claimActor, ErrInvalidTokenClaims)
+	}
+
+	return c, nil
 }
@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/extreme-business/lingo/pkg/token"
 )
@@ -47,3 +48,64 @@ func TestValidator_Validate(t *testing.T) {
 		}
 	})
 }
+
+func TestValidator_Validate_claims(t *testing.T) {
+	t.Run("should return optional claims", func(t *testing.T) {
+		m := token.NewManager(time.Now, []byte("secret"), time.Minute)
+
+		s, err := m.Create("user",
+			token.WithSessionID("session"),
+			token.WithTokenID("jti"),
+			token.WithFamily("family"),
+			token.WithOrganizationID("org"),
+			token.WithRole("admin"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := m.Validate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := token.Claims{
+			ExpirationTime: c.ExpirationTime,
+			Sub:            "user",
+			SessionID:      "session",
+			TokenID:        "jti",
+			Family:         "family",
+			OrganizationID: "org",
+			Role:           "admin",
+		}
+
+		if *c != want {
+			t.Errorf("Validate() = %v, want %v", *c, want)
+		}
+	})
+
+	t.Run("should return the actor", func(t *testing.T) {
+		m := token.NewManager(time.Now, []byte("secret"), time.Hour)
+
+		s, err := m.Create("user",
+			token.WithActor("admin"),
+			token.WithExpirationTime(time.Now().Add(time.Minute)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := m.Validate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Actor != "admin" {
+			t.Errorf("Validate() actor = %q, want %q", c.Actor, "admin")
+		}
+
+		if c.ExpirationTime.After(time.Now().Add(time.Minute)) {
+			t.Errorf("Validate() expiration time = %v, want at most a minute from now", c.ExpirationTime)
+		}
+	})
+}
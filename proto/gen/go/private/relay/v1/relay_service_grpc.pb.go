@@ -28,7 +28,10 @@ const (
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RelayServiceClient interface {
+	// CreateRegisterToken sends a registration token to the email address.
+	// The response does not tell whether the address already has an account.
 	CreateRegisterToken(ctx context.Context, in *CreateRegisterTokenRequest, opts ...grpc.CallOption) (*CreateRegisterTokenResponse, error)
+	// CreateAccount creates the account for the email address of a registration token.
 	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
 	CreateMessage(ctx context.Context, in *CreateMessageRequest, opts ...grpc.CallOption) (*CreateMessageResponse, error)
 }
@@ -72,7 +75,10 @@ func (c *relayServiceClient) CreateMessage(ctx context.Context, in *CreateMessag
 // All implementations must embed UnimplementedRelayServiceServer
 // for forward compatibility
 type RelayServiceServer interface {
+	// CreateRegisterToken sends a registration token to the email address.
+	// The response does not tell whether the address already has an account.
 	CreateRegisterToken(context.Context, *CreateRegisterTokenRequest) (*CreateRegisterTokenResponse, error)
+	// CreateAccount creates the account for the email address of a registration token.
 	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
 	CreateMessage(context.Context, *CreateMessageRequest) (*CreateMessageResponse, error)
 	mustEmbedUnimplementedRelayServiceServer()